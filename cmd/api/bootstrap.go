@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/config"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/secrets"
+)
+
+// bootstrapAdmin creates the ADMIN account signing in with
+// BOOTSTRAP_ADMIN_EMAIL and BOOTSTRAP_ADMIN_PASSWORD, which may be a secret
+// reference, unless it exists already. It runs as the bootstrap subcommand,
+// and at every startup while BOOTSTRAP_ADMIN_EMAIL is set.
+func bootstrapAdmin(ctx context.Context, service *application.BootstrapService, secretStore *secrets.Cache, bootstrap config.Bootstrap) error {
+	email := bootstrap.Email
+	if email == "" {
+		return errors.New("BOOTSTRAP_ADMIN_EMAIL is required")
+	}
+	password, err := secretStore.Get(ctx, bootstrap.Password)
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return errors.New("BOOTSTRAP_ADMIN_PASSWORD is required")
+	}
+
+	created, err := service.BootstrapAdmin(ctx, email, password)
+	if err != nil {
+		return err
+	}
+	if created {
+		slog.InfoContext(ctx, "bootstrap admin account created")
+	} else {
+		slog.InfoContext(ctx, "bootstrap admin account already exists")
+	}
+	return nil
+}
@@ -1,9 +1,1525 @@
 package main
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/config"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/accountcache"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/breach"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/captcha"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/denylist"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/disposable"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/eventbus"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/geoip"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/health"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/mail"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/metrics"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/oauth"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/passkey"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/pepper"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/ratelimit"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/replay"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/secrets"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/siem"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/sms"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/strength"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/token"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/webhook"
+	"github.com/TheJisus28/ranco-auth-service/internal/logging"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	grpctransport "github.com/TheJisus28/ranco-auth-service/internal/transport/grpc"
+	httptransport "github.com/TheJisus28/ranco-auth-service/internal/transport/http"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
 )
 
+// keyVerificationSlack keeps retired keys published a little past the access
+// token lifetime to absorb clock skew between services.
+const keyVerificationSlack = 10 * time.Minute
+
 func main() {
-	log.Println("Ranco Auth Service starting...")
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("load configuration", err)
+	}
+	logger, err := buildLogger(cfg.Logging)
+	if err != nil {
+		log.Fatalf("configure logging: %v", err)
+	}
+	slog.SetDefault(logger)
+	slog.Info("Ranco Auth Service starting")
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg.Database, os.Args[2:]); err != nil {
+			fatal("migrate database", err)
+		}
+		return
+	}
+	if err := migrateOnStartup(cfg.Database); err != nil {
+		fatal("migrate database", err)
+	}
+
+	ctx := context.Background()
+	// stopping is cancelled by SIGTERM or SIGINT, which start the shutdown.
+	stopping, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	tracerProvider, err := buildTracerProvider(ctx, cfg.Tracing)
+	if err != nil {
+		fatal("configure tracing", err)
+	}
+	if tracerProvider != nil {
+		defer tracerProvider.Shutdown(context.Background())
+		otel.SetTracerProvider(tracerProvider)
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	secretStore, err := buildSecretStore(ctx, cfg.Secrets)
+	if err != nil {
+		fatal("configure secrets", err)
+	}
+
+	prometheus := metrics.NewPrometheus()
+	var db *storage
+	if cfg.Database.InMemory() {
+		slog.Warn("DATABASE_URL is memory: data is kept in the process and lost on restart")
+		db = openMemoryStorage()
+	} else if db, err = openPostgresStorage(ctx, cfg.Database, prometheus); err != nil {
+		fatal("open database", err)
+	}
+	defer db.close()
+
+	txManager := db.txManager
+	accounts := db.accounts
+	roles := db.roles
+	organizations := db.organizations
+	memberships := db.memberships
+	invitations := db.invitations
+	brandings := db.brandings
+	policies := db.policies
+	authMethods := db.authMethods
+	verificationCodes := db.verificationCodes
+	refreshTokens := db.refreshTokens
+	passwordCredentials := db.passwordCredentials
+	var auditStream ports.AuditStream
+	siemStream, err := buildSIEMStream(cfg.SIEM)
+	if err != nil {
+		fatal("configure siem export", err)
+	}
+	// The stream runs until the servers have stopped, so it also delivers the
+	// audit events of the requests drained at shutdown.
+	streamCtx, stopStream := context.WithCancel(ctx)
+	streamDone := make(chan struct{})
+	if siemStream != nil {
+		go func() {
+			defer close(streamDone)
+			siemStream.Run(streamCtx)
+		}()
+		auditStream = siemStream
+	} else {
+		close(streamDone)
+	}
+	auditLog := application.NewAuditLog(txManager, db.auditEvents, auditStream)
+	webhookCipher, err := buildWebhookCipher(cfg)
+	if err != nil {
+		fatal("configure webhooks", err)
+	}
+	webhookService := application.NewWebhookService(
+		txManager,
+		db.webhookEndpoints,
+		db.webhookDeliveries,
+		webhookCipher,
+		webhook.NewHTTPSender(),
+	)
+	relayed := []ports.EventBus{webhookService}
+	publisher, err := buildEventBroker(cfg.EventBroker)
+	if err != nil {
+		fatal("configure event broker", err)
+	}
+	if publisher != nil {
+		defer publisher.Close()
+		relayed = append(relayed, eventbus.NewBrokerBus(publisher))
+	}
+	notifier := mail.NewNotifier(buildMailer(cfg.SMTP), eventbus.NewLogBus(), brandings, mail.NotifierConfig{
+		MagicLinkURL:  cfg.Mail.MagicLinkURL,
+		InvitationURL: cfg.Mail.InvitationURL,
+		Branding: models.Branding{
+			SenderName:      cfg.Mail.Branding.SenderName,
+			SenderAddress:   cfg.SMTP.From,
+			LogoURL:         cfg.Mail.Branding.LogoURL,
+			PrimaryColor:    cfg.Mail.Branding.PrimaryColor,
+			BackgroundColor: cfg.Mail.Branding.BackgroundColor,
+			FooterText:      cfg.Mail.Branding.FooterText,
+		},
+	})
+	eventBus := application.NewOutbox(txManager, db.outbox, notifier, eventbus.NewMultiBus(relayed...))
+
+	accessTTL := cfg.Tokens.AccessTTL
+	keyStore, err := buildKeyStore(ctx, db.signingKeys, txManager, secretStore, cfg.Signing, accessTTL)
+	if err != nil {
+		fatal("load signing keys", err)
+	}
+	// Platform keys rotate on demand, and organizations get signing keys of
+	// their own, only with rotating keys.
+	var (
+		organizationKeys ports.OrganizationKeyManager
+		platformKeys     ports.PlatformKeyManager
+	)
+	if manager, ok := keyStore.(*token.KeyManager); ok {
+		organizationKeys = manager
+		platformKeys = manager
+	}
+
+	tokenCodec, err := token.NewCodec(cfg.Tokens.Format, keyStore, db.accessTokens)
+	if err != nil {
+		fatal("ACCESS_TOKEN_FORMAT", err)
+	}
+
+	issuer := cfg.Tokens.Issuer
+	tokenService := token.NewJWTService(keyStore, token.JWTConfig{
+		Issuer:   issuer,
+		Audience: cfg.Tokens.Audience,
+		TTL:      accessTTL,
+		Codec:    tokenCodec,
+	})
+	issuedTokens := metrics.NewTokenService(tokenService, prometheus)
+
+	redisClient, err := buildRedisClient(ctx, cfg.Redis)
+	if err != nil {
+		fatal("connect redis", err)
+	}
+	healthChecks := append(db.healthChecks, health.SigningKeys(keyStore))
+	var accessTokenDenylist ports.AccessTokenDenylist = denylist.NewMemoryDenylist(accessTTL)
+	var replayCache ports.ReplayCache = replay.NewMemoryCache()
+	var rateLimiter ports.RateLimiter = ratelimit.NewMemoryLimiter()
+	// lookups reads accounts from the primary, through the cache when there
+	// is one, for the services that only look accounts up.
+	lookups := db.accounts
+	if redisClient != nil {
+		defer redisClient.Close()
+		accessTokenDenylist = denylist.NewRedisDenylist(redisClient, accessTTL)
+		replayCache = replay.NewRedisCache(redisClient)
+		rateLimiter = ratelimit.NewRedisLimiter(redisClient)
+		healthChecks = append(healthChecks, health.Redis(redisClient))
+
+		if cfg.Redis.AccountCacheTTL > 0 {
+			accountCache := accountcache.NewRedisCache(redisClient, txManager, cfg.Redis.AccountCacheTTL, prometheus)
+			accounts = accountCache.Writes(accounts)
+			lookups = accountCache.Reads(db.accounts)
+			db.replica.accounts = accountCache.Reads(db.replica.accounts)
+		}
+	}
+	limits := httptransport.NewRateLimiter(rateLimiter, buildRateLimits(cfg.RateLimits))
+	apiKeyService := application.NewAPIKeyService(db.apiKeys, accounts, roles, eventBus)
+	tokenValidator := application.NewTokenValidator(tokenService, accessTokenDenylist, apiKeyService)
+	dpopValidator := application.NewDPoPValidator(token.NewDPoPParser(), replayCache)
+
+	passwordHasher, err := buildPasswordHasher(ctx, secretStore, cfg.Argon2, cfg.Pepper)
+	if err != nil {
+		fatal("configure password hashing", err)
+	}
+	passwordPolicy, err := buildPasswordPolicy(cfg.Passwords)
+	if err != nil {
+		fatal("configure password policy", err)
+	}
+	bootstrapping := len(os.Args) > 1 && os.Args[1] == "bootstrap"
+	if bootstrapping || cfg.Bootstrap.Email != "" {
+		bootstrapService := application.NewBootstrapService(txManager, accounts, authMethods, passwordCredentials, passwordHasher, passwordPolicy)
+		if err := bootstrapAdmin(ctx, bootstrapService, secretStore, cfg.Bootstrap); err != nil {
+			fatal("bootstrap admin account", err)
+		}
+		if bootstrapping {
+			return
+		}
+	}
+	mfaFactors := db.mfaFactors
+	mfaChallenges := db.mfaChallenges
+	passkeys := db.passkeys
+	recoveryCodes := db.recoveryCodes
+
+	mfaPolicy := buildMFAPolicy(cfg.MFA)
+	passwordExpiry := buildPasswordExpiry(cfg.PasswordRotation)
+	sessionLimit := buildSessionLimit(cfg.SessionLimit)
+	sessionLifetime := buildSessionLifetime(cfg.Tokens)
+
+	lockout := application.NewLockout(authMethods, mfaFactors, buildLockoutPolicy(cfg.Lockout), eventBus)
+	captchaGuard, err := buildCaptchaGuard(cfg.Captcha)
+	if err != nil {
+		fatal("configure captcha", err)
+	}
+	disposableEmails, err := buildDisposableEmailGuard(ctx, cfg.DisposableEmails)
+	if err != nil {
+		fatal("configure disposable email blocking", err)
+	}
+	breachedPasswords, err := buildBreachedPasswordGuard(cfg.BreachedPasswords)
+	if err != nil {
+		fatal("configure breached password checks", err)
+	}
+
+	trustedDevices := db.trustedDevices
+	featureFlags := application.NewFeatureFlags(memberships, buildFeatureFlags(cfg.Features)...)
+	sessions := application.NewSessionIssuer(
+		refreshTokens,
+		issuedTokens,
+		roles,
+		memberships,
+		policies,
+		mfaFactors,
+		mfaChallenges,
+		passkeys,
+		recoveryCodes,
+		authMethods,
+		passwordCredentials,
+		mfaPolicy,
+		featureFlags,
+		passwordExpiry,
+		db.legalDocuments,
+		trustedDevices,
+		cfg.Tokens.TrustedDeviceTTL,
+		sessionLimit,
+		sessionLifetime,
+		prometheus,
+		auditLog,
+		eventBus,
+	)
+
+	authService := application.NewAuthService(
+		txManager,
+		accounts,
+		authMethods,
+		verificationCodes,
+		refreshTokens,
+		passwordCredentials,
+		db.accountDeletions,
+		cfg.Accounts.DeletionGracePeriod,
+		sessions,
+		passwordHasher,
+		passwordPolicy,
+		application.NewPasswordHistory(db.passwordHistory, passwordCredentials, passwordHasher, cfg.PasswordRotation.HistorySize),
+		accessTokenDenylist,
+		lockout,
+		captchaGuard,
+		disposableEmails,
+		breachedPasswords,
+		prometheus,
+		auditLog,
+		eventBus,
+	)
+
+	providers, err := buildOAuthProviders(ctx, secretStore, cfg.Providers)
+	if err != nil {
+		fatal("configure oauth providers", err)
+	}
+
+	oauthService := application.NewOAuthService(
+		txManager,
+		accounts,
+		authMethods,
+		db.providers,
+		sessions,
+		featureFlags,
+		eventBus,
+		providers...,
+	)
+	oauthService.SetDisabled(disabledProviders(cfg.Providers)...)
+	if err := oauthService.SyncProviderCatalog(ctx); err != nil {
+		fatal("sync provider catalog", err)
+	}
+
+	authMethodService := application.NewAuthMethodService(txManager, accounts, authMethods, eventBus)
+
+	mfaCipher, err := buildMFACipher(cfg.MFA)
+	if err != nil {
+		fatal("configure mfa", err)
+	}
+	mfaService := application.NewMFAService(
+		txManager,
+		accounts,
+		authMethods,
+		mfaFactors,
+		mfaChallenges,
+		db.mfaCodes,
+		recoveryCodes,
+		trustedDevices,
+		sessions,
+		lockout,
+		mfaCipher,
+		buildSMSSender(cfg.Twilio),
+		prometheus,
+		auditLog,
+		eventBus,
+		cfg.MFA.Issuer,
+	)
+
+	webAuthn, err := passkey.NewWebAuthnService(passkey.Config{
+		RPID:          cfg.WebAuthn.ID,
+		RPDisplayName: cfg.WebAuthn.Name,
+		RPOrigins:     cfg.WebAuthn.Origins,
+	})
+	if err != nil {
+		fatal("configure passkeys", err)
+	}
+	passkeyService := application.NewPasskeyService(
+		txManager,
+		accounts,
+		authMethods,
+		passkeys,
+		db.passkeyCeremonies,
+		mfaChallenges,
+		recoveryCodes,
+		sessions,
+		featureFlags,
+		webAuthn,
+		prometheus,
+		auditLog,
+		eventBus,
+	)
+
+	stepUpService := application.NewStepUpService(
+		accounts,
+		authMethods,
+		passwordCredentials,
+		passwordHasher,
+		lockout,
+		mfaService,
+		issuedTokens,
+		roles,
+	)
+
+	locator, err := buildGeoLocator(cfg.GeoIP)
+	if err != nil {
+		fatal("configure geoip", err)
+	}
+	sessionService := application.NewSessionService(txManager, accounts, refreshTokens, accessTokenDenylist, locator, auditLog, eventBus)
+	dataExportSigner, err := buildDataExportSigner(cfg)
+	if err != nil {
+		fatal("configure data exports", err)
+	}
+	dataExportService := application.NewDataExportService(
+		txManager,
+		accounts,
+		authMethods,
+		refreshTokens,
+		db.consents,
+		db.dataExports,
+		dataExportSigner,
+		cfg.DataExports.DownloadURL,
+		auditLog,
+		eventBus,
+	)
+	consentService := application.NewConsentService(txManager, db.legalDocuments, db.consents, auditLog)
+
+	oauthClients := db.oauthClients
+	clientService := application.NewClientService(oauthClients, issuedTokens)
+	if err := clientService.Sync(ctx, buildClientRegistrations(cfg.AuthorizationServer)); err != nil {
+		fatal("sync oauth clients", err)
+	}
+	introspectionService := application.NewIntrospectionService(tokenValidator, db.replica.accounts, db.replica.refreshTokens)
+	revocationService := application.NewRevocationService(tokenService, accessTokenDenylist, refreshTokens, eventBus)
+	tokenExchangeService := application.NewTokenExchangeService(tokenValidator, accounts, roles, issuedTokens)
+	authorizationService := application.NewAuthorizationService(
+		txManager,
+		accounts,
+		authMethods,
+		oauthClients,
+		db.authorizationCodes,
+		db.browserSessions,
+		db.deviceCodes,
+		refreshTokens,
+		sessions,
+		issuedTokens,
+	)
+
+	authenticator := httptransport.NewAuthenticator(tokenValidator, dpopValidator)
+	accountService := application.NewAccountService(lookups, authMethods)
+	// Only the admin account search and export tolerate replication lag.
+	accountSearch := application.NewAccountService(db.replica.accounts, db.replica.authMethods)
+	provisioningService := application.NewProvisioningService(txManager, accounts, authMethods, refreshTokens, accessTokenDenylist, eventBus)
+	banService := application.NewBanService(txManager, accounts, db.accountBans, refreshTokens, accessTokenDenylist, auditLog, eventBus)
+	impersonationService := application.NewImpersonationService(txManager, accounts, roles, db.impersonations, issuedTokens, accessTokenDenylist, auditLog, eventBus)
+	roleService := application.NewRoleService(txManager, accounts, roles, db.roleChanges, accessTokenDenylist, auditLog, eventBus)
+	organizationService := application.NewOrganizationService(txManager, accounts, authMethods, roles, organizations, memberships, invitations, brandings, policies, organizationKeys, eventBus)
+	dataRetention := application.NewDataRetention(refreshTokens, trustedDevices, db.auditEvents, verificationCodes, buildRetentionPolicy(cfg.Retention))
+	reloader := config.NewReloader(cfg, func(next *config.Config) error {
+		passwordPolicy, err := buildPasswordPolicy(next.Passwords)
+		if err != nil {
+			return err
+		}
+		limits.SetLimits(buildRateLimits(next.RateLimits))
+		authService.SetPasswordPolicy(passwordPolicy)
+		oauthService.SetDisabled(disabledProviders(next.Providers)...)
+		featureFlags.SetFlags(buildFeatureFlags(next.Features)...)
+		tokenService.SetTTL(next.Tokens.AccessTTL)
+		sessions.SetDurations(buildSessionLifetime(next.Tokens), next.Tokens.TrustedDeviceTTL)
+		dataRetention.SetPolicy(buildRetentionPolicy(next.Retention))
+		return nil
+	})
+
+	var jobs sync.WaitGroup
+	jobs.Go(func() { secretStore.Run(stopping) })
+	jobs.Go(func() { reloadOnHangup(stopping, reloader) })
+	scheduler := application.NewScheduler(db.leader, prometheus)
+	scheduler.OnFailure(logJobFailure)
+	scheduler.Register(application.Job{Name: "lift_expired_bans", Interval: cfg.Jobs.BanExpiryInterval, Run: liftExpiredBans(banService)})
+	scheduler.Register(application.Job{Name: "purge_outbox", Interval: time.Hour, Run: purgeOutbox(eventBus)})
+	if cfg.Accounts.PendingTTL > 0 {
+		scheduler.Register(application.Job{Name: "expire_pending_accounts", Interval: time.Hour, Run: expirePendingAccounts(authService, cfg.Accounts.PendingTTL)})
+	}
+	scheduler.Register(application.Job{Name: "purge_deleted_accounts", Interval: time.Hour, Run: purgeDeletedAccounts(authService)})
+	scheduler.Register(application.Job{Name: "purge_verification_codes", Interval: time.Hour, Run: purgeVerificationCodes(dataRetention)})
+	scheduler.Register(application.Job{Name: "generate_data_exports", Interval: time.Minute, Run: generateDataExports(dataExportService)})
+	scheduler.Register(application.Job{Name: "purge_data_exports", Interval: time.Hour, Run: purgeDataExports(dataExportService)})
+	scheduler.Register(application.Job{Name: "purge_refresh_tokens", Interval: cfg.Jobs.RefreshTokenPurgeInterval, Run: purgeRefreshTokens(dataRetention)})
+	scheduler.Register(application.Job{Name: "purge_login_origins", Interval: time.Hour, Run: purgeLoginOrigins(dataRetention)})
+	scheduler.Register(application.Job{Name: "purge_audit_events", Interval: time.Hour, Run: purgeAuditEvents(dataRetention)})
+	jobs.Go(func() { scheduler.Run(stopping) })
+	jobs.Go(func() { deliverWebhooks(stopping, webhookService, cfg.Webhooks.DeliveryInterval) })
+	jobs.Go(func() { relayOutbox(stopping, eventBus, cfg.Jobs.OutboxRelayInterval) })
+	jobs.Go(func() { countActiveSessions(stopping, refreshTokens, prometheus, cfg.Jobs.ActiveSessionsInterval) })
+	router := httptransport.NewRouter(
+		httptransport.NewAuthHandler(authService, authenticator, limits),
+		httptransport.NewOAuthHandler(oauthService, authenticator),
+		httptransport.NewAuthMethodHandler(authMethodService, authenticator),
+		httptransport.NewMFAHandler(mfaService, authenticator, limits),
+		httptransport.NewPasskeyHandler(passkeyService, authenticator),
+		httptransport.NewAPIKeyHandler(apiKeyService, authenticator),
+		httptransport.NewStepUpHandler(stepUpService, authenticator, limits),
+		httptransport.NewSessionHandler(sessionService, authenticator),
+		httptransport.NewAuthorizationServerHandler(clientService, introspectionService, revocationService),
+		httptransport.NewOIDCHandler(authorizationService, clientService, tokenExchangeService, authService, dpopValidator, authenticator, cfg.AuthorizationServer.LoginURL, cfg.AuthorizationServer.DeviceVerificationURL),
+		httptransport.NewDiscoveryHandler(issuer, tokenService, dpopValidator),
+		httptransport.NewJWKSHandler(tokenService),
+		httptransport.NewAdminHandler(accountService, accountSearch, banService, impersonationService, roleService, organizationService, auditLog, webhookService, sessionService, authService, consentService, platformKeys, reloader, authenticator),
+		httptransport.NewSCIMHandler(provisioningService, authenticator, issuer),
+		httptransport.NewOrganizationHandler(organizationService, authenticator),
+		httptransport.NewSecurityActivityHandler(auditLog, authenticator),
+		httptransport.NewDataExportHandler(dataExportService, authenticator),
+		httptransport.NewConsentHandler(consentService, authenticator),
+	)
+
+	grpcServer := grpctransport.NewServer(
+		grpctransport.NewAuthServer(tokenValidator, accountService, application.NewPermissionService(tokenValidator, lookups, roles)),
+		grpctransport.NewClientAuthenticator(tokenValidator),
+	)
+	grpcAddr := cfg.Server.GRPCAddr
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		fatal("grpc listen", err)
+	}
+	go func() {
+		slog.Info("grpc listening", "addr", grpcAddr)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			fatal("grpc server", err)
+		}
+	}()
+
+	healthService := application.NewHealthService(healthChecks...)
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", prometheus.Handler())
+	httptransport.NewHealthHandler(healthService).RegisterRoutes(metricsMux)
+	metricsServer := &http.Server{Addr: cfg.Server.MetricsAddr, Handler: metricsMux}
+	go func() {
+		slog.Info("metrics listening", "addr", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("metrics server", err)
+		}
+	}()
+
+	httpServer := &http.Server{Addr: cfg.Server.HTTPAddr, Handler: router}
+	go func() {
+		slog.Info("http listening", "addr", httpServer.Addr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("http server", err)
+		}
+	}()
+
+	shutdownTimeout := cfg.Server.ShutdownTimeout
+
+	<-stopping.Done()
+	// A second signal exits at once.
+	stop()
+	slog.Info("shutting down", "timeout", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
+
+	// Readiness fails first so the instance leaves rotation, then the servers
+	// stop accepting connections and wait for in-flight requests.
+	healthService.Drain()
+	var servers sync.WaitGroup
+	servers.Go(func() {
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("drain http server", "error", err)
+		}
+	})
+	servers.Go(func() { stopGRPC(shutdownCtx, grpcServer) })
+	servers.Wait()
+
+	// Jobs finish the run they are in, then what the drained requests queued
+	// is relayed and delivered once more before the stream is flushed.
+	wait(shutdownCtx, &jobs)
+	if _, err := eventBus.RelayDue(shutdownCtx, time.Now().UTC()); err != nil {
+		slog.Error("relay outbox", "error", err)
+	}
+	if _, err := webhookService.DeliverDue(shutdownCtx, time.Now().UTC()); err != nil {
+		slog.Error("deliver webhooks", "error", err)
+	}
+	stopStream()
+	<-streamDone
+
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("stop metrics server", "error", err)
+	}
+	slog.Info("stopped")
+}
+
+// stopGRPC waits for in-flight calls to finish, and cancels those still
+// running once ctx is done.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// wait waits for group until ctx is done.
+func wait(ctx context.Context, group *sync.WaitGroup) {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("background jobs still running at shutdown")
+	}
+}
+
+// fatal logs err and exits, for failures that keep the service from running.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// buildLogger logs records of LOG_LEVEL and above to stderr, as JSON or, with
+// LOG_FORMAT=text, as key=value pairs.
+func buildLogger(configured config.Logging) (*slog.Logger, error) {
+	level, err := logging.ParseLevel(configured.Level)
+	if err != nil {
+		return nil, err
+	}
+	return logging.New(os.Stderr, configured.Format, level)
+}
+
+// logJobFailure logs the failed runs of the scheduled jobs.
+func logJobFailure(ctx context.Context, job string, err error, failures int) {
+	slog.ErrorContext(ctx, "run scheduled job", "job", job, "failures", failures, "error", err)
+}
+
+// liftExpiredBans is the job lifting the bans that have expired.
+func liftExpiredBans(bans *application.BanService) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		lifted, err := bans.LiftExpired(ctx, now)
+		if lifted > 0 {
+			slog.InfoContext(ctx, "lifted expired bans", "count", lifted)
+		}
+		return err
+	}
+}
+
+// purgeOutbox is the job purging the events published more than
+// OutboxRetention ago.
+func purgeOutbox(outbox *application.Outbox) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		_, err := outbox.PurgePublished(ctx, now.Add(-domain.OutboxRetention))
+		return err
+	}
+}
+
+// purgeRefreshTokens is the job purging the refresh tokens that expired or
+// were revoked more than REFRESH_TOKEN_RETENTION ago.
+func purgeRefreshTokens(retention *application.DataRetention) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		purged, err := retention.PurgeRefreshTokens(ctx, now)
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged refresh tokens", "count", purged)
+		}
+		return err
+	}
+}
+
+// purgeLoginOrigins is the job clearing the IP addresses and user agents
+// recorded more than LOGIN_ORIGIN_RETENTION ago.
+func purgeLoginOrigins(retention *application.DataRetention) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		cleared, err := retention.PurgeLoginOrigins(ctx, now)
+		if cleared > 0 {
+			slog.InfoContext(ctx, "cleared login origins", "count", cleared)
+		}
+		return err
+	}
+}
+
+// purgeAuditEvents is the job deleting the audit events recorded more than
+// AUDIT_EVENT_RETENTION ago.
+func purgeAuditEvents(retention *application.DataRetention) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		purged, err := retention.PurgeAuditEvents(ctx, now)
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged audit events", "count", purged)
+		}
+		return err
+	}
+}
+
+// expirePendingAccounts is the job deleting the accounts left PENDING more
+// than ttl after they registered.
+func expirePendingAccounts(auth *application.AuthService, ttl time.Duration) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		expired, err := auth.ExpirePendingAccounts(ctx, now.Add(-ttl))
+		if expired > 0 {
+			slog.InfoContext(ctx, "expired pending accounts", "count", expired)
+		}
+		return err
+	}
+}
+
+// purgeDeletedAccounts is the job deleting for good the accounts whose grace
+// period after their holder deleted them has ended.
+func purgeDeletedAccounts(auth *application.AuthService) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		purged, err := auth.PurgeDeletedAccounts(ctx, now)
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged deleted accounts", "count", purged)
+		}
+		return err
+	}
+}
+
+// purgeVerificationCodes is the job purging the verification codes that
+// expired or were consumed more than VERIFICATION_CODE_RETENTION ago.
+func purgeVerificationCodes(retention *application.DataRetention) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		_, err := retention.PurgeVerificationCodes(ctx, now)
+		return err
+	}
+}
+
+// generateDataExports is the job generating the archives of pending data
+// exports and emailing their download links.
+func generateDataExports(exports *application.DataExportService) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		generated, err := exports.GenerateDue(ctx, now)
+		if generated > 0 {
+			slog.InfoContext(ctx, "generated data exports", "count", generated)
+		}
+		return err
+	}
+}
+
+// purgeDataExports is the job deleting the data exports whose download link
+// has expired.
+func purgeDataExports(exports *application.DataExportService) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		_, err := exports.PurgeExpired(ctx, now)
+		return err
+	}
+}
+
+// deliverWebhooks attempts the webhook deliveries that are due every
+// interval, until ctx is done.
+func deliverWebhooks(ctx context.Context, webhooks *application.WebhookService, interval time.Duration) {
+	run := context.WithoutCancel(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := webhooks.DeliverDue(run, now.UTC()); err != nil {
+				slog.ErrorContext(ctx, "deliver webhooks", "error", err)
+			}
+		}
+	}
+}
+
+// relayOutbox publishes the stored events that are due every interval,
+// until ctx is done.
+func relayOutbox(ctx context.Context, outbox *application.Outbox, interval time.Duration) {
+	run := context.WithoutCancel(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := outbox.RelayDue(run, now.UTC()); err != nil {
+				slog.ErrorContext(ctx, "relay outbox", "error", err)
+			}
+		}
+	}
+}
+
+// countActiveSessions counts the active sessions for the metrics every
+// interval, until ctx is cancelled.
+func countActiveSessions(ctx context.Context, refreshTokens repositories.RefreshTokenRepository, prometheus *metrics.Prometheus, interval time.Duration) {
+	run := context.WithoutCancel(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			count, err := refreshTokens.CountActiveSessions(run, now.UTC())
+			if err != nil {
+				slog.ErrorContext(ctx, "count active sessions", "error", err)
+				continue
+			}
+			prometheus.SetActiveSessions(count)
+		}
+	}
+}
+
+// buildTracerProvider exports traces over OTLP/gRPC when
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set,
+// and returns nil to leave tracing off otherwise. The exporter, sampler and
+// resource read the other standard OTEL_* variables.
+func buildTracerProvider(ctx context.Context, tracing config.Tracing) (*sdktrace.TracerProvider, error) {
+	endpoint := tracing.CollectorURL()
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+	service := resource.NewSchemaless(attribute.String("service.name", tracing.ServiceName))
+	res, err := resource.Merge(resource.Default(), service)
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
+
+// buildKeyStore selects database-managed rotating keys when
+// JWT_KEY_ROTATION_INTERVAL is set, and a single static key otherwise.
+// A static key held in a secrets backend is replaced when it changes there.
+func buildKeyStore(ctx context.Context, signingKeys repositories.SigningKeyRepository, txManager ports.TxManager, secretStore *secrets.Cache, signing config.Signing, accessTTL time.Duration) (token.KeyStore, error) {
+	if !signing.Rotating() {
+		var store *token.StaticKeyStore
+		err := secretStore.Watch(ctx, signing.PrivateKey, func(pemData string) error {
+			signingKey, err := token.ParseSigningKey([]byte(pemData))
+			if err != nil {
+				return err
+			}
+			if store == nil {
+				store = token.NewStaticKeyStore(signingKey)
+			} else {
+				store.Replace(signingKey)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY: %w", err)
+		}
+		return store, nil
+	}
+
+	encoded, err := secretStore.Get(ctx, signing.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY: %w", err)
+	}
+	encryptionKey, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode JWT_KEY_ENCRYPTION_KEY: %w", err)
+	}
+	keyCipher, err := security.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY: %w", err)
+	}
+
+	manager, err := token.NewKeyManager(signingKeys, txManager, keyCipher, token.KeyManagerConfig{
+		Algorithm:          signing.Algorithm,
+		RotationInterval:   signing.RotationInterval,
+		PrePublish:         signing.PrePublish,
+		VerificationWindow: accessTTL + keyVerificationSlack,
+		RefreshInterval:    time.Minute,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manager.Start(ctx); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// buildMailer delivers through SMTP_HOST when set, and logs emails otherwise.
+func buildMailer(smtp config.SMTP) ports.Mailer {
+	if smtp.Host == "" {
+		return mail.NewLogMailer()
+	}
+	return mail.NewSMTPMailer(mail.SMTPConfig{
+		Host:     smtp.Host,
+		Port:     strconv.Itoa(smtp.Port),
+		Username: smtp.Username,
+		Password: smtp.Password,
+		From:     smtp.From,
+	})
+}
+
+// buildRedisClient connects to REDIS_URL, e.g. redis://localhost:6379/0. It
+// returns nil when Redis is not configured.
+func buildRedisClient(ctx context.Context, configured config.Redis) (*redis.Client, error) {
+	if configured.URL == "" {
+		return nil, nil
+	}
+
+	options, err := redis.ParseURL(configured.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse REDIS_URL: %w", err)
+	}
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// buildGeoLocator locates session IP addresses with the MaxMind City
+// database at GEOIP_DB_PATH. Without one, sessions are listed without a
+// location.
+func buildGeoLocator(configured config.GeoIP) (ports.GeoLocator, error) {
+	if configured.DBPath == "" {
+		return geoip.NewNopLocator(), nil
+	}
+	return geoip.NewMaxMindLocator(configured.DBPath)
+}
+
+// buildSIEMStream streams audit events to the collector named by SIEM_SINK:
+// syslog, splunk or elastic. Without one, audit events are only stored.
+func buildSIEMStream(configured config.SIEM) (*siem.Stream, error) {
+	hostname, _ := os.Hostname()
+
+	var sink siem.Sink
+	switch configured.Sink {
+	case "":
+		return nil, nil
+	case "syslog":
+		format := siem.FormatCEF
+		if configured.Syslog.Format == "leef" {
+			format = siem.FormatLEEF
+		}
+		syslog, err := siem.NewSyslogSink(siem.SyslogConfig{
+			Network:  configured.Syslog.Network,
+			Address:  configured.Syslog.Addr,
+			Hostname: hostname,
+			Format:   format,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sink = syslog
+	case "splunk":
+		sink = siem.NewSplunkSink(siem.SplunkConfig{
+			URL:      configured.Splunk.URL,
+			Token:    configured.Splunk.Token,
+			Index:    configured.Splunk.Index,
+			Hostname: hostname,
+		})
+	case "elastic":
+		sink = siem.NewElasticSink(siem.ElasticConfig{
+			URL:    configured.Elastic.URL,
+			APIKey: configured.Elastic.APIKey,
+			Index:  configured.Elastic.Index,
+		})
+	}
+
+	return siem.NewStream(sink, siem.StreamConfig{
+		BufferSize:    configured.BufferSize,
+		BatchSize:     configured.BatchSize,
+		FlushInterval: configured.FlushInterval,
+		BlockTimeout:  configured.BlockTimeout,
+		MaxRetryDelay: time.Minute,
+	}), nil
+}
+
+// buildEventBroker publishes domain events to the broker named by
+// EVENT_BROKER: nats or kafka. Without one, events are only logged.
+func buildEventBroker(configured config.EventBroker) (eventbus.Publisher, error) {
+	switch configured.Kind {
+	case "nats":
+		return eventbus.NewNATSPublisher(eventbus.NATSConfig{
+			URL:           configured.NATS.URL,
+			SubjectPrefix: configured.NATS.SubjectPrefix,
+			JetStream:     configured.NATS.JetStream,
+			Credentials:   configured.NATS.Credentials,
+		})
+	case "kafka":
+		return eventbus.NewKafkaPublisher(eventbus.KafkaConfig{
+			Brokers:  configured.Kafka.Brokers,
+			Topic:    configured.Kafka.Topic,
+			Username: configured.Kafka.Username,
+			Password: configured.Kafka.Password,
+			TLS:      configured.Kafka.TLS,
+		})
+	default:
+		return nil, nil
+	}
+}
+
+// buildSMSSender delivers through Twilio when TWILIO_ACCOUNT_SID is set, and
+// logs text messages otherwise.
+func buildSMSSender(twilio config.Twilio) ports.SMSSender {
+	if twilio.AccountSID == "" {
+		return sms.NewLogSender()
+	}
+	return sms.NewTwilioSender(sms.TwilioConfig{
+		AccountSID: twilio.AccountSID,
+		AuthToken:  twilio.AuthToken,
+		From:       twilio.From,
+	})
+}
+
+// buildPasswordHasher hashes with the Argon2id cost of ARGON2_MEMORY_KIB,
+// ARGON2_ITERATIONS and ARGON2_PARALLELISM, which default to RFC 9106 values.
+func buildPasswordHasher(ctx context.Context, secretStore *secrets.Cache, argon2 config.Argon2, configured config.Pepper) (*security.Argon2Hasher, error) {
+	peppers, err := buildPeppers(ctx, secretStore, configured)
+	if err != nil {
+		return nil, err
+	}
+
+	return security.NewArgon2Hasher(security.Argon2Params{
+		Memory:      uint32(argon2.MemoryKiB),
+		Iterations:  uint32(argon2.Iterations),
+		Parallelism: uint8(argon2.Parallelism),
+	}, peppers)
+}
+
+// buildPeppers loads the password pepper from PASSWORD_PEPPER_SOURCE: vault
+// reads the versions of a KV v2 secret at PASSWORD_PEPPER_VAULT_PATH, env
+// reads PASSWORD_PEPPERS, a list of version:base64 pairs or a reference to
+// a secret holding one, which is reloaded when the secret changes, and new
+// hashes take PASSWORD_PEPPER_VERSION, by default the highest. Unset leaves
+// passwords unpeppered.
+func buildPeppers(ctx context.Context, secretStore *secrets.Cache, configured config.Pepper) (*security.Peppers, error) {
+	// version picks the configured version over the current one.
+	version := func(current int) int {
+		if configured.Version > 0 {
+			return configured.Version
+		}
+		return current
+	}
+
+	switch configured.Source {
+	case "vault":
+		source, err := pepper.NewVault(pepper.VaultConfig{
+			Mount: configured.Vault.Mount,
+			Path:  configured.Vault.Path,
+			Field: configured.Vault.Field,
+		})
+		if err != nil {
+			return nil, err
+		}
+		current, keys, err := source.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return security.NewPeppers(version(current), keys)
+	case "env":
+		var peppers *security.Peppers
+		err := secretStore.Watch(ctx, configured.Peppers, func(value string) error {
+			current, keys, err := parsePeppers(value)
+			if err != nil {
+				return err
+			}
+			if peppers == nil {
+				peppers, err = security.NewPeppers(version(current), keys)
+				return err
+			}
+			return peppers.Replace(version(current), keys)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return peppers, nil
+	default:
+		return nil, nil
+	}
+}
+
+// parsePeppers reads a list of version:base64 pairs, returning the highest
+// version with the keys.
+func parsePeppers(list string) (int, map[int][]byte, error) {
+	current, keys := 0, make(map[int][]byte)
+	for _, item := range config.SplitList(list) {
+		name, encoded, ok := strings.Cut(item, ":")
+		version, err := strconv.Atoi(name)
+		if !ok || err != nil {
+			return 0, nil, fmt.Errorf("PASSWORD_PEPPERS: invalid entry %q", name)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return 0, nil, fmt.Errorf("PASSWORD_PEPPERS: decode version %d: %w", version, err)
+		}
+		keys[version] = key
+		current = max(current, version)
+	}
+	return current, keys, nil
+}
+
+// buildMFACipher seals TOTP secrets and SMS phone numbers with the base64 encoded 32 byte key in
+// MFA_ENCRYPTION_KEY.
+func buildMFACipher(mfa config.MFA) (*security.Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(mfa.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode MFA_ENCRYPTION_KEY: %w", err)
+	}
+	mfaCipher, err := security.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("MFA_ENCRYPTION_KEY: %w", err)
+	}
+	return mfaCipher, nil
+}
+
+// buildWebhookCipher seals webhook signing secrets with the base64 encoded 32
+// byte key in WEBHOOK_ENCRYPTION_KEY, or with the MFA key when it is unset.
+func buildWebhookCipher(cfg *config.Config) (*security.Cipher, error) {
+	raw := cfg.Webhooks.EncryptionKey
+	if raw == "" {
+		return buildMFACipher(cfg.MFA)
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode WEBHOOK_ENCRYPTION_KEY: %w", err)
+	}
+	webhookCipher, err := security.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("WEBHOOK_ENCRYPTION_KEY: %w", err)
+	}
+	return webhookCipher, nil
+}
+
+// buildDataExportSigner signs data export download links with the base64
+// encoded key of at least 32 bytes in DATA_EXPORT_SIGNING_KEY, or with the MFA
+// key when it is unset.
+func buildDataExportSigner(cfg *config.Config) (*security.Signer, error) {
+	name, raw := "DATA_EXPORT_SIGNING_KEY", cfg.DataExports.SigningKey
+	if raw == "" {
+		name, raw = "MFA_ENCRYPTION_KEY", cfg.MFA.EncryptionKey
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+	signer, err := security.NewSigner(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return signer, nil
+}
+
+// buildMFAPolicy requires the roles listed in MFA_REQUIRED_ROLES, e.g. ADMIN,
+// to enroll a second factor before receiving full tokens.
+func buildMFAPolicy(mfa config.MFA) *application.MFAPolicy {
+	roles := make([]domain.Role, 0, len(mfa.RequiredRoles))
+	for _, role := range mfa.RequiredRoles {
+		roles = append(roles, domain.Role(role))
+	}
+	return application.NewMFAPolicy(roles...)
+}
+
+// buildPasswordExpiry applies the max age of passwords of PASSWORD_MAX_AGE,
+// overridden per role by PASSWORD_MAX_AGE_ADMIN and PASSWORD_MAX_AGE_USER.
+// Zero, the default, lets passwords of the role never expire.
+func buildPasswordExpiry(rotation config.PasswordRotation) *application.PasswordExpiry {
+	maxAge := make(map[domain.Role]time.Duration)
+	for role, override := range map[domain.Role]*time.Duration{
+		domain.RoleAdmin: rotation.MaxAgeAdmin,
+		domain.RoleUser:  rotation.MaxAgeUser,
+	} {
+		maxAge[role] = rotation.MaxAge
+		if override != nil {
+			maxAge[role] = *override
+		}
+	}
+	return application.NewPasswordExpiry(maxAge)
+}
+
+// buildSessionLimit caps the active sessions per account at
+// MAX_ACTIVE_SESSIONS (0 disables it), with SESSION_LIMIT_STRATEGY deciding
+// what happens at the cap.
+func buildSessionLimit(limit config.SessionLimit) application.SessionLimit {
+	return application.SessionLimit{Max: limit.Max, Strategy: domain.SessionLimitStrategy(limit.Strategy)}
+}
+
+// buildSessionLifetime applies the refresh token lifetimes of the
+// configuration, extending sessions on refresh when REFRESH_EXPIRATION is
+// SLIDING.
+func buildSessionLifetime(tokens config.Tokens) application.SessionLifetime {
+	lifetime := application.SessionLifetime{
+		Default:    tokens.RefreshTTL,
+		RememberMe: tokens.RememberMeRefreshTTL,
+		MaxAge:     tokens.MaxSessionAge,
+	}
+	if tokens.RefreshExpiration == "SLIDING" {
+		lifetime.Refresh = application.SlidingExpiration{Ceiling: tokens.SlidingSessionCeiling}
+	} else {
+		lifetime.Refresh = application.FixedExpiration{}
+	}
+	return lifetime
+}
+
+// buildRetentionPolicy applies the retention periods of the configuration.
+func buildRetentionPolicy(retention config.Retention) application.RetentionPolicy {
+	return application.RetentionPolicy{
+		LoginOrigins:      retention.LoginOrigins,
+		AuditEvents:       retention.AuditEvents,
+		VerificationCodes: retention.VerificationCodes,
+		RefreshTokens:     retention.RefreshTokens,
+	}
+}
+
+// buildPasswordPolicy builds the rules for new passwords, with a strength
+// estimator for the minimum score.
+func buildPasswordPolicy(configured config.PasswordPolicy) (application.PasswordPolicy, error) {
+	policy := application.PasswordPolicy{
+		MinLength:   configured.MinLength,
+		MaxLength:   configured.MaxLength,
+		BannedWords: configured.BannedWords,
+		MinScore:    configured.MinScore,
+		Strength:    strength.NewEstimator(),
+	}
+	for _, class := range configured.RequiredClasses {
+		policy.RequiredClasses = append(policy.RequiredClasses, domain.CharacterClass(strings.ToLower(class)))
+	}
+	return policy, policy.Check()
+}
+
+// buildLockoutPolicy locks an auth method after LOCKOUT_THRESHOLD
+// consecutive failures (0 disables lockouts), for LOCKOUT_DURATION at first
+// and LOCKOUT_MAX_DURATION at the longest.
+func buildLockoutPolicy(lockout config.Lockout) application.LockoutPolicy {
+	return application.LockoutPolicy{Threshold: lockout.Threshold, Duration: lockout.Duration, MaxDuration: lockout.MaxDuration}
+}
+
+// buildCaptchaGuard configures CAPTCHA checks when CAPTCHA_PROVIDER names
+// recaptcha, hcaptcha or turnstile, verified with CAPTCHA_SECRET. The checks
+// apply to the flows listed in CAPTCHA_REQUIRED_ON, register and
+// password_reset, and to logins after CAPTCHA_LOGIN_AFTER_FAILURES failures.
+func buildCaptchaGuard(configured config.Captcha) (*application.CaptchaGuard, error) {
+	if configured.Provider == "" {
+		return application.NewCaptchaGuard(nil, application.CaptchaPolicy{}), nil
+	}
+
+	verifier, err := captcha.NewSiteVerifier(captcha.Config{
+		Provider: configured.Provider,
+		Secret:   configured.Secret,
+		MinScore: configured.MinScore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy := application.CaptchaPolicy{
+		Register:           slices.Contains(configured.RequiredOn, "register"),
+		PasswordReset:      slices.Contains(configured.RequiredOn, "password_reset"),
+		LoginAfterFailures: configured.LoginAfterFailures,
+	}
+	return application.NewCaptchaGuard(verifier, policy), nil
+}
+
+// buildDisposableEmailGuard applies DISPOSABLE_EMAIL_ACTION, REJECT, FLAG or
+// OFF, to registrations from disposable email domains. Domains come from the
+// embedded list, or from the URL or file in DISPOSABLE_EMAIL_SOURCE reloaded
+// every DISPOSABLE_EMAIL_RELOAD_INTERVAL, and DISPOSABLE_EMAIL_ALLOW and
+// DISPOSABLE_EMAIL_DENY override them.
+func buildDisposableEmailGuard(ctx context.Context, configured config.DisposableEmails) (*application.DisposableEmailGuard, error) {
+	if configured.Action == "OFF" {
+		return application.NewDisposableEmailGuard(nil, ""), nil
+	}
+
+	blocklist := disposable.NewBlocklist(disposable.Config{
+		Source:         configured.Source,
+		ReloadInterval: configured.ReloadInterval,
+		Allow:          configured.Allow,
+		Deny:           configured.Deny,
+	})
+	if err := blocklist.Start(ctx); err != nil {
+		return nil, err
+	}
+	return application.NewDisposableEmailGuard(blocklist, domain.DisposableEmailAction(configured.Action)), nil
+}
+
+// buildBreachedPasswordGuard applies BREACHED_PASSWORD_ACTION, BLOCK, WARN or
+// OFF, to new passwords found in data breaches. Passwords are checked with
+// the Pwned Passwords range API at BREACHED_PASSWORD_API_URL ("off" for
+// air-gapped deployments), backed by the offline bloom filter in
+// BREACHED_PASSWORD_BLOOM_FILTER when one is given.
+func buildBreachedPasswordGuard(configured config.BreachedPasswords) (*application.BreachedPasswordGuard, error) {
+	action := domain.BreachedPasswordAction(configured.Action)
+	if action == "OFF" {
+		return application.NewBreachedPasswordGuard(nil, ""), nil
+	}
+
+	var filter *breach.BloomFilter
+	if configured.BloomFilter != "" {
+		var err error
+		if filter, err = breach.LoadBloomFilter(configured.BloomFilter); err != nil {
+			return nil, err
+		}
+	}
+
+	apiURL := configured.APIURL
+	switch {
+	case !strings.EqualFold(apiURL, "off") && filter != nil:
+		return application.NewBreachedPasswordGuard(breach.NewFallback(breach.NewPwnedPasswords(apiURL), filter), action), nil
+	case !strings.EqualFold(apiURL, "off"):
+		return application.NewBreachedPasswordGuard(breach.NewPwnedPasswords(apiURL), action), nil
+	default:
+		return application.NewBreachedPasswordGuard(filter, action), nil
+	}
+}
+
+// buildRateLimits converts the per-IP and per-identifier limits of each
+// endpoint group.
+func buildRateLimits(configured config.RateLimits) httptransport.RateLimits {
+	group := func(limit config.RateLimitGroup) httptransport.RateLimit {
+		return httptransport.RateLimit{IP: models.RateLimit(limit.IP), Identifier: models.RateLimit(limit.Identifier)}
+	}
+	return httptransport.RateLimits{
+		Login:        group(configured.Login),
+		Register:     group(configured.Register),
+		Refresh:      group(configured.Refresh),
+		Verification: group(configured.Verification),
+	}
+}
+
+// buildClientRegistrations converts the clients allowed to call the
+// authorization server endpoints.
+func buildClientRegistrations(server config.AuthorizationServer) []application.ClientRegistration {
+	registrations := make([]application.ClientRegistration, 0, len(server.Clients))
+	for _, client := range server.Clients {
+		registrations = append(registrations, application.ClientRegistration{
+			ClientID:          client.ID,
+			Name:              client.Name,
+			Secret:            client.Secret,
+			Public:            client.Public,
+			RedirectURIs:      client.RedirectURIs,
+			GrantTypes:        client.GrantTypes,
+			Scopes:            client.Scopes,
+			ExchangeAudiences: client.ExchangeAudiences,
+			Impersonation:     client.Impersonation,
+		})
+	}
+	return registrations
+}
+
+// buildOAuthProviders enables each social login provider whose client ID is configured.
+func buildOAuthProviders(ctx context.Context, secretStore *secrets.Cache, configured config.Providers) ([]ports.OAuthProvider, error) {
+	var providers []ports.OAuthProvider
+
+	if google := configured.Google; google.Enabled() {
+		clientSecret, err := secretFunc(ctx, secretStore, google.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("GOOGLE_CLIENT_SECRET: %w", err)
+		}
+		provider, err := oauth.NewGoogleProvider(ctx, oauth.GoogleConfig{
+			ClientID:         google.ClientID,
+			ClientSecret:     google.ClientSecret,
+			RedirectURL:      google.RedirectURL,
+			ClientSecretFunc: clientSecret,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	if github := configured.GitHub; github.Enabled() {
+		clientSecret, err := secretFunc(ctx, secretStore, github.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("GITHUB_CLIENT_SECRET: %w", err)
+		}
+		providers = append(providers, oauth.NewGithubProvider(oauth.GithubConfig{
+			ClientID:         github.ClientID,
+			ClientSecret:     github.ClientSecret,
+			RedirectURL:      github.RedirectURL,
+			ClientSecretFunc: clientSecret,
+		}))
+	}
+
+	if apple := configured.Apple; apple.Enabled() {
+		privateKey, err := secretStore.Get(ctx, apple.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("APPLE_PRIVATE_KEY: %w", err)
+		}
+		provider, err := oauth.NewAppleProvider(ctx, oauth.AppleConfig{
+			ClientID:    apple.ClientID,
+			TeamID:      apple.TeamID,
+			KeyID:       apple.KeyID,
+			PrivateKey:  []byte(privateKey),
+			RedirectURL: apple.RedirectURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	if microsoft := configured.Microsoft; microsoft.Enabled() {
+		clientSecret, err := secretFunc(ctx, secretStore, microsoft.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("MICROSOFT_CLIENT_SECRET: %w", err)
+		}
+		provider, err := oauth.NewMicrosoftProvider(ctx, oauth.MicrosoftConfig{
+			Tenant:           microsoft.Tenant,
+			ClientID:         microsoft.ClientID,
+			ClientSecret:     microsoft.ClientSecret,
+			RedirectURL:      microsoft.RedirectURL,
+			ClientSecretFunc: clientSecret,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	for _, oidc := range configured.OIDC {
+		clientSecret, err := secretFunc(ctx, secretStore, oidc.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("OIDC_%s_CLIENT_SECRET: %w", strings.ToUpper(oidc.Name), err)
+		}
+		provider, err := oauth.NewOIDCProvider(ctx, oauth.OIDCConfig{
+			Name:             oidc.Name,
+			DiscoveryURL:     oidc.DiscoveryURL,
+			ClientID:         oidc.ClientID,
+			ClientSecret:     oidc.ClientSecret,
+			RedirectURL:      oidc.RedirectURL,
+			Scopes:           oidc.Scopes,
+			ClientSecretFunc: clientSecret,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// disabledProviders returns the codes of the providers turned off.
+func disabledProviders(configured config.Providers) []domain.Provider {
+	codes := make([]domain.Provider, 0, len(configured.Disabled))
+	for _, name := range configured.Disabled {
+		codes = append(codes, oauth.ProviderCode(name))
+	}
+	return codes
+}
+
+func buildFeatureFlags(configured []config.FeatureFlag) []models.FeatureFlag {
+	flags := make([]models.FeatureFlag, 0, len(configured))
+	for _, flag := range configured {
+		flags = append(flags, models.FeatureFlag{
+			Key:           domain.Feature(flag.Key),
+			Enabled:       flag.Enabled,
+			Percentage:    flag.Percentage,
+			Organizations: flag.Organizations,
+		})
+	}
+	return flags
+}
+
+// reloadOnHangup reloads the configuration on every SIGHUP until ctx is
+// cancelled.
+func reloadOnHangup(ctx context.Context, reloader *config.Reloader) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+			if err := reloader.Reload(ctx); err != nil {
+				slog.ErrorContext(ctx, "reload configuration; keeping the active one", "error", err)
+			}
+		}
+	}
+}
+
+// secretFunc returns a function resolving a client secret held in a secrets
+// backend on every code exchange, after checking it resolves now. Plain
+// secrets need none.
+func secretFunc(ctx context.Context, secretStore *secrets.Cache, value string) (func() (string, error), error) {
+	if !secrets.IsReference(value) {
+		return nil, nil
+	}
+	if _, err := secretStore.Get(ctx, value); err != nil {
+		return nil, err
+	}
+	return secretStore.Getter(value), nil
+}
+
+// buildSecretStore resolves settings that reference Vault or AWS Secrets
+// Manager secrets, caching them for SECRETS_CACHE_TTL when the backend sets
+// no lease.
+func buildSecretStore(ctx context.Context, configured config.Secrets) (*secrets.Cache, error) {
+	vault, err := secrets.NewVault()
+	if err != nil {
+		return nil, err
+	}
+	aws, err := secrets.NewAWS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewCache(map[string]secrets.Backend{"vault": vault, "aws": aws}, configured.CacheTTL), nil
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/config"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres"
+)
+
+const migrateUsage = "usage: migrate [up | down <steps> | force <version> | version]"
+
+// runMigrate runs the migrate subcommand against the PostgreSQL database
+// with the migrations embedded in the binary:
+//
+//	api migrate              apply every pending migration
+//	api migrate down 1       revert the last migration
+//	api migrate force 42     mark version 42 as applied and clean
+//	api migrate version      print the applied version
+func runMigrate(database config.Database, args []string) (err error) {
+	if database.InMemory() {
+		return errors.New("the in-memory store has no schema to migrate")
+	}
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	migrator, err := postgres.NewMigrator(database.URL)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, migrator.Close()) }()
+
+	switch command {
+	case "up":
+		if len(args) != 0 {
+			return errors.New(migrateUsage)
+		}
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down", "force":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 || (command == "down" && n == 0) {
+			return errors.New(migrateUsage)
+		}
+		if command == "down" {
+			err = migrator.Down(n)
+		} else {
+			err = migrator.Force(n)
+		}
+		if err != nil {
+			return err
+		}
+	case "version":
+		if len(args) != 0 {
+			return errors.New(migrateUsage)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	if command == "version" {
+		fmt.Println(version)
+	}
+	slog.Info("schema version", "version", version, "dirty", dirty, "build_version", postgres.SchemaVersion)
+	return nil
+}
+
+// migrateOnStartup applies pending migrations before the service connects,
+// when MIGRATE_ON_STARTUP is true and the data is kept in PostgreSQL.
+func migrateOnStartup(database config.Database) error {
+	if !database.MigrateOnStartup || database.InMemory() {
+		return nil
+	}
+	return runMigrate(database, nil)
+}
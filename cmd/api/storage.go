@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/config"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/health"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/metrics"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/memory"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres"
+	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5/multitracer"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// storage holds the repositories the services persist to, and the health
+// checks of the database behind them.
+type storage struct {
+	txManager ports.TxManager
+	leader    ports.LeaderElection
+
+	accounts            repositories.AccountRepository
+	roles               repositories.RoleRepository
+	providers           repositories.AuthProviderRepository
+	authMethods         repositories.AuthMethodRepository
+	passwordCredentials repositories.PasswordCredentialRepository
+	passwordHistory     repositories.PasswordHistoryRepository
+	verificationCodes   repositories.VerificationCodeRepository
+	refreshTokens       repositories.RefreshTokenRepository
+	accessTokens        repositories.AccessTokenRepository
+	apiKeys             repositories.APIKeyRepository
+	signingKeys         repositories.SigningKeyRepository
+
+	mfaFactors         repositories.MFAFactorRepository
+	mfaChallenges      repositories.MFAChallengeRepository
+	mfaCodes           repositories.MFACodeRepository
+	recoveryCodes      repositories.MFARecoveryCodeRepository
+	trustedDevices     repositories.TrustedDeviceRepository
+	passkeys           repositories.PasskeyCredentialRepository
+	passkeyCeremonies  repositories.PasskeyCeremonyRepository
+	oauthClients       repositories.OAuthClientRepository
+	authorizationCodes repositories.AuthorizationCodeRepository
+	browserSessions    repositories.BrowserSessionRepository
+	deviceCodes        repositories.DeviceCodeRepository
+
+	accountBans      repositories.AccountBanRepository
+	accountDeletions repositories.AccountDeletionRepository
+	dataExports      repositories.DataExportRepository
+	legalDocuments   repositories.LegalDocumentRepository
+	consents         repositories.ConsentRepository
+	roleChanges      repositories.RoleChangeRepository
+	impersonations   repositories.ImpersonationRepository
+	auditEvents      repositories.AuditEventRepository
+
+	organizations repositories.OrganizationRepository
+	memberships   repositories.MembershipRepository
+	invitations   repositories.InvitationRepository
+	brandings     repositories.BrandingRepository
+	policies      repositories.AuthPolicyRepository
+
+	webhookEndpoints  repositories.WebhookEndpointRepository
+	webhookDeliveries repositories.WebhookDeliveryRepository
+	outbox            repositories.OutboxRepository
+
+	// replica serves the read paths that tolerate replication lag; without
+	// DATABASE_REPLICA_URL it reads from the primary.
+	replica struct {
+		accounts      repositories.AccountRepository
+		authMethods   repositories.AuthMethodRepository
+		refreshTokens repositories.RefreshTokenRepository
+	}
+
+	healthChecks []ports.HealthCheck
+	close        func()
+}
+
+// openPostgresStorage connects to the PostgreSQL primary, and to its read
+// replica when one is configured.
+func openPostgresStorage(ctx context.Context, database config.Database, prometheus *metrics.Prometheus) (*storage, error) {
+	pool, err := connect(ctx, "DATABASE_URL", database.URL, database, prometheus)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &storage{
+		txManager:           postgres.NewPostgresTxManager(pool),
+		leader:              postgres.NewAdvisoryLeaderElection(pool),
+		accounts:            postgres.NewAccountRepository(pool),
+		roles:               postgres.NewRoleRepository(pool),
+		providers:           postgres.NewAuthProviderRepository(pool),
+		authMethods:         postgres.NewAuthMethodRepository(pool),
+		passwordCredentials: postgres.NewPasswordCredentialRepository(pool),
+		passwordHistory:     postgres.NewPasswordHistoryRepository(pool),
+		verificationCodes:   postgres.NewVerificationCodeRepository(pool),
+		refreshTokens:       postgres.NewRefreshTokenRepository(pool),
+		accessTokens:        postgres.NewAccessTokenRepository(pool),
+		apiKeys:             postgres.NewAPIKeyRepository(pool),
+		signingKeys:         postgres.NewSigningKeyRepository(pool),
+		mfaFactors:          postgres.NewMFAFactorRepository(pool),
+		mfaChallenges:       postgres.NewMFAChallengeRepository(pool),
+		mfaCodes:            postgres.NewMFACodeRepository(pool),
+		recoveryCodes:       postgres.NewMFARecoveryCodeRepository(pool),
+		trustedDevices:      postgres.NewTrustedDeviceRepository(pool),
+		passkeys:            postgres.NewPasskeyCredentialRepository(pool),
+		passkeyCeremonies:   postgres.NewPasskeyCeremonyRepository(pool),
+		oauthClients:        postgres.NewOAuthClientRepository(pool),
+		authorizationCodes:  postgres.NewAuthorizationCodeRepository(pool),
+		browserSessions:     postgres.NewBrowserSessionRepository(pool),
+		deviceCodes:         postgres.NewDeviceCodeRepository(pool),
+		accountBans:         postgres.NewAccountBanRepository(pool),
+		accountDeletions:    postgres.NewAccountDeletionRepository(pool),
+		dataExports:         postgres.NewDataExportRepository(pool),
+		legalDocuments:      postgres.NewLegalDocumentRepository(pool),
+		consents:            postgres.NewConsentRepository(pool),
+		roleChanges:         postgres.NewRoleChangeRepository(pool),
+		impersonations:      postgres.NewImpersonationRepository(pool),
+		auditEvents:         postgres.NewAuditEventRepository(pool),
+		organizations:       postgres.NewOrganizationRepository(pool),
+		memberships:         postgres.NewMembershipRepository(pool),
+		invitations:         postgres.NewInvitationRepository(pool),
+		brandings:           postgres.NewBrandingRepository(pool),
+		policies:            postgres.NewAuthPolicyRepository(pool),
+		webhookEndpoints:    postgres.NewWebhookEndpointRepository(pool),
+		webhookDeliveries:   postgres.NewWebhookDeliveryRepository(pool),
+		outbox:              postgres.NewOutboxRepository(pool),
+		healthChecks: []ports.HealthCheck{
+			health.Database(pool),
+			health.Migrations(pool, postgres.SchemaVersion),
+		},
+		close: pool.Close,
+	}
+	db.replica.accounts = db.accounts
+	db.replica.authMethods = db.authMethods
+	db.replica.refreshTokens = db.refreshTokens
+	if database.ReplicaURL == "" {
+		return db, nil
+	}
+
+	replicaPool, err := connect(ctx, "DATABASE_REPLICA_URL", database.ReplicaURL, database, prometheus)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	db.replica.accounts = postgres.NewAccountRepository(replicaPool)
+	db.replica.authMethods = postgres.NewAuthMethodRepository(replicaPool)
+	db.replica.refreshTokens = postgres.NewRefreshTokenRepository(replicaPool)
+	db.healthChecks = append(db.healthChecks, health.Replica(replicaPool))
+	db.close = func() {
+		replicaPool.Close()
+		pool.Close()
+	}
+	return db, nil
+}
+
+// connect opens a pool to url with the configured pool settings; name is the
+// variable url came from, for the error message.
+func connect(ctx context.Context, name, url string, database config.Database, prometheus *metrics.Prometheus) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if database.MaxConns > 0 {
+		poolConfig.MaxConns = int32(database.MaxConns)
+	}
+	if database.MinConns > 0 {
+		poolConfig.MinConns = int32(database.MinConns)
+	}
+	if database.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = database.MaxConnLifetime
+	}
+	if database.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = database.MaxConnIdleTime
+	}
+	if database.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = database.HealthCheckPeriod
+	}
+	poolConfig.ConnConfig.Tracer = multitracer.New(metrics.NewQueryTracer(prometheus), otelpgx.NewTracer())
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("connect %s: %w", name, err)
+	}
+	return pool, nil
+}
+
+// openMemoryStorage keeps every row in the process, for local demos: nothing
+// survives a restart, and instances do not share data.
+func openMemoryStorage() *storage {
+	store := memory.NewStore()
+	db := &storage{
+		txManager:           memory.NewMemoryTxManager(store),
+		leader:              memory.NewLeaderElection(),
+		accounts:            memory.NewAccountRepository(store),
+		roles:               memory.NewRoleRepository(store),
+		providers:           memory.NewAuthProviderRepository(store),
+		authMethods:         memory.NewAuthMethodRepository(store),
+		passwordCredentials: memory.NewPasswordCredentialRepository(store),
+		passwordHistory:     memory.NewPasswordHistoryRepository(store),
+		verificationCodes:   memory.NewVerificationCodeRepository(store),
+		refreshTokens:       memory.NewRefreshTokenRepository(store),
+		accessTokens:        memory.NewAccessTokenRepository(store),
+		apiKeys:             memory.NewAPIKeyRepository(store),
+		signingKeys:         memory.NewSigningKeyRepository(store),
+		mfaFactors:          memory.NewMFAFactorRepository(store),
+		mfaChallenges:       memory.NewMFAChallengeRepository(store),
+		mfaCodes:            memory.NewMFACodeRepository(store),
+		recoveryCodes:       memory.NewMFARecoveryCodeRepository(store),
+		trustedDevices:      memory.NewTrustedDeviceRepository(store),
+		passkeys:            memory.NewPasskeyCredentialRepository(store),
+		passkeyCeremonies:   memory.NewPasskeyCeremonyRepository(store),
+		oauthClients:        memory.NewOAuthClientRepository(store),
+		authorizationCodes:  memory.NewAuthorizationCodeRepository(store),
+		browserSessions:     memory.NewBrowserSessionRepository(store),
+		deviceCodes:         memory.NewDeviceCodeRepository(store),
+		accountBans:         memory.NewAccountBanRepository(store),
+		accountDeletions:    memory.NewAccountDeletionRepository(store),
+		dataExports:         memory.NewDataExportRepository(store),
+		legalDocuments:      memory.NewLegalDocumentRepository(store),
+		consents:            memory.NewConsentRepository(store),
+		roleChanges:         memory.NewRoleChangeRepository(store),
+		impersonations:      memory.NewImpersonationRepository(store),
+		auditEvents:         memory.NewAuditEventRepository(store),
+		organizations:       memory.NewOrganizationRepository(store),
+		memberships:         memory.NewMembershipRepository(store),
+		invitations:         memory.NewInvitationRepository(store),
+		brandings:           memory.NewBrandingRepository(store),
+		policies:            memory.NewAuthPolicyRepository(store),
+		webhookEndpoints:    memory.NewWebhookEndpointRepository(store),
+		webhookDeliveries:   memory.NewWebhookDeliveryRepository(store),
+		outbox:              memory.NewOutboxRepository(store),
+		close:               func() {},
+	}
+	db.replica.accounts = db.accounts
+	db.replica.authMethods = db.authMethods
+	db.replica.refreshTokens = db.refreshTokens
+	return db
+}
@@ -0,0 +1,84 @@
+// Command breach-filter builds the bloom filter used to check passwords
+// against the Pwned Passwords set offline. It reads the SHA-1 download, one
+// HASH:COUNT line per password, and writes the filter for
+// BREACHED_PASSWORD_BLOOM_FILTER:
+//
+//	breach-filter -in pwned-passwords-sha1.txt -out breached.bloom
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/breach"
+)
+
+func main() {
+	in := flag.String("in", "", "Pwned Passwords SHA-1 file, one HASH:COUNT line per password")
+	out := flag.String("out", "breached.bloom", "filter file to write")
+	rate := flag.Float64("fp", 0.001, "false positive rate")
+	minCount := flag.Uint64("min-count", 1, "skip passwords seen in fewer breaches")
+	flag.Parse()
+
+	if *in == "" || *rate <= 0 || *rate >= 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var entries uint64
+	if err := scan(*in, *minCount, func(string) error { entries++; return nil }); err != nil {
+		log.Fatalf("count hashes: %v", err)
+	}
+
+	filter := breach.NewBloomFilter(entries, *rate)
+	if err := scan(*in, *minCount, filter.AddHash); err != nil {
+		log.Fatalf("add hashes: %v", err)
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("create filter: %v", err)
+	}
+	writer := bufio.NewWriter(file)
+	if _, err := filter.WriteTo(writer); err != nil {
+		log.Fatalf("write filter: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		log.Fatalf("write filter: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		log.Fatalf("write filter: %v", err)
+	}
+	log.Printf("wrote %d hashes to %s", entries, *out)
+}
+
+// scan calls add with the hash of every line of path seen at least minCount
+// times.
+func scan(path string, minCount uint64, add func(hash string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		hash, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if hash == "" {
+			continue
+		}
+		if found {
+			if n, err := strconv.ParseUint(count, 10, 64); err == nil && n < minCount {
+				continue
+			}
+		}
+		if err := add(hash); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
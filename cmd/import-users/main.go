@@ -0,0 +1,137 @@
+// Command import-users migrates the users of an Auth0 or Firebase export
+// into the database at DATABASE_URL. Password hashes are stored as exported
+// and rehashed with Argon2id at each user's first login:
+//
+//	import-users -format auth0 -in users.json
+//	import-users -format firebase -in users.json \
+//		-firebase-signer-key … -firebase-salt-separator Bw== \
+//		-firebase-rounds 8 -firebase-mem-cost 14
+//
+// Addresses that are already registered are skipped, so an interrupted
+// import can be run again.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/userimport"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	format := flag.String("format", "", "export format: auth0 or firebase")
+	in := flag.String("in", "", "export file")
+	workers := flag.Int("workers", 8, "users imported concurrently")
+	signerKey := flag.String("firebase-signer-key", "", "base64 signer key of the Firebase project")
+	saltSeparator := flag.String("firebase-salt-separator", "", "base64 salt separator of the Firebase project")
+	rounds := flag.Int("firebase-rounds", 8, "scrypt rounds of the Firebase project")
+	memCost := flag.Int("firebase-mem-cost", 14, "scrypt memory cost of the Firebase project")
+	flag.Parse()
+
+	if *in == "" || *workers < 1 || (*format != "auth0" && *format != "firebase") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		log.Fatalf("connect database: %v", err)
+	}
+	defer pool.Close()
+
+	// Only the supported hash formats matter here: imported hashes are
+	// stored as they are.
+	hasher, err := security.NewArgon2Hasher(security.DefaultArgon2Params, nil)
+	if err != nil {
+		log.Fatalf("configure password hashing: %v", err)
+	}
+	importer := application.NewImportService(
+		postgres.NewPostgresTxManager(pool),
+		postgres.NewAccountRepository(pool),
+		postgres.NewAuthMethodRepository(pool),
+		postgres.NewPasswordCredentialRepository(pool),
+		hasher,
+	)
+
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("open export: %v", err)
+	}
+	defer file.Close()
+
+	var imported, skipped, failed atomic.Int64
+	users := make(chan *models.ImportedUser, *workers)
+	var wg sync.WaitGroup
+	for range *workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for user := range users {
+				err := importer.ImportUser(ctx, user)
+				switch {
+				case err == nil:
+					imported.Add(1)
+				case errors.Is(err, domain.ErrAccountAlreadyExists):
+					skipped.Add(1)
+				default:
+					failed.Add(1)
+					log.Printf("import %s: %v", user.Email, err)
+				}
+				if total := imported.Load() + skipped.Load() + failed.Load(); total%10000 == 0 {
+					log.Printf("%d users processed", total)
+				}
+			}
+		}()
+	}
+
+	send := func(user *models.ImportedUser) error {
+		users <- user
+		return nil
+	}
+	switch *format {
+	case "auth0":
+		err = userimport.ReadAuth0(file, send)
+	case "firebase":
+		var params security.FirebaseScryptParams
+		params, err = firebaseParams(*signerKey, *saltSeparator, *rounds, *memCost)
+		if err == nil {
+			err = userimport.ReadFirebase(file, params, send)
+		}
+	}
+	close(users)
+	wg.Wait()
+
+	log.Printf("imported %d users, skipped %d already registered, %d failed", imported.Load(), skipped.Load(), failed.Load())
+	if err != nil {
+		log.Fatalf("read export: %v", err)
+	}
+	if failed.Load() > 0 {
+		os.Exit(1)
+	}
+}
+
+func firebaseParams(signerKey, saltSeparator string, rounds, memCost int) (security.FirebaseScryptParams, error) {
+	params := security.FirebaseScryptParams{Rounds: rounds, MemoryCost: memCost}
+
+	var err error
+	if params.SignerKey, err = base64.StdEncoding.DecodeString(signerKey); err != nil || len(params.SignerKey) == 0 {
+		return params, errors.New("-firebase-signer-key must be the base64 signer key")
+	}
+	if params.SaltSeparator, err = base64.StdEncoding.DecodeString(saltSeparator); err != nil {
+		return params, errors.New("-firebase-salt-separator must be base64")
+	}
+	return params, nil
+}
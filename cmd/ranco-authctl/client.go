@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// client calls the admin API with the access token or API key of an ADMIN
+// account.
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newClient(baseURL, token string) *client {
+	return &client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// apiError is an error answered by the service, such as account_not_found.
+type apiError struct {
+	Status int
+	Code   string `json:"error"`
+	Fields []struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+	} `json:"fields"`
+}
+
+func (e *apiError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (HTTP %d)", e.Code, e.Status)
+	for _, field := range e.Fields {
+		fmt.Fprintf(&b, "\n  %s: %s", field.Field, field.Code)
+	}
+	return b.String()
+}
+
+// do sends body, when not nil, as JSON and decodes the response into out,
+// when not nil. raw, when set, receives the response body as it was sent.
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, out any, raw *json.RawMessage) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		apiErr := &apiError{Status: resp.StatusCode, Code: http.StatusText(resp.StatusCode)}
+		_ = json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+
+	if raw != nil {
+		*raw = data
+	}
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+type authMethod struct {
+	ID          uuid.UUID  `json:"id"`
+	Provider    string     `json:"provider"`
+	ProviderID  string     `json:"provider_id"`
+	IsVerified  bool       `json:"is_verified"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+type account struct {
+	ID          uuid.UUID    `json:"id"`
+	RoleCode    string       `json:"role_code"`
+	StatusCode  string       `json:"status_code"`
+	CreatedAt   time.Time    `json:"created_at"`
+	AuthMethods []authMethod `json:"auth_methods"`
+}
+
+type accountPage struct {
+	Accounts   []account `json:"accounts"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+type ban struct {
+	ID             uuid.UUID  `json:"id"`
+	AccountID      uuid.UUID  `json:"account_id"`
+	PreviousStatus string     `json:"previous_status"`
+	Reason         string     `json:"reason"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	LiftedAt       *time.Time `json:"lifted_at"`
+}
+
+type codeIssued struct {
+	ExpiresIn int `json:"expires_in"`
+}
+
+type signingKey struct {
+	ID          string     `json:"kid"`
+	Algorithm   string     `json:"alg"`
+	ActivatesAt time.Time  `json:"activates_at"`
+	RetiredAt   *time.Time `json:"retired_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type signingKeys struct {
+	Keys []signingKey `json:"keys"`
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cli runs one command against the admin API and prints its result.
+type cli struct {
+	client *client
+	json   bool
+	out    io.Writer
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch command, args := args[0], args[1:]; {
+	case command == "accounts" && len(args) > 0 && args[0] == "search":
+		return c.searchAccounts(ctx, args[1:])
+	case command == "accounts" && len(args) > 0 && args[0] == "get":
+		return c.getAccount(ctx, args[1:])
+	case command == "sessions" && len(args) > 0 && args[0] == "revoke":
+		return c.revokeSessions(ctx, args[1:])
+	case command == "ban":
+		return c.ban(ctx, args)
+	case command == "unban":
+		return c.unban(ctx, args)
+	case command == "verification" && len(args) > 0 && args[0] == "resend":
+		return c.resendVerification(ctx, args[1:])
+	case command == "keys" && len(args) > 0 && args[0] == "list":
+		return c.listKeys(ctx, args[1:])
+	case command == "keys" && len(args) > 0 && args[0] == "rotate":
+		return c.rotateKeys(ctx, args[1:])
+	default:
+		return errUsage
+	}
+}
+
+func (c *cli) searchAccounts(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("accounts search", flag.ContinueOnError)
+	email := flags.String("email", "", "address, or part of it")
+	role := flags.String("role", "", "role code, such as ADMIN")
+	status := flags.String("status", "", "status code, such as BANNED")
+	provider := flags.String("provider", "", "provider code, such as GOOGLE")
+	limit := flags.Int("limit", 0, "accounts per page")
+	cursor := flags.String("cursor", "", "next_cursor of the previous page")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"email": *email, "role": *role, "status": *status, "provider": *provider, "cursor": *cursor,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if *limit > 0 {
+		query.Set("limit", strconv.Itoa(*limit))
+	}
+
+	var page accountPage
+	var raw json.RawMessage
+	if err := c.client.do(ctx, http.MethodGet, "/v1/admin/accounts", query, nil, &page, &raw); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(raw)
+	}
+
+	w := c.table("ID", "ROLE", "STATUS", "EMAIL", "CREATED")
+	for _, account := range page.Accounts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			account.ID, account.RoleCode, account.StatusCode, account.email(), formatTime(&account.CreatedAt))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if page.NextCursor != "" {
+		fmt.Fprintf(c.out, "\nnext page: -cursor %s\n", page.NextCursor)
+	}
+	return nil
+}
+
+func (c *cli) getAccount(ctx context.Context, args []string) error {
+	id, err := accountArg(args)
+	if err != nil {
+		return err
+	}
+
+	var account account
+	var raw json.RawMessage
+	if err := c.client.do(ctx, http.MethodGet, "/v1/admin/accounts/"+id.String(), nil, nil, &account, &raw); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(raw)
+	}
+
+	fmt.Fprintf(c.out, "ID:       %s\nRole:     %s\nStatus:   %s\nCreated:  %s\n\n",
+		account.ID, account.RoleCode, account.StatusCode, formatTime(&account.CreatedAt))
+	w := c.table("METHOD", "PROVIDER", "PROVIDER ID", "VERIFIED", "LAST LOGIN")
+	for _, method := range account.AuthMethods {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n",
+			method.ID, method.Provider, method.ProviderID, method.IsVerified, formatTime(method.LastLoginAt))
+	}
+	return w.Flush()
+}
+
+func (c *cli) revokeSessions(ctx context.Context, args []string) error {
+	id, err := accountArg(args)
+	if err != nil {
+		return err
+	}
+	if err := c.client.do(ctx, http.MethodPost, "/v1/admin/accounts/"+id.String()+"/sessions/revoke", nil, nil, nil, nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "revoked the sessions of %s\n", id)
+	return nil
+}
+
+func (c *cli) ban(ctx context.Context, args []string) error {
+	id, err := accountArg(args)
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("ban", flag.ContinueOnError)
+	reason := flags.String("reason", "", "reason recorded with the ban")
+	expires := flags.String("expires", "", "RFC 3339 time the ban is lifted at; permanent when empty")
+	if err := flags.Parse(args[1:]); err != nil || *reason == "" {
+		return errUsage
+	}
+
+	body := struct {
+		Reason    string     `json:"reason"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	}{Reason: *reason}
+	if *expires != "" {
+		expiresAt, err := time.Parse(time.RFC3339, *expires)
+		if err != nil {
+			return fmt.Errorf("-expires: %w", err)
+		}
+		body.ExpiresAt = &expiresAt
+	}
+
+	var ban ban
+	var raw json.RawMessage
+	if err := c.client.do(ctx, http.MethodPost, "/v1/admin/accounts/"+id.String()+"/ban", nil, body, &ban, &raw); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(raw)
+	}
+	until := "permanently"
+	if ban.ExpiresAt != nil {
+		until = "until " + formatTime(ban.ExpiresAt)
+	}
+	fmt.Fprintf(c.out, "banned %s %s (ban %s)\n", ban.AccountID, until, ban.ID)
+	return nil
+}
+
+func (c *cli) unban(ctx context.Context, args []string) error {
+	id, err := accountArg(args)
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("unban", flag.ContinueOnError)
+	reason := flags.String("reason", "", "reason recorded with the lift")
+	if err := flags.Parse(args[1:]); err != nil || *reason == "" {
+		return errUsage
+	}
+
+	body := struct {
+		Reason string `json:"reason"`
+	}{Reason: *reason}
+	var ban ban
+	var raw json.RawMessage
+	if err := c.client.do(ctx, http.MethodPost, "/v1/admin/accounts/"+id.String()+"/unban", nil, body, &ban, &raw); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(raw)
+	}
+	fmt.Fprintf(c.out, "lifted ban %s of %s, back to %s\n", ban.ID, ban.AccountID, ban.PreviousStatus)
+	return nil
+}
+
+func (c *cli) resendVerification(ctx context.Context, args []string) error {
+	id, err := accountArg(args)
+	if err != nil {
+		return err
+	}
+
+	var issued codeIssued
+	var raw json.RawMessage
+	if err := c.client.do(ctx, http.MethodPost, "/v1/admin/accounts/"+id.String()+"/verification/resend", nil, nil, &issued, &raw); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(raw)
+	}
+	fmt.Fprintf(c.out, "sent a new verification code to %s, valid for %s\n", id, time.Duration(issued.ExpiresIn)*time.Second)
+	return nil
+}
+
+func (c *cli) listKeys(ctx context.Context, args []string) error {
+	path, err := keysPath("keys list", args)
+	if err != nil {
+		return err
+	}
+	return c.signingKeys(ctx, http.MethodGet, path)
+}
+
+func (c *cli) rotateKeys(ctx context.Context, args []string) error {
+	path, err := keysPath("keys rotate", args)
+	if err != nil {
+		return err
+	}
+	return c.signingKeys(ctx, http.MethodPost, path+"/rotate")
+}
+
+func (c *cli) signingKeys(ctx context.Context, method, path string) error {
+	var keys signingKeys
+	var raw json.RawMessage
+	if err := c.client.do(ctx, method, path, nil, nil, &keys, &raw); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(raw)
+	}
+
+	w := c.table("KID", "ALG", "ACTIVATES", "RETIRED", "EXPIRES")
+	for _, key := range keys.Keys {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Algorithm, formatTime(&key.ActivatesAt), formatTime(key.RetiredAt), formatTime(key.ExpiresAt))
+	}
+	return w.Flush()
+}
+
+// keysPath is the path of the platform signing keys, or of the keys of the
+// organization named by -org.
+func keysPath(name string, args []string) (string, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	org := flags.String("org", "", "organization whose keys to manage instead of the platform's")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return "", errUsage
+	}
+	if *org == "" {
+		return "/v1/admin/signing-keys", nil
+	}
+	id, err := uuid.Parse(*org)
+	if err != nil {
+		return "", fmt.Errorf("-org: %w", err)
+	}
+	return "/v1/admin/organizations/" + id.String() + "/signing-keys", nil
+}
+
+// accountArg parses the account ID every account command starts with.
+func accountArg(args []string) (uuid.UUID, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return uuid.Nil, errUsage
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("account ID: %w", err)
+	}
+	return id, nil
+}
+
+func (c *cli) table(columns ...string) *tabwriter.Writer {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+	return w
+}
+
+// printJSON indents the JSON answered by the service.
+func (c *cli) printJSON(raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+// email is the address of the account's EMAIL method, if it has one.
+func (a account) email() string {
+	for _, method := range a.AuthMethods {
+		if method.Provider == "EMAIL" {
+			return method.ProviderID
+		}
+	}
+	return "-"
+}
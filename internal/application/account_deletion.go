@@ -0,0 +1,266 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// DeleteAccount makes an ACTIVE account DELETED on its holder's request and
+// ends its sessions. The account keeps its address and can be restored
+// through its verified EMAIL method until the grace period ends, when
+// PurgeDeletedAccounts deletes it for good.
+func (s *AuthService) DeleteAccount(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletion, error) {
+	now := time.Now().UTC()
+	deletion := &models.AccountDeletion{
+		AccountID: accountID,
+		PurgeAt:   now.Add(s.deletionGracePeriod),
+	}
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.accounts.GetByIDForUpdate(txCtx, accountID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if account.StatusCode != domain.StatusActive {
+			return domain.ErrInvalidAccountState
+		}
+
+		if err := s.deletions.Create(txCtx, deletion); err != nil {
+			return err
+		}
+		if err := s.accounts.UpdateStatus(txCtx, accountID, domain.StatusDeleted); err != nil {
+			return err
+		}
+		if _, err := s.refreshTokens.RevokeAllByAccountID(txCtx, accountID, now); err != nil {
+			return err
+		}
+
+		details := map[string]string{"purge_at": deletion.PurgeAt.Format(time.RFC3339)}
+		if err := s.audit.record(txCtx, domain.AuditAccountDeleted, accountID, accountID, details); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountStatusChangedEvent{
+			AccountID: accountID,
+			Previous:  string(domain.StatusActive),
+			Status:    string(domain.StatusDeleted),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.denylist.DenyAccount(ctx, accountID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", accountID, "error", err)
+	}
+
+	event := events.AccountDeletedEvent{AccountID: accountID, PurgeAt: deletion.PurgeAt}
+	if method, err := s.verifiedEmailMethod(ctx, accountID); err == nil {
+		event.Email = method.ProviderID
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		slog.ErrorContext(ctx, "read email of deleted account", "account_id", accountID, "error", err)
+	}
+	publish(ctx, s.eventBus, event)
+	return deletion, nil
+}
+
+// RequestAccountRestore emails a restore code to the verified EMAIL method of
+// an account deleted by its holder, while it can still be restored. Other
+// emails get the same response, so the endpoint cannot be used to discover
+// deleted accounts.
+func (s *AuthService) RequestAccountRestore(ctx context.Context, email string) (*CodeIssuedResult, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	result := &CodeIssuedResult{ExpiresIn: domain.AccountRestoreCodeTTL}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !method.IsVerified {
+		return result, nil
+	}
+	if err := s.checkRestorable(ctx, method.AccountID, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrInvalidAccountState) {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	var code string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		code, err = s.issueVerificationCode(txCtx, method.ID, domain.PurposeAccountRestore, domain.AccountRestoreCodeTTL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.AccountRestoreRequestedEvent{
+		AccountID: method.AccountID,
+		Email:     email,
+		Code:      code,
+		ExpiresIn: int(domain.AccountRestoreCodeTTL.Seconds()),
+	})
+
+	return result, nil
+}
+
+// RestoreAccount consumes a restore code and makes the deleted account
+// ACTIVE again, cancelling its purge. The account then signs in as before.
+func (s *AuthService) RestoreAccount(ctx context.Context, email, code string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return domain.ErrInvalidOrExpiredCode
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidOrExpiredCode
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.checkRestorable(ctx, method.AccountID, time.Now()); err != nil {
+		return err
+	}
+	if err := s.lockout.check(method); err != nil {
+		return err
+	}
+
+	verification, err := s.checkVerificationCode(ctx, method, domain.PurposeAccountRestore, code)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	return s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.verificationCodes.MarkConsumed(txCtx, verification.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidOrExpiredCode
+			}
+			return err
+		}
+
+		// Locked, then checked again, so a restore cannot race the purge.
+		if _, err := s.accounts.GetByIDForUpdate(txCtx, method.AccountID); err != nil {
+			return err
+		}
+		if err := s.checkRestorable(txCtx, method.AccountID, now); err != nil {
+			return err
+		}
+		if err := s.deletions.Delete(txCtx, method.AccountID); err != nil {
+			return err
+		}
+		if err := s.accounts.UpdateStatus(txCtx, method.AccountID, domain.StatusActive); err != nil {
+			return err
+		}
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+
+		if err := s.audit.record(txCtx, domain.AuditAccountRestored, method.AccountID, method.AccountID, nil); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountStatusChangedEvent{
+			AccountID: method.AccountID,
+			Previous:  string(domain.StatusDeleted),
+			Status:    string(domain.StatusActive),
+		})
+	})
+}
+
+// PurgeDeletedAccounts deletes for good the accounts whose grace period ended
+// at now and returns how many it deleted. Accounts restored concurrently are
+// skipped.
+func (s *AuthService) PurgeDeletedAccounts(ctx context.Context, now time.Time) (int, error) {
+	purged := 0
+	for {
+		due, err := s.deletions.ListDue(ctx, now, domain.AccountPurgeBatch)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, deletion := range due {
+			err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+				if _, err := s.accounts.GetByIDForUpdate(txCtx, deletion.AccountID); err != nil {
+					return err
+				}
+				if _, err := s.deletions.GetByAccountID(txCtx, deletion.AccountID); err != nil {
+					return err
+				}
+				if err := s.accounts.Delete(txCtx, deletion.AccountID); err != nil {
+					return err
+				}
+
+				if err := s.audit.record(txCtx, domain.AuditAccountPurged, uuid.Nil, deletion.AccountID, nil); err != nil {
+					return err
+				}
+				return publishWithin(txCtx, s.eventBus, events.AccountPurgedEvent{AccountID: deletion.AccountID})
+			})
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+
+			purged++
+		}
+		if len(due) < domain.AccountPurgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// checkRestorable fails with ErrInvalidAccountState unless the account was
+// deleted by its holder and its grace period has not ended at now.
+func (s *AuthService) checkRestorable(ctx context.Context, accountID uuid.UUID, now time.Time) error {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account.StatusCode != domain.StatusDeleted {
+		return domain.ErrInvalidAccountState
+	}
+
+	deletion, err := s.deletions.GetByAccountID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidAccountState
+	}
+	if err != nil {
+		return err
+	}
+	if !now.Before(deletion.PurgeAt) {
+		return domain.ErrInvalidAccountState
+	}
+	return nil
+}
+
+// verifiedEmailMethod returns the verified EMAIL method of an account, or
+// domain.ErrNotFound when it has none.
+func (s *AuthService) verifiedEmailMethod(ctx context.Context, accountID uuid.UUID) (*models.AuthMethod, error) {
+	methods, err := s.authMethods.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		if method.ProviderCode == domain.ProviderEmail && method.IsVerified {
+			return method, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
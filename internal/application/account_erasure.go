@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/google/uuid"
+)
+
+// EraseAccount anonymizes for good the personal data held about the account
+// of its holder, who is signed out everywhere. Unlike DeleteAccount there is
+// no grace period: the account is DELETED at once and cannot be restored.
+func (s *AuthService) EraseAccount(ctx context.Context, accountID uuid.UUID) error {
+	return s.erase(ctx, accountID, accountID)
+}
+
+// EraseAccountByAdmin is EraseAccount on an administrator's request, such as
+// a right to be forgotten request received out of band. Administrators
+// erase their own account through EraseAccount.
+func (s *AuthService) EraseAccountByAdmin(ctx context.Context, adminID, accountID uuid.UUID) error {
+	if adminID == accountID {
+		return domain.ErrCannotEraseSelf
+	}
+	return s.erase(ctx, adminID, accountID)
+}
+
+// erase keeps the account, its audit events and the rows referring to it,
+// so aggregates and foreign keys still hold, while what identifies its
+// holder is overwritten or deleted by AccountRepository.Erase.
+func (s *AuthService) erase(ctx context.Context, actorID, accountID uuid.UUID) error {
+	now := time.Now().UTC()
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.accounts.GetByIDForUpdate(txCtx, accountID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		// Recorded first, so the erasure clears the origin of its own entry.
+		if err := s.audit.record(txCtx, domain.AuditAccountErased, actorID, accountID, nil); err != nil {
+			return err
+		}
+		if _, err := s.refreshTokens.RevokeAllByAccountID(txCtx, accountID, now); err != nil {
+			return err
+		}
+		if err := s.accounts.Erase(txCtx, accountID); err != nil {
+			return err
+		}
+
+		if account.StatusCode != domain.StatusDeleted {
+			if err := s.accounts.UpdateStatus(txCtx, accountID, domain.StatusDeleted); err != nil {
+				return err
+			}
+			if err := publishWithin(txCtx, s.eventBus, events.AccountStatusChangedEvent{
+				AccountID: accountID,
+				Previous:  string(account.StatusCode),
+				Status:    string(domain.StatusDeleted),
+			}); err != nil {
+				return err
+			}
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountErasedEvent{AccountID: accountID})
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.denylist.DenyAccount(ctx, accountID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", accountID, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// accountExportBatch is how many accounts Export reads at once.
+const accountExportBatch = 500
+
+// AccountService looks accounts up on behalf of other services, and searches
+// and exports them for administrators.
+type AccountService struct {
+	accounts    repositories.AccountRepository
+	authMethods repositories.AuthMethodRepository
+}
+
+func NewAccountService(accounts repositories.AccountRepository, authMethods repositories.AuthMethodRepository) *AccountService {
+	return &AccountService{accounts: accounts, authMethods: authMethods}
+}
+
+// ExportedAccount is an account with its sign-in methods. It carries no
+// secrets.
+type ExportedAccount struct {
+	Account     *models.Account
+	AuthMethods []*models.AuthMethod
+}
+
+func (s *AccountService) Get(ctx context.Context, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	return account, err
+}
+
+// Lookup returns an account with its auth methods.
+func (s *AccountService) Lookup(ctx context.Context, accountID uuid.UUID) (*ExportedAccount, error) {
+	account, err := s.Get(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	exported, err := s.withAuthMethods(ctx, []*models.Account{account})
+	if err != nil {
+		return nil, err
+	}
+	return exported[0], nil
+}
+
+// AccountPage is a page of search results. Next is the cursor of the page
+// that follows, nil on the last page.
+type AccountPage struct {
+	Accounts []*ExportedAccount
+	Next     *models.AccountCursor
+}
+
+// Search returns the accounts matching filter that follow after, in the
+// order of models.AccountCursor, with their auth methods. The order is
+// stable, so the cursor of a page always leads to the next one.
+func (s *AccountService) Search(ctx context.Context, filter models.AccountFilter, after models.AccountCursor, limit int) (*AccountPage, error) {
+	if limit <= 0 {
+		limit = domain.AccountSearchDefaultLimit
+	}
+	limit = min(limit, domain.AccountSearchMaxLimit)
+	filter.Email = strings.ToLower(strings.TrimSpace(filter.Email))
+
+	// One more than a page tells whether a next page exists.
+	accounts, err := s.accounts.Search(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &AccountPage{}
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+		next := accounts[limit-1].Cursor()
+		page.Next = &next
+	}
+	if page.Accounts, err = s.withAuthMethods(ctx, accounts); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// Export calls each with every account following after, in the order of
+// models.AccountCursor, so an interrupted export resumes from the cursor of
+// the last account it received. Accounts are read in batches rather than in
+// one snapshot: accounts created meanwhile are exported at the end.
+func (s *AccountService) Export(ctx context.Context, after models.AccountCursor, each func(*ExportedAccount) error) error {
+	for {
+		accounts, err := s.accounts.ListAfter(ctx, after, accountExportBatch)
+		if err != nil {
+			return err
+		}
+		if len(accounts) == 0 {
+			return nil
+		}
+
+		exported, err := s.withAuthMethods(ctx, accounts)
+		if err != nil {
+			return err
+		}
+		for _, account := range exported {
+			if err := each(account); err != nil {
+				return err
+			}
+		}
+		if len(accounts) < accountExportBatch {
+			return nil
+		}
+		after = accounts[len(accounts)-1].Cursor()
+	}
+}
+
+// withAuthMethods reads the auth methods of accounts in one query.
+func (s *AccountService) withAuthMethods(ctx context.Context, accounts []*models.Account) ([]*ExportedAccount, error) {
+	ids := make([]uuid.UUID, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+	methods, err := s.authMethods.ListByAccountIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byAccount := make(map[uuid.UUID][]*models.AuthMethod, len(accounts))
+	for _, method := range methods {
+		byAccount[method.AccountID] = append(byAccount[method.AccountID], method)
+	}
+
+	exported := make([]*ExportedAccount, 0, len(accounts))
+	for _, account := range accounts {
+		exported = append(exported, &ExportedAccount{Account: account, AuthMethods: byAccount[account.ID]})
+	}
+	return exported, nil
+}
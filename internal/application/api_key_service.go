@@ -0,0 +1,197 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// CreatedAPIKey is a new API key. Key is the plaintext key, which is shown
+// once and cannot be recovered afterwards.
+type CreatedAPIKey struct {
+	APIKey *models.APIKey
+	Key    string
+}
+
+// APIKeyService manages the API keys of account holders and resolves keys
+// presented in place of access tokens.
+type APIKeyService struct {
+	apiKeys  repositories.APIKeyRepository
+	accounts repositories.AccountRepository
+	roles    repositories.RoleRepository
+	eventBus ports.EventBus
+}
+
+func NewAPIKeyService(apiKeys repositories.APIKeyRepository, accounts repositories.AccountRepository, roles repositories.RoleRepository, eventBus ports.EventBus) *APIKeyService {
+	return &APIKeyService{apiKeys: apiKeys, accounts: accounts, roles: roles, eventBus: eventBus}
+}
+
+// Create issues a key for the account. A key without scopes acts with the
+// full rights of the account; scoped keys are meant for services that check
+// scope. Keys never expire unless expiresAt is set.
+func (s *APIKeyService) Create(ctx context.Context, accountID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > domain.MaxAPIKeyNameLength {
+		return nil, domain.ErrInvalidAPIKeyName
+	}
+
+	normalized, err := requestedScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, domain.ErrInvalidAPIKeyExpiry
+	}
+
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+
+	active, err := s.apiKeys.CountActive(ctx, accountID, now)
+	if err != nil {
+		return nil, err
+	}
+	if active >= domain.MaxAPIKeysPerAccount {
+		return nil, domain.ErrAPIKeyLimitReached
+	}
+
+	secret, err := security.GenerateOpaqueToken(domain.APIKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	plain := domain.APIKeyPrefix + secret
+
+	key := &models.APIKey{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      name,
+		KeyHash:   security.HashToken(plain),
+		KeyPrefix: plain[:domain.APIKeyDisplayLength],
+		Scopes:    normalized,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.apiKeys.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.APIKeyCreatedEvent{
+		AccountID: accountID,
+		APIKeyID:  key.ID,
+	})
+
+	return &CreatedAPIKey{APIKey: key, Key: plain}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, accountID uuid.UUID) ([]*models.APIKey, error) {
+	return s.apiKeys.ListByAccountID(ctx, accountID)
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, accountID, apiKeyID uuid.UUID) error {
+	err := s.apiKeys.Revoke(ctx, apiKeyID, accountID, time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	publish(ctx, s.eventBus, events.APIKeyRevokedEvent{
+		AccountID: accountID,
+		APIKeyID:  apiKeyID,
+	})
+
+	return nil
+}
+
+// Authenticate resolves a key into the claims of its account, as if it were
+// an access token without a session. Unknown keys are reported as
+// ErrAPIKeyNotFound, revoked and expired keys and keys of accounts that are
+// no longer active as ErrInvalidAccessToken.
+func (s *APIKeyService) Authenticate(ctx context.Context, raw string) (*models.AccessTokenClaims, error) {
+	key, err := s.apiKeys.GetByKeyHash(ctx, security.HashToken(raw))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if !key.Usable(now) {
+		return nil, domain.ErrInvalidAccessToken
+	}
+
+	account, err := s.accounts.GetByID(ctx, key.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccessToken
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= domain.APIKeyUsageResolution {
+		if err := s.apiKeys.RecordUse(ctx, key.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	permissions, err := s.roles.ListPermissions(ctx, account.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &models.AccessTokenClaims{
+		TokenID:     key.ID.String(),
+		AccountID:   account.ID,
+		RoleCode:    account.RoleCode,
+		StatusCode:  account.StatusCode,
+		Scope:       domain.TokenScope(strings.Join(key.Scopes, " ")),
+		Permissions: permissions,
+		APIKeyID:    key.ID,
+		IssuedAt:    key.CreatedAt,
+	}
+	if key.ExpiresAt != nil {
+		claims.ExpiresAt = *key.ExpiresAt
+	}
+	return claims, nil
+}
+
+// requestedScopes checks and deduplicates the scopes requested for an API key
+// or a session, which follow the scope-token syntax of RFC 6749 section 3.3.
+// The restricted scopes of this service's own tokens cannot be requested.
+func requestedScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		restricted := domain.TokenScope(scope) == domain.TokenScopeMFAEnrollment || domain.TokenScope(scope) == domain.TokenScopePasswordChange ||
+			domain.TokenScope(scope) == domain.TokenScopeConsent
+		if scope == "" || restricted || strings.ContainsFunc(scope, func(r rune) bool {
+			return r <= ' ' || r == '"' || r == '\\' || r > '~'
+		}) {
+			return nil, domain.ErrInvalidScope
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) > domain.MaxAPIKeyScopes {
+		return nil, domain.ErrInvalidScope
+	}
+	return normalized, nil
+}
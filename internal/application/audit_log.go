@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// RequestOrigin is where a request comes from. Transports attach it to the
+// context of every request, so the audit log records it with the actions the
+// request performs.
+type RequestOrigin struct {
+	IPAddress string
+	UserAgent string
+}
+
+type originKey struct{}
+
+// WithRequestOrigin returns a copy of ctx carrying origin.
+func WithRequestOrigin(ctx context.Context, origin RequestOrigin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+func requestOrigin(ctx context.Context) RequestOrigin {
+	origin, _ := ctx.Value(originKey{}).(RequestOrigin)
+	return origin
+}
+
+// securityActivityActions are the actions accounts see in their own security
+// activity, and securityActivityDetails the details they see of them. The
+// others concern administrators, or are too frequent to be of interest.
+var (
+	securityActivityActions = []domain.AuditAction{
+		domain.AuditLoginSucceeded,
+		domain.AuditLoginFailed,
+		domain.AuditPasswordChanged,
+		domain.AuditPasswordReset,
+		domain.AuditMFAEnrolled,
+		domain.AuditMFADisabled,
+		domain.AuditAccountDeleted,
+		domain.AuditAccountRestored,
+		domain.AuditDataExportRequested,
+		domain.AuditConsentAccepted,
+	}
+	securityActivityDetails = []string{"provider", "factor"}
+)
+
+// AuditLog appends security-relevant actions to the append-only audit log,
+// with who performed them, on which account and from where, and reads them
+// back for administrators and for the accounts they concern.
+type AuditLog struct {
+	txManager ports.TxManager
+	events    repositories.AuditEventRepository
+	// stream, when set, receives every event once it is committed.
+	stream ports.AuditStream
+}
+
+func NewAuditLog(txManager ports.TxManager, events repositories.AuditEventRepository, stream ports.AuditStream) *AuditLog {
+	return &AuditLog{txManager: txManager, events: events, stream: stream}
+}
+
+// record appends an action of actorID on targetID, either of which is
+// uuid.Nil when there is none or it is unknown. Actions that change state
+// record inside their transaction, so the entry commits with the change and
+// a change that cannot be recorded does not happen.
+func (l *AuditLog) record(ctx context.Context, action domain.AuditAction, actorID, targetID uuid.UUID, details map[string]string) error {
+	origin := requestOrigin(ctx)
+	event := &models.AuditEvent{
+		ID:        uuid.New(),
+		Action:    action,
+		ActorID:   auditAccount(actorID),
+		TargetID:  auditAccount(targetID),
+		IPAddress: optional(origin.IPAddress),
+		UserAgent: optional(origin.UserAgent),
+		Details:   details,
+	}
+	if err := l.events.Append(ctx, event); err != nil {
+		return err
+	}
+
+	if l.stream != nil {
+		l.txManager.AfterCommit(ctx, func(context.Context) { l.stream.Send(event) })
+	}
+	return nil
+}
+
+// recordFailure appends a failed attempt, which has no change to commit with
+// it. Write failures are logged rather than returned, so they do not hide
+// the error of the attempt.
+func (l *AuditLog) recordFailure(ctx context.Context, action domain.AuditAction, actorID, targetID uuid.UUID, cause error, details map[string]string) {
+	if details == nil {
+		details = map[string]string{}
+	}
+	details["error"] = cause.Error()
+	if err := l.record(ctx, action, actorID, targetID, details); err != nil {
+		slog.ErrorContext(ctx, "record audit event", "action", action, "error", err)
+	}
+}
+
+func auditAccount(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// AuditEventPage is a page of audit events. Next is the cursor of the page
+// that follows, nil on the last page.
+type AuditEventPage struct {
+	Events []*models.AuditEvent
+	Next   *models.AuditEventCursor
+}
+
+// Search returns the events matching filter that follow after, newest first
+// in the order of models.AuditEventCursor.
+func (l *AuditLog) Search(ctx context.Context, filter models.AuditEventFilter, after models.AuditEventCursor, limit int) (*AuditEventPage, error) {
+	if limit <= 0 {
+		limit = domain.AuditEventDefaultLimit
+	}
+	limit = min(limit, domain.AuditEventMaxLimit)
+
+	// One more than a page tells whether a next page exists.
+	events, err := l.events.Search(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &AuditEventPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		next := page.Events[limit-1].Cursor()
+		page.Next = &next
+	}
+	return page, nil
+}
+
+// SecurityActivity returns the recent sign-ins, failed ones included, and
+// changes of the password and second factors of an account, newest first.
+// Events carry only the details meant for the account holder, and no actor.
+func (l *AuditLog) SecurityActivity(ctx context.Context, accountID uuid.UUID, after models.AuditEventCursor, limit int) (*AuditEventPage, error) {
+	page, err := l.Search(ctx, models.AuditEventFilter{
+		TargetID:    accountID,
+		Actions:     securityActivityActions,
+		CreatedFrom: time.Now().UTC().Add(-domain.SecurityActivityPeriod),
+	}, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range page.Events {
+		details := make(map[string]string)
+		for _, key := range securityActivityDetails {
+			if value, ok := event.Details[key]; ok {
+				details[key] = value
+			}
+		}
+		event.ActorID, event.Details = nil, details
+	}
+	return page, nil
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// AuthMethodService manages the sign-in methods attached to an account.
+// Linking new methods happens through the flow that proves the identity, such
+// as OAuthService.Link.
+type AuthMethodService struct {
+	txManager   ports.TxManager
+	accounts    repositories.AccountRepository
+	authMethods repositories.AuthMethodRepository
+	eventBus    ports.EventBus
+}
+
+func NewAuthMethodService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	eventBus ports.EventBus,
+) *AuthMethodService {
+	return &AuthMethodService{
+		txManager:   txManager,
+		accounts:    accounts,
+		authMethods: authMethods,
+		eventBus:    eventBus,
+	}
+}
+
+func (s *AuthMethodService) List(ctx context.Context, accountID uuid.UUID) ([]*models.AuthMethod, error) {
+	return s.authMethods.ListByAccountID(ctx, accountID)
+}
+
+// Unlink removes a method from the account. The account row is locked so two
+// concurrent unlinks cannot remove its last verified method.
+func (s *AuthMethodService) Unlink(ctx context.Context, accountID, methodID uuid.UUID) error {
+	var removed *models.AuthMethod
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.accounts.GetByIDForUpdate(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+		if account.StatusCode != domain.StatusActive {
+			return domain.ErrInvalidAccountState
+		}
+
+		methods, err := s.authMethods.ListByAccountID(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+
+		remainingVerified := 0
+		for _, method := range methods {
+			if method.ID == methodID {
+				removed = method
+			} else if method.IsVerified {
+				remainingVerified++
+			}
+		}
+		if removed == nil {
+			return domain.ErrAuthMethodNotFound
+		}
+		if remainingVerified == 0 {
+			return domain.ErrLastAuthMethod
+		}
+
+		return s.authMethods.Delete(txCtx, methodID)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrAuthMethodNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	publish(ctx, s.eventBus, events.AuthMethodUnlinkedEvent{
+		AccountID:    accountID,
+		AuthMethodID: removed.ID,
+		Provider:     string(removed.ProviderCode),
+	})
+
+	return nil
+}
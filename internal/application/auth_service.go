@@ -0,0 +1,1281 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+type AuthService struct {
+	txManager           ports.TxManager
+	accounts            repositories.AccountRepository
+	authMethods         repositories.AuthMethodRepository
+	verificationCodes   repositories.VerificationCodeRepository
+	refreshTokens       repositories.RefreshTokenRepository
+	passwordCredentials repositories.PasswordCredentialRepository
+	deletions           repositories.AccountDeletionRepository
+	// deletionGracePeriod is how long accounts deleted by their holder can
+	// be restored.
+	deletionGracePeriod time.Duration
+	passwords           ports.PasswordHasher
+	passwordPolicy      atomic.Pointer[PasswordPolicy]
+	passwordHistory     *PasswordHistory
+	sessions            *SessionIssuer
+	denylist            ports.AccessTokenDenylist
+	lockout             *Lockout
+	captcha             *CaptchaGuard
+	disposableEmails    *DisposableEmailGuard
+	breachedPasswords   *BreachedPasswordGuard
+	metrics             ports.Metrics
+	audit               *AuditLog
+	eventBus            ports.EventBus
+}
+
+func NewAuthService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	verificationCodes repositories.VerificationCodeRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+	passwordCredentials repositories.PasswordCredentialRepository,
+	deletions repositories.AccountDeletionRepository,
+	deletionGracePeriod time.Duration,
+	sessions *SessionIssuer,
+	passwords ports.PasswordHasher,
+	passwordPolicy PasswordPolicy,
+	passwordHistory *PasswordHistory,
+	denylist ports.AccessTokenDenylist,
+	lockout *Lockout,
+	captcha *CaptchaGuard,
+	disposableEmails *DisposableEmailGuard,
+	breachedPasswords *BreachedPasswordGuard,
+	metrics ports.Metrics,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *AuthService {
+	service := &AuthService{
+		txManager:           txManager,
+		accounts:            accounts,
+		authMethods:         authMethods,
+		verificationCodes:   verificationCodes,
+		refreshTokens:       refreshTokens,
+		passwordCredentials: passwordCredentials,
+		deletions:           deletions,
+		deletionGracePeriod: deletionGracePeriod,
+		passwords:           passwords,
+		passwordHistory:     passwordHistory,
+		sessions:            sessions,
+		denylist:            denylist,
+		lockout:             lockout,
+		captcha:             captcha,
+		disposableEmails:    disposableEmails,
+		breachedPasswords:   breachedPasswords,
+		metrics:             metrics,
+		audit:               audit,
+		eventBus:            eventBus,
+	}
+	service.SetPasswordPolicy(passwordPolicy)
+	return service
+}
+
+// SetPasswordPolicy changes the rules passwords set from now on must follow.
+func (s *AuthService) SetPasswordPolicy(policy PasswordPolicy) {
+	s.passwordPolicy.Store(&policy)
+}
+
+type CodeIssuedResult struct {
+	ExpiresIn time.Duration
+	// PasswordBreached warns that the chosen password appears in a data
+	// breach.
+	PasswordBreached bool
+}
+
+// PasswordStrengthResult rates a candidate password. Violations lists the
+// password policy rules it breaks, the minimum score included; a password
+// without any is accepted unless it was used recently or, when breached
+// passwords are blocked, appears in a breach.
+type PasswordStrengthResult struct {
+	Strength   *models.PasswordStrength
+	MinScore   int
+	Violations []domain.FieldError
+}
+
+// EstimatePasswordStrength rates password against the password policy, for
+// the account with address email when it is not empty.
+func (s *AuthService) EstimatePasswordStrength(password, email string) (*PasswordStrengthResult, error) {
+	if email != "" {
+		var err error
+		if email, err = normalizeEmail(email); err != nil {
+			return nil, err
+		}
+	}
+
+	policy := s.passwordPolicy.Load()
+	result := &PasswordStrengthResult{
+		Strength: policy.Estimate(password, email),
+		MinScore: policy.MinScore,
+	}
+	if err := policy.Validate(password, email); err != nil {
+		var invalid *domain.ValidationError
+		if !errors.As(err, &invalid) {
+			return nil, err
+		}
+		result.Violations = invalid.Fields
+	}
+	return result, nil
+}
+
+// RegisterWithEmail creates a PENDING account with an unverified EMAIL method
+// and issues the confirmation code that activates it. The password is
+// optional; without one the account signs in with emailed login codes only.
+// Addresses of disposable email domains are refused or flagged in the
+// registration event, as configured.
+func (s *AuthService) RegisterWithEmail(ctx context.Context, email, password string, client ClientInfo) (*CodeIssuedResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.RegisterWithEmail")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	disposable, err := s.disposableEmails.check(email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.captcha.register(ctx, client); err != nil {
+		return nil, err
+	}
+
+	var (
+		passwordHash string
+		breached     bool
+	)
+	if password != "" {
+		if err := s.passwordPolicy.Load().Validate(password, email); err != nil {
+			return nil, err
+		}
+		if breached, err = s.breachedPasswords.check(ctx, password); err != nil {
+			return nil, err
+		}
+		if passwordHash, err = s.passwords.Hash(password); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	switch {
+	case err == nil:
+		if err := s.checkReRegistration(ctx, existing); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		existing = nil
+	default:
+		return nil, err
+	}
+
+	var account *models.Account
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if existing != nil {
+			if _, err := s.expirePending(txCtx, existing, domain.AccountExpiredReRegistered); err != nil {
+				return err
+			}
+		}
+
+		account = &models.Account{
+			ID:         uuid.New(),
+			RoleCode:   domain.RoleUser,
+			StatusCode: domain.StatusPending,
+		}
+		if err := s.accounts.Create(txCtx, account); err != nil {
+			return err
+		}
+
+		method := &models.AuthMethod{
+			ID:           uuid.New(),
+			AccountID:    account.ID,
+			ProviderCode: domain.ProviderEmail,
+			ProviderID:   email,
+			IsVerified:   false,
+		}
+		if err := s.authMethods.Create(txCtx, method); err != nil {
+			return err
+		}
+
+		if passwordHash != "" {
+			err := s.passwordCredentials.Create(txCtx, &models.PasswordCredential{
+				AuthMethodID: method.ID,
+				PasswordHash: passwordHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		code, err := s.issueVerificationCode(txCtx, method.ID, domain.PurposeEmailVerification, domain.VerificationCodeTTL)
+		if err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.UserRegisteredEvent{
+			AccountID:       account.ID,
+			Email:           email,
+			Code:            code,
+			ExpiresIn:       int(domain.VerificationCodeTTL.Seconds()),
+			DisposableEmail: disposable,
+			Organization:    client.Organization,
+		})
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrAccountAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if breached {
+		publish(ctx, s.eventBus, events.PasswordBreachedEvent{AccountID: account.ID, Email: email})
+	}
+
+	return &CodeIssuedResult{ExpiresIn: domain.VerificationCodeTTL, PasswordBreached: breached}, nil
+}
+
+// checkReRegistration lets an address register again while its account is
+// PENDING and every confirmation code sent to it has expired, replacing an
+// abandoned or mistyped registration. A code still pending keeps the
+// registration, so a second one cannot swap the password of a registration
+// its owner is about to confirm.
+func (s *AuthService) checkReRegistration(ctx context.Context, method *models.AuthMethod) error {
+	if method.IsVerified {
+		return domain.ErrAccountAlreadyExists
+	}
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return err
+	}
+	if account.StatusCode != domain.StatusPending {
+		return domain.ErrAccountAlreadyExists
+	}
+
+	latest, err := s.verificationCodes.GetLatestByAuthMethodID(ctx, method.ID, domain.PurposeEmailVerification)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if latest.ConsumedAt == nil && latest.ExpiresAt.After(time.Now().UTC()) {
+		return domain.ErrAccountAlreadyExists
+	}
+	return nil
+}
+
+// ExpirePendingAccounts deletes the accounts that were created before
+// createdBefore and are still PENDING, and returns how many it deleted.
+// Each is deleted in a transaction of its own, which publishes an
+// AccountExpiredEvent.
+func (s *AuthService) ExpirePendingAccounts(ctx context.Context, createdBefore time.Time) (int, error) {
+	filter := models.AccountFilter{Status: domain.StatusPending, CreatedBefore: createdBefore}
+	expired := 0
+	var after models.AccountCursor
+	for {
+		accounts, err := s.accounts.Search(ctx, filter, after, domain.PendingAccountExpiryBatch)
+		if err != nil {
+			return expired, err
+		}
+		for _, account := range accounts {
+			methods, err := s.authMethods.ListByAccountID(ctx, account.ID)
+			if err != nil {
+				return expired, err
+			}
+			var method *models.AuthMethod
+			for _, m := range methods {
+				if m.ProviderCode == domain.ProviderEmail {
+					method = m
+				}
+			}
+			if method == nil {
+				continue
+			}
+
+			var deleted bool
+			err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+				deleted, err = s.expirePending(txCtx, method, domain.AccountExpiredUnverified)
+				return err
+			})
+			if err != nil {
+				return expired, err
+			}
+			if deleted {
+				expired++
+			}
+		}
+		if len(accounts) < domain.PendingAccountExpiryBatch {
+			return expired, nil
+		}
+		after = accounts[len(accounts)-1].Cursor()
+	}
+}
+
+// expirePending deletes the account of method, unless it has left PENDING or
+// is gone already, and publishes an AccountExpiredEvent within txCtx's
+// transaction. It reports whether it deleted the account.
+func (s *AuthService) expirePending(txCtx context.Context, method *models.AuthMethod, reason domain.AccountExpiryReason) (bool, error) {
+	account, err := s.accounts.GetByIDForUpdate(txCtx, method.AccountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if account.StatusCode != domain.StatusPending {
+		return false, nil
+	}
+
+	if err := s.accounts.Delete(txCtx, account.ID); err != nil {
+		return false, err
+	}
+	return true, publishWithin(txCtx, s.eventBus, events.AccountExpiredEvent{
+		AccountID: account.ID,
+		Email:     method.ProviderID,
+		Reason:    string(reason),
+	})
+}
+
+// ResendVerification issues a new confirmation code for the unverified EMAIL
+// method of a PENDING account and emails it, replacing the code sent at
+// registration. Administrators use it for users whose code expired or never
+// arrived. It fails with ErrVerificationResendThrottled within the cooldown
+// or past the daily cap of the method.
+func (s *AuthService) ResendVerification(ctx context.Context, accountID uuid.UUID) (*CodeIssuedResult, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusPending {
+		return nil, domain.ErrInvalidAccountState
+	}
+
+	methods, err := s.authMethods.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	var method *models.AuthMethod
+	for _, m := range methods {
+		if m.ProviderCode == domain.ProviderEmail && !m.IsVerified {
+			method = m
+		}
+	}
+	if method == nil {
+		return nil, domain.ErrInvalidAccountState
+	}
+
+	if err := s.resendVerificationCode(ctx, method); err != nil {
+		return nil, err
+	}
+	return &CodeIssuedResult{ExpiresIn: domain.VerificationCodeTTL}, nil
+}
+
+// ResendVerificationEmail is ResendVerification for the owner of email,
+// who has not received or has lost the code sent at registration. Like
+// ForgotPassword, it answers the same whether or not a PENDING account
+// awaits the code and whether or not the resend was throttled, so it does
+// not reveal which addresses are registered.
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, email string) (*CodeIssuedResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ResendVerificationEmail")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	result := &CodeIssuedResult{ExpiresIn: domain.VerificationCodeTTL}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if method.IsVerified {
+		return result, nil
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusPending {
+		return result, nil
+	}
+
+	err = s.resendVerificationCode(ctx, method)
+	if err != nil && !errors.Is(err, domain.ErrVerificationResendThrottled) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resendVerificationCode replaces the confirmation code of method, unless
+// the latest one was sent less than VerificationResendCooldown ago or the
+// method was sent MaxVerificationCodesPerDay codes over the last day, and
+// emails the new one. The account is locked meanwhile, so concurrent
+// resends cannot both pass the checks.
+func (s *AuthService) resendVerificationCode(ctx context.Context, method *models.AuthMethod) error {
+	now := time.Now().UTC()
+	var code string
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.accounts.GetByIDForUpdate(txCtx, method.AccountID); err != nil {
+			return err
+		}
+
+		latest, err := s.verificationCodes.GetLatestByAuthMethodID(txCtx, method.ID, domain.PurposeEmailVerification)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if latest != nil && now.Sub(latest.CreatedAt) < domain.VerificationResendCooldown {
+			return domain.ErrVerificationResendThrottled
+		}
+		sent, err := s.verificationCodes.CountCreatedSince(txCtx, method.ID, domain.PurposeEmailVerification, now.Add(-24*time.Hour))
+		if err != nil {
+			return err
+		}
+		if sent >= domain.MaxVerificationCodesPerDay {
+			return domain.ErrVerificationResendThrottled
+		}
+
+		code, err = s.issueVerificationCode(txCtx, method.ID, domain.PurposeEmailVerification, domain.VerificationCodeTTL)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	publish(ctx, s.eventBus, events.VerificationResentEvent{
+		AccountID: method.AccountID,
+		Email:     method.ProviderID,
+		Code:      code,
+		ExpiresIn: int(domain.VerificationCodeTTL.Seconds()),
+	})
+	return nil
+}
+
+// VerifyEmail consumes the confirmation code issued at registration, marks the
+// EMAIL method verified, activates the PENDING account and opens its first
+// session.
+func (s *AuthService) VerifyEmail(ctx context.Context, email, code string, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.VerifyEmail")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusPending {
+		return nil, domain.ErrInvalidAccountState
+	}
+	if err := s.lockout.check(method); err != nil {
+		return nil, err
+	}
+
+	verification, err := s.checkVerificationCode(ctx, method, domain.PurposeEmailVerification, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+		if err := s.verificationCodes.MarkConsumed(txCtx, verification.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidOrExpiredCode
+			}
+			return err
+		}
+		if err := s.authMethods.UpdateVerified(txCtx, method.ID, true); err != nil {
+			return err
+		}
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+		if err := s.accounts.UpdateStatus(txCtx, account.ID, domain.StatusActive); err != nil {
+			return err
+		}
+		if err := s.authMethods.UpdateLastLogin(txCtx, method.ID, now); err != nil {
+			return err
+		}
+
+		account.StatusCode = domain.StatusActive
+		result, err = s.sessions.open(txCtx, account, client)
+		if err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountVerifiedEvent{AccountID: account.ID, Email: email})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RequestLoginCode issues a one-time login code for an active, verified EMAIL method.
+func (s *AuthService) RequestLoginCode(ctx context.Context, email string, client ClientInfo) (*CodeIssuedResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.RequestLoginCode")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+	if !method.IsVerified {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	var code string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		code, err = s.issueVerificationCode(txCtx, method.ID, domain.PurposeLogin, domain.VerificationCodeTTL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.LoginCodeRequestedEvent{
+		AccountID:    account.ID,
+		Email:        email,
+		Code:         code,
+		ExpiresIn:    int(domain.VerificationCodeTTL.Seconds()),
+		Organization: client.Organization,
+	})
+
+	return &CodeIssuedResult{ExpiresIn: domain.VerificationCodeTTL}, nil
+}
+
+// VerifyLoginCode consumes a login code and opens a new session, revoking any
+// session the account already had.
+func (s *AuthService) VerifyLoginCode(ctx context.Context, email, code string, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.VerifyLoginCode")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, s.loginFailed(ctx, uuid.Nil, email, domain.ErrInvalidOrExpiredCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, s.loginFailed(ctx, account.ID, email, domain.ErrInvalidAccountState)
+	}
+	if !method.IsVerified {
+		return nil, s.loginFailed(ctx, account.ID, email, domain.ErrInvalidOrExpiredCode)
+	}
+	if err := s.lockout.check(method); err != nil {
+		return nil, s.loginFailed(ctx, account.ID, email, err)
+	}
+	if err := s.captcha.login(ctx, method, client); err != nil {
+		return nil, s.loginFailed(ctx, account.ID, email, err)
+	}
+
+	verification, err := s.checkVerificationCode(ctx, method, domain.PurposeLogin, code)
+	if err != nil {
+		return nil, s.loginFailed(ctx, account.ID, email, err)
+	}
+
+	client.Provider = domain.ProviderEmail
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+		if err := s.verificationCodes.MarkConsumed(txCtx, verification.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidOrExpiredCode
+			}
+			return err
+		}
+		if err := s.authMethods.UpdateLastLogin(txCtx, method.ID, now); err != nil {
+			return err
+		}
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+
+		result, err = s.sessions.login(txCtx, account, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LoginWithPassword opens a session for an active, verified EMAIL method
+// whose stored password matches.
+func (s *AuthService) LoginWithPassword(ctx context.Context, email, password string, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.LoginWithPassword")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	// No stored password is longer, whatever the policy was when it was set.
+	if utf8.RuneCountInString(password) > domain.MaxPasswordLength {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Spend the same hashing time as a real check, so response times do
+		// not reveal which emails are registered.
+		_, _ = s.passwords.Hash(password)
+		return nil, s.loginFailed(ctx, uuid.Nil, email, domain.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.lockout.check(method); err != nil {
+		return nil, s.loginFailed(ctx, method.AccountID, email, err)
+	}
+	if err := s.captcha.login(ctx, method, client); err != nil {
+		return nil, s.loginFailed(ctx, method.AccountID, email, err)
+	}
+
+	credential, err := s.passwordCredentials.GetByAuthMethodID(ctx, method.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		_, _ = s.passwords.Hash(password)
+		return nil, s.loginFailed(ctx, method.AccountID, email, domain.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.passwords.Verify(password, credential.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.lockout.fail(ctx, method); err != nil {
+			return nil, err
+		}
+		return nil, s.loginFailed(ctx, method.AccountID, email, domain.ErrInvalidCredentials)
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, s.loginFailed(ctx, account.ID, email, domain.ErrInvalidAccountState)
+	}
+	if !method.IsVerified {
+		return nil, s.loginFailed(ctx, account.ID, email, domain.ErrInvalidCredentials)
+	}
+	// Passwords set before an organization of the account tightened its
+	// policy are reset before they sign in again.
+	tenant, err := s.sessions.tenantPolicy(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.accepts(password) {
+		return nil, s.loginFailed(ctx, account.ID, email, domain.ErrPasswordResetRequired)
+	}
+
+	client.Provider = domain.ProviderEmail
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.authMethods.UpdateLastLogin(txCtx, method.ID, time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+
+		result, err = s.sessions.login(txCtx, account, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.upgradePasswordHash(ctx, method.ID, password, credential.PasswordHash)
+
+	return result, nil
+}
+
+// Refresh rotates a refresh token: the presented token is revoked and a new
+// one continues its session.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*AuthResult, error) {
+	return s.refresh(ctx, refreshToken, client, nil)
+}
+
+// SwitchOrganization rotates a refresh token like Refresh, making
+// organizationID the active organization of the session from then on, or
+// leaving it without one when organizationID is uuid.Nil. The account must be
+// a member of the organization.
+func (s *AuthService) SwitchOrganization(ctx context.Context, refreshToken string, organizationID uuid.UUID, client ClientInfo) (*AuthResult, error) {
+	return s.refresh(ctx, refreshToken, client, &organizationID)
+}
+
+// refresh rotates a refresh token, into organizationID when it is set, and
+// records how long the rotation took.
+func (s *AuthService) refresh(ctx context.Context, refreshToken string, client ClientInfo, organizationID *uuid.UUID) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.refresh")
+	defer span.End()
+
+	start := time.Now()
+	result, err := s.rotate(ctx, refreshToken, client, organizationID)
+	s.metrics.RefreshCompleted(err == nil, time.Since(start))
+	return result, err
+}
+
+func (s *AuthService) rotate(ctx context.Context, refreshToken string, client ClientInfo, organizationID *uuid.UUID) (*AuthResult, error) {
+	token, err := s.refreshTokens.GetByTokenHash(ctx, security.HashToken(refreshToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	// Sessions bound to a DPoP key are only refreshed with a proof signed by
+	// that key.
+	if token.KeyThumbprint != nil && *token.KeyThumbprint != client.KeyThumbprint {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	account, err := s.accounts.GetByID(ctx, token.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+	if organizationID != nil {
+		token.OrganizationID = nil
+		if *organizationID != uuid.Nil {
+			membership, err := s.sessions.membership(ctx, account.ID, *organizationID)
+			if err != nil {
+				return nil, err
+			}
+			if membership == nil {
+				return nil, domain.ErrOrganizationNotFound
+			}
+			token.OrganizationID = organizationID
+		}
+	}
+
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.refreshTokens.Revoke(txCtx, token.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		result, err = s.sessions.rotate(txCtx, account, token, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Logout revokes the session identified by the refresh token.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.Logout")
+	defer span.End()
+
+	token, err := s.refreshTokens.GetByTokenHash(ctx, security.HashToken(refreshToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	err = s.refreshTokens.Revoke(ctx, token.ID, time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	publishSessionRevoked(ctx, s.eventBus, token, domain.SessionRevokedLogout)
+	return nil
+}
+
+// ForgotPassword emails a single-use reset code to an active, verified EMAIL
+// method. Unknown or inactive emails get the same response, so the endpoint
+// cannot be used to discover accounts.
+func (s *AuthService) ForgotPassword(ctx context.Context, email string, client ClientInfo) (*CodeIssuedResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ForgotPassword")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.captcha.passwordReset(ctx, client); err != nil {
+		return nil, err
+	}
+	result := &CodeIssuedResult{ExpiresIn: domain.PasswordResetCodeTTL}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive || !method.IsVerified {
+		return result, nil
+	}
+
+	var code string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		code, err = s.issueVerificationCode(txCtx, method.ID, domain.PurposePasswordReset, domain.PasswordResetCodeTTL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.PasswordResetRequestedEvent{
+		AccountID:    account.ID,
+		Email:        email,
+		Code:         code,
+		ExpiresIn:    int(domain.PasswordResetCodeTTL.Seconds()),
+		Organization: client.Organization,
+	})
+
+	return result, nil
+}
+
+// ResetPassword consumes a reset code, replaces the password and revokes every
+// session of the account in one transaction. Access tokens issued until then
+// are denylisted afterwards.
+func (s *AuthService) ResetPassword(ctx context.Context, email, code, password string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.ResetPassword")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return domain.ErrInvalidOrExpiredCode
+	}
+	if err := s.passwordPolicy.Load().Validate(password, email); err != nil {
+		return err
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidOrExpiredCode
+	}
+	if err != nil {
+		return err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return domain.ErrInvalidAccountState
+	}
+	if err := s.lockout.check(method); err != nil {
+		return err
+	}
+
+	verification, err := s.checkVerificationCode(ctx, method, domain.PurposePasswordReset, code)
+	if err != nil {
+		return err
+	}
+	if err := s.memberPasswordPolicy(ctx, account.ID, password, email); err != nil {
+		return err
+	}
+	// Checked once the code is known to be right, so the endpoint cannot be
+	// used to query the breach database or guess past passwords.
+	if err := s.passwordHistory.check(ctx, method.ID, password); err != nil {
+		return err
+	}
+	breached, err := s.breachedPasswords.check(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := s.passwords.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.verificationCodes.MarkConsumed(txCtx, verification.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidOrExpiredCode
+			}
+			return err
+		}
+
+		if err := s.setPassword(txCtx, method.ID, passwordHash, now); err != nil {
+			return err
+		}
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+
+		if _, err := s.refreshTokens.RevokeAllByAccountID(txCtx, account.ID, now); err != nil {
+			return err
+		}
+		if err := s.audit.record(txCtx, domain.AuditPasswordReset, account.ID, account.ID, nil); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.PasswordChangedEvent{
+			AccountID: account.ID,
+			Email:     email,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// The password has already changed, so a denylist failure only leaves
+	// the old access tokens valid until they expire.
+	if err := s.denylist.DenyAccount(ctx, account.ID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", account.ID, "error", err)
+	}
+
+	if breached {
+		publish(ctx, s.eventBus, events.PasswordBreachedEvent{AccountID: account.ID, Email: email})
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the password of a signed-in account, which must
+// confirm its current one. The new password follows the same rules as on a
+// reset, and every session of the account ends, including the restricted
+// session of an expired password: the user signs in again with the new one.
+func (s *AuthService) ChangePassword(ctx context.Context, accountID uuid.UUID, currentPassword, newPassword string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.ChangePassword")
+	defer span.End()
+
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidAccountState
+	}
+	if err != nil {
+		return err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return domain.ErrInvalidAccountState
+	}
+
+	methods, err := s.authMethods.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	var (
+		method     *models.AuthMethod
+		credential *models.PasswordCredential
+	)
+	for _, candidate := range methods {
+		if candidate.ProviderCode != domain.ProviderEmail {
+			continue
+		}
+		method = candidate
+		credential, err = s.passwordCredentials.GetByAuthMethodID(ctx, method.ID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		break
+	}
+	if credential == nil {
+		_, _ = s.passwords.Hash(currentPassword)
+		return domain.ErrInvalidCredentials
+	}
+	if err := s.lockout.check(method); err != nil {
+		return err
+	}
+
+	ok, err := s.passwords.Verify(currentPassword, credential.PasswordHash)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := s.lockout.fail(ctx, method); err != nil {
+			return err
+		}
+		return domain.ErrInvalidCredentials
+	}
+
+	if err := s.memberPasswordPolicy(ctx, account.ID, newPassword, method.ProviderID); err != nil {
+		return err
+	}
+	if err := s.passwordHistory.check(ctx, method.ID, newPassword); err != nil {
+		return err
+	}
+	breached, err := s.breachedPasswords.check(ctx, newPassword)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := s.passwords.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.setPassword(txCtx, method.ID, passwordHash, now); err != nil {
+			return err
+		}
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+
+		if _, err := s.refreshTokens.RevokeAllByAccountID(txCtx, account.ID, now); err != nil {
+			return err
+		}
+		if err := s.audit.record(txCtx, domain.AuditPasswordChanged, account.ID, account.ID, nil); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.PasswordChangedEvent{
+			AccountID: account.ID,
+			Email:     method.ProviderID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.denylist.DenyAccount(ctx, account.ID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", account.ID, "error", err)
+	}
+
+	if breached {
+		publish(ctx, s.eventBus, events.PasswordBreachedEvent{AccountID: account.ID, Email: method.ProviderID})
+	}
+
+	return nil
+}
+
+// loginFailed records a failed login with the EMAIL method of an address, on
+// the account behind it or on none when it is not registered, and returns
+// err.
+func (s *AuthService) loginFailed(ctx context.Context, accountID uuid.UUID, email string, err error) error {
+	s.audit.recordFailure(ctx, domain.AuditLoginFailed, uuid.Nil, accountID, err, map[string]string{
+		"provider": string(domain.ProviderEmail),
+		"email":    email,
+	})
+	if accountID != uuid.Nil {
+		publish(ctx, s.eventBus, events.LoginFailedEvent{
+			AccountID: accountID,
+			Provider:  string(domain.ProviderEmail),
+			Reason:    err.Error(),
+		})
+	}
+	s.metrics.LoginAttempted(string(domain.ProviderEmail), "", domain.LoginResultFailure)
+	return err
+}
+
+// memberPasswordPolicy validates a new password of the account against the
+// password policy tightened by the policies of its organizations.
+func (s *AuthService) memberPasswordPolicy(ctx context.Context, accountID uuid.UUID, password, email string) error {
+	tenant, err := s.sessions.tenantPolicy(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	return tenant.password(*s.passwordPolicy.Load()).Validate(password, email)
+}
+
+// upgradePasswordHash replaces a verified hash made with an older algorithm,
+// cost or pepper by one with the current settings. Failures are only logged:
+// the old hash keeps working and the next login tries again.
+func (s *AuthService) upgradePasswordHash(ctx context.Context, authMethodID uuid.UUID, password, encoded string) {
+	if !s.passwords.NeedsRehash(encoded) {
+		return
+	}
+	passwordHash, err := s.passwords.Hash(password)
+	if err == nil {
+		err = s.passwordCredentials.Rehash(ctx, authMethodID, encoded, passwordHash)
+	}
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		slog.ErrorContext(ctx, "rehash password", "auth_method_id", authMethodID, "error", err)
+	}
+}
+
+// setPassword stores the hash for the method, creating the credential when
+// the method so far only signed in with login codes. A replaced password goes
+// to the password history.
+func (s *AuthService) setPassword(ctx context.Context, authMethodID uuid.UUID, passwordHash string, at time.Time) error {
+	current, err := s.passwordCredentials.GetByAuthMethodID(ctx, authMethodID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return s.passwordCredentials.Create(ctx, &models.PasswordCredential{
+			AuthMethodID: authMethodID,
+			PasswordHash: passwordHash,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.passwordHistory.record(ctx, authMethodID, current.PasswordHash); err != nil {
+		return err
+	}
+	return s.passwordCredentials.UpdateHash(ctx, authMethodID, passwordHash, at)
+}
+
+// issueVerificationCode invalidates any active code of the same purpose for
+// the method and stores the hash of a freshly generated one, returning the
+// plaintext.
+func (s *AuthService) issueVerificationCode(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, ttl time.Duration) (string, error) {
+	code, err := security.GenerateNumericCode(domain.VerificationCodeLength)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.storeVerificationSecret(ctx, authMethodID, purpose, code, ttl); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// storeVerificationSecret replaces the active secret of the method for
+// purpose with the hash of secret.
+func (s *AuthService) storeVerificationSecret(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, secret string, ttl time.Duration) error {
+	now := time.Now().UTC()
+	if err := s.verificationCodes.InvalidateActive(ctx, authMethodID, purpose, now); err != nil {
+		return err
+	}
+
+	err := s.verificationCodes.Create(ctx, &models.VerificationCode{
+		ID:           uuid.New(),
+		AuthMethodID: authMethodID,
+		Purpose:      purpose,
+		CodeHash:     security.HashToken(secret),
+		Attempts:     0,
+		ExpiresAt:    now.Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.metrics.CodeIssued(string(purpose))
+	return nil
+}
+
+// checkVerificationCode validates the latest code of a method for purpose. A
+// mismatch is counted against the code and the method outside any
+// transaction so it survives the error.
+func (s *AuthService) checkVerificationCode(ctx context.Context, method *models.AuthMethod, purpose domain.CodePurpose, code string) (*models.VerificationCode, error) {
+	verification, err := s.verificationCodes.GetLatestByAuthMethodID(ctx, method.ID, purpose)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if verification.ConsumedAt != nil || !time.Now().Before(verification.ExpiresAt) {
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+	if verification.Attempts >= domain.MaxVerificationAttempts {
+		return nil, domain.ErrVerificationAttemptsExceeded
+	}
+
+	if !security.CompareTokenHash(code, verification.CodeHash) {
+		attempts, err := s.verificationCodes.IncrementAttempts(ctx, verification.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.lockout.fail(ctx, method); err != nil {
+			return nil, err
+		}
+		if attempts >= domain.MaxVerificationAttempts {
+			return nil, domain.ErrVerificationAttemptsExceeded
+		}
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+
+	return verification, nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", domain.ErrInvalidEmail
+	}
+	return email, nil
+}
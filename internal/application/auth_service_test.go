@@ -0,0 +1,386 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/denylist"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/eventbus"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/metrics"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/token"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/memory"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+const (
+	testEmail    = "ana@example.com"
+	testPassword = "correct horse battery staple"
+)
+
+// recordingBus keeps the events delivered after commit, such as the codes
+// mailed to users.
+type recordingBus struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (b *recordingBus) Publish(ctx context.Context, event events.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *recordingBus) registrationCode(t *testing.T, email string) string {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, event := range b.events {
+		if registered, ok := event.(events.UserRegisteredEvent); ok && registered.Email == email {
+			return registered.Code
+		}
+	}
+	t.Fatalf("no registration code sent to %s", email)
+	return ""
+}
+
+type testAuth struct {
+	service       *AuthService
+	mfa           *MFAService
+	stepUp        *StepUpService
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	refreshTokens repositories.RefreshTokenRepository
+	auditEvents   repositories.AuditEventRepository
+	outbox        repositories.OutboxRepository
+	mail          *recordingBus
+}
+
+// newTestAuth wires an AuthService, with the MFA and step-up services, to the
+// in-memory repositories, with a lockout after three failed attempts.
+func newTestAuth(t *testing.T) *testAuth {
+	t.Helper()
+
+	store := memory.NewStore()
+	txManager := memory.NewMemoryTxManager(store)
+	accounts := memory.NewAccountRepository(store)
+	authMethods := memory.NewAuthMethodRepository(store)
+	refreshTokens := memory.NewRefreshTokenRepository(store)
+	passwordCredentials := memory.NewPasswordCredentialRepository(store)
+	memberships := memory.NewMembershipRepository(store)
+	mfaFactors := memory.NewMFAFactorRepository(store)
+	roles := memory.NewRoleRepository(store)
+	auditEvents := memory.NewAuditEventRepository(store)
+	outbox := memory.NewOutboxRepository(store)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingKey, err := token.NewSigningKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := token.NewJWTService(token.NewStaticKeyStore(signingKey), token.JWTConfig{
+		Issuer:   "https://auth.example.com",
+		Audience: "test",
+		TTL:      15 * time.Minute,
+	})
+	// Cheap parameters keep the tests fast; the hashes are still Argon2id.
+	passwords, err := security.NewArgon2Hasher(security.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets, err := security.NewCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mail := &recordingBus{}
+	eventBus := NewOutbox(txManager, outbox, mail, eventbus.NewLogBus())
+	prometheus := metrics.NewPrometheus()
+	audit := NewAuditLog(txManager, auditEvents, nil)
+	lockout := NewLockout(authMethods, mfaFactors, LockoutPolicy{Threshold: 3, Duration: time.Minute, MaxDuration: time.Hour}, eventBus)
+
+	sessions := NewSessionIssuer(
+		refreshTokens,
+		tokens,
+		roles,
+		memberships,
+		memory.NewAuthPolicyRepository(store),
+		mfaFactors,
+		memory.NewMFAChallengeRepository(store),
+		memory.NewPasskeyCredentialRepository(store),
+		memory.NewMFARecoveryCodeRepository(store),
+		authMethods,
+		passwordCredentials,
+		NewMFAPolicy(),
+		NewFeatureFlags(memberships),
+		NewPasswordExpiry(nil),
+		memory.NewLegalDocumentRepository(store),
+		memory.NewTrustedDeviceRepository(store),
+		domain.TrustedDeviceTTL,
+		SessionLimit{},
+		SessionLifetime{Default: time.Hour, RememberMe: 24 * time.Hour, Refresh: FixedExpiration{}},
+		prometheus,
+		audit,
+		eventBus,
+	)
+	service := NewAuthService(
+		txManager,
+		accounts,
+		authMethods,
+		memory.NewVerificationCodeRepository(store),
+		refreshTokens,
+		passwordCredentials,
+		memory.NewAccountDeletionRepository(store),
+		domain.AccountDeletionGracePeriod,
+		sessions,
+		passwords,
+		DefaultPasswordPolicy,
+		NewPasswordHistory(memory.NewPasswordHistoryRepository(store), passwordCredentials, passwords, 0),
+		denylist.NewMemoryDenylist(15*time.Minute),
+		lockout,
+		NewCaptchaGuard(nil, CaptchaPolicy{}),
+		NewDisposableEmailGuard(nil, ""),
+		NewBreachedPasswordGuard(nil, ""),
+		prometheus,
+		audit,
+		eventBus,
+	)
+
+	mfa := NewMFAService(
+		txManager,
+		accounts,
+		authMethods,
+		mfaFactors,
+		memory.NewMFAChallengeRepository(store),
+		memory.NewMFACodeRepository(store),
+		memory.NewMFARecoveryCodeRepository(store),
+		memory.NewTrustedDeviceRepository(store),
+		sessions,
+		lockout,
+		secrets,
+		nil,
+		prometheus,
+		audit,
+		eventBus,
+		"Ranco",
+	)
+
+	return &testAuth{
+		service:       service,
+		mfa:           mfa,
+		stepUp:        NewStepUpService(accounts, authMethods, passwordCredentials, passwords, lockout, mfa, tokens, roles),
+		accounts:      accounts,
+		authMethods:   authMethods,
+		refreshTokens: refreshTokens,
+		auditEvents:   auditEvents,
+		outbox:        outbox,
+		mail:          mail,
+	}
+}
+
+// register signs an account up with testEmail and testPassword and confirms
+// its address, which opens a first session.
+func (a *testAuth) register(t *testing.T) *AuthResult {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := a.service.RegisterWithEmail(ctx, testEmail, testPassword, ClientInfo{}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	result, err := a.service.VerifyEmail(ctx, testEmail, a.mail.registrationCode(t, testEmail), ClientInfo{})
+	if err != nil {
+		t.Fatalf("verify email: %v", err)
+	}
+	return result
+}
+
+func TestLoginWithPassword(t *testing.T) {
+	auth := newTestAuth(t)
+	account := auth.register(t).Account
+	ctx := context.Background()
+
+	result, err := auth.service.LoginWithPassword(ctx, "  Ana@Example.com ", testPassword, ClientInfo{})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if result.Account.ID != account.ID {
+		t.Errorf("logged in as %s, want %s", result.Account.ID, account.ID)
+	}
+	if result.AccessToken == "" || result.RefreshToken == "" || result.Restricted() {
+		t.Errorf("login did not open a full session: %+v", result)
+	}
+
+	if _, err := auth.service.LoginWithPassword(ctx, testEmail, "wrong password", ClientInfo{}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("login with a wrong password: got %v, want %v", err, domain.ErrInvalidCredentials)
+	}
+	if _, err := auth.service.LoginWithPassword(ctx, "bob@example.com", testPassword, ClientInfo{}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("login with an unknown email: got %v, want %v", err, domain.ErrInvalidCredentials)
+	}
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	auth := newTestAuth(t)
+	login := auth.register(t)
+	ctx := context.Background()
+
+	rotated, err := auth.service.Refresh(ctx, login.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if rotated.RefreshToken == login.RefreshToken {
+		t.Error("refresh returned the presented token")
+	}
+	if rotated.SessionID != login.SessionID {
+		t.Errorf("rotation moved to session %s, want %s", rotated.SessionID, login.SessionID)
+	}
+
+	if _, err := auth.service.Refresh(ctx, login.RefreshToken, ClientInfo{}); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("reusing a rotated token: got %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+	if _, err := auth.service.Refresh(ctx, rotated.RefreshToken, ClientInfo{}); err != nil {
+		t.Errorf("refresh with the rotated token: %v", err)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	auth := newTestAuth(t)
+	auth.register(t)
+	ctx := context.Background()
+
+	for i := range 3 {
+		if _, err := auth.service.LoginWithPassword(ctx, testEmail, "wrong password", ClientInfo{}); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("failed attempt %d: got %v, want %v", i+1, err, domain.ErrInvalidCredentials)
+		}
+	}
+
+	method, err := auth.authMethods.GetByProvider(ctx, domain.ProviderEmail, testEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !method.LockedAt(time.Now()) {
+		t.Fatal("method not locked after reaching the threshold")
+	}
+	if _, err := auth.service.LoginWithPassword(ctx, testEmail, testPassword, ClientInfo{}); !errors.Is(err, domain.ErrAuthMethodLocked) {
+		t.Errorf("login while locked: got %v, want %v", err, domain.ErrAuthMethodLocked)
+	}
+}
+
+func TestLoginResetsFailedAttempts(t *testing.T) {
+	auth := newTestAuth(t)
+	auth.register(t)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := auth.service.LoginWithPassword(ctx, testEmail, "wrong password", ClientInfo{}); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("failed attempt: got %v", err)
+		}
+	}
+	if _, err := auth.service.LoginWithPassword(ctx, testEmail, testPassword, ClientInfo{}); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	method, err := auth.authMethods.GetByProvider(ctx, domain.ProviderEmail, testEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method.FailedAttempts != 0 {
+		t.Errorf("failed attempts after a login: got %d, want 0", method.FailedAttempts)
+	}
+}
+
+func TestEraseAccount(t *testing.T) {
+	auth := newTestAuth(t)
+	login := auth.register(t)
+	accountID := login.Account.ID
+	ctx := context.Background()
+
+	// A failed login records the address in the audit log.
+	if _, err := auth.service.LoginWithPassword(ctx, testEmail, "wrong password", ClientInfo{IPAddress: "203.0.113.7"}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("failed login: got %v", err)
+	}
+
+	if err := auth.service.EraseAccount(ctx, accountID); err != nil {
+		t.Fatalf("erase: %v", err)
+	}
+
+	account, err := auth.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.StatusCode != domain.StatusDeleted {
+		t.Errorf("status after erasure: got %s, want %s", account.StatusCode, domain.StatusDeleted)
+	}
+	if _, err := auth.authMethods.GetByProvider(ctx, domain.ProviderEmail, testEmail); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("email method after erasure: got %v, want %v", err, domain.ErrNotFound)
+	}
+	if _, err := auth.service.Refresh(ctx, login.RefreshToken, ClientInfo{}); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("refresh after erasure: got %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+	if _, err := auth.service.LoginWithPassword(ctx, testEmail, testPassword, ClientInfo{}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("login after erasure: got %v, want %v", err, domain.ErrInvalidCredentials)
+	}
+
+	audited, err := auth.auditEvents.Search(ctx, models.AuditEventFilter{TargetID: accountID}, models.AuditEventCursor{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(audited) == 0 {
+		t.Fatal("erasure removed the audit events of the account")
+	}
+	for _, event := range audited {
+		if _, ok := event.Details["email"]; ok {
+			t.Errorf("%s event still records the email", event.Action)
+		}
+		if event.IPAddress != nil {
+			t.Errorf("%s event still records the IP address", event.Action)
+		}
+	}
+
+	now := time.Now().UTC()
+	pending, err := auth.outbox.ClaimDue(ctx, now, now.Add(time.Minute), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range pending {
+		if bytes.Contains(event.Payload, []byte(testEmail)) {
+			t.Errorf("unpublished %s event still carries the email", event.Name)
+		}
+	}
+	if !anyOutbox(pending, events.NameAccountErased, accountID) {
+		t.Error("erasure event missing from the outbox")
+	}
+}
+
+func TestEraseAccountByAdminRefusesSelf(t *testing.T) {
+	auth := newTestAuth(t)
+	accountID := auth.register(t).Account.ID
+
+	if err := auth.service.EraseAccountByAdmin(context.Background(), accountID, accountID); !errors.Is(err, domain.ErrCannotEraseSelf) {
+		t.Errorf("got %v, want %v", err, domain.ErrCannotEraseSelf)
+	}
+	if err := auth.service.EraseAccountByAdmin(context.Background(), accountID, uuid.New()); !errors.Is(err, domain.ErrAccountNotFound) {
+		t.Errorf("erasing an unknown account: got %v, want %v", err, domain.ErrAccountNotFound)
+	}
+}
+
+func anyOutbox(stored []*models.OutboxEvent, name string, accountID uuid.UUID) bool {
+	for _, event := range stored {
+		if event.Name == name && bytes.Contains(event.Payload, []byte(accountID.String())) {
+			return true
+		}
+	}
+	return false
+}
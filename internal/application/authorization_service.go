@@ -0,0 +1,468 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// AuthorizationRequest carries the parameters of an OpenID Connect
+// authentication request using the authorization code flow.
+type AuthorizationRequest struct {
+	ClientID     string
+	RedirectURI  string
+	ResponseType string
+	Scope        string
+	State        string
+	Nonce        string
+	Prompt       string
+	// CodeChallenge and CodeChallengeMethod carry PKCE (RFC 7636), which
+	// public clients must use.
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// CodeExchange carries the token request of the authorization code grant.
+type CodeExchange struct {
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+}
+
+// BrowserSessionResult is the cookie token of a new browser session.
+type BrowserSessionResult struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// UserInfo holds the claims about an account released to OpenID Connect
+// clients. Email is empty for accounts without an email method.
+type UserInfo struct {
+	Subject       uuid.UUID
+	Email         string
+	EmailVerified bool
+}
+
+// TokenGrant is the session opened for an authorization code, with the ID
+// token asserting the authentication to the client.
+type TokenGrant struct {
+	*AuthResult
+	IDToken string
+	Scope   string
+}
+
+// AuthorizationService lets first-party applications sign users in with the
+// OpenID Connect authorization code flow. Users authenticate through the
+// regular login endpoints; the browser session cookie then lets the
+// authorization endpoint issue codes without asking again. Devices without a
+// browser use the device authorization grant instead.
+type AuthorizationService struct {
+	txManager       ports.TxManager
+	accounts        repositories.AccountRepository
+	authMethods     repositories.AuthMethodRepository
+	clients         repositories.OAuthClientRepository
+	codes           repositories.AuthorizationCodeRepository
+	browserSessions repositories.BrowserSessionRepository
+	deviceCodes     repositories.DeviceCodeRepository
+	refreshTokens   repositories.RefreshTokenRepository
+	sessions        *SessionIssuer
+	tokens          ports.TokenService
+}
+
+func NewAuthorizationService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	clients repositories.OAuthClientRepository,
+	codes repositories.AuthorizationCodeRepository,
+	browserSessions repositories.BrowserSessionRepository,
+	deviceCodes repositories.DeviceCodeRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+	sessions *SessionIssuer,
+	tokens ports.TokenService,
+) *AuthorizationService {
+	return &AuthorizationService{
+		txManager:       txManager,
+		accounts:        accounts,
+		authMethods:     authMethods,
+		clients:         clients,
+		codes:           codes,
+		browserSessions: browserSessions,
+		deviceCodes:     deviceCodes,
+		refreshTokens:   refreshTokens,
+		sessions:        sessions,
+		tokens:          tokens,
+	}
+}
+
+// Client resolves the client of an authorization request. Until it succeeds
+// the redirect URI cannot be trusted, so errors must not be sent to it.
+func (s *AuthorizationService) Client(ctx context.Context, clientID, redirectURI string) (*models.OAuthClient, error) {
+	client, err := s.clients.GetByClientID(ctx, clientID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidClient
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.AllowsRedirectURI(redirectURI) {
+		return nil, domain.ErrInvalidRedirectURI
+	}
+	return client, nil
+}
+
+// Authorize issues an authorization code for the account signed in through
+// browserToken. Browsers without a live session get ErrLoginRequired.
+func (s *AuthorizationService) Authorize(ctx context.Context, client *models.OAuthClient, req AuthorizationRequest, browserToken string) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthorizationService.Authorize")
+	defer span.End()
+
+	if req.ResponseType != domain.ResponseTypeCode {
+		return "", domain.ErrUnsupportedResponseType
+	}
+	if !client.AllowsGrantType(domain.GrantTypeAuthorizationCode) {
+		return "", domain.ErrUnauthorizedClient
+	}
+	if !slices.Contains(strings.Fields(req.Scope), domain.ScopeOpenID) {
+		return "", domain.ErrInvalidScope
+	}
+	scope, err := authorizedScope(client, req.Scope)
+	if err != nil {
+		return "", err
+	}
+	if err := checkCodeChallenge(client, req); err != nil {
+		return "", err
+	}
+
+	session, err := s.browserSession(ctx, browserToken)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := security.GenerateOpaqueToken(domain.AuthorizationCodeBytes)
+	if err != nil {
+		return "", err
+	}
+
+	code := &models.AuthorizationCode{
+		ID:                  uuid.New(),
+		CodeHash:            security.HashToken(plain),
+		ClientID:            client.ID,
+		AccountID:           session.AccountID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               scope,
+		Nonce:               optional(req.Nonce),
+		CodeChallenge:       optional(req.CodeChallenge),
+		CodeChallengeMethod: optional(req.CodeChallengeMethod),
+		AuthTime:            session.SessionStartedAt,
+		ExpiresAt:           time.Now().UTC().Add(domain.AuthorizationCodeTTL),
+	}
+	if err := s.codes.Create(ctx, code); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+// StartBrowserSession creates the browser session cookie for the session an
+// access token was issued for. The cookie stops working when that session
+// ends.
+func (s *AuthorizationService) StartBrowserSession(ctx context.Context, claims *models.AccessTokenClaims) (*BrowserSessionResult, error) {
+	if claims.SessionID == uuid.Nil {
+		return nil, domain.ErrInvalidAccessToken
+	}
+
+	token, err := s.activeSession(ctx, claims.AccountID, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := security.GenerateOpaqueToken(domain.BrowserSessionTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.BrowserSession{
+		ID:        uuid.New(),
+		AccountID: token.AccountID,
+		SessionID: token.SessionID,
+		TokenHash: security.HashToken(plain),
+		ExpiresAt: token.ExpiresAt,
+	}
+	if err := s.browserSessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &BrowserSessionResult{Token: plain, ExpiresAt: session.ExpiresAt}, nil
+}
+
+// EndBrowserSession deletes the browser session behind a cookie. The session
+// it was created from stays open.
+func (s *AuthorizationService) EndBrowserSession(ctx context.Context, browserToken string) error {
+	if browserToken == "" {
+		return nil
+	}
+
+	session, err := s.browserSessions.GetByTokenHash(ctx, security.HashToken(browserToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.browserSessions.Delete(ctx, session.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Exchange redeems an authorization code for a new session and an ID token.
+// Codes are single use, bound to the client, redirect URI and PKCE challenge
+// they were issued for, and expire after a minute.
+func (s *AuthorizationService) Exchange(ctx context.Context, client *models.OAuthClient, exchange CodeExchange, clientInfo ClientInfo) (*TokenGrant, error) {
+	ctx, span := tracer.Start(ctx, "AuthorizationService.Exchange")
+	defer span.End()
+
+	code, err := s.codes.GetByCodeHash(ctx, security.HashToken(exchange.Code))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidGrant
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if code.ClientID != client.ID || code.RedirectURI != exchange.RedirectURI || code.ConsumedAt != nil || !now.Before(code.ExpiresAt) {
+		return nil, domain.ErrInvalidGrant
+	}
+	if !verifyCodeVerifier(code, exchange.CodeVerifier) {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	account, err := s.accounts.GetByID(ctx, code.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	clientInfo.Scope = sessionScope(code.Scope)
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.codes.Consume(txCtx, code.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidGrant
+			}
+			return err
+		}
+
+		result, err = s.sessions.open(txCtx, account, clientInfo)
+		if err != nil {
+			return err
+		}
+		// Accounts that must enroll a second factor or change their
+		// password only get restricted sessions, which are not handed to
+		// other applications.
+		if result.Restricted() {
+			return domain.ErrInvalidGrant
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts := models.IDTokenOptions{
+		SessionID: result.SessionID,
+		AuthTime:  code.AuthTime,
+	}
+	if code.Nonce != nil {
+		opts.Nonce = *code.Nonce
+	}
+
+	idToken, err := s.idToken(ctx, account, client, code.Scope, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenGrant{AuthResult: result, IDToken: idToken, Scope: grantedScope(code.Scope, result.Scope)}, nil
+}
+
+// authorizedScope keeps the OpenID Connect scopes of a requested scope and
+// the other scopes the client may request, which restrict the session opened
+// for the grant. A request for only scopes the client may not request fails
+// with ErrInvalidScope rather than yielding an unrestricted session.
+func authorizedScope(client *models.OAuthClient, scope string) (string, error) {
+	var kept []string
+	requested, allowed := 0, 0
+	for _, name := range strings.Fields(scope) {
+		if slices.Contains(kept, name) {
+			continue
+		}
+		if oidcScope(name) {
+			kept = append(kept, name)
+			continue
+		}
+		requested++
+		if slices.Contains(client.Scopes, name) {
+			kept = append(kept, name)
+			allowed++
+		}
+	}
+	if requested > 0 && allowed == 0 {
+		return "", domain.ErrInvalidScope
+	}
+	return strings.Join(kept, " "), nil
+}
+
+// sessionScope returns the scopes of an authorized scope that restrict the
+// session, leaving out the OpenID Connect ones.
+func sessionScope(scope string) string {
+	var restricted []string
+	for _, name := range strings.Fields(scope) {
+		if !oidcScope(name) {
+			restricted = append(restricted, name)
+		}
+	}
+	return strings.Join(restricted, " ")
+}
+
+// grantedScope is the scope reported for a grant: the OpenID Connect scopes
+// authorized and the scope granted to its session.
+func grantedScope(authorized string, session domain.TokenScope) string {
+	var granted []string
+	for _, name := range strings.Fields(authorized) {
+		if oidcScope(name) {
+			granted = append(granted, name)
+		}
+	}
+	return strings.Join(append(granted, strings.Fields(string(session))...), " ")
+}
+
+func oidcScope(name string) bool {
+	return name == domain.ScopeOpenID || name == domain.ScopeEmail
+}
+
+// idToken asserts the authentication behind a grant to the client, with the
+// email claims when the scope asks for them.
+func (s *AuthorizationService) idToken(ctx context.Context, account *models.Account, client *models.OAuthClient, scope string, opts models.IDTokenOptions) (string, error) {
+	opts.Audience = client.ClientID
+	if slices.Contains(strings.Fields(scope), domain.ScopeEmail) {
+		info, err := s.userInfo(ctx, account)
+		if err != nil {
+			return "", err
+		}
+		opts.Email, opts.EmailVerified = info.Email, info.EmailVerified
+	}
+
+	return s.tokens.GenerateIDToken(ctx, account, opts)
+}
+
+// UserInfo returns the claims about the account of a valid access token.
+// Accounts that are no longer active are refused.
+func (s *AuthorizationService) UserInfo(ctx context.Context, accountID uuid.UUID) (*UserInfo, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidAccessToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccessToken
+	}
+
+	return s.userInfo(ctx, account)
+}
+
+func (s *AuthorizationService) userInfo(ctx context.Context, account *models.Account) (*UserInfo, error) {
+	methods, err := s.authMethods.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &UserInfo{Subject: account.ID}
+	for _, method := range methods {
+		if method.ProviderCode == domain.ProviderEmail {
+			info.Email, info.EmailVerified = method.ProviderID, method.IsVerified
+			break
+		}
+	}
+	return info, nil
+}
+
+// browserSession returns the active refresh token of the session behind a
+// browser session cookie.
+func (s *AuthorizationService) browserSession(ctx context.Context, browserToken string) (*models.RefreshToken, error) {
+	if browserToken == "" {
+		return nil, domain.ErrLoginRequired
+	}
+
+	session, err := s.browserSessions.GetByTokenHash(ctx, security.HashToken(browserToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrLoginRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(session.ExpiresAt) {
+		return nil, domain.ErrLoginRequired
+	}
+
+	token, err := s.activeSession(ctx, session.AccountID, session.SessionID)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return nil, domain.ErrLoginRequired
+	}
+	return token, err
+}
+
+func (s *AuthorizationService) activeSession(ctx context.Context, accountID, sessionID uuid.UUID) (*models.RefreshToken, error) {
+	tokens, err := s.refreshTokens.ListActiveByAccountID(ctx, accountID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.SessionID == sessionID {
+			return token, nil
+		}
+	}
+	return nil, domain.ErrSessionNotFound
+}
+
+// checkCodeChallenge requires PKCE from public clients and accepts only the
+// S256 method, since plain challenges give no protection once intercepted.
+func checkCodeChallenge(client *models.OAuthClient, req AuthorizationRequest) error {
+	if req.CodeChallenge == "" && req.CodeChallengeMethod == "" {
+		if client.Public {
+			return domain.ErrCodeChallengeRequired
+		}
+		return nil
+	}
+
+	if req.CodeChallengeMethod != domain.CodeChallengeMethodS256 || !security.ValidS256Challenge(req.CodeChallenge) {
+		return domain.ErrInvalidCodeChallenge
+	}
+	return nil
+}
+
+// verifyCodeVerifier checks the verifier of a code issued with a challenge,
+// and refuses verifiers for codes issued without one.
+func verifyCodeVerifier(code *models.AuthorizationCode, verifier string) bool {
+	if code.CodeChallenge == nil {
+		return verifier == ""
+	}
+	return security.ValidCodeVerifier(verifier) && security.VerifyS256Challenge(verifier, *code.CodeChallenge)
+}
@@ -0,0 +1,255 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// BanService lets administrators ban accounts, for a while or until they
+// lift the ban, and lifts the bans that expire. Every ban is recorded with
+// its reason and who banned the account.
+type BanService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	bans          repositories.AccountBanRepository
+	refreshTokens repositories.RefreshTokenRepository
+	denylist      ports.AccessTokenDenylist
+	audit         *AuditLog
+	eventBus      ports.EventBus
+}
+
+func NewBanService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	bans repositories.AccountBanRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+	denylist ports.AccessTokenDenylist,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *BanService {
+	return &BanService{
+		txManager:     txManager,
+		accounts:      accounts,
+		bans:          bans,
+		refreshTokens: refreshTokens,
+		denylist:      denylist,
+		audit:         audit,
+		eventBus:      eventBus,
+	}
+}
+
+// Ban makes a PENDING or ACTIVE account BANNED and ends its sessions. A nil
+// expiresAt bans it until an administrator lifts the ban.
+func (s *BanService) Ban(ctx context.Context, adminID, accountID uuid.UUID, reason string, expiresAt *time.Time) (*models.AccountBan, error) {
+	reason, err := banReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, domain.ErrInvalidBanExpiry
+	}
+	if adminID == accountID {
+		return nil, domain.ErrCannotBanSelf
+	}
+
+	var ban *models.AccountBan
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.lockAccount(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+		if account.StatusCode == domain.StatusBanned {
+			return domain.ErrAccountAlreadyBanned
+		}
+
+		ban = &models.AccountBan{
+			ID:             uuid.New(),
+			AccountID:      accountID,
+			PreviousStatus: account.StatusCode,
+			Reason:         reason,
+			BannedBy:       &adminID,
+			ExpiresAt:      expiresAt,
+		}
+		if err := s.bans.Create(txCtx, ban); err != nil {
+			return err
+		}
+		if err := s.accounts.UpdateStatus(txCtx, accountID, domain.StatusBanned); err != nil {
+			return err
+		}
+		if _, err := s.refreshTokens.RevokeAllByAccountID(txCtx, accountID, now); err != nil {
+			return err
+		}
+
+		details := map[string]string{"ban_id": ban.ID.String(), "reason": reason}
+		if expiresAt != nil {
+			details["expires_at"] = expiresAt.UTC().Format(time.RFC3339)
+		}
+		if err := s.audit.record(txCtx, domain.AuditAccountBanned, adminID, accountID, details); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountStatusChangedEvent{
+			AccountID: accountID,
+			Previous:  string(ban.PreviousStatus),
+			Status:    string(domain.StatusBanned),
+		})
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrAccountAlreadyBanned
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.denylist.DenyAccount(ctx, accountID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", accountID, "error", err)
+	}
+	return ban, nil
+}
+
+// Unban lifts the ban of an account, which returns to the status it had
+// before. Accounts BANNED otherwise than by Ban, such as through SCIM, are not
+// banned in this sense.
+func (s *BanService) Unban(ctx context.Context, adminID, accountID uuid.UUID, reason string) (*models.AccountBan, error) {
+	reason, err := banReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	var ban *models.AccountBan
+	now := time.Now().UTC()
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.lockAccount(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+		ban, err = s.bans.GetActive(txCtx, accountID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrAccountNotBanned
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.lift(txCtx, account, ban, now, &adminID, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+// History returns every ban of an account, newest first.
+func (s *BanService) History(ctx context.Context, accountID uuid.UUID) ([]*models.AccountBan, error) {
+	if _, err := s.accounts.GetByID(ctx, accountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return s.bans.ListByAccountID(ctx, accountID)
+}
+
+// LiftExpired lifts every ban expired at now and returns how many it lifted.
+// Bans lifted concurrently, by an administrator or another instance, are
+// skipped.
+func (s *BanService) LiftExpired(ctx context.Context, now time.Time) (int, error) {
+	lifted := 0
+	for {
+		expired, err := s.bans.ListExpired(ctx, now, domain.BanExpiryBatch)
+		if err != nil {
+			return lifted, err
+		}
+
+		for _, ban := range expired {
+			err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+				account, err := s.accounts.GetByIDForUpdate(txCtx, ban.AccountID)
+				if err != nil {
+					return err
+				}
+				return s.lift(txCtx, account, ban, now, nil, "")
+			})
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return lifted, err
+			}
+
+			lifted++
+		}
+		if len(expired) < domain.BanExpiryBatch {
+			return lifted, nil
+		}
+	}
+}
+
+// lift records the ban as lifted, in the audit log too, and, when the
+// account is still BANNED, restores and publishes its previous status. It
+// fails with domain.ErrNotFound when the ban was already lifted.
+func (s *BanService) lift(ctx context.Context, account *models.Account, ban *models.AccountBan, now time.Time, liftedBy *uuid.UUID, reason string) error {
+	if err := s.bans.Lift(ctx, ban.ID, now, liftedBy, reason); err != nil {
+		return err
+	}
+	ban.LiftedAt, ban.LiftedBy, ban.LiftReason = &now, liftedBy, reason
+
+	actorID := uuid.Nil
+	if liftedBy != nil {
+		actorID = *liftedBy
+	}
+	details := map[string]string{"ban_id": ban.ID.String()}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	if err := s.audit.record(ctx, domain.AuditAccountUnbanned, actorID, account.ID, details); err != nil {
+		return err
+	}
+
+	if account.StatusCode != domain.StatusBanned {
+		return nil
+	}
+	if err := s.accounts.UpdateStatus(ctx, account.ID, ban.PreviousStatus); err != nil {
+		return err
+	}
+	return publishWithin(ctx, s.eventBus, events.AccountStatusChangedEvent{
+		AccountID: account.ID,
+		Previous:  string(domain.StatusBanned),
+		Status:    string(ban.PreviousStatus),
+	})
+}
+
+// lockAccount reads the account for update, reporting DELETED accounts as
+// ErrAccountNotFound.
+func (s *BanService) lockAccount(ctx context.Context, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accounts.GetByIDForUpdate(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode == domain.StatusDeleted {
+		return nil, domain.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// banReason trims a ban or unban reason, which is required.
+func banReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > domain.MaxBanReasonLength {
+		return "", domain.ErrInvalidBanReason
+	}
+	return reason, nil
+}
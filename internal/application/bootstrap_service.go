@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// BootstrapService seeds the first ADMIN account of a fresh deployment, which
+// otherwise has nobody to promote the accounts that register.
+type BootstrapService struct {
+	txManager           ports.TxManager
+	accounts            repositories.AccountRepository
+	authMethods         repositories.AuthMethodRepository
+	passwordCredentials repositories.PasswordCredentialRepository
+	passwords           ports.PasswordHasher
+	policy              PasswordPolicy
+}
+
+func NewBootstrapService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	passwordCredentials repositories.PasswordCredentialRepository,
+	passwords ports.PasswordHasher,
+	policy PasswordPolicy,
+) *BootstrapService {
+	return &BootstrapService{
+		txManager:           txManager,
+		accounts:            accounts,
+		authMethods:         authMethods,
+		passwordCredentials: passwordCredentials,
+		passwords:           passwords,
+		policy:              policy,
+	}
+}
+
+// BootstrapAdmin creates an ACTIVE ADMIN account with a verified EMAIL method
+// and password, and reports whether it did. It does nothing when the address
+// already belongs to an ADMIN account, so it can run on every deployment. An
+// address registered by another account fails with ErrAccountAlreadyExists
+// rather than promoting whoever registered it.
+func (s *BootstrapService) BootstrapAdmin(ctx context.Context, email, password string) (bool, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := s.create(ctx, email, password)
+	if errors.Is(err, domain.ErrConflict) {
+		// Another instance may have created the account since the lookup.
+		created, err = s.create(ctx, email, password)
+	}
+	return created, err
+}
+
+func (s *BootstrapService) create(ctx context.Context, email, password string) (bool, error) {
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if err == nil {
+		account, err := s.accounts.GetByID(ctx, method.AccountID)
+		if err != nil {
+			return false, err
+		}
+		if account.RoleCode != domain.RoleAdmin {
+			return false, domain.ErrAccountAlreadyExists
+		}
+		return false, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return false, err
+	}
+
+	if err := s.policy.Validate(password, email); err != nil {
+		return false, err
+	}
+	passwordHash, err := s.passwords.Hash(password)
+	if err != nil {
+		return false, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account := &models.Account{
+			ID:         uuid.New(),
+			RoleCode:   domain.RoleAdmin,
+			StatusCode: domain.StatusActive,
+		}
+		if err := s.accounts.Create(txCtx, account); err != nil {
+			return err
+		}
+
+		method := &models.AuthMethod{
+			ID:           uuid.New(),
+			AccountID:    account.ID,
+			ProviderCode: domain.ProviderEmail,
+			ProviderID:   email,
+			IsVerified:   true,
+		}
+		if err := s.authMethods.Create(txCtx, method); err != nil {
+			return err
+		}
+
+		return s.passwordCredentials.Create(txCtx, &models.PasswordCredential{
+			AuthMethodID: method.ID,
+			PasswordHash: passwordHash,
+		})
+	})
+	return err == nil, err
+}
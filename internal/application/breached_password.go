@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// BreachedPasswordGuard screens new passwords against known data breaches,
+// blocking them or only warning, as configured. Without a checker every
+// password is accepted.
+type BreachedPasswordGuard struct {
+	checker ports.BreachedPasswordChecker
+	action  domain.BreachedPasswordAction
+}
+
+func NewBreachedPasswordGuard(checker ports.BreachedPasswordChecker, action domain.BreachedPasswordAction) *BreachedPasswordGuard {
+	return &BreachedPasswordGuard{checker: checker, action: action}
+}
+
+// check reports whether password appears in a breach, failing with
+// ErrBreachedPassword when such passwords are blocked. A failed check is
+// logged and the password accepted, so an unreachable breach database does
+// not stop registrations.
+func (g *BreachedPasswordGuard) check(ctx context.Context, password string) (bool, error) {
+	if g.checker == nil {
+		return false, nil
+	}
+
+	breached, err := g.checker.IsBreached(ctx, password)
+	if err != nil {
+		slog.WarnContext(ctx, "check breached password", "error", err)
+		return false, nil
+	}
+	if breached && g.action == domain.BreachedPasswordBlock {
+		return true, domain.ErrBreachedPassword
+	}
+	return breached, nil
+}
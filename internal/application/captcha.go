@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// CaptchaPolicy selects the requests that must carry a solved CAPTCHA.
+// LoginAfterFailures requires one on logins once the auth method has failed
+// that many times in a row; zero never does.
+type CaptchaPolicy struct {
+	Register           bool
+	PasswordReset      bool
+	LoginAfterFailures int
+}
+
+// CaptchaGuard enforces a CaptchaPolicy with the configured verifier. Without
+// a verifier no request needs a CAPTCHA.
+type CaptchaGuard struct {
+	verifier ports.CaptchaVerifier
+	policy   CaptchaPolicy
+}
+
+func NewCaptchaGuard(verifier ports.CaptchaVerifier, policy CaptchaPolicy) *CaptchaGuard {
+	return &CaptchaGuard{verifier: verifier, policy: policy}
+}
+
+func (g *CaptchaGuard) register(ctx context.Context, client ClientInfo) error {
+	if !g.policy.Register {
+		return nil
+	}
+	return g.verify(ctx, client)
+}
+
+func (g *CaptchaGuard) passwordReset(ctx context.Context, client ClientInfo) error {
+	if !g.policy.PasswordReset {
+		return nil
+	}
+	return g.verify(ctx, client)
+}
+
+// login requires a CAPTCHA when method has failed more times in a row than
+// the policy allows without one.
+func (g *CaptchaGuard) login(ctx context.Context, method *models.AuthMethod, client ClientInfo) error {
+	if g.policy.LoginAfterFailures <= 0 || method.FailedAttempts < g.policy.LoginAfterFailures {
+		return nil
+	}
+	return g.verify(ctx, client)
+}
+
+func (g *CaptchaGuard) verify(ctx context.Context, client ClientInfo) error {
+	if g.verifier == nil {
+		return nil
+	}
+	if client.CaptchaToken == "" {
+		return domain.ErrCaptchaRequired
+	}
+
+	ok, err := g.verifier.Verify(ctx, client.CaptchaToken, client.IPAddress)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidCaptcha
+	}
+	return nil
+}
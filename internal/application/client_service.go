@@ -0,0 +1,225 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// ClientRegistration is an OAuth client defined by the operator.
+type ClientRegistration struct {
+	ClientID     string
+	Name         string
+	Secret       string
+	Public       bool
+	RedirectURIs []string
+	// GrantTypes defaults to the authorization code and refresh token
+	// grants.
+	GrantTypes []string
+	Scopes     []string
+	// ExchangeAudiences and Impersonation are the token exchange policy of
+	// the client.
+	ExchangeAudiences []string
+	Impersonation     bool
+}
+
+// ClientToken is an access token a client obtained for itself.
+type ClientToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	Scope       string
+}
+
+// grantTypes are the grants clients can be registered for.
+var grantTypes = []string{
+	domain.GrantTypeAuthorizationCode,
+	domain.GrantTypeRefreshToken,
+	domain.GrantTypeClientCredentials,
+	domain.GrantTypeDeviceCode,
+	domain.GrantTypeTokenExchange,
+}
+
+// ClientService keeps the OAuth clients of the authorization server,
+// authenticates their requests and issues the tokens clients obtain for
+// themselves.
+type ClientService struct {
+	clients repositories.OAuthClientRepository
+	tokens  ports.TokenService
+}
+
+func NewClientService(clients repositories.OAuthClientRepository, tokens ports.TokenService) *ClientService {
+	return &ClientService{clients: clients, tokens: tokens}
+}
+
+// Sync registers every configured client, replacing the secret of clients
+// already registered.
+func (s *ClientService) Sync(ctx context.Context, registrations []ClientRegistration) error {
+	for _, registration := range registrations {
+		var secretHash *string
+		switch {
+		case registration.Public && registration.Secret != "":
+			return fmt.Errorf("client %s: public clients have no secret", registration.ClientID)
+		case registration.Public:
+		case len(registration.Secret) < domain.MinClientSecretLength:
+			return fmt.Errorf("client %s: secret must be at least %d characters", registration.ClientID, domain.MinClientSecretLength)
+		default:
+			hash := security.HashToken(registration.Secret)
+			secretHash = &hash
+		}
+
+		for _, uri := range registration.RedirectURIs {
+			if err := validateRedirectURI(uri); err != nil {
+				return fmt.Errorf("client %s: %w", registration.ClientID, err)
+			}
+		}
+
+		registered := registration.GrantTypes
+		if len(registered) == 0 {
+			registered = []string{domain.GrantTypeAuthorizationCode, domain.GrantTypeRefreshToken}
+		}
+		for _, grantType := range registered {
+			if !slices.Contains(grantTypes, grantType) {
+				return fmt.Errorf("client %s: unsupported grant type %q", registration.ClientID, grantType)
+			}
+		}
+		if registration.Public && slices.Contains(registered, domain.GrantTypeClientCredentials) {
+			return fmt.Errorf("client %s: public clients cannot use the client credentials grant", registration.ClientID)
+		}
+		if slices.Contains(registered, domain.GrantTypeTokenExchange) {
+			if registration.Public {
+				return fmt.Errorf("client %s: public clients cannot use the token exchange grant", registration.ClientID)
+			}
+			if len(registration.ExchangeAudiences) == 0 {
+				return fmt.Errorf("client %s: the token exchange grant needs at least one exchange audience", registration.ClientID)
+			}
+		}
+
+		client := &models.OAuthClient{
+			ID:                uuid.New(),
+			ClientID:          registration.ClientID,
+			Name:              registration.Name,
+			SecretHash:        secretHash,
+			Public:            registration.Public,
+			RedirectURIs:      registration.RedirectURIs,
+			GrantTypes:        registered,
+			Scopes:            registration.Scopes,
+			ExchangeAudiences: registration.ExchangeAudiences,
+			Impersonation:     registration.Impersonation,
+		}
+		if err := s.clients.Upsert(ctx, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Authenticate checks a client ID and secret pair. Public clients have no
+// secret and never authenticate this way.
+func (s *ClientService) Authenticate(ctx context.Context, clientID, secret string) (*models.OAuthClient, error) {
+	if clientID == "" || secret == "" {
+		return nil, domain.ErrInvalidClient
+	}
+
+	client, err := s.client(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.SecretHash == nil || !security.CompareTokenHash(secret, *client.SecretHash) {
+		return nil, domain.ErrInvalidClient
+	}
+	return client, nil
+}
+
+// Identify accepts a public client by its client ID alone, and
+// authenticates confidential clients with their secret.
+func (s *ClientService) Identify(ctx context.Context, clientID, secret string) (*models.OAuthClient, error) {
+	if secret != "" {
+		return s.Authenticate(ctx, clientID, secret)
+	}
+	if clientID == "" {
+		return nil, domain.ErrInvalidClient
+	}
+
+	client, err := s.client(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !client.Public {
+		return nil, domain.ErrInvalidClient
+	}
+	return client, nil
+}
+
+// IssueToken implements the client credentials grant. The requested scope
+// must be among the client's scopes; without one, the client receives all of
+// them. A key thumbprint binds the token to the client's DPoP key.
+func (s *ClientService) IssueToken(ctx context.Context, client *models.OAuthClient, scope, keyThumbprint string) (*ClientToken, error) {
+	if client.Public || !client.AllowsGrantType(domain.GrantTypeClientCredentials) {
+		return nil, domain.ErrUnauthorizedClient
+	}
+
+	granted, err := grantedScopes(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	token, claims, err := s.tokens.GenerateClientToken(ctx, client, models.ClientTokenOptions{
+		Scope:         granted,
+		KeyThumbprint: keyThumbprint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientToken{
+		AccessToken: token,
+		ExpiresAt:   claims.ExpiresAt,
+		Scope:       string(claims.Scope),
+	}, nil
+}
+
+// grantedScopes checks that the requested scopes are among the client's
+// scopes, and grants all of them when none were requested.
+func grantedScopes(client *models.OAuthClient, scope string) (string, error) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return strings.Join(client.Scopes, " "), nil
+	}
+
+	for _, name := range requested {
+		if !slices.Contains(client.Scopes, name) {
+			return "", domain.ErrInvalidScope
+		}
+	}
+	return strings.Join(requested, " "), nil
+}
+
+func (s *ClientService) client(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	client, err := s.clients.GetByClientID(ctx, clientID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidClient
+	}
+	return client, err
+}
+
+// validateRedirectURI accepts absolute URIs without a fragment, as RFC 6749
+// section 3.1.2 requires.
+func validateRedirectURI(uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil || !parsed.IsAbs() || parsed.Host == "" || parsed.Fragment != "" {
+		return fmt.Errorf("invalid redirect uri %q", uri)
+	}
+	return nil
+}
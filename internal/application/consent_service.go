@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// ConsentService keeps the versions of the terms of service and privacy
+// policy that administrators publish, and which of them each account
+// accepted, when and from where. Until an account accepts the version of
+// each document published last, its logins get a session restricted to
+// accepting them.
+type ConsentService struct {
+	txManager ports.TxManager
+	documents repositories.LegalDocumentRepository
+	consents  repositories.ConsentRepository
+	audit     *AuditLog
+}
+
+func NewConsentService(
+	txManager ports.TxManager,
+	documents repositories.LegalDocumentRepository,
+	consents repositories.ConsentRepository,
+	audit *AuditLog,
+) *ConsentService {
+	return &ConsentService{
+		txManager: txManager,
+		documents: documents,
+		consents:  consents,
+		audit:     audit,
+	}
+}
+
+// Publish publishes a new version of a legal document, found at url, which
+// supersedes the previous one. Every account must then accept it before its
+// next login completes.
+func (s *ConsentService) Publish(ctx context.Context, adminID uuid.UUID, kind domain.LegalDocumentKind, version, rawURL string) (*models.LegalDocument, error) {
+	document, err := newLegalDocument(kind, version, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.documents.Create(txCtx, document); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return domain.ErrLegalDocumentExists
+			}
+			return err
+		}
+		return s.audit.record(txCtx, domain.AuditDocumentPublished, adminID, uuid.Nil, map[string]string{
+			"document_id": document.ID.String(),
+			"kind":        string(document.Kind),
+			"version":     document.Version,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// List returns every version of the legal documents, newest first.
+func (s *ConsentService) List(ctx context.Context) ([]*models.LegalDocument, error) {
+	return s.documents.List(ctx)
+}
+
+// Current returns the version of each legal document published last.
+func (s *ConsentService) Current(ctx context.Context) ([]*models.LegalDocument, error) {
+	return s.documents.ListCurrent(ctx)
+}
+
+// Pending returns the current legal documents the account has yet to accept.
+func (s *ConsentService) Pending(ctx context.Context, accountID uuid.UUID) ([]*models.LegalDocument, error) {
+	return s.documents.ListPendingByAccountID(ctx, accountID)
+}
+
+// History returns the consents of an account, newest first.
+func (s *ConsentService) History(ctx context.Context, accountID uuid.UUID) ([]*models.Consent, error) {
+	return s.consents.ListByAccountID(ctx, accountID)
+}
+
+// Accept records that the account accepted the given legal documents, from
+// the origin of the request. Only current versions can be accepted; those
+// already accepted are skipped.
+func (s *ConsentService) Accept(ctx context.Context, accountID uuid.UUID, documentIDs []uuid.UUID) error {
+	if len(documentIDs) == 0 {
+		return domain.ErrInvalidLegalDocument
+	}
+
+	origin := requestOrigin(ctx)
+	return s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		current, err := s.documents.ListCurrent(txCtx)
+		if err != nil {
+			return err
+		}
+		pending, err := s.documents.ListPendingByAccountID(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+
+		var accepted []*models.LegalDocument
+		for _, id := range documentIDs {
+			if !slices.ContainsFunc(current, func(d *models.LegalDocument) bool { return d.ID == id }) {
+				if _, err := s.documents.GetByID(txCtx, id); errors.Is(err, domain.ErrNotFound) {
+					return domain.ErrLegalDocumentNotFound
+				} else if err != nil {
+					return err
+				}
+				return domain.ErrLegalDocumentSuperseded
+			}
+			index := slices.IndexFunc(pending, func(d *models.LegalDocument) bool { return d.ID == id })
+			if index >= 0 && !slices.Contains(accepted, pending[index]) {
+				accepted = append(accepted, pending[index])
+			}
+		}
+
+		for _, document := range accepted {
+			consent := &models.Consent{
+				ID:         uuid.New(),
+				AccountID:  accountID,
+				DocumentID: document.ID,
+				IPAddress:  optional(origin.IPAddress),
+				UserAgent:  optional(origin.UserAgent),
+			}
+			if err := s.consents.Create(txCtx, consent); err != nil {
+				return err
+			}
+			if err := s.audit.record(txCtx, domain.AuditConsentAccepted, accountID, accountID, map[string]string{
+				"document_id": document.ID.String(),
+				"kind":        string(document.Kind),
+				"version":     document.Version,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// newLegalDocument checks a version of a legal document about to be
+// published, whose URL must be an https one.
+func newLegalDocument(kind domain.LegalDocumentKind, version, rawURL string) (*models.LegalDocument, error) {
+	if kind != domain.LegalDocumentTermsOfService && kind != domain.LegalDocumentPrivacyPolicy {
+		return nil, domain.ErrInvalidLegalDocument
+	}
+	version = strings.TrimSpace(version)
+	if version == "" || utf8.RuneCountInString(version) > domain.MaxLegalDocumentVersionLength {
+		return nil, domain.ErrInvalidLegalDocument
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" || len(rawURL) > domain.MaxLegalDocumentURLLength {
+		return nil, domain.ErrInvalidLegalDocument
+	}
+	return &models.LegalDocument{ID: uuid.New(), Kind: kind, Version: version, URL: rawURL}, nil
+}
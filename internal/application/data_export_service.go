@@ -0,0 +1,360 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// DataExportService hands account holders a copy of the data this service
+// holds about them: their account, auth methods, sessions, consents and
+// audit events. Archives are generated in the background, then downloaded
+// through a signed link until they expire, so the download needs no session.
+type DataExportService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	refreshTokens repositories.RefreshTokenRepository
+	consents      repositories.ConsentRepository
+	exports       repositories.DataExportRepository
+	signer        *security.Signer
+	// downloadURL is the download endpoint the signed link points to.
+	downloadURL string
+	audit       *AuditLog
+	eventBus    ports.EventBus
+}
+
+func NewDataExportService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+	consents repositories.ConsentRepository,
+	exports repositories.DataExportRepository,
+	signer *security.Signer,
+	downloadURL string,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *DataExportService {
+	return &DataExportService{
+		txManager:     txManager,
+		accounts:      accounts,
+		authMethods:   authMethods,
+		refreshTokens: refreshTokens,
+		consents:      consents,
+		exports:       exports,
+		signer:        signer,
+		downloadURL:   downloadURL,
+		audit:         audit,
+		eventBus:      eventBus,
+	}
+}
+
+// Request queues an export of an account's data. An account has at most one
+// export waiting to be generated.
+func (s *DataExportService) Request(ctx context.Context, accountID uuid.UUID) (*models.DataExport, error) {
+	export := &models.DataExport{ID: uuid.New(), AccountID: accountID}
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.exports.Create(txCtx, export); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return domain.ErrDataExportInProgress
+			}
+			return err
+		}
+		return s.audit.record(txCtx, domain.AuditDataExportRequested, accountID, accountID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// List returns the exports of an account, newest first.
+func (s *DataExportService) List(ctx context.Context, accountID uuid.UUID) ([]*models.DataExport, error) {
+	return s.exports.ListByAccountID(ctx, accountID)
+}
+
+// GenerateDue generates the archives of a batch of pending exports, oldest
+// first, and emails their holders the download link. It returns how many it
+// generated.
+func (s *DataExportService) GenerateDue(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.exports.ListPending(ctx, domain.DataExportBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, export := range pending {
+		if err := s.generate(ctx, export, now); err != nil {
+			return generated, fmt.Errorf("generate data export %s: %w", export.ID, err)
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+func (s *DataExportService) generate(ctx context.Context, export *models.DataExport, now time.Time) error {
+	account, err := s.accounts.GetByID(ctx, export.AccountID)
+	if err != nil {
+		return err
+	}
+	methods, err := s.authMethods.ListByAccountID(ctx, export.AccountID)
+	if err != nil {
+		return err
+	}
+	tokens, err := s.refreshTokens.ListByAccountID(ctx, export.AccountID)
+	if err != nil {
+		return err
+	}
+	consents, err := s.consents.ListByAccountID(ctx, export.AccountID)
+	if err != nil {
+		return err
+	}
+	auditEvents, err := s.auditEvents(ctx, export.AccountID)
+	if err != nil {
+		return err
+	}
+
+	archive, err := json.MarshalIndent(newDataExportArchive(account, methods, tokens, consents, auditEvents, now), "", "  ")
+	if err != nil {
+		return err
+	}
+	expiresAt := now.Add(domain.DataExportTTL)
+	export.Status, export.ExpiresAt = domain.DataExportReady, &expiresAt
+	link, err := s.DownloadLink(export)
+	if err != nil {
+		return err
+	}
+	if err := s.exports.Complete(ctx, export.ID, archive, now, expiresAt); err != nil {
+		return err
+	}
+
+	event := events.DataExportReadyEvent{
+		AccountID: export.AccountID,
+		ExportID:  export.ID,
+		Link:      link,
+		ExpiresIn: int(domain.DataExportTTL.Seconds()),
+	}
+	for _, method := range methods {
+		if method.ProviderCode == domain.ProviderEmail && method.IsVerified {
+			event.Email = method.ProviderID
+			break
+		}
+	}
+	if event.Email == "" {
+		// Accounts without a verified address get their link from List.
+		slog.InfoContext(ctx, "no email for ready data export", "export_id", export.ID)
+		return nil
+	}
+	publish(ctx, s.eventBus, event)
+	return nil
+}
+
+// auditEvents returns every audit event of the account, newest first.
+func (s *DataExportService) auditEvents(ctx context.Context, accountID uuid.UUID) ([]*models.AuditEvent, error) {
+	var all []*models.AuditEvent
+	after := models.AuditEventCursor{}
+	for {
+		page, err := s.audit.Search(ctx, models.AuditEventFilter{TargetID: accountID}, after, domain.AuditEventMaxLimit)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Events...)
+		if page.Next == nil {
+			return all, nil
+		}
+		after = *page.Next
+	}
+}
+
+// DownloadLink returns the signed link that downloads a READY export until
+// it expires.
+func (s *DataExportService) DownloadLink(export *models.DataExport) (string, error) {
+	if export.Status != domain.DataExportReady || export.ExpiresAt == nil {
+		return "", domain.ErrDataExportNotFound
+	}
+	u, err := url.Parse(s.downloadURL)
+	if err != nil {
+		return "", err
+	}
+	if !u.IsAbs() {
+		return "", fmt.Errorf("%q is not an absolute URL", s.downloadURL)
+	}
+
+	expires := strconv.FormatInt(export.ExpiresAt.Unix(), 10)
+	query := u.Query()
+	query.Set("id", export.ID.String())
+	query.Set("expires", expires)
+	query.Set("signature", s.signer.Sign(export.ID.String()+"."+expires))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Download returns the archive a signed link points to. Links that were
+// altered or have expired fail with domain.ErrInvalidDownloadLink.
+func (s *DataExportService) Download(ctx context.Context, id, expires, signature string) (*models.DataExport, error) {
+	if !s.signer.Verify(id+"."+expires, signature) {
+		return nil, domain.ErrInvalidDownloadLink
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !time.Now().Before(time.Unix(expiresAt, 0)) {
+		return nil, domain.ErrInvalidDownloadLink
+	}
+	exportID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrInvalidDownloadLink
+	}
+
+	export, err := s.exports.GetByID(ctx, exportID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrDataExportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != domain.DataExportReady {
+		return nil, domain.ErrDataExportNotFound
+	}
+	return export, nil
+}
+
+// PurgeExpired deletes, in batches, the exports expired at now and returns
+// how many it deleted.
+func (s *DataExportService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	var purged int64
+	for {
+		deleted, err := s.exports.DeleteExpired(ctx, now, domain.DataExportPurgeBatch)
+		purged += deleted
+		if err != nil || deleted < domain.DataExportPurgeBatch {
+			return purged, err
+		}
+	}
+}
+
+// dataExportArchive is the JSON document handed to the account holder.
+type dataExportArchive struct {
+	ExportedAt  time.Time              `json:"exported_at"`
+	Account     dataExportAccount      `json:"account"`
+	AuthMethods []dataExportAuthMethod `json:"auth_methods"`
+	Sessions    []dataExportSession    `json:"sessions"`
+	Consents    []dataExportConsent    `json:"consents"`
+	AuditEvents []dataExportAuditEvent `json:"audit_events"`
+}
+
+type dataExportAccount struct {
+	ID        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type dataExportAuthMethod struct {
+	Provider    string     `json:"provider"`
+	ProviderID  string     `json:"provider_id"`
+	Verified    bool       `json:"verified"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+type dataExportSession struct {
+	SessionID   uuid.UUID  `json:"session_id"`
+	IPAddress   *string    `json:"ip_address,omitempty"`
+	UserAgent   *string    `json:"user_agent,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	RefreshedAt time.Time  `json:"refreshed_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+type dataExportConsent struct {
+	Document   string    `json:"document"`
+	Version    string    `json:"version"`
+	IPAddress  *string   `json:"ip_address,omitempty"`
+	UserAgent  *string   `json:"user_agent,omitempty"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+type dataExportAuditEvent struct {
+	Action    string            `json:"action"`
+	IPAddress *string           `json:"ip_address,omitempty"`
+	UserAgent *string           `json:"user_agent,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// newDataExportArchive assembles the archive. Sessions are rebuilt from
+// their refresh tokens, newest first, each as of its latest rotation.
+func newDataExportArchive(account *models.Account, methods []*models.AuthMethod, tokens []*models.RefreshToken, consents []*models.Consent, auditEvents []*models.AuditEvent, now time.Time) dataExportArchive {
+	archive := dataExportArchive{
+		ExportedAt: now,
+		Account: dataExportAccount{
+			ID:        account.ID,
+			Role:      string(account.RoleCode),
+			Status:    string(account.StatusCode),
+			CreatedAt: account.CreatedAt,
+		},
+		AuthMethods: make([]dataExportAuthMethod, 0, len(methods)),
+		Sessions:    []dataExportSession{},
+		Consents:    make([]dataExportConsent, 0, len(consents)),
+		AuditEvents: make([]dataExportAuditEvent, 0, len(auditEvents)),
+	}
+
+	for _, method := range methods {
+		archive.AuthMethods = append(archive.AuthMethods, dataExportAuthMethod{
+			Provider:    string(method.ProviderCode),
+			ProviderID:  method.ProviderID,
+			Verified:    method.IsVerified,
+			LastLoginAt: method.LastLoginAt,
+		})
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, token := range tokens {
+		if seen[token.SessionID] {
+			continue
+		}
+		seen[token.SessionID] = true
+		archive.Sessions = append(archive.Sessions, dataExportSession{
+			SessionID:   token.SessionID,
+			IPAddress:   token.IPAddress,
+			UserAgent:   token.UserAgent,
+			StartedAt:   token.SessionStartedAt,
+			RefreshedAt: token.CreatedAt,
+			ExpiresAt:   token.ExpiresAt,
+			RevokedAt:   token.RevokedAt,
+		})
+	}
+
+	for _, consent := range consents {
+		archive.Consents = append(archive.Consents, dataExportConsent{
+			Document:   string(consent.Kind),
+			Version:    consent.Version,
+			IPAddress:  consent.IPAddress,
+			UserAgent:  consent.UserAgent,
+			AcceptedAt: consent.AcceptedAt,
+		})
+	}
+
+	for _, event := range auditEvents {
+		archive.AuditEvents = append(archive.AuditEvents, dataExportAuditEvent{
+			Action:    string(event.Action),
+			IPAddress: event.IPAddress,
+			UserAgent: event.UserAgent,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return archive
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+// RetentionPolicy is how long each class of personal data is kept before it
+// is pruned.
+type RetentionPolicy struct {
+	// LoginOrigins is how long the IP addresses and user agents recorded
+	// with sessions, trusted devices and audit events are kept; 0 keeps
+	// them.
+	LoginOrigins time.Duration
+	// AuditEvents is how long audit events are kept; 0 keeps them.
+	AuditEvents time.Duration
+	// VerificationCodes and RefreshTokens are how long codes and tokens are
+	// kept once they have expired, been consumed or been revoked.
+	VerificationCodes time.Duration
+	RefreshTokens     time.Duration
+}
+
+// DataRetention prunes the personal data whose retention period has ended,
+// in batches so no purge locks a large part of a table. Each purge is meant
+// to run as a scheduled job.
+type DataRetention struct {
+	refreshTokens     repositories.RefreshTokenRepository
+	trustedDevices    repositories.TrustedDeviceRepository
+	auditEvents       repositories.AuditEventRepository
+	verificationCodes repositories.VerificationCodeRepository
+	policy            atomic.Pointer[RetentionPolicy]
+}
+
+func NewDataRetention(
+	refreshTokens repositories.RefreshTokenRepository,
+	trustedDevices repositories.TrustedDeviceRepository,
+	auditEvents repositories.AuditEventRepository,
+	verificationCodes repositories.VerificationCodeRepository,
+	policy RetentionPolicy,
+) *DataRetention {
+	retention := &DataRetention{
+		refreshTokens:     refreshTokens,
+		trustedDevices:    trustedDevices,
+		auditEvents:       auditEvents,
+		verificationCodes: verificationCodes,
+	}
+	retention.SetPolicy(policy)
+	return retention
+}
+
+// SetPolicy changes the retention periods the next purges enforce.
+func (r *DataRetention) SetPolicy(policy RetentionPolicy) {
+	r.policy.Store(&policy)
+}
+
+// PurgeLoginOrigins clears the IP addresses and user agents recorded more
+// than LoginOrigins before now, and returns how many rows it cleared.
+func (r *DataRetention) PurgeLoginOrigins(ctx context.Context, now time.Time) (int64, error) {
+	period := r.policy.Load().LoginOrigins
+	if period == 0 {
+		return 0, nil
+	}
+	before := now.Add(-period)
+
+	var cleared int64
+	for _, clearOrigins := range []func(ctx context.Context, before time.Time, limit int) (int64, error){
+		r.refreshTokens.ClearOrigins,
+		r.trustedDevices.ClearOrigins,
+		r.auditEvents.ClearOrigins,
+	} {
+		purged, err := purgeInBatches(ctx, before, domain.LoginOriginPurgeBatch, clearOrigins)
+		cleared += purged
+		if err != nil {
+			return cleared, err
+		}
+	}
+	return cleared, nil
+}
+
+// PurgeAuditEvents deletes the audit events recorded more than AuditEvents
+// before now, and returns how many it deleted.
+func (r *DataRetention) PurgeAuditEvents(ctx context.Context, now time.Time) (int64, error) {
+	period := r.policy.Load().AuditEvents
+	if period == 0 {
+		return 0, nil
+	}
+	return purgeInBatches(ctx, now.Add(-period), domain.AuditEventPurgeBatch, r.auditEvents.DeleteBefore)
+}
+
+// PurgeVerificationCodes deletes the codes that expired or were consumed
+// more than VerificationCodes before now, and returns how many it deleted.
+func (r *DataRetention) PurgeVerificationCodes(ctx context.Context, now time.Time) (int64, error) {
+	before := now.Add(-r.policy.Load().VerificationCodes)
+	return purgeInBatches(ctx, before, domain.VerificationCodePurgeBatch, r.verificationCodes.DeleteStale)
+}
+
+// PurgeRefreshTokens deletes the refresh tokens that expired or were revoked
+// more than RefreshTokens before now, and returns how many it deleted.
+func (r *DataRetention) PurgeRefreshTokens(ctx context.Context, now time.Time) (int64, error) {
+	before := now.Add(-r.policy.Load().RefreshTokens)
+	return purgeInBatches(ctx, before, domain.RefreshTokenPurgeBatch, r.refreshTokens.DeleteStale)
+}
+
+// purgeInBatches calls purge with batch until it prunes fewer rows than
+// that, and returns how many rows it pruned in all.
+func purgeInBatches(ctx context.Context, before time.Time, batch int, purge func(ctx context.Context, before time.Time, limit int) (int64, error)) (int64, error) {
+	var purged int64
+	for {
+		pruned, err := purge(ctx, before, batch)
+		purged += pruned
+		if err != nil || pruned < int64(batch) {
+			return purged, err
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// DeviceAuthorization is a new device authorization request. The device
+// shows the user code and polls the token endpoint with the device code.
+type DeviceAuthorization struct {
+	DeviceCode string
+	UserCode   string
+	ExpiresAt  time.Time
+	Interval   time.Duration
+}
+
+// DeviceRequest describes a pending device authorization request to the user
+// who entered its user code, so the verification page can ask for approval.
+type DeviceRequest struct {
+	ClientName string
+	Scope      string
+	ExpiresAt  time.Time
+}
+
+// StartDeviceAuthorization begins the device authorization grant of RFC 8628
+// for a client registered for it.
+func (s *AuthorizationService) StartDeviceAuthorization(ctx context.Context, client *models.OAuthClient, scope string) (*DeviceAuthorization, error) {
+	if !client.AllowsGrantType(domain.GrantTypeDeviceCode) {
+		return nil, domain.ErrUnauthorizedClient
+	}
+	scope, err := authorizedScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceCode, err := security.GenerateOpaqueToken(domain.DeviceCodeBytes)
+	if err != nil {
+		return nil, err
+	}
+	userCode, err := security.GenerateUserCode(domain.UserCodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	code := &models.DeviceCode{
+		ID:             uuid.New(),
+		DeviceCodeHash: security.HashToken(deviceCode),
+		UserCodeHash:   security.HashToken(security.NormalizeUserCode(userCode)),
+		ClientID:       client.ID,
+		Scope:          scope,
+		Interval:       domain.DevicePollInterval,
+		ExpiresAt:      time.Now().UTC().Add(domain.DeviceCodeTTL),
+	}
+	if err := s.deviceCodes.Create(ctx, code); err != nil {
+		return nil, err
+	}
+
+	return &DeviceAuthorization{
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		ExpiresAt:  code.ExpiresAt,
+		Interval:   code.Interval,
+	}, nil
+}
+
+// DescribeDeviceRequest returns the pending request of a user code.
+func (s *AuthorizationService) DescribeDeviceRequest(ctx context.Context, userCode string) (*DeviceRequest, error) {
+	code, err := s.pendingDeviceCode(ctx, userCode)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.clients.GetByID(ctx, code.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceRequest{ClientName: client.Name, Scope: code.Scope, ExpiresAt: code.ExpiresAt}, nil
+}
+
+// ApproveDevice lets the device behind a user code sign in as the caller.
+// The device gets a session of its own; the caller's session only vouches
+// for the authentication time.
+func (s *AuthorizationService) ApproveDevice(ctx context.Context, claims *models.AccessTokenClaims, userCode string) error {
+	return s.decideDevice(ctx, claims, userCode, domain.DeviceCodeApproved)
+}
+
+// DenyDevice refuses the request behind a user code; the device receives
+// access_denied on its next poll.
+func (s *AuthorizationService) DenyDevice(ctx context.Context, claims *models.AccessTokenClaims, userCode string) error {
+	return s.decideDevice(ctx, claims, userCode, domain.DeviceCodeDenied)
+}
+
+// PollDevice answers a token request of the device authorization grant.
+// Until the user decides, the device gets ErrAuthorizationPending, or
+// ErrSlowDown when it polls faster than its interval, which then grows.
+func (s *AuthorizationService) PollDevice(ctx context.Context, client *models.OAuthClient, deviceCode string, clientInfo ClientInfo) (*TokenGrant, error) {
+	code, err := s.deviceCodes.GetByDeviceCodeHash(ctx, security.HashToken(deviceCode))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidGrant
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if code.ClientID != client.ID || code.ConsumedAt != nil {
+		return nil, domain.ErrInvalidGrant
+	}
+	if !now.Before(code.ExpiresAt) {
+		return nil, domain.ErrExpiredToken
+	}
+
+	interval := code.Interval
+	tooFast := code.LastPolledAt != nil && now.Sub(*code.LastPolledAt) < interval
+	if tooFast {
+		interval += domain.DevicePollSlowDown
+	}
+	if err := s.deviceCodes.RecordPoll(ctx, code.ID, now, interval); err != nil {
+		return nil, err
+	}
+	if tooFast {
+		return nil, domain.ErrSlowDown
+	}
+
+	switch code.Status {
+	case domain.DeviceCodePending:
+		return nil, domain.ErrAuthorizationPending
+	case domain.DeviceCodeDenied:
+		return nil, domain.ErrAccessDenied
+	}
+
+	account, err := s.accounts.GetByID(ctx, *code.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	clientInfo.Scope = sessionScope(code.Scope)
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.deviceCodes.Consume(txCtx, code.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidGrant
+			}
+			return err
+		}
+
+		result, err = s.sessions.open(txCtx, account, clientInfo)
+		if err != nil {
+			return err
+		}
+		if result.Restricted() {
+			return domain.ErrInvalidGrant
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	grant := &TokenGrant{AuthResult: result, Scope: grantedScope(code.Scope, result.Scope)}
+	if slices.Contains(strings.Fields(code.Scope), domain.ScopeOpenID) {
+		grant.IDToken, err = s.idToken(ctx, account, client, code.Scope, models.IDTokenOptions{
+			SessionID: result.SessionID,
+			AuthTime:  *code.AuthTime,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return grant, nil
+}
+
+func (s *AuthorizationService) decideDevice(ctx context.Context, claims *models.AccessTokenClaims, userCode string, status domain.DeviceCodeStatus) error {
+	if claims.SessionID == uuid.Nil {
+		return domain.ErrInvalidAccessToken
+	}
+
+	session, err := s.activeSession(ctx, claims.AccountID, claims.SessionID)
+	if err != nil {
+		return err
+	}
+
+	code, err := s.pendingDeviceCode(ctx, userCode)
+	if err != nil {
+		return err
+	}
+
+	err = s.deviceCodes.Decide(ctx, code.ID, status, session.AccountID, session.SessionStartedAt)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidUserCode
+	}
+	return err
+}
+
+func (s *AuthorizationService) pendingDeviceCode(ctx context.Context, userCode string) (*models.DeviceCode, error) {
+	normalized := security.NormalizeUserCode(userCode)
+	if normalized == "" {
+		return nil, domain.ErrInvalidUserCode
+	}
+
+	code, err := s.deviceCodes.GetPendingByUserCodeHash(ctx, security.HashToken(normalized), time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidUserCode
+	}
+	return code, err
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// DisposableEmailGuard screens email registrations against a list of
+// disposable email domains, rejecting or flagging them as configured.
+// Without a checker every address is accepted.
+type DisposableEmailGuard struct {
+	checker ports.DisposableEmailChecker
+	action  domain.DisposableEmailAction
+}
+
+func NewDisposableEmailGuard(checker ports.DisposableEmailChecker, action domain.DisposableEmailAction) *DisposableEmailGuard {
+	return &DisposableEmailGuard{checker: checker, action: action}
+}
+
+// check reports whether the normalized address email is disposable, failing
+// with ErrDisposableEmail when such addresses are rejected.
+func (g *DisposableEmailGuard) check(email string) (bool, error) {
+	if g.checker == nil {
+		return false, nil
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	if !g.checker.IsDisposable(email[at+1:]) {
+		return false, nil
+	}
+	if g.action == domain.DisposableEmailReject {
+		return true, domain.ErrDisposableEmail
+	}
+	return true, nil
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+)
+
+// DPoPValidator accepts DPoP proofs (RFC 9449): on top of the signature
+// checks of the parser, it binds a proof to the request it came with and
+// rejects proofs that were already used.
+type DPoPValidator struct {
+	proofs  ports.DPoPProofParser
+	replays ports.ReplayCache
+}
+
+func NewDPoPValidator(proofs ports.DPoPProofParser, replays ports.ReplayCache) *DPoPValidator {
+	return &DPoPValidator{proofs: proofs, replays: replays}
+}
+
+// Algorithms lists the accepted proof signing algorithms.
+func (v *DPoPValidator) Algorithms() []string {
+	return v.proofs.Algorithms()
+}
+
+// Validate checks that proof was created moments ago for a request with the
+// given method and URI, and returns the thumbprint of its key. Proofs sent
+// with an access token must carry its hash. Replay cache failures are
+// returned as is, so proofs are not accepted when replays cannot be ruled
+// out.
+func (v *DPoPValidator) Validate(ctx context.Context, proof, method, uri, accessToken string) (string, error) {
+	parsed, err := v.proofs.ParseProof(proof)
+	if err != nil {
+		return "", domain.ErrInvalidDPoPProof
+	}
+
+	if parsed.Method != method || !sameHTU(parsed.URI, uri) {
+		return "", domain.ErrInvalidDPoPProof
+	}
+
+	now := time.Now()
+	if parsed.IssuedAt.Before(now.Add(-domain.DPoPProofWindow)) || parsed.IssuedAt.After(now.Add(domain.DPoPProofWindow)) {
+		return "", domain.ErrInvalidDPoPProof
+	}
+
+	if accessToken != "" && !security.VerifyAccessTokenHash(accessToken, parsed.AccessTokenHash) {
+		return "", domain.ErrInvalidDPoPProof
+	}
+
+	fresh, err := v.replays.Claim(ctx, "dpop:"+parsed.KeyThumbprint+":"+parsed.TokenID, parsed.IssuedAt.Add(domain.DPoPProofWindow))
+	if err != nil {
+		return "", err
+	}
+	if !fresh {
+		return "", domain.ErrInvalidDPoPProof
+	}
+
+	return parsed.KeyThumbprint, nil
+}
+
+// sameHTU compares the htu claim with the request URI, ignoring the query and
+// fragment as RFC 9449 section 4.3 requires, and the case of the scheme and
+// host.
+func sameHTU(htu, uri string) bool {
+	claimed, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	actual, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(claimed.Scheme, actual.Scheme) &&
+		strings.EqualFold(claimed.Host, actual.Host) &&
+		normalizedPath(claimed) == normalizedPath(actual)
+}
+
+func normalizedPath(u *url.URL) string {
+	if u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
@@ -0,0 +1,24 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// publish emits an event after commit. Delivery failures are logged rather
+// than returned because the state change has already been persisted.
+func publish(ctx context.Context, bus ports.EventBus, event events.Event) {
+	if err := bus.Publish(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "publish event", "event", event.Name(), "error", err)
+	}
+}
+
+// publishWithin emits an event within the transaction of the change that
+// raised it. Failures are returned to roll the change back, since the outbox
+// stores the event in that transaction.
+func publishWithin(txCtx context.Context, bus ports.EventBus, event events.Event) error {
+	return bus.Publish(txCtx, event)
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// FeatureFlags decides whether a gated behavior applies to an account. A
+// feature without a flag keeps the behavior the service had before it was
+// gated: on, except for FeatureAdminMFA.
+type FeatureFlags struct {
+	memberships repositories.MembershipRepository
+	flags       atomic.Pointer[map[domain.Feature]models.FeatureFlag]
+}
+
+func NewFeatureFlags(memberships repositories.MembershipRepository, flags ...models.FeatureFlag) *FeatureFlags {
+	features := &FeatureFlags{memberships: memberships}
+	features.SetFlags(flags...)
+	return features
+}
+
+// SetFlags replaces every flag; features left out fall back to their
+// default.
+func (f *FeatureFlags) SetFlags(flags ...models.FeatureFlag) {
+	byKey := make(map[domain.Feature]models.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		byKey[flag.Key] = flag
+	}
+	f.flags.Store(&byKey)
+}
+
+// Enabled reports whether feature is on for the account. Accounts not
+// created yet are evaluated by the ID they are about to get, outside of any
+// organization.
+func (f *FeatureFlags) Enabled(ctx context.Context, feature domain.Feature, accountID uuid.UUID) (bool, error) {
+	flag, ok := (*f.flags.Load())[feature]
+	switch {
+	case !ok:
+		return feature != domain.FeatureAdminMFA, nil
+	case flag.Enabled:
+		return true, nil
+	case flag.Percentage > 0 && featureBucket(feature, accountID) < flag.Percentage:
+		return true, nil
+	case len(flag.Organizations) == 0:
+		return false, nil
+	}
+
+	memberships, err := f.memberships.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+	for _, membership := range memberships {
+		if slices.Contains(flag.Organizations, membership.OrganizationID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// require returns ErrFeatureNotEnabled unless feature is on for the account.
+func (f *FeatureFlags) require(ctx context.Context, feature domain.Feature, accountID uuid.UUID) error {
+	enabled, err := f.Enabled(ctx, feature, accountID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return domain.ErrFeatureNotEnabled
+	}
+	return nil
+}
+
+// providerFeature is the feature gating the rollout of an OAuth provider.
+func providerFeature(provider domain.Provider) domain.Feature {
+	return domain.Feature(domain.FeatureProviderPrefix + strings.ToLower(string(provider)))
+}
+
+// featureBucket places an account in one of FeatureBuckets buckets. The
+// feature is part of the hash so that a rollout at a given percentage does
+// not reach the same accounts for every feature.
+func featureBucket(feature domain.Feature, accountID uuid.UUID) int {
+	h := fnv.New32a()
+	h.Write([]byte(feature))
+	h.Write(accountID[:])
+	return int(h.Sum32() % domain.FeatureBuckets)
+}
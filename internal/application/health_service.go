@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// DependencyHealth is the outcome of checking one dependency. Error is empty
+// when it is available, and Version when its check reports none.
+type DependencyHealth struct {
+	Name     string
+	Version  string
+	Error    string
+	Duration time.Duration
+}
+
+func (d DependencyHealth) Healthy() bool {
+	return d.Error == ""
+}
+
+// HealthReport lists the dependencies in the order their checks were given.
+// Draining is set once the service is shutting down.
+type HealthReport struct {
+	Draining     bool
+	Dependencies []DependencyHealth
+}
+
+// Ready reports whether the service takes traffic: it is not draining and
+// every dependency is available.
+func (r *HealthReport) Ready() bool {
+	if r.Draining {
+		return false
+	}
+	for _, dependency := range r.Dependencies {
+		if !dependency.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// HealthService checks the dependencies the service needs before it can take
+// traffic.
+type HealthService struct {
+	checks   []ports.HealthCheck
+	draining atomic.Bool
+}
+
+func NewHealthService(checks ...ports.HealthCheck) *HealthService {
+	return &HealthService{checks: checks}
+}
+
+// Drain reports the service as not ready from then on, so it is taken out of
+// rotation while in-flight requests finish.
+func (s *HealthService) Drain() {
+	s.draining.Store(true)
+}
+
+// Check runs every check at once, each bounded by domain.HealthCheckTimeout.
+func (s *HealthService) Check(ctx context.Context) *HealthReport {
+	report := &HealthReport{Draining: s.draining.Load(), Dependencies: make([]DependencyHealth, len(s.checks))}
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Go(func() {
+			checkCtx, cancel := context.WithTimeout(ctx, domain.HealthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			dependency := DependencyHealth{Name: check.Name()}
+			var err error
+			if versioned, ok := check.(ports.VersionedHealthCheck); ok {
+				dependency.Version, err = versioned.CheckVersion(checkCtx)
+			} else {
+				err = check.Check(checkCtx)
+			}
+			if err != nil {
+				dependency.Error = err.Error()
+			}
+			dependency.Duration = time.Since(start)
+			report.Dependencies[i] = dependency
+		})
+	}
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,194 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// ImpersonationService lets administrators act as an account, to see what
+// its holder sees, through short-lived access tokens whose act claim names
+// the administrator. Impersonation tokens have no refresh token and are
+// refused by the endpoints that change how the account signs in. Every
+// impersonation is recorded with its reason.
+type ImpersonationService struct {
+	txManager      ports.TxManager
+	accounts       repositories.AccountRepository
+	roles          repositories.RoleRepository
+	impersonations repositories.ImpersonationRepository
+	tokens         ports.TokenService
+	denylist       ports.AccessTokenDenylist
+	audit          *AuditLog
+	eventBus       ports.EventBus
+}
+
+func NewImpersonationService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	roles repositories.RoleRepository,
+	impersonations repositories.ImpersonationRepository,
+	tokens ports.TokenService,
+	denylist ports.AccessTokenDenylist,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *ImpersonationService {
+	return &ImpersonationService{
+		txManager:      txManager,
+		accounts:       accounts,
+		roles:          roles,
+		impersonations: impersonations,
+		tokens:         tokens,
+		denylist:       denylist,
+		audit:          audit,
+		eventBus:       eventBus,
+	}
+}
+
+// ImpersonationToken is an access token issued to an administrator acting as
+// an account.
+type ImpersonationToken struct {
+	AccessToken   string
+	Impersonation *models.Impersonation
+}
+
+// Start issues an access token acting as an ACTIVE account for ttl, or
+// domain.ImpersonationTTL when ttl is zero. Administrators can neither
+// impersonate themselves nor other administrators.
+func (s *ImpersonationService) Start(ctx context.Context, adminID, accountID uuid.UUID, reason string, ttl time.Duration, client ClientInfo) (*ImpersonationToken, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > domain.MaxImpersonationReasonLength {
+		return nil, domain.ErrInvalidImpersonationReason
+	}
+	if ttl == 0 {
+		ttl = domain.ImpersonationTTL
+	}
+	if ttl < 0 || ttl > domain.MaxImpersonationTTL {
+		return nil, domain.ErrInvalidImpersonationDuration
+	}
+	if adminID == accountID {
+		return nil, domain.ErrCannotImpersonate
+	}
+
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode == domain.StatusDeleted {
+		return nil, domain.ErrAccountNotFound
+	}
+	if account.StatusCode != domain.StatusActive || account.RoleCode == domain.RoleAdmin {
+		return nil, domain.ErrCannotImpersonate
+	}
+	permissions, err := s.roles.ListPermissions(ctx, account.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// The impersonation ID stands for the session of the token, so it is
+	// reported by introspection and kept apart from the account's sessions.
+	impersonation := &models.Impersonation{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		AdminID:   &adminID,
+		Reason:    reason,
+		IPAddress: optional(client.IPAddress),
+		UserAgent: optional(client.UserAgent),
+	}
+	accessToken, claims, err := s.tokens.GenerateAccessToken(ctx, account, models.AccessTokenOptions{
+		Scope:       domain.TokenScopeFull,
+		SessionID:   impersonation.ID,
+		TTL:         ttl,
+		Actor:       &models.Actor{Subject: adminID.String(), Impersonator: true},
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	impersonation.TokenID = claims.TokenID
+	impersonation.ExpiresAt = claims.ExpiresAt
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.impersonations.Create(txCtx, impersonation); err != nil {
+			return err
+		}
+		return s.audit.record(txCtx, domain.AuditImpersonationStarted, adminID, accountID, map[string]string{
+			"impersonation_id": impersonation.ID.String(),
+			"reason":           reason,
+			"expires_at":       impersonation.ExpiresAt.UTC().Format(time.RFC3339),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.ImpersonationStartedEvent{
+		ImpersonationID: impersonation.ID,
+		AccountID:       accountID,
+		AdminID:         adminID,
+		Reason:          reason,
+		ExpiresAt:       impersonation.ExpiresAt,
+	})
+	return &ImpersonationToken{AccessToken: accessToken, Impersonation: impersonation}, nil
+}
+
+// End revokes the token of an impersonation before it expires.
+func (s *ImpersonationService) End(ctx context.Context, adminID, id uuid.UUID) (*models.Impersonation, error) {
+	impersonation, err := s.impersonations.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrImpersonationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.impersonations.End(txCtx, id, now); err != nil {
+			return err
+		}
+		return s.audit.record(txCtx, domain.AuditImpersonationEnded, adminID, impersonation.AccountID, map[string]string{
+			"impersonation_id": id.String(),
+		})
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrImpersonationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	impersonation.EndedAt = &now
+
+	if err := s.denylist.DenyToken(ctx, impersonation.TokenID, impersonation.ExpiresAt); err != nil {
+		slog.ErrorContext(ctx, "denylist impersonation", "impersonation_id", id, "error", err)
+	}
+	publish(ctx, s.eventBus, events.ImpersonationEndedEvent{
+		ImpersonationID: id,
+		AccountID:       impersonation.AccountID,
+		EndedBy:         adminID,
+	})
+	return impersonation, nil
+}
+
+// History returns every impersonation of an account, newest first.
+func (s *ImpersonationService) History(ctx context.Context, accountID uuid.UUID) ([]*models.Impersonation, error) {
+	if _, err := s.accounts.GetByID(ctx, accountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return s.impersonations.ListByAccountID(ctx, accountID)
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// ImportService creates the accounts of users migrated from another
+// identity provider. Their password hashes are kept as they are, and
+// replaced by Argon2id hashes at their first login.
+type ImportService struct {
+	txManager           ports.TxManager
+	accounts            repositories.AccountRepository
+	authMethods         repositories.AuthMethodRepository
+	passwordCredentials repositories.PasswordCredentialRepository
+	passwords           ports.PasswordHasher
+}
+
+func NewImportService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	passwordCredentials repositories.PasswordCredentialRepository,
+	passwords ports.PasswordHasher,
+) *ImportService {
+	return &ImportService{
+		txManager:           txManager,
+		accounts:            accounts,
+		authMethods:         authMethods,
+		passwordCredentials: passwordCredentials,
+		passwords:           passwords,
+	}
+}
+
+// ImportUser creates the account, EMAIL method and password of user in one
+// transaction. Users with a verified address become ACTIVE; the others stay
+// PENDING like an unconfirmed registration. Registered addresses fail with
+// ErrAccountAlreadyExists and hashes Verify cannot check with
+// ErrUnsupportedPasswordHash.
+func (s *ImportService) ImportUser(ctx context.Context, user *models.ImportedUser) error {
+	email, err := normalizeEmail(user.Email)
+	if err != nil {
+		return err
+	}
+	if user.PasswordHash != "" && !s.passwords.Supports(user.PasswordHash) {
+		return domain.ErrUnsupportedPasswordHash
+	}
+
+	status := domain.StatusPending
+	if user.EmailVerified {
+		status = domain.StatusActive
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account := &models.Account{
+			ID:         uuid.New(),
+			RoleCode:   domain.RoleUser,
+			StatusCode: status,
+		}
+		if err := s.accounts.Create(txCtx, account); err != nil {
+			return err
+		}
+
+		method := &models.AuthMethod{
+			ID:           uuid.New(),
+			AccountID:    account.ID,
+			ProviderCode: domain.ProviderEmail,
+			ProviderID:   email,
+			IsVerified:   user.EmailVerified,
+		}
+		if err := s.authMethods.Create(txCtx, method); err != nil {
+			return err
+		}
+
+		if user.PasswordHash == "" {
+			return nil
+		}
+		return s.passwordCredentials.Create(txCtx, &models.PasswordCredential{
+			AuthMethodID: method.ID,
+			PasswordHash: user.PasswordHash,
+		})
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return domain.ErrAccountAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// TokenIntrospection describes a token to a resource server. Only Active is
+// set for tokens that are unknown, expired or revoked.
+type TokenIntrospection struct {
+	Active    bool
+	TokenType string
+	Scope     domain.TokenScope
+	Subject   uuid.UUID
+	// ClientID is set instead of Subject on client tokens.
+	ClientID string
+	// RoleCode and StatusCode describe the account of account tokens.
+	RoleCode   domain.Role
+	StatusCode domain.Status
+	// Permissions are those carried by access tokens.
+	Permissions []string
+	// OrganizationID is the active organization of the session, and
+	// OrganizationRole the account's role in it on access tokens.
+	OrganizationID   uuid.UUID
+	OrganizationRole domain.Role
+	TokenID          string
+	SessionID        uuid.UUID
+	IssuedAt         time.Time
+	// ExpiresAt is zero for API keys that never expire.
+	ExpiresAt time.Time
+	// KeyThumbprint is the DPoP key the token is bound to, if any.
+	KeyThumbprint string
+	// Actor is the party acting as the subject of delegated and
+	// impersonation tokens.
+	Actor *models.Actor
+}
+
+// IntrospectionService answers RFC 7662 introspection requests for access
+// tokens and refresh tokens.
+type IntrospectionService struct {
+	validator     *TokenValidator
+	accounts      repositories.AccountRepository
+	refreshTokens repositories.RefreshTokenRepository
+}
+
+func NewIntrospectionService(
+	validator *TokenValidator,
+	accounts repositories.AccountRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+) *IntrospectionService {
+	return &IntrospectionService{
+		validator:     validator,
+		accounts:      accounts,
+		refreshTokens: refreshTokens,
+	}
+}
+
+// Introspect looks the token up as the type named by hint first, then as
+// the other type, as RFC 7662 requires when the hint is wrong.
+func (s *IntrospectionService) Introspect(ctx context.Context, token, hint string) (*TokenIntrospection, error) {
+	lookups := []func(context.Context, string) (*TokenIntrospection, error){s.accessToken, s.refreshToken}
+	if hint == domain.TokenTypeRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		introspection, err := lookup(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if introspection.Active {
+			return introspection, nil
+		}
+	}
+	return &TokenIntrospection{}, nil
+}
+
+// IntrospectAccessTokens describes a batch of access tokens in order, for
+// callers that validate many tokens at once. Refresh tokens are reported
+// inactive.
+func (s *IntrospectionService) IntrospectAccessTokens(ctx context.Context, tokens []string) ([]*TokenIntrospection, error) {
+	claims, err := s.validator.ValidateBatch(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	introspections := make([]*TokenIntrospection, len(claims))
+	for i, tokenClaims := range claims {
+		if tokenClaims == nil {
+			introspections[i] = &TokenIntrospection{}
+			continue
+		}
+		introspections[i] = newAccessTokenIntrospection(tokenClaims)
+	}
+	return introspections, nil
+}
+
+func (s *IntrospectionService) accessToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	claims, err := s.validator.Validate(ctx, token)
+	if errors.Is(err, domain.ErrInvalidAccessToken) {
+		return &TokenIntrospection{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccessTokenIntrospection(claims), nil
+}
+
+func (s *IntrospectionService) refreshToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	refreshToken, err := s.refreshTokens.GetByTokenHash(ctx, security.HashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return &TokenIntrospection{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if refreshToken.RevokedAt != nil || !time.Now().Before(refreshToken.ExpiresAt) {
+		return &TokenIntrospection{}, nil
+	}
+
+	account, err := s.accounts.GetByID(ctx, refreshToken.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return &TokenIntrospection{}, nil
+	}
+
+	introspection := &TokenIntrospection{
+		Active:     true,
+		TokenType:  domain.TokenTypeRefreshToken,
+		Subject:    refreshToken.AccountID,
+		RoleCode:   account.RoleCode,
+		StatusCode: account.StatusCode,
+		SessionID:  refreshToken.SessionID,
+		IssuedAt:   refreshToken.CreatedAt,
+		ExpiresAt:  refreshToken.ExpiresAt,
+	}
+	if refreshToken.KeyThumbprint != nil {
+		introspection.KeyThumbprint = *refreshToken.KeyThumbprint
+	}
+	if refreshToken.OrganizationID != nil {
+		introspection.OrganizationID = *refreshToken.OrganizationID
+	}
+	return introspection, nil
+}
+
+func newAccessTokenIntrospection(claims *models.AccessTokenClaims) *TokenIntrospection {
+	tokenType := domain.TokenTypeAccessToken
+	if claims.APIKeyID != uuid.Nil {
+		tokenType = domain.TokenTypeAPIKey
+	}
+
+	return &TokenIntrospection{
+		Active:           true,
+		TokenType:        tokenType,
+		Scope:            claims.Scope,
+		Subject:          claims.AccountID,
+		ClientID:         claims.ClientID,
+		RoleCode:         claims.RoleCode,
+		StatusCode:       claims.StatusCode,
+		Permissions:      claims.Permissions,
+		OrganizationID:   claims.OrganizationID,
+		OrganizationRole: claims.OrganizationRole,
+		TokenID:          claims.TokenID,
+		SessionID:        claims.SessionID,
+		IssuedAt:         claims.IssuedAt,
+		ExpiresAt:        claims.ExpiresAt,
+		KeyThumbprint:    claims.KeyThumbprint,
+		Actor:            claims.Actor,
+	}
+}
@@ -0,0 +1,137 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+// LockoutPolicy locks an auth method, or a TOTP factor, after Threshold
+// consecutive failed sign-in attempts. The first lock lasts Duration and
+// every failure after it doubles the lock, up to MaxDuration. A Threshold of
+// zero or less disables lockouts.
+type LockoutPolicy struct {
+	Threshold   int
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+func (p LockoutPolicy) enabled() bool {
+	return p.Threshold > 0
+}
+
+// lockDuration returns how long a method with failures consecutive failed
+// attempts stays locked, or zero below the threshold.
+func (p LockoutPolicy) lockDuration(failures int) time.Duration {
+	if failures < p.Threshold {
+		return 0
+	}
+	duration := p.Duration
+	for i := p.Threshold; i < failures && duration < p.MaxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, p.MaxDuration)
+}
+
+// Lockout enforces a LockoutPolicy on the passwords and emailed codes of auth
+// methods, and on the TOTP codes checked outside MFA challenges. Counts are
+// kept on the method or factor, so they hold across instances and restarts;
+// a lock ends on its own once its time has passed.
+type Lockout struct {
+	authMethods repositories.AuthMethodRepository
+	mfaFactors  repositories.MFAFactorRepository
+	policy      LockoutPolicy
+	eventBus    ports.EventBus
+}
+
+func NewLockout(authMethods repositories.AuthMethodRepository, mfaFactors repositories.MFAFactorRepository, policy LockoutPolicy, eventBus ports.EventBus) *Lockout {
+	return &Lockout{authMethods: authMethods, mfaFactors: mfaFactors, policy: policy, eventBus: eventBus}
+}
+
+// check refuses attempts on a locked method. Attempts are refused before the
+// secret is looked at, so guesses during a lock reveal nothing.
+func (l *Lockout) check(method *models.AuthMethod) error {
+	if l.policy.enabled() && method.LockedAt(time.Now()) {
+		return domain.ErrAuthMethodLocked
+	}
+	return nil
+}
+
+// fail counts a failed attempt on method and locks it once the count reaches
+// the threshold. It runs outside any transaction, so the count survives the
+// error returned to the caller.
+func (l *Lockout) fail(ctx context.Context, method *models.AuthMethod) error {
+	if !l.policy.enabled() {
+		return nil
+	}
+
+	failures, err := l.authMethods.IncrementFailedAttempts(ctx, method.ID)
+	if err != nil {
+		return err
+	}
+	duration := l.policy.lockDuration(failures)
+	if duration == 0 {
+		return nil
+	}
+
+	if err := l.authMethods.Lock(ctx, method.ID, time.Now().UTC().Add(duration)); err != nil {
+		return err
+	}
+
+	publish(ctx, l.eventBus, events.AuthMethodLockedEvent{
+		AccountID:      method.AccountID,
+		AuthMethodID:   method.ID,
+		Provider:       string(method.ProviderCode),
+		FailedAttempts: failures,
+		LockedFor:      int(duration.Seconds()),
+	})
+	return nil
+}
+
+// succeed clears the failures of method after a successful attempt.
+func (l *Lockout) succeed(ctx context.Context, method *models.AuthMethod) error {
+	if method.FailedAttempts == 0 && method.LockedUntil == nil {
+		return nil
+	}
+	return l.authMethods.ResetFailedAttempts(ctx, method.ID)
+}
+
+// checkFactor refuses codes on a locked factor. Codes checked within an MFA
+// challenge are limited by the challenge instead; the others, such as on
+// step-up or to disable the factor, are only limited by the factor.
+func (l *Lockout) checkFactor(factor *models.MFAFactor) error {
+	if l.policy.enabled() && factor.LockedAt(time.Now()) {
+		return domain.ErrMFAFactorLocked
+	}
+	return nil
+}
+
+// failFactor counts a failed code on factor and locks it once the count
+// reaches the threshold, outside any transaction like fail.
+func (l *Lockout) failFactor(ctx context.Context, factor *models.MFAFactor) error {
+	if !l.policy.enabled() {
+		return nil
+	}
+
+	failures, err := l.mfaFactors.IncrementFailedAttempts(ctx, factor.ID)
+	if err != nil {
+		return err
+	}
+	if duration := l.policy.lockDuration(failures); duration > 0 {
+		return l.mfaFactors.Lock(ctx, factor.ID, time.Now().UTC().Add(duration))
+	}
+	return nil
+}
+
+// succeedFactor clears the failures of factor after an accepted code.
+func (l *Lockout) succeedFactor(ctx context.Context, factor *models.MFAFactor) error {
+	if factor.FailedAttempts == 0 && factor.LockedUntil == nil {
+		return nil
+	}
+	return l.mfaFactors.ResetFailedAttempts(ctx, factor.ID)
+}
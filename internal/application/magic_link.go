@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+)
+
+// RequestMagicLink emails a single-use sign-in link to an active, verified
+// EMAIL method. Like ForgotPassword, unknown or inactive emails get the same
+// response.
+func (s *AuthService) RequestMagicLink(ctx context.Context, email string, client ClientInfo) (*CodeIssuedResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.RequestMagicLink")
+	defer span.End()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	result := &CodeIssuedResult{ExpiresIn: domain.MagicLinkTTL}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive || !method.IsVerified {
+		return result, nil
+	}
+
+	token, err := security.GenerateOpaqueToken(domain.MagicLinkTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return s.storeVerificationSecret(txCtx, method.ID, domain.PurposeMagicLink, token, domain.MagicLinkTTL)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.MagicLinkRequestedEvent{
+		AccountID:    account.ID,
+		Email:        email,
+		Token:        token,
+		ExpiresIn:    int(domain.MagicLinkTTL.Seconds()),
+		Organization: client.Organization,
+	})
+
+	return result, nil
+}
+
+// VerifyMagicLink consumes a magic link token and opens a new session. The
+// token carries enough entropy to identify its code by hash alone.
+func (s *AuthService) VerifyMagicLink(ctx context.Context, token string, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.VerifyMagicLink")
+	defer span.End()
+
+	verification, err := s.verificationCodes.GetByCodeHash(ctx, domain.PurposeMagicLink, security.HashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidOrExpiredCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := s.authMethods.GetByID(ctx, verification.AuthMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if verification.ConsumedAt != nil || !time.Now().Before(verification.ExpiresAt) {
+		return nil, s.loginFailed(ctx, method.AccountID, method.ProviderID, domain.ErrInvalidOrExpiredCode)
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, s.loginFailed(ctx, account.ID, method.ProviderID, domain.ErrInvalidAccountState)
+	}
+	if !method.IsVerified {
+		return nil, s.loginFailed(ctx, account.ID, method.ProviderID, domain.ErrInvalidOrExpiredCode)
+	}
+
+	client.Provider = domain.ProviderEmail
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+		if err := s.verificationCodes.MarkConsumed(txCtx, verification.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidOrExpiredCode
+			}
+			return err
+		}
+		if err := s.authMethods.UpdateLastLogin(txCtx, method.ID, now); err != nil {
+			return err
+		}
+		// Following the link proves control of the address, which no
+		// guessing can, so it also lifts a lockout.
+		if err := s.lockout.succeed(txCtx, method); err != nil {
+			return err
+		}
+
+		result, err = s.sessions.login(txCtx, account, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,24 @@
+package application
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain"
+
+// MFAPolicy lists the roles whose accounts must be protected by a second
+// factor. Such accounts only receive tokens restricted to MFA enrollment
+// until they enroll one.
+type MFAPolicy struct {
+	requiredRoles map[domain.Role]struct{}
+}
+
+func NewMFAPolicy(requiredRoles ...domain.Role) *MFAPolicy {
+	roles := make(map[domain.Role]struct{}, len(requiredRoles))
+	for _, role := range requiredRoles {
+		roles[role] = struct{}{}
+	}
+	return &MFAPolicy{requiredRoles: roles}
+}
+
+// Requires reports whether accounts with role must enroll a second factor.
+func (p *MFAPolicy) Requires(role domain.Role) bool {
+	_, ok := p.requiredRoles[role]
+	return ok
+}
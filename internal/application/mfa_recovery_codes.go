@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// replaceRecoveryCodes discards every recovery code of an account and stores
+// a new batch, returning the plaintext codes. It must run inside the caller's
+// transaction.
+func replaceRecoveryCodes(ctx context.Context, recoveryCodes repositories.MFARecoveryCodeRepository, accountID uuid.UUID) ([]string, error) {
+	if err := recoveryCodes.DeleteByAccountID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, domain.RecoveryCodeCount)
+	for range domain.RecoveryCodeCount {
+		code, err := security.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		err = recoveryCodes.Create(ctx, &models.MFARecoveryCode{
+			ID:        uuid.New(),
+			AccountID: accountID,
+			CodeHash:  security.HashToken(security.NormalizeRecoveryCode(code)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// initialRecoveryCodes issues the first batch of recovery codes when an
+// account enrolls a second factor. Accounts that still hold unused codes keep
+// them, and nil is returned.
+func initialRecoveryCodes(ctx context.Context, recoveryCodes repositories.MFARecoveryCodeRepository, accountID uuid.UUID) ([]string, error) {
+	remaining, err := recoveryCodes.CountUnused(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if remaining > 0 {
+		return nil, nil
+	}
+
+	return replaceRecoveryCodes(ctx, recoveryCodes, accountID)
+}
+
+// publishRecoveryCodes announces a new batch of recovery codes, if one was
+// issued.
+func publishRecoveryCodes(ctx context.Context, bus ports.EventBus, accountID uuid.UUID, codes []string) {
+	if len(codes) == 0 {
+		return
+	}
+	publish(ctx, bus, events.RecoveryCodesGeneratedEvent{
+		AccountID: accountID,
+		Count:     len(codes),
+	})
+}
+
+// RemainingRecoveryCodes reports how many recovery codes the account can
+// still use.
+func (s *MFAService) RemainingRecoveryCodes(ctx context.Context, accountID uuid.UUID) (int, error) {
+	return s.recoveryCodes.CountUnused(ctx, accountID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of an account with a
+// new batch and invalidates the old one. Only accounts protected by a second
+// factor have recovery codes.
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, accountID uuid.UUID) ([]string, error) {
+	methods, err := s.sessions.secondFactors(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, domain.ErrMFANotEnrolled
+	}
+
+	var codes []string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		codes, err = replaceRecoveryCodes(txCtx, s.recoveryCodes, accountID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishRecoveryCodes(ctx, s.eventBus, accountID, codes)
+
+	return codes, nil
+}
+
+// verifyRecoveryCodeChallenge is the recovery code branch of VerifyChallenge.
+// The code is consumed together with the challenge, so it cannot be reused
+// even if opening the session fails.
+func (s *MFAService) verifyRecoveryCodeChallenge(ctx context.Context, challenge *models.MFAChallenge, code string, rememberDevice bool, client ClientInfo) (*AuthResult, error) {
+	codeHash := security.HashToken(security.NormalizeRecoveryCode(code))
+
+	var (
+		result    *AuthResult
+		remaining int
+	)
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		err := s.recoveryCodes.Consume(txCtx, challenge.AccountID, codeHash, time.Now().UTC())
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidMFACode
+		}
+		if err != nil {
+			return err
+		}
+
+		remaining, err = s.recoveryCodes.CountUnused(txCtx, challenge.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result, err = completeMFAChallenge(txCtx, s.mfaChallenges, s.accounts, s.sessions, challenge, rememberDevice, client)
+		return err
+	})
+	if errors.Is(err, domain.ErrInvalidMFACode) {
+		return nil, failMFAChallenge(ctx, s.mfaChallenges, s.metrics, s.audit, s.eventBus, challenge, domain.MFAFactorRecoveryCode, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.RecoveryCodeUsedEvent{
+		AccountID: challenge.AccountID,
+		Remaining: remaining,
+	})
+
+	return result, nil
+}
@@ -0,0 +1,496 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// MFAService enrolls second factors and completes the MFA challenges issued
+// by SessionIssuer. TOTP secrets and SMS phone numbers are sealed with
+// secrets before they are stored.
+type MFAService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	mfaFactors    repositories.MFAFactorRepository
+	mfaChallenges repositories.MFAChallengeRepository
+	mfaCodes      repositories.MFACodeRepository
+	recoveryCodes repositories.MFARecoveryCodeRepository
+	devices       repositories.TrustedDeviceRepository
+	sessions      *SessionIssuer
+	lockout       *Lockout
+	secrets       *security.Cipher
+	sms           ports.SMSSender
+	metrics       ports.Metrics
+	audit         *AuditLog
+	eventBus      ports.EventBus
+	issuer        string
+}
+
+func NewMFAService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	mfaFactors repositories.MFAFactorRepository,
+	mfaChallenges repositories.MFAChallengeRepository,
+	mfaCodes repositories.MFACodeRepository,
+	recoveryCodes repositories.MFARecoveryCodeRepository,
+	devices repositories.TrustedDeviceRepository,
+	sessions *SessionIssuer,
+	lockout *Lockout,
+	secrets *security.Cipher,
+	sms ports.SMSSender,
+	metrics ports.Metrics,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+	issuer string,
+) *MFAService {
+	return &MFAService{
+		txManager:     txManager,
+		accounts:      accounts,
+		authMethods:   authMethods,
+		mfaFactors:    mfaFactors,
+		mfaChallenges: mfaChallenges,
+		mfaCodes:      mfaCodes,
+		recoveryCodes: recoveryCodes,
+		devices:       devices,
+		sessions:      sessions,
+		lockout:       lockout,
+		secrets:       secrets,
+		sms:           sms,
+		metrics:       metrics,
+		audit:         audit,
+		eventBus:      eventBus,
+		issuer:        issuer,
+	}
+}
+
+// TOTPEnrollment is returned once, when the secret is generated. The
+// provisioning URI is meant to be rendered as a QR code.
+type TOTPEnrollment struct {
+	FactorID        uuid.UUID
+	Secret          string
+	ProvisioningURI string
+}
+
+func (s *MFAService) ListFactors(ctx context.Context, accountID uuid.UUID) ([]*models.MFAFactor, error) {
+	return s.mfaFactors.ListByAccountID(ctx, accountID)
+}
+
+// EnrollTOTP generates a new TOTP secret. The factor stays inactive until
+// ConfirmTOTP proves the authenticator app was set up; enrolling again before
+// that replaces the pending secret.
+func (s *MFAService) EnrollTOTP(ctx context.Context, accountID uuid.UUID) (*TOTPEnrollment, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.secrets.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	factor := &models.MFAFactor{
+		ID:         uuid.New(),
+		AccountID:  account.ID,
+		FactorType: domain.MFAFactorTOTP,
+		Secret:     sealed,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		existing, err := s.mfaFactors.GetByAccountAndType(txCtx, account.ID, domain.MFAFactorTOTP)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+		case err != nil:
+			return err
+		case existing.ConfirmedAt != nil:
+			return domain.ErrMFAAlreadyEnrolled
+		default:
+			if err := s.mfaFactors.Delete(txCtx, existing.ID); err != nil {
+				return err
+			}
+		}
+
+		return s.mfaFactors.Create(txCtx, factor)
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrMFAAlreadyEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := accountLabel(ctx, s.authMethods, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{
+		FactorID:        factor.ID,
+		Secret:          security.EncodeTOTPSecret(secret),
+		ProvisioningURI: security.TOTPProvisioningURI(s.issuer, label, secret),
+	}, nil
+}
+
+// ConfirmTOTP activates a pending TOTP factor with a code from the
+// authenticator app. From then on every login requires a second factor. The
+// first factor of an account also yields its recovery codes, which are
+// returned here and never again.
+func (s *MFAService) ConfirmTOTP(ctx context.Context, accountID uuid.UUID, code string) ([]string, error) {
+	factor, err := s.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorTOTP)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if factor.ConfirmedAt != nil {
+		return nil, domain.ErrMFAAlreadyEnrolled
+	}
+
+	step, err := s.validateTOTP(factor, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.mfaFactors.Confirm(txCtx, factor.ID, step, time.Now().UTC()); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrMFAAlreadyEnrolled
+			}
+			return err
+		}
+
+		recoveryCodes, err = initialRecoveryCodes(txCtx, s.recoveryCodes, accountID)
+		if err != nil {
+			return err
+		}
+		if err := s.audit.record(txCtx, domain.AuditMFAEnrolled, accountID, accountID, factorDetails(factor.FactorType)); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.MFAEnabledEvent{
+			AccountID:  accountID,
+			FactorID:   factor.ID,
+			FactorType: string(factor.FactorType),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishRecoveryCodes(ctx, s.eventBus, accountID, recoveryCodes)
+
+	return recoveryCodes, nil
+}
+
+// DisableTOTP removes the TOTP factor. A current code is required so a
+// stolen access token alone cannot turn MFA off, and wrong ones count
+// towards the lockout of the factor so the code cannot be guessed either.
+func (s *MFAService) DisableTOTP(ctx context.Context, accountID uuid.UUID, code string) error {
+	factor, err := s.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorTOTP)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrMFANotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+	if factor.ConfirmedAt == nil {
+		return domain.ErrMFANotEnrolled
+	}
+
+	step, err := s.checkTOTP(ctx, factor, code)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.mfaFactors.UpdateLastUsedStep(txCtx, factor.ID, step); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidMFACode
+			}
+			return err
+		}
+		if err := s.mfaFactors.Delete(txCtx, factor.ID); err != nil {
+			return err
+		}
+		if err := s.audit.record(txCtx, domain.AuditMFADisabled, accountID, accountID, factorDetails(factor.FactorType)); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.MFADisabledEvent{
+			AccountID:  accountID,
+			FactorID:   factor.ID,
+			FactorType: string(factor.FactorType),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// VerifyChallenge completes a login that was held back by an MFA challenge
+// and opens the session with a code from the given factor, TOTP when method
+// is empty. Failed codes count against the challenge, which becomes unusable
+// after MaxMFAAttempts. With rememberDevice, the result carries a device token
+// that lets later logins from the same client skip the challenge.
+func (s *MFAService) VerifyChallenge(ctx context.Context, token string, method domain.MFAFactorType, code string, rememberDevice bool, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "MFAService.VerifyChallenge")
+	defer span.End()
+
+	challenge, err := activeMFAChallenge(ctx, s.mfaChallenges, token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case "", domain.MFAFactorTOTP:
+		return s.verifyTOTPChallenge(ctx, challenge, code, rememberDevice, client)
+	case domain.MFAFactorSMS:
+		return s.verifySMSChallenge(ctx, challenge, code, rememberDevice, client)
+	case domain.MFAFactorRecoveryCode:
+		return s.verifyRecoveryCodeChallenge(ctx, challenge, code, rememberDevice, client)
+	default:
+		return nil, domain.ErrMFANotEnrolled
+	}
+}
+
+// verifyTOTPChallenge is the TOTP branch of VerifyChallenge.
+func (s *MFAService) verifyTOTPChallenge(ctx context.Context, challenge *models.MFAChallenge, code string, rememberDevice bool, client ClientInfo) (*AuthResult, error) {
+	factor, err := s.mfaFactors.GetByAccountAndType(ctx, challenge.AccountID, domain.MFAFactorTOTP)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if factor.ConfirmedAt == nil {
+		return nil, domain.ErrMFANotEnrolled
+	}
+
+	step, err := s.validateTOTP(factor, code)
+	if errors.Is(err, domain.ErrInvalidMFACode) {
+		return nil, failMFAChallenge(ctx, s.mfaChallenges, s.metrics, s.audit, s.eventBus, challenge, domain.MFAFactorTOTP, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.mfaFactors.UpdateLastUsedStep(txCtx, factor.ID, step); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidMFACode
+			}
+			return err
+		}
+
+		result, err = completeMFAChallenge(txCtx, s.mfaChallenges, s.accounts, s.sessions, challenge, rememberDevice, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// activeMFAChallenge loads a challenge that can still be completed.
+func activeMFAChallenge(ctx context.Context, challenges repositories.MFAChallengeRepository, token string) (*models.MFAChallenge, error) {
+	challenge, err := challenges.GetByTokenHash(ctx, security.HashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+	if err != nil {
+		return nil, err
+	}
+	if challenge.ConsumedAt != nil || !time.Now().Before(challenge.ExpiresAt) {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+	if challenge.Attempts >= domain.MaxMFAAttempts {
+		return nil, domain.ErrVerificationAttemptsExceeded
+	}
+	return challenge, nil
+}
+
+// failMFAChallenge counts a failed attempt with factor against the challenge,
+// records and publishes it as a failed login and returns cause, or
+// ErrVerificationAttemptsExceeded once the challenge is exhausted.
+func failMFAChallenge(ctx context.Context, challenges repositories.MFAChallengeRepository, metrics ports.Metrics, audit *AuditLog, eventBus ports.EventBus, challenge *models.MFAChallenge, factor domain.MFAFactorType, cause error) error {
+	attempts, err := challenges.IncrementAttempts(ctx, challenge.ID)
+	if err != nil {
+		return err
+	}
+	if attempts >= domain.MaxMFAAttempts {
+		cause = domain.ErrVerificationAttemptsExceeded
+	}
+	audit.recordFailure(ctx, domain.AuditLoginFailed, uuid.Nil, challenge.AccountID, cause, factorDetails(factor))
+	publish(ctx, eventBus, events.LoginFailedEvent{
+		AccountID: challenge.AccountID,
+		Factor:    string(factor),
+		Reason:    cause.Error(),
+	})
+	metrics.LoginAttempted("", string(factor), domain.LoginResultFailure)
+	return cause
+}
+
+// factorDetails describe an audited action on a second factor.
+func factorDetails(factor domain.MFAFactorType) map[string]string {
+	return map[string]string{"factor": string(factor)}
+}
+
+// completeMFAChallenge consumes the challenge and opens the session held back
+// by it, trusting the client's device when asked to. It must run inside the
+// caller's transaction.
+func completeMFAChallenge(
+	ctx context.Context,
+	challenges repositories.MFAChallengeRepository,
+	accounts repositories.AccountRepository,
+	sessions *SessionIssuer,
+	challenge *models.MFAChallenge,
+	rememberDevice bool,
+	client ClientInfo,
+) (*AuthResult, error) {
+	if err := challenges.MarkConsumed(ctx, challenge.ID, time.Now().UTC()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+
+	account, err := accounts.GetByID(ctx, challenge.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+
+	client.RememberMe = challenge.RememberMe
+	client.Scope = challenge.Scope
+	result, err := sessions.open(ctx, account, client)
+	if err != nil {
+		return nil, err
+	}
+	if rememberDevice {
+		if err := sessions.trustDevice(ctx, result, client); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// verifyFactorCode checks and uses up a code from a confirmed TOTP or SMS
+// factor outside any MFA challenge. SMS codes must have been requested with
+// SendSMSCode; wrong TOTP codes count towards the lockout of the factor.
+func (s *MFAService) verifyFactorCode(ctx context.Context, accountID uuid.UUID, method domain.MFAFactorType, code string) error {
+	switch method {
+	case "", domain.MFAFactorTOTP:
+		factor, err := s.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorTOTP)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrMFANotEnrolled
+		}
+		if err != nil {
+			return err
+		}
+		if factor.ConfirmedAt == nil {
+			return domain.ErrMFANotEnrolled
+		}
+
+		step, err := s.checkTOTP(ctx, factor, code)
+		if err != nil {
+			return err
+		}
+		err = s.mfaFactors.UpdateLastUsedStep(ctx, factor.ID, step)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidMFACode
+		}
+		return err
+	case domain.MFAFactorSMS:
+		factor, err := s.smsFactor(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if factor.ConfirmedAt == nil {
+			return domain.ErrMFANotEnrolled
+		}
+
+		mfaCode, err := s.checkSMSCode(ctx, factor.ID, code)
+		if err != nil {
+			return err
+		}
+		return s.consumeSMSCode(ctx, mfaCode.ID, time.Now().UTC())
+	default:
+		return domain.ErrMFANotEnrolled
+	}
+}
+
+// validateTOTP decrypts the factor secret and checks code, rejecting steps
+// that were already used.
+func (s *MFAService) validateTOTP(factor *models.MFAFactor, code string) (int64, error) {
+	secret, err := s.secrets.Decrypt(factor.Secret)
+	if err != nil {
+		return 0, err
+	}
+
+	step, ok := security.ValidateTOTP(secret, code, time.Now(), factor.LastUsedStep)
+	if !ok {
+		return 0, domain.ErrInvalidMFACode
+	}
+	return step, nil
+}
+
+// checkTOTP validates a code of factor outside any MFA challenge, refusing
+// it while the factor is locked out and counting it when it is wrong.
+func (s *MFAService) checkTOTP(ctx context.Context, factor *models.MFAFactor, code string) (int64, error) {
+	if err := s.lockout.checkFactor(factor); err != nil {
+		return 0, err
+	}
+	step, err := s.validateTOTP(factor, code)
+	if errors.Is(err, domain.ErrInvalidMFACode) {
+		if err := s.lockout.failFactor(ctx, factor); err != nil {
+			return 0, err
+		}
+		return 0, domain.ErrInvalidMFACode
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := s.lockout.succeedFactor(ctx, factor); err != nil {
+		return 0, err
+	}
+	return step, nil
+}
+
+// accountLabel names the account inside authenticators, preferring its email
+// address.
+func accountLabel(ctx context.Context, authMethods repositories.AuthMethodRepository, account *models.Account) (string, error) {
+	methods, err := authMethods.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, method := range methods {
+		if method.ProviderCode == domain.ProviderEmail {
+			return method.ProviderID, nil
+		}
+	}
+	return account.ID.String(), nil
+}
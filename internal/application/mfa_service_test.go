@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+func TestDisableTOTPLocksFactor(t *testing.T) {
+	auth := newTestAuth(t)
+	accountID := auth.register(t).Account.ID
+	authenticator := auth.enrollTOTP(t, accountID)
+	ctx := context.Background()
+
+	for i := range 3 {
+		if err := auth.mfa.DisableTOTP(ctx, accountID, authenticator.wrong()); !errors.Is(err, domain.ErrInvalidMFACode) {
+			t.Fatalf("wrong code %d: got %v, want %v", i+1, err, domain.ErrInvalidMFACode)
+		}
+	}
+	if err := auth.mfa.DisableTOTP(ctx, accountID, authenticator.code(0)); !errors.Is(err, domain.ErrMFAFactorLocked) {
+		t.Errorf("right code while locked: got %v, want %v", err, domain.ErrMFAFactorLocked)
+	}
+
+	if _, err := auth.mfa.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorTOTP); err != nil {
+		t.Errorf("factor after a refused removal: %v", err)
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	auth := newTestAuth(t)
+	accountID := auth.register(t).Account.ID
+	authenticator := auth.enrollTOTP(t, accountID)
+	ctx := context.Background()
+
+	if err := auth.mfa.DisableTOTP(ctx, accountID, authenticator.code(0)); err != nil {
+		t.Fatalf("disable totp: %v", err)
+	}
+	if _, err := auth.mfa.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorTOTP); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("factor after removal: got %v, want %v", err, domain.ErrNotFound)
+	}
+}
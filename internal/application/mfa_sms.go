@@ -0,0 +1,347 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// e164Pattern matches phone numbers in E.164 form, e.g. +573001234567.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// EnrollSMS registers a phone number as a pending SMS factor and texts it a
+// code. The factor stays inactive until ConfirmSMS; enrolling again before
+// that replaces the pending number.
+func (s *MFAService) EnrollSMS(ctx context.Context, accountID uuid.UUID, phoneNumber string) error {
+	phoneNumber, err := normalizePhoneNumber(phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return domain.ErrInvalidAccountState
+	}
+
+	sealed, err := s.secrets.Encrypt([]byte(phoneNumber))
+	if err != nil {
+		return err
+	}
+
+	factor := &models.MFAFactor{
+		ID:         uuid.New(),
+		AccountID:  account.ID,
+		FactorType: domain.MFAFactorSMS,
+		Secret:     sealed,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		existing, err := s.mfaFactors.GetByAccountAndType(txCtx, account.ID, domain.MFAFactorSMS)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+		case err != nil:
+			return err
+		case existing.ConfirmedAt != nil:
+			return domain.ErrMFAAlreadyEnrolled
+		default:
+			if err := s.mfaFactors.Delete(txCtx, existing.ID); err != nil {
+				return err
+			}
+		}
+
+		return s.mfaFactors.Create(txCtx, factor)
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return domain.ErrMFAAlreadyEnrolled
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.sendSMSCode(ctx, factor)
+}
+
+// ConfirmSMS activates a pending SMS factor with the code texted during
+// enrollment, proving the account holder controls the phone number. Like
+// ConfirmTOTP, it returns the recovery codes issued with the first factor.
+func (s *MFAService) ConfirmSMS(ctx context.Context, accountID uuid.UUID, code string) ([]string, error) {
+	factor, err := s.smsFactor(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if factor.ConfirmedAt != nil {
+		return nil, domain.ErrMFAAlreadyEnrolled
+	}
+
+	mfaCode, err := s.checkSMSCode(ctx, factor.ID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+		if err := s.consumeSMSCode(txCtx, mfaCode.ID, now); err != nil {
+			return err
+		}
+		if err := s.mfaFactors.Confirm(txCtx, factor.ID, 0, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrMFAAlreadyEnrolled
+			}
+			return err
+		}
+
+		recoveryCodes, err = initialRecoveryCodes(txCtx, s.recoveryCodes, accountID)
+		if err != nil {
+			return err
+		}
+		if err := s.audit.record(txCtx, domain.AuditMFAEnrolled, accountID, accountID, factorDetails(factor.FactorType)); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.MFAEnabledEvent{
+			AccountID:  accountID,
+			FactorID:   factor.ID,
+			FactorType: string(factor.FactorType),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishRecoveryCodes(ctx, s.eventBus, accountID, recoveryCodes)
+
+	return recoveryCodes, nil
+}
+
+// SendSMSCode texts a fresh code to the confirmed SMS factor of an account,
+// for use with DisableSMS.
+func (s *MFAService) SendSMSCode(ctx context.Context, accountID uuid.UUID) error {
+	factor, err := s.smsFactor(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if factor.ConfirmedAt == nil {
+		return domain.ErrMFANotEnrolled
+	}
+
+	return s.sendSMSCode(ctx, factor)
+}
+
+// DisableSMS removes the SMS factor. A code from SendSMSCode is required so a
+// stolen access token alone cannot turn MFA off.
+func (s *MFAService) DisableSMS(ctx context.Context, accountID uuid.UUID, code string) error {
+	factor, err := s.smsFactor(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if factor.ConfirmedAt == nil {
+		return domain.ErrMFANotEnrolled
+	}
+
+	mfaCode, err := s.checkSMSCode(ctx, factor.ID, code)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.consumeSMSCode(txCtx, mfaCode.ID, time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.mfaFactors.Delete(txCtx, factor.ID); err != nil {
+			return err
+		}
+		if err := s.audit.record(txCtx, domain.AuditMFADisabled, accountID, accountID, factorDetails(factor.FactorType)); err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.MFADisabledEvent{
+			AccountID:  accountID,
+			FactorID:   factor.ID,
+			FactorType: string(factor.FactorType),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendChallengeSMS texts a code that completes the MFA challenge identified
+// by token through VerifyChallenge.
+func (s *MFAService) SendChallengeSMS(ctx context.Context, token string) error {
+	ctx, span := tracer.Start(ctx, "MFAService.SendChallengeSMS")
+	defer span.End()
+
+	challenge, err := activeMFAChallenge(ctx, s.mfaChallenges, token)
+	if err != nil {
+		return err
+	}
+
+	factor, err := s.smsFactor(ctx, challenge.AccountID)
+	if err != nil {
+		return err
+	}
+	if factor.ConfirmedAt == nil {
+		return domain.ErrMFANotEnrolled
+	}
+
+	return s.sendSMSCode(ctx, factor)
+}
+
+// verifySMSChallenge is the SMS branch of VerifyChallenge.
+func (s *MFAService) verifySMSChallenge(ctx context.Context, challenge *models.MFAChallenge, code string, rememberDevice bool, client ClientInfo) (*AuthResult, error) {
+	factor, err := s.smsFactor(ctx, challenge.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if factor.ConfirmedAt == nil {
+		return nil, domain.ErrMFANotEnrolled
+	}
+
+	mfaCode, err := s.checkSMSCode(ctx, factor.ID, code)
+	if errors.Is(err, domain.ErrInvalidMFACode) || errors.Is(err, domain.ErrVerificationAttemptsExceeded) {
+		return nil, failMFAChallenge(ctx, s.mfaChallenges, s.metrics, s.audit, s.eventBus, challenge, domain.MFAFactorSMS, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.consumeSMSCode(txCtx, mfaCode.ID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		result, err = completeMFAChallenge(txCtx, s.mfaChallenges, s.accounts, s.sessions, challenge, rememberDevice, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *MFAService) smsFactor(ctx context.Context, accountID uuid.UUID) (*models.MFAFactor, error) {
+	factor, err := s.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorSMS)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return factor, nil
+}
+
+// sendSMSCode replaces any outstanding code of the factor and texts the new
+// one to its phone number. The text is sent after the code is committed so a
+// delivered code is always usable.
+func (s *MFAService) sendSMSCode(ctx context.Context, factor *models.MFAFactor) error {
+	phoneNumber, err := s.secrets.Decrypt(factor.Secret)
+	if err != nil {
+		return err
+	}
+
+	code, err := security.GenerateNumericCode(domain.MFACodeLength)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+		if err := s.mfaCodes.InvalidateActive(txCtx, factor.ID, now); err != nil {
+			return err
+		}
+		return s.mfaCodes.Create(txCtx, &models.MFACode{
+			ID:        uuid.New(),
+			FactorID:  factor.ID,
+			CodeHash:  security.HashToken(code),
+			Attempts:  0,
+			ExpiresAt: now.Add(domain.MFACodeTTL),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.sms.Send(ctx, ports.SMS{
+		To: string(phoneNumber),
+		Body: fmt.Sprintf("Your %s verification code is %s. It expires in %d minutes.",
+			s.issuer, code, int(domain.MFACodeTTL.Minutes())),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.metrics.CodeIssued(string(domain.MFAFactorSMS))
+	return nil
+}
+
+// checkSMSCode validates the latest code of an SMS factor. A mismatch is
+// counted against the code outside any transaction so it survives the error.
+func (s *MFAService) checkSMSCode(ctx context.Context, factorID uuid.UUID, code string) (*models.MFACode, error) {
+	mfaCode, err := s.mfaCodes.GetLatestByFactorID(ctx, factorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidMFACode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mfaCode.ConsumedAt != nil || !time.Now().Before(mfaCode.ExpiresAt) {
+		return nil, domain.ErrInvalidMFACode
+	}
+	if mfaCode.Attempts >= domain.MaxVerificationAttempts {
+		return nil, domain.ErrVerificationAttemptsExceeded
+	}
+
+	if !security.CompareTokenHash(code, mfaCode.CodeHash) {
+		attempts, err := s.mfaCodes.IncrementAttempts(ctx, mfaCode.ID)
+		if err != nil {
+			return nil, err
+		}
+		if attempts >= domain.MaxVerificationAttempts {
+			return nil, domain.ErrVerificationAttemptsExceeded
+		}
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	return mfaCode, nil
+}
+
+// consumeSMSCode marks a checked code as used, failing if a concurrent request
+// consumed it first.
+func (s *MFAService) consumeSMSCode(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := s.mfaCodes.MarkConsumed(ctx, id, at)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidMFACode
+	}
+	return err
+}
+
+func normalizePhoneNumber(phoneNumber string) (string, error) {
+	phoneNumber = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phoneNumber))
+	if !e164Pattern.MatchString(phoneNumber) {
+		return "", domain.ErrInvalidPhoneNumber
+	}
+	return phoneNumber, nil
+}
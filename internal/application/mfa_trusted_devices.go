@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// ListTrustedDevices returns the devices of an account that can still skip
+// the MFA challenge.
+func (s *MFAService) ListTrustedDevices(ctx context.Context, accountID uuid.UUID) ([]*models.TrustedDevice, error) {
+	return s.devices.ListActiveByAccountID(ctx, accountID, time.Now().UTC())
+}
+
+// RevokeTrustedDevice makes a device answer the MFA challenge again on its
+// next login.
+func (s *MFAService) RevokeTrustedDevice(ctx context.Context, accountID, deviceID uuid.UUID) error {
+	err := s.devices.Delete(ctx, deviceID, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrTrustedDeviceNotFound
+	}
+	return err
+}
+
+// RevokeAllTrustedDevices revokes every trusted device of an account.
+func (s *MFAService) RevokeAllTrustedDevices(ctx context.Context, accountID uuid.UUID) error {
+	_, err := s.devices.DeleteAllByAccountID(ctx, accountID)
+	return err
+}
@@ -0,0 +1,337 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// oauthSecretBytes is the entropy of the state, nonce and PKCE verifier.
+const oauthSecretBytes = 32
+
+type OAuthService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	authProviders repositories.AuthProviderRepository
+	sessions      *SessionIssuer
+	features      *FeatureFlags
+	eventBus      ports.EventBus
+	providers     map[domain.Provider]ports.OAuthProvider
+	disabled      atomic.Pointer[map[domain.Provider]bool]
+}
+
+func NewOAuthService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	authProviders repositories.AuthProviderRepository,
+	sessions *SessionIssuer,
+	features *FeatureFlags,
+	eventBus ports.EventBus,
+	providers ...ports.OAuthProvider,
+) *OAuthService {
+	registry := make(map[domain.Provider]ports.OAuthProvider, len(providers))
+	for _, provider := range providers {
+		registry[provider.Code()] = provider
+	}
+
+	service := &OAuthService{
+		txManager:     txManager,
+		accounts:      accounts,
+		authMethods:   authMethods,
+		authProviders: authProviders,
+		sessions:      sessions,
+		features:      features,
+		eventBus:      eventBus,
+		providers:     registry,
+	}
+	service.SetDisabled()
+	return service
+}
+
+// SetDisabled turns the given configured providers off, and every other
+// one on. Disabled providers are unsupported for new sign-ins and links;
+// identities already linked with them are kept. A provider being rolled
+// out is instead gated by its feature flag, once the account is known.
+func (s *OAuthService) SetDisabled(providers ...domain.Provider) {
+	disabled := make(map[domain.Provider]bool, len(providers))
+	for _, provider := range providers {
+		disabled[provider] = true
+	}
+	s.disabled.Store(&disabled)
+}
+
+// provider returns the configured provider with code, unless it is disabled.
+func (s *OAuthService) provider(code domain.Provider) (ports.OAuthProvider, bool) {
+	if (*s.disabled.Load())[code] {
+		return nil, false
+	}
+	p, ok := s.providers[code]
+	return p, ok
+}
+
+// SyncProviderCatalog registers every configured provider in the
+// auth_providers catalog, so operator-defined providers satisfy the
+// auth_methods foreign key.
+func (s *OAuthService) SyncProviderCatalog(ctx context.Context) error {
+	for code := range s.providers {
+		if err := s.authProviders.Ensure(ctx, code, "OAuth provider "+string(code)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Authorize prepares the redirect to the provider's consent page.
+func (s *OAuthService) Authorize(provider domain.Provider) (*ports.OAuthAuthorization, error) {
+	p, ok := s.provider(provider)
+	if !ok {
+		return nil, domain.ErrUnsupportedProvider
+	}
+
+	secrets := make([]string, 3)
+	for i := range secrets {
+		secret, err := security.GenerateOpaqueToken(oauthSecretBytes)
+		if err != nil {
+			return nil, err
+		}
+		secrets[i] = secret
+	}
+	state, nonce, verifier := secrets[0], secrets[1], secrets[2]
+
+	return &ports.OAuthAuthorization{
+		URL:          p.AuthCodeURL(state, nonce, verifier),
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	}, nil
+}
+
+// Callback completes the authorization code flow. expected holds the secrets
+// returned by Authorize; state is the value echoed back by the provider.
+func (s *OAuthService) Callback(
+	ctx context.Context,
+	provider domain.Provider,
+	code, state string,
+	expected ports.OAuthAuthorization,
+	client ClientInfo,
+) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "OAuthService.Callback")
+	defer span.End()
+
+	identity, err := s.exchange(ctx, provider, code, state, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.login(ctx, identity, client)
+	if errors.Is(err, domain.ErrConflict) {
+		// A concurrent first login created the identity; it now exists.
+		result, err = s.login(ctx, identity, client)
+	}
+	return result, err
+}
+
+// Link completes an authorization code flow started by a signed-in user and
+// attaches the provider identity to their account as a verified method.
+func (s *OAuthService) Link(
+	ctx context.Context,
+	accountID uuid.UUID,
+	provider domain.Provider,
+	code, state string,
+	expected ports.OAuthAuthorization,
+) (*models.AuthMethod, error) {
+	ctx, span := tracer.Start(ctx, "OAuthService.Link")
+	defer span.End()
+
+	identity, err := s.exchange(ctx, provider, code, state, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.features.require(ctx, providerFeature(identity.Provider), accountID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.authMethods.GetByProvider(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		if existing.AccountID == accountID {
+			return existing, nil
+		}
+		return nil, domain.ErrIdentityAlreadyLinked
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	var method *models.AuthMethod
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.accounts.GetByIDForUpdate(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+		if account.StatusCode != domain.StatusActive {
+			return domain.ErrInvalidAccountState
+		}
+
+		methods, err := s.authMethods.ListByAccountID(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+		for _, m := range methods {
+			if m.ProviderCode == identity.Provider {
+				return domain.ErrProviderAlreadyLinked
+			}
+		}
+
+		method = &models.AuthMethod{
+			ID:           uuid.New(),
+			AccountID:    accountID,
+			ProviderCode: identity.Provider,
+			ProviderID:   identity.Subject,
+			IsVerified:   true,
+		}
+		return s.authMethods.Create(txCtx, method)
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		// The identity was attached to an account since the lookup above.
+		return nil, domain.ErrIdentityAlreadyLinked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.AuthMethodLinkedEvent{
+		AccountID:    accountID,
+		AuthMethodID: method.ID,
+		Provider:     string(method.ProviderCode),
+	})
+
+	return method, nil
+}
+
+// exchange checks the echoed state and trades the code for the identity.
+func (s *OAuthService) exchange(
+	ctx context.Context,
+	provider domain.Provider,
+	code, state string,
+	expected ports.OAuthAuthorization,
+) (*ports.ExternalIdentity, error) {
+	p, ok := s.provider(provider)
+	if !ok {
+		return nil, domain.ErrUnsupportedProvider
+	}
+	if expected.State == "" || !security.ConstantTimeEqual(state, expected.State) {
+		return nil, domain.ErrInvalidOAuthState
+	}
+
+	identity, err := p.Exchange(ctx, code, expected.Nonce, expected.CodeVerifier)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return identity, nil
+}
+
+// login opens a session for the identity, creating an ACTIVE account with a
+// verified method the first time the identity is seen.
+func (s *OAuthService) login(ctx context.Context, identity *ports.ExternalIdentity, client ClientInfo) (*AuthResult, error) {
+	method, err := s.authMethods.GetByProvider(ctx, identity.Provider, identity.Subject)
+	if errors.Is(err, domain.ErrNotFound) {
+		return s.register(ctx, identity, client)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+	if !method.IsVerified {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err := s.features.require(ctx, providerFeature(identity.Provider), account.ID); err != nil {
+		return nil, err
+	}
+
+	client.Provider = identity.Provider
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.authMethods.UpdateLastLogin(txCtx, method.ID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		result, err = s.sessions.login(txCtx, account, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *OAuthService) register(ctx context.Context, identity *ports.ExternalIdentity, client ClientInfo) (*AuthResult, error) {
+	// Sign-ups are admitted to a provider's rollout at its percentage, by
+	// the ID the account is about to get.
+	accountID := uuid.New()
+	if err := s.features.require(ctx, providerFeature(identity.Provider), accountID); err != nil {
+		return nil, err
+	}
+
+	var result *AuthResult
+	var account *models.Account
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account = &models.Account{
+			ID:         accountID,
+			RoleCode:   domain.RoleUser,
+			StatusCode: domain.StatusActive,
+		}
+		if err := s.accounts.Create(txCtx, account); err != nil {
+			return err
+		}
+
+		method := &models.AuthMethod{
+			ID:           uuid.New(),
+			AccountID:    account.ID,
+			ProviderCode: identity.Provider,
+			ProviderID:   identity.Subject,
+			IsVerified:   true,
+		}
+		if err := s.authMethods.Create(txCtx, method); err != nil {
+			return err
+		}
+		if err := s.authMethods.UpdateLastLogin(txCtx, method.ID, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = s.sessions.open(txCtx, account, client)
+		if err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.OAuthUserRegisteredEvent{
+			AccountID: account.ID,
+			Provider:  string(identity.Provider),
+			Email:     identity.Email,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
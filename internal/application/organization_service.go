@@ -0,0 +1,608 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// organizationSlugPattern accepts DNS labels, such as acme-corp.
+var organizationSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// brandingColorPattern accepts hex colors, such as #1a73e8.
+var brandingColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// AccountOrganization is an organization an account is a member of, with the
+// account's role in it.
+type AccountOrganization struct {
+	Organization *models.Organization
+	Membership   *models.Membership
+}
+
+// OrganizationService lets administrators manage organizations and their
+// members, organization admins invite new members and set the authentication
+// policy of the organization, and accounts list the organizations they belong
+// to.
+type OrganizationService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	roles         repositories.RoleRepository
+	organizations repositories.OrganizationRepository
+	memberships   repositories.MembershipRepository
+	invitations   repositories.InvitationRepository
+	brandings     repositories.BrandingRepository
+	policies      repositories.AuthPolicyRepository
+	// keys is nil when signing keys do not rotate, which also rules out
+	// organization keys.
+	keys     ports.OrganizationKeyManager
+	eventBus ports.EventBus
+}
+
+func NewOrganizationService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	roles repositories.RoleRepository,
+	organizations repositories.OrganizationRepository,
+	memberships repositories.MembershipRepository,
+	invitations repositories.InvitationRepository,
+	brandings repositories.BrandingRepository,
+	policies repositories.AuthPolicyRepository,
+	keys ports.OrganizationKeyManager,
+	eventBus ports.EventBus,
+) *OrganizationService {
+	return &OrganizationService{
+		txManager:     txManager,
+		accounts:      accounts,
+		authMethods:   authMethods,
+		roles:         roles,
+		organizations: organizations,
+		memberships:   memberships,
+		invitations:   invitations,
+		brandings:     brandings,
+		policies:      policies,
+		keys:          keys,
+		eventBus:      eventBus,
+	}
+}
+
+// CreateOrganization creates an organization without members. Slugs are
+// lowercase DNS labels and unique.
+func (s *OrganizationService) CreateOrganization(ctx context.Context, slug, name string) (*models.Organization, error) {
+	if !organizationSlugPattern.MatchString(slug) {
+		return nil, domain.ErrInvalidOrganizationSlug
+	}
+	name, err := organizationName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	organization := &models.Organization{ID: uuid.New(), Slug: slug, Name: name}
+	err = s.organizations.Create(ctx, organization)
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrOrganizationAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (s *OrganizationService) GetOrganization(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	organization, err := s.organizations.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	return organization, err
+}
+
+// ListOrganizations returns every organization by slug.
+func (s *OrganizationService) ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	return s.organizations.List(ctx)
+}
+
+// RenameOrganization changes the name of an organization; its slug does not
+// change.
+func (s *OrganizationService) RenameOrganization(ctx context.Context, id uuid.UUID, name string) (*models.Organization, error) {
+	name, err := organizationName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.organizations.UpdateName(ctx, id, name); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return s.GetOrganization(ctx, id)
+}
+
+// DeleteOrganization deletes an organization with its memberships. Sessions
+// active in it continue outside of any organization once refreshed.
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, id uuid.UUID) error {
+	err := s.organizations.Delete(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrOrganizationNotFound
+	}
+	return err
+}
+
+// Members returns the memberships of an organization, oldest first.
+func (s *OrganizationService) Members(ctx context.Context, organizationID uuid.UUID) ([]*models.Membership, error) {
+	if _, err := s.GetOrganization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	return s.memberships.ListByOrganizationID(ctx, organizationID)
+}
+
+// SetMember makes an account a member of an organization with a role, system
+// or custom, or changes its role there. The role applies within the
+// organization only; the account keeps its own role. Sessions active in the
+// organization carry the new role once refreshed.
+func (s *OrganizationService) SetMember(ctx context.Context, organizationID, accountID uuid.UUID, role domain.Role) (*models.Membership, error) {
+	if !roleCodePattern.MatchString(string(role)) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	membership := &models.Membership{OrganizationID: organizationID, AccountID: accountID, RoleCode: role}
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.GetOrganization(txCtx, organizationID); err != nil {
+			return err
+		}
+		account, err := s.accounts.GetByID(txCtx, accountID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if account.StatusCode == domain.StatusDeleted {
+			return domain.ErrAccountNotFound
+		}
+		if _, err := s.roles.GetByCode(txCtx, role); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidRole
+			}
+			return err
+		}
+		return s.memberships.Save(txCtx, membership)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// RemoveMember removes an account from an organization. Its sessions active
+// in the organization continue outside of any once refreshed.
+func (s *OrganizationService) RemoveMember(ctx context.Context, organizationID, accountID uuid.UUID) error {
+	err := s.memberships.Delete(ctx, organizationID, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrMembershipNotFound
+	}
+	return err
+}
+
+// AccountOrganizations returns the organizations an account is a member of,
+// by slug.
+func (s *OrganizationService) AccountOrganizations(ctx context.Context, accountID uuid.UUID) ([]*AccountOrganization, error) {
+	organizations, err := s.organizations.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := s.memberships.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	byOrganization := make(map[uuid.UUID]*models.Membership, len(memberships))
+	for _, membership := range memberships {
+		byOrganization[membership.OrganizationID] = membership
+	}
+	result := make([]*AccountOrganization, 0, len(organizations))
+	for _, organization := range organizations {
+		// Memberships removed between both reads are left out.
+		if membership, ok := byOrganization[organization.ID]; ok {
+			result = append(result, &AccountOrganization{Organization: organization, Membership: membership})
+		}
+	}
+	return result, nil
+}
+
+// Branding returns the email branding of an organization.
+func (s *OrganizationService) Branding(ctx context.Context, organizationID uuid.UUID) (*models.Branding, error) {
+	branding, err := s.brandings.Get(ctx, organizationID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrBrandingNotFound
+	}
+	return branding, err
+}
+
+// SetBranding sets the branding of the emails sent on behalf of an
+// organization. Every field is optional and falls back to the default
+// branding when empty.
+func (s *OrganizationService) SetBranding(ctx context.Context, branding *models.Branding) (*models.Branding, error) {
+	if err := validateBranding(branding); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetOrganization(ctx, branding.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	err := s.brandings.Save(ctx, branding)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return branding, nil
+}
+
+// DeleteBranding returns the emails of an organization to the default
+// branding.
+func (s *OrganizationService) DeleteBranding(ctx context.Context, organizationID uuid.UUID) error {
+	err := s.brandings.Delete(ctx, organizationID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrBrandingNotFound
+	}
+	return err
+}
+
+// Policy returns the authentication policy of an organization. Members with
+// the ADMIN role in the organization and ADMIN accounts may read it.
+func (s *OrganizationService) Policy(ctx context.Context, actorID, organizationID uuid.UUID) (*models.AuthPolicy, error) {
+	if _, err := s.authorizeAdmin(ctx, actorID, organizationID); err != nil {
+		return nil, err
+	}
+	policy, err := s.policies.Get(ctx, organizationID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrPolicyNotFound
+	}
+	return policy, err
+}
+
+// SetPolicy sets the authentication policy of an organization, which applies
+// to every member from their next login or session rotation, whichever
+// organization the session acts in. Members with the ADMIN role in the
+// organization and ADMIN accounts may set it.
+func (s *OrganizationService) SetPolicy(ctx context.Context, actorID uuid.UUID, policy *models.AuthPolicy) (*models.AuthPolicy, error) {
+	if err := validatePolicy(policy); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeAdmin(ctx, actorID, policy.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	err := s.policies.Save(ctx, policy)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeletePolicy returns the members of an organization to the service-wide
+// policies.
+func (s *OrganizationService) DeletePolicy(ctx context.Context, actorID, organizationID uuid.UUID) error {
+	if _, err := s.authorizeAdmin(ctx, actorID, organizationID); err != nil {
+		return err
+	}
+	err := s.policies.Delete(ctx, organizationID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrPolicyNotFound
+	}
+	return err
+}
+
+// SigningKeys returns the published signing keys of an organization, newest
+// first.
+func (s *OrganizationService) SigningKeys(ctx context.Context, organizationID uuid.UUID) ([]*models.SigningKey, error) {
+	if err := s.requireKeys(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	return s.keys.OrganizationKeys(ctx, organizationID)
+}
+
+// RotateSigningKey schedules a new signing key for an organization, its
+// first one if it has none, and returns its published keys. Access tokens of
+// sessions active in the organization are signed with its newest active key,
+// or the platform key until it has one.
+func (s *OrganizationService) RotateSigningKey(ctx context.Context, organizationID uuid.UUID) ([]*models.SigningKey, error) {
+	if err := s.requireKeys(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	if err := s.keys.RotateOrganizationKey(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	return s.keys.OrganizationKeys(ctx, organizationID)
+}
+
+// RevokeSigningKeys revokes every signing key of an organization, so that
+// the tokens they signed stop verifying, and returns the organization to the
+// platform keys.
+func (s *OrganizationService) RevokeSigningKeys(ctx context.Context, organizationID uuid.UUID) error {
+	if err := s.requireKeys(ctx, organizationID); err != nil {
+		return err
+	}
+	return s.keys.RevokeOrganizationKeys(ctx, organizationID)
+}
+
+// requireKeys fails when signing keys do not rotate or the organization does
+// not exist.
+func (s *OrganizationService) requireKeys(ctx context.Context, organizationID uuid.UUID) error {
+	if s.keys == nil {
+		return domain.ErrKeyRotationDisabled
+	}
+	_, err := s.GetOrganization(ctx, organizationID)
+	return err
+}
+
+// Invite invites an email address to join an organization with a role,
+// replacing the invitations still pending for the address there. Members
+// with the ADMIN role in the organization and ADMIN accounts may invite. The
+// invitation token is emailed to the address only.
+func (s *OrganizationService) Invite(ctx context.Context, actorID, organizationID uuid.UUID, email string, role domain.Role) (*models.Invitation, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if !roleCodePattern.MatchString(string(role)) {
+		return nil, domain.ErrInvalidRole
+	}
+	organization, err := s.authorizeAdmin(ctx, actorID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := security.GenerateOpaqueToken(domain.InvitationTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	invitation := &models.Invitation{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Email:          email,
+		RoleCode:       role,
+		TokenHash:      security.HashToken(token),
+		InvitedBy:      &actorID,
+		ExpiresAt:      now.Add(domain.InvitationTTL),
+	}
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.roles.GetByCode(txCtx, role); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidRole
+			}
+			return err
+		}
+		if err := s.invitations.RevokePendingByEmail(txCtx, organizationID, email, now); err != nil {
+			return err
+		}
+		return s.invitations.Create(txCtx, invitation)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.InvitationCreatedEvent{
+		InvitationID:     invitation.ID,
+		OrganizationID:   organizationID,
+		OrganizationName: organization.Name,
+		Organization:     organization.Slug,
+		Email:            email,
+		Role:             string(role),
+		Token:            token,
+		ExpiresIn:        int(domain.InvitationTTL.Seconds()),
+		InvitedBy:        actorID,
+	})
+	return invitation, nil
+}
+
+// PendingInvitations returns the invitations of an organization that can
+// still be accepted, newest first, to its admins and ADMIN accounts.
+func (s *OrganizationService) PendingInvitations(ctx context.Context, actorID, organizationID uuid.UUID) ([]*models.Invitation, error) {
+	if _, err := s.authorizeAdmin(ctx, actorID, organizationID); err != nil {
+		return nil, err
+	}
+	return s.invitations.ListPending(ctx, organizationID, time.Now().UTC())
+}
+
+// RevokeInvitation revokes a pending invitation of an organization, which
+// its admins and ADMIN accounts may do.
+func (s *OrganizationService) RevokeInvitation(ctx context.Context, actorID, organizationID, invitationID uuid.UUID) error {
+	if _, err := s.authorizeAdmin(ctx, actorID, organizationID); err != nil {
+		return err
+	}
+	err := s.invitations.Revoke(ctx, invitationID, organizationID, time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvitationNotFound
+	}
+	return err
+}
+
+// AcceptInvitation makes the account a member of the organization it was
+// invited to, with the invited role, which replaces its role there if it is
+// a member already. The account must hold the invited address as a verified
+// EMAIL method, so invitees register or sign in with it first.
+func (s *OrganizationService) AcceptInvitation(ctx context.Context, accountID uuid.UUID, token string) (*AccountOrganization, error) {
+	invitation, err := s.invitations.GetByTokenHash(ctx, security.HashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidOrExpiredInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if !invitation.Pending(now) {
+		return nil, domain.ErrInvalidOrExpiredInvitation
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, invitation.Email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvitationEmailMismatch
+	}
+	if err != nil {
+		return nil, err
+	}
+	if method.AccountID != accountID || !method.IsVerified {
+		return nil, domain.ErrInvitationEmailMismatch
+	}
+
+	result := &AccountOrganization{
+		Membership: &models.Membership{OrganizationID: invitation.OrganizationID, AccountID: accountID, RoleCode: invitation.RoleCode},
+	}
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		// Accepting fails once the invitation is accepted, revoked or
+		// expired concurrently, or its organization deleted.
+		if err := s.invitations.Accept(txCtx, invitation.ID, accountID, now); err != nil {
+			return err
+		}
+		if err := s.memberships.Save(txCtx, result.Membership); err != nil {
+			return err
+		}
+		organization, err := s.organizations.GetByID(txCtx, invitation.OrganizationID)
+		result.Organization = organization
+		return err
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidOrExpiredInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.InvitationAcceptedEvent{
+		InvitationID:   invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		AccountID:      accountID,
+		Role:           string(invitation.RoleCode),
+	})
+	return result, nil
+}
+
+// authorizeAdmin returns the organization when the actor is an ADMIN account
+// or a member with the ADMIN role in it. Other accounts get
+// ErrOrganizationAdminRequired, whether the organization exists or not.
+func (s *OrganizationService) authorizeAdmin(ctx context.Context, actorID, organizationID uuid.UUID) (*models.Organization, error) {
+	actor, err := s.accounts.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.RoleCode != domain.RoleAdmin {
+		membership, err := s.memberships.Get(ctx, organizationID, actorID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrOrganizationAdminRequired
+		}
+		if err != nil {
+			return nil, err
+		}
+		if membership.RoleCode != domain.RoleAdmin {
+			return nil, domain.ErrOrganizationAdminRequired
+		}
+	}
+	return s.GetOrganization(ctx, organizationID)
+}
+
+// validateBranding trims the branding and checks that its sender can head
+// an email, its logo is an https URL and its colors are #rrggbb.
+func validateBranding(branding *models.Branding) error {
+	branding.SenderName = strings.TrimSpace(branding.SenderName)
+	branding.FooterText = strings.TrimSpace(branding.FooterText)
+	branding.LogoURL = strings.TrimSpace(branding.LogoURL)
+
+	if utf8.RuneCountInString(branding.SenderName) > domain.MaxSenderNameLength || strings.ContainsAny(branding.SenderName, "\r\n") {
+		return domain.ErrInvalidBranding
+	}
+	if branding.SenderAddress != "" {
+		address, err := normalizeEmail(branding.SenderAddress)
+		if err != nil {
+			return domain.ErrInvalidBranding
+		}
+		branding.SenderAddress = address
+	}
+	if branding.LogoURL != "" {
+		logo, err := url.Parse(branding.LogoURL)
+		if err != nil || logo.Scheme != "https" || logo.Host == "" || len(branding.LogoURL) > domain.MaxLogoURLLength {
+			return domain.ErrInvalidBranding
+		}
+	}
+	for _, color := range []string{branding.PrimaryColor, branding.BackgroundColor} {
+		if color != "" && !brandingColorPattern.MatchString(color) {
+			return domain.ErrInvalidBranding
+		}
+	}
+	if utf8.RuneCountInString(branding.FooterText) > domain.MaxFooterTextLength {
+		return domain.ErrInvalidBranding
+	}
+	return nil
+}
+
+// validatePolicy normalizes the policy and checks that its passwords can be
+// hashed and its sessions last long enough to be used. Providers are codes of
+// the auth_providers catalog; unknown ones never match a login.
+func validatePolicy(policy *models.AuthPolicy) error {
+	if policy.PasswordMinLength < 0 || policy.PasswordMinLength > domain.MaxPasswordLength {
+		return domain.ErrInvalidPolicy
+	}
+	classes := make([]domain.CharacterClass, 0, len(policy.PasswordRequiredClasses))
+	for _, class := range policy.PasswordRequiredClasses {
+		if _, ok := passwordClassViolations[class]; !ok {
+			return domain.ErrInvalidPolicy
+		}
+		if !slices.Contains(classes, class) {
+			classes = append(classes, class)
+		}
+	}
+	policy.PasswordRequiredClasses = classes
+	if policy.SessionMaxAge < 0 || policy.SessionMaxAge > domain.MaxPolicySessionMaxAge ||
+		(policy.SessionMaxAge > 0 && policy.SessionMaxAge < domain.MinPolicySessionMaxAge) {
+		return domain.ErrInvalidPolicy
+	}
+	policy.SessionMaxAge = policy.SessionMaxAge.Truncate(time.Second)
+
+	providers := make([]domain.Provider, 0, len(policy.AllowedProviders))
+	for _, provider := range policy.AllowedProviders {
+		provider = domain.Provider(strings.ToUpper(strings.TrimSpace(string(provider))))
+		if !roleCodePattern.MatchString(string(provider)) {
+			return domain.ErrInvalidPolicy
+		}
+		if !slices.Contains(providers, provider) {
+			providers = append(providers, provider)
+		}
+	}
+	policy.AllowedProviders = providers
+	return nil
+}
+
+// organizationName trims an organization name, which is required.
+func organizationName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > domain.MaxOrganizationNameLength {
+		return "", domain.ErrInvalidOrganizationName
+	}
+	return name, nil
+}
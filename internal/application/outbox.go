@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// outboxEvents decodes the stored events, by name. They are the events the
+// broker and webhooks report; others are only published in process.
+var outboxEvents = map[string]func(payload []byte) (events.Event, error){
+	events.NameUserRegistered:       decodeEvent[events.UserRegisteredEvent],
+	events.NameOAuthUserRegistered:  decodeEvent[events.OAuthUserRegisteredEvent],
+	events.NameAccountProvisioned:   decodeEvent[events.AccountProvisionedEvent],
+	events.NameAccountVerified:      decodeEvent[events.AccountVerifiedEvent],
+	events.NameAccountStatusChanged: decodeEvent[events.AccountStatusChangedEvent],
+	events.NameAccountRoleChanged:   decodeEvent[events.AccountRoleChangedEvent],
+	events.NameLoginSucceeded:       decodeEvent[events.LoginSucceededEvent],
+	events.NameLoginFailed:          decodeEvent[events.LoginFailedEvent],
+	events.NameSessionRevoked:       decodeEvent[events.SessionRevokedEvent],
+	events.NameAccountExpired:       decodeEvent[events.AccountExpiredEvent],
+	events.NameAccountPurged:        decodeEvent[events.AccountPurgedEvent],
+	events.NameAccountErased:        decodeEvent[events.AccountErasedEvent],
+	events.NamePasswordChanged:      decodeEvent[events.PasswordChangedEvent],
+	events.NameMFAEnabled:           decodeEvent[events.MFAEnabledEvent],
+	events.NameMFADisabled:          decodeEvent[events.MFADisabledEvent],
+}
+
+// Outbox is the event bus of the services. Events the broker and webhooks
+// report are stored in the transaction ctx carries, so they are relayed if
+// and only if the change that raised them commits. Every event reaches the
+// direct bus, which sends emails, once that change has committed.
+type Outbox struct {
+	txManager ports.TxManager
+	events    repositories.OutboxRepository
+	direct    ports.EventBus
+	relayed   ports.EventBus
+}
+
+func NewOutbox(
+	txManager ports.TxManager,
+	events repositories.OutboxRepository,
+	direct ports.EventBus,
+	relayed ports.EventBus,
+) *Outbox {
+	return &Outbox{
+		txManager: txManager,
+		events:    events,
+		direct:    direct,
+		relayed:   relayed,
+	}
+}
+
+func (o *Outbox) Publish(ctx context.Context, event events.Event) error {
+	if _, ok := outboxEvents[event.Name()]; ok {
+		payload, err := json.Marshal(withoutSecrets(event))
+		if err != nil {
+			return err
+		}
+		err = o.events.Create(ctx, &models.OutboxEvent{
+			ID:      uuid.New(),
+			Name:    event.Name(),
+			Payload: payload,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s in outbox: %w", event.Name(), err)
+		}
+	}
+
+	o.txManager.AfterCommit(ctx, func(ctx context.Context) { publish(ctx, o.direct, event) })
+	return nil
+}
+
+// RelayDue publishes the stored events due at now to the relayed bus, a
+// batch at a time, and returns how many it attempted. Each batch is claimed
+// for OutboxLease, so other instances skip it meanwhile, and published in
+// the order the events were stored. An event is published at least once:
+// one whose outcome is not recorded is published again once its lease runs
+// out, with the same id.
+func (o *Outbox) RelayDue(ctx context.Context, now time.Time) (int, error) {
+	attempted := 0
+	for {
+		due, err := o.events.ClaimDue(ctx, now, now.Add(domain.OutboxLease), domain.OutboxBatch)
+		if err != nil {
+			return attempted, err
+		}
+		for _, stored := range due {
+			o.relay(ctx, stored)
+		}
+
+		attempted += len(due)
+		if len(due) < domain.OutboxBatch {
+			return attempted, nil
+		}
+	}
+}
+
+// relay publishes a stored event and records the outcome, rescheduling it
+// with exponential backoff when it fails. Events that cannot be decoded,
+// such as those of a newer release, are retried too, so an instance that
+// knows them publishes them.
+func (o *Outbox) relay(ctx context.Context, stored *models.OutboxEvent) {
+	err := o.publishStored(ctx, stored)
+	now := time.Now().UTC()
+	if err == nil {
+		err = o.events.MarkPublished(ctx, stored.ID, now)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			slog.ErrorContext(ctx, "mark outbox event published", "outbox_event_id", stored.ID, "error", err)
+		}
+		return
+	}
+
+	slog.WarnContext(ctx, "publish outbox event", "outbox_event_id", stored.ID, "event", stored.Name, "error", err)
+	next := now.Add(outboxRetryDelay(stored.Attempts + 1))
+	if err := o.events.Reschedule(ctx, stored.ID, next, err.Error()); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		slog.ErrorContext(ctx, "reschedule outbox event", "outbox_event_id", stored.ID, "error", err)
+	}
+}
+
+func (o *Outbox) publishStored(ctx context.Context, stored *models.OutboxEvent) error {
+	decode, ok := outboxEvents[stored.Name]
+	if !ok {
+		return fmt.Errorf("unknown event")
+	}
+	event, err := decode(stored.Payload)
+	if err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	return o.relayed.Publish(events.WithID(ctx, stored.ID), event)
+}
+
+// PurgePublished deletes the events published before before and returns how
+// many it deleted.
+func (o *Outbox) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	return o.events.DeletePublishedBefore(ctx, before)
+}
+
+// outboxRetryDelay is the wait after the attempts made so far failed:
+// OutboxRetryBaseDelay after the first, doubling after each one up to
+// OutboxMaxRetryDelay.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := domain.OutboxRetryBaseDelay
+	for i := 1; i < attempts && delay < domain.OutboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, domain.OutboxMaxRetryDelay)
+}
+
+// withoutSecrets returns an event as it is stored: without the codes it
+// carries for emails, which the broker and webhooks never report.
+func withoutSecrets(event events.Event) events.Event {
+	if e, ok := event.(events.UserRegisteredEvent); ok {
+		e.Code = ""
+		return e
+	}
+	return event
+}
+
+func decodeEvent[T events.Event](payload []byte) (events.Event, error) {
+	var event T
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,466 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// PasskeyService registers WebAuthn passkeys and runs the ceremonies that use
+// them, either as the only login factor or to complete an MFA challenge.
+// Registration and passwordless login are gated by FeaturePasskeys; passkeys
+// already registered keep completing MFA challenges when it is off, so that
+// no account is locked out.
+type PasskeyService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	passkeys      repositories.PasskeyCredentialRepository
+	ceremonies    repositories.PasskeyCeremonyRepository
+	mfaChallenges repositories.MFAChallengeRepository
+	recoveryCodes repositories.MFARecoveryCodeRepository
+	sessions      *SessionIssuer
+	features      *FeatureFlags
+	webauthn      ports.WebAuthnService
+	metrics       ports.Metrics
+	audit         *AuditLog
+	eventBus      ports.EventBus
+}
+
+func NewPasskeyService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	passkeys repositories.PasskeyCredentialRepository,
+	ceremonies repositories.PasskeyCeremonyRepository,
+	mfaChallenges repositories.MFAChallengeRepository,
+	recoveryCodes repositories.MFARecoveryCodeRepository,
+	sessions *SessionIssuer,
+	features *FeatureFlags,
+	webauthn ports.WebAuthnService,
+	metrics ports.Metrics,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *PasskeyService {
+	return &PasskeyService{
+		txManager:     txManager,
+		accounts:      accounts,
+		authMethods:   authMethods,
+		passkeys:      passkeys,
+		ceremonies:    ceremonies,
+		mfaChallenges: mfaChallenges,
+		recoveryCodes: recoveryCodes,
+		sessions:      sessions,
+		features:      features,
+		webauthn:      webauthn,
+		metrics:       metrics,
+		audit:         audit,
+		eventBus:      eventBus,
+	}
+}
+
+// PasskeyCeremonyResult carries the options for navigator.credentials and
+// the ID the client presents to finish the ceremony.
+type PasskeyCeremonyResult struct {
+	CeremonyID uuid.UUID
+	Options    []byte
+	ExpiresAt  time.Time
+}
+
+// PasskeyRegistration is the stored credential, plus the account's first
+// recovery codes when the passkey is its first second factor.
+type PasskeyRegistration struct {
+	Credential    *models.PasskeyCredential
+	RecoveryCodes []string
+}
+
+func (s *PasskeyService) List(ctx context.Context, accountID uuid.UUID) ([]*models.PasskeyCredential, error) {
+	return s.passkeys.ListByAccountID(ctx, accountID)
+}
+
+func (s *PasskeyService) BeginRegistration(ctx context.Context, accountID uuid.UUID) (*PasskeyCeremonyResult, error) {
+	account, err := s.activeAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.features.require(ctx, domain.FeaturePasskeys, account.ID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.passkeyUser(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremony, err := s.webauthn.BeginRegistration(*user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storeCeremony(ctx, &account.ID, domain.PasskeyPurposeRegistration, ceremony)
+}
+
+// FinishRegistration verifies the attestation returned by the authenticator
+// and stores the new credential under an optional display name.
+func (s *PasskeyService) FinishRegistration(ctx context.Context, accountID, ceremonyID uuid.UUID, name string, response []byte) (*PasskeyRegistration, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > domain.MaxPasskeyNameLength {
+		return nil, domain.ErrInvalidPasskeyName
+	}
+
+	ceremony, err := s.consumeCeremony(ctx, ceremonyID, domain.PasskeyPurposeRegistration, &accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.activeAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.features.require(ctx, domain.FeaturePasskeys, account.ID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.passkeyUser(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.webauthn.FinishRegistration(*user, ceremony.State, response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPasskey, err)
+	}
+
+	credential.ID = uuid.New()
+	credential.AccountID = account.ID
+	credential.Name = optional(name)
+
+	var recoveryCodes []string
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.passkeys.Create(txCtx, credential); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return domain.ErrInvalidPasskey
+			}
+			return err
+		}
+
+		recoveryCodes, err = initialRecoveryCodes(txCtx, s.recoveryCodes, account.ID)
+		if err != nil {
+			return err
+		}
+		return s.audit.record(txCtx, domain.AuditMFAEnrolled, account.ID, account.ID, factorDetails(domain.MFAFactorPasskey))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publish(ctx, s.eventBus, events.PasskeyRegisteredEvent{
+		AccountID: account.ID,
+		PasskeyID: credential.ID,
+	})
+	publishRecoveryCodes(ctx, s.eventBus, account.ID, recoveryCodes)
+
+	return &PasskeyRegistration{Credential: credential, RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *PasskeyService) Delete(ctx context.Context, accountID, passkeyID uuid.UUID) error {
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.passkeys.Delete(txCtx, passkeyID, accountID); err != nil {
+			return err
+		}
+		return s.audit.record(txCtx, domain.AuditMFADisabled, accountID, accountID, factorDetails(domain.MFAFactorPasskey))
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrPasskeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	publish(ctx, s.eventBus, events.PasskeyRemovedEvent{
+		AccountID: accountID,
+		PasskeyID: passkeyID,
+	})
+
+	return nil
+}
+
+// BeginLogin starts a discoverable login: the authenticator picks the
+// account, so the client does not need to know the email address.
+func (s *PasskeyService) BeginLogin(ctx context.Context) (*PasskeyCeremonyResult, error) {
+	ceremony, err := s.webauthn.BeginLogin(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storeCeremony(ctx, nil, domain.PasskeyPurposeLogin, ceremony)
+}
+
+// FinishLogin verifies the assertion and opens a session. A user-verified
+// passkey already combines possession and a local PIN or biometric, so no
+// MFA challenge is issued.
+func (s *PasskeyService) FinishLogin(ctx context.Context, ceremonyID uuid.UUID, response []byte, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "PasskeyService.FinishLogin")
+	defer span.End()
+
+	ceremony, err := s.consumeCeremony(ctx, ceremonyID, domain.PasskeyPurposeLogin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		account *models.Account
+		user    *ports.PasskeyUser
+	)
+	assertion, err := s.webauthn.FinishLogin(ceremony.State, response, func(handle []byte) (*ports.PasskeyUser, error) {
+		accountID, err := uuid.FromBytes(handle)
+		if err != nil {
+			return nil, err
+		}
+		if account, err = s.accounts.GetByID(ctx, accountID); err != nil {
+			return nil, err
+		}
+		user, err = s.passkeyUser(ctx, account)
+		return user, err
+	})
+	if err != nil {
+		return nil, s.loginFailed(ctx, account, fmt.Errorf("%w: %v", domain.ErrInvalidPasskey, err))
+	}
+
+	credential, err := assertedCredential(user, assertion)
+	if err != nil {
+		return nil, s.loginFailed(ctx, account, err)
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, s.loginFailed(ctx, account, domain.ErrInvalidAccountState)
+	}
+	if err := s.features.require(ctx, domain.FeaturePasskeys, account.ID); err != nil {
+		return nil, err
+	}
+
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.recordUsage(txCtx, credential, assertion); err != nil {
+			return err
+		}
+
+		result, err = s.sessions.open(txCtx, account, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BeginMFA starts an assertion restricted to the passkeys of the account
+// behind an MFA challenge.
+func (s *PasskeyService) BeginMFA(ctx context.Context, mfaToken string) (*PasskeyCeremonyResult, error) {
+	challenge, err := activeMFAChallenge(ctx, s.mfaChallenges, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, challenge.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.passkeyUser(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.Credentials) == 0 {
+		return nil, domain.ErrMFANotEnrolled
+	}
+
+	ceremony, err := s.webauthn.BeginLogin(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storeCeremony(ctx, &account.ID, domain.PasskeyPurposeMFA, ceremony)
+}
+
+// FinishMFA completes an MFA challenge with a passkey assertion. Failed
+// assertions count against the challenge like invalid TOTP codes, and
+// rememberDevice behaves as in MFAService.VerifyChallenge.
+func (s *PasskeyService) FinishMFA(ctx context.Context, mfaToken string, ceremonyID uuid.UUID, response []byte, rememberDevice bool, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "PasskeyService.FinishMFA")
+	defer span.End()
+
+	challenge, err := activeMFAChallenge(ctx, s.mfaChallenges, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ceremony, err := s.consumeCeremony(ctx, ceremonyID, domain.PasskeyPurposeMFA, &challenge.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, challenge.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.passkeyUser(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, err := s.webauthn.FinishLogin(ceremony.State, response, func([]byte) (*ports.PasskeyUser, error) {
+		return user, nil
+	})
+	if err != nil {
+		return nil, failMFAChallenge(ctx, s.mfaChallenges, s.metrics, s.audit, s.eventBus, challenge, domain.MFAFactorPasskey, fmt.Errorf("%w: %v", domain.ErrInvalidPasskey, err))
+	}
+
+	credential, err := assertedCredential(user, assertion)
+	if errors.Is(err, domain.ErrInvalidPasskey) {
+		return nil, failMFAChallenge(ctx, s.mfaChallenges, s.metrics, s.audit, s.eventBus, challenge, domain.MFAFactorPasskey, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result *AuthResult
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.recordUsage(txCtx, credential, assertion); err != nil {
+			return err
+		}
+
+		result, err = completeMFAChallenge(txCtx, s.mfaChallenges, s.accounts, s.sessions, challenge, rememberDevice, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// loginFailed records a failed passkey login, on the account the passkey
+// claimed to belong to if it was found, and returns err.
+func (s *PasskeyService) loginFailed(ctx context.Context, account *models.Account, err error) error {
+	targetID := uuid.Nil
+	if account != nil {
+		targetID = account.ID
+	}
+	s.audit.recordFailure(ctx, domain.AuditLoginFailed, uuid.Nil, targetID, err, factorDetails(domain.MFAFactorPasskey))
+	if targetID != uuid.Nil {
+		publish(ctx, s.eventBus, events.LoginFailedEvent{
+			AccountID: targetID,
+			Factor:    string(domain.MFAFactorPasskey),
+			Reason:    err.Error(),
+		})
+	}
+	s.metrics.LoginAttempted("", string(domain.MFAFactorPasskey), domain.LoginResultFailure)
+	return err
+}
+
+func (s *PasskeyService) activeAccount(ctx context.Context, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+	return account, nil
+}
+
+// passkeyUser describes the account to the authenticator. The account ID is
+// the user handle, so discoverable logins resolve straight to the account.
+func (s *PasskeyService) passkeyUser(ctx context.Context, account *models.Account) (*ports.PasskeyUser, error) {
+	label, err := accountLabel(ctx, s.authMethods, account)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.passkeys.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := account.ID
+	return &ports.PasskeyUser{
+		Handle:      handle[:],
+		Name:        label,
+		DisplayName: label,
+		Credentials: credentials,
+	}, nil
+}
+
+func (s *PasskeyService) storeCeremony(ctx context.Context, accountID *uuid.UUID, purpose domain.PasskeyPurpose, ceremony *ports.PasskeyCeremony) (*PasskeyCeremonyResult, error) {
+	stored := &models.PasskeyCeremony{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Purpose:   purpose,
+		State:     ceremony.State,
+		ExpiresAt: time.Now().UTC().Add(domain.PasskeyCeremonyTTL),
+	}
+	if err := s.ceremonies.Create(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &PasskeyCeremonyResult{
+		CeremonyID: stored.ID,
+		Options:    ceremony.Options,
+		ExpiresAt:  stored.ExpiresAt,
+	}, nil
+}
+
+// consumeCeremony removes the ceremony and checks it was started for the same
+// purpose and account.
+func (s *PasskeyService) consumeCeremony(ctx context.Context, id uuid.UUID, purpose domain.PasskeyPurpose, accountID *uuid.UUID) (*models.PasskeyCeremony, error) {
+	ceremony, err := s.ceremonies.Consume(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidPasskeyCeremony
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ceremony.Purpose != purpose || !time.Now().Before(ceremony.ExpiresAt) {
+		return nil, domain.ErrInvalidPasskeyCeremony
+	}
+	if (ceremony.AccountID == nil) != (accountID == nil) {
+		return nil, domain.ErrInvalidPasskeyCeremony
+	}
+	if accountID != nil && *ceremony.AccountID != *accountID {
+		return nil, domain.ErrInvalidPasskeyCeremony
+	}
+
+	return ceremony, nil
+}
+
+func (s *PasskeyService) recordUsage(ctx context.Context, credential *models.PasskeyCredential, assertion *ports.PasskeyAssertion) error {
+	return s.passkeys.UpdateUsage(ctx, credential.ID, assertion.SignCount, assertion.BackupState, time.Now().UTC())
+}
+
+// assertedCredential finds the stored credential used for the assertion and
+// rejects it when its signature counter suggests a cloned authenticator.
+func assertedCredential(user *ports.PasskeyUser, assertion *ports.PasskeyAssertion) (*models.PasskeyCredential, error) {
+	if assertion.CloneWarning {
+		return nil, fmt.Errorf("%w: signature counter did not increase", domain.ErrInvalidPasskey)
+	}
+	for _, credential := range user.Credentials {
+		if bytes.Equal(credential.CredentialID, assertion.CredentialID) {
+			return credential, nil
+		}
+	}
+	return nil, domain.ErrInvalidPasskey
+}
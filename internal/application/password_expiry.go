@@ -0,0 +1,37 @@
+package application
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+// PasswordExpiry sets how long passwords stay valid for the accounts of each
+// role. Accounts whose password is older only receive tokens restricted to
+// changing it. Roles without a max age keep their passwords indefinitely.
+type PasswordExpiry struct {
+	maxAge map[domain.Role]time.Duration
+}
+
+func NewPasswordExpiry(maxAge map[domain.Role]time.Duration) *PasswordExpiry {
+	ages := make(map[domain.Role]time.Duration, len(maxAge))
+	for role, age := range maxAge {
+		if age > 0 {
+			ages[role] = age
+		}
+	}
+	return &PasswordExpiry{maxAge: ages}
+}
+
+// Applies reports whether the passwords of accounts with role expire.
+func (p *PasswordExpiry) Applies(role domain.Role) bool {
+	_, ok := p.maxAge[role]
+	return ok
+}
+
+// Expired reports whether a password of an account with role, last changed
+// at changedAt, must be changed by now.
+func (p *PasswordExpiry) Expired(role domain.Role, changedAt, now time.Time) bool {
+	age, ok := p.maxAge[role]
+	return ok && !now.Before(changedAt.Add(age))
+}
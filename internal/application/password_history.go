@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// PasswordHistory refuses passwords an auth method used recently. Size is how
+// many of its latest passwords, the current one included, cannot be chosen
+// again; zero disables the check and keeps no history.
+type PasswordHistory struct {
+	entries     repositories.PasswordHistoryRepository
+	credentials repositories.PasswordCredentialRepository
+	passwords   ports.PasswordHasher
+	size        int
+}
+
+func NewPasswordHistory(
+	entries repositories.PasswordHistoryRepository,
+	credentials repositories.PasswordCredentialRepository,
+	passwords ports.PasswordHasher,
+	size int,
+) *PasswordHistory {
+	return &PasswordHistory{entries: entries, credentials: credentials, passwords: passwords, size: size}
+}
+
+// check fails with a ValidationError wrapping ErrInvalidPassword when
+// password is the current password of the method or one of the previous ones
+// the history covers.
+func (h *PasswordHistory) check(ctx context.Context, authMethodID uuid.UUID, password string) error {
+	if h.size <= 0 {
+		return nil
+	}
+
+	var hashes []string
+	credential, err := h.credentials.GetByAuthMethodID(ctx, authMethodID)
+	switch {
+	case err == nil:
+		hashes = append(hashes, credential.PasswordHash)
+	case !errors.Is(err, domain.ErrNotFound):
+		return err
+	}
+	if h.size > 1 {
+		entries, err := h.entries.ListRecent(ctx, authMethodID, h.size-1)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			hashes = append(hashes, entry.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		reused, err := h.passwords.Verify(password, hash)
+		if err != nil {
+			return err
+		}
+		if reused {
+			return &domain.ValidationError{
+				Err: domain.ErrInvalidPassword,
+				Fields: []domain.FieldError{{
+					Field:  "password",
+					Code:   domain.PasswordRecentlyUsed,
+					Params: map[string]int{"history": h.size},
+				}},
+			}
+		}
+	}
+	return nil
+}
+
+// record keeps the hash of a password that is being replaced, dropping the
+// entries the history no longer covers.
+func (h *PasswordHistory) record(ctx context.Context, authMethodID uuid.UUID, previousHash string) error {
+	if h.size <= 1 {
+		return nil
+	}
+
+	err := h.entries.Create(ctx, &models.PasswordHistoryEntry{
+		ID:           uuid.New(),
+		AuthMethodID: authMethodID,
+		PasswordHash: previousHash,
+	})
+	if err != nil {
+		return err
+	}
+	return h.entries.Prune(ctx, authMethodID, h.size-1)
+}
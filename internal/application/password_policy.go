@@ -0,0 +1,153 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// passwordClassViolations maps every character class to the violation
+// reported when a password lacks it.
+var passwordClassViolations = map[domain.CharacterClass]string{
+	domain.CharacterClassLower:  domain.PasswordMissingLowercase,
+	domain.CharacterClassUpper:  domain.PasswordMissingUppercase,
+	domain.CharacterClassDigit:  domain.PasswordMissingDigit,
+	domain.CharacterClassSymbol: domain.PasswordMissingSymbol,
+}
+
+// PasswordPolicy sets the rules new passwords must follow: a length between
+// MinLength and MaxLength characters, at least one character of each of the
+// RequiredClasses, and none of the BannedWords or the local part of the
+// account's email address, compared without case. Passwords must also score
+// at least MinScore with the Strength estimator.
+type PasswordPolicy struct {
+	MinLength       int
+	MaxLength       int
+	RequiredClasses []domain.CharacterClass
+	BannedWords     []string
+	MinScore        int
+	Strength        ports.PasswordStrengthEstimator
+}
+
+// DefaultPasswordPolicy only bounds the length of passwords.
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength: domain.MinPasswordLength,
+	MaxLength: domain.MaxPasswordLength,
+}
+
+// Check reports a policy that could refuse every password, accept passwords
+// longer than the hash input bound, which logins enforce, or ban words short
+// enough to match too many passwords. A minimum score needs an estimator.
+func (p PasswordPolicy) Check() error {
+	if p.MinLength < 1 || p.MaxLength < p.MinLength {
+		return fmt.Errorf("password policy: length range %d-%d is empty", p.MinLength, p.MaxLength)
+	}
+	if p.MaxLength > domain.MaxPasswordLength {
+		return fmt.Errorf("password policy: maximum length %d exceeds %d", p.MaxLength, domain.MaxPasswordLength)
+	}
+	if len(p.RequiredClasses) > p.MaxLength {
+		return fmt.Errorf("password policy: %d required classes do not fit in %d characters", len(p.RequiredClasses), p.MaxLength)
+	}
+	for _, class := range p.RequiredClasses {
+		if _, ok := passwordClassViolations[class]; !ok {
+			return fmt.Errorf("password policy: unknown character class %q", class)
+		}
+	}
+	for _, word := range p.BannedWords {
+		if utf8.RuneCountInString(word) < domain.MinBannedWordLength {
+			return fmt.Errorf("password policy: banned word %q is shorter than %d characters", word, domain.MinBannedWordLength)
+		}
+	}
+	if p.MinScore < 0 || p.MinScore > domain.MaxPasswordScore {
+		return fmt.Errorf("password policy: minimum score %d is outside 0-%d", p.MinScore, domain.MaxPasswordScore)
+	}
+	if p.MinScore > 0 && p.Strength == nil {
+		return fmt.Errorf("password policy: minimum score %d needs a strength estimator", p.MinScore)
+	}
+	return nil
+}
+
+// Estimate rates the strength of password, chosen for the account with the
+// normalized address email, or returns nil without an estimator.
+func (p PasswordPolicy) Estimate(password, email string) *models.PasswordStrength {
+	if p.Strength == nil {
+		return nil
+	}
+	var inputs []string
+	if email != "" {
+		inputs = append(inputs, email, emailLocalPart(email))
+	}
+	return p.Strength.Estimate(password, inputs)
+}
+
+// Validate checks password, chosen for the account with the normalized
+// address email, and lists every broken rule in a ValidationError wrapping
+// ErrInvalidPassword.
+func (p PasswordPolicy) Validate(password, email string) error {
+	var violations []domain.FieldError
+	violate := func(code string, params map[string]int) {
+		violations = append(violations, domain.FieldError{Field: "password", Code: code, Params: params})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violate(domain.PasswordTooShort, map[string]int{"min": p.MinLength})
+	}
+	if length > p.MaxLength {
+		violate(domain.PasswordTooLong, map[string]int{"max": p.MaxLength})
+	}
+
+	for _, class := range p.RequiredClasses {
+		if !strings.ContainsFunc(password, characterClassTest(class)) {
+			violate(passwordClassViolations[class], nil)
+		}
+	}
+
+	lowered := strings.ToLower(password)
+	if local := emailLocalPart(email); utf8.RuneCountInString(local) >= domain.MinBannedWordLength && strings.Contains(lowered, local) {
+		violate(domain.PasswordContainsEmail, nil)
+	}
+	for _, word := range p.BannedWords {
+		if strings.Contains(lowered, strings.ToLower(word)) {
+			violate(domain.PasswordContainsBanned, nil)
+			break
+		}
+	}
+	if p.MinScore > 0 {
+		if strength := p.Estimate(password, email); strength.Score < p.MinScore {
+			violate(domain.PasswordTooWeak, map[string]int{"min_score": p.MinScore, "score": strength.Score})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &domain.ValidationError{Err: domain.ErrInvalidPassword, Fields: violations}
+	}
+	return nil
+}
+
+func characterClassTest(class domain.CharacterClass) func(rune) bool {
+	switch class {
+	case domain.CharacterClassLower:
+		return unicode.IsLower
+	case domain.CharacterClassUpper:
+		return unicode.IsUpper
+	case domain.CharacterClassDigit:
+		return unicode.IsDigit
+	default:
+		return func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+		}
+	}
+}
+
+// emailLocalPart returns the part of email before the @, without any +tag.
+func emailLocalPart(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	local, _, _ = strings.Cut(local, "+")
+	return strings.ToLower(local)
+}
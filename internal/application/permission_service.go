@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+// PermissionRequirement is what a resource server needs from a token. An
+// empty Role requires no role; ADMIN accounts satisfy any role and have every
+// permission.
+type PermissionRequirement struct {
+	Role        domain.Role
+	Scopes      []string
+	Permissions []string
+}
+
+// PermissionDecision is the outcome of a permission check, with the claims
+// of the token that was checked.
+type PermissionDecision struct {
+	Allowed bool
+	Claims  *models.AccessTokenClaims
+}
+
+// PermissionService answers whether a token grants a requirement, so other
+// services need not interpret roles and scopes themselves.
+type PermissionService struct {
+	validator *TokenValidator
+	accounts  repositories.AccountRepository
+	roles     repositories.RoleRepository
+}
+
+func NewPermissionService(validator *TokenValidator, accounts repositories.AccountRepository, roles repositories.RoleRepository) *PermissionService {
+	return &PermissionService{validator: validator, accounts: accounts, roles: roles}
+}
+
+// Check validates raw and matches it against requirement. Roles and
+// permissions are checked against the current role of the account, which
+// must still be ACTIVE, as those in the token may be stale. Unrestricted account tokens grant
+// every scope; other tokens only those they carry. Invalid tokens are
+// reported as ErrInvalidAccessToken.
+func (s *PermissionService) Check(ctx context.Context, raw string, requirement PermissionRequirement) (*PermissionDecision, error) {
+	claims, err := s.validator.Validate(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &PermissionDecision{Claims: claims}
+	if claims.Scope == domain.TokenScopeMFAEnrollment || claims.Scope == domain.TokenScopePasswordChange || claims.Scope == domain.TokenScopeConsent {
+		return decision, nil
+	}
+
+	if !claims.HasAccount() {
+		decision.Allowed = requirement.Role == "" && len(requirement.Permissions) == 0 && grantsScopes(claims.Scope, requirement.Scopes)
+		return decision, nil
+	}
+
+	account, err := s.accounts.GetByID(ctx, claims.AccountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return decision, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return decision, nil
+	}
+	if requirement.Role != "" && account.RoleCode != requirement.Role && account.RoleCode != domain.RoleAdmin {
+		return decision, nil
+	}
+	if len(requirement.Permissions) > 0 && account.RoleCode != domain.RoleAdmin {
+		permissions, err := s.roles.ListPermissions(ctx, account.RoleCode)
+		if err != nil {
+			return nil, err
+		}
+		if !containsAll(permissions, requirement.Permissions) {
+			return decision, nil
+		}
+	}
+
+	decision.Allowed = claims.Scope == domain.TokenScopeFull || grantsScopes(claims.Scope, requirement.Scopes)
+	return decision, nil
+}
+
+func grantsScopes(granted domain.TokenScope, required []string) bool {
+	return containsAll(strings.Fields(string(granted)), required)
+}
+
+func containsAll(granted, required []string) bool {
+	for _, value := range required {
+		if !slices.Contains(granted, value) {
+			return false
+		}
+	}
+	return true
+}
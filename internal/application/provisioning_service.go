@@ -0,0 +1,339 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// ProvisionedUser is an account as an identity provider manages it: the
+// account and the address of its EMAIL method, its user name.
+type ProvisionedUser struct {
+	Account *models.Account
+	Email   string
+}
+
+// Active reports whether the user may sign in.
+func (u *ProvisionedUser) Active() bool {
+	return u.Account.StatusCode == domain.StatusActive
+}
+
+// ProvisioningService lets an enterprise identity provider create, update,
+// deactivate and delete the accounts of its users. Accounts it manages are
+// those with an EMAIL method; the provider vouches for their addresses.
+type ProvisioningService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	authMethods   repositories.AuthMethodRepository
+	refreshTokens repositories.RefreshTokenRepository
+	denylist      ports.AccessTokenDenylist
+	eventBus      ports.EventBus
+}
+
+func NewProvisioningService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+	denylist ports.AccessTokenDenylist,
+	eventBus ports.EventBus,
+) *ProvisioningService {
+	return &ProvisioningService{
+		txManager:     txManager,
+		accounts:      accounts,
+		authMethods:   authMethods,
+		refreshTokens: refreshTokens,
+		denylist:      denylist,
+		eventBus:      eventBus,
+	}
+}
+
+// Create provisions an ACTIVE account, or a BANNED one when it starts
+// deactivated, with a verified EMAIL method. Without a password, the user
+// signs in with login codes or magic links until setting one.
+func (s *ProvisioningService) Create(ctx context.Context, email string, active bool) (*ProvisionedUser, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.StatusActive
+	if !active {
+		status = domain.StatusBanned
+	}
+
+	account := &models.Account{
+		ID:         uuid.New(),
+		RoleCode:   domain.RoleUser,
+		StatusCode: status,
+	}
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.accounts.Create(txCtx, account); err != nil {
+			return err
+		}
+		err := s.authMethods.Create(txCtx, &models.AuthMethod{
+			ID:           uuid.New(),
+			AccountID:    account.ID,
+			ProviderCode: domain.ProviderEmail,
+			ProviderID:   email,
+			IsVerified:   true,
+		})
+		if err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountProvisionedEvent{
+			AccountID: account.ID,
+			Email:     email,
+			Status:    string(status),
+		})
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrAccountAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProvisionedUser{Account: account, Email: email}, nil
+}
+
+// Get returns a provisioned user. DELETED accounts and accounts without an
+// EMAIL method are reported as ErrAccountNotFound.
+func (s *ProvisioningService) Get(ctx context.Context, accountID uuid.UUID) (*ProvisionedUser, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := s.emailMethod(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return &ProvisionedUser{Account: account, Email: method.ProviderID}, nil
+}
+
+// FindByEmail returns the provisioned user with the given address.
+func (s *ProvisioningService) FindByEmail(ctx context.Context, email string) (*ProvisionedUser, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	method, err := s.authMethods.GetByProvider(ctx, domain.ProviderEmail, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, method.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode == domain.StatusDeleted {
+		return nil, domain.ErrAccountNotFound
+	}
+	return &ProvisionedUser{Account: account, Email: method.ProviderID}, nil
+}
+
+// List pages through the provisioned users, oldest first, and counts them
+// all.
+func (s *ProvisioningService) List(ctx context.Context, offset, limit int) ([]*ProvisionedUser, int, error) {
+	total, err := s.authMethods.CountByProvider(ctx, domain.ProviderEmail)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit == 0 || offset >= total {
+		return []*ProvisionedUser{}, total, nil
+	}
+
+	methods, err := s.authMethods.ListByProvider(ctx, domain.ProviderEmail, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]uuid.UUID, 0, len(methods))
+	for _, method := range methods {
+		ids = append(ids, method.AccountID)
+	}
+	accounts, err := s.accounts.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uuid.UUID]*models.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	users := make([]*ProvisionedUser, 0, len(methods))
+	for _, method := range methods {
+		if account, ok := byID[method.AccountID]; ok {
+			users = append(users, &ProvisionedUser{Account: account, Email: method.ProviderID})
+		}
+	}
+	return users, total, nil
+}
+
+// Update sets the address and the activation of a provisioned user.
+// Deactivating an ACTIVE account bans it and ends its sessions; activating
+// a PENDING or BANNED one makes it ACTIVE, its address verified.
+func (s *ProvisioningService) Update(ctx context.Context, accountID uuid.UUID, email string, active bool) (*ProvisionedUser, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		user     *ProvisionedUser
+		previous domain.Status
+	)
+	now := time.Now().UTC()
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		account, err := s.lockAccount(txCtx, accountID)
+		if err != nil {
+			return err
+		}
+		method, err := s.emailMethod(txCtx, account)
+		if err != nil {
+			return err
+		}
+		previous = account.StatusCode
+
+		status := account.StatusCode
+		switch {
+		case active:
+			status = domain.StatusActive
+		case account.StatusCode == domain.StatusActive:
+			status = domain.StatusBanned
+		}
+
+		verified := method.IsVerified || status == domain.StatusActive
+		if email != method.ProviderID || verified != method.IsVerified {
+			if err := s.authMethods.UpdateProviderID(txCtx, method.ID, email, verified); err != nil {
+				return err
+			}
+		}
+		if status != account.StatusCode {
+			if err := s.setStatus(txCtx, account, status, now); err != nil {
+				return err
+			}
+		}
+
+		user = &ProvisionedUser{Account: account, Email: email}
+		return nil
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrAccountAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.statusChanged(ctx, user.Account, previous, now)
+	return user, nil
+}
+
+// Delete marks a provisioned user DELETED and ends its sessions. The account
+// is kept, so its address stays taken.
+func (s *ProvisioningService) Delete(ctx context.Context, accountID uuid.UUID) error {
+	var (
+		account  *models.Account
+		previous domain.Status
+	)
+	now := time.Now().UTC()
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		if account, err = s.lockAccount(txCtx, accountID); err != nil {
+			return err
+		}
+		if _, err := s.emailMethod(txCtx, account); err != nil {
+			return err
+		}
+		previous = account.StatusCode
+		return s.setStatus(txCtx, account, domain.StatusDeleted, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.statusChanged(ctx, account, previous, now)
+	return nil
+}
+
+// lockAccount reads the account for update, reporting DELETED accounts as
+// ErrAccountNotFound.
+func (s *ProvisioningService) lockAccount(ctx context.Context, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accounts.GetByIDForUpdate(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode == domain.StatusDeleted {
+		return nil, domain.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// emailMethod returns the EMAIL method of a provisioned account.
+func (s *ProvisioningService) emailMethod(ctx context.Context, account *models.Account) (*models.AuthMethod, error) {
+	if account.StatusCode == domain.StatusDeleted {
+		return nil, domain.ErrAccountNotFound
+	}
+	methods, err := s.authMethods.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		if method.ProviderCode == domain.ProviderEmail {
+			return method, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
+
+// setStatus moves the account to status, revoking its sessions unless it
+// becomes ACTIVE, and publishes the change.
+func (s *ProvisioningService) setStatus(ctx context.Context, account *models.Account, status domain.Status, now time.Time) error {
+	previous := account.StatusCode
+	if err := s.accounts.UpdateStatus(ctx, account.ID, status); err != nil {
+		return err
+	}
+	account.StatusCode = status
+	if status != domain.StatusActive {
+		if _, err := s.refreshTokens.RevokeAllByAccountID(ctx, account.ID, now); err != nil {
+			return err
+		}
+	}
+	if status == previous {
+		return nil
+	}
+	return publishWithin(ctx, s.eventBus, events.AccountStatusChangedEvent{
+		AccountID: account.ID,
+		Previous:  string(previous),
+		Status:    string(status),
+	})
+}
+
+// statusChanged denylists the access tokens of an account that can no longer
+// sign in, if its status changed.
+func (s *ProvisioningService) statusChanged(ctx context.Context, account *models.Account, previous domain.Status, now time.Time) {
+	if account.StatusCode == previous || account.StatusCode == domain.StatusActive {
+		return
+	}
+	if err := s.denylist.DenyAccount(ctx, account.ID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", account.ID, "error", err)
+	}
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// RefreshPolicy decides when the refresh token issued by a rotation expires.
+// ttl is the lifetime of the session, as selected by remember me.
+type RefreshPolicy interface {
+	ExpiresAt(previous *models.RefreshToken, ttl time.Duration, now time.Time) time.Time
+}
+
+// FixedExpiration keeps the expiry set at login: rotations never extend a
+// session.
+type FixedExpiration struct{}
+
+func (FixedExpiration) ExpiresAt(previous *models.RefreshToken, ttl time.Duration, now time.Time) time.Time {
+	return previous.ExpiresAt
+}
+
+// SlidingExpiration pushes the expiry to a full ttl after each rotation, so
+// sessions in use stay open, but never past Ceiling after the login that
+// opened the session.
+type SlidingExpiration struct {
+	Ceiling time.Duration
+}
+
+func (p SlidingExpiration) ExpiresAt(previous *models.RefreshToken, ttl time.Duration, now time.Time) time.Time {
+	expiresAt := now.Add(ttl)
+	if ceiling := previous.SessionStartedAt.Add(p.Ceiling); expiresAt.After(ceiling) {
+		return ceiling
+	}
+	return expiresAt
+}
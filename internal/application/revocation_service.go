@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+)
+
+// RevocationService answers RFC 7009 revocation requests. Refresh tokens are
+// revoked in the database; access tokens are put on the denylist until they
+// expire.
+type RevocationService struct {
+	tokens        ports.TokenService
+	denylist      ports.AccessTokenDenylist
+	refreshTokens repositories.RefreshTokenRepository
+	eventBus      ports.EventBus
+}
+
+func NewRevocationService(
+	tokens ports.TokenService,
+	denylist ports.AccessTokenDenylist,
+	refreshTokens repositories.RefreshTokenRepository,
+	eventBus ports.EventBus,
+) *RevocationService {
+	return &RevocationService{
+		tokens:        tokens,
+		denylist:      denylist,
+		refreshTokens: refreshTokens,
+		eventBus:      eventBus,
+	}
+}
+
+// Revoke tries the type named by hint first, then the other type. Unknown,
+// expired and already revoked tokens are not an error: the client only
+// learns that the token is no longer usable.
+func (s *RevocationService) Revoke(ctx context.Context, token, hint string) error {
+	revocations := []func(context.Context, string) (bool, error){s.revokeAccessToken, s.revokeRefreshToken}
+	if hint == domain.TokenTypeRefreshToken {
+		revocations[0], revocations[1] = revocations[1], revocations[0]
+	}
+
+	for _, revoke := range revocations {
+		found, err := revoke(ctx, token)
+		if err != nil || found {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RevocationService) revokeAccessToken(ctx context.Context, token string) (bool, error) {
+	claims, err := s.tokens.ParseAccessToken(ctx, token)
+	if err != nil {
+		return false, nil
+	}
+	return true, s.denylist.DenyToken(ctx, claims.TokenID, claims.ExpiresAt)
+}
+
+func (s *RevocationService) revokeRefreshToken(ctx context.Context, token string) (bool, error) {
+	refreshToken, err := s.refreshTokens.GetByTokenHash(ctx, security.HashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if refreshToken.RevokedAt != nil {
+		return true, nil
+	}
+
+	err = s.refreshTokens.Revoke(ctx, refreshToken.ID, time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	publishSessionRevoked(ctx, s.eventBus, refreshToken, domain.SessionRevokedByClient)
+	return true, nil
+}
@@ -0,0 +1,263 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	roleCodePattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,31}$`)
+	permissionPattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]*(:[a-z0-9_.*-]+)*$`)
+)
+
+// RoleService lets administrators define roles with their permissions and
+// change the role of accounts. Every change of role is recorded with who made
+// it.
+type RoleService struct {
+	txManager   ports.TxManager
+	accounts    repositories.AccountRepository
+	roles       repositories.RoleRepository
+	roleChanges repositories.RoleChangeRepository
+	denylist    ports.AccessTokenDenylist
+	audit       *AuditLog
+	eventBus    ports.EventBus
+}
+
+func NewRoleService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	roles repositories.RoleRepository,
+	roleChanges repositories.RoleChangeRepository,
+	denylist ports.AccessTokenDenylist,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *RoleService {
+	return &RoleService{
+		txManager:   txManager,
+		accounts:    accounts,
+		roles:       roles,
+		roleChanges: roleChanges,
+		denylist:    denylist,
+		audit:       audit,
+		eventBus:    eventBus,
+	}
+}
+
+// Assign gives an account a new role, system or custom. The last ACTIVE ADMIN account cannot
+// lose the role, so the service always keeps an administrator. The access
+// tokens issued before are denylisted: refreshes and API keys carry the new
+// role, which is read from the account.
+func (s *RoleService) Assign(ctx context.Context, adminID, accountID uuid.UUID, role domain.Role, reason string) (*models.RoleChange, error) {
+	if !roleCodePattern.MatchString(string(role)) {
+		return nil, domain.ErrInvalidRole
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > domain.MaxRoleChangeReasonLength {
+		return nil, domain.ErrInvalidRoleChangeReason
+	}
+
+	var change *models.RoleChange
+	now := time.Now().UTC()
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.roles.GetByCode(txCtx, role); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidRole
+			}
+			return err
+		}
+
+		// Locking the active admins first serializes demotions, so two
+		// admins cannot demote each other at once.
+		admins, err := s.accounts.ListIDsByRoleForUpdate(txCtx, domain.RoleAdmin, domain.StatusActive)
+		if err != nil {
+			return err
+		}
+
+		account, err := s.accounts.GetByIDForUpdate(txCtx, accountID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if account.StatusCode == domain.StatusDeleted {
+			return domain.ErrAccountNotFound
+		}
+		if account.RoleCode == role {
+			return domain.ErrRoleUnchanged
+		}
+		if account.RoleCode == domain.RoleAdmin && account.StatusCode == domain.StatusActive && len(admins) <= 1 {
+			return domain.ErrLastAdmin
+		}
+
+		if err := s.accounts.UpdateRole(txCtx, accountID, role); err != nil {
+			return err
+		}
+		change = &models.RoleChange{
+			ID:           uuid.New(),
+			AccountID:    accountID,
+			PreviousRole: account.RoleCode,
+			Role:         role,
+			ChangedBy:    &adminID,
+			Reason:       reason,
+		}
+		if err := s.roleChanges.Create(txCtx, change); err != nil {
+			return err
+		}
+		err = s.audit.record(txCtx, domain.AuditRoleChanged, adminID, accountID, map[string]string{
+			"previous_role": string(change.PreviousRole),
+			"role":          string(role),
+			"reason":        reason,
+		})
+		if err != nil {
+			return err
+		}
+		return publishWithin(txCtx, s.eventBus, events.AccountRoleChangedEvent{
+			AccountID: accountID,
+			Previous:  string(change.PreviousRole),
+			Role:      string(role),
+			ChangedBy: adminID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.denylist.DenyAccount(ctx, accountID, now); err != nil {
+		slog.ErrorContext(ctx, "denylist account", "account_id", accountID, "error", err)
+	}
+	return change, nil
+}
+
+// History returns every role change of an account, newest first.
+func (s *RoleService) History(ctx context.Context, accountID uuid.UUID) ([]*models.RoleChange, error) {
+	if _, err := s.accounts.GetByID(ctx, accountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return s.roleChanges.ListByAccountID(ctx, accountID)
+}
+
+// CreateRole defines a role that accounts can then be given.
+func (s *RoleService) CreateRole(ctx context.Context, code domain.Role, description string, permissions []string) (*models.Role, error) {
+	role, err := newRole(code, description, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return s.roles.Create(txCtx, role)
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, domain.ErrRoleAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// GetRole returns a role with its permissions.
+func (s *RoleService) GetRole(ctx context.Context, code domain.Role) (*models.Role, error) {
+	role, err := s.roles.GetByCode(ctx, code)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrRoleNotFound
+	}
+	return role, err
+}
+
+// ListRoles returns every role, system roles included, by code.
+func (s *RoleService) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	return s.roles.List(ctx)
+}
+
+// UpdateRole replaces the description and the permissions of a role, system
+// roles included. Access tokens carry the new permissions once refreshed.
+func (s *RoleService) UpdateRole(ctx context.Context, code domain.Role, description string, permissions []string) (*models.Role, error) {
+	role, err := newRole(code, description, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		current, err := s.roles.GetByCode(txCtx, code)
+		if err != nil {
+			return err
+		}
+		role.System, role.CreatedAt = current.System, current.CreatedAt
+		return s.roles.Update(txCtx, role)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteRole deletes a role that no account has. System roles cannot be
+// deleted.
+func (s *RoleService) DeleteRole(ctx context.Context, code domain.Role) error {
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		role, err := s.roles.GetByCode(txCtx, code)
+		if err != nil {
+			return err
+		}
+		if role.System {
+			return domain.ErrSystemRole
+		}
+		return s.roles.Delete(txCtx, code)
+	})
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return domain.ErrRoleNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return domain.ErrRoleInUse
+	}
+	return err
+}
+
+// newRole checks a role definition. Codes are uppercase identifiers such as
+// BILLING_ADMIN; permissions are lowercase, colon separated names such as
+// orders:write, which are deduplicated and sorted.
+func newRole(code domain.Role, description string, permissions []string) (*models.Role, error) {
+	if !roleCodePattern.MatchString(string(code)) {
+		return nil, domain.ErrInvalidRole
+	}
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > domain.MaxRoleDescriptionLength {
+		return nil, domain.ErrInvalidRoleDescription
+	}
+
+	normalized := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if len(permission) > domain.MaxPermissionLength || !permissionPattern.MatchString(permission) {
+			return nil, domain.ErrInvalidPermission
+		}
+		if !slices.Contains(normalized, permission) {
+			normalized = append(normalized, permission)
+		}
+	}
+	if len(normalized) > domain.MaxRolePermissions {
+		return nil, domain.ErrInvalidPermission
+	}
+	slices.Sort(normalized)
+
+	return &models.Role{Code: code, Description: description, Permissions: normalized}, nil
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// Job is maintenance work the Scheduler runs every Interval.
+type Job struct {
+	// Name identifies the job in logs and metrics, such as
+	// lift_expired_bans.
+	Name     string
+	Interval time.Duration
+	// Run does the work due at now. Runs of a job never overlap on an
+	// instance, but a run may repeat on another instance when the lead
+	// changes hands, so it must be safe to repeat.
+	Run func(ctx context.Context, now time.Time) error
+}
+
+// JobFailureHook is called after a run of job failed with err, failures
+// being how many runs of it in a row have failed, so alerts can fire on the
+// first failure or on a streak.
+type JobFailureHook func(ctx context.Context, job string, err error, failures int)
+
+// Scheduler runs the registered jobs on the instance leading the election,
+// and on no other. Every instance runs one, so when the leader stops another
+// takes the lead on its next tick.
+type Scheduler struct {
+	leader  ports.LeaderElection
+	metrics ports.Metrics
+	jobs    []Job
+	hooks   []JobFailureHook
+}
+
+func NewScheduler(leader ports.LeaderElection, metrics ports.Metrics) *Scheduler {
+	return &Scheduler{leader: leader, metrics: metrics}
+}
+
+// Register adds job to those Run runs. It must be called before Run.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// OnFailure calls hook after every failed run. It must be called before Run.
+func (s *Scheduler) OnFailure(hook JobFailureHook) {
+	s.hooks = append(s.hooks, hook)
+}
+
+// Run runs every job each interval, while this instance leads, until ctx is
+// done. It then lets the runs in progress finish and resigns the lead.
+func (s *Scheduler) Run(ctx context.Context) {
+	run := context.WithoutCancel(ctx)
+	var jobs sync.WaitGroup
+	for _, job := range s.jobs {
+		jobs.Go(func() { s.schedule(ctx, run, job) })
+	}
+	jobs.Wait()
+
+	if err := s.leader.Resign(run); err != nil {
+		slog.ErrorContext(ctx, "resign job leadership", "error", err)
+	}
+}
+
+// schedule runs job on every tick of its interval until ctx is done. Runs
+// use run, which outlives ctx, so that they finish once started.
+func (s *Scheduler) schedule(ctx, run context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			leads, err := s.leader.Lead(run)
+			if err != nil {
+				slog.ErrorContext(ctx, "elect job leader", "job", job.Name, "error", err)
+				continue
+			}
+			if !leads {
+				failures = 0
+				continue
+			}
+
+			started := time.Now()
+			err = job.Run(run, now.UTC())
+			s.metrics.JobCompleted(job.Name, err == nil, time.Since(started))
+			if err == nil {
+				failures = 0
+				continue
+			}
+			failures++
+			for _, hook := range s.hooks {
+				hook(run, job.Name, err, failures)
+			}
+		}
+	}
+}
@@ -0,0 +1,671 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// ClientInfo describes the device a session is opened from. DeviceToken is
+// the token of a trusted device, when the client presents one, and
+// RememberMe asks for the long session lifetime. KeyThumbprint is the key of
+// a verified DPoP proof, which the session's tokens are bound to.
+type ClientInfo struct {
+	IPAddress     string
+	UserAgent     string
+	DeviceToken   string
+	RememberMe    bool
+	KeyThumbprint string
+	// CaptchaToken is the CAPTCHA solution sent with the request, if any.
+	CaptchaToken string
+	// Scope is the space separated scope requested for the session, whose
+	// access tokens are then restricted to it. Empty asks for an
+	// unrestricted session.
+	Scope string
+	// OrganizationID is the organization the session acts in, which the
+	// account must be a member of; uuid.Nil opens it outside of any.
+	OrganizationID uuid.UUID
+	// Organization is the slug of the white-label organization the request
+	// comes from, whose branding the emails it triggers use.
+	Organization string
+	// Provider is the provider the login authenticated with, which the
+	// policies of the account's organizations may not allow. Empty for
+	// logins that do not go through a provider and for rotations.
+	Provider domain.Provider
+}
+
+// AuthResult carries either a new session or, when the account has a
+// confirmed second factor, the MFA challenge that must be completed first.
+// Accounts that the MFA policy requires to enroll a factor get a restricted
+// access token and no refresh token, flagged by MFAEnrollmentRequired, and so
+// do accounts whose password has expired, flagged by PasswordChangeRequired,
+// and accounts yet to accept the current legal documents, flagged by
+// ConsentRequired.
+type AuthResult struct {
+	Account               *models.Account
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+	RememberMe            bool
+	SessionID             uuid.UUID
+	// Scope is the scope granted to the session, empty when unrestricted.
+	Scope domain.TokenScope
+	// Membership is the account's membership of the active organization of
+	// the session, nil when it has none.
+	Membership             *models.Membership
+	MFAChallenge           *MFAChallengeResult
+	MFAEnrollmentRequired  bool
+	PasswordChangeRequired bool
+	ConsentRequired        bool
+	// PendingDocuments are the legal documents to accept when
+	// ConsentRequired is set.
+	PendingDocuments []*models.LegalDocument
+	// DeviceToken is set when the device was trusted while completing the
+	// MFA challenge.
+	DeviceToken          string
+	DeviceTokenExpiresAt time.Time
+}
+
+// Restricted reports whether the result is a restricted session, which is
+// not handed to other applications.
+func (r *AuthResult) Restricted() bool {
+	return r.MFAEnrollmentRequired || r.PasswordChangeRequired || r.ConsentRequired
+}
+
+// MFAChallengeResult lists the factors that can complete the challenge.
+type MFAChallengeResult struct {
+	Token     string
+	ExpiresAt time.Time
+	Methods   []domain.MFAFactorType
+}
+
+// SessionIssuer opens sessions on behalf of every login flow, so the session
+// rules live in a single place. It is shared by the services that log users in.
+type SessionIssuer struct {
+	refreshTokens repositories.RefreshTokenRepository
+	tokens        ports.TokenService
+	roles         repositories.RoleRepository
+	memberships   repositories.MembershipRepository
+	policies      repositories.AuthPolicyRepository
+	mfaFactors    repositories.MFAFactorRepository
+	mfaChallenges repositories.MFAChallengeRepository
+	passkeys      repositories.PasskeyCredentialRepository
+	recoveryCodes repositories.MFARecoveryCodeRepository
+	authMethods   repositories.AuthMethodRepository
+	credentials   repositories.PasswordCredentialRepository
+	policy        *MFAPolicy
+	features      *FeatureFlags
+	expiry        *PasswordExpiry
+	documents     repositories.LegalDocumentRepository
+	devices       repositories.TrustedDeviceRepository
+	limit         SessionLimit
+	durations     atomic.Pointer[sessionDurations]
+	metrics       ports.Metrics
+	audit         *AuditLog
+	eventBus      ports.EventBus
+}
+
+func NewSessionIssuer(
+	refreshTokens repositories.RefreshTokenRepository,
+	tokens ports.TokenService,
+	roles repositories.RoleRepository,
+	memberships repositories.MembershipRepository,
+	policies repositories.AuthPolicyRepository,
+	mfaFactors repositories.MFAFactorRepository,
+	mfaChallenges repositories.MFAChallengeRepository,
+	passkeys repositories.PasskeyCredentialRepository,
+	recoveryCodes repositories.MFARecoveryCodeRepository,
+	authMethods repositories.AuthMethodRepository,
+	credentials repositories.PasswordCredentialRepository,
+	policy *MFAPolicy,
+	features *FeatureFlags,
+	expiry *PasswordExpiry,
+	documents repositories.LegalDocumentRepository,
+	devices repositories.TrustedDeviceRepository,
+	deviceTTL time.Duration,
+	limit SessionLimit,
+	lifetime SessionLifetime,
+	metrics ports.Metrics,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *SessionIssuer {
+	issuer := &SessionIssuer{
+		refreshTokens: refreshTokens,
+		tokens:        tokens,
+		roles:         roles,
+		memberships:   memberships,
+		policies:      policies,
+		mfaFactors:    mfaFactors,
+		mfaChallenges: mfaChallenges,
+		passkeys:      passkeys,
+		recoveryCodes: recoveryCodes,
+		authMethods:   authMethods,
+		credentials:   credentials,
+		policy:        policy,
+		features:      features,
+		expiry:        expiry,
+		documents:     documents,
+		devices:       devices,
+		limit:         limit,
+		metrics:       metrics,
+		audit:         audit,
+		eventBus:      eventBus,
+	}
+	issuer.SetDurations(lifetime, deviceTTL)
+	return issuer
+}
+
+// sessionDurations are the lifetimes SetDurations swaps together.
+type sessionDurations struct {
+	lifetime  SessionLifetime
+	deviceTTL time.Duration
+}
+
+// SetDurations changes the lifetime of the sessions opened and refreshed,
+// and of the devices trusted, from now on.
+func (i *SessionIssuer) SetDurations(lifetime SessionLifetime, deviceTTL time.Duration) {
+	i.durations.Store(&sessionDurations{lifetime: lifetime, deviceTTL: deviceTTL})
+}
+
+// login completes a primary authentication. Accounts with a confirmed second
+// factor or a registered passkey receive an MFA challenge and keep their
+// existing sessions until the challenge is verified, unless they log in from
+// a trusted device. Recovery codes are offered alongside those factors but
+// never trigger a challenge on their own. Providers the account's
+// organizations do not allow are refused before any challenge.
+func (i *SessionIssuer) login(ctx context.Context, account *models.Account, client ClientInfo) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "SessionIssuer.login")
+	defer span.End()
+
+	tenant, err := i.tenantPolicy(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.allows(client.Provider) {
+		return nil, domain.ErrProviderNotAllowed
+	}
+
+	methods, err := i.secondFactors(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) > 0 {
+		trusted, err := i.trustedDevice(ctx, account.ID, client.DeviceToken)
+		if err != nil {
+			return nil, err
+		}
+		if trusted {
+			return i.open(ctx, account, client)
+		}
+
+		remaining, err := i.recoveryCodes.CountUnused(ctx, account.ID)
+		if err != nil {
+			return nil, err
+		}
+		if remaining > 0 {
+			methods = append(methods, domain.MFAFactorRecoveryCode)
+		}
+		return i.challenge(ctx, account, methods, client)
+	}
+
+	return i.open(ctx, account, client)
+}
+
+// secondFactors lists the factor types able to complete an MFA challenge.
+func (i *SessionIssuer) secondFactors(ctx context.Context, accountID uuid.UUID) ([]domain.MFAFactorType, error) {
+	factors, err := i.mfaFactors.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []domain.MFAFactorType
+	for _, factor := range factors {
+		if factor.ConfirmedAt != nil {
+			methods = append(methods, factor.FactorType)
+		}
+	}
+
+	passkeys, err := i.passkeys.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(passkeys) > 0 {
+		methods = append(methods, domain.MFAFactorPasskey)
+	}
+
+	return methods, nil
+}
+
+func (i *SessionIssuer) challenge(ctx context.Context, account *models.Account, methods []domain.MFAFactorType, client ClientInfo) (*AuthResult, error) {
+	plain, err := security.GenerateOpaqueToken(domain.MFAChallengeTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &models.MFAChallenge{
+		ID:         uuid.New(),
+		AccountID:  account.ID,
+		TokenHash:  security.HashToken(plain),
+		RememberMe: client.RememberMe,
+		Scope:      client.Scope,
+		ExpiresAt:  time.Now().UTC().Add(domain.MFAChallengeTTL),
+	}
+	if err := i.mfaChallenges.Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	i.metrics.LoginAttempted(string(client.Provider), "", domain.LoginResultMFAChallenge)
+	return &AuthResult{
+		Account: account,
+		MFAChallenge: &MFAChallengeResult{
+			Token:     plain,
+			ExpiresAt: challenge.ExpiresAt,
+			Methods:   methods,
+		},
+	}, nil
+}
+
+// trustedDevice reports whether token identifies an unexpired trusted device
+// of the account, recording its use.
+func (i *SessionIssuer) trustedDevice(ctx context.Context, accountID uuid.UUID, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	device, err := i.devices.GetByTokenHash(ctx, security.HashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	if device.AccountID != accountID || !now.Before(device.ExpiresAt) {
+		return false, nil
+	}
+
+	if err := i.devices.UpdateLastUsed(ctx, device.ID, now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// trustDevice issues a device token for the client of a session just opened
+// through an MFA challenge, so later logins from it skip the challenge.
+func (i *SessionIssuer) trustDevice(ctx context.Context, result *AuthResult, client ClientInfo) error {
+	plain, err := security.GenerateOpaqueToken(domain.TrustedDeviceTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	device := &models.TrustedDevice{
+		ID:        uuid.New(),
+		AccountID: result.Account.ID,
+		TokenHash: security.HashToken(plain),
+		IPAddress: optional(client.IPAddress),
+		UserAgent: optional(client.UserAgent),
+		ExpiresAt: time.Now().UTC().Add(i.durations.Load().deviceTTL),
+	}
+	if err := i.devices.Create(ctx, device); err != nil {
+		return err
+	}
+
+	result.DeviceToken = plain
+	result.DeviceTokenExpiresAt = device.ExpiresAt
+	return nil
+}
+
+// open starts a new session for a completed login, which it records in the
+// audit log and publishes within the caller's transaction.
+func (i *SessionIssuer) open(ctx context.Context, account *models.Account, client ClientInfo) (*AuthResult, error) {
+	now := time.Now().UTC()
+	lifetime := i.durations.Load().lifetime
+	expiresAt := lifetime.clamp(now, now.Add(lifetime.refreshTTL(client.RememberMe)))
+	sessionID := uuid.New()
+	result, err := i.issue(ctx, account, client, sessionID, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"session_id": sessionID.String()}
+	if client.Provider != "" {
+		details["provider"] = string(client.Provider)
+	}
+	if err := i.audit.record(ctx, domain.AuditLoginSucceeded, account.ID, account.ID, details); err != nil {
+		return nil, err
+	}
+
+	err = publishWithin(ctx, i.eventBus, events.LoginSucceededEvent{
+		AccountID: account.ID,
+		SessionID: sessionID,
+		Provider:  string(client.Provider),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.metrics.LoginAttempted(string(client.Provider), "", domain.LoginResultSuccess)
+	return result, nil
+}
+
+// rotate continues the session of a refresh token that has just been
+// revoked, keeping its ID, remember me choice, start time, DPoP key, scope
+// and active organization. The refresh policy decides the new expiry, within
+// the absolute session lifetime; once it has passed, the session is over.
+func (i *SessionIssuer) rotate(ctx context.Context, account *models.Account, previous *models.RefreshToken, client ClientInfo) (*AuthResult, error) {
+	now := time.Now().UTC()
+	lifetime := i.durations.Load().lifetime
+	expiresAt := lifetime.Refresh.ExpiresAt(previous, lifetime.refreshTTL(previous.RememberMe), now)
+	expiresAt = lifetime.clamp(previous.SessionStartedAt, expiresAt)
+	if !now.Before(expiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	client.RememberMe = previous.RememberMe
+	client.Scope = previous.Scope
+	client.OrganizationID = uuid.Nil
+	if previous.OrganizationID != nil {
+		client.OrganizationID = *previous.OrganizationID
+	}
+	if previous.KeyThumbprint != nil {
+		client.KeyThumbprint = *previous.KeyThumbprint
+	}
+	result, err := i.issue(ctx, account, client, previous.SessionID, previous.SessionStartedAt, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"session_id": previous.SessionID.String()}
+	if err := i.audit.record(ctx, domain.AuditTokenRefreshed, account.ID, account.ID, details); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// issue enforces the session limit, persists a new refresh token and mints
+// the access token that accompanies it. Accounts out of compliance with the
+// MFA policy, that of one of their organizations or FeatureAdminMFA, get a
+// restricted session instead, and lose their other sessions. Accounts with
+// an expired password get a session restricted to changing it, and accounts
+// yet to accept the current version of a legal document one restricted to
+// accepting it. Organizations may also shorten the sessions of their
+// members, which end once the shortest lifetime has passed.
+func (i *SessionIssuer) issue(
+	ctx context.Context,
+	account *models.Account,
+	client ClientInfo,
+	sessionID uuid.UUID,
+	startedAt, expiresAt time.Time,
+) (*AuthResult, error) {
+	ctx, span := tracer.Start(ctx, "SessionIssuer.issue")
+	defer span.End()
+
+	now := time.Now().UTC()
+	tenant, err := i.tenantPolicy(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.allows(client.Provider) {
+		return nil, domain.ErrProviderNotAllowed
+	}
+	if expiresAt = tenant.clamp(startedAt, expiresAt); !now.Before(expiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	requireMFA, err := i.requiresMFA(ctx, account, tenant)
+	if err != nil {
+		return nil, err
+	}
+	if requireMFA {
+		methods, err := i.secondFactors(ctx, account.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(methods) == 0 {
+			if _, err := i.refreshTokens.RevokeAllByAccountID(ctx, account.ID, now); err != nil {
+				return nil, err
+			}
+			return i.restricted(ctx, account, domain.TokenScopeMFAEnrollment)
+		}
+	}
+	if i.expiry.Applies(account.RoleCode) {
+		expired, err := i.passwordExpired(ctx, account, now)
+		if err != nil {
+			return nil, err
+		}
+		if expired {
+			return i.restricted(ctx, account, domain.TokenScopePasswordChange)
+		}
+	}
+	pending, err := i.documents.ListPendingByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		result, err := i.restricted(ctx, account, domain.TokenScopeConsent)
+		if err != nil {
+			return nil, err
+		}
+		result.PendingDocuments = pending
+		return result, nil
+	}
+
+	permissions, err := i.roles.ListPermissions(ctx, account.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := grantScope(account, permissions, client.Scope)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := i.membership(ctx, account.ID, client.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.enforceLimit(ctx, account.ID, now); err != nil {
+		return nil, err
+	}
+
+	plain, err := security.GenerateOpaqueToken(domain.RefreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &models.RefreshToken{
+		ID:               uuid.New(),
+		AccountID:        account.ID,
+		SessionID:        sessionID,
+		TokenHash:        security.HashToken(plain),
+		IPAddress:        optional(client.IPAddress),
+		UserAgent:        optional(client.UserAgent),
+		RememberMe:       client.RememberMe,
+		SessionStartedAt: startedAt,
+		KeyThumbprint:    optional(client.KeyThumbprint),
+		Scope:            string(scope),
+		ExpiresAt:        expiresAt,
+	}
+	opts := models.AccessTokenOptions{
+		Scope:         scope,
+		SessionID:     token.SessionID,
+		KeyThumbprint: client.KeyThumbprint,
+		Permissions:   permissions,
+	}
+	if membership != nil {
+		token.OrganizationID = &membership.OrganizationID
+		opts.OrganizationID, opts.OrganizationRole = membership.OrganizationID, membership.RoleCode
+	}
+	if err := i.refreshTokens.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	accessToken, claims, err := i.tokens.GenerateAccessToken(ctx, account, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResult{
+		Account:               account,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  claims.ExpiresAt,
+		RefreshToken:          plain,
+		RefreshTokenExpiresAt: token.ExpiresAt,
+		RememberMe:            token.RememberMe,
+		SessionID:             token.SessionID,
+		Scope:                 scope,
+		Membership:            membership,
+	}, nil
+}
+
+// requiresMFA reports whether the account must be protected by a second
+// factor, by the MFA policy, the policy of one of its organizations or, for
+// ADMIN accounts, FeatureAdminMFA.
+func (i *SessionIssuer) requiresMFA(ctx context.Context, account *models.Account, tenant tenantPolicy) (bool, error) {
+	if i.policy.Requires(account.RoleCode) || tenant.requireMFA {
+		return true, nil
+	}
+	if account.RoleCode != domain.RoleAdmin {
+		return false, nil
+	}
+	return i.features.Enabled(ctx, domain.FeatureAdminMFA, account.ID)
+}
+
+// tenantPolicy combines the policies of the organizations the account is a
+// member of.
+func (i *SessionIssuer) tenantPolicy(ctx context.Context, accountID uuid.UUID) (tenantPolicy, error) {
+	policies, err := i.policies.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return tenantPolicy{}, err
+	}
+	return combinePolicies(policies), nil
+}
+
+// membership returns the account's membership of the organization a session
+// acts in, or nil when it acts outside of any. A session whose account was
+// removed from the organization since continues outside of it.
+func (i *SessionIssuer) membership(ctx context.Context, accountID, organizationID uuid.UUID) (*models.Membership, error) {
+	if organizationID == uuid.Nil {
+		return nil, nil
+	}
+	membership, err := i.memberships.Get(ctx, organizationID, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return membership, err
+}
+
+// grantScope restricts the scope requested for a session to the permissions
+// of the account's role; ADMIN accounts may be granted any scope. A request
+// for only scopes the account is not allowed fails with ErrInvalidScope
+// rather than yielding an unrestricted session.
+func grantScope(account *models.Account, permissions []string, requested string) (domain.TokenScope, error) {
+	if strings.TrimSpace(requested) == "" {
+		return domain.TokenScopeFull, nil
+	}
+	scopes, err := requestedScopes(strings.Fields(requested))
+	if err != nil {
+		return "", err
+	}
+
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if account.RoleCode == domain.RoleAdmin || slices.Contains(permissions, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	if len(granted) == 0 {
+		return "", domain.ErrInvalidScope
+	}
+	return domain.TokenScope(strings.Join(granted, " ")), nil
+}
+
+// enforceLimit makes room for one more session of the account, revoking the
+// oldest active sessions or failing with ErrSessionLimitReached depending on
+// the strategy.
+func (i *SessionIssuer) enforceLimit(ctx context.Context, accountID uuid.UUID, now time.Time) error {
+	if !i.limit.enabled() {
+		return nil
+	}
+
+	active, err := i.refreshTokens.ListActiveByAccountID(ctx, accountID, now)
+	if err != nil {
+		return err
+	}
+	if len(active) < i.limit.Max {
+		return nil
+	}
+	if i.limit.Strategy == domain.SessionLimitReject {
+		return domain.ErrSessionLimitReached
+	}
+
+	// Active sessions are listed newest first.
+	for _, token := range active[i.limit.Max-1:] {
+		if err := i.refreshTokens.Revoke(ctx, token.ID, now); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// passwordExpired reports whether the account's password is older than its
+// role allows. Accounts without a password never have an expired one.
+func (i *SessionIssuer) passwordExpired(ctx context.Context, account *models.Account, now time.Time) (bool, error) {
+	methods, err := i.authMethods.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, method := range methods {
+		if method.ProviderCode != domain.ProviderEmail {
+			continue
+		}
+		credential, err := i.credentials.GetByAuthMethodID(ctx, method.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return i.expiry.Expired(account.RoleCode, credential.UpdatedAt, now), nil
+	}
+	return false, nil
+}
+
+// restricted mints an access token that only allows enrolling a second
+// factor, changing the password or accepting the legal documents, depending
+// on scope. No refresh token is issued: once done, the user signs in again.
+func (i *SessionIssuer) restricted(ctx context.Context, account *models.Account, scope domain.TokenScope) (*AuthResult, error) {
+	accessToken, claims, err := i.tokens.GenerateAccessToken(ctx, account, models.AccessTokenOptions{Scope: scope})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResult{
+		Account:                account,
+		AccessToken:            accessToken,
+		AccessTokenExpiresAt:   claims.ExpiresAt,
+		MFAEnrollmentRequired:  scope == domain.TokenScopeMFAEnrollment,
+		PasswordChangeRequired: scope == domain.TokenScopePasswordChange,
+		ConsentRequired:        scope == domain.TokenScopeConsent,
+	}, nil
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,33 @@
+package application
+
+import "time"
+
+// SessionLifetime sets how long refresh tokens last. RememberMe applies to
+// sessions opened with the remember me option and Default to the others;
+// Refresh decides whether rotations extend them. MaxAge, when positive, ends
+// every session that long after its login, however active it is.
+type SessionLifetime struct {
+	Default    time.Duration
+	RememberMe time.Duration
+	Refresh    RefreshPolicy
+	MaxAge     time.Duration
+}
+
+func (l SessionLifetime) refreshTTL(rememberMe bool) time.Duration {
+	if rememberMe {
+		return l.RememberMe
+	}
+	return l.Default
+}
+
+// clamp moves expiresAt back to the end of the absolute lifetime of a session
+// started at startedAt.
+func (l SessionLifetime) clamp(startedAt, expiresAt time.Time) time.Time {
+	if l.MaxAge <= 0 {
+		return expiresAt
+	}
+	if end := startedAt.Add(l.MaxAge); expiresAt.After(end) {
+		return end
+	}
+	return expiresAt
+}
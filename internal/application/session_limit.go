@@ -0,0 +1,14 @@
+package application
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain"
+
+// SessionLimit caps the active sessions of an account. A Max of zero or less
+// disables the cap.
+type SessionLimit struct {
+	Max      int
+	Strategy domain.SessionLimitStrategy
+}
+
+func (l SessionLimit) enabled() bool {
+	return l.Max > 0
+}
@@ -0,0 +1,194 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// Session is the active refresh token of a session as shown to its owner.
+// ID identifies the session across rotations. Device and Location summarize
+// the user agent and IP address of the latest rotation; Location is nil when
+// the address cannot be located.
+type Session struct {
+	ID        uuid.UUID
+	IPAddress *string
+	UserAgent *string
+	Device    string
+	Location  *ports.Location
+	Current   bool
+	// CreatedAt is the login that opened the session and RefreshedAt its
+	// latest rotation.
+	CreatedAt   time.Time
+	RefreshedAt time.Time
+	ExpiresAt   time.Time
+}
+
+// SessionService lets users review and revoke their own sessions, and
+// administrators end every session of an account.
+type SessionService struct {
+	txManager     ports.TxManager
+	accounts      repositories.AccountRepository
+	refreshTokens repositories.RefreshTokenRepository
+	denylist      ports.AccessTokenDenylist
+	locator       ports.GeoLocator
+	audit         *AuditLog
+	eventBus      ports.EventBus
+}
+
+func NewSessionService(
+	txManager ports.TxManager,
+	accounts repositories.AccountRepository,
+	refreshTokens repositories.RefreshTokenRepository,
+	denylist ports.AccessTokenDenylist,
+	locator ports.GeoLocator,
+	audit *AuditLog,
+	eventBus ports.EventBus,
+) *SessionService {
+	return &SessionService{
+		txManager:     txManager,
+		accounts:      accounts,
+		refreshTokens: refreshTokens,
+		denylist:      denylist,
+		locator:       locator,
+		audit:         audit,
+		eventBus:      eventBus,
+	}
+}
+
+// List returns the active sessions of an account. The session identified by
+// currentID, the one the caller is using, is flagged as current.
+func (s *SessionService) List(ctx context.Context, accountID, currentID uuid.UUID) ([]*Session, error) {
+	tokens, err := s.refreshTokens.ListActiveByAccountID(ctx, accountID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, s.describe(token, currentID))
+	}
+	return sessions, nil
+}
+
+// Revoke ends one session of an account. Sessions of other accounts, and
+// sessions that already ended, are reported as not found.
+func (s *SessionService) Revoke(ctx context.Context, accountID, sessionID uuid.UUID) error {
+	now := time.Now().UTC()
+	tokens, err := s.refreshTokens.ListActiveByAccountID(ctx, accountID, now)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if token.SessionID != sessionID {
+			continue
+		}
+		err := s.refreshTokens.Revoke(ctx, token.ID, now)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+		publishSessionRevoked(ctx, s.eventBus, token, domain.SessionRevokedByAccount)
+		return nil
+	}
+	return domain.ErrSessionNotFound
+}
+
+// RevokeAll ends every session of an account. Access tokens already issued
+// keep working until they expire unless invalidateAccessTokens is set, in
+// which case the account is denylisted so they stop validating at once.
+func (s *SessionService) RevokeAll(ctx context.Context, accountID uuid.UUID, invalidateAccessTokens bool) error {
+	now := time.Now().UTC()
+	revoked, err := s.refreshTokens.RevokeAllByAccountID(ctx, accountID, now)
+	if err != nil {
+		return err
+	}
+	if revoked > 0 {
+		publish(ctx, s.eventBus, events.SessionRevokedEvent{
+			AccountID: accountID,
+			Reason:    string(domain.SessionRevokedByAccount),
+		})
+	}
+
+	if invalidateAccessTokens {
+		return s.denylist.DenyAccount(ctx, accountID, now)
+	}
+	return nil
+}
+
+// RevokeAccountSessions ends every session of an account on an
+// administrator's request and denylists its access tokens, so a compromised
+// account can be signed out everywhere without being banned.
+func (s *SessionService) RevokeAccountSessions(ctx context.Context, adminID, accountID uuid.UUID) error {
+	now := time.Now().UTC()
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.accounts.GetByID(txCtx, accountID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrAccountNotFound
+			}
+			return err
+		}
+
+		revoked, err := s.refreshTokens.RevokeAllByAccountID(txCtx, accountID, now)
+		if err != nil {
+			return err
+		}
+		details := map[string]string{"sessions": strconv.FormatInt(revoked, 10)}
+		if err := s.audit.record(txCtx, domain.AuditSessionsRevoked, adminID, accountID, details); err != nil {
+			return err
+		}
+		if revoked == 0 {
+			return nil
+		}
+		return publishWithin(txCtx, s.eventBus, events.SessionRevokedEvent{
+			AccountID: accountID,
+			Reason:    string(domain.SessionRevokedByAdmin),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.denylist.DenyAccount(ctx, accountID, now)
+}
+
+func (s *SessionService) describe(token *models.RefreshToken, currentID uuid.UUID) *Session {
+	session := &Session{
+		ID:          token.SessionID,
+		IPAddress:   token.IPAddress,
+		UserAgent:   token.UserAgent,
+		Current:     token.SessionID == currentID,
+		CreatedAt:   token.SessionStartedAt,
+		RefreshedAt: token.CreatedAt,
+		ExpiresAt:   token.ExpiresAt,
+	}
+	if token.UserAgent != nil {
+		session.Device = describeUserAgent(*token.UserAgent)
+	}
+	if token.IPAddress != nil {
+		if location, ok := s.locator.Locate(*token.IPAddress); ok {
+			session.Location = location
+		}
+	}
+	return session
+}
+
+// publishSessionRevoked publishes the end of the session of token.
+func publishSessionRevoked(ctx context.Context, bus ports.EventBus, token *models.RefreshToken, reason domain.SessionRevocationReason) {
+	publish(ctx, bus, events.SessionRevokedEvent{
+		AccountID: token.AccountID,
+		SessionID: &token.SessionID,
+		Reason:    string(reason),
+	})
+}
@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// StepUpService reauthenticates a signed-in user before sensitive operations.
+// It issues short-lived elevated access tokens whose auth_time, amr and acr
+// claims record when and how the user proved their identity again.
+type StepUpService struct {
+	accounts            repositories.AccountRepository
+	authMethods         repositories.AuthMethodRepository
+	passwordCredentials repositories.PasswordCredentialRepository
+	passwords           ports.PasswordHasher
+	lockout             *Lockout
+	mfa                 *MFAService
+	tokens              ports.TokenService
+	roles               repositories.RoleRepository
+}
+
+func NewStepUpService(
+	accounts repositories.AccountRepository,
+	authMethods repositories.AuthMethodRepository,
+	passwordCredentials repositories.PasswordCredentialRepository,
+	passwords ports.PasswordHasher,
+	lockout *Lockout,
+	mfa *MFAService,
+	tokens ports.TokenService,
+	roles repositories.RoleRepository,
+) *StepUpService {
+	return &StepUpService{
+		accounts:            accounts,
+		authMethods:         authMethods,
+		passwordCredentials: passwordCredentials,
+		passwords:           passwords,
+		lockout:             lockout,
+		mfa:                 mfa,
+		tokens:              tokens,
+		roles:               roles,
+	}
+}
+
+// ElevatedToken is an access token minted right after a reauthentication.
+type ElevatedToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	AuthTime    time.Time
+	AMR         []string
+	ACR         string
+}
+
+// WithPassword reauthenticates the account with the password of its email
+// method. Failures count towards the lockout of the method, so a stolen
+// access token cannot be used to guess the password.
+func (s *StepUpService) WithPassword(ctx context.Context, accountID uuid.UUID, password string) (*ElevatedToken, error) {
+	ctx, span := tracer.Start(ctx, "StepUpService.WithPassword")
+	defer span.End()
+
+	account, err := s.activeAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	methods, err := s.authMethods.ListByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		method     *models.AuthMethod
+		credential *models.PasswordCredential
+	)
+	for _, candidate := range methods {
+		if candidate.ProviderCode != domain.ProviderEmail {
+			continue
+		}
+		method = candidate
+		credential, err = s.passwordCredentials.GetByAuthMethodID(ctx, method.ID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		break
+	}
+	if credential == nil {
+		_, _ = s.passwords.Hash(password)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err := s.lockout.check(method); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.passwords.Verify(password, credential.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.lockout.fail(ctx, method); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err := s.lockout.succeed(ctx, method); err != nil {
+		return nil, err
+	}
+
+	return s.elevate(ctx, account, domain.AMRPassword, domain.ACRSingleFactor)
+}
+
+// WithMFA reauthenticates the account with a TOTP code, or with an SMS code
+// requested beforehand. As the session already passed a primary login, the
+// elevated token is reported at the multi-factor level.
+func (s *StepUpService) WithMFA(ctx context.Context, accountID uuid.UUID, method domain.MFAFactorType, code string) (*ElevatedToken, error) {
+	ctx, span := tracer.Start(ctx, "StepUpService.WithMFA")
+	defer span.End()
+
+	account, err := s.activeAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfa.verifyFactorCode(ctx, account.ID, method, code); err != nil {
+		return nil, err
+	}
+
+	amr := domain.AMROTP
+	if method == domain.MFAFactorSMS {
+		amr = domain.AMRSMS
+	}
+	return s.elevate(ctx, account, amr, domain.ACRMultiFactor)
+}
+
+func (s *StepUpService) activeAccount(ctx context.Context, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidAccountState
+	}
+	return account, nil
+}
+
+func (s *StepUpService) elevate(ctx context.Context, account *models.Account, amr, acr string) (*ElevatedToken, error) {
+	permissions, err := s.roles.ListPermissions(ctx, account.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	authTime := time.Now().UTC().Truncate(time.Second)
+	accessToken, claims, err := s.tokens.GenerateAccessToken(ctx, account, models.AccessTokenOptions{
+		Scope:       domain.TokenScopeFull,
+		TTL:         domain.StepUpTokenTTL,
+		AuthTime:    &authTime,
+		AMR:         []string{amr},
+		ACR:         acr,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElevatedToken{
+		AccessToken: accessToken,
+		ExpiresAt:   claims.ExpiresAt,
+		AuthTime:    authTime,
+		AMR:         claims.AMR,
+		ACR:         claims.ACR,
+	}, nil
+}
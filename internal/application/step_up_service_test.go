@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// totpAuthenticator computes the codes an authenticator app shows for an
+// enrolled secret.
+type totpAuthenticator struct {
+	secret []byte
+}
+
+// code returns the code of the period offset steps away from the current
+// one.
+func (a totpAuthenticator) code(offset int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(time.Now().Unix()/30+offset))
+
+	mac := hmac.New(sha1.New, a.secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	index := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[index:index+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1_000_000)
+}
+
+// wrong returns a code no current period accepts.
+func (a totpAuthenticator) wrong() string {
+	for n := 0; ; n++ {
+		code := fmt.Sprintf("%06d", n)
+		if code != a.code(-1) && code != a.code(0) && code != a.code(1) {
+			return code
+		}
+	}
+}
+
+// enrollTOTP enrolls and confirms a TOTP factor for the account, using up
+// the code of the previous period.
+func (a *testAuth) enrollTOTP(t *testing.T, accountID uuid.UUID) totpAuthenticator {
+	t.Helper()
+	ctx := context.Background()
+
+	enrollment, err := a.mfa.EnrollTOTP(ctx, accountID)
+	if err != nil {
+		t.Fatalf("enroll totp: %v", err)
+	}
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(enrollment.Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator := totpAuthenticator{secret: secret}
+	if _, err := a.mfa.ConfirmTOTP(ctx, accountID, authenticator.code(-1)); err != nil {
+		t.Fatalf("confirm totp: %v", err)
+	}
+	return authenticator
+}
+
+func TestStepUpWithMFALocksFactor(t *testing.T) {
+	auth := newTestAuth(t)
+	accountID := auth.register(t).Account.ID
+	authenticator := auth.enrollTOTP(t, accountID)
+	ctx := context.Background()
+
+	for i := range 3 {
+		if _, err := auth.stepUp.WithMFA(ctx, accountID, domain.MFAFactorTOTP, authenticator.wrong()); !errors.Is(err, domain.ErrInvalidMFACode) {
+			t.Fatalf("wrong code %d: got %v, want %v", i+1, err, domain.ErrInvalidMFACode)
+		}
+	}
+
+	if _, err := auth.stepUp.WithMFA(ctx, accountID, domain.MFAFactorTOTP, authenticator.code(0)); !errors.Is(err, domain.ErrMFAFactorLocked) {
+		t.Errorf("right code while locked: got %v, want %v", err, domain.ErrMFAFactorLocked)
+	}
+}
+
+func TestStepUpWithMFAResetsFailures(t *testing.T) {
+	auth := newTestAuth(t)
+	accountID := auth.register(t).Account.ID
+	authenticator := auth.enrollTOTP(t, accountID)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := auth.stepUp.WithMFA(ctx, accountID, domain.MFAFactorTOTP, authenticator.wrong()); !errors.Is(err, domain.ErrInvalidMFACode) {
+			t.Fatalf("wrong code: got %v", err)
+		}
+	}
+	elevated, err := auth.stepUp.WithMFA(ctx, accountID, domain.MFAFactorTOTP, authenticator.code(0))
+	if err != nil {
+		t.Fatalf("step-up: %v", err)
+	}
+	if elevated.ACR != domain.ACRMultiFactor {
+		t.Errorf("acr: got %q, want %q", elevated.ACR, domain.ACRMultiFactor)
+	}
+
+	factor, err := auth.mfa.mfaFactors.GetByAccountAndType(ctx, accountID, domain.MFAFactorTOTP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if factor.FailedAttempts != 0 {
+		t.Errorf("failed attempts after a step-up: got %d, want 0", factor.FailedAttempts)
+	}
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// tenantPolicy combines the policies of every organization an account is a
+// member of, so that each applies whichever organization a session acts in:
+// the strictest rule of any of them wins.
+type tenantPolicy struct {
+	passwordMinLength       int
+	passwordRequiredClasses []domain.CharacterClass
+	requireMFA              bool
+	sessionMaxAge           time.Duration
+	// allowedProviders is nil when no organization restricts providers.
+	allowedProviders []domain.Provider
+}
+
+func combinePolicies(policies []*models.AuthPolicy) tenantPolicy {
+	var combined tenantPolicy
+	for _, policy := range policies {
+		combined.passwordMinLength = max(combined.passwordMinLength, policy.PasswordMinLength)
+		for _, class := range policy.PasswordRequiredClasses {
+			if !slices.Contains(combined.passwordRequiredClasses, class) {
+				combined.passwordRequiredClasses = append(combined.passwordRequiredClasses, class)
+			}
+		}
+		combined.requireMFA = combined.requireMFA || policy.RequireMFA
+		if policy.SessionMaxAge > 0 && (combined.sessionMaxAge == 0 || policy.SessionMaxAge < combined.sessionMaxAge) {
+			combined.sessionMaxAge = policy.SessionMaxAge
+		}
+
+		if len(policy.AllowedProviders) == 0 {
+			continue
+		}
+		if combined.allowedProviders == nil {
+			combined.allowedProviders = slices.Clone(policy.AllowedProviders)
+			continue
+		}
+		// Providers allowed by some organizations and not others are not
+		// allowed; none may remain.
+		combined.allowedProviders = slices.DeleteFunc(combined.allowedProviders, func(provider domain.Provider) bool {
+			return !slices.Contains(policy.AllowedProviders, provider)
+		})
+	}
+	return combined
+}
+
+// allows reports whether members may sign in with provider. Logins that do
+// not go through a provider, such as with a passkey, are always allowed.
+func (p tenantPolicy) allows(provider domain.Provider) bool {
+	return provider == "" || p.allowedProviders == nil || slices.Contains(p.allowedProviders, provider)
+}
+
+// accepts reports whether password follows the password rules of the tenant,
+// leaving aside the service-wide rules it may have been set under.
+func (p tenantPolicy) accepts(password string) bool {
+	if utf8.RuneCountInString(password) < p.passwordMinLength {
+		return false
+	}
+	for _, class := range p.passwordRequiredClasses {
+		if !strings.ContainsFunc(password, characterClassTest(class)) {
+			return false
+		}
+	}
+	return true
+}
+
+// password tightens the service-wide password policy with the tenant rules.
+func (p tenantPolicy) password(policy PasswordPolicy) PasswordPolicy {
+	policy.MinLength = min(max(policy.MinLength, p.passwordMinLength), policy.MaxLength)
+	classes := slices.Clone(policy.RequiredClasses)
+	for _, class := range p.passwordRequiredClasses {
+		if !slices.Contains(classes, class) {
+			classes = append(classes, class)
+		}
+	}
+	policy.RequiredClasses = classes
+	return policy
+}
+
+// clamp moves expiresAt back to the end of the tenant lifetime of a session
+// started at startedAt.
+func (p tenantPolicy) clamp(startedAt, expiresAt time.Time) time.Time {
+	return SessionLifetime{MaxAge: p.sessionMaxAge}.clamp(startedAt, expiresAt)
+}
@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// TokenExchange carries a token request of the RFC 8693 token exchange
+// grant. Only access tokens are accepted and issued.
+type TokenExchange struct {
+	SubjectToken       string
+	SubjectTokenType   string
+	ActorToken         string
+	ActorTokenType     string
+	Audience           string
+	Scope              string
+	RequestedTokenType string
+	// KeyThumbprint binds the new token to the client's DPoP key.
+	KeyThumbprint string
+}
+
+// ExchangedToken is an access token for a downstream audience.
+type ExchangedToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	Scope       string
+}
+
+// TokenExchangeService lets trusted services trade a user's access token for
+// one addressed to another service, either on their own behalf (delegation,
+// recorded in the act claim) or as the user (impersonation).
+type TokenExchangeService struct {
+	validator *TokenValidator
+	accounts  repositories.AccountRepository
+	roles     repositories.RoleRepository
+	tokens    ports.TokenService
+}
+
+func NewTokenExchangeService(validator *TokenValidator, accounts repositories.AccountRepository, roles repositories.RoleRepository, tokens ports.TokenService) *TokenExchangeService {
+	return &TokenExchangeService{validator: validator, accounts: accounts, roles: roles, tokens: tokens}
+}
+
+// Exchange issues a token for the subject of exchange.SubjectToken, addressed
+// to one of the client's exchange audiences and scoped to its scopes. An
+// actor token makes the new token a delegation; without one, the client must
+// be allowed to impersonate. The new token never outlives the subject token.
+func (s *TokenExchangeService) Exchange(ctx context.Context, client *models.OAuthClient, exchange TokenExchange) (*ExchangedToken, error) {
+	if client.Public || !client.AllowsGrantType(domain.GrantTypeTokenExchange) {
+		return nil, domain.ErrUnauthorizedClient
+	}
+	if exchange.SubjectTokenType != domain.TokenTypeURIAccessToken {
+		return nil, domain.ErrUnsupportedTokenType
+	}
+	if exchange.RequestedTokenType != "" && exchange.RequestedTokenType != domain.TokenTypeURIAccessToken {
+		return nil, domain.ErrUnsupportedTokenType
+	}
+	if !client.AllowsExchangeAudience(exchange.Audience) {
+		return nil, domain.ErrInvalidTarget
+	}
+
+	scope, err := grantedScopes(client, exchange.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.validate(ctx, exchange.SubjectToken)
+	if err != nil {
+		return nil, err
+	}
+	// API keys have no session to tie the new token to, and impersonation
+	// tokens are not delegated further.
+	if !subject.HasAccount() || subject.Scope != domain.TokenScopeFull || subject.APIKeyID != uuid.Nil || subject.Impersonated() {
+		return nil, domain.ErrInvalidExchangeToken
+	}
+
+	actor, err := s.actor(ctx, client, exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accounts.GetByID(ctx, subject.AccountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidExchangeToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.StatusCode != domain.StatusActive {
+		return nil, domain.ErrInvalidExchangeToken
+	}
+	permissions, err := s.roles.ListPermissions(ctx, account.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	token, claims, err := s.tokens.GenerateAccessToken(ctx, account, models.AccessTokenOptions{
+		Scope:         domain.TokenScope(scope),
+		SessionID:     subject.SessionID,
+		Audience:      exchange.Audience,
+		ClientID:      client.ClientID,
+		Actor:         actor,
+		NotAfter:      subject.ExpiresAt,
+		KeyThumbprint: exchange.KeyThumbprint,
+		Permissions:   permissions,
+		// Delegations act in the organization of the subject token.
+		OrganizationID:   subject.OrganizationID,
+		OrganizationRole: subject.OrganizationRole,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExchangedToken{AccessToken: token, ExpiresAt: claims.ExpiresAt, Scope: scope}, nil
+}
+
+// actor returns the party a delegated token is issued to act for. Client
+// tokens used as actor tokens must belong to the requesting client, so a
+// client cannot claim to act as another one.
+func (s *TokenExchangeService) actor(ctx context.Context, client *models.OAuthClient, exchange TokenExchange) (*models.Actor, error) {
+	if exchange.ActorToken == "" {
+		if exchange.ActorTokenType != "" {
+			return nil, domain.ErrInvalidExchangeToken
+		}
+		if !client.Impersonation {
+			return nil, domain.ErrUnauthorizedClient
+		}
+		return nil, nil
+	}
+	if exchange.ActorTokenType != domain.TokenTypeURIAccessToken {
+		return nil, domain.ErrUnsupportedTokenType
+	}
+
+	claims, err := s.validate(ctx, exchange.ActorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.HasAccount() {
+		if claims.ClientID != client.ClientID {
+			return nil, domain.ErrInvalidExchangeToken
+		}
+		return &models.Actor{Subject: claims.ClientID, ClientID: claims.ClientID}, nil
+	}
+	if claims.Scope != domain.TokenScopeFull || claims.Impersonated() {
+		return nil, domain.ErrInvalidExchangeToken
+	}
+	return &models.Actor{Subject: claims.AccountID.String()}, nil
+}
+
+func (s *TokenExchangeService) validate(ctx context.Context, raw string) (*models.AccessTokenClaims, error) {
+	claims, err := s.validator.Validate(ctx, raw)
+	if errors.Is(err, domain.ErrInvalidAccessToken) {
+		return nil, domain.ErrInvalidExchangeToken
+	}
+	return claims, err
+}
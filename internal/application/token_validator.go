@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"golang.org/x/sync/errgroup"
+)
+
+// TokenValidator is the single place access tokens are accepted: on top of
+// the signature and expiry checks of the token service, it rejects tokens on
+// the denylist. API keys are accepted in place of tokens. Every API
+// validating tokens on behalf of clients goes through it.
+type TokenValidator struct {
+	tokens   ports.TokenService
+	denylist ports.AccessTokenDenylist
+	apiKeys  *APIKeyService
+}
+
+func NewTokenValidator(tokens ports.TokenService, denylist ports.AccessTokenDenylist, apiKeys *APIKeyService) *TokenValidator {
+	return &TokenValidator{tokens: tokens, denylist: denylist, apiKeys: apiKeys}
+}
+
+// Validate returns the claims of a usable token or API key, or
+// ErrInvalidAccessToken. Lookup failures are returned as is, so the token is
+// not accepted when its revocation cannot be checked. API keys are revoked
+// on their own and skip the denylist.
+func (v *TokenValidator) Validate(ctx context.Context, raw string) (*models.AccessTokenClaims, error) {
+	ctx, span := tracer.Start(ctx, "TokenValidator.Validate")
+	defer span.End()
+
+	if strings.HasPrefix(raw, domain.APIKeyPrefix) {
+		claims, err := v.apiKeys.Authenticate(ctx, raw)
+		// Opaque access tokens are random and may start with the prefix.
+		if !errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return claims, err
+		}
+	}
+
+	claims, err := v.tokens.ParseAccessToken(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := v.denylist.IsDenied(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return nil, domain.ErrInvalidAccessToken
+	}
+	return claims, nil
+}
+
+// ValidateBatch validates up to MaxBatchTokens tokens at once and returns
+// their claims in order, nil for invalid tokens. A lookup failure fails the
+// whole batch.
+func (v *TokenValidator) ValidateBatch(ctx context.Context, raws []string) ([]*models.AccessTokenClaims, error) {
+	ctx, span := tracer.Start(ctx, "TokenValidator.ValidateBatch")
+	defer span.End()
+
+	if len(raws) > domain.MaxBatchTokens {
+		return nil, domain.ErrTooManyTokens
+	}
+
+	results := make([]*models.AccessTokenClaims, len(raws))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(domain.BatchValidationConcurrency)
+	for i, raw := range raws {
+		group.Go(func() error {
+			claims, err := v.Validate(groupCtx, raw)
+			if errors.Is(err, domain.ErrInvalidAccessToken) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			results[i] = claims
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,7 @@
+package application
+
+import "go.opentelemetry.io/otel"
+
+// tracer traces the service methods on the paths that sign accounts in and
+// check their tokens, as children of the request spans that call them.
+var tracer = otel.Tracer("github.com/TheJisus28/ranco-auth-service/internal/application")
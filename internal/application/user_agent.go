@@ -0,0 +1,55 @@
+package application
+
+import "strings"
+
+// userAgentBrowsers and userAgentPlatforms are matched in order, so tokens
+// that other browsers also send (Safari, Chrome) come after the more
+// specific ones.
+var (
+	userAgentBrowsers = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	}
+	userAgentPlatforms = []struct{ token, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"CrOS", "ChromeOS"},
+		{"Windows", "Windows"},
+		{"Macintosh", "macOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// describeUserAgent summarizes a User-Agent header as "<browser> on
+// <platform>", e.g. "Chrome on macOS". Parts that cannot be recognized are
+// left out, and an empty string is returned when neither is.
+func describeUserAgent(userAgent string) string {
+	browser := matchUserAgent(userAgent, userAgentBrowsers)
+	platform := matchUserAgent(userAgent, userAgentPlatforms)
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	default:
+		return platform
+	}
+}
+
+func matchUserAgent(userAgent string, candidates []struct{ token, name string }) string {
+	for _, candidate := range candidates {
+		if strings.Contains(userAgent, candidate.token) {
+			return candidate.name
+		}
+	}
+	return ""
+}
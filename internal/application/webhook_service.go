@@ -0,0 +1,469 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+)
+
+// webhookEvents are the events endpoints can subscribe to.
+var webhookEvents = []domain.WebhookEvent{
+	domain.WebhookAccountCreated,
+	domain.WebhookAccountVerified,
+	domain.WebhookAccountStatusChanged,
+	domain.WebhookAccountBanned,
+	domain.WebhookAccountRoleChanged,
+	domain.WebhookLoginFailed,
+	domain.WebhookPasswordChanged,
+	domain.WebhookMFAEnabled,
+	domain.WebhookMFADisabled,
+	domain.WebhookAccountExpired,
+	domain.WebhookAccountPurged,
+	domain.WebhookAccountErased,
+}
+
+// CreatedWebhookEndpoint is a new endpoint. Secret is its plaintext signing
+// secret, which is shown once and cannot be recovered afterwards.
+type CreatedWebhookEndpoint struct {
+	Endpoint *models.WebhookEndpoint
+	Secret   string
+}
+
+// WebhookService lets administrators register endpoints for account events
+// and delivers the events to them. As an event bus it turns the events it
+// publishes into webhook payloads, holding none of the codes or tokens the
+// domain events carry, and queues a delivery to every subscribed endpoint;
+// DeliverDue then sends them, signed with the endpoint's secret, retrying
+// failures with exponential backoff.
+type WebhookService struct {
+	txManager  ports.TxManager
+	endpoints  repositories.WebhookEndpointRepository
+	deliveries repositories.WebhookDeliveryRepository
+	secrets    *security.Cipher
+	sender     ports.WebhookSender
+}
+
+func NewWebhookService(
+	txManager ports.TxManager,
+	endpoints repositories.WebhookEndpointRepository,
+	deliveries repositories.WebhookDeliveryRepository,
+	secrets *security.Cipher,
+	sender ports.WebhookSender,
+) *WebhookService {
+	return &WebhookService{
+		txManager:  txManager,
+		endpoints:  endpoints,
+		deliveries: deliveries,
+		secrets:    secrets,
+		sender:     sender,
+	}
+}
+
+// Create registers an endpoint for events, active from now on.
+func (s *WebhookService) Create(ctx context.Context, adminID uuid.UUID, rawURL, description string, subscribed []domain.WebhookEvent) (*CreatedWebhookEndpoint, error) {
+	endpoint := &models.WebhookEndpoint{
+		ID:        uuid.New(),
+		CreatedBy: &adminID,
+	}
+	if err := setWebhookEndpoint(endpoint, rawURL, description, subscribed); err != nil {
+		return nil, err
+	}
+
+	secret, sealed, err := s.newSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = sealed
+	if err := s.endpoints.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return &CreatedWebhookEndpoint{Endpoint: endpoint, Secret: secret}, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	return s.endpoints.List(ctx)
+}
+
+func (s *WebhookService) Get(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.endpoints.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return endpoint, err
+}
+
+// Update replaces the URL, description and events of an endpoint and turns
+// it on or off. Deliveries already queued keep the payload they were queued
+// with.
+func (s *WebhookService) Update(ctx context.Context, id uuid.UUID, rawURL, description string, subscribed []domain.WebhookEvent, active bool) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := setWebhookEndpoint(endpoint, rawURL, description, subscribed); err != nil {
+		return nil, err
+	}
+	endpoint.IsActive = active
+
+	err = s.endpoints.Update(ctx, endpoint)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// Delete removes an endpoint with its deliveries.
+func (s *WebhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	err := s.endpoints.Delete(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrWebhookNotFound
+	}
+	return err
+}
+
+// RotateSecret replaces the signing secret of an endpoint and returns the new
+// one, which signs every attempt from now on, retries included.
+func (s *WebhookService) RotateSecret(ctx context.Context, id uuid.UUID) (string, error) {
+	secret, sealed, err := s.newSecret()
+	if err != nil {
+		return "", err
+	}
+	err = s.endpoints.UpdateSecret(ctx, id, sealed)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// Deliveries returns the latest deliveries to an endpoint, newest first.
+func (s *WebhookService) Deliveries(ctx context.Context, endpointID uuid.UUID) ([]*models.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, endpointID); err != nil {
+		return nil, err
+	}
+	return s.deliveries.ListByEndpointID(ctx, endpointID, domain.WebhookDeliveryListLimit)
+}
+
+// Delivery returns a delivery with its attempts, oldest first.
+func (s *WebhookService) Delivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error) {
+	delivery, err := s.deliveries.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil, domain.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := s.deliveries.ListAttempts(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, attempts, nil
+}
+
+// Redeliver queues a delivery that succeeded or failed again, due now and
+// with every attempt available. The payload and event id stay the same.
+func (s *WebhookService) Redeliver(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.deliveries.GetByID(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrWebhookDeliveryNotFound
+		}
+		return err
+	}
+	err := s.deliveries.Requeue(ctx, id, time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrWebhookDeliveryPending
+	}
+	return err
+}
+
+// Publish queues a delivery of the webhook events behind event to every
+// active endpoint subscribed to them. Events without a webhook are ignored.
+func (s *WebhookService) Publish(ctx context.Context, event events.Event) error {
+	now := time.Now().UTC()
+	for _, payload := range webhookPayloads(event) {
+		endpoints, err := s.endpoints.ListSubscribed(ctx, payload.event)
+		if err != nil {
+			return err
+		}
+		if len(endpoints) == 0 {
+			continue
+		}
+
+		eventID := events.MessageID(ctx, string(payload.event))
+		body, err := json.Marshal(webhookBody{
+			ID:        eventID,
+			Type:      payload.event,
+			CreatedAt: now,
+			Data:      payload.data,
+		})
+		if err != nil {
+			return err
+		}
+		for _, endpoint := range endpoints {
+			err := s.deliveries.Create(ctx, &models.WebhookDelivery{
+				ID:            uuid.New(),
+				EndpointID:    endpoint.ID,
+				EventID:       eventID,
+				Event:         payload.event,
+				Payload:       body,
+				Status:        domain.WebhookDeliveryPending,
+				NextAttemptAt: &now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeliverDue attempts the deliveries due at now, a batch at a time, and
+// returns how many it attempted. Each batch is claimed for WebhookLease and
+// sent concurrently, so other instances skip it meanwhile.
+func (s *WebhookService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	attempted := 0
+	for {
+		due, err := s.deliveries.ClaimDue(ctx, now, now.Add(domain.WebhookLease), domain.WebhookBatch)
+		if err != nil {
+			return attempted, err
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range due {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.deliver(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		attempted += len(due)
+		if len(due) < domain.WebhookBatch {
+			return attempted, nil
+		}
+	}
+}
+
+// deliver makes one attempt at a delivery and records its outcome. Failures
+// to record are only logged: the lease runs out and the delivery is
+// attempted again.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	endpoint, err := s.endpoints.GetByID(ctx, delivery.EndpointID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "load webhook endpoint", "webhook_delivery_id", delivery.ID, "error", err)
+		return
+	}
+
+	attempt := &models.WebhookDeliveryAttempt{ID: uuid.New(), DeliveryID: delivery.ID}
+	switch secret, err := s.secrets.Decrypt(endpoint.Secret); {
+	case err != nil:
+		slog.ErrorContext(ctx, "decrypt webhook secret", "webhook_delivery_id", delivery.ID, "error", err)
+		return
+	case !endpoint.IsActive:
+		attempt.Error = optional("endpoint is disabled")
+	default:
+		started := time.Now()
+		status, err := s.sender.Send(ctx, ports.WebhookRequest{
+			URL:        endpoint.URL,
+			DeliveryID: delivery.ID,
+			Event:      string(delivery.Event),
+			Payload:    delivery.Payload,
+			Secret:     secret,
+		})
+		attempt.Duration = time.Since(started)
+		if status != 0 {
+			attempt.StatusCode = &status
+		}
+		if err != nil {
+			attempt.Error = optional(err.Error())
+		}
+	}
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.NextAttemptAt = nil
+	switch {
+	case attempt.Error == nil:
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= domain.WebhookMaxAttempts || !endpoint.IsActive:
+		delivery.Status = domain.WebhookDeliveryFailed
+	default:
+		next := now.Add(webhookRetryDelay(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return s.deliveries.RecordAttempt(txCtx, delivery, attempt)
+	})
+	// Deleted or redelivered meanwhile.
+	if errors.Is(err, domain.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "record webhook attempt", "webhook_delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// webhookRetryDelay is the wait after the attempts made so far failed:
+// WebhookRetryBaseDelay after the first, doubling after each one up to
+// WebhookMaxRetryDelay.
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := domain.WebhookRetryBaseDelay
+	for i := 1; i < attempts && delay < domain.WebhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, domain.WebhookMaxRetryDelay)
+}
+
+// newSecret returns a new signing secret, in plaintext and encrypted.
+func (s *WebhookService) newSecret() (string, []byte, error) {
+	token, err := security.GenerateOpaqueToken(domain.WebhookSecretBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	secret := domain.WebhookSecretPrefix + token
+	sealed, err := s.secrets.Encrypt([]byte(secret))
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, sealed, nil
+}
+
+// setWebhookEndpoint validates and sets what administrators choose of an
+// endpoint. URLs must be absolute http or https URLs without credentials.
+func setWebhookEndpoint(endpoint *models.WebhookEndpoint, rawURL, description string, subscribed []domain.WebhookEvent) error {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" ||
+		parsed.User != nil || parsed.Fragment != "" || len(rawURL) > domain.MaxWebhookURLLength {
+		return domain.ErrInvalidWebhookURL
+	}
+
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > domain.MaxWebhookDescriptionLength {
+		return domain.ErrInvalidWebhookDescription
+	}
+
+	if len(subscribed) == 0 {
+		return domain.ErrInvalidWebhookEvents
+	}
+	normalized := make([]domain.WebhookEvent, 0, len(subscribed))
+	for _, event := range subscribed {
+		if !slices.Contains(webhookEvents, event) {
+			return domain.ErrInvalidWebhookEvents
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+
+	endpoint.URL = rawURL
+	endpoint.Description = description
+	endpoint.Events = normalized
+	return nil
+}
+
+// webhookBody is the JSON body of a webhook. ID identifies the event across
+// deliveries and redeliveries.
+type webhookBody struct {
+	ID        uuid.UUID           `json:"id"`
+	Type      domain.WebhookEvent `json:"type"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      any                 `json:"data"`
+}
+
+type webhookPayload struct {
+	event domain.WebhookEvent
+	data  any
+}
+
+// webhookAccountCreated is the data of account.created. Source is
+// registration, oauth or provisioning.
+type webhookAccountCreated struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Source    string    `json:"source"`
+}
+
+type webhookAccount struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email,omitempty"`
+}
+
+// webhookPayloads returns the webhook events behind a domain event with
+// their data, none for events webhooks do not report.
+func webhookPayloads(event events.Event) []webhookPayload {
+	switch e := event.(type) {
+	case events.UserRegisteredEvent:
+		return []webhookPayload{{domain.WebhookAccountCreated, webhookAccountCreated{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Provider:  string(domain.ProviderEmail),
+			Source:    "registration",
+		}}}
+	case events.OAuthUserRegisteredEvent:
+		return []webhookPayload{{domain.WebhookAccountCreated, webhookAccountCreated{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Provider:  e.Provider,
+			Source:    "oauth",
+		}}}
+	case events.AccountProvisionedEvent:
+		return []webhookPayload{{domain.WebhookAccountCreated, webhookAccountCreated{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Source:    "provisioning",
+		}}}
+	case events.AccountVerifiedEvent:
+		return []webhookPayload{{domain.WebhookAccountVerified, webhookAccount{AccountID: e.AccountID, Email: e.Email}}}
+	case events.AccountStatusChangedEvent:
+		payloads := []webhookPayload{{domain.WebhookAccountStatusChanged, e}}
+		if e.Status == string(domain.StatusBanned) {
+			payloads = append(payloads, webhookPayload{domain.WebhookAccountBanned, e})
+		}
+		return payloads
+	case events.AccountRoleChangedEvent:
+		return []webhookPayload{{domain.WebhookAccountRoleChanged, e}}
+	case events.LoginFailedEvent:
+		return []webhookPayload{{domain.WebhookLoginFailed, e}}
+	case events.PasswordChangedEvent:
+		return []webhookPayload{{domain.WebhookPasswordChanged, webhookAccount{AccountID: e.AccountID, Email: e.Email}}}
+	case events.MFAEnabledEvent:
+		return []webhookPayload{{domain.WebhookMFAEnabled, e}}
+	case events.MFADisabledEvent:
+		return []webhookPayload{{domain.WebhookMFADisabled, e}}
+	case events.AccountExpiredEvent:
+		return []webhookPayload{{domain.WebhookAccountExpired, e}}
+	case events.AccountPurgedEvent:
+		return []webhookPayload{{domain.WebhookAccountPurged, e}}
+	case events.AccountErasedEvent:
+		return []webhookPayload{{domain.WebhookAccountErased, e}}
+	}
+	return nil
+}
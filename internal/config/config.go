@@ -0,0 +1,779 @@
+// Package config loads every setting of the service, such as the database,
+// token lifetimes, signing keys, OAuth provider credentials, SMTP, rate
+// limits, the password policy, data retention and feature flags. Settings
+// come from an optional YAML file named by CONFIG_FILE and from environment
+// variables, which take precedence, and are validated before the service starts. Reloader applies
+// changes to the runtime-tunable ones while the service runs.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/breach"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/token"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the typed configuration of the service. The env tag of a field
+// names its environment variable, prefixed by the env tags of the structs
+// containing it.
+type Config struct {
+	Server              Server              `yaml:"server"`
+	Logging             Logging             `yaml:"logging" env:"LOG_"`
+	Tracing             Tracing             `yaml:"tracing"`
+	Database            Database            `yaml:"database"`
+	Redis               Redis               `yaml:"redis"`
+	Secrets             Secrets             `yaml:"secrets" env:"SECRETS_"`
+	Bootstrap           Bootstrap           `yaml:"bootstrap" env:"BOOTSTRAP_ADMIN_"`
+	Tokens              Tokens              `yaml:"tokens"`
+	Signing             Signing             `yaml:"signing"`
+	Providers           Providers           `yaml:"providers"`
+	AuthorizationServer AuthorizationServer `yaml:"authorization_server"`
+	SMTP                SMTP                `yaml:"smtp" env:"SMTP_"`
+	Mail                Mail                `yaml:"mail"`
+	MFA                 MFA                 `yaml:"mfa" env:"MFA_"`
+	WebAuthn            WebAuthn            `yaml:"webauthn" env:"WEBAUTHN_RP_"`
+	Twilio              Twilio              `yaml:"twilio" env:"TWILIO_"`
+	RateLimits          RateLimits          `yaml:"rate_limits" env:"RATE_LIMIT_"`
+	Passwords           PasswordPolicy      `yaml:"password_policy" env:"PASSWORD_"`
+	PasswordRotation    PasswordRotation    `yaml:"password_rotation" env:"PASSWORD_"`
+	Argon2              Argon2              `yaml:"argon2" env:"ARGON2_"`
+	Pepper              Pepper              `yaml:"password_pepper"`
+	SessionLimit        SessionLimit        `yaml:"session_limit"`
+	Lockout             Lockout             `yaml:"lockout" env:"LOCKOUT_"`
+	Captcha             Captcha             `yaml:"captcha" env:"CAPTCHA_"`
+	DisposableEmails    DisposableEmails    `yaml:"disposable_emails" env:"DISPOSABLE_EMAIL_"`
+	BreachedPasswords   BreachedPasswords   `yaml:"breached_passwords" env:"BREACHED_PASSWORD_"`
+	GeoIP               GeoIP               `yaml:"geoip" env:"GEOIP_"`
+	Accounts            Accounts            `yaml:"accounts"`
+	DataExports         DataExports         `yaml:"data_exports" env:"DATA_EXPORT_"`
+	Webhooks            Webhooks            `yaml:"webhooks" env:"WEBHOOK_"`
+	SIEM                SIEM                `yaml:"siem" env:"SIEM_"`
+	EventBroker         EventBroker         `yaml:"event_broker"`
+	Jobs                Jobs                `yaml:"jobs"`
+	Retention           Retention           `yaml:"retention"`
+	// Features are the feature flags, set in CONFIG_FILE only.
+	Features []FeatureFlag `yaml:"features"`
+}
+
+// Server sets the addresses the servers listen on, and how long a shutdown
+// waits for in-flight requests.
+type Server struct {
+	HTTPAddr        string        `yaml:"http_addr" env:"HTTP_ADDR"`
+	GRPCAddr        string        `yaml:"grpc_addr" env:"GRPC_ADDR"`
+	MetricsAddr     string        `yaml:"metrics_addr" env:"METRICS_ADDR"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+type Logging struct {
+	// Level is debug, info, warn or error.
+	Level string `yaml:"level" env:"LEVEL"`
+	// Format is json or text.
+	Format string `yaml:"format" env:"FORMAT"`
+}
+
+// Tracing exports traces over OTLP/gRPC when an endpoint is set. The
+// exporter, sampler and resource read the other standard OTEL_* variables.
+type Tracing struct {
+	Endpoint       string `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	TracesEndpoint string `yaml:"traces_endpoint" env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"`
+	ServiceName    string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+}
+
+// CollectorURL returns the endpoint traces are exported to, or "" to leave
+// tracing off.
+func (t Tracing) CollectorURL() string {
+	if t.TracesEndpoint != "" {
+		return t.TracesEndpoint
+	}
+	return t.Endpoint
+}
+
+type Database struct {
+	// URL is the PostgreSQL connection string, or "memory" for the
+	// in-memory store.
+	URL string `yaml:"url" env:"DATABASE_URL"`
+	// MigrateOnStartup applies pending migrations before the service
+	// connects.
+	MigrateOnStartup bool `yaml:"migrate_on_startup" env:"MIGRATE_ON_STARTUP"`
+	// ReplicaURL is a read replica of URL that admin account search and
+	// token introspection query instead of the primary.
+	ReplicaURL string `yaml:"replica_url" env:"DATABASE_REPLICA_URL"`
+	// The pool settings apply to the primary and the replica alike; zero
+	// keeps the pgxpool default.
+	MaxConns          int           `yaml:"max_conns" env:"DATABASE_MAX_CONNS"`
+	MinConns          int           `yaml:"min_conns" env:"DATABASE_MIN_CONNS"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env:"DATABASE_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env:"DATABASE_MAX_CONN_IDLE_TIME"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"DATABASE_HEALTH_CHECK_PERIOD"`
+}
+
+// InMemory reports whether the data is kept in the process rather than in
+// PostgreSQL.
+func (d Database) InMemory() bool {
+	return d.URL == "memory"
+}
+
+// Redis is shared by the instances for the access token denylist, the DPoP
+// replay cache, the rate limits and the account cache. Each instance keeps
+// its own in memory when URL is unset.
+type Redis struct {
+	URL string `yaml:"url" env:"REDIS_URL"`
+	// AccountCacheTTL is how long accounts looked up by id stay cached; 0
+	// disables the cache.
+	AccountCacheTTL time.Duration `yaml:"account_cache_ttl" env:"ACCOUNT_CACHE_TTL"`
+}
+
+type Secrets struct {
+	// CacheTTL is how long secrets without a lease are cached.
+	CacheTTL time.Duration `yaml:"cache_ttl" env:"CACHE_TTL"`
+}
+
+// Bootstrap names the first ADMIN account, created at startup while Email
+// is set. Password may be a secret reference.
+type Bootstrap struct {
+	Email    string `yaml:"email" env:"EMAIL"`
+	Password string `yaml:"password" env:"PASSWORD"`
+}
+
+type Tokens struct {
+	Issuer   string `yaml:"issuer" env:"JWT_ISSUER"`
+	Audience string `yaml:"audience" env:"JWT_AUDIENCE"`
+	// Format is jwt, paseto or opaque.
+	Format    string        `yaml:"format" env:"ACCESS_TOKEN_FORMAT"`
+	AccessTTL time.Duration `yaml:"access_ttl" env:"ACCESS_TOKEN_TTL"`
+	// RefreshTTL and RememberMeRefreshTTL are the refresh token lifetimes of
+	// sessions opened without and with remember me.
+	RefreshTTL           time.Duration `yaml:"refresh_ttl" env:"REFRESH_TOKEN_TTL"`
+	RememberMeRefreshTTL time.Duration `yaml:"remember_me_refresh_ttl" env:"REMEMBER_ME_REFRESH_TOKEN_TTL"`
+	// RefreshExpiration is FIXED or SLIDING. Sliding sessions are extended
+	// up to SlidingSessionCeiling after login.
+	RefreshExpiration     string        `yaml:"refresh_expiration" env:"REFRESH_EXPIRATION"`
+	SlidingSessionCeiling time.Duration `yaml:"sliding_session_ceiling" env:"SLIDING_SESSION_CEILING"`
+	// MaxSessionAge is the absolute lifetime of a session; 0 disables it.
+	MaxSessionAge    time.Duration `yaml:"max_session_age" env:"MAX_SESSION_AGE"`
+	TrustedDeviceTTL time.Duration `yaml:"trusted_device_ttl" env:"TRUSTED_DEVICE_TTL"`
+}
+
+// Signing configures the keys tokens are signed with: a single PEM key, or
+// database-managed keys rotated every RotationInterval when it is set.
+type Signing struct {
+	PrivateKey       string        `yaml:"private_key" env:"JWT_PRIVATE_KEY"`
+	PrivateKeyFile   string        `yaml:"private_key_file" env:"JWT_PRIVATE_KEY_FILE"`
+	RotationInterval time.Duration `yaml:"rotation_interval" env:"JWT_KEY_ROTATION_INTERVAL"`
+	PrePublish       time.Duration `yaml:"prepublish" env:"JWT_KEY_PREPUBLISH"`
+	// Algorithm is RS256 or EdDSA, for rotated keys.
+	Algorithm string `yaml:"algorithm" env:"JWT_KEY_ALGORITHM"`
+	// EncryptionKey is the base64 AES-256 key rotated keys are stored
+	// encrypted with.
+	EncryptionKey string `yaml:"encryption_key" env:"JWT_KEY_ENCRYPTION_KEY"`
+}
+
+// Rotating reports whether keys are rotated rather than loaded from PEM.
+func (s Signing) Rotating() bool {
+	return s.RotationInterval > 0
+}
+
+type Providers struct {
+	Google    OAuthClient `yaml:"google" env:"GOOGLE_"`
+	GitHub    OAuthClient `yaml:"github" env:"GITHUB_"`
+	Apple     Apple       `yaml:"apple" env:"APPLE_"`
+	Microsoft Microsoft   `yaml:"microsoft" env:"MICROSOFT_"`
+	// OIDC lists generic OpenID Connect providers. From the environment,
+	// OIDC_PROVIDERS names them and OIDC_<NAME>_* configures each.
+	OIDC []OIDCProvider `yaml:"oidc"`
+	// Disabled names configured providers that are turned off, such as
+	// google or an OIDC provider name.
+	Disabled []string `yaml:"disabled" env:"OAUTH_DISABLED_PROVIDERS"`
+}
+
+// Names returns the names of the configured providers, lower-cased.
+func (p Providers) Names() []string {
+	var names []string
+	for _, provider := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"google", p.Google.Enabled()},
+		{"github", p.GitHub.Enabled()},
+		{"apple", p.Apple.Enabled()},
+		{"microsoft", p.Microsoft.Enabled()},
+	} {
+		if provider.enabled {
+			names = append(names, provider.name)
+		}
+	}
+	for _, provider := range p.OIDC {
+		names = append(names, strings.ToLower(provider.Name))
+	}
+	return names
+}
+
+// OAuthClient is the client registered with a provider, which is enabled
+// when ClientID is set.
+type OAuthClient struct {
+	ClientID     string `yaml:"client_id" env:"CLIENT_ID"`
+	ClientSecret string `yaml:"client_secret" env:"CLIENT_SECRET"`
+	RedirectURL  string `yaml:"redirect_url" env:"REDIRECT_URL"`
+}
+
+func (c OAuthClient) Enabled() bool {
+	return c.ClientID != ""
+}
+
+type Apple struct {
+	ClientID       string `yaml:"client_id" env:"CLIENT_ID"`
+	TeamID         string `yaml:"team_id" env:"TEAM_ID"`
+	KeyID          string `yaml:"key_id" env:"KEY_ID"`
+	PrivateKey     string `yaml:"private_key" env:"PRIVATE_KEY"`
+	PrivateKeyFile string `yaml:"private_key_file" env:"PRIVATE_KEY_FILE"`
+	RedirectURL    string `yaml:"redirect_url" env:"REDIRECT_URL"`
+}
+
+func (a Apple) Enabled() bool {
+	return a.ClientID != ""
+}
+
+type Microsoft struct {
+	OAuthClient `yaml:",inline"`
+	// Tenant is common, organizations, consumers or a tenant ID.
+	Tenant string `yaml:"tenant" env:"TENANT"`
+}
+
+type OIDCProvider struct {
+	Name         string   `yaml:"name"`
+	DiscoveryURL string   `yaml:"discovery_url" env:"DISCOVERY_URL"`
+	ClientID     string   `yaml:"client_id" env:"CLIENT_ID"`
+	ClientSecret string   `yaml:"client_secret" env:"CLIENT_SECRET"`
+	RedirectURL  string   `yaml:"redirect_url" env:"REDIRECT_URL"`
+	Scopes       []string `yaml:"scopes" env:"SCOPES"`
+}
+
+// AuthorizationServer configures the OAuth 2.0 and OpenID Connect
+// endpoints the service serves to its own clients.
+type AuthorizationServer struct {
+	// LoginURL is the page browsers without a session are sent to from
+	// the authorize endpoint.
+	LoginURL string `yaml:"login_url" env:"OIDC_LOGIN_URL"`
+	// DeviceVerificationURL defaults to /device under the issuer.
+	DeviceVerificationURL string `yaml:"device_verification_url" env:"OIDC_DEVICE_VERIFICATION_URL"`
+	// Clients lists the clients allowed to call the endpoints. From the
+	// environment, OAUTH_CLIENTS names them and OAUTH_CLIENT_<NAME>_*
+	// configures each.
+	Clients []Client `yaml:"clients"`
+}
+
+// Client is a client of the authorization server. A public client has no
+// secret, and Impersonation lets the client exchange tokens without an
+// actor token.
+type Client struct {
+	Name              string   `yaml:"name"`
+	ID                string   `yaml:"id" env:"ID"`
+	Secret            string   `yaml:"secret" env:"SECRET"`
+	Public            bool     `yaml:"public" env:"PUBLIC"`
+	RedirectURIs      []string `yaml:"redirect_uris" env:"REDIRECT_URIS"`
+	GrantTypes        []string `yaml:"grant_types" env:"GRANT_TYPES"`
+	Scopes            []string `yaml:"scopes" env:"SCOPES"`
+	ExchangeAudiences []string `yaml:"exchange_audiences" env:"EXCHANGE_AUDIENCES"`
+	Impersonation     bool     `yaml:"impersonation" env:"IMPERSONATION"`
+}
+
+// SMTP configures the relay outgoing email is sent through. Emails are
+// logged when Host is unset.
+type SMTP struct {
+	Host     string `yaml:"host" env:"HOST"`
+	Port     int    `yaml:"port" env:"PORT"`
+	Username string `yaml:"username" env:"USERNAME"`
+	Password string `yaml:"password" env:"PASSWORD"`
+	// From is the sender address of every email.
+	From string `yaml:"from" env:"FROM"`
+}
+
+// Mail sets the links and the default branding of outgoing email.
+type Mail struct {
+	MagicLinkURL  string       `yaml:"magic_link_url" env:"MAGIC_LINK_URL"`
+	InvitationURL string       `yaml:"invitation_url" env:"INVITATION_URL"`
+	Branding      MailBranding `yaml:"branding" env:"MAIL_"`
+}
+
+// MailBranding is the branding of organizations that set none. Colors are
+// written #rrggbb.
+type MailBranding struct {
+	SenderName      string `yaml:"sender_name" env:"SENDER_NAME"`
+	LogoURL         string `yaml:"logo_url" env:"LOGO_URL"`
+	PrimaryColor    string `yaml:"primary_color" env:"PRIMARY_COLOR"`
+	BackgroundColor string `yaml:"background_color" env:"BACKGROUND_COLOR"`
+	FooterText      string `yaml:"footer_text" env:"FOOTER_TEXT"`
+}
+
+type MFA struct {
+	// EncryptionKey is the base64 AES-256 key TOTP secrets and SMS phone
+	// numbers are stored encrypted with.
+	EncryptionKey string `yaml:"encryption_key" env:"ENCRYPTION_KEY"`
+	// Issuer is the name shown in authenticator apps and SMS codes.
+	Issuer string `yaml:"issuer" env:"ISSUER"`
+	// RequiredRoles are the roles, ADMIN or USER, whose accounts must
+	// enroll a second factor.
+	RequiredRoles []string `yaml:"required_roles" env:"REQUIRED_ROLES"`
+}
+
+// WebAuthn is the relying party passkeys are registered with.
+type WebAuthn struct {
+	ID      string   `yaml:"id" env:"ID"`
+	Name    string   `yaml:"name" env:"NAME"`
+	Origins []string `yaml:"origins" env:"ORIGINS"`
+}
+
+// Twilio sends SMS codes when AccountSID is set; they are logged otherwise.
+type Twilio struct {
+	AccountSID string `yaml:"account_sid" env:"ACCOUNT_SID"`
+	AuthToken  string `yaml:"auth_token" env:"AUTH_TOKEN"`
+	// From is a phone number or a messaging service SID.
+	From string `yaml:"from" env:"FROM"`
+}
+
+// RateLimits are the limits of each endpoint group.
+type RateLimits struct {
+	Login        RateLimitGroup `yaml:"login" env:"LOGIN_"`
+	Register     RateLimitGroup `yaml:"register" env:"REGISTER_"`
+	Refresh      RateLimitGroup `yaml:"refresh" env:"REFRESH_"`
+	Verification RateLimitGroup `yaml:"verification" env:"VERIFICATION_"`
+}
+
+// RateLimitGroup limits a group of endpoints per client IP address and per
+// identifier, such as the email address a request is about.
+type RateLimitGroup struct {
+	IP         RateLimit `yaml:"ip" env:"IP"`
+	Identifier RateLimit `yaml:"identifier" env:"IDENTIFIER"`
+}
+
+// RateLimit is written attempts/window, such as 10/15m, or off.
+type RateLimit models.RateLimit
+
+func (l *RateLimit) UnmarshalText(text []byte) error {
+	raw := string(text)
+	if strings.EqualFold(raw, "off") {
+		*l = RateLimit{}
+		return nil
+	}
+
+	count, window, ok := strings.Cut(raw, "/")
+	limit, err := strconv.Atoi(count)
+	if !ok || err != nil || limit <= 0 {
+		return fmt.Errorf("expected attempts/window, got %q", raw)
+	}
+	duration, err := time.ParseDuration(window)
+	if err != nil || duration <= 0 {
+		return fmt.Errorf("invalid window %q", window)
+	}
+	*l = RateLimit{Limit: limit, Window: duration}
+	return nil
+}
+
+// PasswordPolicy sets the rules new passwords must follow. RequiredClasses
+// are lower, upper, digit or symbol, and MinScore a strength score from 0
+// to 4.
+type PasswordPolicy struct {
+	MinLength       int      `yaml:"min_length" env:"MIN_LENGTH"`
+	MaxLength       int      `yaml:"max_length" env:"MAX_LENGTH"`
+	RequiredClasses []string `yaml:"required_classes" env:"REQUIRED_CLASSES"`
+	BannedWords     []string `yaml:"banned_words" env:"BANNED_WORDS"`
+	MinScore        int      `yaml:"min_score" env:"MIN_SCORE"`
+}
+
+// PasswordRotation sets how many past passwords cannot be chosen again and
+// how long a password stays valid, overridden per role by MaxAgeAdmin and
+// MaxAgeUser. Zero disables each.
+type PasswordRotation struct {
+	HistorySize int            `yaml:"history_size" env:"HISTORY_SIZE"`
+	MaxAge      time.Duration  `yaml:"max_age" env:"MAX_AGE"`
+	MaxAgeAdmin *time.Duration `yaml:"max_age_admin" env:"MAX_AGE_ADMIN"`
+	MaxAgeUser  *time.Duration `yaml:"max_age_user" env:"MAX_AGE_USER"`
+}
+
+// Argon2 is the Argon2id cost of new password hashes.
+type Argon2 struct {
+	MemoryKiB   int `yaml:"memory_kib" env:"MEMORY_KIB"`
+	Iterations  int `yaml:"iterations" env:"ITERATIONS"`
+	Parallelism int `yaml:"parallelism" env:"PARALLELISM"`
+}
+
+// Pepper sets where the password pepper is read from: vault, or env for
+// Peppers, a list of version:base64 pairs or a secret reference holding
+// one. Passwords are hashed unpeppered when Source is unset.
+type Pepper struct {
+	Source  string      `yaml:"source" env:"PASSWORD_PEPPER_SOURCE"`
+	Vault   PepperVault `yaml:"vault" env:"PASSWORD_PEPPER_VAULT_"`
+	Peppers string      `yaml:"peppers" env:"PASSWORD_PEPPERS"`
+	// Version is the pepper new hashes are made with; 0 takes Vault's
+	// current version or the highest listed.
+	Version int `yaml:"version" env:"PASSWORD_PEPPER_VERSION"`
+}
+
+// PepperVault is the KV v2 secret field holding the pepper.
+type PepperVault struct {
+	Mount string `yaml:"mount" env:"MOUNT"`
+	Path  string `yaml:"path" env:"PATH"`
+	Field string `yaml:"field" env:"FIELD"`
+}
+
+// SessionLimit caps the active sessions of an account; Max 0 disables it.
+// Strategy is REVOKE_OLDEST or REJECT.
+type SessionLimit struct {
+	Max      int    `yaml:"max" env:"MAX_ACTIVE_SESSIONS"`
+	Strategy string `yaml:"strategy" env:"SESSION_LIMIT_STRATEGY"`
+}
+
+// Lockout locks a sign-in method after Threshold consecutive failures, 0
+// disabling lockouts, for Duration, doubled by every further failure up to
+// MaxDuration.
+type Lockout struct {
+	Threshold   int           `yaml:"threshold" env:"THRESHOLD"`
+	Duration    time.Duration `yaml:"duration" env:"DURATION"`
+	MaxDuration time.Duration `yaml:"max_duration" env:"MAX_DURATION"`
+}
+
+// Captcha enables CAPTCHA checks when Provider is recaptcha, hcaptcha or
+// turnstile. RequiredOn lists the flows always checked, register and
+// password_reset.
+type Captcha struct {
+	Provider           string   `yaml:"provider" env:"PROVIDER"`
+	Secret             string   `yaml:"secret" env:"SECRET"`
+	MinScore           float64  `yaml:"min_score" env:"MIN_SCORE"`
+	RequiredOn         []string `yaml:"required_on" env:"REQUIRED_ON"`
+	LoginAfterFailures int      `yaml:"login_after_failures" env:"LOGIN_AFTER_FAILURES"`
+}
+
+// DisposableEmails sets what happens to registrations from disposable email
+// domains: REJECT, FLAG or OFF. Source replaces the embedded domain list.
+type DisposableEmails struct {
+	Action         string        `yaml:"action" env:"ACTION"`
+	Source         string        `yaml:"source" env:"SOURCE"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"RELOAD_INTERVAL"`
+	Allow          []string      `yaml:"allow" env:"ALLOW"`
+	Deny           []string      `yaml:"deny" env:"DENY"`
+}
+
+// BreachedPasswords sets what happens to new passwords found in data
+// breaches: BLOCK, WARN or OFF. APIURL is off for air-gapped deployments,
+// which then need BloomFilter.
+type BreachedPasswords struct {
+	Action      string `yaml:"action" env:"ACTION"`
+	APIURL      string `yaml:"api_url" env:"API_URL"`
+	BloomFilter string `yaml:"bloom_filter" env:"BLOOM_FILTER"`
+}
+
+// GeoIP locates sessions with the MaxMind City database at DBPath.
+type GeoIP struct {
+	DBPath string `yaml:"db_path" env:"DB_PATH"`
+}
+
+type Accounts struct {
+	// DeletionGracePeriod is how long an account deleted by its holder
+	// can be restored.
+	DeletionGracePeriod time.Duration `yaml:"deletion_grace_period" env:"ACCOUNT_DELETION_GRACE_PERIOD"`
+	// PendingTTL is how long an account may stay PENDING before it is
+	// deleted; 0 keeps pending accounts.
+	PendingTTL time.Duration `yaml:"pending_ttl" env:"PENDING_ACCOUNT_TTL"`
+}
+
+type DataExports struct {
+	// SigningKey is the base64 key download links are signed with, the MFA
+	// encryption key when unset.
+	SigningKey string `yaml:"signing_key" env:"SIGNING_KEY"`
+	// DownloadURL defaults to the download endpoint under the issuer.
+	DownloadURL string `yaml:"download_url" env:"DOWNLOAD_URL"`
+}
+
+type Webhooks struct {
+	// EncryptionKey is the base64 AES-256 key signing secrets are stored
+	// encrypted with, the MFA encryption key when unset.
+	EncryptionKey    string        `yaml:"encryption_key" env:"ENCRYPTION_KEY"`
+	DeliveryInterval time.Duration `yaml:"delivery_interval" env:"DELIVERY_INTERVAL"`
+}
+
+// SIEM streams audit events to the collector named by Sink: syslog, splunk
+// or elastic. Audit events are only stored when it is unset.
+type SIEM struct {
+	Sink    string      `yaml:"sink" env:"SINK"`
+	Syslog  SIEMSyslog  `yaml:"syslog" env:"SYSLOG_"`
+	Splunk  SIEMSplunk  `yaml:"splunk" env:"SPLUNK_"`
+	Elastic SIEMElastic `yaml:"elastic" env:"ELASTIC_"`
+	// BufferSize events are held before requests wait up to BlockTimeout
+	// for room, and sent BatchSize at a time or every FlushInterval.
+	BufferSize    int           `yaml:"buffer_size" env:"BUFFER_SIZE"`
+	BatchSize     int           `yaml:"batch_size" env:"BATCH_SIZE"`
+	FlushInterval time.Duration `yaml:"flush_interval" env:"FLUSH_INTERVAL"`
+	BlockTimeout  time.Duration `yaml:"block_timeout" env:"BLOCK_TIMEOUT"`
+}
+
+type SIEMSyslog struct {
+	// Network is udp, tcp or tls, and Format cef or leef.
+	Network string `yaml:"network" env:"NETWORK"`
+	Addr    string `yaml:"addr" env:"ADDR"`
+	Format  string `yaml:"format" env:"FORMAT"`
+}
+
+type SIEMSplunk struct {
+	URL   string `yaml:"url" env:"URL"`
+	Token string `yaml:"token" env:"TOKEN"`
+	Index string `yaml:"index" env:"INDEX"`
+}
+
+type SIEMElastic struct {
+	URL    string `yaml:"url" env:"URL"`
+	APIKey string `yaml:"api_key" env:"API_KEY"`
+	Index  string `yaml:"index" env:"INDEX"`
+}
+
+// EventBroker publishes domain events to the broker named by Kind: nats or
+// kafka. Events are only logged when it is unset.
+type EventBroker struct {
+	Kind  string `yaml:"kind" env:"EVENT_BROKER"`
+	NATS  NATS   `yaml:"nats" env:"NATS_"`
+	Kafka Kafka  `yaml:"kafka" env:"KAFKA_"`
+}
+
+type NATS struct {
+	URL           string `yaml:"url" env:"URL"`
+	SubjectPrefix string `yaml:"subject_prefix" env:"SUBJECT_PREFIX"`
+	JetStream     bool   `yaml:"jetstream" env:"JETSTREAM"`
+	// Credentials is the path of a .creds file.
+	Credentials string `yaml:"credentials" env:"CREDENTIALS"`
+}
+
+type Kafka struct {
+	Brokers  []string `yaml:"brokers" env:"BROKERS"`
+	Topic    string   `yaml:"topic" env:"TOPIC"`
+	Username string   `yaml:"username" env:"USERNAME"`
+	Password string   `yaml:"password" env:"PASSWORD"`
+	TLS      bool     `yaml:"tls" env:"TLS"`
+}
+
+// Jobs sets how often the background jobs run.
+type Jobs struct {
+	BanExpiryInterval         time.Duration `yaml:"ban_expiry_interval" env:"BAN_EXPIRY_INTERVAL"`
+	RefreshTokenPurgeInterval time.Duration `yaml:"refresh_token_purge_interval" env:"REFRESH_TOKEN_PURGE_INTERVAL"`
+	OutboxRelayInterval       time.Duration `yaml:"outbox_relay_interval" env:"OUTBOX_RELAY_INTERVAL"`
+	ActiveSessionsInterval    time.Duration `yaml:"active_sessions_interval" env:"ACTIVE_SESSIONS_INTERVAL"`
+}
+
+// Retention sets how long each class of personal data is kept before the
+// scheduled jobs prune it, so each deployment can follow the rules of its
+// jurisdiction.
+type Retention struct {
+	// LoginOrigins is how long the IP addresses and user agents recorded
+	// with sessions, trusted devices and audit events are kept before they
+	// are cleared; 0 keeps them.
+	LoginOrigins time.Duration `yaml:"login_origins" env:"LOGIN_ORIGIN_RETENTION"`
+	// AuditEvents is how long audit events are kept before they are
+	// deleted; 0 keeps them.
+	AuditEvents time.Duration `yaml:"audit_events" env:"AUDIT_EVENT_RETENTION"`
+	// VerificationCodes and RefreshTokens are how long codes and tokens are
+	// kept once they have expired, been consumed or been revoked.
+	VerificationCodes time.Duration `yaml:"verification_codes" env:"VERIFICATION_CODE_RETENTION"`
+	RefreshTokens     time.Duration `yaml:"refresh_tokens" env:"REFRESH_TOKEN_RETENTION"`
+}
+
+// FeatureFlag turns a feature on for everyone, or for the members of some
+// organizations and a percentage of the other accounts. Key is passkeys,
+// admin_mfa or provider.<name> for a configured OAuth provider.
+type FeatureFlag struct {
+	Key           string      `yaml:"key"`
+	Enabled       bool        `yaml:"enabled"`
+	Percentage    int         `yaml:"percentage"`
+	Organizations []uuid.UUID `yaml:"organizations"`
+}
+
+// Default returns the configuration used for the settings that are not
+// set.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			HTTPAddr:        ":8080",
+			GRPCAddr:        ":9090",
+			MetricsAddr:     ":2112",
+			ShutdownTimeout: 25 * time.Second,
+		},
+		Logging: Logging{Level: "info"},
+		Tracing: Tracing{ServiceName: "ranco-auth-service"},
+		Redis:   Redis{AccountCacheTTL: time.Minute},
+		Secrets: Secrets{CacheTTL: 5 * time.Minute},
+		Tokens: Tokens{
+			Issuer:                "ranco-auth-service",
+			Audience:              "ranco",
+			Format:                token.FormatJWT,
+			AccessTTL:             domain.AccessTokenTTL,
+			RefreshTTL:            domain.RefreshTokenTTL,
+			RememberMeRefreshTTL:  domain.RememberMeRefreshTokenTTL,
+			RefreshExpiration:     "FIXED",
+			SlidingSessionCeiling: domain.SlidingSessionCeiling,
+			MaxSessionAge:         domain.MaxSessionAge,
+			TrustedDeviceTTL:      domain.TrustedDeviceTTL,
+		},
+		Signing: Signing{
+			PrePublish: time.Hour,
+			Algorithm:  "RS256",
+		},
+		SMTP: SMTP{
+			Port: 587,
+			From: "no-reply@localhost",
+		},
+		Mail: Mail{
+			MagicLinkURL:  "http://localhost:3000/auth/magic-link",
+			InvitationURL: "http://localhost:3000/invitations/accept",
+			Branding: MailBranding{
+				PrimaryColor:    "#2563eb",
+				BackgroundColor: "#ffffff",
+			},
+		},
+		MFA: MFA{Issuer: "Ranco"},
+		WebAuthn: WebAuthn{
+			ID:      "localhost",
+			Name:    "Ranco",
+			Origins: []string{"http://localhost:3000"},
+		},
+		RateLimits: RateLimits{
+			Login:        RateLimitGroup{IP: RateLimit{Limit: 30, Window: time.Minute}, Identifier: RateLimit{Limit: 10, Window: 15 * time.Minute}},
+			Register:     RateLimitGroup{IP: RateLimit{Limit: 10, Window: time.Hour}, Identifier: RateLimit{Limit: 5, Window: time.Hour}},
+			Refresh:      RateLimitGroup{IP: RateLimit{Limit: 60, Window: time.Minute}, Identifier: RateLimit{Limit: 10, Window: time.Minute}},
+			Verification: RateLimitGroup{IP: RateLimit{Limit: 30, Window: time.Minute}, Identifier: RateLimit{Limit: 10, Window: 15 * time.Minute}},
+		},
+		Passwords: PasswordPolicy{
+			MinLength: domain.MinPasswordLength,
+			MaxLength: domain.MaxPasswordLength,
+		},
+		Argon2: Argon2{
+			MemoryKiB:   int(security.DefaultArgon2Params.Memory),
+			Iterations:  int(security.DefaultArgon2Params.Iterations),
+			Parallelism: int(security.DefaultArgon2Params.Parallelism),
+		},
+		Pepper: Pepper{
+			Vault: PepperVault{Mount: "secret", Field: "pepper"},
+		},
+		SessionLimit: SessionLimit{
+			Max:      domain.MaxActiveSessions,
+			Strategy: string(domain.SessionLimitRevokeOldest),
+		},
+		Lockout: Lockout{
+			Threshold:   domain.LockoutThreshold,
+			Duration:    domain.LockoutDuration,
+			MaxDuration: domain.LockoutMaxDuration,
+		},
+		Captcha: Captcha{
+			RequiredOn:         []string{"register", "password_reset"},
+			LoginAfterFailures: 3,
+		},
+		DisposableEmails: DisposableEmails{
+			Action:         string(domain.DisposableEmailReject),
+			ReloadInterval: 24 * time.Hour,
+		},
+		BreachedPasswords: BreachedPasswords{
+			Action: "OFF",
+			APIURL: breach.PwnedPasswordsURL,
+		},
+		Accounts: Accounts{
+			DeletionGracePeriod: domain.AccountDeletionGracePeriod,
+			PendingTTL:          domain.PendingAccountTTL,
+		},
+		Webhooks: Webhooks{DeliveryInterval: 10 * time.Second},
+		SIEM: SIEM{
+			Syslog:        SIEMSyslog{Network: "tcp", Format: "cef"},
+			Elastic:       SIEMElastic{Index: "ranco-audit"},
+			BufferSize:    10000,
+			BatchSize:     100,
+			FlushInterval: time.Second,
+			BlockTimeout:  100 * time.Millisecond,
+		},
+		EventBroker: EventBroker{
+			NATS:  NATS{URL: "nats://localhost:4222", SubjectPrefix: "ranco.auth"},
+			Kafka: Kafka{Topic: "ranco.auth.events"},
+		},
+		Jobs: Jobs{
+			BanExpiryInterval:         time.Minute,
+			RefreshTokenPurgeInterval: time.Hour,
+			OutboxRelayInterval:       time.Second,
+			ActiveSessionsInterval:    time.Minute,
+		},
+		Retention: Retention{
+			VerificationCodes: domain.VerificationCodeRetention,
+			RefreshTokens:     domain.RefreshTokenRetention,
+		},
+	}
+}
+
+// Load reads the defaults, then the YAML file named by CONFIG_FILE if any,
+// then the environment, and validates the result. Key files are read, so
+// PrivateKey holds the PEM data wherever it came from. Every error wraps
+// domain.ErrInvalidConfiguration.
+func Load() (*Config, error) {
+	config := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: read CONFIG_FILE: %w", domain.ErrInvalidConfiguration, err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: parse %s: %w", domain.ErrInvalidConfiguration, path, err)
+		}
+	}
+
+	if err := loadEnv(config); err != nil {
+		return nil, fmt.Errorf("%w:\n%w", domain.ErrInvalidConfiguration, err)
+	}
+	if err := config.readKeyFiles(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidConfiguration, err)
+	}
+	config.defaultIssuerURLs()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// defaultIssuerURLs points the URLs left unset at their endpoints under the
+// issuer.
+func (c *Config) defaultIssuerURLs() {
+	base := strings.TrimSuffix(c.Tokens.Issuer, "/")
+	if c.DataExports.DownloadURL == "" {
+		c.DataExports.DownloadURL = base + "/v1/auth/data-exports/download"
+	}
+	if c.AuthorizationServer.DeviceVerificationURL == "" {
+		c.AuthorizationServer.DeviceVerificationURL = base + "/device"
+	}
+}
+
+func (c *Config) readKeyFiles() error {
+	for _, key := range []struct {
+		name string
+		pem  *string
+		path string
+	}{
+		{"JWT_PRIVATE_KEY_FILE", &c.Signing.PrivateKey, c.Signing.PrivateKeyFile},
+		{"APPLE_PRIVATE_KEY_FILE", &c.Providers.Apple.PrivateKey, c.Providers.Apple.PrivateKeyFile},
+	} {
+		if *key.pem != "" || key.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(key.path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", key.name, err)
+		}
+		*key.pem = string(data)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// loadEnv overrides the fields of config whose environment variable is set.
+func loadEnv(config *Config) error {
+	errs := loadStruct(reflect.ValueOf(config).Elem(), "")
+	errs = append(errs, loadOIDCProviders(&config.Providers)...)
+	errs = append(errs, loadClients(&config.AuthorizationServer)...)
+	return errors.Join(errs...)
+}
+
+// loadStruct sets the fields of v from the environment, naming each
+// variable by prefix and the env tags leading to the field. Fields without
+// a tag are skipped, except embedded structs, which share the prefix.
+func loadStruct(v reflect.Value, prefix string) []error {
+	var errs []error
+	for i := range v.NumField() {
+		field, value := v.Type().Field(i), v.Field(i)
+		tag, tagged := field.Tag.Lookup("env")
+		if value.Kind() == reflect.Struct && !reflect.PointerTo(value.Type()).Implements(textUnmarshalerType) {
+			if tagged || field.Anonymous {
+				errs = append(errs, loadStruct(value, prefix+tag)...)
+			} else {
+				errs = append(errs, loadStruct(value, prefix)...)
+			}
+			continue
+		}
+		if !tagged {
+			continue
+		}
+
+		key := prefix + tag
+		raw, ok := os.LookupEnv(key)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := setValue(value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return errs
+}
+
+func setValue(value reflect.Value, raw string) error {
+	switch {
+	case value.Kind() == reflect.Pointer:
+		set := reflect.New(value.Type().Elem())
+		if err := setValue(set.Elem(), raw); err != nil {
+			return err
+		}
+		value.Set(set)
+	case reflect.PointerTo(value.Type()).Implements(textUnmarshalerType):
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	case value.Type() == durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+	case value.Kind() == reflect.String:
+		value.SetString(raw)
+	case value.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		value.SetInt(int64(n))
+	case value.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		value.SetFloat(f)
+	case value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", raw)
+		}
+		value.SetBool(b)
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		value.Set(reflect.ValueOf(SplitList(raw)))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// loadOIDCProviders replaces the OIDC providers with those OIDC_PROVIDERS
+// names, when set. Each starts from the YAML entry of the same name, if
+// any, overridden by the OIDC_<NAME>_ variables.
+func loadOIDCProviders(providers *Providers) []error {
+	names := SplitList(os.Getenv("OIDC_PROVIDERS"))
+	if len(names) == 0 {
+		return nil
+	}
+
+	var errs []error
+	configured := make([]OIDCProvider, 0, len(names))
+	for _, name := range names {
+		provider := OIDCProvider{Name: name}
+		for _, existing := range providers.OIDC {
+			if strings.EqualFold(existing.Name, name) {
+				provider = existing
+			}
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		errs = append(errs, loadStruct(reflect.ValueOf(&provider).Elem(), prefix)...)
+		configured = append(configured, provider)
+	}
+	providers.OIDC = configured
+	return errs
+}
+
+// loadClients replaces the authorization server clients with those
+// OAUTH_CLIENTS names, when set. Each starts from the YAML entry of the same
+// name, if any, overridden by the OAUTH_CLIENT_<NAME>_ variables.
+func loadClients(server *AuthorizationServer) []error {
+	names := SplitList(os.Getenv("OAUTH_CLIENTS"))
+	if len(names) == 0 {
+		return nil
+	}
+
+	var errs []error
+	configured := make([]Client, 0, len(names))
+	for _, name := range names {
+		client := Client{Name: name}
+		for _, existing := range server.Clients {
+			if strings.EqualFold(existing.Name, name) {
+				client = existing
+			}
+		}
+		prefix := "OAUTH_CLIENT_" + strings.ToUpper(name) + "_"
+		errs = append(errs, loadStruct(reflect.ValueOf(&client).Elem(), prefix)...)
+		configured = append(configured, client)
+	}
+	server.Clients = configured
+	return errs
+}
+
+// SplitList parses a comma separated value, ignoring blanks, as list
+// settings are read from the environment.
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+// Reloader holds the active configuration and swaps in a new one when
+// asked to, such as on SIGHUP or from the admin API. Only the rate limits,
+// the OAuth providers that are disabled, the token and session lifetimes,
+// the password policy, the retention periods and the feature flags change while the service runs; changes to other
+// settings are logged and wait for a restart.
+//
+// Environment variables do not change in a running process, so the
+// settings they set stay as they are: tune through CONFIG_FILE.
+type Reloader struct {
+	mu      sync.Mutex
+	current atomic.Pointer[Config]
+	apply   func(*Config) error
+}
+
+// NewReloader starts from the configuration the service started with.
+// apply hands the new settings to the services using them; an error leaves
+// every setting as it was, so apply must validate before it changes
+// anything.
+func NewReloader(initial *Config, apply func(*Config) error) *Reloader {
+	r := &Reloader{apply: apply}
+	r.current.Store(initial)
+	return r
+}
+
+// Current returns the active configuration.
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// Reload loads and validates the configuration again and applies its
+// runtime-tunable settings. Errors wrap domain.ErrInvalidConfiguration and
+// leave the active configuration in place.
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := Load()
+	if err != nil {
+		return err
+	}
+	current := r.current.Load()
+	// Access tokens are verified against retired signing keys, and kept in
+	// the denylist, for the lifetime set at startup.
+	if loaded.Tokens.AccessTTL > current.Tokens.AccessTTL {
+		return fmt.Errorf("%w: ACCESS_TOKEN_TTL cannot be raised above %s without a restart", domain.ErrInvalidConfiguration, current.Tokens.AccessTTL)
+	}
+
+	next := *current
+	next.RateLimits = loaded.RateLimits
+	next.Passwords = loaded.Passwords
+	next.Retention = loaded.Retention
+	next.Providers.Disabled = loaded.Providers.Disabled
+	next.Features = loaded.Features
+	next.Tokens.AccessTTL = loaded.Tokens.AccessTTL
+	next.Tokens.RefreshTTL = loaded.Tokens.RefreshTTL
+	next.Tokens.RememberMeRefreshTTL = loaded.Tokens.RememberMeRefreshTTL
+	next.Tokens.RefreshExpiration = loaded.Tokens.RefreshExpiration
+	next.Tokens.SlidingSessionCeiling = loaded.Tokens.SlidingSessionCeiling
+	next.Tokens.MaxSessionAge = loaded.Tokens.MaxSessionAge
+	next.Tokens.TrustedDeviceTTL = loaded.Tokens.TrustedDeviceTTL
+
+	if err := r.apply(&next); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidConfiguration, err)
+	}
+	r.current.Store(&next)
+
+	if pending := changed(&next, loaded); len(pending) > 0 {
+		slog.WarnContext(ctx, "configuration changes need a restart", "settings", pending)
+	}
+	slog.InfoContext(ctx, "configuration reloaded")
+	return nil
+}
+
+// changed names the sections that differ between two configurations.
+func changed(a, b *Config) []string {
+	var names []string
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := range va.NumField() {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			names = append(names, va.Type().Field(i).Tag.Get("yaml"))
+		}
+	}
+	return names
+}
@@ -0,0 +1,358 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/secrets"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/token"
+	"github.com/TheJisus28/ranco-auth-service/internal/logging"
+)
+
+// Validate reports every invalid setting at once, each named by its
+// environment variable.
+func (c *Config) Validate() error {
+	var v validator
+
+	v.check(c.Server.ShutdownTimeout >= 0, "SHUTDOWN_TIMEOUT must not be negative")
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		v.check(false, fmt.Sprintf("LOG_LEVEL: %v", err))
+	}
+	v.oneOf("LOG_FORMAT", strings.ToLower(c.Logging.Format), "", "json", "text")
+
+	v.require("DATABASE_URL", c.Database.URL)
+	c.Database.validate(&v)
+	v.check(c.Redis.AccountCacheTTL >= 0, "ACCOUNT_CACHE_TTL must not be negative")
+	v.check(c.Secrets.CacheTTL > 0, "SECRETS_CACHE_TTL must be positive")
+
+	v.require("JWT_ISSUER", c.Tokens.Issuer)
+	v.require("JWT_AUDIENCE", c.Tokens.Audience)
+	v.oneOf("ACCESS_TOKEN_FORMAT", c.Tokens.Format, token.FormatJWT, token.FormatPASETO, token.FormatOpaque)
+	v.check(c.Tokens.AccessTTL > 0, "ACCESS_TOKEN_TTL must be positive")
+	v.check(c.Tokens.RefreshTTL > 0, "REFRESH_TOKEN_TTL must be positive")
+	v.check(c.Tokens.RememberMeRefreshTTL > 0, "REMEMBER_ME_REFRESH_TOKEN_TTL must be positive")
+	c.Tokens.RefreshExpiration = strings.ToUpper(c.Tokens.RefreshExpiration)
+	v.oneOf("REFRESH_EXPIRATION", c.Tokens.RefreshExpiration, "FIXED", "SLIDING")
+	if c.Tokens.RefreshExpiration == "SLIDING" {
+		v.check(c.Tokens.SlidingSessionCeiling > 0, "SLIDING_SESSION_CEILING must be positive")
+	}
+	v.check(c.Tokens.MaxSessionAge >= 0, "MAX_SESSION_AGE must not be negative")
+	v.check(c.Tokens.TrustedDeviceTTL > 0, "TRUSTED_DEVICE_TTL must be positive")
+
+	if c.Signing.Rotating() {
+		v.check(c.Signing.PrePublish >= 0, "JWT_KEY_PREPUBLISH must not be negative")
+		v.oneOf("JWT_KEY_ALGORITHM", c.Signing.Algorithm, "RS256", "EdDSA")
+		if !secrets.IsReference(c.Signing.EncryptionKey) {
+			if key, err := base64.StdEncoding.DecodeString(c.Signing.EncryptionKey); err != nil || len(key) != 32 {
+				v.check(false, "JWT_KEY_ENCRYPTION_KEY must be 32 bytes encoded in base64")
+			}
+		}
+	} else {
+		v.check(c.Signing.RotationInterval == 0, "JWT_KEY_ROTATION_INTERVAL must be positive")
+		v.check(c.Signing.PrivateKey != "", "JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE is required without JWT_KEY_ROTATION_INTERVAL")
+	}
+
+	c.Providers.validate(&v)
+	c.AuthorizationServer.validate(&v)
+
+	if c.SMTP.Host != "" {
+		v.check(c.SMTP.Port > 0 && c.SMTP.Port <= 65535, "SMTP_PORT must be between 1 and 65535")
+	}
+	if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+		v.check(false, fmt.Sprintf("SMTP_FROM must be an email address: %v", err))
+	}
+
+	c.MFA.validate(&v)
+	c.RateLimits.validate(&v)
+	c.Passwords.validate(&v)
+	c.PasswordRotation.validate(&v)
+	c.Argon2.validate(&v)
+	c.Pepper.validate(&v)
+	c.validateGuards(&v)
+	c.validateBackground(&v)
+	c.Retention.validate(&v)
+	validateFeatures(&v, c.Features, c.Providers.Names())
+
+	if err := errors.Join(v.errs...); err != nil {
+		return fmt.Errorf("%w:\n%w", domain.ErrInvalidConfiguration, err)
+	}
+	return nil
+}
+
+func (d Database) validate(v *validator) {
+	v.check(d.ReplicaURL == "" || !d.InMemory(), "DATABASE_REPLICA_URL requires a PostgreSQL DATABASE_URL")
+	v.check(d.MaxConns >= 0, "DATABASE_MAX_CONNS must not be negative")
+	v.check(d.MinConns >= 0, "DATABASE_MIN_CONNS must not be negative")
+	v.check(d.MaxConns == 0 || d.MinConns <= d.MaxConns, "DATABASE_MIN_CONNS must not exceed DATABASE_MAX_CONNS")
+	v.check(d.MaxConnLifetime >= 0, "DATABASE_MAX_CONN_LIFETIME must not be negative")
+	v.check(d.MaxConnIdleTime >= 0, "DATABASE_MAX_CONN_IDLE_TIME must not be negative")
+	v.check(d.HealthCheckPeriod >= 0, "DATABASE_HEALTH_CHECK_PERIOD must not be negative")
+}
+
+func (p *Providers) validate(v *validator) {
+	if p.Google.Enabled() {
+		v.require("GOOGLE_CLIENT_SECRET", p.Google.ClientSecret)
+		v.url("GOOGLE_REDIRECT_URL", p.Google.RedirectURL)
+	}
+	if p.GitHub.Enabled() {
+		v.require("GITHUB_CLIENT_SECRET", p.GitHub.ClientSecret)
+		v.url("GITHUB_REDIRECT_URL", p.GitHub.RedirectURL)
+	}
+	if p.Apple.Enabled() {
+		v.require("APPLE_TEAM_ID", p.Apple.TeamID)
+		v.require("APPLE_KEY_ID", p.Apple.KeyID)
+		v.check(p.Apple.PrivateKey != "", "APPLE_PRIVATE_KEY or APPLE_PRIVATE_KEY_FILE is required")
+		v.url("APPLE_REDIRECT_URL", p.Apple.RedirectURL)
+	}
+	if p.Microsoft.Enabled() {
+		v.require("MICROSOFT_CLIENT_SECRET", p.Microsoft.ClientSecret)
+		v.url("MICROSOFT_REDIRECT_URL", p.Microsoft.RedirectURL)
+	}
+
+	configured := p.Names()
+	for _, name := range p.Disabled {
+		v.check(slices.Contains(configured, strings.ToLower(name)), fmt.Sprintf("OAUTH_DISABLED_PROVIDERS: %s is not a configured provider", name))
+	}
+
+	seen := make(map[string]bool, len(p.OIDC))
+	for _, provider := range p.OIDC {
+		prefix := "OIDC_" + strings.ToUpper(provider.Name) + "_"
+		if provider.Name == "" {
+			v.check(false, "OIDC provider names are required")
+			continue
+		}
+		name := strings.ToLower(provider.Name)
+		v.check(!seen[name], fmt.Sprintf("OIDC provider %s is configured twice", provider.Name))
+		seen[name] = true
+
+		v.url(prefix+"DISCOVERY_URL", provider.DiscoveryURL)
+		v.require(prefix+"CLIENT_ID", provider.ClientID)
+		v.require(prefix+"CLIENT_SECRET", provider.ClientSecret)
+		v.url(prefix+"REDIRECT_URL", provider.RedirectURL)
+	}
+}
+
+func (s AuthorizationServer) validate(v *validator) {
+	seen := make(map[string]bool, len(s.Clients))
+	for _, client := range s.Clients {
+		if client.Name == "" {
+			v.check(false, "OAuth client names are required")
+			continue
+		}
+		name := strings.ToLower(client.Name)
+		v.check(!seen[name], fmt.Sprintf("OAuth client %s is configured twice", client.Name))
+		seen[name] = true
+
+		v.require("OAUTH_CLIENT_"+strings.ToUpper(client.Name)+"_ID", client.ID)
+	}
+}
+
+func (m *MFA) validate(v *validator) {
+	v.key("MFA_ENCRYPTION_KEY", m.EncryptionKey)
+	for i, role := range m.RequiredRoles {
+		m.RequiredRoles[i] = strings.ToUpper(role)
+		v.oneOf("MFA_REQUIRED_ROLES", m.RequiredRoles[i], string(domain.RoleAdmin), string(domain.RoleUser))
+	}
+}
+
+func (l RateLimits) validate(v *validator) {
+	for _, group := range []struct {
+		name  string
+		limit RateLimitGroup
+	}{
+		{"LOGIN", l.Login},
+		{"REGISTER", l.Register},
+		{"REFRESH", l.Refresh},
+		{"VERIFICATION", l.Verification},
+	} {
+		for _, limit := range []struct {
+			key   string
+			limit RateLimit
+		}{
+			{"IP", group.limit.IP},
+			{"IDENTIFIER", group.limit.Identifier},
+		} {
+			v.check(limit.limit.Limit >= 0 && limit.limit.Window >= 0, fmt.Sprintf("RATE_LIMIT_%s_%s must not be negative", group.name, limit.key))
+		}
+	}
+}
+
+func (p PasswordPolicy) validate(v *validator) {
+	v.check(p.MinLength > 0, "PASSWORD_MIN_LENGTH must be positive")
+	v.check(p.MaxLength >= p.MinLength, "PASSWORD_MAX_LENGTH must not be below PASSWORD_MIN_LENGTH")
+	for _, class := range p.RequiredClasses {
+		v.oneOf("PASSWORD_REQUIRED_CLASSES", strings.ToLower(class), string(domain.CharacterClassLower), string(domain.CharacterClassUpper), string(domain.CharacterClassDigit), string(domain.CharacterClassSymbol))
+	}
+	v.check(p.MinScore >= 0 && p.MinScore <= 4, "PASSWORD_MIN_SCORE must be between 0 and 4")
+}
+
+func (r PasswordRotation) validate(v *validator) {
+	v.check(r.HistorySize >= 0 && r.HistorySize <= domain.MaxPasswordHistorySize, fmt.Sprintf("PASSWORD_HISTORY_SIZE must be between 0 and %d", domain.MaxPasswordHistorySize))
+	v.check(r.MaxAge >= 0, "PASSWORD_MAX_AGE must not be negative")
+	v.check(r.MaxAgeAdmin == nil || *r.MaxAgeAdmin >= 0, "PASSWORD_MAX_AGE_ADMIN must not be negative")
+	v.check(r.MaxAgeUser == nil || *r.MaxAgeUser >= 0, "PASSWORD_MAX_AGE_USER must not be negative")
+}
+
+func (a Argon2) validate(v *validator) {
+	v.check(a.MemoryKiB > 0 && a.MemoryKiB <= math.MaxUint32, "ARGON2_MEMORY_KIB must be positive")
+	v.check(a.Iterations > 0 && a.Iterations <= math.MaxUint32, "ARGON2_ITERATIONS must be positive")
+	v.check(a.Parallelism > 0 && a.Parallelism <= math.MaxUint8, "ARGON2_PARALLELISM must be between 1 and 255")
+}
+
+func (p Pepper) validate(v *validator) {
+	v.oneOf("PASSWORD_PEPPER_SOURCE", p.Source, "", "vault", "env")
+	switch p.Source {
+	case "vault":
+		v.require("PASSWORD_PEPPER_VAULT_PATH", p.Vault.Path)
+	case "env":
+		v.require("PASSWORD_PEPPERS", p.Peppers)
+	}
+	v.check(p.Version >= 0, "PASSWORD_PEPPER_VERSION must not be negative")
+}
+
+// validateGuards checks the settings of the session limit, lockouts and
+// the checks made at sign-in and registration.
+func (c *Config) validateGuards(v *validator) {
+	v.check(c.SessionLimit.Max >= 0, "MAX_ACTIVE_SESSIONS must not be negative")
+	c.SessionLimit.Strategy = strings.ToUpper(c.SessionLimit.Strategy)
+	v.oneOf("SESSION_LIMIT_STRATEGY", c.SessionLimit.Strategy, string(domain.SessionLimitRevokeOldest), string(domain.SessionLimitReject))
+
+	v.check(c.Lockout.Threshold >= 0, "LOCKOUT_THRESHOLD must not be negative")
+	if c.Lockout.Threshold > 0 {
+		v.check(c.Lockout.Duration > 0 && c.Lockout.MaxDuration >= c.Lockout.Duration, "LOCKOUT_MAX_DURATION must be at least LOCKOUT_DURATION, which must be positive")
+	}
+
+	c.Captcha.Provider = strings.ToLower(c.Captcha.Provider)
+	if c.Captcha.Provider != "" {
+		v.oneOf("CAPTCHA_PROVIDER", c.Captcha.Provider, "recaptcha", "hcaptcha", "turnstile")
+		v.require("CAPTCHA_SECRET", c.Captcha.Secret)
+		v.check(c.Captcha.MinScore >= 0 && c.Captcha.MinScore <= 1, "CAPTCHA_MIN_SCORE must be between 0 and 1")
+		for i, flow := range c.Captcha.RequiredOn {
+			c.Captcha.RequiredOn[i] = strings.ToLower(flow)
+			v.oneOf("CAPTCHA_REQUIRED_ON", c.Captcha.RequiredOn[i], "register", "password_reset")
+		}
+		v.check(c.Captcha.LoginAfterFailures >= 0, "CAPTCHA_LOGIN_AFTER_FAILURES must not be negative")
+	}
+
+	c.DisposableEmails.Action = strings.ToUpper(c.DisposableEmails.Action)
+	v.oneOf("DISPOSABLE_EMAIL_ACTION", c.DisposableEmails.Action, string(domain.DisposableEmailReject), string(domain.DisposableEmailFlag), "OFF")
+
+	c.BreachedPasswords.Action = strings.ToUpper(c.BreachedPasswords.Action)
+	v.oneOf("BREACHED_PASSWORD_ACTION", c.BreachedPasswords.Action, string(domain.BreachedPasswordBlock), string(domain.BreachedPasswordWarn), "OFF")
+	if c.BreachedPasswords.Action != "OFF" && strings.EqualFold(c.BreachedPasswords.APIURL, "off") {
+		v.check(c.BreachedPasswords.BloomFilter != "", "BREACHED_PASSWORD_API_URL is off and no BREACHED_PASSWORD_BLOOM_FILTER is given")
+	}
+}
+
+// validateBackground checks the settings of the work done outside
+// requests: account expiry, webhooks, the SIEM stream, the event broker and
+// the scheduled jobs.
+func (c *Config) validateBackground(v *validator) {
+	v.check(c.Accounts.DeletionGracePeriod >= 0, "ACCOUNT_DELETION_GRACE_PERIOD must not be negative")
+	v.check(c.Accounts.PendingTTL >= 0, "PENDING_ACCOUNT_TTL must not be negative")
+
+	if c.DataExports.SigningKey != "" {
+		if key, err := base64.StdEncoding.DecodeString(c.DataExports.SigningKey); err != nil || len(key) < 32 {
+			v.check(false, "DATA_EXPORT_SIGNING_KEY must be at least 32 bytes encoded in base64")
+		}
+	}
+	if c.Webhooks.EncryptionKey != "" {
+		v.key("WEBHOOK_ENCRYPTION_KEY", c.Webhooks.EncryptionKey)
+	}
+	v.check(c.Webhooks.DeliveryInterval > 0, "WEBHOOK_DELIVERY_INTERVAL must be positive")
+
+	siem := c.SIEM
+	v.oneOf("SIEM_SINK", siem.Sink, "", "syslog", "splunk", "elastic")
+	switch siem.Sink {
+	case "syslog":
+		v.oneOf("SIEM_SYSLOG_FORMAT", siem.Syslog.Format, "cef", "leef")
+		v.require("SIEM_SYSLOG_ADDR", siem.Syslog.Addr)
+	case "splunk":
+		v.require("SIEM_SPLUNK_URL", siem.Splunk.URL)
+		v.require("SIEM_SPLUNK_TOKEN", siem.Splunk.Token)
+	case "elastic":
+		v.require("SIEM_ELASTIC_URL", siem.Elastic.URL)
+	}
+	if siem.Sink != "" {
+		v.check(siem.BufferSize > 0, "SIEM_BUFFER_SIZE must be positive")
+		v.check(siem.BatchSize > 0, "SIEM_BATCH_SIZE must be positive")
+		v.check(siem.FlushInterval > 0, "SIEM_FLUSH_INTERVAL must be positive")
+		v.check(siem.BlockTimeout >= 0, "SIEM_BLOCK_TIMEOUT must not be negative")
+	}
+
+	v.oneOf("EVENT_BROKER", c.EventBroker.Kind, "", "nats", "kafka")
+
+	v.check(c.Jobs.BanExpiryInterval > 0, "BAN_EXPIRY_INTERVAL must be positive")
+	v.check(c.Jobs.RefreshTokenPurgeInterval > 0, "REFRESH_TOKEN_PURGE_INTERVAL must be positive")
+	v.check(c.Jobs.OutboxRelayInterval > 0, "OUTBOX_RELAY_INTERVAL must be positive")
+	v.check(c.Jobs.ActiveSessionsInterval > 0, "ACTIVE_SESSIONS_INTERVAL must be positive")
+}
+
+func (r Retention) validate(v *validator) {
+	v.check(r.LoginOrigins >= 0, "LOGIN_ORIGIN_RETENTION must not be negative")
+	v.check(r.AuditEvents >= 0, "AUDIT_EVENT_RETENTION must not be negative")
+	v.check(r.VerificationCodes >= domain.VerificationCodeRetention, fmt.Sprintf("VERIFICATION_CODE_RETENTION must be at least %s, the day verification resends are counted over", domain.VerificationCodeRetention))
+	v.check(r.RefreshTokens >= 0, "REFRESH_TOKEN_RETENTION must not be negative")
+}
+
+func validateFeatures(v *validator, flags []FeatureFlag, providers []string) {
+	keys := []string{string(domain.FeaturePasskeys), string(domain.FeatureAdminMFA)}
+	for _, provider := range providers {
+		keys = append(keys, domain.FeatureProviderPrefix+provider)
+	}
+
+	seen := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		v.oneOf("feature key", flag.Key, keys...)
+		v.check(!seen[flag.Key], fmt.Sprintf("feature %s is configured twice", flag.Key))
+		seen[flag.Key] = true
+		v.check(flag.Percentage >= 0 && flag.Percentage <= 100, fmt.Sprintf("feature %s percentage must be between 0 and 100", flag.Key))
+	}
+}
+
+// validator collects the problems found in a configuration.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, problem string) {
+	if !ok {
+		v.errs = append(v.errs, errors.New(problem))
+	}
+}
+
+func (v *validator) require(key, value string) {
+	v.check(value != "", key+" is required")
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return
+		}
+	}
+	v.check(false, fmt.Sprintf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value))
+}
+
+// key requires value to be a 32 byte key encoded in base64.
+func (v *validator) key(name, value string) {
+	key, err := base64.StdEncoding.DecodeString(value)
+	v.check(err == nil && len(key) == 32, name+" must be 32 bytes encoded in base64")
+}
+
+// url requires value to be an absolute http or https URL.
+func (v *validator) url(key, value string) {
+	if value == "" {
+		v.require(key, value)
+		return
+	}
+	parsed, err := url.Parse(value)
+	v.check(err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "", key+" must be an absolute http or https URL")
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Account Roles
 const (
 	RoleAdmin Role = "ADMIN"
@@ -16,6 +18,591 @@ const (
 
 // Auth Providers
 const (
-	ProviderEmail  Provider = "EMAIL"
-	ProviderGoogle Provider = "GOOGLE"
+	ProviderEmail     Provider = "EMAIL"
+	ProviderGoogle    Provider = "GOOGLE"
+	ProviderGithub    Provider = "GITHUB"
+	ProviderApple     Provider = "APPLE"
+	ProviderMicrosoft Provider = "MICROSOFT"
+)
+
+// Verification Code Purposes
+const (
+	PurposeEmailVerification CodePurpose = "EMAIL_VERIFICATION"
+	PurposeLogin             CodePurpose = "LOGIN"
+	PurposePasswordReset     CodePurpose = "PASSWORD_RESET"
+	PurposeMagicLink         CodePurpose = "MAGIC_LINK"
+	PurposeAccountRestore    CodePurpose = "ACCOUNT_RESTORE"
+)
+
+// Verification Codes
+const (
+	VerificationCodeLength  = 6
+	VerificationCodeTTL     = 5 * time.Minute
+	MaxVerificationAttempts = 5
+	PasswordResetCodeTTL    = 15 * time.Minute
+	// VerificationResendCooldown is the least time between a verification
+	// code and the one resent to replace it.
+	VerificationResendCooldown = time.Minute
+	// MaxVerificationCodesPerDay caps the verification codes a method is sent
+	// in any 24 hours, the one sent at registration included.
+	MaxVerificationCodesPerDay = 10
+	// VerificationCodeRetention is the default, and the least, time codes
+	// are kept once they have expired or been consumed, before they are
+	// purged. It covers the day MaxVerificationCodesPerDay counts codes over.
+	VerificationCodeRetention = 24 * time.Hour
+	// VerificationCodePurgeBatch is how many codes a purge deletes at once.
+	VerificationCodePurgeBatch = 1000
+)
+
+// Sign-In Lockout
+const (
+	// LockoutThreshold is the default number of consecutive failed attempts
+	// after which an auth method is locked for LockoutDuration. Each further
+	// failure doubles the lock, up to LockoutMaxDuration.
+	LockoutThreshold   = 5
+	LockoutDuration    = time.Minute
+	LockoutMaxDuration = time.Hour
+)
+
+// Disposable Email Actions
+const (
+	// DisposableEmailReject refuses sign-ups from disposable email domains.
+	DisposableEmailReject DisposableEmailAction = "REJECT"
+	// DisposableEmailFlag accepts them and marks the registration event.
+	DisposableEmailFlag DisposableEmailAction = "FLAG"
+)
+
+// Breached Password Actions
+const (
+	// BreachedPasswordBlock refuses passwords found in data breaches.
+	BreachedPasswordBlock BreachedPasswordAction = "BLOCK"
+	// BreachedPasswordWarn accepts them and reports the finding.
+	BreachedPasswordWarn BreachedPasswordAction = "WARN"
+)
+
+// Magic Links
+const (
+	MagicLinkTokenBytes = 32
+	MagicLinkTTL        = 10 * time.Minute
+)
+
+// MFA Factor Types
+const (
+	MFAFactorTOTP MFAFactorType = "TOTP"
+	MFAFactorSMS  MFAFactorType = "SMS"
+	// MFAFactorPasskey is reported for accounts with registered passkeys,
+	// which are stored as passkey credentials rather than MFA factors.
+	MFAFactorPasskey MFAFactorType = "PASSKEY"
+	// MFAFactorRecoveryCode is reported for accounts holding unused recovery
+	// codes, which stand in for an unavailable factor.
+	MFAFactorRecoveryCode MFAFactorType = "RECOVERY_CODE"
+)
+
+// MFA Challenges
+const (
+	MFAChallengeTokenBytes = 32
+	MFAChallengeTTL        = 5 * time.Minute
+	MaxMFAAttempts         = 5
+)
+
+// MFA Codes
+const (
+	MFACodeLength = 6
+	MFACodeTTL    = 5 * time.Minute
+)
+
+// MFA Recovery Codes
+const (
+	RecoveryCodeCount = 10
+)
+
+// Access Token Scopes
+const (
+	// TokenScopeFull is the unrestricted scope of a regular session.
+	TokenScopeFull TokenScope = ""
+	// TokenScopeMFAEnrollment limits a token to enrolling a second factor. It is
+	// issued to accounts whose role requires MFA but that have none yet.
+	TokenScopeMFAEnrollment TokenScope = "mfa_enrollment"
+	// TokenScopePasswordChange limits a token to changing the password. It is
+	// issued to accounts whose password is older than their role allows.
+	TokenScopePasswordChange TokenScope = "password_change"
+	// TokenScopeConsent limits a token to accepting the legal documents. It
+	// is issued to accounts that have not accepted their current versions.
+	TokenScopeConsent TokenScope = "consent"
+)
+
+// Step-Up Authentication
+const (
+	// StepUpTokenTTL bounds the lifetime of elevated access tokens.
+	StepUpTokenTTL = 5 * time.Minute
+	// StepUpMaxAge is how recent a reauthentication must be for the sensitive
+	// operations of this service.
+	StepUpMaxAge = 5 * time.Minute
+)
+
+// Authentication Method References (RFC 8176)
+const (
+	AMRPassword = "pwd"
+	AMROTP      = "otp"
+	AMRSMS      = "sms"
+)
+
+// Authentication Context Classes, after the NIST SP 800-63B assurance levels
+const (
+	ACRSingleFactor = "aal1"
+	ACRMultiFactor  = "aal2"
+)
+
+// Passkey Ceremony Purposes
+const (
+	PasskeyPurposeRegistration PasskeyPurpose = "REGISTRATION"
+	PasskeyPurposeLogin        PasskeyPurpose = "LOGIN"
+	PasskeyPurposeMFA          PasskeyPurpose = "MFA"
+)
+
+// Passkeys
+const (
+	PasskeyCeremonyTTL   = 5 * time.Minute
+	MaxPasskeyNameLength = 64
+)
+
+// Passwords
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength bounds the input fed to the password hash. Password
+	// policies may lower the limit for new passwords but not raise it.
+	MaxPasswordLength = 128
+	// MinBannedWordLength is the shortest banned word, email local parts
+	// included; shorter ones would match too many passwords.
+	MinBannedWordLength = 3
+	// MaxPasswordHistorySize bounds how many past passwords are remembered
+	// per auth method.
+	MaxPasswordHistorySize = 24
+	// MaxPasswordScore is the score of the strongest passwords, on the
+	// 0 to 4 scale of the strength estimator.
+	MaxPasswordScore = 4
+)
+
+// Password Character Classes
+const (
+	CharacterClassLower  CharacterClass = "lower"
+	CharacterClassUpper  CharacterClass = "upper"
+	CharacterClassDigit  CharacterClass = "digit"
+	CharacterClassSymbol CharacterClass = "symbol"
+)
+
+// Password Policy Violations
+const (
+	PasswordTooShort         = "too_short"
+	PasswordTooLong          = "too_long"
+	PasswordMissingLowercase = "missing_lowercase"
+	PasswordMissingUppercase = "missing_uppercase"
+	PasswordMissingDigit     = "missing_digit"
+	PasswordMissingSymbol    = "missing_symbol"
+	PasswordContainsEmail    = "contains_email"
+	PasswordContainsBanned   = "contains_banned_word"
+	PasswordRecentlyUsed     = "recently_used"
+	PasswordTooWeak          = "too_weak"
+)
+
+// Access Tokens
+const (
+	AccessTokenTTL = 15 * time.Minute
+	// OpaqueAccessTokenBytes sizes the random access tokens of the opaque
+	// token format.
+	OpaqueAccessTokenBytes = 32
+	// MaxBatchTokens caps the tokens of a single batch validation, and
+	// BatchValidationConcurrency how many of them are validated at once.
+	MaxBatchTokens             = 100
+	BatchValidationConcurrency = 8
+)
+
+// Refresh Tokens
+const (
+	RefreshTokenBytes = 32
+	// RefreshTokenTTL and RememberMeRefreshTokenTTL are the default lifetimes
+	// of sessions opened without and with remember me.
+	RefreshTokenTTL           = 24 * time.Hour
+	RememberMeRefreshTokenTTL = 30 * 24 * time.Hour
+	// SlidingSessionCeiling is the default time after login past which a
+	// sliding session is no longer extended.
+	SlidingSessionCeiling = 90 * 24 * time.Hour
+	// MaxSessionAge is the default absolute lifetime of a session.
+	MaxSessionAge = 90 * 24 * time.Hour
+	// MaxActiveSessions is the default cap on active refresh tokens per account.
+	MaxActiveSessions = 1
+	// RefreshTokenRetention is the default time refresh tokens are kept once
+	// they have expired or been revoked, before they are purged.
+	RefreshTokenRetention = 30 * 24 * time.Hour
+	// RefreshTokenPurgeBatch is how many refresh tokens a purge deletes at
+	// once, so it never locks a large part of the table.
+	RefreshTokenPurgeBatch = 1000
+)
+
+// Session Limit Strategies
+const (
+	// SessionLimitRevokeOldest makes room for a new session by revoking the
+	// oldest active ones.
+	SessionLimitRevokeOldest SessionLimitStrategy = "REVOKE_OLDEST"
+	// SessionLimitReject refuses new sessions while the account is at the cap.
+	SessionLimitReject SessionLimitStrategy = "REJECT"
+)
+
+// Session Revocation Reasons
+const (
+	// SessionRevokedLogout ends the session of the refresh token presented
+	// to logout.
+	SessionRevokedLogout SessionRevocationReason = "logout"
+	// SessionRevokedByAccount ends sessions the account holder chose from
+	// their list of sessions.
+	SessionRevokedByAccount SessionRevocationReason = "revoked_by_account"
+	// SessionRevokedByClient ends the session of a refresh token revoked
+	// through the RFC 7009 revocation endpoint.
+	SessionRevokedByClient SessionRevocationReason = "revoked_by_client"
+	// SessionRevokedByAdmin ends every session of an account on an
+	// administrator's request.
+	SessionRevokedByAdmin SessionRevocationReason = "revoked_by_admin"
+)
+
+// Pending Account Expiry
+const (
+	// PendingAccountTTL is the default time a PENDING account awaits
+	// verification before it is deleted.
+	PendingAccountTTL = 7 * 24 * time.Hour
+	// PendingAccountExpiryBatch is how many expired accounts are looked up
+	// at once.
+	PendingAccountExpiryBatch = 100
+	// AccountExpiredUnverified deletes an account left PENDING past
+	// PendingAccountTTL.
+	AccountExpiredUnverified AccountExpiryReason = "unverified"
+	// AccountExpiredReRegistered deletes a PENDING account whose codes have
+	// expired when its address registers again.
+	AccountExpiredReRegistered AccountExpiryReason = "re_registered"
+)
+
+// Account Deletion
+const (
+	// AccountDeletionGracePeriod is the default time an account deleted by
+	// its holder can be restored before it is purged.
+	AccountDeletionGracePeriod = 30 * 24 * time.Hour
+	AccountRestoreCodeTTL      = 15 * time.Minute
+	// AccountPurgeBatch is how many due deletions are looked up at once.
+	AccountPurgeBatch = 100
+	// ErasedPrefix, followed by the row id, replaces the provider ids and
+	// invitation emails of erased accounts, keeping them unique.
+	ErasedPrefix = "erased:"
+)
+
+// Legal Documents
+const (
+	LegalDocumentTermsOfService   LegalDocumentKind = "TERMS_OF_SERVICE"
+	LegalDocumentPrivacyPolicy    LegalDocumentKind = "PRIVACY_POLICY"
+	MaxLegalDocumentVersionLength                   = 64
+	MaxLegalDocumentURLLength                       = 2048
+)
+
+// Data Exports
+const (
+	DataExportPending DataExportStatus = "PENDING"
+	DataExportReady   DataExportStatus = "READY"
+	// DataExportTTL is how long a generated archive can be downloaded
+	// before it is purged.
+	DataExportTTL = 7 * 24 * time.Hour
+	// DataExportBatch is how many pending exports are generated per run.
+	DataExportBatch = 10
+	// DataExportPurgeBatch is how many expired archives are deleted at
+	// once.
+	DataExportPurgeBatch = 100
+)
+
+// Login Results
+const (
+	LoginResultSuccess LoginResult = "success"
+	LoginResultFailure LoginResult = "failure"
+	// LoginResultMFAChallenge holds a login back until a second factor is
+	// verified, which counts as a login of its own.
+	LoginResultMFAChallenge LoginResult = "mfa_challenge"
+)
+
+// Trusted Devices
+const (
+	TrustedDeviceTokenBytes = 32
+	// TrustedDeviceTTL is the default window in which a trusted device skips
+	// the MFA challenge.
+	TrustedDeviceTTL = 30 * 24 * time.Hour
+)
+
+// OAuth Clients
+const (
+	// MinClientSecretLength keeps client secrets, stored as a fast hash, out
+	// of reach of guessing.
+	MinClientSecretLength = 32
+)
+
+// OpenID Connect Provider
+const (
+	ScopeOpenID                = "openid"
+	ScopeEmail                 = "email"
+	ResponseTypeCode           = "code"
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	PromptNone                 = "none"
+	CodeChallengeMethodS256    = "S256"
+	AuthorizationCodeBytes     = 32
+	AuthorizationCodeTTL       = time.Minute
+	BrowserSessionTokenBytes   = 32
+)
+
+// Device Authorization Grant (RFC 8628)
+const (
+	DeviceCodePending  DeviceCodeStatus = "PENDING"
+	DeviceCodeApproved DeviceCodeStatus = "APPROVED"
+	DeviceCodeDenied   DeviceCodeStatus = "DENIED"
+
+	DeviceCodeBytes = 32
+	DeviceCodeTTL   = 10 * time.Minute
+	// UserCodeLength leaves about 34 bits of entropy in the code the user
+	// types, enough for the lifetime of a device code.
+	UserCodeLength = 8
+	// DevicePollInterval is the initial interval between token requests;
+	// devices polling faster get slow_down and the interval grows by
+	// DevicePollSlowDown.
+	DevicePollInterval = 5 * time.Second
+	DevicePollSlowDown = 5 * time.Second
+)
+
+// API Keys
+const (
+	// APIKeyPrefix starts every API key, so validators can tell keys from
+	// access tokens and secret scanners can recognize leaked keys.
+	APIKeyPrefix = "rk_"
+	APIKeyBytes  = 32
+	// APIKeyDisplayLength is how many leading characters of a key are kept
+	// in clear, prefix included.
+	APIKeyDisplayLength  = 11
+	MaxAPIKeyNameLength  = 64
+	MaxAPIKeyScopes      = 20
+	MaxAPIKeysPerAccount = 25
+	// APIKeyUsageResolution limits last use updates to one per key and
+	// period.
+	APIKeyUsageResolution = time.Minute
+	// TokenTypeAPIKey is the introspection token_type of API keys.
+	TokenTypeAPIKey = "api_key"
+)
+
+// DPoP (RFC 9449)
+const (
+	// DPoPProofWindow bounds how far the iat of a proof may be from now. Proof
+	// IDs are remembered for as long, so a proof is accepted once.
+	DPoPProofWindow = time.Minute
+	TokenTypeDPoP   = "DPoP"
+)
+
+// Token Type Hints (RFC 7662 and RFC 7009)
+const (
+	TokenTypeAccessToken  = "access_token"
+	TokenTypeRefreshToken = "refresh_token"
+)
+
+// Token Type Identifiers (RFC 8693)
+const (
+	TokenTypeURIAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// SCIM Provisioning (RFC 7643 and RFC 7644)
+const (
+	// SCIMScope is the API key scope that grants the SCIM endpoints to keys
+	// of ADMIN accounts restricted to scopes.
+	SCIMScope           = "scim"
+	SCIMDefaultPageSize = 100
+	SCIMMaxPageSize     = 200
+)
+
+// Admin Account Search
+const (
+	AccountSearchDefaultLimit = 50
+	AccountSearchMaxLimit     = 100
+)
+
+// Account Bans
+const (
+	MaxBanReasonLength = 500
+	// BanExpiryBatch is how many expired bans are lifted per transaction
+	// round.
+	BanExpiryBatch = 100
+)
+
+// Role Assignment
+const (
+	MaxRoleChangeReasonLength = 500
+)
+
+// Custom Roles
+const (
+	MaxRoleDescriptionLength = 500
+	// MaxRolePermissions bounds the permissions of a role, which every
+	// access token of its accounts carries.
+	MaxRolePermissions  = 100
+	MaxPermissionLength = 128
+)
+
+// Organizations
+const (
+	MaxOrganizationNameLength = 255
+	InvitationTokenBytes      = 32
+	InvitationTTL             = 7 * 24 * time.Hour
+)
+
+// Organization Branding
+const (
+	MaxSenderNameLength = 100
+	MaxLogoURLLength    = 2048
+	MaxFooterTextLength = 500
+)
+
+// Organization Policies
+const (
+	// MinPolicySessionMaxAge and MaxPolicySessionMaxAge bound the session
+	// lifetime organizations may set for their members.
+	MinPolicySessionMaxAge = 5 * time.Minute
+	MaxPolicySessionMaxAge = 366 * 24 * time.Hour
+)
+
+// Impersonation
+const (
+	// ImpersonationTTL is the default lifetime of impersonation tokens, and
+	// MaxImpersonationTTL the longest one an administrator may ask for.
+	ImpersonationTTL             = 15 * time.Minute
+	MaxImpersonationTTL          = time.Hour
+	MaxImpersonationReasonLength = 500
+)
+
+// Audit Actions
+const (
+	AuditLoginSucceeded       AuditAction = "login.succeeded"
+	AuditLoginFailed          AuditAction = "login.failed"
+	AuditTokenRefreshed       AuditAction = "token.refreshed"
+	AuditPasswordChanged      AuditAction = "password.changed"
+	AuditPasswordReset        AuditAction = "password.reset"
+	AuditRoleChanged          AuditAction = "account.role_changed"
+	AuditAccountBanned        AuditAction = "account.banned"
+	AuditAccountUnbanned      AuditAction = "account.unbanned"
+	AuditMFAEnrolled          AuditAction = "mfa.enrolled"
+	AuditMFADisabled          AuditAction = "mfa.disabled"
+	AuditImpersonationStarted AuditAction = "impersonation.started"
+	AuditImpersonationEnded   AuditAction = "impersonation.ended"
+	AuditSessionsRevoked      AuditAction = "account.sessions_revoked"
+	AuditAccountDeleted       AuditAction = "account.deleted"
+	AuditAccountRestored      AuditAction = "account.restored"
+	AuditAccountPurged        AuditAction = "account.purged"
+	AuditDataExportRequested  AuditAction = "account.data_export_requested"
+	AuditAccountErased        AuditAction = "account.erased"
+	AuditConsentAccepted      AuditAction = "account.consent_accepted"
+	AuditDocumentPublished    AuditAction = "legal_document.published"
+)
+
+// Audit Log Queries
+const (
+	AuditEventDefaultLimit = 50
+	AuditEventMaxLimit     = 100
+	// SecurityActivityPeriod is how far back accounts see their own
+	// security activity.
+	SecurityActivityPeriod = 90 * 24 * time.Hour
+)
+
+// Data Retention
+const (
+	// LoginOriginPurgeBatch is how many rows a purge clears the IP address
+	// and user agent of at once.
+	LoginOriginPurgeBatch = 1000
+	// AuditEventPurgeBatch is how many audit events a purge deletes at once.
+	AuditEventPurgeBatch = 1000
+)
+
+// Webhook Events
+const (
+	WebhookAccountCreated       WebhookEvent = "account.created"
+	WebhookAccountVerified      WebhookEvent = "account.verified"
+	WebhookAccountStatusChanged WebhookEvent = "account.status_changed"
+	WebhookAccountBanned        WebhookEvent = "account.banned"
+	WebhookAccountRoleChanged   WebhookEvent = "account.role_changed"
+	WebhookLoginFailed          WebhookEvent = "login.failed"
+	WebhookPasswordChanged      WebhookEvent = "password.changed"
+	WebhookMFAEnabled           WebhookEvent = "mfa.enabled"
+	WebhookMFADisabled          WebhookEvent = "mfa.disabled"
+	WebhookAccountExpired       WebhookEvent = "account.expired"
+	WebhookAccountPurged        WebhookEvent = "account.purged"
+	WebhookAccountErased        WebhookEvent = "account.erased"
+)
+
+// Webhook Delivery Statuses
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// Webhooks
+const (
+	// WebhookMaxAttempts is how many times a delivery is attempted before it
+	// fails. Attempts are spaced by WebhookRetryBaseDelay, doubling after
+	// each one up to WebhookMaxRetryDelay, which spreads them over about
+	// four hours.
+	WebhookMaxAttempts    = 10
+	WebhookRetryBaseDelay = 30 * time.Second
+	WebhookMaxRetryDelay  = 6 * time.Hour
+	// WebhookTimeout bounds each attempt, and WebhookLease how long a claimed
+	// delivery is kept from other workers while it is attempted.
+	WebhookTimeout              = 10 * time.Second
+	WebhookLease                = time.Minute
+	WebhookBatch                = 50
+	MaxWebhookURLLength         = 2048
+	MaxWebhookDescriptionLength = 500
+	// WebhookDeliveryListLimit is how many of its latest deliveries an
+	// endpoint lists.
+	WebhookDeliveryListLimit = 100
+	// WebhookSecretPrefix starts every webhook secret, so it is recognized
+	// when pasted in the wrong place.
+	WebhookSecretPrefix = "whsec_"
+	WebhookSecretBytes  = 32
+)
+
+// Outbox
+const (
+	// OutboxRetryBaseDelay spaces the attempts to relay a stored event,
+	// doubling after each failure up to OutboxMaxRetryDelay. Events are
+	// retried until they are published.
+	OutboxRetryBaseDelay = 5 * time.Second
+	OutboxMaxRetryDelay  = 10 * time.Minute
+	// OutboxLease is how long a claimed event is kept from other relays while
+	// it is published.
+	OutboxLease = time.Minute
+	OutboxBatch = 100
+	// OutboxRetention is how long published events are kept before they are
+	// purged.
+	OutboxRetention = 24 * time.Hour
+)
+
+// Health Checks
+const (
+	// HealthCheckTimeout bounds each dependency check of a readiness probe,
+	// so an unresponsive dependency answers the probe before it times out.
+	HealthCheckTimeout = 2 * time.Second
+)
+
+// Feature Flags
+const (
+	FeaturePasskeys Feature = "passkeys"
+	// FeatureAdminMFA requires a second factor of ADMIN accounts, on top of
+	// the roles listed in MFA_REQUIRED_ROLES.
+	FeatureAdminMFA Feature = "admin_mfa"
+	// FeatureProviderPrefix followed by the lower-cased code of an OAuth
+	// provider gates its rollout, such as provider.google.
+	FeatureProviderPrefix = "provider."
+	// FeatureBuckets is how many buckets accounts are hashed into for a
+	// percentage rollout.
+	FeatureBuckets = 100
 )
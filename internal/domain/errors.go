@@ -0,0 +1,133 @@
+package domain
+
+import "errors"
+
+// Persistence Errors
+var (
+	ErrNotFound = errors.New("resource not found")
+	ErrConflict = errors.New("resource already exists")
+)
+
+// Business Errors
+var (
+	ErrInvalidEmail                 = errors.New("invalid email address")
+	ErrInvalidPassword              = errors.New("invalid password")
+	ErrAccountAlreadyExists         = errors.New("account already exists")
+	ErrInvalidCredentials           = errors.New("invalid credentials")
+	ErrInvalidAccountState          = errors.New("invalid account state")
+	ErrInvalidOrExpiredCode         = errors.New("invalid or expired code")
+	ErrVerificationAttemptsExceeded = errors.New("verification attempts exceeded")
+	ErrInvalidRefreshToken          = errors.New("invalid refresh token")
+	ErrInvalidAccessToken           = errors.New("invalid access token")
+	ErrUnsupportedProvider          = errors.New("unsupported provider")
+	ErrInvalidOAuthState            = errors.New("invalid oauth state")
+	ErrAuthMethodNotFound           = errors.New("auth method not found")
+	ErrIdentityAlreadyLinked        = errors.New("identity already linked to another account")
+	ErrProviderAlreadyLinked        = errors.New("provider already linked to this account")
+	ErrLastAuthMethod               = errors.New("cannot remove the last verified auth method")
+	ErrMFAAlreadyEnrolled           = errors.New("mfa factor already enrolled")
+	ErrMFANotEnrolled               = errors.New("mfa factor not enrolled")
+	ErrInvalidMFACode               = errors.New("invalid mfa code")
+	ErrInvalidMFAChallenge          = errors.New("invalid or expired mfa challenge")
+	ErrInvalidPhoneNumber           = errors.New("invalid phone number")
+	ErrInvalidPasskeyCeremony       = errors.New("invalid or expired passkey ceremony")
+	ErrInvalidPasskey               = errors.New("invalid passkey")
+	ErrPasskeyNotFound              = errors.New("passkey not found")
+	ErrInvalidPasskeyName           = errors.New("invalid passkey name")
+	ErrMFAEnrollmentRequired        = errors.New("mfa enrollment required")
+	ErrTrustedDeviceNotFound        = errors.New("trusted device not found")
+	ErrReauthenticationRequired     = errors.New("reauthentication required")
+	ErrSessionNotFound              = errors.New("session not found")
+	ErrSessionLimitReached          = errors.New("active session limit reached")
+	ErrInvalidClient                = errors.New("invalid client")
+	ErrInvalidRedirectURI           = errors.New("invalid redirect uri")
+	ErrUnsupportedResponseType      = errors.New("unsupported response type")
+	ErrInvalidScope                 = errors.New("invalid scope")
+	ErrCodeChallengeRequired        = errors.New("code challenge required")
+	ErrInvalidCodeChallenge         = errors.New("invalid code challenge")
+	ErrLoginRequired                = errors.New("login required")
+	ErrInvalidGrant                 = errors.New("invalid grant")
+	ErrUnsupportedGrantType         = errors.New("unsupported grant type")
+	ErrUnauthorizedClient           = errors.New("client not allowed to use this grant")
+	ErrAuthorizationPending         = errors.New("authorization pending")
+	ErrSlowDown                     = errors.New("polling too fast")
+	ErrAccessDenied                 = errors.New("access denied")
+	ErrExpiredToken                 = errors.New("device code expired")
+	ErrInvalidUserCode              = errors.New("invalid or expired user code")
+	ErrInvalidTarget                = errors.New("invalid token exchange audience")
+	ErrInvalidExchangeToken         = errors.New("invalid subject or actor token")
+	ErrUnsupportedTokenType         = errors.New("unsupported token type")
+	ErrInvalidDPoPProof             = errors.New("invalid dpop proof")
+	ErrAPIKeyNotFound               = errors.New("api key not found")
+	ErrInvalidAPIKeyName            = errors.New("invalid api key name")
+	ErrInvalidAPIKeyExpiry          = errors.New("api key expiry must be in the future")
+	ErrAPIKeyLimitReached           = errors.New("api key limit reached")
+	ErrAccountNotFound              = errors.New("account not found")
+	ErrTooManyTokens                = errors.New("too many tokens in batch")
+	ErrRateLimited                  = errors.New("too many requests")
+	ErrAuthMethodLocked             = errors.New("sign-in method temporarily locked")
+	ErrMFAFactorLocked              = errors.New("second factor temporarily locked")
+	ErrCaptchaRequired              = errors.New("captcha required")
+	ErrInvalidCaptcha               = errors.New("invalid captcha")
+	ErrDisposableEmail              = errors.New("disposable email addresses are not allowed")
+	ErrBreachedPassword             = errors.New("password appears in a data breach")
+	ErrPasswordChangeRequired       = errors.New("password change required")
+	ErrUnsupportedPasswordHash      = errors.New("unsupported password hash")
+	ErrAdminRequired                = errors.New("admin role required")
+	ErrInvalidCursor                = errors.New("invalid cursor")
+	ErrInsufficientScope            = errors.New("insufficient scope")
+	ErrInvalidBanReason             = errors.New("invalid ban reason")
+	ErrInvalidBanExpiry             = errors.New("ban expiry must be in the future")
+	ErrAccountAlreadyBanned         = errors.New("account already banned")
+	ErrAccountNotBanned             = errors.New("account not banned")
+	ErrCannotBanSelf                = errors.New("administrators cannot ban themselves")
+	ErrCannotEraseSelf              = errors.New("administrators cannot erase their own account")
+	ErrInvalidRole                  = errors.New("invalid role")
+	ErrRoleUnchanged                = errors.New("account already has this role")
+	ErrLastAdmin                    = errors.New("cannot demote the last active admin")
+	ErrInvalidRoleChangeReason      = errors.New("invalid role change reason")
+	ErrRoleNotFound                 = errors.New("role not found")
+	ErrRoleAlreadyExists            = errors.New("role already exists")
+	ErrSystemRole                   = errors.New("system roles cannot be deleted")
+	ErrRoleInUse                    = errors.New("role is assigned to accounts")
+	ErrInvalidPermission            = errors.New("invalid permission")
+	ErrInvalidRoleDescription       = errors.New("invalid role description")
+	ErrOrganizationNotFound         = errors.New("organization not found")
+	ErrOrganizationAlreadyExists    = errors.New("organization already exists")
+	ErrInvalidOrganizationName      = errors.New("invalid organization name")
+	ErrInvalidOrganizationSlug      = errors.New("invalid organization slug")
+	ErrMembershipNotFound           = errors.New("organization membership not found")
+	ErrOrganizationAdminRequired    = errors.New("organization admin role required")
+	ErrInvitationNotFound           = errors.New("invitation not found")
+	ErrInvalidOrExpiredInvitation   = errors.New("invalid or expired invitation")
+	ErrInvitationEmailMismatch      = errors.New("invitation was sent to another email address")
+	ErrKeyRotationDisabled          = errors.New("signing key rotation is disabled")
+	ErrBrandingNotFound             = errors.New("organization branding not found")
+	ErrInvalidBranding              = errors.New("invalid organization branding")
+	ErrPolicyNotFound               = errors.New("organization policy not found")
+	ErrInvalidPolicy                = errors.New("invalid organization policy")
+	ErrProviderNotAllowed           = errors.New("provider not allowed by the account's organizations")
+	ErrPasswordResetRequired        = errors.New("password does not meet the policy of the account's organizations")
+	ErrImpersonationNotFound        = errors.New("impersonation not found")
+	ErrCannotImpersonate            = errors.New("account cannot be impersonated")
+	ErrInvalidImpersonationReason   = errors.New("invalid impersonation reason")
+	ErrInvalidImpersonationDuration = errors.New("invalid impersonation duration")
+	ErrImpersonationNotAllowed      = errors.New("operation not allowed while impersonating")
+	ErrWebhookNotFound              = errors.New("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL            = errors.New("invalid webhook url")
+	ErrInvalidWebhookEvents         = errors.New("invalid webhook events")
+	ErrInvalidWebhookDescription    = errors.New("invalid webhook description")
+	ErrWebhookDeliveryPending       = errors.New("webhook delivery still pending")
+	ErrInvalidConfiguration         = errors.New("invalid configuration")
+	ErrFeatureNotEnabled            = errors.New("feature not enabled")
+	ErrVerificationResendThrottled  = errors.New("verification code resent too recently or too often")
+	ErrDataExportNotFound           = errors.New("data export not found")
+	ErrDataExportInProgress         = errors.New("data export already in progress")
+	ErrInvalidDownloadLink          = errors.New("invalid or expired download link")
+	ErrConsentRequired              = errors.New("acceptance of the current legal documents required")
+	ErrLegalDocumentNotFound        = errors.New("legal document not found")
+	ErrLegalDocumentExists          = errors.New("legal document version already published")
+	ErrLegalDocumentSuperseded      = errors.New("legal document version superseded")
+	ErrInvalidLegalDocument         = errors.New("invalid legal document")
+)
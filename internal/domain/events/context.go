@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type idKey struct{}
+
+// WithID returns a context publishing the event identified by id, as the
+// outbox does when it relays a stored event.
+func WithID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// MessageID returns the id of the message of the given kind published for
+// the event in ctx. It is derived from the id set by WithID, so an event
+// published again keeps the ids consumers deduplicate by, and random when
+// there is none.
+func MessageID(ctx context.Context, kind string) uuid.UUID {
+	id, ok := ctx.Value(idKey{}).(uuid.UUID)
+	if !ok {
+		return uuid.New()
+	}
+	return uuid.NewSHA1(id, []byte(kind))
+}
@@ -0,0 +1,384 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event Names
+const (
+	NameUserRegistered          = "user.registered"
+	NameLoginCodeRequested      = "login.code_requested"
+	NameVerificationResent      = "user.verification_resent"
+	NameOAuthUserRegistered     = "user.registered.oauth"
+	NameAuthMethodLinked        = "auth_method.linked"
+	NameAuthMethodUnlinked      = "auth_method.unlinked"
+	NamePasswordResetRequested  = "password.reset_requested"
+	NamePasswordChanged         = "password.changed"
+	NameMagicLinkRequested      = "login.magic_link_requested"
+	NameMFAEnabled              = "mfa.enabled"
+	NameMFADisabled             = "mfa.disabled"
+	NameRecoveryCodesGenerated  = "mfa.recovery_codes_generated"
+	NameRecoveryCodeUsed        = "mfa.recovery_code_used"
+	NamePasskeyRegistered       = "passkey.registered"
+	NamePasskeyRemoved          = "passkey.removed"
+	NameAPIKeyCreated           = "api_key.created"
+	NameAPIKeyRevoked           = "api_key.revoked"
+	NameAuthMethodLocked        = "auth_method.locked"
+	NamePasswordBreached        = "password.breached"
+	NameAccountProvisioned      = "account.provisioned"
+	NameAccountStatusChanged    = "account.status_changed"
+	NameAccountRoleChanged      = "account.role_changed"
+	NameInvitationCreated       = "organization.invitation_created"
+	NameInvitationAccepted      = "organization.invitation_accepted"
+	NameImpersonationStarted    = "impersonation.started"
+	NameImpersonationEnded      = "impersonation.ended"
+	NameAccountVerified         = "account.verified"
+	NameLoginFailed             = "login.failed"
+	NameLoginSucceeded          = "login.succeeded"
+	NameSessionRevoked          = "session.revoked"
+	NameAccountExpired          = "account.expired"
+	NameAccountDeleted          = "account.deleted"
+	NameAccountRestoreRequested = "account.restore_requested"
+	NameAccountPurged           = "account.purged"
+	NameDataExportReady         = "account.data_export_ready"
+	NameAccountErased           = "account.erased"
+)
+
+type Event interface {
+	Name() string
+}
+
+type UserRegisteredEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	Code      string    `json:"code"`
+	ExpiresIn int       `json:"expires_in"`
+	// DisposableEmail flags addresses of disposable email domains that were
+	// let through.
+	DisposableEmail bool `json:"disposable_email,omitempty"`
+	// Organization is the slug of the white-label organization the request
+	// came from, whose branding the email uses.
+	Organization string `json:"organization,omitempty"`
+}
+
+func (UserRegisteredEvent) Name() string { return NameUserRegistered }
+
+// VerificationResentEvent carries a new email confirmation code for a
+// PENDING account, sent again on an administrator's request.
+type VerificationResentEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	Code      string    `json:"code"`
+	ExpiresIn int       `json:"expires_in"`
+}
+
+func (VerificationResentEvent) Name() string { return NameVerificationResent }
+
+type LoginCodeRequestedEvent struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	Email        string    `json:"email"`
+	Code         string    `json:"code"`
+	ExpiresIn    int       `json:"expires_in"`
+	Organization string    `json:"organization,omitempty"`
+}
+
+func (LoginCodeRequestedEvent) Name() string { return NameLoginCodeRequested }
+
+type OAuthUserRegisteredEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email,omitempty"`
+}
+
+func (OAuthUserRegisteredEvent) Name() string { return NameOAuthUserRegistered }
+
+type AuthMethodLinkedEvent struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	AuthMethodID uuid.UUID `json:"auth_method_id"`
+	Provider     string    `json:"provider"`
+}
+
+func (AuthMethodLinkedEvent) Name() string { return NameAuthMethodLinked }
+
+type AuthMethodUnlinkedEvent struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	AuthMethodID uuid.UUID `json:"auth_method_id"`
+	Provider     string    `json:"provider"`
+}
+
+func (AuthMethodUnlinkedEvent) Name() string { return NameAuthMethodUnlinked }
+
+type PasswordResetRequestedEvent struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	Email        string    `json:"email"`
+	Code         string    `json:"code"`
+	ExpiresIn    int       `json:"expires_in"`
+	Organization string    `json:"organization,omitempty"`
+}
+
+func (PasswordResetRequestedEvent) Name() string { return NamePasswordResetRequested }
+
+type PasswordChangedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+}
+
+func (PasswordChangedEvent) Name() string { return NamePasswordChanged }
+
+type MagicLinkRequestedEvent struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	Email        string    `json:"email"`
+	Token        string    `json:"token"`
+	ExpiresIn    int       `json:"expires_in"`
+	Organization string    `json:"organization,omitempty"`
+}
+
+func (MagicLinkRequestedEvent) Name() string { return NameMagicLinkRequested }
+
+type MFAEnabledEvent struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	FactorID   uuid.UUID `json:"factor_id"`
+	FactorType string    `json:"factor_type"`
+}
+
+func (MFAEnabledEvent) Name() string { return NameMFAEnabled }
+
+type MFADisabledEvent struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	FactorID   uuid.UUID `json:"factor_id"`
+	FactorType string    `json:"factor_type"`
+}
+
+func (MFADisabledEvent) Name() string { return NameMFADisabled }
+
+type RecoveryCodesGeneratedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Count     int       `json:"count"`
+}
+
+func (RecoveryCodesGeneratedEvent) Name() string { return NameRecoveryCodesGenerated }
+
+type RecoveryCodeUsedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Remaining int       `json:"remaining"`
+}
+
+func (RecoveryCodeUsedEvent) Name() string { return NameRecoveryCodeUsed }
+
+type PasskeyRegisteredEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	PasskeyID uuid.UUID `json:"passkey_id"`
+}
+
+func (PasskeyRegisteredEvent) Name() string { return NamePasskeyRegistered }
+
+type PasskeyRemovedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	PasskeyID uuid.UUID `json:"passkey_id"`
+}
+
+func (PasskeyRemovedEvent) Name() string { return NamePasskeyRemoved }
+
+type APIKeyCreatedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	APIKeyID  uuid.UUID `json:"api_key_id"`
+}
+
+func (APIKeyCreatedEvent) Name() string { return NameAPIKeyCreated }
+
+type APIKeyRevokedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	APIKeyID  uuid.UUID `json:"api_key_id"`
+}
+
+func (APIKeyRevokedEvent) Name() string { return NameAPIKeyRevoked }
+
+type AuthMethodLockedEvent struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	AuthMethodID   uuid.UUID `json:"auth_method_id"`
+	Provider       string    `json:"provider"`
+	FailedAttempts int       `json:"failed_attempts"`
+	LockedFor      int       `json:"locked_for"`
+}
+
+func (AuthMethodLockedEvent) Name() string { return NameAuthMethodLocked }
+
+// PasswordBreachedEvent reports a password found in a data breach that was
+// accepted because breached passwords only raise a warning.
+type PasswordBreachedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+}
+
+func (PasswordBreachedEvent) Name() string { return NamePasswordBreached }
+
+// AccountProvisionedEvent reports an account created by a provisioning
+// client rather than by its user.
+type AccountProvisionedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+}
+
+func (AccountProvisionedEvent) Name() string { return NameAccountProvisioned }
+
+type AccountStatusChangedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Previous  string    `json:"previous"`
+	Status    string    `json:"status"`
+}
+
+func (AccountStatusChangedEvent) Name() string { return NameAccountStatusChanged }
+
+type AccountRoleChangedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Previous  string    `json:"previous"`
+	Role      string    `json:"role"`
+	ChangedBy uuid.UUID `json:"changed_by"`
+}
+
+func (AccountRoleChangedEvent) Name() string { return NameAccountRoleChanged }
+
+type InvitationCreatedEvent struct {
+	InvitationID     uuid.UUID `json:"invitation_id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	Organization     string    `json:"organization"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	Token            string    `json:"token"`
+	ExpiresIn        int       `json:"expires_in"`
+	InvitedBy        uuid.UUID `json:"invited_by"`
+}
+
+func (InvitationCreatedEvent) Name() string { return NameInvitationCreated }
+
+type InvitationAcceptedEvent struct {
+	InvitationID   uuid.UUID `json:"invitation_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	AccountID      uuid.UUID `json:"account_id"`
+	Role           string    `json:"role"`
+}
+
+func (InvitationAcceptedEvent) Name() string { return NameInvitationAccepted }
+
+type ImpersonationStartedEvent struct {
+	ImpersonationID uuid.UUID `json:"impersonation_id"`
+	AccountID       uuid.UUID `json:"account_id"`
+	AdminID         uuid.UUID `json:"admin_id"`
+	Reason          string    `json:"reason"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+func (ImpersonationStartedEvent) Name() string { return NameImpersonationStarted }
+
+type ImpersonationEndedEvent struct {
+	ImpersonationID uuid.UUID `json:"impersonation_id"`
+	AccountID       uuid.UUID `json:"account_id"`
+	EndedBy         uuid.UUID `json:"ended_by"`
+}
+
+func (ImpersonationEndedEvent) Name() string { return NameImpersonationEnded }
+
+// AccountVerifiedEvent reports an account activated by confirming the email
+// address it registered with.
+type AccountVerifiedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+}
+
+func (AccountVerifiedEvent) Name() string { return NameAccountVerified }
+
+// LoginFailedEvent reports a failed attempt to sign in to an account, with
+// the provider or second factor it used. Attempts on unknown accounts are
+// only audited.
+type LoginFailedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Provider  string    `json:"provider,omitempty"`
+	Factor    string    `json:"factor,omitempty"`
+	Reason    string    `json:"reason"`
+}
+
+func (LoginFailedEvent) Name() string { return NameLoginFailed }
+
+// LoginSucceededEvent reports a session opened by a completed login, with
+// the provider it authenticated with when it went through one.
+type LoginSucceededEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	Provider  string    `json:"provider,omitempty"`
+}
+
+func (LoginSucceededEvent) Name() string { return NameLoginSucceeded }
+
+// SessionRevokedEvent reports sessions ended before they expired: the
+// session SessionID, or every session of the account when it is nil.
+type SessionRevokedEvent struct {
+	AccountID uuid.UUID  `json:"account_id"`
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+	Reason    string     `json:"reason"`
+}
+
+func (SessionRevokedEvent) Name() string { return NameSessionRevoked }
+
+// AccountExpiredEvent reports a PENDING account deleted before it was ever
+// verified, and the address it registered with.
+type AccountExpiredEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+}
+
+func (AccountExpiredEvent) Name() string { return NameAccountExpired }
+
+// AccountDeletedEvent reports an account deleted by its holder, which can
+// be restored until PurgeAt.
+type AccountDeletedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email,omitempty"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+func (AccountDeletedEvent) Name() string { return NameAccountDeleted }
+
+// AccountRestoreRequestedEvent carries the code that restores a deleted
+// account within its grace period.
+type AccountRestoreRequestedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	Code      string    `json:"code"`
+	ExpiresIn int       `json:"expires_in"`
+}
+
+func (AccountRestoreRequestedEvent) Name() string { return NameAccountRestoreRequested }
+
+// AccountPurgedEvent reports an account deleted for good at the end of its
+// grace period, so other services can erase what they hold about it.
+type AccountPurgedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+}
+
+func (AccountPurgedEvent) Name() string { return NameAccountPurged }
+
+// AccountErasedEvent reports an account whose personal data was anonymized
+// on its holder's or an administrator's request, so other services erase
+// what they hold about it.
+type AccountErasedEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+}
+
+func (AccountErasedEvent) Name() string { return NameAccountErased }
+
+// DataExportReadyEvent carries the signed link that downloads the archive
+// of a data export until it expires.
+type DataExportReadyEvent struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	ExportID  uuid.UUID `json:"export_id"`
+	Link      string    `json:"link"`
+	ExpiresIn int       `json:"expires_in"`
+}
+
+func (DataExportReadyEvent) Name() string { return NameDataExportReady }
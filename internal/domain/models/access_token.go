@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessToken is a stored access token of the opaque format. The token
+// itself is random, so its claims are kept here, encoded as the payload of
+// the equivalent JWT. Only the hash of the token is stored.
+type AccessToken struct {
+	ID        uuid.UUID
+	TokenHash string
+	Claims    []byte
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AccessTokenClaims struct {
+	TokenID string
+	// AccountID is uuid.Nil on client tokens, which a client obtains for
+	// itself with the client credentials grant.
+	AccountID  uuid.UUID
+	RoleCode   domain.Role
+	StatusCode domain.Status
+	Scope      domain.TokenScope
+	// Permissions are those of the account's role when the token was
+	// issued, or now for API keys.
+	Permissions []string
+	// SessionID is the session the access token was issued for, or uuid.Nil
+	// for tokens that do not belong to a session.
+	SessionID uuid.UUID
+	// OrganizationID is the active organization of the session, or uuid.Nil
+	// for tokens issued outside of any, and OrganizationRole the account's
+	// role in it.
+	OrganizationID   uuid.UUID
+	OrganizationRole domain.Role
+	// AuthTime, AMR and ACR are only set on elevated tokens issued by a
+	// step-up reauthentication.
+	AuthTime *time.Time
+	AMR      []string
+	ACR      string
+	// ClientID is the client a client token was issued to.
+	ClientID string
+	// Actor is set on delegated tokens issued by a token exchange and on
+	// impersonation tokens.
+	Actor *Actor
+	// KeyThumbprint is the cnf.jkt claim of tokens bound to a DPoP key.
+	KeyThumbprint string
+	// APIKeyID is set on claims resolved from an API key rather than a
+	// token. ExpiresAt is zero for keys that never expire.
+	APIKeyID  uuid.UUID
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Actor identifies the party acting on behalf of the subject of a delegated
+// token, following the act claim of RFC 8693 section 4.1. ClientID is set
+// when the actor is a client, and Impersonator when it is an administrator
+// impersonating the subject.
+type Actor struct {
+	Subject      string
+	ClientID     string
+	Impersonator bool
+}
+
+// HasAccount reports whether the token was issued for an account, rather
+// than to a client acting on its own behalf.
+func (c *AccessTokenClaims) HasAccount() bool {
+	return c.AccountID != uuid.Nil
+}
+
+// Impersonated reports whether the token was issued to an administrator
+// impersonating the account.
+func (c *AccessTokenClaims) Impersonated() bool {
+	return c.Actor != nil && c.Actor.Impersonator
+}
+
+// AuthenticatedWithin reports whether the user reauthenticated no longer than
+// maxAge before now. Tokens without an auth time never qualify.
+func (c *AccessTokenClaims) AuthenticatedWithin(maxAge time.Duration, now time.Time) bool {
+	return c.AuthTime != nil && !c.AuthTime.Add(maxAge).Before(now)
+}
+
+// AccessTokenOptions tunes a single access token.
+type AccessTokenOptions struct {
+	Scope     domain.TokenScope
+	SessionID uuid.UUID
+	// TTL replaces the configured lifetime when positive.
+	TTL      time.Duration
+	AuthTime *time.Time
+	AMR      []string
+	ACR      string
+	// Audience replaces the configured audience when set, for tokens issued
+	// by a token exchange to a downstream service.
+	Audience string
+	// ClientID names the client that requested the token, if any.
+	ClientID string
+	Actor    *Actor
+	// KeyThumbprint binds the token to a DPoP key.
+	KeyThumbprint string
+	// NotAfter caps the expiry when set, so an exchanged token never
+	// outlives the token it was exchanged for.
+	NotAfter time.Time
+	// Permissions are those of the account's role.
+	Permissions []string
+	// OrganizationID and OrganizationRole set the organization context of
+	// the token, when the session has an active organization.
+	OrganizationID   uuid.UUID
+	OrganizationRole domain.Role
+}
+
+// ClientTokenOptions tunes a client token.
+type ClientTokenOptions struct {
+	// Scope is the space separated scope granted to the client.
+	Scope         string
+	KeyThumbprint string
+}
@@ -13,3 +13,27 @@ type Account struct {
 	StatusCode domain.Status
 	CreatedAt  time.Time
 }
+
+// AccountCursor is a position in the order accounts are listed in: by
+// creation time, then id. The zero cursor comes before every account.
+type AccountCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// AccountFilter narrows an account search. Zero fields match every account.
+// CreatedFrom is inclusive and CreatedBefore exclusive; Email matches the
+// addresses of EMAIL methods that contain it.
+type AccountFilter struct {
+	Role          domain.Role
+	Status        domain.Status
+	Provider      domain.Provider
+	CreatedFrom   time.Time
+	CreatedBefore time.Time
+	Email         string
+}
+
+// Cursor returns the position of the account in that order.
+func (a *Account) Cursor() AccountCursor {
+	return AccountCursor{CreatedAt: a.CreatedAt, ID: a.ID}
+}
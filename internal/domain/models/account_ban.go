@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AccountBan records an administrator banning an account and the ban being
+// lifted. Bans stay recorded after they are lifted, as an audit trail.
+type AccountBan struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	// PreviousStatus is the status the account returns to when the ban is
+	// lifted.
+	PreviousStatus domain.Status
+	Reason         string
+	// BannedBy and LiftedBy are the administrators who banned the account
+	// and lifted the ban. LiftedBy is nil on bans lifted by their expiry.
+	BannedBy *uuid.UUID
+	// ExpiresAt is when the ban is lifted by itself, nil for bans that only
+	// an administrator lifts.
+	ExpiresAt  *time.Time
+	LiftedAt   *time.Time
+	LiftedBy   *uuid.UUID
+	LiftReason string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletion records an account holder deleting their account. The
+// account stays DELETED, and can be restored, until PurgeAt, when it is
+// deleted for good along with the record.
+type AccountDeletion struct {
+	AccountID uuid.UUID
+	PurgeAt   time.Time
+	CreatedAt time.Time
+}
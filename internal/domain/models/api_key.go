@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a long-lived credential an account holder creates so that
+// integrations can call Ranco APIs without an interactive login. Only the
+// hash of the key is stored, along with its first characters so keys can be
+// told apart.
+type APIKey struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	Name       string
+	KeyHash    string
+	KeyPrefix  string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Usable reports whether the key is neither revoked nor expired at now.
+func (k *APIKey) Usable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AuditEvent is an entry of the append-only log of security-relevant
+// actions. ActorID is the account that acted, nil for attempts by someone
+// not signed in, and TargetID the account acted on, nil when it is unknown,
+// such as on a login with an unregistered address.
+type AuditEvent struct {
+	ID        uuid.UUID
+	Action    domain.AuditAction
+	ActorID   *uuid.UUID
+	TargetID  *uuid.UUID
+	IPAddress *string
+	UserAgent *string
+	// Details describe the action, such as the provider of a login or the
+	// reason of a ban.
+	Details   map[string]string
+	CreatedAt time.Time
+}
+
+// AuditEventCursor is a position in the order audit events are listed in:
+// newest first, by creation time, then id. The zero cursor comes before
+// every event.
+type AuditEventCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// AuditEventFilter narrows an audit log search. Zero fields match every
+// event. CreatedFrom is inclusive and CreatedBefore exclusive; Actions
+// matches events of any of them.
+type AuditEventFilter struct {
+	ActorID       uuid.UUID
+	TargetID      uuid.UUID
+	Actions       []domain.AuditAction
+	CreatedFrom   time.Time
+	CreatedBefore time.Time
+}
+
+// Cursor returns the position of the event in that order.
+func (e *AuditEvent) Cursor() AuditEventCursor {
+	return AuditEventCursor{CreatedAt: e.CreatedAt, ID: e.ID}
+}
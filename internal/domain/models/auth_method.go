@@ -14,4 +14,13 @@ type AuthMethod struct {
 	ProviderID   string
 	IsVerified   bool
 	LastLoginAt  *time.Time
+	// FailedAttempts counts the failed sign-in attempts since the last
+	// successful one; LockedUntil is set while the method is locked out.
+	FailedAttempts int
+	LockedUntil    *time.Time
+}
+
+// LockedAt reports whether the method refuses sign-in attempts at t.
+func (m *AuthMethod) LockedAt(t time.Time) bool {
+	return m.LockedUntil != nil && t.Before(*m.LockedUntil)
 }
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthorizationCode is issued by the authorization endpoint and exchanged
+// once for tokens. Only the hash of the code is stored.
+type AuthorizationCode struct {
+	ID          uuid.UUID
+	CodeHash    string
+	ClientID    uuid.UUID
+	AccountID   uuid.UUID
+	RedirectURI string
+	Scope       string
+	Nonce       *string
+	// CodeChallenge and CodeChallengeMethod are the PKCE parameters of the
+	// authorization request, when it had them.
+	CodeChallenge       *string
+	CodeChallengeMethod *string
+	AuthTime            time.Time
+	ExpiresAt           time.Time
+	ConsumedAt          *time.Time
+	CreatedAt           time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BrowserSession backs the session cookie of the authorization endpoint. It
+// is tied to the session it was created from and ends with it. Only the hash
+// of the cookie token is stored.
+type BrowserSession struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	SessionID uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Consent records an account accepting a version of a legal document, and
+// from where. Kind and Version are those of the document, read back with
+// the record.
+type Consent struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	DocumentID uuid.UUID
+	Kind       domain.LegalDocumentKind
+	Version    string
+	IPAddress  *string
+	UserAgent  *string
+	AcceptedAt time.Time
+}
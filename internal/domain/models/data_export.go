@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DataExport is an account holder's request for a copy of their data. It is
+// PENDING until the archive is generated, then READY and downloadable until
+// ExpiresAt, when it is deleted.
+type DataExport struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Status      domain.DataExportStatus
+	Archive     []byte
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
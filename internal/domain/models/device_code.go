@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DeviceCode is a device authorization request of RFC 8628. The device polls
+// the token endpoint with the device code while the user approves the request
+// elsewhere by entering the user code. Only the hashes of both codes are
+// stored.
+type DeviceCode struct {
+	ID             uuid.UUID
+	DeviceCodeHash string
+	UserCodeHash   string
+	ClientID       uuid.UUID
+	Scope          string
+	Status         domain.DeviceCodeStatus
+	// AccountID and AuthTime are set when the request is approved or denied.
+	AccountID    *uuid.UUID
+	AuthTime     *time.Time
+	Interval     time.Duration
+	LastPolledAt *time.Time
+	ExpiresAt    time.Time
+	ConsumedAt   *time.Time
+	CreatedAt    time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// DPoPProof holds the claims of a DPoP proof JWT (RFC 9449) whose signature
+// was verified with the public key in its header.
+type DPoPProof struct {
+	TokenID string
+	Method  string
+	URI     string
+	// AccessTokenHash is the ath claim, sent with proofs for protected
+	// resources.
+	AccessTokenHash string
+	IssuedAt        time.Time
+	// KeyThumbprint is the RFC 7638 JWK thumbprint of the proof key, the
+	// jkt that tokens are bound to.
+	KeyThumbprint string
+}
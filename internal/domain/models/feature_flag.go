@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// FeatureFlag turns a gated behavior on for every account when Enabled.
+// Otherwise it is on for the members of Organizations and for Percentage
+// percent of the other accounts, picked by hashing the account ID.
+type FeatureFlag struct {
+	Key           domain.Feature
+	Enabled       bool
+	Percentage    int
+	Organizations []uuid.UUID
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDTokenOptions describes the authentication an OpenID Connect ID token
+// asserts to a client.
+type IDTokenOptions struct {
+	// Audience is the client ID of the relying party.
+	Audience  string
+	Nonce     string
+	SessionID uuid.UUID
+	AuthTime  time.Time
+	// Email is included with its verification state when the client asked
+	// for the email scope.
+	Email         string
+	EmailVerified bool
+}
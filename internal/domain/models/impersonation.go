@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Impersonation records an administrator acting as an account through a
+// short-lived access token, as an audit trail. TokenID is the jti of that
+// token.
+type Impersonation struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	// AdminID is nil once the administrator's account is deleted.
+	AdminID   *uuid.UUID
+	Reason    string
+	TokenID   string
+	IPAddress *string
+	UserAgent *string
+	ExpiresAt time.Time
+	EndedAt   *time.Time
+	CreatedAt time.Time
+}
+
+// Active reports whether the impersonation token is still usable at now.
+func (i *Impersonation) Active(now time.Time) bool {
+	return i.EndedAt == nil && now.Before(i.ExpiresAt)
+}
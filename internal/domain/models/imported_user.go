@@ -0,0 +1,10 @@
+package models
+
+// ImportedUser is a user exported from another identity provider.
+type ImportedUser struct {
+	Email         string
+	EmailVerified bool
+	// PasswordHash is the encoded hash of the user's password, empty for
+	// users who signed in without one.
+	PasswordHash string
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// LegalDocument is a published version of the terms of service or the
+// privacy policy, whose text lives at URL. The version of each kind
+// published last is the current one, which accounts must have accepted.
+type LegalDocument struct {
+	ID          uuid.UUID
+	Kind        domain.LegalDocumentKind
+	Version     string
+	URL         string
+	PublishedAt time.Time
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFAChallenge is issued after a successful primary login when the account
+// has a confirmed factor. Only the hash of the challenge token is stored.
+type MFAChallenge struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	TokenHash string
+	Attempts  int
+	// RememberMe carries the choice made at the primary login to the session
+	// opened once the challenge is completed.
+	RememberMe bool
+	// Scope carries the scope requested at the primary login likewise.
+	Scope      string
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFACode is a one-time code delivered out of band for an MFA factor, such as
+// an SMS. Like verification codes, only its hash is stored.
+type MFACode struct {
+	ID         uuid.UUID
+	FactorID   uuid.UUID
+	CodeHash   string
+	Attempts   int
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
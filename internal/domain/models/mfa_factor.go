@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MFAFactor is a second factor enrolled by an account. A factor only guards
+// logins once ConfirmedAt is set. Secret is encrypted at rest.
+type MFAFactor struct {
+	ID           uuid.UUID
+	AccountID    uuid.UUID
+	FactorType   domain.MFAFactorType
+	Secret       []byte
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+	CreatedAt    time.Time
+	// FailedAttempts counts the failed codes checked outside an MFA
+	// challenge since the last accepted one; LockedUntil is set while the
+	// factor is locked out.
+	FailedAttempts int
+	LockedUntil    *time.Time
+}
+
+// LockedAt reports whether the factor refuses codes at t.
+func (f *MFAFactor) LockedAt(t time.Time) bool {
+	return f.LockedUntil != nil && t.Before(*f.LockedUntil)
+}
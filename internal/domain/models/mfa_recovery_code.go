@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFARecoveryCode is a single-use code that completes an MFA challenge when
+// the account's factors are unavailable. Only its hash is stored.
+type MFARecoveryCode struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	CodeHash   string
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
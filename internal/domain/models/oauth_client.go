@@ -0,0 +1,51 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthClient is an application registered with the authorization server.
+// Only the hash of its secret is stored. Public clients, such as mobile apps,
+// have no secret and must protect their authorization codes with PKCE.
+type OAuthClient struct {
+	ID           uuid.UUID
+	ClientID     string
+	Name         string
+	SecretHash   *string
+	Public       bool
+	RedirectURIs []string
+	// GrantTypes lists the grants the client may use at the token endpoint.
+	GrantTypes []string
+	// Scopes lists the scopes the client may request with the client
+	// credentials and token exchange grants, and for the sessions it opens
+	// with the authorization code and device grants.
+	Scopes []string
+	// ExchangeAudiences lists the audiences the client may request tokens
+	// for with the token exchange grant.
+	ExchangeAudiences []string
+	// Impersonation lets the client exchange a subject token without an
+	// actor token, receiving a token indistinguishable from the subject's.
+	Impersonation bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// AllowsGrantType reports whether the client may use the grant.
+func (c *OAuthClient) AllowsGrantType(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// AllowsExchangeAudience reports whether the client may exchange tokens for
+// the audience.
+func (c *OAuthClient) AllowsExchangeAudience(audience string) bool {
+	return slices.Contains(c.ExchangeAudiences, audience)
+}
+
+// AllowsRedirectURI reports whether uri exactly matches one of the
+// registered redirect URIs.
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Organization is a customer organization that accounts belong to. Slug is
+// its unique, URL-safe identifier.
+type Organization struct {
+	ID        uuid.UUID
+	Slug      string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Membership makes an account a member of an organization, with a role that
+// applies within the organization only.
+type Membership struct {
+	OrganizationID uuid.UUID
+	AccountID      uuid.UUID
+	RoleCode       domain.Role
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Invitation invites an email address to join an organization with a role.
+// Only the hash of the token emailed to the address is stored.
+type Invitation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Email          string
+	RoleCode       domain.Role
+	TokenHash      string
+	InvitedBy      *uuid.UUID
+	ExpiresAt      time.Time
+	AcceptedAt     *time.Time
+	AcceptedBy     *uuid.UUID
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+// Pending reports whether the invitation can still be accepted at now.
+func (i *Invitation) Pending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// Branding customizes the emails sent on behalf of a white-label
+// organization. Empty fields fall back to the default branding.
+type Branding struct {
+	OrganizationID  uuid.UUID
+	SenderName      string
+	SenderAddress   string
+	LogoURL         string
+	PrimaryColor    string
+	BackgroundColor string
+	FooterText      string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// AuthPolicy tightens the authentication rules of an organization's members
+// beyond the service-wide policies. Zero fields keep the service-wide rule,
+// and an empty AllowedProviders allows every provider.
+type AuthPolicy struct {
+	OrganizationID          uuid.UUID
+	PasswordMinLength       int
+	PasswordRequiredClasses []domain.CharacterClass
+	RequireMFA              bool
+	SessionMaxAge           time.Duration
+	AllowedProviders        []domain.Provider
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
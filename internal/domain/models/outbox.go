@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a domain event stored in the transaction that raised it,
+// until the relay publishes it. Unpublished events are attempted at
+// NextAttemptAt.
+type OutboxEvent struct {
+	ID            uuid.UUID
+	Name          string
+	Payload       []byte
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
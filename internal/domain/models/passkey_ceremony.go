@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// PasskeyCeremony keeps the server side state of a WebAuthn ceremony between
+// its begin and finish steps. AccountID is nil for discoverable logins, where
+// the account is only known from the assertion.
+type PasskeyCeremony struct {
+	ID        uuid.UUID
+	AccountID *uuid.UUID
+	Purpose   domain.PasskeyPurpose
+	State     []byte
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
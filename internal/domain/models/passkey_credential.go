@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasskeyCredential is a WebAuthn public key credential registered by an
+// account. The private key never leaves the authenticator.
+type PasskeyCredential struct {
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	CredentialID    []byte
+	PublicKey       []byte
+	AttestationType string
+	AAGUID          []byte
+	SignCount       uint32
+	Transports      []string
+	UserVerified    bool
+	BackupEligible  bool
+	BackupState     bool
+	Name            *string
+	LastUsedAt      *time.Time
+	CreatedAt       time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordCredential holds the password of an EMAIL auth method. Only the
+// encoded hash, including its algorithm parameters, is stored.
+type PasswordCredential struct {
+	AuthMethodID uuid.UUID
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHistoryEntry is the hash of a password an EMAIL auth method used
+// before, kept to refuse its reuse.
+type PasswordHistoryEntry struct {
+	ID           uuid.UUID
+	AuthMethodID uuid.UUID
+	PasswordHash string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+// PasswordStrength is the estimated resistance of a password to guessing.
+// Score goes from 0, guessable within a thousand attempts, to 4, beyond ten
+// billion. Warning names the weakest pattern found and Suggestions how to do
+// better; both are codes and empty for strong passwords.
+type PasswordStrength struct {
+	Score        int
+	GuessesLog10 float64
+	Warning      string
+	Suggestions  []string
+}
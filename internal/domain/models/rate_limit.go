@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RateLimit allows Limit attempts in any sliding Window. A zero Limit
+// disables it.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Limit > 0
+}
@@ -9,10 +9,25 @@ import (
 type RefreshToken struct {
 	ID        uuid.UUID
 	AccountID uuid.UUID
+	// SessionID links the tokens rotated from the same login.
+	SessionID uuid.UUID
 	TokenHash string
 	IPAddress *string
 	UserAgent *string
-	RevokedAt *time.Time
-	ExpiresAt time.Time
-	CreatedAt time.Time
+	// RememberMe selects the long lifetime, kept across rotations.
+	RememberMe bool
+	// SessionStartedAt is the login that opened the session; rotations keep it.
+	SessionStartedAt time.Time
+	// KeyThumbprint is the JWK thumbprint of the DPoP key the session is
+	// bound to, if any.
+	KeyThumbprint *string
+	// Scope is the space separated scope the session's access tokens are
+	// restricted to, kept across rotations; empty for unrestricted sessions.
+	Scope string
+	// OrganizationID is the active organization of the session, kept across
+	// rotations until the session switches to another one.
+	OrganizationID *uuid.UUID
+	RevokedAt      *time.Time
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+// Role is a role that accounts can be given, with the permissions it grants.
+// System roles, ADMIN and USER, are built in and cannot be deleted.
+type Role struct {
+	Code        domain.Role
+	Description string
+	System      bool
+	// Permissions are sorted.
+	Permissions []string
+	CreatedAt   time.Time
+}
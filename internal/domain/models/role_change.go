@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// RoleChange records an administrator changing the role of an account, as
+// an audit trail.
+type RoleChange struct {
+	ID           uuid.UUID
+	AccountID    uuid.UUID
+	PreviousRole domain.Role
+	Role         domain.Role
+	// ChangedBy is nil once the administrator's account is deleted.
+	ChangedBy *uuid.UUID
+	Reason    string
+	CreatedAt time.Time
+}
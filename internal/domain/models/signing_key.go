@@ -0,0 +1,31 @@
+package models
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SigningKey is a persisted token signing key. PrivateKey holds the
+// encrypted PKCS#8 encoding and is never exposed outside the key manager.
+// Keys of an organization sign the access tokens of its sessions only;
+// platform keys have no OrganizationID.
+type SigningKey struct {
+	ID             string
+	Algorithm      string
+	PrivateKey     []byte
+	OrganizationID *uuid.UUID
+	ActivatesAt    time.Time
+	RetiredAt      *time.Time
+	ExpiresAt      *time.Time
+	CreatedAt      time.Time
+}
+
+// PublicSigningKey is the verification half of a signing key, as published to
+// token consumers.
+type PublicSigningKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrustedDevice lets logins that present its device token skip the MFA
+// challenge until ExpiresAt. Only the hash of the device token is stored.
+type TrustedDevice struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	TokenHash  string
+	IPAddress  *string
+	UserAgent  *string
+	LastUsedAt *time.Time
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
@@ -3,12 +3,14 @@ package models
 import (
 	"time"
 
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
 	"github.com/google/uuid"
 )
 
 type VerificationCode struct {
 	ID           uuid.UUID
 	AuthMethodID uuid.UUID
+	Purpose      domain.CodePurpose
 	CodeHash     string
 	Attempts     int
 	ExpiresAt    time.Time
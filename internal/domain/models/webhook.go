@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// WebhookEndpoint is a URL that receives the webhook events it subscribes
+// to. Secret signs its deliveries and is encrypted at rest.
+type WebhookEndpoint struct {
+	ID          uuid.UUID
+	URL         string
+	Description string
+	Events      []domain.WebhookEvent
+	Secret      []byte
+	IsActive    bool
+	CreatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// WebhookDelivery is an event queued for an endpoint. PENDING deliveries are
+// attempted at NextAttemptAt until they succeed or run out of attempts.
+type WebhookDelivery struct {
+	ID         uuid.UUID
+	EndpointID uuid.UUID
+	// EventID identifies the event, shared by its deliveries to every
+	// endpoint, so receivers can discard events delivered twice.
+	EventID        uuid.UUID
+	Event          domain.WebhookEvent
+	Payload        []byte
+	Status         domain.WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookDeliveryAttempt is the outcome of one attempt to deliver a webhook:
+// the status code of the response, or the error when there was none or it
+// did not succeed.
+type WebhookDeliveryAttempt struct {
+	ID         uuid.UUID
+	DeliveryID uuid.UUID
+	StatusCode *int
+	Error      *string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// AccessTokenDenylist invalidates access tokens before they expire. Entries
+// only need to outlive the tokens they cover.
+type AccessTokenDenylist interface {
+	// DenyToken invalidates the token with the given jti until it expires.
+	DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// DenyAccount invalidates every access token of the account issued at or
+	// before the given time.
+	DenyAccount(ctx context.Context, accountID uuid.UUID, issuedBefore time.Time) error
+	IsDenied(ctx context.Context, claims *models.AccessTokenClaims) (bool, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+
+// AuditStream forwards audit events to an external collector, such as a
+// SIEM, once they are recorded. Send must not fail the action it reports:
+// streams buffer events and deliver them in the background.
+type AuditStream interface {
+	Send(event *models.AuditEvent)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// BreachedPasswordChecker tells whether a password appears in known data
+// breaches.
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// CaptchaVerifier checks the response token a CAPTCHA widget gave a client.
+type CaptchaVerifier interface {
+	// Verify reports whether token is a valid, unused solution. remoteIP is
+	// the address of the client and may be empty.
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// ConfigReloader reloads the configuration and applies the settings that
+// can change while the service runs.
+type ConfigReloader interface {
+	Reload(ctx context.Context) error
+}
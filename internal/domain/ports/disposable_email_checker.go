@@ -0,0 +1,6 @@
+package ports
+
+// DisposableEmailChecker recognizes the domains of throwaway email services.
+type DisposableEmailChecker interface {
+	IsDisposable(domain string) bool
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// DPoPProofParser verifies the signature and structure of DPoP proofs. It
+// does not check what the proof is bound to; callers do.
+type DPoPProofParser interface {
+	ParseProof(proof string) (*models.DPoPProof, error)
+	// Algorithms lists the accepted proof signing algorithms.
+	Algorithms() []string
+}
+
+// ReplayCache remembers single-use identifiers, such as DPoP proof IDs, for
+// as long as they could be replayed.
+type ReplayCache interface {
+	// Claim records key until expiresAt and reports whether it was unseen.
+	Claim(ctx context.Context, key string, expiresAt time.Time) (bool, error)
+}
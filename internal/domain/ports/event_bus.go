@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event events.Event) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+// Location is the approximate place an IP address is registered to. Any
+// field may be empty when the lookup database does not know it.
+type Location struct {
+	City    string
+	Region  string
+	Country string
+}
+
+type GeoLocator interface {
+	// Locate reports false when the address cannot be located, e.g. for
+	// private networks.
+	Locate(ip string) (*Location, bool)
+}
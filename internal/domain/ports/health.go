@@ -0,0 +1,19 @@
+package ports
+
+import "context"
+
+// HealthCheck reports whether a dependency the service needs to serve
+// requests is available.
+type HealthCheck interface {
+	// Name identifies the dependency in readiness reports, such as database.
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// VersionedHealthCheck is a HealthCheck that also reports the version of
+// the dependency it checked, such as the schema version, even when the check
+// fails.
+type VersionedHealthCheck interface {
+	HealthCheck
+	CheckVersion(ctx context.Context) (string, error)
+}
@@ -0,0 +1,7 @@
+package ports
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+
+type KeySet interface {
+	PublicKeys() []models.PublicSigningKey
+}
@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+// LeaderElection picks one instance, among those sharing the database, to run
+// the work that must not run on several at once.
+type LeaderElection interface {
+	// Lead reports whether this instance leads, taking the lead when no
+	// instance holds it. An instance keeps the lead until it resigns or
+	// loses its connection to the database.
+	Lead(ctx context.Context) (bool, error)
+	// Resign gives the lead up, for another instance to take.
+	Resign(ctx context.Context) error
+}
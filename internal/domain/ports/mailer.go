@@ -0,0 +1,18 @@
+package ports
+
+import "context"
+
+type Email struct {
+	// From is the sender, such as "Acme <no-reply@acme.com>"; empty uses
+	// the sender of the mailer.
+	From    string
+	To      string
+	Subject string
+	Body    string
+	// HTML is an HTML alternative of Body, if any.
+	HTML string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+// Metrics counts and times what the service does, for dashboards and alerts.
+// It is called on the request path, so implementations must be cheap and
+// safe for concurrent use, and must not fail the actions they observe.
+type Metrics interface {
+	// LoginAttempted counts a login through provider or with the second
+	// factor, either of which is empty when the login did not use one.
+	LoginAttempted(provider, factor string, result domain.LoginResult)
+	// RefreshCompleted records how long refreshing a session took, and
+	// whether it succeeded.
+	RefreshCompleted(succeeded bool, duration time.Duration)
+	// CodeIssued counts a one-time code or link issued for purpose: a
+	// verification code purpose, or the second factor an MFA code is for.
+	CodeIssued(purpose string)
+	// JobCompleted records how long a run of the scheduled job named job
+	// took, and whether it succeeded.
+	JobCompleted(job string, succeeded bool, duration time.Duration)
+}
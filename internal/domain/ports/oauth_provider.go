@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+// ExternalIdentity is the identity asserted by an OAuth provider after a
+// successful authorization code exchange.
+type ExternalIdentity struct {
+	Provider      domain.Provider
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// OAuthAuthorization carries the per-request secrets of an authorization
+// redirect. State, Nonce and CodeVerifier must be kept by the client side of
+// the flow and presented again on callback.
+type OAuthAuthorization struct {
+	URL          string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+type OAuthProvider interface {
+	Code() domain.Provider
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	Exchange(ctx context.Context, code, nonce, codeVerifier string) (*ExternalIdentity, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// OrganizationKeyManager manages the signing keys dedicated to
+// organizations, which sign the access tokens of their sessions only.
+type OrganizationKeyManager interface {
+	// OrganizationKeys returns the published keys of the organization,
+	// newest first, without their private halves.
+	OrganizationKeys(ctx context.Context, organizationID uuid.UUID) ([]*models.SigningKey, error)
+	RotateOrganizationKey(ctx context.Context, organizationID uuid.UUID) error
+	RevokeOrganizationKeys(ctx context.Context, organizationID uuid.UUID) error
+}
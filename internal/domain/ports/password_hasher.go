@@ -0,0 +1,14 @@
+package ports
+
+type PasswordHasher interface {
+	// Hash returns a self-describing encoding of the password hash.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches an encoding returned by Hash.
+	Verify(password, encoded string) (bool, error)
+	// Supports reports whether Verify can check passwords against encoded,
+	// such as a hash imported from another system.
+	Supports(encoded string) bool
+	// NeedsRehash reports whether an encoding verified by Verify was made
+	// with an older algorithm or settings and should be replaced by Hash.
+	NeedsRehash(encoded string) bool
+}
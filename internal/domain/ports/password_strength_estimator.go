@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+
+// PasswordStrengthEstimator predicts how hard a password is to guess.
+// userInputs are words a guesser would try first, such as the account's
+// email address.
+type PasswordStrengthEstimator interface {
+	Estimate(password string, userInputs []string) *models.PasswordStrength
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// RateLimiter counts attempts per key in a sliding window.
+type RateLimiter interface {
+	// Allow records an attempt under key if limit allows it. Refused
+	// attempts are not recorded; retryAfter says when the next one will be
+	// allowed.
+	Allow(ctx context.Context, key string, limit models.RateLimit) (allowed bool, retryAfter time.Duration, err error)
+}
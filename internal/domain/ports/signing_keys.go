@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// PlatformKeyManager rotates the platform signing keys, which sign every
+// token not signed by an organization's own keys, ahead of their schedule.
+type PlatformKeyManager interface {
+	// PlatformKeys returns the published platform keys, newest first,
+	// without their private halves.
+	PlatformKeys(ctx context.Context) ([]*models.SigningKey, error)
+	// Rotate schedules a new platform key, which starts signing once the
+	// pre-publication period has elapsed.
+	Rotate(ctx context.Context) error
+}
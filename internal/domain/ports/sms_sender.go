@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// SMS is a text message addressed to an E.164 phone number.
+type SMS struct {
+	To   string
+	Body string
+}
+
+type SMSSender interface {
+	Send(ctx context.Context, sms SMS) error
+}
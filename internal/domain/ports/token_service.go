@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+type TokenService interface {
+	GenerateAccessToken(ctx context.Context, account *models.Account, opts models.AccessTokenOptions) (string, *models.AccessTokenClaims, error)
+	ParseAccessToken(ctx context.Context, token string) (*models.AccessTokenClaims, error)
+	// GenerateClientToken issues a token to a client acting on its own
+	// behalf.
+	GenerateClientToken(ctx context.Context, client *models.OAuthClient, opts models.ClientTokenOptions) (string, *models.AccessTokenClaims, error)
+	GenerateIDToken(ctx context.Context, account *models.Account, opts models.IDTokenOptions) (string, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// TxManager makes the repository calls of a multi-step operation atomic.
+type TxManager interface {
+	// WithinTransaction runs fn in a transaction that commits when fn returns
+	// nil and rolls back otherwise. Repositories called with the context fn
+	// receives take part in it. Called within a transaction already, fn joins
+	// that one, so the caller's commit or rollback covers it.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit runs fn once the transaction of ctx commits, and not at all
+	// when it rolls back, with the context the transaction was started from.
+	// Outside transactions it runs fn at once with ctx.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
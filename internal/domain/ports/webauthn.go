@@ -0,0 +1,43 @@
+package ports
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+
+// PasskeyUser is the account a WebAuthn ceremony runs for. Handle is the
+// stable user handle stored by the authenticator.
+type PasskeyUser struct {
+	Handle      []byte
+	Name        string
+	DisplayName string
+	Credentials []*models.PasskeyCredential
+}
+
+// PasskeyCeremony is the first half of a WebAuthn ceremony. Options are the
+// JSON sent to navigator.credentials; State must be kept server side and
+// presented again to finish the ceremony.
+type PasskeyCeremony struct {
+	Options []byte
+	State   []byte
+}
+
+// PasskeyAssertion is the outcome of a successful authentication ceremony.
+type PasskeyAssertion struct {
+	UserHandle   []byte
+	CredentialID []byte
+	SignCount    uint32
+	BackupState  bool
+	CloneWarning bool
+}
+
+// PasskeyUserResolver loads the account behind a user handle during a
+// WebAuthn login.
+type PasskeyUserResolver func(handle []byte) (*PasskeyUser, error)
+
+type WebAuthnService interface {
+	BeginRegistration(user PasskeyUser) (*PasskeyCeremony, error)
+	FinishRegistration(user PasskeyUser, state, response []byte) (*models.PasskeyCredential, error)
+	// BeginLogin starts a discoverable login when user is nil, letting the
+	// authenticator choose the account, and a login restricted to the user's
+	// credentials otherwise.
+	BeginLogin(user *PasskeyUser) (*PasskeyCeremony, error)
+	FinishLogin(state, response []byte, resolve PasskeyUserResolver) (*PasskeyAssertion, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRequest is a webhook payload to deliver to an endpoint, signed with
+// the endpoint's secret.
+type WebhookRequest struct {
+	URL        string
+	DeliveryID uuid.UUID
+	Event      string
+	Payload    []byte
+	Secret     []byte
+}
+
+type WebhookSender interface {
+	// Send posts a webhook and returns the status code of the response, zero
+	// when there was none. Responses other than 2xx are errors.
+	Send(ctx context.Context, request WebhookRequest) (int, error)
+}
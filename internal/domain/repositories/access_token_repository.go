@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+type AccessTokenRepository interface {
+	Create(ctx context.Context, token *models.AccessToken) error
+	// GetByTokenHash returns the token with the hash unless it expired
+	// before now.
+	GetByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*models.AccessToken, error)
+}
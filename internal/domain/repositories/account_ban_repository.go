@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AccountBanRepository interface {
+	Create(ctx context.Context, ban *models.AccountBan) error
+	// GetActive returns the ban of the account that has not been lifted.
+	GetActive(ctx context.Context, accountID uuid.UUID) (*models.AccountBan, error)
+	// ListByAccountID returns every ban of the account, newest first.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AccountBan, error)
+	// ListExpired returns up to limit unlifted bans expired at now, those
+	// that expired first first.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.AccountBan, error)
+	// Lift records the ban as lifted, unless it already is. liftedBy is nil
+	// for expired bans.
+	Lift(ctx context.Context, id uuid.UUID, at time.Time, liftedBy *uuid.UUID, reason string) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AccountDeletionRepository interface {
+	Create(ctx context.Context, deletion *models.AccountDeletion) error
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletion, error)
+	// ListDue returns up to limit deletions whose purge time is at or before
+	// now, those due first first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AccountDeletion, error)
+	// Delete removes the record of a restored account.
+	Delete(ctx context.Context, accountID uuid.UUID) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AccountRepository interface {
+	Create(ctx context.Context, account *models.Account) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error)
+	// GetByIDForUpdate reads the account and locks it until the surrounding
+	// transaction ends, serializing changes to its auth methods.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error)
+	// ListAfter returns up to limit accounts following after, in the order
+	// of AccountCursor.
+	ListAfter(ctx context.Context, after models.AccountCursor, limit int) ([]*models.Account, error)
+	// Search returns up to limit accounts matching filter and following
+	// after, in the order of AccountCursor.
+	Search(ctx context.Context, filter models.AccountFilter, after models.AccountCursor, limit int) ([]*models.Account, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Account, error)
+	// ListIDsByRoleForUpdate returns the ids of the accounts with role and
+	// status and locks them until the surrounding transaction ends.
+	ListIDsByRoleForUpdate(ctx context.Context, role domain.Role, status domain.Status) ([]uuid.UUID, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.Status) error
+	UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Erase anonymizes the personal data held about an account: its provider
+	// ids and invitation emails become domain.ErasedPrefix and the row id,
+	// the IP addresses and user agents of its sessions and audit events are
+	// cleared, as are the addresses in the details of those events, and its
+	// credentials, factors, devices, keys and exports are deleted, with the
+	// webhook deliveries and outbox events about it. The account and the
+	// rows referring to it are kept.
+	Erase(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	// ListByAccountID returns the unrevoked keys of an account, expired ones
+	// included, newest first.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.APIKey, error)
+	CountActive(ctx context.Context, accountID uuid.UUID, now time.Time) (int, error)
+	Revoke(ctx context.Context, id, accountID uuid.UUID, at time.Time) error
+	RecordUse(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// AuditEventRepository stores the audit log, whose entries are never
+// changed once appended but to clear the personal data they record when
+// retention periods end.
+type AuditEventRepository interface {
+	Append(ctx context.Context, event *models.AuditEvent) error
+	// Search returns up to limit events matching filter that follow after,
+	// in the order of AuditEventCursor.
+	Search(ctx context.Context, filter models.AuditEventFilter, after models.AuditEventCursor, limit int) ([]*models.AuditEvent, error)
+	// ClearOrigins clears the IP address and user agent of up to limit events
+	// created before before, and returns how many it cleared.
+	ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error)
+	// DeleteBefore deletes up to limit events created before before, and
+	// returns how many it deleted.
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
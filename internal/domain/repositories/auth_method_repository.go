@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AuthMethodRepository interface {
+	Create(ctx context.Context, method *models.AuthMethod) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AuthMethod, error)
+	GetByProvider(ctx context.Context, provider domain.Provider, providerID string) (*models.AuthMethod, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AuthMethod, error)
+	ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]*models.AuthMethod, error)
+	// ListByProvider pages through the methods of provider whose accounts
+	// are not DELETED, in the order the accounts were created.
+	// CountByProvider counts them.
+	ListByProvider(ctx context.Context, provider domain.Provider, offset, limit int) ([]*models.AuthMethod, error)
+	CountByProvider(ctx context.Context, provider domain.Provider) (int, error)
+	UpdateProviderID(ctx context.Context, id uuid.UUID, providerID string, verified bool) error
+	UpdateVerified(ctx context.Context, id uuid.UUID, verified bool) error
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+	// IncrementFailedAttempts counts a failed sign-in attempt and returns the
+	// new count.
+	IncrementFailedAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	Lock(ctx context.Context, id uuid.UUID, until time.Time) error
+	// ResetFailedAttempts clears the failure count and any lockout.
+	ResetFailedAttempts(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AuthPolicyRepository interface {
+	// Save sets the policy of the organization, replacing any previous one.
+	Save(ctx context.Context, policy *models.AuthPolicy) error
+	Get(ctx context.Context, organizationID uuid.UUID) (*models.AuthPolicy, error)
+	// ListByAccountID returns the policies of the organizations the account
+	// is a member of.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AuthPolicy, error)
+	Delete(ctx context.Context, organizationID uuid.UUID) error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+type AuthProviderRepository interface {
+	// Ensure registers the provider code in the catalog if it is missing.
+	Ensure(ctx context.Context, code domain.Provider, description string) error
+}
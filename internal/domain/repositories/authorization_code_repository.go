@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *models.AuthorizationCode) error
+	GetByCodeHash(ctx context.Context, codeHash string) (*models.AuthorizationCode, error)
+	// Consume marks an unconsumed code as used, or returns ErrNotFound.
+	Consume(ctx context.Context, id uuid.UUID, at time.Time) error
+}
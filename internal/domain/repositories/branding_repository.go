@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type BrandingRepository interface {
+	// Save sets the branding of the organization, replacing any previous one.
+	Save(ctx context.Context, branding *models.Branding) error
+	Get(ctx context.Context, organizationID uuid.UUID) (*models.Branding, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Branding, error)
+	Delete(ctx context.Context, organizationID uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type BrowserSessionRepository interface {
+	Create(ctx context.Context, session *models.BrowserSession) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.BrowserSession, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ConsentRepository interface {
+	// Create fails with domain.ErrConflict when the account has already
+	// accepted the document.
+	Create(ctx context.Context, consent *models.Consent) error
+	// ListByAccountID returns the consents of an account, newest first.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Consent, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type DataExportRepository interface {
+	// Create fails with domain.ErrConflict when the account already has a
+	// PENDING export.
+	Create(ctx context.Context, export *models.DataExport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.DataExport, error)
+	// ListByAccountID returns the exports of an account, newest first,
+	// without their archives.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.DataExport, error)
+	// ListPending returns up to limit PENDING exports, oldest first.
+	ListPending(ctx context.Context, limit int) ([]*models.DataExport, error)
+	// Complete stores the archive of a PENDING export and makes it READY.
+	Complete(ctx context.Context, id uuid.UUID, archive []byte, at, expiresAt time.Time) error
+	// DeleteExpired deletes up to limit exports expired at now and returns
+	// how many it deleted.
+	DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error)
+}
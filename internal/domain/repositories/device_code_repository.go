@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type DeviceCodeRepository interface {
+	Create(ctx context.Context, code *models.DeviceCode) error
+	GetByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*models.DeviceCode, error)
+	// GetPendingByUserCodeHash returns the undecided request of a user code
+	// that is still valid at now.
+	GetPendingByUserCodeHash(ctx context.Context, userCodeHash string, now time.Time) (*models.DeviceCode, error)
+	// Decide records the user's answer to a pending request, or returns
+	// ErrNotFound once it was decided.
+	Decide(ctx context.Context, id uuid.UUID, status domain.DeviceCodeStatus, accountID uuid.UUID, authTime time.Time) error
+	// RecordPoll stores the time of a token request and the interval the
+	// device must wait before the next one.
+	RecordPoll(ctx context.Context, id uuid.UUID, at time.Time, interval time.Duration) error
+	// Consume marks an unconsumed code as used, or returns ErrNotFound.
+	Consume(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, impersonation *models.Impersonation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Impersonation, error)
+	// ListByAccountID returns every impersonation of the account, newest
+	// first.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Impersonation, error)
+	// End marks an active impersonation as ended at the given time. It
+	// fails with domain.ErrNotFound when it has already ended or expired.
+	End(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *models.Invitation) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error)
+	// ListPending returns the invitations of the organization that can still
+	// be accepted at now, newest first.
+	ListPending(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]*models.Invitation, error)
+	// Accept fails with domain.ErrNotFound unless the invitation is pending
+	// at at.
+	Accept(ctx context.Context, id, accountID uuid.UUID, at time.Time) error
+	Revoke(ctx context.Context, id, organizationID uuid.UUID, at time.Time) error
+	// RevokePendingByEmail revokes the invitations of the organization for
+	// email that were neither accepted nor revoked.
+	RevokePendingByEmail(ctx context.Context, organizationID uuid.UUID, email string, at time.Time) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type LegalDocumentRepository interface {
+	// Create fails with domain.ErrConflict when the version of the kind is
+	// already published.
+	Create(ctx context.Context, document *models.LegalDocument) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.LegalDocument, error)
+	// List returns every published version, newest first.
+	List(ctx context.Context) ([]*models.LegalDocument, error)
+	// ListCurrent returns the version of each kind published last, by kind.
+	ListCurrent(ctx context.Context) ([]*models.LegalDocument, error)
+	// ListPendingByAccountID returns the current versions the account has
+	// not accepted, by kind.
+	ListPendingByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.LegalDocument, error)
+}
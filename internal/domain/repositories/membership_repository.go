@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MembershipRepository interface {
+	// Save adds the account to the organization, or changes its role there
+	// if it is a member already.
+	Save(ctx context.Context, membership *models.Membership) error
+	Get(ctx context.Context, organizationID, accountID uuid.UUID) (*models.Membership, error)
+	// ListByOrganizationID returns the members of the organization, oldest
+	// first.
+	ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Membership, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Membership, error)
+	Delete(ctx context.Context, organizationID, accountID uuid.UUID) error
+}
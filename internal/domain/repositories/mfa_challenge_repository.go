@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MFAChallengeRepository interface {
+	Create(ctx context.Context, challenge *models.MFAChallenge) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.MFAChallenge, error)
+	IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
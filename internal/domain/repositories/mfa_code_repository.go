@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MFACodeRepository interface {
+	Create(ctx context.Context, code *models.MFACode) error
+	GetLatestByFactorID(ctx context.Context, factorID uuid.UUID) (*models.MFACode, error)
+	IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error
+	InvalidateActive(ctx context.Context, factorID uuid.UUID, at time.Time) error
+}
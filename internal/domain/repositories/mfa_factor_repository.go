@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MFAFactorRepository interface {
+	Create(ctx context.Context, factor *models.MFAFactor) error
+	GetByAccountAndType(ctx context.Context, accountID uuid.UUID, factorType domain.MFAFactorType) (*models.MFAFactor, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.MFAFactor, error)
+	Confirm(ctx context.Context, id uuid.UUID, step int64, at time.Time) error
+	// UpdateLastUsedStep only moves the step forward and returns ErrNotFound
+	// when the step was already used, so a code cannot be replayed.
+	UpdateLastUsedStep(ctx context.Context, id uuid.UUID, step int64) error
+	// IncrementFailedAttempts counts a failed code and returns the new count.
+	IncrementFailedAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	Lock(ctx context.Context, id uuid.UUID, until time.Time) error
+	// ResetFailedAttempts clears the failure count and any lockout.
+	ResetFailedAttempts(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
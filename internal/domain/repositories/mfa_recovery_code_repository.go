@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MFARecoveryCodeRepository interface {
+	Create(ctx context.Context, code *models.MFARecoveryCode) error
+	Consume(ctx context.Context, accountID uuid.UUID, codeHash string, at time.Time) error
+	CountUnused(ctx context.Context, accountID uuid.UUID) (int, error)
+	DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error
+}
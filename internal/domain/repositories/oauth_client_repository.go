@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OAuthClientRepository interface {
+	// Upsert registers the client, replacing the settings and secret of an
+	// existing client with the same client ID.
+	Upsert(ctx context.Context, client *models.OAuthClient) error
+	GetByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.OAuthClient, error)
+}
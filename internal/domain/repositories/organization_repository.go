@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *models.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	List(ctx context.Context) ([]*models.Organization, error)
+	// ListByAccountID returns the organizations the account is a member of.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Organization, error)
+	UpdateName(ctx context.Context, id uuid.UUID, name string) error
+	// Delete removes the organization with its memberships.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, event *models.OutboxEvent) error
+	// ClaimDue returns up to limit unpublished events due at now, oldest
+	// first, pushing their next attempt back to leaseUntil so no other relay
+	// claims them meanwhile.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.OutboxEvent, error)
+	// MarkPublished counts the attempt that published an event. It fails with
+	// domain.ErrNotFound when the event was already published.
+	MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Reschedule counts a failed attempt and makes the event due again at
+	// next.
+	Reschedule(ctx context.Context, id uuid.UUID, next time.Time, lastError string) error
+	// DeletePublishedBefore deletes the events published before before and
+	// returns how many it deleted.
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
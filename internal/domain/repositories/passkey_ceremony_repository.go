@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PasskeyCeremonyRepository interface {
+	Create(ctx context.Context, ceremony *models.PasskeyCeremony) error
+	// Consume removes and returns the ceremony, so it can only be finished once.
+	Consume(ctx context.Context, id uuid.UUID) (*models.PasskeyCeremony, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PasskeyCredentialRepository interface {
+	Create(ctx context.Context, credential *models.PasskeyCredential) error
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.PasskeyCredential, error)
+	UpdateUsage(ctx context.Context, id uuid.UUID, signCount uint32, backupState bool, at time.Time) error
+	Delete(ctx context.Context, id, accountID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PasswordCredentialRepository interface {
+	Create(ctx context.Context, credential *models.PasswordCredential) error
+	GetByAuthMethodID(ctx context.Context, authMethodID uuid.UUID) (*models.PasswordCredential, error)
+	UpdateHash(ctx context.Context, authMethodID uuid.UUID, passwordHash string, at time.Time) error
+	// Rehash replaces previousHash, a hash of the same password, without
+	// changing when the password was set. It fails with ErrNotFound when the
+	// stored hash is no longer previousHash.
+	Rehash(ctx context.Context, authMethodID uuid.UUID, previousHash, passwordHash string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *models.PasswordHistoryEntry) error
+	// ListRecent returns up to limit entries of the method, newest first.
+	ListRecent(ctx context.Context, authMethodID uuid.UUID, limit int) ([]*models.PasswordHistoryEntry, error)
+	// Prune deletes every entry of the method but the keep newest.
+	Prune(ctx context.Context, authMethodID uuid.UUID, keep int) error
+}
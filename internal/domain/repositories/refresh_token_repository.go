@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	ListActiveByAccountID(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*models.RefreshToken, error)
+	// ListByAccountID returns every token kept for the account, revoked and
+	// expired ones included, newest first.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.RefreshToken, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	RevokeAllByAccountID(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error)
+	// CountActiveSessions counts the sessions of every account with a refresh
+	// token that is neither revoked nor expired at now.
+	CountActiveSessions(ctx context.Context, now time.Time) (int, error)
+	// DeleteStale deletes up to limit tokens that expired or were revoked
+	// before before, and returns how many it deleted.
+	DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error)
+	// ClearOrigins clears the IP address and user agent of up to limit tokens
+	// created before before, and returns how many it cleared.
+	ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error)
+}
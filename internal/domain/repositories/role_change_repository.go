@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type RoleChangeRepository interface {
+	Create(ctx context.Context, change *models.RoleChange) error
+	// ListByAccountID returns every role change of the account, newest
+	// first.
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.RoleChange, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+type RoleRepository interface {
+	// Create stores the role and its permissions.
+	Create(ctx context.Context, role *models.Role) error
+	GetByCode(ctx context.Context, code domain.Role) (*models.Role, error)
+	List(ctx context.Context) ([]*models.Role, error)
+	// Update replaces the description and the permissions of the role.
+	Update(ctx context.Context, role *models.Role) error
+	// Delete removes a role that is not a system role and that no account
+	// has, failing with domain.ErrConflict otherwise.
+	Delete(ctx context.Context, code domain.Role) error
+	// ListPermissions returns the sorted permissions of the role, none for
+	// unknown roles.
+	ListPermissions(ctx context.Context, code domain.Role) ([]string, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key *models.SigningKey) error
+	ListPublished(ctx context.Context, now time.Time) ([]*models.SigningKey, error)
+	Retire(ctx context.Context, id string, retiredAt, expiresAt time.Time) error
+	// ExpireByOrganizationID unpublishes every key of the organization at
+	// at, retiring those still signing.
+	ExpireByOrganizationID(ctx context.Context, organizationID uuid.UUID, at time.Time) error
+	// AcquireRotationLock serializes rotation across instances. It must be
+	// called inside a transaction and is released when that transaction ends.
+	AcquireRotationLock(ctx context.Context) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type TrustedDeviceRepository interface {
+	Create(ctx context.Context, device *models.TrustedDevice) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.TrustedDevice, error)
+	ListActiveByAccountID(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*models.TrustedDevice, error)
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+	Delete(ctx context.Context, id, accountID uuid.UUID) error
+	DeleteAllByAccountID(ctx context.Context, accountID uuid.UUID) (int64, error)
+	// ClearOrigins clears the IP address and user agent of up to limit
+	// devices trusted before before, and returns how many it cleared.
+	ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type VerificationCodeRepository interface {
+	Create(ctx context.Context, code *models.VerificationCode) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.VerificationCode, error)
+	GetLatestByAuthMethodID(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose) (*models.VerificationCode, error)
+	// GetByCodeHash finds a code by its hash. It is meant for high-entropy
+	// secrets, such as magic link tokens, that identify the code on their own.
+	GetByCodeHash(ctx context.Context, purpose domain.CodePurpose, codeHash string) (*models.VerificationCode, error)
+	IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error)
+	MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error
+	InvalidateActive(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, at time.Time) error
+	// CountCreatedSince counts the codes issued to a method for purpose at
+	// or after since.
+	CountCreatedSince(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, since time.Time) (int, error)
+	// DeleteStale deletes up to limit codes that expired or were consumed
+	// before before, and returns how many it deleted.
+	DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type WebhookEndpointRepository interface {
+	Create(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error)
+	List(ctx context.Context) ([]*models.WebhookEndpoint, error)
+	// ListSubscribed returns the active endpoints subscribed to event.
+	ListSubscribed(ctx context.Context, event domain.WebhookEvent) ([]*models.WebhookEndpoint, error)
+	// Update replaces the URL, description, events and activity of an
+	// endpoint.
+	Update(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateSecret(ctx context.Context, id uuid.UUID, secret []byte) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Create queues a delivery, unless its endpoint already has one of the
+	// same event.
+	Create(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error)
+	// ListByEndpointID returns the latest deliveries to an endpoint, newest
+	// first.
+	ListByEndpointID(ctx context.Context, endpointID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+	// ClaimDue returns up to limit PENDING deliveries due at now, pushing
+	// their next attempt back to leaseUntil so no other worker claims them
+	// meanwhile.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// RecordAttempt stores an attempt and the delivery's resulting status,
+	// counting the attempt. It fails with domain.ErrNotFound when the
+	// delivery is no longer PENDING.
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error
+	// Requeue makes a delivery that succeeded or failed PENDING again, due
+	// at at, with every attempt available. It fails with domain.ErrNotFound
+	// when the delivery is already PENDING.
+	Requeue(ctx context.Context, id uuid.UUID, at time.Time) error
+	// ListAttempts returns the attempts of a delivery, oldest first.
+	ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*models.WebhookDeliveryAttempt, error)
+}
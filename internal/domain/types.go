@@ -3,3 +3,21 @@ package domain
 type Role string
 type Status string
 type Provider string
+type CodePurpose string
+type MFAFactorType string
+type PasskeyPurpose string
+type TokenScope string
+type SessionLimitStrategy string
+type DeviceCodeStatus string
+type DisposableEmailAction string
+type BreachedPasswordAction string
+type CharacterClass string
+type AuditAction string
+type WebhookEvent string
+type WebhookDeliveryStatus string
+type SessionRevocationReason string
+type AccountExpiryReason string
+type DataExportStatus string
+type LegalDocumentKind string
+type LoginResult string
+type Feature string
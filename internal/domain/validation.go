@@ -0,0 +1,30 @@
+package domain
+
+import "strings"
+
+// FieldError tells why the value of a request field was refused. Params
+// holds the limits of the rule, such as the minimum length.
+type FieldError struct {
+	Field  string
+	Code   string
+	Params map[string]int
+}
+
+// ValidationError carries every FieldError found in a request. It wraps Err
+// so it is served with the public code of that error.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	codes := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		codes = append(codes, field.Field+": "+field.Code)
+	}
+	return e.Err.Error() + " (" + strings.Join(codes, ", ") + ")"
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
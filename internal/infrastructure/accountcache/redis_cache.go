@@ -0,0 +1,136 @@
+package accountcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/metrics"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "account:"
+
+// RedisCache keeps accounts, and with them their status and role, in Redis
+// for ttl so that lookups by id skip the database. Reads fills it from the
+// repository it wraps; Writes updates it whenever an account changes, so a
+// ban or a role change is seen by every instance once it commits.
+type RedisCache struct {
+	client    *redis.Client
+	txManager ports.TxManager
+	ttl       time.Duration
+	metrics   *metrics.Prometheus
+}
+
+func NewRedisCache(client *redis.Client, txManager ports.TxManager, ttl time.Duration, metrics *metrics.Prometheus) *RedisCache {
+	return &RedisCache{client: client, txManager: txManager, ttl: ttl, metrics: metrics}
+}
+
+// Reads serves GetByID of accounts from the cache. It must only be read from
+// outside transactions, as an account read within one could be cached before
+// its transaction rolls back.
+func (c *RedisCache) Reads(accounts repositories.AccountRepository) repositories.AccountRepository {
+	return &readThrough{AccountRepository: accounts, cache: c}
+}
+
+// Writes writes every change of accounts through to the cache.
+func (c *RedisCache) Writes(accounts repositories.AccountRepository) repositories.AccountRepository {
+	return &writeThrough{AccountRepository: accounts, cache: c}
+}
+
+type readThrough struct {
+	repositories.AccountRepository
+	cache *RedisCache
+}
+
+// GetByID falls back to the repository when Redis fails, like on a miss. A
+// miss is filled with SET NX, so it never replaces the entry a write stored
+// meanwhile, which may come from a fresher read than a replica's.
+func (r *readThrough) GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	raw, err := r.cache.client.Get(ctx, keyPrefix+id.String()).Bytes()
+	switch {
+	case err == nil:
+		var account models.Account
+		if err := json.Unmarshal(raw, &account); err == nil {
+			r.cache.metrics.AccountCacheLookup("hit")
+			return &account, nil
+		}
+		r.cache.metrics.AccountCacheLookup("error")
+	case errors.Is(err, redis.Nil):
+		r.cache.metrics.AccountCacheLookup("miss")
+	default:
+		r.cache.metrics.AccountCacheLookup("error")
+		slog.WarnContext(ctx, "read account cache", "account_id", id, "error", err)
+	}
+
+	account, err := r.AccountRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if raw, err := json.Marshal(account); err == nil {
+		if err := r.cache.client.SetNX(ctx, keyPrefix+id.String(), raw, r.cache.ttl).Err(); err != nil {
+			slog.WarnContext(ctx, "fill account cache", "account_id", id, "error", err)
+		}
+	}
+	return account, nil
+}
+
+type writeThrough struct {
+	repositories.AccountRepository
+	cache *RedisCache
+}
+
+func (r *writeThrough) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.Status) error {
+	if err := r.AccountRepository.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+	r.changed(ctx, id)
+	return nil
+}
+
+func (r *writeThrough) UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) error {
+	if err := r.AccountRepository.UpdateRole(ctx, id, role); err != nil {
+		return err
+	}
+	r.changed(ctx, id)
+	return nil
+}
+
+func (r *writeThrough) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.AccountRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.changed(ctx, id)
+	return nil
+}
+
+// changed drops the entry at once, so it is not served while the change
+// commits, and stores the account as committed afterwards. Failures are
+// logged rather than failing the change: the entry then expires with ttl.
+func (r *writeThrough) changed(ctx context.Context, id uuid.UUID) {
+	key := keyPrefix + id.String()
+	if err := r.cache.client.Del(ctx, key).Err(); err != nil {
+		slog.ErrorContext(ctx, "drop account cache entry", "account_id", id, "error", err)
+	}
+	r.cache.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		account, err := r.AccountRepository.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			err = r.cache.client.Del(ctx, key).Err()
+		} else if err == nil {
+			var raw []byte
+			if raw, err = json.Marshal(account); err == nil {
+				err = r.cache.client.Set(ctx, key, raw, r.cache.ttl).Err()
+			}
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "write account cache entry", "account_id", id, "error", err)
+		}
+	})
+}
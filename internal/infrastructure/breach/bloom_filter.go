@@ -0,0 +1,122 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// bloomMagic starts the files written by BloomFilter.WriteTo: the magic, the
+// number of bits and of hash functions as big-endian integers, then the bits.
+const bloomMagic = "RBF1"
+
+// maxBloomBits keeps corrupt headers from allocating unbounded memory.
+const maxBloomBits = 1 << 36
+
+// BloomFilter checks passwords offline against a set of breached SHA-1
+// hashes, such as the Pwned Passwords download, for deployments that cannot
+// reach the range API. It reports no false negatives and false positives at
+// the rate it was sized for.
+type BloomFilter struct {
+	bits   []byte
+	size   uint64
+	hashes uint32
+}
+
+// NewBloomFilter sizes an empty filter for entries hashes at the given false
+// positive rate.
+func NewBloomFilter(entries uint64, falsePositiveRate float64) *BloomFilter {
+	n := math.Max(float64(entries), 1)
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint32(math.Max(1, math.Round(float64(size)/n*math.Ln2)))
+	return &BloomFilter{bits: make([]byte, (size+7)/8), size: size, hashes: hashes}
+}
+
+// LoadBloomFilter reads a filter written by WriteTo from path.
+func LoadBloomFilter(path string) (*BloomFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadBloomFilter(bufio.NewReader(file))
+}
+
+func ReadBloomFilter(r io.Reader) (*BloomFilter, error) {
+	header := make([]byte, len(bloomMagic)+12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("bloom filter: %w", err)
+	}
+	if string(header[:len(bloomMagic)]) != bloomMagic {
+		return nil, errors.New("bloom filter: unknown file format")
+	}
+	size := binary.BigEndian.Uint64(header[len(bloomMagic):])
+	hashes := binary.BigEndian.Uint32(header[len(bloomMagic)+8:])
+	if size == 0 || size > maxBloomBits || hashes == 0 {
+		return nil, errors.New("bloom filter: invalid header")
+	}
+
+	filter := &BloomFilter{bits: make([]byte, (size+7)/8), size: size, hashes: hashes}
+	if _, err := io.ReadFull(r, filter.bits); err != nil {
+		return nil, fmt.Errorf("bloom filter: %w", err)
+	}
+	return filter, nil
+}
+
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, 0, len(bloomMagic)+12)
+	header = append(header, bloomMagic...)
+	header = binary.BigEndian.AppendUint64(header, f.size)
+	header = binary.BigEndian.AppendUint32(header, f.hashes)
+
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(f.bits)
+	return int64(n + m), err
+}
+
+// AddHash adds a hex encoded SHA-1 hash, the format of the Pwned Passwords
+// download.
+func (f *BloomFilter) AddHash(hash string) error {
+	sum, err := hex.DecodeString(strings.TrimSpace(hash))
+	if err != nil || len(sum) != sha1.Size {
+		return fmt.Errorf("bloom filter: %q is not a sha-1 hash", hash)
+	}
+	for _, bit := range f.positions([sha1.Size]byte(sum)) {
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+	return nil
+}
+
+func (f *BloomFilter) IsBreached(ctx context.Context, password string) (bool, error) {
+	for _, bit := range f.positions(sha1.Sum([]byte(password))) {
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// positions derives the bits of a hash by double hashing over two halves of
+// the digest, which is uniform enough for a cryptographic hash.
+func (f *BloomFilter) positions(sum [sha1.Size]byte) []uint64 {
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+
+	positions := make([]uint64, f.hashes)
+	for i := range positions {
+		positions[i] = (h1 + uint64(i)*h2) % f.size
+	}
+	return positions
+}
@@ -0,0 +1,28 @@
+package breach
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// Fallback checks passwords with a primary checker and, when it fails, with
+// a secondary one, typically the range API backed by an offline filter.
+type Fallback struct {
+	primary   ports.BreachedPasswordChecker
+	secondary ports.BreachedPasswordChecker
+}
+
+func NewFallback(primary, secondary ports.BreachedPasswordChecker) *Fallback {
+	return &Fallback{primary: primary, secondary: secondary}
+}
+
+func (f *Fallback) IsBreached(ctx context.Context, password string) (bool, error) {
+	breached, err := f.primary.IsBreached(ctx, password)
+	if err == nil {
+		return breached, nil
+	}
+	slog.WarnContext(ctx, "breached password check failed; using fallback", "error", err)
+	return f.secondary.IsBreached(ctx, password)
+}
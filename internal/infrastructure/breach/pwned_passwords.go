@@ -0,0 +1,72 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// PwnedPasswordsURL is the range endpoint of the Have I Been Pwned Pwned
+// Passwords API.
+const PwnedPasswordsURL = "https://api.pwnedpasswords.com/range/"
+
+// PwnedPasswords checks passwords with the k-anonymity range API of Have I
+// Been Pwned: only the first five characters of the SHA-1 of a password leave
+// the service, and the matching suffixes are compared locally.
+type PwnedPasswords struct {
+	rangeURL string
+	client   *http.Client
+}
+
+// NewPwnedPasswords returns a checker querying rangeURL, PwnedPasswordsURL or
+// a mirror serving the same API.
+func NewPwnedPasswords(rangeURL string) *PwnedPasswords {
+	return &PwnedPasswords{
+		rangeURL: strings.TrimSuffix(rangeURL, "/") + "/",
+		client:   &http.Client{Timeout: 5 * time.Second, Transport: otelhttp.NewTransport(http.DefaultTransport)},
+	}
+}
+
+func (p *PwnedPasswords) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.rangeURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the number of suffixes sharing the prefix from anyone
+	// watching the response size.
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("check pwned passwords: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("check pwned passwords: range api responded %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// Padding entries have a count of zero.
+		if strings.EqualFold(candidate, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("check pwned passwords: %w", err)
+	}
+	return false, nil
+}
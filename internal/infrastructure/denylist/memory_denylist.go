@@ -0,0 +1,75 @@
+package denylist
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// MemoryDenylist keeps the denylist in process memory, so it only covers
+// tokens validated by this instance and is lost on restart. Entries are
+// dropped once every token they cover has expired, ttl being the access
+// token lifetime.
+type MemoryDenylist struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	tokens   map[string]time.Time
+	accounts map[uuid.UUID]time.Time
+}
+
+func NewMemoryDenylist(ttl time.Duration) *MemoryDenylist {
+	return &MemoryDenylist{
+		ttl:      ttl,
+		tokens:   make(map[string]time.Time),
+		accounts: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (d *MemoryDenylist) DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweep(time.Now())
+	d.tokens[tokenID] = expiresAt
+	return nil
+}
+
+// DenyAccount compares whole seconds, the precision of the iat claim, so a
+// token issued in the same second as the denial is denied as well.
+func (d *MemoryDenylist) DenyAccount(ctx context.Context, accountID uuid.UUID, issuedBefore time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweep(time.Now())
+	d.accounts[accountID] = issuedBefore.Truncate(time.Second)
+	return nil
+}
+
+func (d *MemoryDenylist) IsDenied(ctx context.Context, claims *models.AccessTokenClaims) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.tokens[claims.TokenID]; ok {
+		return true, nil
+	}
+	cutoff, ok := d.accounts[claims.AccountID]
+	return ok && !claims.IssuedAt.After(cutoff), nil
+}
+
+// sweep removes the entries whose tokens have all expired.
+func (d *MemoryDenylist) sweep(now time.Time) {
+	for tokenID, expiresAt := range d.tokens {
+		if now.After(expiresAt) {
+			delete(d.tokens, tokenID)
+		}
+	}
+	for accountID, cutoff := range d.accounts {
+		if now.After(cutoff.Add(d.ttl)) {
+			delete(d.accounts, accountID)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package denylist
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	tokenKeyPrefix   = "denylist:token:"
+	accountKeyPrefix = "denylist:account:"
+)
+
+// RedisDenylist shares the denylist between every instance through Redis.
+// Token entries expire with the token; account entries hold the cutoff as
+// Unix seconds and expire once every token issued before it has, ttl being
+// the access token lifetime.
+type RedisDenylist struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisDenylist(client *redis.Client, ttl time.Duration) *RedisDenylist {
+	return &RedisDenylist{client: client, ttl: ttl}
+}
+
+func (d *RedisDenylist) DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return d.client.Set(ctx, tokenKeyPrefix+tokenID, 1, ttl).Err()
+}
+
+func (d *RedisDenylist) DenyAccount(ctx context.Context, accountID uuid.UUID, issuedBefore time.Time) error {
+	ttl := time.Until(issuedBefore.Add(d.ttl))
+	if ttl <= 0 {
+		return nil
+	}
+	return d.client.Set(ctx, accountKeyPrefix+accountID.String(), issuedBefore.Unix(), ttl).Err()
+}
+
+// IsDenied looks both entries up in a single round trip.
+func (d *RedisDenylist) IsDenied(ctx context.Context, claims *models.AccessTokenClaims) (bool, error) {
+	values, err := d.client.MGet(ctx, tokenKeyPrefix+claims.TokenID, accountKeyPrefix+claims.AccountID.String()).Result()
+	if err != nil {
+		return false, err
+	}
+	if values[0] != nil {
+		return true, nil
+	}
+	if values[1] == nil {
+		return false, nil
+	}
+
+	raw, ok := values[1].(string)
+	if !ok {
+		return false, errors.New("denylist: unexpected account entry")
+	}
+	cutoff, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return claims.IssuedAt.Unix() <= cutoff, nil
+}
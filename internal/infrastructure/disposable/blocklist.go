@@ -0,0 +1,171 @@
+package disposable
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed domains.txt
+var defaultDomains string
+
+// maxListBytes bounds the domain lists read from a source.
+const maxListBytes = 16 << 20
+
+type Config struct {
+	// Source is the URL or file path of a domain list replacing the embedded
+	// one, in the same format: one domain per line, # starting comments.
+	Source string
+	// ReloadInterval is how often Source is read again.
+	ReloadInterval time.Duration
+	// Allow lists domains never treated as disposable, and Deny domains
+	// always treated as such, whatever the list says.
+	Allow []string
+	Deny  []string
+}
+
+// Blocklist tells disposable email domains apart by matching them and their
+// parent domains against a list, the embedded one unless a source is
+// configured.
+type Blocklist struct {
+	config Config
+	client *http.Client
+	allow  map[string]struct{}
+	deny   map[string]struct{}
+
+	mu      sync.RWMutex
+	domains map[string]struct{}
+}
+
+func NewBlocklist(config Config) *Blocklist {
+	domains, _ := parseDomains(strings.NewReader(defaultDomains))
+	allow, _ := parseDomains(strings.NewReader(strings.Join(config.Allow, "\n")))
+	deny, _ := parseDomains(strings.NewReader(strings.Join(config.Deny, "\n")))
+	return &Blocklist{
+		config:  config,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		allow:   allow,
+		deny:    deny,
+		domains: domains,
+	}
+}
+
+// Start loads the configured source synchronously, so a broken source is
+// noticed at startup, then keeps reloading it in the background until ctx is
+// cancelled. A failed reload keeps the list loaded last. Without a source the
+// embedded list is used and Start does nothing.
+func (b *Blocklist) Start(ctx context.Context) error {
+	if b.config.Source == "" {
+		return nil
+	}
+	if err := b.reload(ctx); err != nil {
+		return err
+	}
+	if b.config.ReloadInterval <= 0 {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(b.config.ReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := b.reload(ctx); err != nil {
+					slog.ErrorContext(ctx, "reload disposable email domains", "error", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// IsDisposable reports whether domain or one of its parent domains is
+// disposable.
+func (b *Blocklist) IsDisposable(domain string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for name := strings.ToLower(domain); name != ""; {
+		if _, ok := b.allow[name]; ok {
+			return false
+		}
+		if _, ok := b.deny[name]; ok {
+			return true
+		}
+		if _, ok := b.domains[name]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(name, ".")
+		if !found {
+			break
+		}
+		name = parent
+	}
+	return false
+}
+
+func (b *Blocklist) reload(ctx context.Context) error {
+	body, err := b.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	domains, err := parseDomains(io.LimitReader(body, maxListBytes))
+	if err != nil {
+		return fmt.Errorf("disposable email domains: %w", err)
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("disposable email domains: %s lists no domains", b.config.Source)
+	}
+
+	b.mu.Lock()
+	b.domains = domains
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *Blocklist) open(ctx context.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(b.config.Source, "http://") && !strings.HasPrefix(b.config.Source, "https://") {
+		return os.Open(b.config.Source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.config.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch disposable email domains: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetch disposable email domains: %s responded %s", b.config.Source, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func parseDomains(r io.Reader) (map[string]struct{}, error) {
+	domains := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.ToLower(strings.TrimSpace(line)); line != "" {
+			domains[strings.TrimPrefix(line, "@")] = struct{}{}
+		}
+	}
+	return domains, scanner.Err()
+}
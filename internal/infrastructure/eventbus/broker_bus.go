@@ -0,0 +1,89 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/google/uuid"
+)
+
+const (
+	// source names the service in envelopes.
+	source = "ranco-auth-service"
+	// publishTimeout bounds how long publishing an event may hold up the
+	// request that raised it.
+	publishTimeout = 5 * time.Second
+)
+
+// The headers messages carry besides their envelope, so brokers and
+// consumers can route them without decoding the body.
+const (
+	TypeHeader          = "Ranco-Event-Type"
+	SchemaVersionHeader = "Ranco-Schema-Version"
+)
+
+// Message is an envelope encoded for a broker. Subject is the type and
+// schema version, such as account.registered.v1, and Key the account the
+// message concerns.
+type Message struct {
+	ID      uuid.UUID
+	Subject string
+	Key     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// Publisher sends messages to a broker.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// BrokerBus publishes the domain events other services of the platform react
+// to, wrapped in versioned envelopes, through a NATS or Kafka publisher.
+// Events without a message are dropped.
+type BrokerBus struct {
+	publisher Publisher
+}
+
+func NewBrokerBus(publisher Publisher) *BrokerBus {
+	return &BrokerBus{publisher: publisher}
+}
+
+func (b *BrokerBus) Publish(ctx context.Context, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, m := range messages(event) {
+		envelope := Envelope{
+			ID:            events.MessageID(ctx, m.kind),
+			Type:          m.kind,
+			SchemaVersion: m.version,
+			Source:        source,
+			Time:          time.Now().UTC(),
+			Data:          m.data,
+		}
+		body, err := json.Marshal(envelope)
+		if err != nil {
+			return err
+		}
+
+		version := strconv.Itoa(m.version)
+		err = b.publisher.Publish(ctx, Message{
+			ID:      envelope.ID,
+			Subject: m.kind + ".v" + version,
+			Key:     m.key.String(),
+			Headers: map[string]string{TypeHeader: m.kind, SchemaVersionHeader: version},
+			Body:    body,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
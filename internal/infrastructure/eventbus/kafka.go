@@ -0,0 +1,75 @@
+package eventbus
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+	// Username and Password authenticate with SASL PLAIN when set.
+	Username string
+	Password string
+	TLS      bool
+}
+
+// KafkaPublisher publishes every message to one topic, keyed by account, so
+// the messages of an account keep their order within a partition. Each write
+// waits for every in-sync replica.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(config KafkaConfig) (*KafkaPublisher, error) {
+	if len(config.Brokers) == 0 || config.Topic == "" {
+		return nil, fmt.Errorf("kafka brokers and topic are required")
+	}
+
+	transport := &kafka.Transport{ClientID: source}
+	if config.TLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if config.Username != "" {
+		transport.SASL = plain.Mechanism{Username: config.Username, Password: config.Password}
+	}
+
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(config.Brokers...),
+			Topic:        config.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// Events are written one at a time, from the request that
+			// raised them, so batches are not waited for.
+			BatchTimeout: time.Millisecond,
+			Transport:    transport,
+		},
+	}, nil
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, msg Message) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(msg.Key),
+		Value:   msg.Body,
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("publish to kafka: %w", err)
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,38 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+)
+
+// LogBus writes events to the standard logger at debug level, one attribute
+// per field, so the codes some events carry are redacted like any other
+// secret.
+type LogBus struct{}
+
+func NewLogBus() *LogBus {
+	return &LogBus{}
+}
+
+func (b *LogBus) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return err
+	}
+
+	data := make([]any, 0, len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		data = append(data, slog.Any(key, fields[key]))
+	}
+	slog.DebugContext(ctx, "event", slog.String("name", event.Name()), slog.Group("data", data...))
+	return nil
+}
@@ -0,0 +1,184 @@
+package eventbus
+
+import (
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/google/uuid"
+)
+
+// Envelope wraps every message published to the broker. Type and
+// SchemaVersion name the schema of Data, so consumers dispatch on both and
+// skip versions they do not know. Fields may be added to a payload within
+// its version; removing, renaming or retyping one publishes a new version.
+type Envelope struct {
+	ID            uuid.UUID `json:"id"`
+	Type          string    `json:"type"`
+	SchemaVersion int       `json:"schema_version"`
+	Source        string    `json:"source"`
+	Time          time.Time `json:"time"`
+	Data          any       `json:"data"`
+}
+
+// The message types published to the broker.
+const (
+	TypeAccountRegistered    = "account.registered"
+	TypeAccountVerified      = "account.verified"
+	TypeAccountStatusChanged = "account.status_changed"
+	TypeLoginSucceeded       = "login.succeeded"
+	TypeLoginFailed          = "login.failed"
+	TypeSessionRevoked       = "session.revoked"
+	TypeAccountExpired       = "account.expired"
+	TypeAccountPurged        = "account.purged"
+	TypeAccountErased        = "account.erased"
+)
+
+// AccountRegisteredV1 reports a new account. Source is registration, oauth
+// or provisioning, and Provider the provider it registered with, if any.
+type AccountRegisteredV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Source    string    `json:"source"`
+}
+
+type AccountVerifiedV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+}
+
+// AccountStatusChangedV1 reports an account moving between statuses, such
+// as ACTIVE to BANNED.
+type AccountStatusChangedV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Previous  string    `json:"previous"`
+	Status    string    `json:"status"`
+}
+
+type LoginSucceededV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	Provider  string    `json:"provider,omitempty"`
+}
+
+// LoginFailedV1 reports a failed login on a known account, with the
+// provider or second factor it used.
+type LoginFailedV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Provider  string    `json:"provider,omitempty"`
+	Factor    string    `json:"factor,omitempty"`
+	Reason    string    `json:"reason"`
+}
+
+// SessionRevokedV1 reports the end of the session SessionID, or of every
+// session of the account when it is absent.
+type SessionRevokedV1 struct {
+	AccountID uuid.UUID  `json:"account_id"`
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+	Reason    string     `json:"reason"`
+}
+
+// AccountExpiredV1 reports a PENDING account deleted unverified, so other
+// services drop what they keep about it. Reason is unverified, or
+// re_registered when its address registered again.
+type AccountExpiredV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+}
+
+// AccountPurgedV1 reports an account deleted for good at the end of the
+// grace period that followed its holder deleting it.
+type AccountPurgedV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+}
+
+// AccountErasedV1 reports an account whose personal data was anonymized.
+// Consumers should erase what they hold about it.
+type AccountErasedV1 struct {
+	AccountID uuid.UUID `json:"account_id"`
+}
+
+// message is a domain event as published. Key is the account it concerns,
+// which keeps the messages of an account in order on partitioned brokers.
+type message struct {
+	kind    string
+	version int
+	key     uuid.UUID
+	data    any
+}
+
+// messages returns the messages published for a domain event, none for
+// events other services are not told about. Payloads are built field by
+// field, so codes and tokens carried by domain events never leave the
+// service.
+func messages(event events.Event) []message {
+	switch e := event.(type) {
+	case events.UserRegisteredEvent:
+		return []message{{TypeAccountRegistered, 1, e.AccountID, AccountRegisteredV1{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Provider:  string(domain.ProviderEmail),
+			Source:    "registration",
+		}}}
+	case events.OAuthUserRegisteredEvent:
+		return []message{{TypeAccountRegistered, 1, e.AccountID, AccountRegisteredV1{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Provider:  e.Provider,
+			Source:    "oauth",
+		}}}
+	case events.AccountProvisionedEvent:
+		return []message{{TypeAccountRegistered, 1, e.AccountID, AccountRegisteredV1{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Source:    "provisioning",
+		}}}
+	case events.AccountVerifiedEvent:
+		return []message{{TypeAccountVerified, 1, e.AccountID, AccountVerifiedV1{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+		}}}
+	case events.AccountStatusChangedEvent:
+		return []message{{TypeAccountStatusChanged, 1, e.AccountID, AccountStatusChangedV1{
+			AccountID: e.AccountID,
+			Previous:  e.Previous,
+			Status:    e.Status,
+		}}}
+	case events.LoginSucceededEvent:
+		return []message{{TypeLoginSucceeded, 1, e.AccountID, LoginSucceededV1{
+			AccountID: e.AccountID,
+			SessionID: e.SessionID,
+			Provider:  e.Provider,
+		}}}
+	case events.LoginFailedEvent:
+		return []message{{TypeLoginFailed, 1, e.AccountID, LoginFailedV1{
+			AccountID: e.AccountID,
+			Provider:  e.Provider,
+			Factor:    e.Factor,
+			Reason:    e.Reason,
+		}}}
+	case events.SessionRevokedEvent:
+		return []message{{TypeSessionRevoked, 1, e.AccountID, SessionRevokedV1{
+			AccountID: e.AccountID,
+			SessionID: e.SessionID,
+			Reason:    e.Reason,
+		}}}
+	case events.AccountExpiredEvent:
+		return []message{{TypeAccountExpired, 1, e.AccountID, AccountExpiredV1{
+			AccountID: e.AccountID,
+			Email:     e.Email,
+			Reason:    e.Reason,
+		}}}
+	case events.AccountPurgedEvent:
+		return []message{{TypeAccountPurged, 1, e.AccountID, AccountPurgedV1{
+			AccountID: e.AccountID,
+		}}}
+	case events.AccountErasedEvent:
+		return []message{{TypeAccountErased, 1, e.AccountID, AccountErasedV1{
+			AccountID: e.AccountID,
+		}}}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// MultiBus publishes every event to each of its buses in turn. A bus that
+// fails does not keep the event from the others; the failures are joined.
+type MultiBus struct {
+	buses []ports.EventBus
+}
+
+func NewMultiBus(buses ...ports.EventBus) *MultiBus {
+	return &MultiBus{buses: buses}
+}
+
+func (b *MultiBus) Publish(ctx context.Context, event events.Event) error {
+	var errs []error
+	for _, bus := range b.buses {
+		if err := bus.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type NATSConfig struct {
+	URL string
+	// SubjectPrefix starts every subject, as in
+	// ranco.auth.account.registered.v1.
+	SubjectPrefix string
+	// JetStream waits for a stream to store each message, deduplicated by
+	// envelope id. Without it messages are published at most once, to the
+	// subscribers connected at the time.
+	JetStream bool
+	// Credentials is the path of a .creds file, when the server requires one.
+	Credentials string
+}
+
+// NATSPublisher publishes messages on subjects of their own, so consumers
+// subscribe to the types and versions they handle, such as
+// ranco.auth.login.*.v1.
+type NATSPublisher struct {
+	conn   *nats.Conn
+	stream jetstream.JetStream
+	prefix string
+}
+
+func NewNATSPublisher(config NATSConfig) (*NATSPublisher, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("nats url is required")
+	}
+
+	options := []nats.Option{
+		nats.Name(source),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	}
+	if config.Credentials != "" {
+		options = append(options, nats.UserCredentials(config.Credentials))
+	}
+	conn, err := nats.Connect(config.URL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("connect nats: %w", err)
+	}
+
+	publisher := &NATSPublisher{conn: conn, prefix: config.SubjectPrefix}
+	if config.JetStream {
+		if publisher.stream, err = jetstream.New(conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("open jetstream: %w", err)
+		}
+	}
+	return publisher, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, msg Message) error {
+	subject := msg.Subject
+	if p.prefix != "" {
+		subject = p.prefix + "." + subject
+	}
+	m := nats.NewMsg(subject)
+	m.Data = msg.Body
+	for key, value := range msg.Headers {
+		m.Header.Set(key, value)
+	}
+
+	if p.stream == nil {
+		if err := p.conn.PublishMsg(m); err != nil {
+			return fmt.Errorf("publish to nats: %w", err)
+		}
+		return nil
+	}
+	if _, err := p.stream.PublishMsg(ctx, m, jetstream.WithMsgID(msg.ID.String())); err != nil {
+		return fmt.Errorf("publish to jetstream: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the messages still buffered and closes the connection.
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,51 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// MaxMindLocator looks addresses up in a MaxMind GeoLite2 or GeoIP2 City
+// database.
+type MaxMindLocator struct {
+	reader *geoip2.Reader
+}
+
+func NewMaxMindLocator(path string) (*MaxMindLocator, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open geoip database: %w", err)
+	}
+	return &MaxMindLocator{reader: reader}, nil
+}
+
+func (l *MaxMindLocator) Locate(ip string) (*ports.Location, bool) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, false
+	}
+
+	record, err := l.reader.City(addr)
+	if err != nil {
+		return nil, false
+	}
+
+	location := &ports.Location{
+		City:    record.City.Names["en"],
+		Country: record.Country.IsoCode,
+	}
+	if len(record.Subdivisions) > 0 {
+		location.Region = record.Subdivisions[0].Names["en"]
+	}
+	if *location == (ports.Location{}) {
+		return nil, false
+	}
+	return location, true
+}
+
+func (l *MaxMindLocator) Close() error {
+	return l.reader.Close()
+}
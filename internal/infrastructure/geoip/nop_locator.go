@@ -0,0 +1,15 @@
+package geoip
+
+import "github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+
+// NopLocator never locates an address. It is used when no GeoIP database is
+// configured, so sessions are listed without a location.
+type NopLocator struct{}
+
+func NewNopLocator() *NopLocator {
+	return &NopLocator{}
+}
+
+func (l *NopLocator) Locate(ip string) (*ports.Location, bool) {
+	return nil, false
+}
@@ -0,0 +1,104 @@
+// Package health checks the dependencies reported by the readiness probe.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/token"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type check struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (c check) Name() string {
+	return c.name
+}
+
+func (c check) Check(ctx context.Context) error {
+	return c.run(ctx)
+}
+
+// Database checks that a connection to the database can be acquired and
+// answers.
+func Database(pool *pgxpool.Pool) ports.HealthCheck {
+	return check{name: "database", run: pool.Ping}
+}
+
+// Replica checks the read replica like Database checks the primary.
+func Replica(pool *pgxpool.Pool) ports.HealthCheck {
+	return check{name: "database_replica", run: pool.Ping}
+}
+
+// Migrations checks that the schema is at version or later and not dirty, as
+// recorded by golang-migrate, and reports the version it is at. Later
+// versions are accepted so a migration can be applied while instances of
+// this build still run.
+func Migrations(pool *pgxpool.Pool, version int64) ports.HealthCheck {
+	return migrationsCheck{pool: pool, version: version}
+}
+
+type migrationsCheck struct {
+	pool    *pgxpool.Pool
+	version int64
+}
+
+func (c migrationsCheck) Name() string {
+	return "migrations"
+}
+
+func (c migrationsCheck) Check(ctx context.Context) error {
+	_, err := c.CheckVersion(ctx)
+	return err
+}
+
+func (c migrationsCheck) CheckVersion(ctx context.Context) (string, error) {
+	var current int64
+	var dirty bool
+	err := c.pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("no migration applied, want version %d", c.version)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	reported := strconv.FormatInt(current, 10)
+	if dirty {
+		return reported, fmt.Errorf("migration %d is dirty", current)
+	}
+	if current < c.version {
+		return reported, fmt.Errorf("schema is at version %d, want %d", current, c.version)
+	}
+	return reported, nil
+}
+
+// Redis checks that Redis answers.
+func Redis(client *redis.Client) ports.HealthCheck {
+	return check{name: "redis", run: func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}}
+}
+
+// SigningKeys checks that the platform key is available to sign tokens.
+func SigningKeys(keys token.KeyStore) ports.HealthCheck {
+	return check{name: "signing_keys", run: func(ctx context.Context) error {
+		key, err := keys.SigningKey(uuid.Nil)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return errors.New("no signing key")
+		}
+		return nil
+	}}
+}
@@ -0,0 +1,79 @@
+package mail
+
+import (
+	htmltemplate "html/template"
+	netmail "net/mail"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// layout renders the HTML alternative of an email, whose text body is
+// split into paragraphs. The paragraph holding the link becomes a button.
+var layout = htmltemplate.Must(htmltemplate.New("").Parse(`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:24px;background-color:{{.Branding.BackgroundColor}};font-family:Helvetica,Arial,sans-serif;color:#1f2933">
+<div style="max-width:560px;margin:0 auto">
+{{- if .Branding.LogoURL}}
+<img src="{{.Branding.LogoURL}}" alt="{{.Branding.SenderName}}" style="max-height:48px;margin-bottom:24px">
+{{- end}}
+{{- range .Paragraphs}}
+{{- if and $.Link (eq . $.Link)}}
+<p><a href="{{.}}" style="display:inline-block;padding:12px 20px;background-color:{{$.Branding.PrimaryColor}};color:#ffffff;text-decoration:none;border-radius:4px">Open link</a></p>
+{{- else}}
+<p style="line-height:1.5">{{.}}</p>
+{{- end}}
+{{- end}}
+{{- if .Branding.FooterText}}
+<p style="margin-top:32px;font-size:12px;color:#6b7280">{{.Branding.FooterText}}</p>
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+type layoutData struct {
+	Branding   models.Branding
+	Paragraphs []string
+	Link       string
+}
+
+func renderHTML(branding models.Branding, body, link string) (string, error) {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	var html strings.Builder
+	err := layout.Execute(&html, layoutData{Branding: branding, Paragraphs: paragraphs, Link: link})
+	return html.String(), err
+}
+
+// sender formats the From header of a branding, empty without an address.
+func sender(branding models.Branding) string {
+	if branding.SenderAddress == "" {
+		return ""
+	}
+	return (&netmail.Address{Name: branding.SenderName, Address: branding.SenderAddress}).String()
+}
+
+// override replaces the fields of branding that custom sets.
+func override(branding *models.Branding, custom *models.Branding) {
+	for _, field := range []struct {
+		value  *string
+		custom string
+	}{
+		{&branding.SenderName, custom.SenderName},
+		{&branding.SenderAddress, custom.SenderAddress},
+		{&branding.LogoURL, custom.LogoURL},
+		{&branding.PrimaryColor, custom.PrimaryColor},
+		{&branding.BackgroundColor, custom.BackgroundColor},
+		{&branding.FooterText, custom.FooterText},
+	} {
+		if field.custom != "" {
+			*field.value = field.custom
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// LogMailer writes emails to the standard logger. It is intended for local
+// development, where no SMTP relay is available, and logs the codes and
+// links the emails carry so they can be used.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, email ports.Email) error {
+	slog.InfoContext(ctx, "email", "from", email.From, "to", email.To, "subject", email.Subject, "body", email.Body)
+	return nil
+}
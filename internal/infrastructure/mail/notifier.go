@@ -0,0 +1,253 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/events"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+// message renders the email sent for an event.
+type message struct {
+	subject string
+	body    *template.Template
+}
+
+var messages = map[string]message{
+	events.NameUserRegistered: {
+		subject: "Confirm your email",
+		body: parseBody(
+			"Your confirmation code is {{.Event.Code}}.\n\nIt expires in {{minutes .Event.ExpiresIn}} minutes.\n"),
+	},
+	events.NameVerificationResent: {
+		subject: "Confirm your email",
+		body: parseBody(
+			"Your confirmation code is {{.Event.Code}}.\n\nIt expires in {{minutes .Event.ExpiresIn}} minutes. Any code sent before no longer works.\n"),
+	},
+	events.NameLoginCodeRequested: {
+		subject: "Your login code",
+		body: parseBody(
+			"Your login code is {{.Event.Code}}.\n\nIt expires in {{minutes .Event.ExpiresIn}} minutes. If you did not try to sign in, you can ignore this email.\n"),
+	},
+	events.NamePasswordResetRequested: {
+		subject: "Reset your password",
+		body: parseBody(
+			"Your password reset code is {{.Event.Code}}.\n\nIt expires in {{minutes .Event.ExpiresIn}} minutes. If you did not ask to reset your password, you can ignore this email.\n"),
+	},
+	events.NameMagicLinkRequested: {
+		subject: "Your sign-in link",
+		body: parseBody(
+			"Sign in by opening this link:\n\n{{.Link}}\n\nIt expires in {{minutes .Event.ExpiresIn}} minutes and works once. If you did not try to sign in, you can ignore this email.\n"),
+	},
+	events.NamePasswordChanged: {
+		subject: "Your password was changed",
+		body: parseBody(
+			"The password of your account was just changed and every session was signed out.\n\nIf this was not you, reset your password immediately.\n"),
+	},
+	events.NameAccountDeleted: {
+		subject: "Your account was deleted",
+		body: parseBody(
+			"Your account was deleted and every session was signed out.\n\nIt will be erased for good on {{date .Event.PurgeAt}}. Until then you can restore it with a code sent to this address. If this was not you, restore your account and reset your password immediately.\n"),
+	},
+	events.NameAccountRestoreRequested: {
+		subject: "Restore your account",
+		body: parseBody(
+			"Your account restore code is {{.Event.Code}}.\n\nIt expires in {{minutes .Event.ExpiresIn}} minutes. If you did not ask to restore your account, you can ignore this email.\n"),
+	},
+	events.NameDataExportReady: {
+		subject: "Your data export is ready",
+		body: parseBody(
+			"The copy of your account data you asked for is ready. Download it by opening this link:\n\n{{.Link}}\n\nIt expires in {{days .Event.ExpiresIn}} days. If you did not ask for a copy of your data, reset your password immediately.\n"),
+	},
+	events.NameInvitationCreated: {
+		subject: "You are invited to join an organization",
+		body: parseBody(
+			"You are invited to join {{.Event.OrganizationName}}. Accept the invitation by opening this link, then signing in or creating an account with this email address:\n\n{{.Link}}\n\nIt expires in {{days .Event.ExpiresIn}} days. If you did not expect this invitation, you can ignore this email.\n"),
+	},
+}
+
+func parseBody(text string) *template.Template {
+	return template.Must(template.New("").Funcs(template.FuncMap{
+		"minutes": func(seconds int) int { return seconds / 60 },
+		"days":    func(seconds int) int { return seconds / 86400 },
+		"date":    func(t time.Time) string { return t.UTC().Format("January 2, 2006") },
+	}).Parse(text))
+}
+
+// templateData is what message bodies are rendered with.
+type templateData struct {
+	Event events.Event
+	Link  string
+}
+
+type NotifierConfig struct {
+	// MagicLinkURL is the client page that completes a magic link sign-in; the
+	// token is appended as the "token" query parameter.
+	MagicLinkURL string
+	// InvitationURL is the client page that accepts an organization
+	// invitation; the token is appended as the "token" query parameter.
+	InvitationURL string
+	// Branding is the default branding of emails, which organizations
+	// override field by field.
+	Branding models.Branding
+}
+
+// Notifier is an EventBus that emails the user for events carrying codes or
+// security notices and forwards every event to the next bus. Emails of
+// events naming an organization use its branding.
+type Notifier struct {
+	mailer    ports.Mailer
+	next      ports.EventBus
+	brandings repositories.BrandingRepository
+	config    NotifierConfig
+}
+
+func NewNotifier(mailer ports.Mailer, next ports.EventBus, brandings repositories.BrandingRepository, config NotifierConfig) *Notifier {
+	return &Notifier{mailer: mailer, next: next, brandings: brandings, config: config}
+}
+
+func (n *Notifier) Publish(ctx context.Context, event events.Event) error {
+	return errors.Join(n.notify(ctx, event), n.next.Publish(ctx, event))
+}
+
+func (n *Notifier) notify(ctx context.Context, event events.Event) error {
+	m, ok := messages[event.Name()]
+	if !ok {
+		return nil
+	}
+
+	to := recipient(event)
+	if to == "" {
+		return nil
+	}
+
+	data := templateData{Event: event}
+	if e, ok := event.(events.MagicLinkRequestedEvent); ok {
+		link, err := withQuery(n.config.MagicLinkURL, "token", e.Token)
+		if err != nil {
+			return fmt.Errorf("build magic link: %w", err)
+		}
+		data.Link = link
+	}
+	if e, ok := event.(events.InvitationCreatedEvent); ok {
+		link, err := withQuery(n.config.InvitationURL, "token", e.Token)
+		if err != nil {
+			return fmt.Errorf("build invitation link: %w", err)
+		}
+		data.Link = link
+	}
+	if e, ok := event.(events.DataExportReadyEvent); ok {
+		data.Link = e.Link
+	}
+
+	var body strings.Builder
+	if err := m.body.Execute(&body, data); err != nil {
+		return fmt.Errorf("render %s email: %w", event.Name(), err)
+	}
+
+	branding := n.branding(ctx, event)
+	html, err := renderHTML(branding, body.String(), data.Link)
+	if err != nil {
+		return fmt.Errorf("render %s email: %w", event.Name(), err)
+	}
+	if branding.FooterText != "" {
+		body.WriteString("\n--\n" + branding.FooterText + "\n")
+	}
+
+	return n.mailer.Send(ctx, ports.Email{
+		From:    sender(branding),
+		To:      to,
+		Subject: m.subject,
+		Body:    body.String(),
+		HTML:    html,
+	})
+}
+
+// branding returns the branding of the organization an event names, if it
+// has one, over the default branding. Emails are sent with the default
+// branding when it cannot be read.
+func (n *Notifier) branding(ctx context.Context, event events.Event) models.Branding {
+	branding := n.config.Branding
+	slug := organization(event)
+	if slug == "" {
+		return branding
+	}
+
+	custom, err := n.brandings.GetBySlug(ctx, slug)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			slog.ErrorContext(ctx, "read branding", "organization", slug, "error", err)
+		}
+		return branding
+	}
+	override(&branding, custom)
+	return branding
+}
+
+func recipient(event events.Event) string {
+	switch e := event.(type) {
+	case events.UserRegisteredEvent:
+		return e.Email
+	case events.VerificationResentEvent:
+		return e.Email
+	case events.LoginCodeRequestedEvent:
+		return e.Email
+	case events.PasswordResetRequestedEvent:
+		return e.Email
+	case events.PasswordChangedEvent:
+		return e.Email
+	case events.MagicLinkRequestedEvent:
+		return e.Email
+	case events.InvitationCreatedEvent:
+		return e.Email
+	case events.AccountDeletedEvent:
+		return e.Email
+	case events.AccountRestoreRequestedEvent:
+		return e.Email
+	case events.DataExportReadyEvent:
+		return e.Email
+	}
+	return ""
+}
+
+// organization returns the slug of the organization an event names, if any.
+func organization(event events.Event) string {
+	switch e := event.(type) {
+	case events.UserRegisteredEvent:
+		return e.Organization
+	case events.LoginCodeRequestedEvent:
+		return e.Organization
+	case events.PasswordResetRequestedEvent:
+		return e.Organization
+	case events.MagicLinkRequestedEvent:
+		return e.Organization
+	case events.InvitationCreatedEvent:
+		return e.Organization
+	}
+	return ""
+}
+
+func withQuery(rawURL, key, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if !u.IsAbs() {
+		return "", fmt.Errorf("%q is not an absolute URL", rawURL)
+	}
+
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,102 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer delivers emails through an SMTP relay, upgrading to TLS with
+// STARTTLS when the server offers it. Emails with an HTML alternative are
+// sent as multipart/alternative. The envelope sender is always From of the
+// config, whatever the From header of an email.
+type SMTPMailer struct {
+	config SMTPConfig
+	auth   smtp.Auth
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return &SMTPMailer{config: config, auth: auth}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, email ports.Email) error {
+	if strings.ContainsAny(email.To, "\r\n") {
+		return fmt.Errorf("invalid recipient %q", email.To)
+	}
+	from := m.config.From
+	if email.From != "" {
+		if strings.ContainsAny(email.From, "\r\n") {
+			return fmt.Errorf("invalid sender %q", email.From)
+		}
+		from = email.From
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", email.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	if email.HTML == "" {
+		msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		msg.WriteString("\r\n")
+		msg.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	} else if err := writeAlternative(&msg, email); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, m.auth, m.config.From, []string{email.To}, []byte(msg.String()))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// writeAlternative writes the Content-Type header and the body of an email
+// with an HTML alternative, plain text first.
+func writeAlternative(msg *strings.Builder, email ports.Email) error {
+	parts := multipart.NewWriter(msg)
+	fmt.Fprintf(msg, "Content-Type: multipart/alternative; boundary=%q\r\n", parts.Boundary())
+	msg.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", email.Body},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(strings.ReplaceAll(part.body, "\n", "\r\n"))); err != nil {
+			return err
+		}
+	}
+	return parts.Close()
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "auth"
+
+// Prometheus records the metrics of the service in a registry of its own,
+// along with those of the Go runtime and the process, and serves them in the
+// Prometheus text format.
+type Prometheus struct {
+	registry       *prometheus.Registry
+	logins         *prometheus.CounterVec
+	refreshes      *prometheus.HistogramVec
+	codes          *prometheus.CounterVec
+	tokens         *prometheus.CounterVec
+	activeSessions prometheus.Gauge
+	queries        *prometheus.HistogramVec
+	accountCache   *prometheus.CounterVec
+	jobs           *prometheus.HistogramVec
+	jobSuccesses   *prometheus.GaugeVec
+}
+
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Logins by provider, second factor and result.",
+		}, []string{"provider", "factor", "result"}),
+		refreshes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "refresh_duration_seconds",
+			Help:      "Time taken to rotate a refresh token, by result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"result"}),
+		codes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "codes_issued_total",
+			Help:      "Verification codes and links issued, by purpose.",
+		}, []string{"purpose"}),
+		tokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tokens_issued_total",
+			Help:      "Signed tokens issued, by kind.",
+		}, []string{"kind"}),
+		activeSessions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_sessions",
+			Help:      "Sessions with a refresh token that is neither revoked nor expired.",
+		}),
+		queries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Time taken by repository queries, by query and result.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"query", "result"}),
+		accountCache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "account_cache_lookups_total",
+			Help:      "Account lookups by id served from the cache, by result: hit, miss or error.",
+		}, []string{"result"}),
+		jobs: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_duration_seconds",
+			Help:      "Time taken by runs of the scheduled maintenance jobs, by job and result.",
+			Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+		}, []string{"job", "result"}),
+		jobSuccesses: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "job_last_success_timestamp_seconds",
+			Help:      "Unix time the scheduled maintenance job last succeeded at, by job.",
+		}, []string{"job"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.logins,
+		p.refreshes,
+		p.codes,
+		p.tokens,
+		p.activeSessions,
+		p.queries,
+		p.accountCache,
+		p.jobs,
+		p.jobSuccesses,
+	)
+	return p
+}
+
+func (p *Prometheus) LoginAttempted(provider, factor string, result domain.LoginResult) {
+	p.logins.WithLabelValues(provider, factor, string(result)).Inc()
+}
+
+func (p *Prometheus) RefreshCompleted(succeeded bool, duration time.Duration) {
+	p.refreshes.WithLabelValues(outcome(succeeded)).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) CodeIssued(purpose string) {
+	p.codes.WithLabelValues(purpose).Inc()
+}
+
+func (p *Prometheus) JobCompleted(job string, succeeded bool, duration time.Duration) {
+	p.jobs.WithLabelValues(job, outcome(succeeded)).Observe(duration.Seconds())
+	if succeeded {
+		p.jobSuccesses.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
+// SetActiveSessions records the number of active sessions, which is counted
+// periodically rather than tracked as sessions open and close.
+func (p *Prometheus) SetActiveSessions(count int) {
+	p.activeSessions.Set(float64(count))
+}
+
+// AccountCacheLookup counts a lookup of the account cache.
+func (p *Prometheus) AccountCacheLookup(result string) {
+	p.accountCache.WithLabelValues(result).Inc()
+}
+
+// Handler serves the metrics to a Prometheus scraper.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+func outcome(succeeded bool) string {
+	if succeeded {
+		return "ok"
+	}
+	return "error"
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name string
+	at   time.Time
+}
+
+// QueryTracer times the queries of a pgx connection pool. Queries are named
+// by the "-- name:" comment sqlc starts them with, and the others are grouped
+// as "other". A query that finds no rows still succeeded.
+type QueryTracer struct {
+	metrics *Prometheus
+}
+
+func NewQueryTracer(metrics *Prometheus) *QueryTracer {
+	return &QueryTracer{metrics: metrics}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: queryName(data.SQL), at: time.Now()})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	succeeded := data.Err == nil || errors.Is(data.Err, pgx.ErrNoRows)
+	t.metrics.queries.WithLabelValues(start.name, outcome(succeeded)).Observe(time.Since(start.at).Seconds())
+}
+
+func queryName(sql string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(sql), "-- name:")
+	if !ok {
+		return "other"
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "other"
+	}
+	return fields[0]
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// TokenService counts the tokens a token service issues successfully.
+type TokenService struct {
+	ports.TokenService
+	metrics *Prometheus
+}
+
+func NewTokenService(tokens ports.TokenService, metrics *Prometheus) *TokenService {
+	return &TokenService{TokenService: tokens, metrics: metrics}
+}
+
+func (s *TokenService) GenerateAccessToken(ctx context.Context, account *models.Account, opts models.AccessTokenOptions) (string, *models.AccessTokenClaims, error) {
+	token, claims, err := s.TokenService.GenerateAccessToken(ctx, account, opts)
+	if err == nil {
+		s.metrics.tokens.WithLabelValues("access").Inc()
+	}
+	return token, claims, err
+}
+
+func (s *TokenService) GenerateClientToken(ctx context.Context, client *models.OAuthClient, opts models.ClientTokenOptions) (string, *models.AccessTokenClaims, error) {
+	token, claims, err := s.TokenService.GenerateClientToken(ctx, client, opts)
+	if err == nil {
+		s.metrics.tokens.WithLabelValues("client").Inc()
+	}
+	return token, claims, err
+}
+
+func (s *TokenService) GenerateIDToken(ctx context.Context, account *models.Account, opts models.IDTokenOptions) (string, error) {
+	token, err := s.TokenService.GenerateIDToken(ctx, account, opts)
+	if err == nil {
+		s.metrics.tokens.WithLabelValues("id").Inc()
+	}
+	return token, err
+}
@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+const discoverySuffix = "/.well-known/openid-configuration"
+
+var providerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,30}$`)
+
+// OIDCConfig describes an operator-defined OpenID Connect issuer such as
+// Keycloak, Okta or Auth0.
+type OIDCConfig struct {
+	// Name identifies the provider in URLs; its upper-cased form becomes the
+	// AuthMethod provider code.
+	Name         string
+	DiscoveryURL string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// ClientSecretFunc works as in oidcSettings.
+	ClientSecretFunc func() (string, error)
+}
+
+// NewOIDCProvider signs users in against any OpenID Connect issuer. The
+// issuer's subject identifier is used as the AuthMethod provider ID.
+func NewOIDCProvider(ctx context.Context, config OIDCConfig) (ports.OAuthProvider, error) {
+	if !providerNamePattern.MatchString(config.Name) {
+		return nil, fmt.Errorf("oidc provider name %q must match %s", config.Name, providerNamePattern)
+	}
+
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"email", "profile"}
+	}
+	if !containsScope(scopes, oidc.ScopeOpenID) {
+		scopes = append([]string{oidc.ScopeOpenID}, scopes...)
+	}
+
+	return newOIDCClient(ctx, oidcSettings{
+		Code:         ProviderCode(config.Name),
+		Issuer:       strings.TrimSuffix(config.DiscoveryURL, discoverySuffix),
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  config.RedirectURL,
+		Scopes:       scopes,
+
+		ClientSecretFunc: config.ClientSecretFunc,
+	})
+}
+
+// ProviderCode derives the catalog code stored for a configured provider name.
+func ProviderCode(name string) domain.Provider {
+	return domain.Provider(strings.ToUpper(name))
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+const githubAPI = "https://api.github.com"
+
+type GithubConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// ClientSecretFunc, when set, is called for the client secret on every
+	// code exchange instead of using ClientSecret, so a rotated secret is
+	// picked up.
+	ClientSecretFunc func() (string, error)
+}
+
+// githubProvider signs users in with GitHub. GitHub is not an OpenID
+// Connect issuer, so the identity is read from its REST API: the numeric user
+// ID becomes the provider ID and the primary email must be verified.
+type githubProvider struct {
+	config       oauth2.Config
+	clientSecret func() (string, error)
+}
+
+func NewGithubProvider(config GithubConfig) ports.OAuthProvider {
+	return &githubProvider{
+		config: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     github.Endpoint,
+			Scopes:       []string{"read:user", "user:email"},
+		},
+		clientSecret: config.ClientSecretFunc,
+	}
+}
+
+func (p *githubProvider) Code() domain.Provider {
+	return domain.ProviderGithub
+}
+
+// AuthCodeURL ignores nonce, which only applies to ID tokens.
+func (p *githubProvider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return p.config.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier))
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*ports.ExternalIdentity, error) {
+	ctx = traced(ctx)
+	config := p.config
+	if p.clientSecret != nil {
+		secret, err := p.clientSecret()
+		if err != nil {
+			return nil, fmt.Errorf("client secret: %w", err)
+		}
+		config.ClientSecret = secret
+	}
+
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	client := config.Client(ctx, token)
+
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err := getJSON(ctx, client, githubAPI+"/user", &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("github user has no id")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, client, githubAPI+"/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			return &ports.ExternalIdentity{
+				Provider:      domain.ProviderGithub,
+				Subject:       strconv.FormatInt(user.ID, 10),
+				Email:         email.Email,
+				EmailVerified: true,
+			}, nil
+		}
+	}
+
+	return nil, errors.New("github account has no verified primary email")
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("get %s: unexpected status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
@@ -0,0 +1,34 @@
+package oauth
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+const googleIssuer = "https://accounts.google.com"
+
+type GoogleConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// ClientSecretFunc works as in oidcSettings.
+	ClientSecretFunc func() (string, error)
+}
+
+// NewGoogleProvider signs users in with Google. The Google subject identifier
+// is used as the AuthMethod provider ID, since email addresses can change.
+func NewGoogleProvider(ctx context.Context, config GoogleConfig) (ports.OAuthProvider, error) {
+	return newOIDCClient(ctx, oidcSettings{
+		Code:         domain.ProviderGoogle,
+		Issuer:       googleIssuer,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  config.RedirectURL,
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+
+		ClientSecretFunc: config.ClientSecretFunc,
+	})
+}
@@ -0,0 +1,88 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+const (
+	microsoftAuthority = "https://login.microsoftonline.com/"
+	// microsoftTenantIssuer is the placeholder issuer published by the
+	// common, organizations and consumers discovery documents.
+	microsoftTenantIssuer = microsoftAuthority + "{tenantid}/v2.0"
+)
+
+var microsoftMultiTenants = map[string]bool{
+	"common":        true,
+	"organizations": true,
+	"consumers":     true,
+}
+
+type MicrosoftConfig struct {
+	// Tenant is "common" (personal and work/school accounts), "organizations",
+	// "consumers" or a tenant ID. Defaults to "common".
+	Tenant       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// ClientSecretFunc works as in oidcSettings.
+	ClientSecretFunc func() (string, error)
+}
+
+// NewMicrosoftProvider signs users in with Microsoft accounts and Entra ID.
+// The object ID (oid) is used as the AuthMethod provider ID, as it is stable
+// across applications; sub is used when the token carries no oid.
+func NewMicrosoftProvider(ctx context.Context, config MicrosoftConfig) (ports.OAuthProvider, error) {
+	tenant := config.Tenant
+	if tenant == "" {
+		tenant = "common"
+	}
+
+	settings := oidcSettings{
+		Code:         domain.ProviderMicrosoft,
+		Issuer:       microsoftAuthority + tenant + "/v2.0",
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  config.RedirectURL,
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+
+		ClientSecretFunc: config.ClientSecretFunc,
+		Subject: func(idToken *oidc.IDToken) (string, error) {
+			return microsoftSubject(idToken, tenant)
+		},
+	}
+	if microsoftMultiTenants[tenant] {
+		settings.DiscoveredIssuer = microsoftTenantIssuer
+	}
+
+	return newOIDCClient(ctx, settings)
+}
+
+// microsoftSubject checks that the token was issued by the tenant it names,
+// which the multi-tenant endpoints leave to the client, and returns its oid.
+func microsoftSubject(idToken *oidc.IDToken, tenant string) (string, error) {
+	var claims struct {
+		TenantID string `json:"tid"`
+		ObjectID string `json:"oid"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return "", fmt.Errorf("decode id_token claims: %w", err)
+	}
+
+	if claims.TenantID == "" || idToken.Issuer != microsoftAuthority+claims.TenantID+"/v2.0" {
+		return "", fmt.Errorf("id_token issuer %q does not match tenant", idToken.Issuer)
+	}
+	if !microsoftMultiTenants[tenant] && claims.TenantID != tenant {
+		return "", errors.New("id_token issued by another tenant")
+	}
+
+	if claims.ObjectID != "" {
+		return claims.ObjectID, nil
+	}
+	return idToken.Subject, nil
+}
@@ -0,0 +1,162 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/oauth2"
+)
+
+// httpClient calls providers, tracing each request as a child of the span
+// that needs it.
+var httpClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+
+// traced makes oauth2 and go-oidc call providers through httpClient.
+func traced(ctx context.Context) context.Context {
+	return oidc.ClientContext(ctx, httpClient)
+}
+
+// oidcClient runs the authorization code flow with PKCE against an OpenID
+// Connect issuer and verifies the returned ID token.
+type oidcClient struct {
+	code         domain.Provider
+	config       oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+	clientSecret func() (string, error)
+	authParams   []oauth2.AuthCodeOption
+	subject      func(*oidc.IDToken) (string, error)
+}
+
+type oidcSettings struct {
+	Code         domain.Provider
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// ClientSecretFunc, when set, is called for the client secret on every
+	// code exchange instead of using ClientSecret, to mint a signed secret
+	// such as Apple's or to pick up a rotated one.
+	ClientSecretFunc func() (string, error)
+	// AuthParams are added to the authorization URL.
+	AuthParams []oauth2.AuthCodeOption
+	// DiscoveredIssuer is the issuer advertised by a multi-tenant discovery
+	// document when it differs from Issuer. The standard issuer check is then
+	// skipped and Subject must validate the issuer instead.
+	DiscoveredIssuer string
+	// Subject resolves the provider ID from a verified ID token; the sub claim
+	// is used when unset.
+	Subject func(*oidc.IDToken) (string, error)
+}
+
+func newOIDCClient(ctx context.Context, settings oidcSettings) (*oidcClient, error) {
+	ctx = traced(ctx)
+	if settings.DiscoveredIssuer != "" {
+		ctx = oidc.InsecureIssuerURLContext(ctx, settings.DiscoveredIssuer)
+	}
+	provider, err := oidc.NewProvider(ctx, settings.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s: %w", settings.Issuer, err)
+	}
+
+	return &oidcClient{
+		code: settings.Code,
+		config: oauth2.Config{
+			ClientID:     settings.ClientID,
+			ClientSecret: settings.ClientSecret,
+			RedirectURL:  settings.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       settings.Scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{
+			ClientID:        settings.ClientID,
+			SkipIssuerCheck: settings.DiscoveredIssuer != "",
+		}),
+		clientSecret: settings.ClientSecretFunc,
+		authParams:   settings.AuthParams,
+		subject:      settings.Subject,
+	}, nil
+}
+
+func (c *oidcClient) Code() domain.Provider {
+	return c.code
+}
+
+func (c *oidcClient) AuthCodeURL(state, nonce, codeVerifier string) string {
+	opts := append([]oauth2.AuthCodeOption{oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)}, c.authParams...)
+	return c.config.AuthCodeURL(state, opts...)
+}
+
+func (c *oidcClient) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*ports.ExternalIdentity, error) {
+	ctx = traced(ctx)
+	config := c.config
+	if c.clientSecret != nil {
+		secret, err := c.clientSecret()
+		if err != nil {
+			return nil, fmt.Errorf("client secret: %w", err)
+		}
+		config.ClientSecret = secret
+	}
+
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	idToken, err := c.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	subject := idToken.Subject
+	if c.subject != nil {
+		if subject, err = c.subject(idToken); err != nil {
+			return nil, err
+		}
+	}
+
+	var claims struct {
+		Email         string       `json:"email"`
+		EmailVerified flexibleBool `json:"email_verified"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("decode id_token claims: %w", err)
+	}
+
+	return &ports.ExternalIdentity{
+		Provider:      c.code,
+		Subject:       subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+	}, nil
+}
+
+// flexibleBool accepts both JSON booleans and the quoted "true"/"false"
+// strings some issuers emit for email_verified.
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", `"true"`:
+		*b = true
+	case "false", `"false"`, "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package passkey
+
+import (
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// user adapts ports.PasskeyUser to the webauthn.User interface.
+type user struct {
+	handle      []byte
+	name        string
+	displayName string
+	credentials []webauthn.Credential
+}
+
+func newUser(u *ports.PasskeyUser) *user {
+	credentials := make([]webauthn.Credential, 0, len(u.Credentials))
+	for _, c := range u.Credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(c.Transports))
+		for _, transport := range c.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              c.CredentialID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				UserPresent:    true,
+				UserVerified:   c.UserVerified,
+				BackupEligible: c.BackupEligible,
+				BackupState:    c.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    c.AAGUID,
+				SignCount: c.SignCount,
+			},
+		})
+	}
+
+	return &user{
+		handle:      u.Handle,
+		name:        u.Name,
+		displayName: u.DisplayName,
+		credentials: credentials,
+	}
+}
+
+func (u *user) WebAuthnID() []byte {
+	return u.handle
+}
+
+func (u *user) WebAuthnName() string {
+	return u.name
+}
+
+func (u *user) WebAuthnDisplayName() string {
+	return u.displayName
+}
+
+func (u *user) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
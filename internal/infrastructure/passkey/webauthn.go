@@ -0,0 +1,179 @@
+package passkey
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// Config identifies the relying party. RPID is the effective domain shared by
+// every origin allowed to run ceremonies, e.g. example.com for
+// https://app.example.com.
+type Config struct {
+	RPID          string
+	RPDisplayName string
+	RPOrigins     []string
+}
+
+// WebAuthnService runs WebAuthn ceremonies with go-webauthn. Registrations
+// ask for discoverable credentials so they can later be used without typing
+// an email address.
+type WebAuthnService struct {
+	webauthn *webauthn.WebAuthn
+}
+
+func NewWebAuthnService(cfg Config) (*WebAuthnService, error) {
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.RPID,
+		RPDisplayName: cfg.RPDisplayName,
+		RPOrigins:     cfg.RPOrigins,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("configure webauthn: %w", err)
+	}
+	return &WebAuthnService{webauthn: w}, nil
+}
+
+func (s *WebAuthnService) BeginRegistration(user ports.PasskeyUser) (*ports.PasskeyCeremony, error) {
+	u := newUser(&user)
+	creation, session, err := s.webauthn.BeginRegistration(u,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExclusions(webauthn.Credentials(u.credentials).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newCeremony(creation, session)
+}
+
+func (s *WebAuthnService) FinishRegistration(user ports.PasskeyUser, state, response []byte) (*models.PasskeyCredential, error) {
+	session, err := decodeSession(state)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.webauthn.CreateCredential(newUser(&user), *session, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PasskeyCredential{
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		Transports:      transportNames(credential.Transport),
+		UserVerified:    credential.Flags.UserVerified,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}, nil
+}
+
+// BeginLogin requires user verification for discoverable logins, where the
+// passkey is the only factor, and merely prefers it when the passkey is a
+// second factor.
+func (s *WebAuthnService) BeginLogin(user *ports.PasskeyUser) (*ports.PasskeyCeremony, error) {
+	var (
+		assertion *protocol.CredentialAssertion
+		session   *webauthn.SessionData
+		err       error
+	)
+	if user == nil {
+		assertion, session, err = s.webauthn.BeginDiscoverableLogin(
+			webauthn.WithUserVerification(protocol.VerificationRequired),
+		)
+	} else {
+		assertion, session, err = s.webauthn.BeginLogin(newUser(user),
+			webauthn.WithUserVerification(protocol.VerificationPreferred),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newCeremony(assertion, session)
+}
+
+func (s *WebAuthnService) FinishLogin(state, response []byte, resolve ports.PasskeyUserResolver) (*ports.PasskeyAssertion, error) {
+	session, err := decodeSession(state)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		owner      webauthn.User
+		credential *webauthn.Credential
+	)
+	if len(session.UserID) > 0 {
+		passkeyUser, err := resolve(session.UserID)
+		if err != nil {
+			return nil, err
+		}
+		owner = newUser(passkeyUser)
+		credential, err = s.webauthn.ValidateLogin(owner, *session, parsed)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		owner, credential, err = s.webauthn.ValidatePasskeyLogin(func(_, handle []byte) (webauthn.User, error) {
+			passkeyUser, err := resolve(handle)
+			if err != nil {
+				return nil, err
+			}
+			return newUser(passkeyUser), nil
+		}, *session, parsed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ports.PasskeyAssertion{
+		UserHandle:   owner.WebAuthnID(),
+		CredentialID: credential.ID,
+		SignCount:    credential.Authenticator.SignCount,
+		BackupState:  credential.Flags.BackupState,
+		CloneWarning: credential.Authenticator.CloneWarning,
+	}, nil
+}
+
+func newCeremony(options any, session *webauthn.SessionData) (*ports.PasskeyCeremony, error) {
+	encodedOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	state, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.PasskeyCeremony{Options: encodedOptions, State: state}, nil
+}
+
+func decodeSession(state []byte) (*webauthn.SessionData, error) {
+	var session webauthn.SessionData
+	if err := json.Unmarshal(state, &session); err != nil {
+		return nil, fmt.Errorf("decode webauthn session: %w", err)
+	}
+	return &session, nil
+}
+
+func transportNames(transports []protocol.AuthenticatorTransport) []string {
+	names := make([]string, 0, len(transports))
+	for _, transport := range transports {
+		names = append(names, string(transport))
+	}
+	return names
+}
@@ -0,0 +1,79 @@
+package pepper
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// VaultConfig locates the pepper in a Vault KV version 2 secrets engine.
+// The versions of the secret are the versions of the pepper, so rotating it
+// is writing a new version.
+type VaultConfig struct {
+	// Mount is the path the KV engine is mounted at, e.g. secret.
+	Mount string
+	// Path is the path of the secret within the mount.
+	Path string
+	// Field is the secret field holding the base64 encoded pepper.
+	Field string
+}
+
+// Vault reads the versions of the pepper from Vault. The client is
+// configured from the standard environment, VAULT_ADDR and VAULT_TOKEN
+// among others.
+type Vault struct {
+	kv    *vault.KVv2
+	path  string
+	field string
+}
+
+func NewVault(config VaultConfig) (*Vault, error) {
+	if config.Mount == "" || config.Path == "" || config.Field == "" {
+		return nil, errors.New("vault pepper mount, path and field are required")
+	}
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("create vault client: %w", err)
+	}
+	return &Vault{kv: client.KVv2(config.Mount), path: config.Path, field: config.Field}, nil
+}
+
+// Load returns every readable version of the pepper and the current one.
+// Deleted and destroyed versions are left out: hashes made with them no
+// longer verify, which is how a leaked pepper is retired.
+func (v *Vault) Load(ctx context.Context) (int, map[int][]byte, error) {
+	metadata, err := v.kv.GetMetadata(ctx, v.path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read pepper metadata: %w", err)
+	}
+
+	keys := make(map[int][]byte, len(metadata.Versions))
+	for name, version := range metadata.Versions {
+		if version.Destroyed || !version.DeletionTime.IsZero() {
+			continue
+		}
+		number, err := strconv.Atoi(name)
+		if err != nil {
+			return 0, nil, fmt.Errorf("pepper version %q: %w", name, err)
+		}
+
+		secret, err := v.kv.GetVersion(ctx, v.path, number)
+		if err != nil {
+			return 0, nil, fmt.Errorf("read pepper version %d: %w", number, err)
+		}
+		encoded, ok := secret.Data[v.field].(string)
+		if !ok {
+			return 0, nil, fmt.Errorf("pepper version %d has no %s field", number, v.field)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return 0, nil, fmt.Errorf("decode pepper version %d: %w", number, err)
+		}
+		keys[number] = key
+	}
+	return metadata.CurrentVersion, keys, nil
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// MemoryLimiter counts attempts in process memory, so each instance applies
+// the limits on its own. Keys without recent attempts are swept on each
+// call.
+type MemoryLimiter struct {
+	mu       sync.Mutex
+	attempts map[string][]time.Time
+	windows  map[string]time.Duration
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		attempts: make(map[string][]time.Time),
+		windows:  make(map[string]time.Duration),
+	}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit models.RateLimit) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for attempted, times := range l.attempts {
+		if now.Sub(times[len(times)-1]) >= l.windows[attempted] {
+			delete(l.attempts, attempted)
+			delete(l.windows, attempted)
+		}
+	}
+
+	times := l.attempts[key]
+	start := 0
+	for start < len(times) && now.Sub(times[start]) >= limit.Window {
+		start++
+	}
+	times = times[start:]
+
+	if len(times) >= limit.Limit {
+		l.attempts[key] = times
+		return false, times[len(times)-limit.Limit].Add(limit.Window).Sub(now), nil
+	}
+
+	l.attempts[key] = append(times, now)
+	l.windows[key] = limit.Window
+	return true, 0, nil
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+// slidingWindow keeps the attempts of a key in a sorted set scored by time
+// in milliseconds. It drops those older than the window, then records the
+// attempt if fewer than the limit remain, or returns how long until the
+// oldest one that counts leaves the window.
+var slidingWindow = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+if count < limit then
+  redis.call('ZADD', KEYS[1], now, ARGV[4])
+  redis.call('PEXPIRE', KEYS[1], window)
+  return 0
+end
+local oldest = redis.call('ZRANGE', KEYS[1], count - limit, count - limit, 'WITHSCORES')
+return math.max(1, tonumber(oldest[2]) + window - now)
+`)
+
+// RedisLimiter shares attempt counts between every instance through Redis.
+// The script runs atomically, so concurrent attempts cannot exceed a limit.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit models.RateLimit) (bool, time.Duration, error) {
+	retryAfter, err := slidingWindow.Run(ctx, l.client, []string{keyPrefix + key},
+		time.Now().UnixMilli(), limit.Window.Milliseconds(), limit.Limit, uuid.NewString(),
+	).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+	if retryAfter > 0 {
+		return false, time.Duration(retryAfter) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,37 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryCache keeps claimed keys in process memory, so replays are only
+// caught when they reach the same instance. Expired keys are swept on each
+// claim.
+type MemoryCache struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{keys: make(map[string]time.Time)}
+}
+
+func (c *MemoryCache) Claim(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for claimed, until := range c.keys {
+		if now.After(until) {
+			delete(c.keys, claimed)
+		}
+	}
+
+	if _, ok := c.keys[key]; ok {
+		return false, nil
+	}
+	c.keys[key] = expiresAt
+	return true, nil
+}
@@ -0,0 +1,30 @@
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "replay:"
+
+// RedisCache shares claimed keys between every instance through Redis. Keys
+// expire on their own once they can no longer be replayed.
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Claim relies on SET NX, so concurrent claims of the same key on different
+// instances cannot both succeed.
+func (c *RedisCache) Claim(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+	return c.client.SetNX(ctx, keyPrefix+key, 1, ttl).Result()
+}
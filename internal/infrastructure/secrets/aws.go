@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// AWS reads secrets from AWS Secrets Manager. Credentials and the region
+// come from the standard chain: AWS_REGION, AWS_ACCESS_KEY_ID, shared
+// profiles and instance or pod roles.
+type AWS struct {
+	client *secretsmanager.Client
+}
+
+func NewAWS(ctx context.Context) (*AWS, error) {
+	config, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load aws configuration: %w", err)
+	}
+	return &AWS{client: secretsmanager.NewFromConfig(config)}, nil
+}
+
+// Fetch returns the current version of the secret. Secrets Manager has no
+// leases, so the cache decides how long it is kept.
+func (a *AWS) Fetch(ctx context.Context, ref Reference) (Secret, error) {
+	output, err := a.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(ref.Path)})
+	if err != nil {
+		return Secret{}, fmt.Errorf("read %s: %w", ref, err)
+	}
+	if output.SecretString == nil {
+		return Secret{}, fmt.Errorf("%s is not a string secret", ref.Path)
+	}
+	if ref.Field == "" {
+		return Secret{Value: *output.SecretString}, nil
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(*output.SecretString), &fields); err != nil {
+		return Secret{}, fmt.Errorf("%s is not a JSON object of strings: %w", ref.Path, err)
+	}
+	value, ok := fields[ref.Field]
+	if !ok {
+		return Secret{}, fmt.Errorf("%s has no %s field", ref.Path, ref.Field)
+	}
+	return Secret{Value: value}, nil
+}
@@ -0,0 +1,216 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// fetchTimeout bounds each request to a backend.
+	fetchTimeout = 10 * time.Second
+	// retryDelay is how long a failed re-fetch waits before the next
+	// attempt, while the previous value keeps being served.
+	retryDelay = 30 * time.Second
+)
+
+// Cache resolves settings that may be secret references, keeping fetched
+// secrets until their lease expires, or for the default TTL when the backend
+// sets none. Settings holding plain values resolve to themselves.
+//
+// Secrets that are watched are re-fetched by Run as they expire and handed
+// to their watchers when they changed, so a rotated secret is picked up
+// without a restart. Others are re-fetched by the first Get after expiry.
+// Either way, a failed re-fetch keeps the previous value.
+type Cache struct {
+	backends map[string]Backend
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[Reference]*entry
+	wake    chan struct{}
+}
+
+type entry struct {
+	value    string
+	expires  time.Time
+	watchers []func(string) error
+}
+
+// NewCache resolves references with the backend registered for their
+// scheme, vault or aws.
+func NewCache(backends map[string]Backend, ttl time.Duration) *Cache {
+	return &Cache{
+		backends: backends,
+		ttl:      ttl,
+		entries:  make(map[Reference]*entry),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Get returns the value of a setting, fetching it if it is a reference
+// whose secret is not cached.
+func (c *Cache) Get(ctx context.Context, value string) (string, error) {
+	ref, err := ParseReference(value)
+	if err != nil {
+		return value, nil
+	}
+
+	c.mu.Lock()
+	cached, ok := c.entries[ref]
+	if ok && time.Now().Before(cached.expires) {
+		value := cached.value
+		c.mu.Unlock()
+		return value, nil
+	}
+	c.mu.Unlock()
+
+	secret, err := c.fetch(ctx, ref)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		if ok {
+			slog.WarnContext(ctx, "re-fetch secret; serving previous value", "secret", ref.String(), "error", err)
+			cached.expires = time.Now().Add(retryDelay)
+			return cached.value, nil
+		}
+		return "", err
+	}
+	c.store(ref, secret)
+	return secret.Value, nil
+}
+
+// Getter returns a function resolving a setting on every call, for values
+// such as client secrets that are only needed now and then.
+func (c *Cache) Getter(value string) func() (string, error) {
+	return func() (string, error) {
+		return c.Get(context.Background(), value)
+	}
+}
+
+// Watch resolves a setting and applies it, then applies it again whenever
+// Run finds it changed. Errors from the first apply are returned; later
+// ones are logged, and the secret is applied again on its next change.
+func (c *Cache) Watch(ctx context.Context, value string, apply func(string) error) error {
+	resolved, err := c.Get(ctx, value)
+	if err != nil {
+		return err
+	}
+	if err := apply(resolved); err != nil {
+		return err
+	}
+
+	ref, err := ParseReference(value)
+	if err != nil {
+		return nil
+	}
+	c.mu.Lock()
+	cached := c.entries[ref]
+	cached.watchers = append(cached.watchers, apply)
+	c.mu.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run re-fetches watched secrets as their leases expire until ctx is
+// cancelled.
+func (c *Cache) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.wake:
+		case <-timer.C:
+			c.refresh(ctx)
+		}
+		if next, ok := c.nextExpiry(); ok {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// refresh re-fetches the watched secrets that expired and applies those
+// that changed.
+func (c *Cache) refresh(ctx context.Context) {
+	c.mu.Lock()
+	var due []Reference
+	for ref, cached := range c.entries {
+		if len(cached.watchers) > 0 && !time.Now().Before(cached.expires) {
+			due = append(due, ref)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, ref := range due {
+		secret, err := c.fetch(ctx, ref)
+
+		c.mu.Lock()
+		cached := c.entries[ref]
+		if err != nil {
+			cached.expires = time.Now().Add(retryDelay)
+			c.mu.Unlock()
+			slog.WarnContext(ctx, "re-fetch secret; keeping previous value", "secret", ref.String(), "retry_in", retryDelay, "error", err)
+			continue
+		}
+		changed := secret.Value != cached.value
+		watchers := cached.watchers
+		c.store(ref, secret)
+		c.mu.Unlock()
+
+		if !changed {
+			continue
+		}
+		slog.InfoContext(ctx, "secret changed", "secret", ref.String())
+		for _, apply := range watchers {
+			if err := apply(secret.Value); err != nil {
+				slog.ErrorContext(ctx, "apply changed secret", "secret", ref.String(), "error", err)
+			}
+		}
+	}
+}
+
+func (c *Cache) nextExpiry() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var next time.Time
+	for _, cached := range c.entries {
+		if len(cached.watchers) > 0 && (next.IsZero() || cached.expires.Before(next)) {
+			next = cached.expires
+		}
+	}
+	return next, !next.IsZero()
+}
+
+func (c *Cache) fetch(ctx context.Context, ref Reference) (Secret, error) {
+	backend, ok := c.backends[ref.Scheme]
+	if !ok {
+		return Secret{}, fmt.Errorf("secret %s: no %s backend configured", ref, ref.Scheme)
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	return backend.Fetch(ctx, ref)
+}
+
+// store caches a fetched secret. c.mu must be held.
+func (c *Cache) store(ref Reference, secret Secret) {
+	ttl := secret.TTL
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	cached, ok := c.entries[ref]
+	if !ok {
+		cached = &entry{}
+		c.entries[ref] = cached
+	}
+	cached.value = secret.Value
+	cached.expires = time.Now().Add(ttl)
+}
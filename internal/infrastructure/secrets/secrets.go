@@ -0,0 +1,73 @@
+// Package secrets fetches secrets from Vault or AWS Secrets Manager for
+// settings given as references instead of values, caching them until their
+// lease expires.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Secret is a fetched value and how long it may be cached, 0 when the
+// backend sets no lease.
+type Secret struct {
+	Value string
+	TTL   time.Duration
+}
+
+// Backend fetches the secret a reference points at.
+type Backend interface {
+	Fetch(ctx context.Context, ref Reference) (Secret, error)
+}
+
+// Reference locates a secret: vault:<mount>/<path>#<field> names a field of
+// a Vault KV version 2 secret, and aws:<secret-id>[#<field>] an AWS Secrets
+// Manager secret, or a field of its JSON object.
+type Reference struct {
+	Scheme string
+	Path   string
+	Field  string
+}
+
+// schemes are the reference prefixes of the supported backends.
+var schemes = []string{"vault", "aws"}
+
+// IsReference reports whether a setting names a secret rather than holding
+// its value.
+func IsReference(value string) bool {
+	_, err := ParseReference(value)
+	return err == nil
+}
+
+func ParseReference(value string) (Reference, error) {
+	scheme, rest, ok := strings.Cut(value, ":")
+	if !ok || !known(scheme) {
+		return Reference{}, fmt.Errorf("%q is not a secret reference", value)
+	}
+	path, field, _ := strings.Cut(rest, "#")
+	if path == "" {
+		return Reference{}, fmt.Errorf("secret reference %q has no path", value)
+	}
+	if scheme == "vault" && (field == "" || !strings.Contains(path, "/")) {
+		return Reference{}, fmt.Errorf("vault secret reference %q must be vault:<mount>/<path>#<field>", value)
+	}
+	return Reference{Scheme: scheme, Path: path, Field: field}, nil
+}
+
+func (r Reference) String() string {
+	if r.Field == "" {
+		return r.Scheme + ":" + r.Path
+	}
+	return r.Scheme + ":" + r.Path + "#" + r.Field
+}
+
+func known(scheme string) bool {
+	for _, candidate := range schemes {
+		if scheme == candidate {
+			return true
+		}
+	}
+	return false
+}
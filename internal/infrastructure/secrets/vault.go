@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// Vault reads fields of KV version 2 secrets. The client is configured from
+// the standard environment, VAULT_ADDR and VAULT_TOKEN among others.
+type Vault struct {
+	client *vault.Client
+}
+
+func NewVault() (*Vault, error) {
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("create vault client: %w", err)
+	}
+	return &Vault{client: client}, nil
+}
+
+func (v *Vault) Fetch(ctx context.Context, ref Reference) (Secret, error) {
+	mount, path, _ := strings.Cut(ref.Path, "/")
+	secret, err := v.client.KVv2(mount).Get(ctx, path)
+	if err != nil {
+		return Secret{}, fmt.Errorf("read %s: %w", ref, err)
+	}
+	value, ok := secret.Data[ref.Field].(string)
+	if !ok {
+		return Secret{}, fmt.Errorf("%s has no %s field", ref.Path, ref.Field)
+	}
+
+	var ttl time.Duration
+	if secret.Raw != nil {
+		ttl = time.Duration(secret.Raw.LeaseDuration) * time.Second
+	}
+	return Secret{Value: value, TTL: ttl}, nil
+}
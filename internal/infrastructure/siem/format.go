@@ -0,0 +1,176 @@
+package siem
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// The product the events are reported as coming from, in CEF and LEEF
+// headers.
+const (
+	deviceVendor  = "Ranco"
+	deviceProduct = "Auth Service"
+	deviceVersion = "1"
+)
+
+// Format renders an audit event as a single line, without a trailing newline.
+type Format func(event *models.AuditEvent) string
+
+// severities rank audit actions from 0 to 10, as CEF does; unlisted actions
+// rank 3.
+var severities = map[domain.AuditAction]int{
+	domain.AuditLoginFailed:          5,
+	domain.AuditPasswordReset:        5,
+	domain.AuditMFADisabled:          6,
+	domain.AuditRoleChanged:          6,
+	domain.AuditAccountBanned:        6,
+	domain.AuditImpersonationStarted: 7,
+}
+
+func severity(action domain.AuditAction) int {
+	if rank, ok := severities[action]; ok {
+		return rank
+	}
+	return 3
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// FormatCEF renders an event in ArcSight's Common Event Format. Details are
+// carried as a JSON object in cs1.
+func FormatCEF(event *models.AuditEvent) string {
+	var line strings.Builder
+	fmt.Fprintf(&line, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeaderEscaper.Replace(deviceVendor),
+		cefHeaderEscaper.Replace(deviceProduct),
+		cefHeaderEscaper.Replace(deviceVersion),
+		cefHeaderEscaper.Replace(string(event.Action)),
+		cefHeaderEscaper.Replace(string(event.Action)),
+		severity(event.Action),
+	)
+
+	extension := []string{
+		"rt=" + strconv.FormatInt(event.CreatedAt.UnixMilli(), 10),
+		"act=" + cefExtensionEscaper.Replace(string(event.Action)),
+		"externalId=" + event.ID.String(),
+	}
+	if event.ActorID != nil {
+		extension = append(extension, "suid="+event.ActorID.String())
+	}
+	if event.TargetID != nil {
+		extension = append(extension, "duid="+event.TargetID.String())
+	}
+	if event.IPAddress != nil {
+		extension = append(extension, "src="+cefExtensionEscaper.Replace(*event.IPAddress))
+	}
+	if event.UserAgent != nil {
+		extension = append(extension, "requestClientApplication="+cefExtensionEscaper.Replace(*event.UserAgent))
+	}
+	if len(event.Details) > 0 {
+		details, _ := json.Marshal(event.Details)
+		extension = append(extension, "cs1Label=details", "cs1="+cefExtensionEscaper.Replace(string(details)))
+	}
+	line.WriteString(strings.Join(extension, " "))
+	return line.String()
+}
+
+var (
+	leefHeaderEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ")
+	// LEEF attributes are tab separated, so values cannot hold tabs or line
+	// breaks.
+	leefValueEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+)
+
+// leefTimeLayout renders devTime in the pattern named by devTimeFormat.
+const (
+	leefTimeLayout = "Jan 02 2006 15:04:05.000 MST"
+	leefTimeFormat = "MMM dd yyyy HH:mm:ss.SSS z"
+)
+
+// FormatLEEF renders an event in IBM QRadar's Log Event Extended Format 1.0.
+// Details are carried as attributes of their own, prefixed with detail_.
+func FormatLEEF(event *models.AuditEvent) string {
+	var line strings.Builder
+	fmt.Fprintf(&line, "LEEF:1.0|%s|%s|%s|%s|",
+		leefHeaderEscaper.Replace(deviceVendor),
+		leefHeaderEscaper.Replace(deviceProduct),
+		leefHeaderEscaper.Replace(deviceVersion),
+		leefHeaderEscaper.Replace(string(event.Action)),
+	)
+
+	attributes := []string{
+		"devTime=" + event.CreatedAt.UTC().Format(leefTimeLayout),
+		"devTimeFormat=" + leefTimeFormat,
+		"cat=" + leefValueEscaper.Replace(string(event.Action)),
+		"sev=" + strconv.Itoa(severity(event.Action)),
+		"eventId=" + event.ID.String(),
+	}
+	if event.ActorID != nil {
+		attributes = append(attributes, "actorId="+event.ActorID.String())
+	}
+	if event.TargetID != nil {
+		attributes = append(attributes, "targetId="+event.TargetID.String())
+	}
+	if event.IPAddress != nil {
+		attributes = append(attributes, "src="+leefValueEscaper.Replace(*event.IPAddress))
+	}
+	if event.UserAgent != nil {
+		attributes = append(attributes, "userAgent="+leefValueEscaper.Replace(*event.UserAgent))
+	}
+	keys := make([]string, 0, len(event.Details))
+	for key := range event.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attributes = append(attributes, "detail_"+key+"="+leefValueEscaper.Replace(event.Details[key]))
+	}
+	line.WriteString(strings.Join(attributes, "\t"))
+	return line.String()
+}
+
+// document is the JSON form of an audit event sent to HTTP collectors.
+type document struct {
+	Timestamp time.Time         `json:"@timestamp"`
+	ID        string            `json:"id"`
+	Action    string            `json:"action"`
+	Severity  int               `json:"severity"`
+	ActorID   string            `json:"actor_id,omitempty"`
+	TargetID  string            `json:"target_id,omitempty"`
+	IPAddress string            `json:"ip_address,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+func newDocument(event *models.AuditEvent) document {
+	doc := document{
+		Timestamp: event.CreatedAt.UTC(),
+		ID:        event.ID.String(),
+		Action:    string(event.Action),
+		Severity:  severity(event.Action),
+		Details:   event.Details,
+	}
+	if event.ActorID != nil {
+		doc.ActorID = event.ActorID.String()
+	}
+	if event.TargetID != nil {
+		doc.TargetID = event.TargetID.String()
+	}
+	if event.IPAddress != nil {
+		doc.IPAddress = *event.IPAddress
+	}
+	if event.UserAgent != nil {
+		doc.UserAgent = *event.UserAgent
+	}
+	return doc
+}
@@ -0,0 +1,179 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+const collectorTimeout = 10 * time.Second
+
+type SplunkConfig struct {
+	// URL is the base URL of the HTTP Event Collector, such as
+	// https://splunk.example.com:8088.
+	URL   string
+	Token string
+	// Index is optional; the token's default index is used without it.
+	Index    string
+	Hostname string
+}
+
+// SplunkSink sends audit events to a Splunk HTTP Event Collector, a batch
+// per request.
+type SplunkSink struct {
+	config SplunkConfig
+	client *http.Client
+}
+
+func NewSplunkSink(config SplunkConfig) *SplunkSink {
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &SplunkSink{
+		config: config,
+		client: &http.Client{Timeout: collectorTimeout},
+	}
+}
+
+type splunkEvent struct {
+	Time       float64  `json:"time"`
+	Host       string   `json:"host,omitempty"`
+	Source     string   `json:"source"`
+	SourceType string   `json:"sourcetype"`
+	Index      string   `json:"index,omitempty"`
+	Event      document `json:"event"`
+}
+
+func (s *SplunkSink) Write(ctx context.Context, events []*models.AuditEvent) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range events {
+		err := encoder.Encode(splunkEvent{
+			Time:       float64(event.CreatedAt.UnixMilli()) / 1000,
+			Host:       s.config.Hostname,
+			Source:     appName,
+			SourceType: "ranco:audit",
+			Index:      s.config.Index,
+			Event:      newDocument(event),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL+"/services/collector/event", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Splunk "+s.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send to splunk: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("send to splunk: collector responded %s: %s", resp.Status, detail)
+	}
+	return nil
+}
+
+type ElasticConfig struct {
+	// URL is the base URL of the cluster, such as
+	// https://elastic.example.com:9200.
+	URL    string
+	APIKey string
+	// Index is an index or data stream.
+	Index string
+}
+
+// ElasticSink indexes audit events in Elasticsearch through the bulk API.
+// Documents are created with the event's id, so a batch delivered again
+// after a partial failure does not duplicate the events already indexed.
+type ElasticSink struct {
+	config ElasticConfig
+	client *http.Client
+}
+
+func NewElasticSink(config ElasticConfig) *ElasticSink {
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &ElasticSink{
+		config: config,
+		client: &http.Client{Timeout: collectorTimeout},
+	}
+}
+
+type elasticAction struct {
+	Create elasticTarget `json:"create"`
+}
+
+type elasticTarget struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+type elasticBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int             `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	} `json:"items"`
+}
+
+func (s *ElasticSink) Write(ctx context.Context, events []*models.AuditEvent) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range events {
+		if err := encoder.Encode(elasticAction{Create: elasticTarget{Index: s.config.Index, ID: event.ID.String()}}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(newDocument(event)); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL+"/_bulk", &body)
+	if err != nil {
+		return err
+	}
+	if s.config.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+s.config.APIKey)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send to elasticsearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("send to elasticsearch: cluster responded %s: %s", resp.Status, detail)
+	}
+
+	var result elasticBulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("send to elasticsearch: decode response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	// Conflicts are documents indexed by an earlier delivery.
+	for _, item := range result.Items {
+		for _, outcome := range item {
+			if outcome.Status/100 != 2 && outcome.Status != http.StatusConflict {
+				return fmt.Errorf("send to elasticsearch: index responded %d: %s", outcome.Status, outcome.Error)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package siem
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// drainTimeout bounds how long Run keeps delivering buffered events once its
+// context is cancelled.
+const drainTimeout = 5 * time.Second
+
+// Sink delivers batches of audit events to a collector. A batch that fails
+// is retried whole, so sinks should make redelivery harmless where the
+// collector allows it.
+type Sink interface {
+	Write(ctx context.Context, events []*models.AuditEvent) error
+}
+
+type StreamConfig struct {
+	// BufferSize is how many events wait for delivery before Send blocks.
+	BufferSize int
+	// BatchSize is the most events written to the sink at once, and
+	// FlushInterval how long an incomplete batch waits for more.
+	BatchSize     int
+	FlushInterval time.Duration
+	// BlockTimeout is how long Send waits for room in a full buffer before
+	// it drops the event.
+	BlockTimeout time.Duration
+	// MaxRetryDelay caps the backoff between attempts to deliver a batch.
+	MaxRetryDelay time.Duration
+}
+
+// Stream buffers audit events and delivers them to a sink in batches from a
+// single goroutine, retrying failed batches with exponential backoff. While
+// the sink is slow or unavailable the buffer fills and Send slows callers
+// down for up to BlockTimeout per event, then drops events rather than
+// stalling the service.
+type Stream struct {
+	sink    Sink
+	config  StreamConfig
+	events  chan *models.AuditEvent
+	dropped atomic.Uint64
+}
+
+func NewStream(sink Sink, config StreamConfig) *Stream {
+	return &Stream{
+		sink:   sink,
+		config: config,
+		events: make(chan *models.AuditEvent, config.BufferSize),
+	}
+}
+
+func (s *Stream) Send(event *models.AuditEvent) {
+	select {
+	case s.events <- event:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(s.config.BlockTimeout)
+	defer timer.Stop()
+	select {
+	case s.events <- event:
+	case <-timer.C:
+		slog.Warn("siem buffer full; dropped audit event", "audit_event_id", event.ID, "dropped", s.dropped.Add(1))
+	}
+}
+
+// Run delivers buffered events until ctx is cancelled, then for up to
+// drainTimeout delivers the events still buffered.
+func (s *Stream) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*models.AuditEvent, 0, s.config.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			s.drain(batch)
+			return
+		case event := <-s.events:
+			if batch = append(batch, event); len(batch) < s.config.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		if !s.deliver(ctx, batch) {
+			s.drain(batch)
+			return
+		}
+		batch = batch[:0]
+	}
+}
+
+// deliver writes a batch until it succeeds, backing off between attempts. It
+// reports false when ctx is cancelled first.
+func (s *Stream) deliver(ctx context.Context, batch []*models.AuditEvent) bool {
+	delay := time.Second
+	for {
+		err := s.sink.Write(ctx, batch)
+		if err == nil {
+			return true
+		}
+		slog.Warn("deliver audit events to siem; retrying", "events", len(batch), "retry_in", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, s.config.MaxRetryDelay)
+	}
+}
+
+// drain makes a last attempt to deliver batch and the buffered events.
+func (s *Stream) drain(batch []*models.AuditEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case event := <-s.events:
+			batch = append(batch, event)
+			if len(batch) < s.config.BatchSize {
+				continue
+			}
+		default:
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.sink.Write(ctx, batch); err != nil {
+			slog.Error("siem dropped audit events on shutdown", "events", len(batch)+len(s.events), "error", err)
+			return
+		}
+		batch = batch[:0]
+	}
+}
@@ -0,0 +1,126 @@
+package siem
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+const (
+	syslogDialTimeout  = 5 * time.Second
+	syslogWriteTimeout = 10 * time.Second
+	// syslogFacility is authpriv, the facility of security messages.
+	syslogFacility = 10
+	// appName names the service in syslog headers and as the source of
+	// collector events.
+	appName = "ranco-auth"
+)
+
+type SyslogConfig struct {
+	// Network is udp, tcp or tls.
+	Network string
+	Address string
+	// Hostname names this instance in the messages.
+	Hostname string
+	Format   Format
+}
+
+// SyslogSink sends audit events as RFC 5424 syslog messages, one per UDP
+// datagram or newline terminated over TCP and TLS. The connection is dialled
+// on first use and again after a write fails.
+type SyslogSink struct {
+	config SyslogConfig
+	conn   net.Conn
+}
+
+func NewSyslogSink(config SyslogConfig) (*SyslogSink, error) {
+	switch config.Network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", config.Network)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("syslog address is required")
+	}
+	if config.Hostname == "" {
+		config.Hostname = "-"
+	}
+	return &SyslogSink{config: config}, nil
+}
+
+func (s *SyslogSink) Write(ctx context.Context, events []*models.AuditEvent) error {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	deadline := time.Now().Add(syslogWriteTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := s.conn.SetWriteDeadline(deadline); err != nil {
+		return s.fail(err)
+	}
+
+	for _, event := range events {
+		message := s.message(event)
+		if s.config.Network != "udp" {
+			message += "\n"
+		}
+		if _, err := s.conn.Write([]byte(message)); err != nil {
+			return s.fail(err)
+		}
+	}
+	return nil
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogDialTimeout}
+	if s.config.Network == "tls" {
+		host, _, _ := net.SplitHostPort(s.config.Address)
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		conn, err := tlsDialer.DialContext(ctx, "tcp", s.config.Address)
+		if err != nil {
+			return nil, fmt.Errorf("dial syslog: %w", err)
+		}
+		return conn, nil
+	}
+
+	conn, err := dialer.DialContext(ctx, s.config.Network, s.config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("dial syslog: %w", err)
+	}
+	return conn, nil
+}
+
+// fail drops the connection after a failed write, so the next batch redials.
+func (s *SyslogSink) fail(err error) error {
+	_ = s.conn.Close()
+	s.conn = nil
+	return fmt.Errorf("write syslog: %w", err)
+}
+
+// message frames an event with an RFC 5424 header, as a warning from
+// severity 5 and as a notice below.
+func (s *SyslogSink) message(event *models.AuditEvent) string {
+	level := 5
+	if severity(event.Action) >= 5 {
+		level = 4
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogFacility*8+level,
+		event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		strings.ReplaceAll(s.config.Hostname, " ", "-"),
+		appName,
+		strings.ReplaceAll(string(event.Action), " ", "-"),
+		s.config.Format(event),
+	)
+}
@@ -0,0 +1,22 @@
+package sms
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// LogSender writes text messages to the standard logger. It is intended for
+// local development, where no SMS gateway is configured, and logs the codes
+// the messages carry so they can be used.
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, sms ports.SMS) error {
+	slog.InfoContext(ctx, "sms", "to", sms.To, "body", sms.Body)
+	return nil
+}
@@ -0,0 +1,67 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const twilioAPIURL = "https://api.twilio.com/2010-04-01"
+
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	// From is a Twilio phone number or messaging service SID.
+	From string
+}
+
+// TwilioSender delivers text messages through the Twilio Messages API.
+type TwilioSender struct {
+	config TwilioConfig
+	client *http.Client
+}
+
+func NewTwilioSender(config TwilioConfig) *TwilioSender {
+	return &TwilioSender{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second, Transport: otelhttp.NewTransport(http.DefaultTransport)},
+	}
+}
+
+func (s *TwilioSender) Send(ctx context.Context, sms ports.SMS) error {
+	form := url.Values{}
+	form.Set("To", sms.To)
+	form.Set("Body", sms.Body)
+	if strings.HasPrefix(s.config.From, "MG") {
+		form.Set("MessagingServiceSid", s.config.From)
+	} else {
+		form.Set("From", s.config.From)
+	}
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", twilioAPIURL, url.PathEscape(s.config.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.config.AccountSID, s.config.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("send sms: twilio responded %s: %s", resp.Status, body)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package strength
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+var (
+	//go:embed passwords.txt
+	commonPasswords string
+	//go:embed words.txt
+	englishWords string
+	//go:embed names.txt
+	commonNames string
+)
+
+// Dictionary names, which pick the feedback of dictionary matches.
+const (
+	dictionaryPasswords  = "passwords"
+	dictionaryEnglish    = "english"
+	dictionaryNames      = "names"
+	dictionaryUserInputs = "user_inputs"
+)
+
+// maxPasswordRunes bounds the part of a password that is analysed, keeping
+// the estimate cheap on long inputs. Anything longer is strong anyway.
+const maxPasswordRunes = 128
+
+// Guess counts of the zxcvbn model.
+const (
+	minGuessesBeforeGrowingSequence = 10000
+	minSubmatchGuessesSingleChar    = 10
+	minSubmatchGuessesMultiChar     = 50
+	bruteforceCardinality           = 10
+	minYearSpace                    = 20
+)
+
+// scoreThresholds are the guess counts, as base 10 logarithms, a password
+// must exceed for scores 1 to 4. The delta of 5 guesses keeps passwords
+// right at a threshold, such as a top-1000 password, on the lower score.
+var scoreThresholds = [...]float64{
+	math.Log10(1e3 + 5),
+	math.Log10(1e6 + 5),
+	math.Log10(1e8 + 5),
+	math.Log10(1e10 + 5),
+}
+
+// Warnings name the pattern that makes a password weak.
+const (
+	warningTop10Password        = "top_10_password"
+	warningTop100Password       = "top_100_password"
+	warningCommonPassword       = "common_password"
+	warningSimilarToCommon      = "similar_to_common_password"
+	warningWordByItself         = "word_by_itself"
+	warningNamesByThemselves    = "names_by_themselves"
+	warningCommonNames          = "common_names"
+	warningUserInput            = "contains_user_input"
+	warningStraightRow          = "straight_row"
+	warningShortKeyboardPattern = "short_keyboard_pattern"
+	warningRepeatedCharacters   = "repeated_characters"
+	warningRepeatedPattern      = "repeated_pattern"
+	warningSequence             = "sequence"
+	warningRecentYear           = "recent_year"
+	warningDate                 = "date"
+)
+
+// Suggestions tell how to make a weak password stronger.
+const (
+	suggestionUseWords           = "use_few_words"
+	suggestionNoSymbolsNeeded    = "no_symbols_needed"
+	suggestionAddWord            = "add_another_word"
+	suggestionLongerKeyboard     = "use_longer_keyboard_pattern"
+	suggestionAvoidRepeats       = "avoid_repeats"
+	suggestionAvoidSequences     = "avoid_sequences"
+	suggestionAvoidRecentYears   = "avoid_recent_years"
+	suggestionAvoidPersonalYears = "avoid_personal_years"
+	suggestionAvoidPersonalDates = "avoid_personal_dates"
+	suggestionCapitalization     = "capitalization_weak"
+	suggestionAllUppercase       = "all_uppercase_weak"
+	suggestionReversedWords      = "reversed_words_weak"
+	suggestionSubstitutions      = "predictable_substitutions_weak"
+)
+
+type dictionary struct {
+	name string
+	// ranks holds the 1-based frequency rank of every word.
+	ranks map[string]int
+	// longest is the length of the longest word, in runes.
+	longest int
+}
+
+func newDictionary(name string, words []string) *dictionary {
+	d := &dictionary{name: name, ranks: make(map[string]int, len(words))}
+	for _, word := range words {
+		if _, ok := d.ranks[word]; ok {
+			continue
+		}
+		d.ranks[word] = len(d.ranks) + 1
+		d.longest = max(d.longest, utf8.RuneCountInString(word))
+	}
+	return d
+}
+
+// parseWords reads a ranked word list: one word per line, most frequent
+// first, # starting comments.
+func parseWords(list string) []string {
+	var words []string
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if word := strings.ToLower(strings.TrimSpace(line)); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Estimator estimates password strength the way zxcvbn does: it finds the
+// patterns a password is made of (common passwords, words and names, l33t
+// and reversed spellings, keyboard walks, repeats, sequences, years and
+// dates), picks the decomposition that takes the fewest guesses and scores
+// that number from 0 to 4.
+type Estimator struct {
+	dictionaries []*dictionary
+	// now returns the current time, which makes recent years guessable.
+	now func() time.Time
+}
+
+func NewEstimator() *Estimator {
+	return &Estimator{
+		dictionaries: []*dictionary{
+			newDictionary(dictionaryPasswords, parseWords(commonPasswords)),
+			newDictionary(dictionaryEnglish, parseWords(englishWords)),
+			newDictionary(dictionaryNames, parseWords(commonNames)),
+		},
+		now: time.Now,
+	}
+}
+
+// Estimate scores password, treating every user input, and its words, as a
+// dictionary tried first.
+func (e *Estimator) Estimate(password string, userInputs []string) *models.PasswordStrength {
+	runes := []rune(password)
+	if len(runes) > maxPasswordRunes {
+		runes = runes[:maxPasswordRunes]
+	}
+
+	var inputs []string
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		inputs = append(inputs, input)
+		inputs = append(inputs, strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	dictionaries := e.dictionaries
+	if len(inputs) > 0 {
+		dictionaries = append([]*dictionary{newDictionary(dictionaryUserInputs, inputs)}, dictionaries...)
+	}
+
+	m := &matcher{dictionaries: dictionaries, referenceYear: e.now().Year()}
+	guesses, sequence := m.mostGuessable(runes)
+	score := guessesScore(guesses)
+
+	strength := &models.PasswordStrength{
+		Score:        score,
+		GuessesLog10: math.Round(guesses*100) / 100,
+	}
+	strength.Warning, strength.Suggestions = feedback(score, sequence)
+	return strength
+}
+
+func guessesScore(guessesLog10 float64) int {
+	for score, threshold := range scoreThresholds {
+		if guessesLog10 < threshold {
+			return score
+		}
+	}
+	return len(scoreThresholds)
+}
+
+// feedback explains a weak score by the longest pattern of the password.
+// Strong passwords get none.
+func feedback(score int, sequence []*match) (string, []string) {
+	if len(sequence) == 0 {
+		return "", []string{suggestionUseWords, suggestionNoSymbolsNeeded}
+	}
+	if score > 2 {
+		return "", nil
+	}
+
+	longest := sequence[0]
+	for _, m := range sequence[1:] {
+		if len(m.token) > len(longest.token) {
+			longest = m
+		}
+	}
+
+	warning, suggestions := matchFeedback(longest, len(sequence) == 1)
+	return warning, append([]string{suggestionAddWord}, suggestions...)
+}
+
+func matchFeedback(m *match, sole bool) (string, []string) {
+	switch m.pattern {
+	case patternDictionary:
+		return dictionaryFeedback(m, sole)
+	case patternSpatial:
+		if m.turns == 1 {
+			return warningStraightRow, []string{suggestionLongerKeyboard}
+		}
+		return warningShortKeyboardPattern, []string{suggestionLongerKeyboard}
+	case patternRepeat:
+		if len(m.baseToken) == 1 {
+			return warningRepeatedCharacters, []string{suggestionAvoidRepeats}
+		}
+		return warningRepeatedPattern, []string{suggestionAvoidRepeats}
+	case patternSequence:
+		return warningSequence, []string{suggestionAvoidSequences}
+	case patternYear:
+		return warningRecentYear, []string{suggestionAvoidRecentYears, suggestionAvoidPersonalYears}
+	case patternDate:
+		return warningDate, []string{suggestionAvoidPersonalDates}
+	default:
+		return "", nil
+	}
+}
+
+func dictionaryFeedback(m *match, sole bool) (string, []string) {
+	var warning string
+	switch m.dictionary {
+	case dictionaryPasswords:
+		switch {
+		case sole && !m.l33t && !m.reversed && m.rank <= 10:
+			warning = warningTop10Password
+		case sole && !m.l33t && !m.reversed && m.rank <= 100:
+			warning = warningTop100Password
+		case sole && !m.l33t && !m.reversed:
+			warning = warningCommonPassword
+		case m.guessesLog10 <= 4:
+			warning = warningSimilarToCommon
+		}
+	case dictionaryEnglish:
+		if sole {
+			warning = warningWordByItself
+		}
+	case dictionaryNames:
+		warning = warningCommonNames
+		if sole {
+			warning = warningNamesByThemselves
+		}
+	case dictionaryUserInputs:
+		warning = warningUserInput
+	}
+
+	var suggestions []string
+	word := string(m.token)
+	if startsUpper(m.token) {
+		suggestions = append(suggestions, suggestionCapitalization)
+	} else if strings.ToUpper(word) == word && strings.ToLower(word) != word {
+		suggestions = append(suggestions, suggestionAllUppercase)
+	}
+	if m.reversed && len(m.token) >= 4 {
+		suggestions = append(suggestions, suggestionReversedWords)
+	}
+	if m.l33t {
+		suggestions = append(suggestions, suggestionSubstitutions)
+	}
+	return warning, suggestions
+}
@@ -0,0 +1,90 @@
+package strength
+
+import "strings"
+
+// Keyboard layouts, one key per token: the unshifted character followed by
+// the shifted one. Rows of slanted layouts are offset like on a keyboard.
+const (
+	qwertyLayout = "" +
+		"`~ 1! 2@ 3# 4$ 5% 6^ 7& 8* 9( 0) -_ =+\n" +
+		"    qQ wW eE rR tT yY uU iI oO pP [{ ]} \\|\n" +
+		"     aA sS dD fF gG hH jJ kK lL ;: '\"\n" +
+		"      zZ xX cC vV bB nN mM ,< .> /?"
+	keypadLayout = "" +
+		"  / * -\n" +
+		"7 8 9 +\n" +
+		"4 5 6\n" +
+		"1 2 3\n" +
+		"  0 ."
+)
+
+// keyboard maps every key to its neighbours, in a fixed order of directions
+// so a change of direction can be told apart. Missing neighbours are empty.
+type keyboard struct {
+	name     string
+	adjacent map[rune][]string
+	// starts is the number of keys and degree their average number of
+	// neighbours, which size the space of patterns on the keyboard.
+	starts float64
+	degree float64
+}
+
+var (
+	qwerty = newKeyboard("qwerty", qwertyLayout, true)
+	keypad = newKeyboard("keypad", keypadLayout, false)
+)
+
+type position struct{ x, y int }
+
+// newKeyboard builds the adjacency of a layout. Keys of slanted layouts have
+// six neighbours, those of aligned layouts eight.
+func newKeyboard(name, layout string, slanted bool) *keyboard {
+	tokens := make(map[position]string)
+	for y, line := range strings.Split(layout, "\n") {
+		// Tokens of slanted rows are three columns apart, shifted by the
+		// row number; aligned tokens are two columns apart.
+		offset, width := 0, 2
+		if slanted {
+			offset, width = y, 3
+		}
+		for i, field := 0, ""; i < len(line); i++ {
+			if line[i] == ' ' {
+				continue
+			}
+			field = strings.Fields(line[i:])[0]
+			tokens[position{(i - offset) / width, y}] = field
+			i += len(field)
+		}
+	}
+
+	board := &keyboard{name: name, adjacent: make(map[rune][]string)}
+	neighbours := 0
+	for at, token := range tokens {
+		var around []position
+		if slanted {
+			around = []position{{at.x - 1, at.y}, {at.x, at.y - 1}, {at.x + 1, at.y - 1}, {at.x + 1, at.y}, {at.x, at.y + 1}, {at.x - 1, at.y + 1}}
+		} else {
+			around = []position{{at.x - 1, at.y}, {at.x - 1, at.y - 1}, {at.x, at.y - 1}, {at.x + 1, at.y - 1}, {at.x + 1, at.y}, {at.x + 1, at.y + 1}, {at.x, at.y + 1}, {at.x - 1, at.y + 1}}
+		}
+
+		adjacent := make([]string, len(around))
+		for i, near := range around {
+			adjacent[i] = tokens[near]
+			if adjacent[i] != "" {
+				neighbours++
+			}
+		}
+		for _, key := range token {
+			board.adjacent[key] = adjacent
+		}
+	}
+
+	board.starts = float64(len(tokens))
+	board.degree = float64(neighbours) / float64(len(tokens))
+	return board
+}
+
+// shifted reports whether typing key on the layout takes the shift key.
+func (k *keyboard) shifted(key rune) bool {
+	return k == qwerty && strings.ContainsRune(`~!@#$%^&*()_+QWERTYUIOP{}|ASDFGHJKL:"ZXCVBNM<>?`, key)
+}
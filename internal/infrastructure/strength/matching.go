@@ -0,0 +1,806 @@
+package strength
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"unicode"
+)
+
+type pattern int
+
+const (
+	patternBruteforce pattern = iota
+	patternDictionary
+	patternSpatial
+	patternRepeat
+	patternSequence
+	patternYear
+	patternDate
+)
+
+// Bounds of the years dates are read with.
+const (
+	dateMinYear = 1000
+	dateMaxYear = 2050
+)
+
+// maxL33tSubstitutions caps the substitution tables tried on a password, as
+// ambiguous characters multiply them.
+const maxL33tSubstitutions = 64
+
+// l33tTable lists the characters each letter is commonly replaced with.
+var l33tTable = map[rune][]rune{
+	'a': {'4', '@'},
+	'b': {'8'},
+	'c': {'(', '{', '[', '<'},
+	'e': {'3'},
+	'g': {'6', '9'},
+	'i': {'1', '!', '|'},
+	'l': {'1', '|', '7'},
+	'o': {'0'},
+	's': {'$', '5'},
+	't': {'+', '7'},
+	'x': {'%'},
+	'z': {'2'},
+}
+
+// dateSplits are the ways to cut a date written without separators into
+// three numbers, by length: the indexes where the second and third start.
+var dateSplits = map[int][][2]int{
+	4: {{1, 2}, {2, 3}},
+	5: {{1, 3}, {2, 3}},
+	6: {{1, 2}, {2, 4}, {4, 5}},
+	7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+	8: {{2, 4}, {4, 6}},
+}
+
+// match is a run of the password, from i to j inclusive, that follows a
+// pattern. Only the fields of its pattern are set.
+type match struct {
+	pattern pattern
+	i, j    int
+	token   []rune
+
+	dictionary string
+	rank       int
+	reversed   bool
+	l33t       bool
+	// subs maps the substituted characters of a l33t match to the letters
+	// they stand for.
+	subs map[rune]rune
+
+	board   *keyboard
+	turns   int
+	shifted int
+
+	baseToken    []rune
+	baseLog10    float64
+	repeatCount  int
+	ascending    bool
+	year         int
+	hasSeparator bool
+
+	// guessesLog10 is set once estimated is.
+	guessesLog10 float64
+	estimated    bool
+}
+
+// matcher finds the patterns of a password and the cheapest way to guess it.
+type matcher struct {
+	dictionaries  []*dictionary
+	referenceYear int
+}
+
+// mostGuessable returns the base 10 logarithm of the guesses needed for
+// password and the sequence of matches that needs the fewest, following the
+// search of zxcvbn: a sequence of l matches costs l! times the product of
+// their guesses, plus a term that favours short sequences.
+func (m *matcher) mostGuessable(password []rune) (float64, []*match) {
+	n := len(password)
+	if n == 0 {
+		return 0, nil
+	}
+
+	byEnd := make([][]*match, n)
+	for _, found := range m.matches(password) {
+		byEnd[found.j] = append(byEnd[found.j], found)
+	}
+	for _, matches := range byEnd {
+		slices.SortFunc(matches, func(a, b *match) int { return a.i - b.i })
+	}
+
+	// best[k][l] is the cheapest sequence of l matches covering the
+	// password up to k, with its guesses g and product pi.
+	type candidate struct {
+		last  *match
+		pi, g float64
+	}
+	best := make([]map[int]candidate, n)
+	for k := range best {
+		best[k] = make(map[int]candidate)
+	}
+
+	update := func(found *match, l int) {
+		k := found.j
+		pi := m.guesses(found, n)
+		if l > 1 {
+			pi += best[found.i-1][l-1].pi
+		}
+		g := logAdd(logFactorial(l)+pi, float64(l-1)*math.Log10(minGuessesBeforeGrowingSequence))
+		for competingL, competing := range best[k] {
+			if competingL <= l && competing.g <= g {
+				return
+			}
+		}
+		best[k][l] = candidate{last: found, pi: pi, g: g}
+	}
+
+	for k := range n {
+		for _, found := range byEnd[k] {
+			if found.i == 0 {
+				update(found, 1)
+				continue
+			}
+			for l := range best[found.i-1] {
+				update(found, l+1)
+			}
+		}
+
+		update(bruteforceMatch(password, 0, k), 1)
+		for i := 1; i <= k; i++ {
+			for l, previous := range best[i-1] {
+				// Consecutive bruteforce matches are a single one.
+				if previous.last.pattern == patternBruteforce {
+					continue
+				}
+				update(bruteforceMatch(password, i, k), l+1)
+			}
+		}
+	}
+
+	bestL, guesses := 0, math.Inf(1)
+	for l, found := range best[n-1] {
+		if found.g < guesses || (found.g == guesses && l < bestL) {
+			bestL, guesses = l, found.g
+		}
+	}
+
+	sequence := make([]*match, bestL)
+	for k, l := n-1, bestL; k >= 0; l-- {
+		found := best[k][l].last
+		sequence[l-1] = found
+		k = found.i - 1
+	}
+	return guesses, sequence
+}
+
+// guesses estimates, once, the guesses of a match within a password of n
+// runes. Matches covering part of the password take at least a few guesses,
+// which keeps tiny matches from splitting it up.
+func (m *matcher) guesses(found *match, n int) float64 {
+	if found.estimated {
+		return found.guessesLog10
+	}
+
+	minimum := 0.0
+	if len(found.token) < n {
+		minimum = math.Log10(minSubmatchGuessesMultiChar)
+		if len(found.token) == 1 {
+			minimum = math.Log10(minSubmatchGuessesSingleChar)
+		}
+	}
+
+	var guesses float64
+	switch found.pattern {
+	case patternBruteforce:
+		guesses = float64(len(found.token)) * math.Log10(bruteforceCardinality)
+		floor := minSubmatchGuessesMultiChar + 1.0
+		if len(found.token) == 1 {
+			floor = minSubmatchGuessesSingleChar + 1
+		}
+		guesses = max(guesses, math.Log10(floor))
+	case patternDictionary:
+		guesses = math.Log10(float64(found.rank)) + uppercaseVariations(found.token) + l33tVariations(found)
+		if found.reversed {
+			guesses += math.Log10(2)
+		}
+	case patternSpatial:
+		guesses = spatialGuesses(found)
+	case patternRepeat:
+		guesses = found.baseLog10 + math.Log10(float64(found.repeatCount))
+	case patternSequence:
+		base := 26.0
+		switch first := found.token[0]; {
+		case slices.Contains([]rune("aAzZ019"), first):
+			base = 4
+		case unicode.IsDigit(first):
+			base = 10
+		}
+		if !found.ascending {
+			base *= 2
+		}
+		guesses = math.Log10(base * float64(len(found.token)))
+	case patternYear:
+		guesses = math.Log10(m.yearSpace(found.year))
+	case patternDate:
+		guesses = math.Log10(m.yearSpace(found.year) * 365)
+		if found.hasSeparator {
+			guesses += math.Log10(4)
+		}
+	}
+
+	found.guessesLog10, found.estimated = max(guesses, minimum), true
+	return found.guessesLog10
+}
+
+func (m *matcher) yearSpace(year int) float64 {
+	return math.Max(math.Abs(float64(year-m.referenceYear)), minYearSpace)
+}
+
+func (m *matcher) matches(password []rune) []*match {
+	var matches []*match
+	matches = append(matches, m.dictionaryMatches(password)...)
+	matches = append(matches, m.reversedMatches(password)...)
+	matches = append(matches, m.l33tMatches(password)...)
+	matches = append(matches, spatialMatches(password, qwerty)...)
+	matches = append(matches, spatialMatches(password, keypad)...)
+	matches = append(matches, m.repeatMatches(password)...)
+	matches = append(matches, sequenceMatches(password)...)
+	matches = append(matches, m.yearMatches(password)...)
+	matches = append(matches, m.dateMatches(password)...)
+	return matches
+}
+
+func bruteforceMatch(password []rune, i, j int) *match {
+	return &match{pattern: patternBruteforce, i: i, j: j, token: password[i : j+1]}
+}
+
+// dictionaryMatches finds every run of password that is a dictionary word,
+// ignoring case.
+func (m *matcher) dictionaryMatches(password []rune) []*match {
+	lower := make([]rune, len(password))
+	for i, r := range password {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches []*match
+	for _, dict := range m.dictionaries {
+		for i := range lower {
+			for j := i; j < len(lower) && j-i < dict.longest; j++ {
+				if rank, ok := dict.ranks[string(lower[i:j+1])]; ok {
+					matches = append(matches, &match{
+						pattern:    patternDictionary,
+						i:          i,
+						j:          j,
+						token:      password[i : j+1],
+						dictionary: dict.name,
+						rank:       rank,
+					})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// reversedMatches finds dictionary words spelled backwards.
+func (m *matcher) reversedMatches(password []rune) []*match {
+	reversed := slices.Clone(password)
+	slices.Reverse(reversed)
+
+	matches := m.dictionaryMatches(reversed)
+	for _, found := range matches {
+		found.i, found.j = len(password)-1-found.j, len(password)-1-found.i
+		found.token = password[found.i : found.j+1]
+		found.reversed = true
+	}
+	return matches
+}
+
+// l33tMatches finds dictionary words with letters replaced by look-alike
+// characters, trying every reading of the characters that could stand for
+// several letters.
+func (m *matcher) l33tMatches(password []rune) []*match {
+	var matches []*match
+	for _, subs := range l33tSubstitutions(password) {
+		translated := make([]rune, len(password))
+		for i, r := range password {
+			if letter, ok := subs[r]; ok {
+				translated[i] = letter
+			} else {
+				translated[i] = r
+			}
+		}
+
+		for _, found := range m.dictionaryMatches(translated) {
+			token := password[found.i : found.j+1]
+			if len(token) <= 1 {
+				continue
+			}
+			used := make(map[rune]rune)
+			for _, r := range token {
+				if letter, ok := subs[r]; ok {
+					used[r] = letter
+				}
+			}
+			if len(used) == 0 {
+				continue
+			}
+			found.token = token
+			found.l33t = true
+			found.subs = used
+			matches = append(matches, found)
+		}
+	}
+	return matches
+}
+
+// l33tSubstitutions lists the tables mapping each substitute character of
+// password to one letter it may stand for.
+func l33tSubstitutions(password []rune) []map[rune]rune {
+	readings := make(map[rune][]rune)
+	var present []rune
+	for letter, substitutes := range l33tTable {
+		for _, substitute := range substitutes {
+			if slices.Contains(password, substitute) {
+				if readings[substitute] == nil {
+					present = append(present, substitute)
+				}
+				readings[substitute] = append(readings[substitute], letter)
+			}
+		}
+	}
+	if len(present) == 0 {
+		return nil
+	}
+	slices.Sort(present)
+
+	tables := []map[rune]rune{{}}
+	for _, substitute := range present {
+		letters := readings[substitute]
+		slices.Sort(letters)
+
+		var next []map[rune]rune
+		for _, table := range tables {
+			for _, letter := range letters {
+				if len(next) == maxL33tSubstitutions {
+					break
+				}
+				extended := make(map[rune]rune, len(table)+1)
+				for k, v := range table {
+					extended[k] = v
+				}
+				extended[substitute] = letter
+				next = append(next, extended)
+			}
+		}
+		tables = next
+	}
+	return tables
+}
+
+// spatialMatches finds walks of at least three keys on board, counting the
+// changes of direction and the shifted keys.
+func spatialMatches(password []rune, board *keyboard) []*match {
+	var matches []*match
+	for i := 0; i < len(password)-1; {
+		j := i + 1
+		lastDirection, turns, shifted := -1, 0, 0
+		if board.shifted(password[i]) {
+			shifted = 1
+		}
+
+		for {
+			found := false
+			if j < len(password) {
+				current := password[j]
+				for direction, neighbour := range board.adjacent[password[j-1]] {
+					index := slices.Index([]rune(neighbour), current)
+					if index < 0 {
+						continue
+					}
+					found = true
+					if index == 1 {
+						shifted++
+					}
+					if direction != lastDirection {
+						turns++
+						lastDirection = direction
+					}
+					break
+				}
+			}
+			if found {
+				j++
+				continue
+			}
+
+			if j-i > 2 {
+				matches = append(matches, &match{
+					pattern: patternSpatial,
+					i:       i,
+					j:       j - 1,
+					token:   password[i:j],
+					board:   board,
+					turns:   turns,
+					shifted: shifted,
+				})
+			}
+			i = j
+			break
+		}
+	}
+	return matches
+}
+
+func spatialGuesses(found *match) float64 {
+	length := len(found.token)
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(found.turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * found.board.starts * math.Pow(found.board.degree, float64(j))
+		}
+	}
+	logGuesses := math.Log10(guesses)
+
+	shifted, unshifted := found.shifted, length-found.shifted
+	if shifted == 0 || unshifted == 0 {
+		if shifted > 0 {
+			logGuesses += math.Log10(2)
+		}
+		return logGuesses
+	}
+	variations := 0.0
+	for i := 1; i <= min(shifted, unshifted); i++ {
+		variations += binomial(length, i)
+	}
+	return logGuesses + math.Log10(variations)
+}
+
+// repeatMatches finds runs made of a repeated base, such as "aaa" or
+// "abcabc", and estimates the base on its own.
+func (m *matcher) repeatMatches(password []rune) []*match {
+	var matches []*match
+	for i := 0; i < len(password); {
+		baseLength, count := 0, 0
+		for length := 1; i+2*length <= len(password); length++ {
+			repeats := 1
+			for start := i + length; start+length <= len(password) && slices.Equal(password[start:start+length], password[i:i+length]); start += length {
+				repeats++
+			}
+			if repeats > 1 && repeats*length > baseLength*count {
+				baseLength, count = length, repeats
+			}
+		}
+		if count == 0 {
+			i++
+			continue
+		}
+
+		base := password[i : i+baseLength]
+		// Reduce the base to the shortest unit repeating it, as in
+		// "abab" repeated twice.
+		for unit := 1; unit < baseLength; unit++ {
+			if baseLength%unit == 0 && slices.Equal(base, repeatRunes(base[:unit], baseLength/unit)) {
+				count *= baseLength / unit
+				baseLength = unit
+				base = base[:unit]
+				break
+			}
+		}
+
+		baseLog10, _ := m.mostGuessable(base)
+		end := i + baseLength*count
+		matches = append(matches, &match{
+			pattern:     patternRepeat,
+			i:           i,
+			j:           end - 1,
+			token:       password[i:end],
+			baseToken:   base,
+			baseLog10:   baseLog10,
+			repeatCount: count,
+		})
+		i = end
+	}
+	return matches
+}
+
+func repeatRunes(unit []rune, count int) []rune {
+	repeated := make([]rune, 0, len(unit)*count)
+	for range count {
+		repeated = append(repeated, unit...)
+	}
+	return repeated
+}
+
+// sequenceMatches finds runs of characters with a constant step of at most
+// 5, such as "abcd", "7531" or "zyx".
+func sequenceMatches(password []rune) []*match {
+	if len(password) <= 1 {
+		return nil
+	}
+
+	var matches []*match
+	add := func(i, j int, delta int) {
+		if j-i <= 1 && abs(delta) != 1 {
+			return
+		}
+		if delta == 0 || abs(delta) > 5 {
+			return
+		}
+		matches = append(matches, &match{
+			pattern:   patternSequence,
+			i:         i,
+			j:         j,
+			token:     password[i : j+1],
+			ascending: delta > 0,
+		})
+	}
+
+	i, lastDelta := 0, int(password[1]-password[0])
+	for k := 1; k < len(password); k++ {
+		delta := int(password[k] - password[k-1])
+		if delta == lastDelta {
+			continue
+		}
+		add(i, k-1, lastDelta)
+		i, lastDelta = k-1, delta
+	}
+	add(i, len(password)-1, lastDelta)
+	return matches
+}
+
+// yearMatches finds years of the last century up to now.
+func (m *matcher) yearMatches(password []rune) []*match {
+	var matches []*match
+	for i := 0; i+4 <= len(password); i++ {
+		year, ok := digits(password[i : i+4])
+		if !ok || year < 1900 || year > m.referenceYear {
+			continue
+		}
+		matches = append(matches, &match{pattern: patternYear, i: i, j: i + 3, token: password[i : i+4], year: year})
+		i += 3
+	}
+	return matches
+}
+
+// dateMatches finds dates written as day, month and year in any order, with
+// or without a separator, dropping those inside longer dates.
+func (m *matcher) dateMatches(password []rune) []*match {
+	var matches []*match
+	for i := range password {
+		for j := i + 3; j < len(password) && j-i < 8; j++ {
+			token := password[i : j+1]
+			if _, ok := digits(token); !ok {
+				continue
+			}
+			var dates []*match
+			for _, split := range dateSplits[len(token)] {
+				a, _ := digits(token[:split[0]])
+				b, _ := digits(token[split[0]:split[1]])
+				c, _ := digits(token[split[1]:])
+				if year, ok := dateYear(a, b, c); ok {
+					dates = append(dates, &match{pattern: patternDate, i: i, j: j, token: token, year: year})
+				}
+			}
+			if len(dates) == 0 {
+				continue
+			}
+			// Keep the reading closest to the present.
+			closest := dates[0]
+			for _, date := range dates[1:] {
+				if abs(date.year-m.referenceYear) < abs(closest.year-m.referenceYear) {
+					closest = date
+				}
+			}
+			matches = append(matches, closest)
+		}
+	}
+
+	for i := range password {
+		for j := i + 5; j < len(password) && j-i < 10; j++ {
+			if year, ok := separatedDate(password[i : j+1]); ok {
+				matches = append(matches, &match{pattern: patternDate, i: i, j: j, token: password[i : j+1], year: year, hasSeparator: true})
+			}
+		}
+	}
+
+	return slices.DeleteFunc(matches, func(date *match) bool {
+		for _, other := range matches {
+			if other != date && other.i <= date.i && other.j >= date.j && (other.i != date.i || other.j != date.j) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// separatedDate reads a token like "1/2/1990", with the same separator
+// twice.
+func separatedDate(token []rune) (int, bool) {
+	isSeparator := func(r rune) bool { return slices.Contains([]rune(" /\\_.-"), r) }
+
+	first := slices.IndexFunc(token, isSeparator)
+	if first < 1 || first > 4 {
+		return 0, false
+	}
+	second := slices.IndexFunc(token[first+1:], isSeparator) + first + 1
+	if second <= first+1 || second-first-1 > 2 || token[second] != token[first] || len(token)-second-1 < 1 || len(token)-second-1 > 4 {
+		return 0, false
+	}
+
+	a, okA := digits(token[:first])
+	b, okB := digits(token[first+1 : second])
+	c, okC := digits(token[second+1:])
+	if !okA || !okB || !okC {
+		return 0, false
+	}
+	return dateYear(a, b, c)
+}
+
+// dateYear reads three numbers as a day, month and year in some order and
+// returns the year, expanding two digit years.
+func dateYear(a, b, c int) (int, bool) {
+	if b > 31 || b <= 0 {
+		return 0, false
+	}
+	over12, over31, under1 := 0, 0, 0
+	for _, n := range []int{a, b, c} {
+		if (n > 99 && n < dateMinYear) || n > dateMaxYear {
+			return 0, false
+		}
+		if n > 31 {
+			over31++
+		}
+		if n > 12 {
+			over12++
+		}
+		if n <= 0 {
+			under1++
+		}
+	}
+	if over31 >= 2 || over12 == 3 || under1 >= 2 {
+		return 0, false
+	}
+
+	splits := [][3]int{{c, a, b}, {a, b, c}}
+	for _, split := range splits {
+		if year := split[0]; year >= dateMinYear && year <= dateMaxYear {
+			return year, dayMonth(split[1], split[2])
+		}
+	}
+	for _, split := range splits {
+		if dayMonth(split[1], split[2]) {
+			year := split[0]
+			switch {
+			case year > 99:
+			case year > 50:
+				year += 1900
+			default:
+				year += 2000
+			}
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+func dayMonth(a, b int) bool {
+	valid := func(day, month int) bool { return day >= 1 && day <= 31 && month >= 1 && month <= 12 }
+	return valid(a, b) || valid(b, a)
+}
+
+// uppercaseVariations counts the capitalizations of a word a guesser tries:
+// few for the common ones, all mixes of its letters otherwise.
+func uppercaseVariations(token []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 0
+	}
+	if startsUpper(token) || endsUpper(token) || lower == 0 {
+		return math.Log10(2)
+	}
+
+	variations := 0.0
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return math.Log10(variations)
+}
+
+// startsUpper reports whether only the first letter of token is uppercase.
+func startsUpper(token []rune) bool {
+	if len(token) == 0 || !unicode.IsUpper(token[0]) {
+		return false
+	}
+	return !slices.ContainsFunc(token[1:], unicode.IsUpper)
+}
+
+// endsUpper reports whether only the last letter of token is uppercase.
+func endsUpper(token []rune) bool {
+	if len(token) == 0 || !unicode.IsUpper(token[len(token)-1]) {
+		return false
+	}
+	return !slices.ContainsFunc(token[:len(token)-1], unicode.IsUpper)
+}
+
+// l33tVariations counts the ways the substitutions of a match could have
+// been applied to some of its letters only.
+func l33tVariations(found *match) float64 {
+	if !found.l33t {
+		return 0
+	}
+
+	variations := 0.0
+	for substitute, letter := range found.subs {
+		subbed, unsubbed := 0, 0
+		for _, r := range found.token {
+			switch unicode.ToLower(r) {
+			case substitute:
+				subbed++
+			case letter:
+				unsubbed++
+			}
+		}
+		if subbed == 0 || unsubbed == 0 {
+			variations += math.Log10(2)
+			continue
+		}
+		possibilities := 0.0
+		for i := 1; i <= min(subbed, unsubbed); i++ {
+			possibilities += binomial(subbed+unsubbed, i)
+		}
+		variations += math.Log10(possibilities)
+	}
+	return variations
+}
+
+func digits(token []rune) (int, bool) {
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(string(token))
+	return n, err == nil
+}
+
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func logFactorial(n int) float64 {
+	value, _ := math.Lgamma(float64(n + 1))
+	return value / math.Ln10
+}
+
+// logAdd returns the base 10 logarithm of 10^a + 10^b.
+func logAdd(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	return a + math.Log10(1+math.Pow(10, b-a))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
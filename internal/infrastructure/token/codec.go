@@ -0,0 +1,80 @@
+package token
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Access token formats selectable with NewCodec.
+const (
+	FormatJWT    = "jwt"
+	FormatPASETO = "paseto"
+	FormatOpaque = "opaque"
+)
+
+// Codec turns claims into a signed token and back. It only concerns the
+// envelope: JWTService decides what the claims say and validates them once
+// they are decoded, whatever the format.
+type Codec interface {
+	Encode(ctx context.Context, key *SigningKey, claims jwt.Claims) (string, error)
+	// Decode verifies the signature of raw with the key it names and
+	// unmarshals its claims without validating them.
+	Decode(ctx context.Context, raw string, keys KeyStore, claims jwt.Claims) error
+}
+
+// NewCodec returns the codec of an access token format. PASETO v4.public
+// tokens are signed with Ed25519, so every published key must be an EdDSA
+// key. Opaque tokens are stored in accessTokens.
+func NewCodec(format string, keys KeyStore, accessTokens repositories.AccessTokenRepository) (Codec, error) {
+	switch format {
+	case FormatJWT:
+		return NewJWTCodec(), nil
+	case FormatPASETO:
+		for _, key := range keys.PublicKeys() {
+			if _, ok := key.Key.(ed25519.PublicKey); !ok {
+				return nil, fmt.Errorf("token codec: %s tokens need EdDSA keys, key %s is %s", format, key.ID, key.Algorithm)
+			}
+		}
+		return NewPASETOCodec(), nil
+	case FormatOpaque:
+		return NewOpaqueCodec(accessTokens), nil
+	default:
+		return nil, fmt.Errorf("token codec: unsupported format %q", format)
+	}
+}
+
+// JWTCodec encodes tokens as JWS compact serializations with the kid header.
+type JWTCodec struct{}
+
+func NewJWTCodec() *JWTCodec {
+	return &JWTCodec{}
+}
+
+func (c *JWTCodec) Encode(ctx context.Context, key *SigningKey, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+func (c *JWTCodec) Decode(ctx context.Context, raw string, keys KeyStore, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := keys.VerificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		if t.Method.Alg() != key.Method.Alg() {
+			return nil, errors.New("signing method mismatch")
+		}
+		return key.PublicKey(), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithoutClaimsValidation(),
+	)
+	return err
+}
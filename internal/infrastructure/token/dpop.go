@@ -0,0 +1,205 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const dpopProofType = "dpop+jwt"
+
+// minDPoPRSABits rejects RSA proof keys too short to resist factoring.
+const minDPoPRSABits = 2048
+
+// dpopAlgorithms are the asymmetric algorithms accepted for DPoP proofs.
+var dpopAlgorithms = []string{
+	jwt.SigningMethodES256.Alg(),
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodPS256.Alg(),
+	jwt.SigningMethodEdDSA.Alg(),
+}
+
+// dpopClaims is the wire format of a DPoP proof (RFC 9449 section 4.2).
+type dpopClaims struct {
+	jwt.RegisteredClaims
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	ATH string `json:"ath,omitempty"`
+}
+
+// proofJWK is a public key embedded in the jwk header of a proof. D is only
+// decoded to refuse proofs that leak a private key.
+type proofJWK struct {
+	KeyType string `json:"kty"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	D       string `json:"d"`
+}
+
+// DPoPParser verifies DPoP proofs with the key they carry.
+type DPoPParser struct{}
+
+func NewDPoPParser() *DPoPParser {
+	return &DPoPParser{}
+}
+
+func (p *DPoPParser) Algorithms() []string {
+	return dpopAlgorithms
+}
+
+// ParseProof checks the type, algorithm and signature of a proof and returns
+// its claims with the thumbprint of its key. Time and request binding are
+// checked by the caller.
+func (p *DPoPParser) ParseProof(raw string) (*models.DPoPProof, error) {
+	var (
+		claims     dpopClaims
+		thumbprint string
+	)
+	_, err := jwt.ParseWithClaims(raw, &claims, func(t *jwt.Token) (any, error) {
+		if typ, _ := t.Header["typ"].(string); typ != dpopProofType {
+			return nil, errors.New("dpop: unexpected typ header")
+		}
+
+		encoded, err := json.Marshal(t.Header["jwk"])
+		if err != nil {
+			return nil, err
+		}
+		var key proofJWK
+		if err := json.Unmarshal(encoded, &key); err != nil {
+			return nil, err
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, err
+		}
+		thumbprint, err = key.thumbprint()
+		if err != nil {
+			return nil, err
+		}
+		return publicKey, nil
+	},
+		jwt.WithValidMethods(dpopAlgorithms),
+		// iat is checked against the replay window by the caller.
+		jwt.WithoutClaimsValidation(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dpop: %w", err)
+	}
+
+	if claims.ID == "" || claims.IssuedAt == nil || claims.HTM == "" || claims.HTU == "" {
+		return nil, errors.New("dpop: missing claims")
+	}
+
+	return &models.DPoPProof{
+		TokenID:         claims.ID,
+		Method:          claims.HTM,
+		URI:             claims.HTU,
+		AccessTokenHash: claims.ATH,
+		IssuedAt:        claims.IssuedAt.Time,
+		KeyThumbprint:   thumbprint,
+	}, nil
+}
+
+func (k proofJWK) publicKey() (any, error) {
+	if k.D != "" {
+		return nil, errors.New("dpop: jwk contains a private key")
+	}
+
+	switch {
+	case k.KeyType == "EC" && k.Curve == "P-256":
+		x, err := decodeCoordinate(k.X, 32)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeCoordinate(k.Y, 32)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+	case k.KeyType == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("dpop: invalid rsa exponent")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if key.N.BitLen() < minDPoPRSABits {
+			return nil, errors.New("dpop: rsa key too short")
+		}
+		return key, nil
+	case k.KeyType == "OKP" && k.Curve == "Ed25519":
+		x, err := decodeCoordinate(k.X, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("dpop: unsupported key type %q", k.KeyType)
+	}
+}
+
+// thumbprint computes the RFC 7638 thumbprint: the SHA-256 of the required
+// members in lexicographic order, which the struct field order encodes.
+func (k proofJWK) thumbprint() (string, error) {
+	var members any
+	switch k.KeyType {
+	case "EC":
+		members = struct {
+			Curve   string `json:"crv"`
+			KeyType string `json:"kty"`
+			X       string `json:"x"`
+			Y       string `json:"y"`
+		}{k.Curve, k.KeyType, k.X, k.Y}
+	case "RSA":
+		members = struct {
+			E       string `json:"e"`
+			KeyType string `json:"kty"`
+			N       string `json:"n"`
+		}{k.E, k.KeyType, k.N}
+	case "OKP":
+		members = struct {
+			Curve   string `json:"crv"`
+			KeyType string `json:"kty"`
+			X       string `json:"x"`
+		}{k.Curve, k.KeyType, k.X}
+	}
+
+	encoded, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func decodeCoordinate(value string, size int) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != size {
+		return nil, errors.New("dpop: invalid key coordinate")
+	}
+	return decoded, nil
+}
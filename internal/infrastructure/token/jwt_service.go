@@ -0,0 +1,366 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	TTL      time.Duration
+	// Codec sets the format of access tokens, JWT when nil. ID tokens are
+	// always JWTs, as OpenID Connect requires.
+	Codec Codec
+}
+
+// accessClaims is the wire format of an access token. Client tokens carry
+// the client ID as sub and client_id (RFC 9068) and no account claims.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	Role     domain.Role       `json:"role,omitempty"`
+	Status   domain.Status     `json:"status,omitempty"`
+	Scope    domain.TokenScope `json:"scope,omitempty"`
+	ClientID string            `json:"client_id,omitempty"`
+	// Permissions are those of the role when the token was issued.
+	Permissions []string `json:"permissions,omitempty"`
+	// SessionID follows the sid claim of OpenID Connect Front-Channel Logout.
+	SessionID string `json:"sid,omitempty"`
+	// OrganizationID and OrganizationRole are the active organization of the
+	// session and the account's role in it.
+	OrganizationID   string      `json:"org_id,omitempty"`
+	OrganizationRole domain.Role `json:"org_role,omitempty"`
+	// auth_time, amr and acr follow OpenID Connect Core and RFC 8176.
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	AMR      []string         `json:"amr,omitempty"`
+	ACR      string           `json:"acr,omitempty"`
+	// Actor is the act claim of RFC 8693 on delegated and impersonation
+	// tokens.
+	Actor *actorClaim `json:"act,omitempty"`
+	// Confirmation is the cnf claim of RFC 9449 on DPoP-bound tokens.
+	Confirmation *confirmationClaim `json:"cnf,omitempty"`
+}
+
+type confirmationClaim struct {
+	KeyThumbprint string `json:"jkt"`
+}
+
+type actorClaim struct {
+	Subject      string `json:"sub"`
+	ClientID     string `json:"client_id,omitempty"`
+	Impersonator bool   `json:"impersonator,omitempty"`
+}
+
+// idClaims is the wire format of an OpenID Connect ID token.
+type idClaims struct {
+	jwt.RegisteredClaims
+	AuthTime      *jwt.NumericDate `json:"auth_time"`
+	Nonce         string           `json:"nonce,omitempty"`
+	SessionID     string           `json:"sid,omitempty"`
+	Email         string           `json:"email,omitempty"`
+	EmailVerified *bool            `json:"email_verified,omitempty"`
+}
+
+type JWTService struct {
+	keys      KeyStore
+	config    JWTConfig
+	ttl       atomic.Int64
+	codec     Codec
+	idCodec   *JWTCodec
+	validator *jwt.Validator
+}
+
+func NewJWTService(keys KeyStore, config JWTConfig) *JWTService {
+	codec := config.Codec
+	if codec == nil {
+		codec = NewJWTCodec()
+	}
+
+	service := &JWTService{
+		keys:    keys,
+		config:  config,
+		codec:   codec,
+		idCodec: NewJWTCodec(),
+		validator: jwt.NewValidator(
+			jwt.WithIssuer(config.Issuer),
+			jwt.WithAudience(config.Audience),
+			jwt.WithExpirationRequired(),
+		),
+	}
+	service.SetTTL(config.TTL)
+	return service
+}
+
+// SetTTL changes the lifetime of the tokens issued from now on.
+func (s *JWTService) SetTTL(ttl time.Duration) {
+	s.ttl.Store(int64(ttl))
+}
+
+func (s *JWTService) currentTTL() time.Duration {
+	return time.Duration(s.ttl.Load())
+}
+
+func (s *JWTService) GenerateAccessToken(ctx context.Context, account *models.Account, opts models.AccessTokenOptions) (string, *models.AccessTokenClaims, error) {
+	key, err := s.keys.SigningKey(opts.OrganizationID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ttl := s.currentTTL()
+	if opts.TTL > 0 {
+		ttl = opts.TTL
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	claims := &models.AccessTokenClaims{
+		TokenID:          uuid.NewString(),
+		AccountID:        account.ID,
+		RoleCode:         account.RoleCode,
+		StatusCode:       account.StatusCode,
+		Scope:            opts.Scope,
+		Permissions:      opts.Permissions,
+		SessionID:        opts.SessionID,
+		OrganizationID:   opts.OrganizationID,
+		OrganizationRole: opts.OrganizationRole,
+		AuthTime:         opts.AuthTime,
+		AMR:              opts.AMR,
+		ACR:              opts.ACR,
+		ClientID:         opts.ClientID,
+		Actor:            opts.Actor,
+		KeyThumbprint:    opts.KeyThumbprint,
+		IssuedAt:         now,
+		ExpiresAt:        now.Add(ttl),
+	}
+	if !opts.NotAfter.IsZero() && opts.NotAfter.Before(claims.ExpiresAt) {
+		claims.ExpiresAt = opts.NotAfter.UTC().Truncate(time.Second)
+	}
+
+	audience := s.config.Audience
+	if opts.Audience != "" {
+		audience = opts.Audience
+	}
+
+	var sessionID string
+	if opts.SessionID != uuid.Nil {
+		sessionID = opts.SessionID.String()
+	}
+
+	var organizationID string
+	if opts.OrganizationID != uuid.Nil {
+		organizationID = opts.OrganizationID.String()
+	}
+
+	var authTime *jwt.NumericDate
+	if opts.AuthTime != nil {
+		authTime = jwt.NewNumericDate(*opts.AuthTime)
+	}
+
+	var actor *actorClaim
+	if opts.Actor != nil {
+		actor = &actorClaim{Subject: opts.Actor.Subject, ClientID: opts.Actor.ClientID, Impersonator: opts.Actor.Impersonator}
+	}
+
+	signed, err := s.codec.Encode(ctx, key, &accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        claims.TokenID,
+			Issuer:    s.config.Issuer,
+			Subject:   account.ID.String(),
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			NotBefore: jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+		Role:             account.RoleCode,
+		Status:           account.StatusCode,
+		Scope:            opts.Scope,
+		Permissions:      opts.Permissions,
+		SessionID:        sessionID,
+		OrganizationID:   organizationID,
+		OrganizationRole: opts.OrganizationRole,
+		AuthTime:         authTime,
+		AMR:              opts.AMR,
+		ACR:              opts.ACR,
+		ClientID:         opts.ClientID,
+		Actor:            actor,
+		Confirmation:     confirmation(opts.KeyThumbprint),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, claims, nil
+}
+
+func (s *JWTService) GenerateClientToken(ctx context.Context, client *models.OAuthClient, opts models.ClientTokenOptions) (string, *models.AccessTokenClaims, error) {
+	key, err := s.keys.SigningKey(uuid.Nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	claims := &models.AccessTokenClaims{
+		TokenID:       uuid.NewString(),
+		Scope:         domain.TokenScope(opts.Scope),
+		ClientID:      client.ClientID,
+		KeyThumbprint: opts.KeyThumbprint,
+		IssuedAt:      now,
+		ExpiresAt:     now.Add(s.currentTTL()),
+	}
+
+	signed, err := s.codec.Encode(ctx, key, &accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        claims.TokenID,
+			Issuer:    s.config.Issuer,
+			Subject:   client.ClientID,
+			Audience:  jwt.ClaimStrings{s.config.Audience},
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			NotBefore: jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+		Scope:        claims.Scope,
+		ClientID:     client.ClientID,
+		Confirmation: confirmation(opts.KeyThumbprint),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, claims, nil
+}
+
+func (s *JWTService) ParseAccessToken(ctx context.Context, raw string) (*models.AccessTokenClaims, error) {
+	var parsed accessClaims
+	keys := &verifyingKeyStore{KeyStore: s.keys}
+	if err := s.codec.Decode(ctx, raw, keys, &parsed); err != nil {
+		if errors.Is(err, errTokenLookup) {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidAccessToken
+	}
+	if err := s.validator.Validate(&parsed); err != nil {
+		return nil, domain.ErrInvalidAccessToken
+	}
+
+	claims := &models.AccessTokenClaims{
+		TokenID:     parsed.ID,
+		RoleCode:    parsed.Role,
+		StatusCode:  parsed.Status,
+		Scope:       parsed.Scope,
+		Permissions: parsed.Permissions,
+		AMR:         parsed.AMR,
+		ACR:         parsed.ACR,
+		ExpiresAt:   parsed.ExpiresAt.Time,
+	}
+	if parsed.ClientID != "" && parsed.Subject == parsed.ClientID {
+		claims.ClientID = parsed.ClientID
+	} else {
+		accountID, err := uuid.Parse(parsed.Subject)
+		if err != nil {
+			return nil, domain.ErrInvalidAccessToken
+		}
+		claims.AccountID = accountID
+	}
+	if parsed.SessionID != "" {
+		sessionID, err := uuid.Parse(parsed.SessionID)
+		if err != nil {
+			return nil, domain.ErrInvalidAccessToken
+		}
+		claims.SessionID = sessionID
+	}
+	if parsed.OrganizationID != "" {
+		organizationID, err := uuid.Parse(parsed.OrganizationID)
+		if err != nil {
+			return nil, domain.ErrInvalidAccessToken
+		}
+		claims.OrganizationID = organizationID
+		claims.OrganizationRole = parsed.OrganizationRole
+	}
+	// A key of an organization only vouches for tokens of that
+	// organization, so its compromise leaves other tenants unaffected.
+	if keys.key != nil && keys.key.OrganizationID != uuid.Nil && keys.key.OrganizationID != claims.OrganizationID {
+		return nil, domain.ErrInvalidAccessToken
+	}
+	if parsed.IssuedAt != nil {
+		claims.IssuedAt = parsed.IssuedAt.Time
+	}
+	if parsed.AuthTime != nil {
+		claims.AuthTime = &parsed.AuthTime.Time
+	}
+	if parsed.Actor != nil {
+		claims.Actor = &models.Actor{Subject: parsed.Actor.Subject, ClientID: parsed.Actor.ClientID, Impersonator: parsed.Actor.Impersonator}
+	}
+	if parsed.Confirmation != nil {
+		if parsed.Confirmation.KeyThumbprint == "" {
+			return nil, domain.ErrInvalidAccessToken
+		}
+		claims.KeyThumbprint = parsed.Confirmation.KeyThumbprint
+	}
+
+	return claims, nil
+}
+
+// GenerateIDToken signs an ID token for the client named in opts. It shares
+// the signing keys and lifetime of access tokens.
+func (s *JWTService) GenerateIDToken(ctx context.Context, account *models.Account, opts models.IDTokenOptions) (string, error) {
+	key, err := s.keys.SigningKey(uuid.Nil)
+	if err != nil {
+		return "", err
+	}
+
+	var sessionID string
+	if opts.SessionID != uuid.Nil {
+		sessionID = opts.SessionID.String()
+	}
+
+	var emailVerified *bool
+	if opts.Email != "" {
+		emailVerified = &opts.EmailVerified
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	return s.idCodec.Encode(ctx, key, &idClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.config.Issuer,
+			Subject:   account.ID.String(),
+			Audience:  jwt.ClaimStrings{opts.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.currentTTL())),
+		},
+		AuthTime:      jwt.NewNumericDate(opts.AuthTime),
+		Nonce:         opts.Nonce,
+		SessionID:     sessionID,
+		Email:         opts.Email,
+		EmailVerified: emailVerified,
+	})
+}
+
+// verifyingKeyStore records the key a token was verified with. Opaque tokens
+// are not verified with a key.
+type verifyingKeyStore struct {
+	KeyStore
+	key *SigningKey
+}
+
+func (s *verifyingKeyStore) VerificationKey(id string) (*SigningKey, error) {
+	key, err := s.KeyStore.VerificationKey(id)
+	s.key = key
+	return key, err
+}
+
+func confirmation(keyThumbprint string) *confirmationClaim {
+	if keyThumbprint == "" {
+		return nil
+	}
+	return &confirmationClaim{KeyThumbprint: keyThumbprint}
+}
+
+func (s *JWTService) PublicKeys() []models.PublicSigningKey {
+	return s.keys.PublicKeys()
+}
@@ -0,0 +1,387 @@
+package token
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type KeyManagerConfig struct {
+	// Algorithm of newly generated keys: RS256 or EdDSA.
+	Algorithm string
+	// RotationInterval is how long a key signs tokens before its successor
+	// takes over.
+	RotationInterval time.Duration
+	// PrePublish is how long a new key is published before it starts signing,
+	// giving consumers time to refresh their cached key sets.
+	PrePublish time.Duration
+	// VerificationWindow is how long a retired key stays published. It must
+	// cover the access token lifetime.
+	VerificationWindow time.Duration
+	// RefreshInterval is how often the key set is reloaded and rotation due
+	// dates are checked.
+	RefreshInterval time.Duration
+}
+
+type managedKey struct {
+	key         *SigningKey
+	activatesAt time.Time
+}
+
+// KeyManager keeps signing keys in the database so every instance signs and
+// publishes the same key set, and rotates them on a schedule. Besides the
+// platform keys, organizations may have keys of their own, which rotate on
+// the same schedule but independently, and can be revoked without affecting
+// other organizations.
+type KeyManager struct {
+	repo      repositories.SigningKeyRepository
+	txManager ports.TxManager
+	cipher    *security.Cipher
+	config    KeyManagerConfig
+
+	mu   sync.RWMutex
+	keys []managedKey
+}
+
+func NewKeyManager(
+	repo repositories.SigningKeyRepository,
+	txManager ports.TxManager,
+	cipher *security.Cipher,
+	config KeyManagerConfig,
+) (*KeyManager, error) {
+	switch config.Algorithm {
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+	default:
+		return nil, fmt.Errorf("key manager: unsupported algorithm %q", config.Algorithm)
+	}
+
+	return &KeyManager{
+		repo:      repo,
+		txManager: txManager,
+		cipher:    cipher,
+		config:    config,
+	}, nil
+}
+
+// Start performs the first rotation check and load synchronously, so the
+// service never starts without a usable key, then keeps refreshing in the
+// background until ctx is cancelled.
+func (m *KeyManager) Start(ctx context.Context) error {
+	if err := m.refresh(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(m.config.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.refresh(ctx); err != nil {
+					slog.ErrorContext(ctx, "refresh signing keys", "error", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (m *KeyManager) SigningKey(organizationID uuid.UUID) (*SigningKey, error) {
+	now := time.Now()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if organizationID != uuid.Nil {
+		if key := m.signingKey(organizationID, now); key != nil {
+			return key, nil
+		}
+	}
+	if key := m.signingKey(uuid.Nil, now); key != nil {
+		return key, nil
+	}
+	return nil, errUnknownKey
+}
+
+// signingKey returns the newest active key of an organization, or of the
+// platform for uuid.Nil. The caller must hold m.mu.
+func (m *KeyManager) signingKey(organizationID uuid.UUID, now time.Time) *SigningKey {
+	for _, k := range m.keys {
+		if k.key.OrganizationID == organizationID && !k.activatesAt.After(now) {
+			return k.key
+		}
+	}
+	return nil
+}
+
+func (m *KeyManager) VerificationKey(id string) (*SigningKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, k := range m.keys {
+		if k.key.ID == id {
+			return k.key, nil
+		}
+	}
+	return nil, errUnknownKey
+}
+
+func (m *KeyManager) PublicKeys() []models.PublicSigningKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]models.PublicSigningKey, 0, len(m.keys))
+	for _, k := range m.keys {
+		keys = append(keys, k.key.Public())
+	}
+	return keys
+}
+
+func (m *KeyManager) refresh(ctx context.Context) error {
+	if err := m.rotate(ctx, false, uuid.Nil); err != nil {
+		return err
+	}
+	return m.load(ctx)
+}
+
+// rotate rotates the platform keys and those of every organization that has
+// published keys. When force is set, the keys of organizationID rotate
+// immediately, and an organization without keys gets its first one.
+func (m *KeyManager) rotate(ctx context.Context, force bool, organizationID uuid.UUID) error {
+	return m.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := m.repo.AcquireRotationLock(txCtx); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		keys, err := m.repo.ListPublished(txCtx, now)
+		if err != nil {
+			return err
+		}
+
+		byOrganization := map[uuid.UUID][]*models.SigningKey{uuid.Nil: nil}
+		if force {
+			byOrganization[organizationID] = nil
+		}
+		for _, key := range keys {
+			owner := keyOrganization(key)
+			byOrganization[owner] = append(byOrganization[owner], key)
+		}
+
+		for owner, owned := range byOrganization {
+			if err := m.rotateKeys(txCtx, owner, owned, now, force && owner == organizationID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// rotateKeys schedules a successor for the newest key of an organization, or
+// of the platform for uuid.Nil, when it is due (or immediately when forced)
+// and retires keys whose successor has activated. keys are newest first.
+func (m *KeyManager) rotateKeys(ctx context.Context, organizationID uuid.UUID, keys []*models.SigningKey, now time.Time, force bool) error {
+	if len(keys) == 0 {
+		// The platform cannot sign without a key, while organizations
+		// fall back to the platform key until theirs is published.
+		if organizationID == uuid.Nil {
+			return m.generate(ctx, organizationID, now)
+		}
+		return m.generate(ctx, organizationID, now.Add(m.config.PrePublish))
+	}
+
+	newest := keys[0]
+	due := newest.ActivatesAt.Add(m.config.RotationInterval - m.config.PrePublish)
+	if force || !now.Before(due) {
+		activatesAt := newest.ActivatesAt.Add(m.config.RotationInterval)
+		if earliest := now.Add(m.config.PrePublish); activatesAt.Before(earliest) {
+			activatesAt = earliest
+		}
+		if err := m.generate(ctx, organizationID, activatesAt); err != nil {
+			return err
+		}
+	}
+
+	var current *models.SigningKey
+	for _, key := range keys {
+		if !key.ActivatesAt.After(now) {
+			current = key
+			break
+		}
+	}
+	if current == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		if key.RetiredAt != nil || !key.ActivatesAt.Before(current.ActivatesAt) {
+			continue
+		}
+		expiresAt := current.ActivatesAt.Add(m.config.VerificationWindow)
+		if err := m.repo.Retire(ctx, key.ID, current.ActivatesAt, expiresAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rotate schedules a new platform key right away, regardless of the rotation
+// interval. The key starts signing once the pre-publication period has
+// elapsed.
+func (m *KeyManager) Rotate(ctx context.Context) error {
+	if err := m.rotate(ctx, true, uuid.Nil); err != nil {
+		return err
+	}
+	return m.load(ctx)
+}
+
+// PlatformKeys returns the published platform keys, newest first, without
+// their private halves.
+func (m *KeyManager) PlatformKeys(ctx context.Context) ([]*models.SigningKey, error) {
+	return m.OrganizationKeys(ctx, uuid.Nil)
+}
+
+// OrganizationKeys returns the published keys of an organization, newest
+// first, without their private halves.
+func (m *KeyManager) OrganizationKeys(ctx context.Context, organizationID uuid.UUID) ([]*models.SigningKey, error) {
+	keys, err := m.repo.ListPublished(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]*models.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if keyOrganization(key) == organizationID {
+			key.PrivateKey = nil
+			owned = append(owned, key)
+		}
+	}
+	return owned, nil
+}
+
+// RotateOrganizationKey schedules a new key for an organization right away,
+// its first one if it has none. Like platform keys, it starts signing once
+// the pre-publication period has elapsed, and the organization's keys rotate
+// on schedule from then on.
+func (m *KeyManager) RotateOrganizationKey(ctx context.Context, organizationID uuid.UUID) error {
+	if err := m.rotate(ctx, true, organizationID); err != nil {
+		return err
+	}
+	return m.load(ctx)
+}
+
+// RevokeOrganizationKeys unpublishes every key of an organization at once,
+// so tokens they signed no longer verify; other instances follow at their
+// next refresh. The organization's tokens are signed with the platform keys
+// from then on.
+func (m *KeyManager) RevokeOrganizationKeys(ctx context.Context, organizationID uuid.UUID) error {
+	err := m.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := m.repo.AcquireRotationLock(txCtx); err != nil {
+			return err
+		}
+		return m.repo.ExpireByOrganizationID(txCtx, organizationID, time.Now().UTC())
+	})
+	if err != nil {
+		return err
+	}
+	return m.load(ctx)
+}
+
+func (m *KeyManager) generate(ctx context.Context, organizationID uuid.UUID, activatesAt time.Time) error {
+	var private any
+	var err error
+	switch m.config.Algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		private, err = rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	default:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		return err
+	}
+
+	key, err := NewSigningKey(private)
+	if err != nil {
+		return err
+	}
+	var owner *uuid.UUID
+	if organizationID != uuid.Nil {
+		key.forOrganization(organizationID)
+		owner = &organizationID
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+	sealed, err := m.cipher.Encrypt(der)
+	if err != nil {
+		return err
+	}
+
+	return m.repo.Create(ctx, &models.SigningKey{
+		ID:             key.ID,
+		Algorithm:      key.Method.Alg(),
+		PrivateKey:     sealed,
+		OrganizationID: owner,
+		ActivatesAt:    activatesAt,
+	})
+}
+
+func (m *KeyManager) load(ctx context.Context) error {
+	stored, err := m.repo.ListPublished(ctx, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	keys := make([]managedKey, 0, len(stored))
+	for _, s := range stored {
+		der, err := m.cipher.Decrypt(s.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("decrypt signing key %s: %w", s.ID, err)
+		}
+		private, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return fmt.Errorf("parse signing key %s: %w", s.ID, err)
+		}
+		key, err := NewSigningKey(private)
+		if err != nil {
+			return err
+		}
+		if s.OrganizationID != nil {
+			key.forOrganization(*s.OrganizationID)
+		}
+		keys = append(keys, managedKey{key: key, activatesAt: s.ActivatesAt})
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.mu.Unlock()
+	return nil
+}
+
+// keyOrganization returns the organization of a key, uuid.Nil for platform
+// keys.
+func keyOrganization(key *models.SigningKey) uuid.UUID {
+	if key.OrganizationID == nil {
+		return uuid.Nil
+	}
+	return *key.OrganizationID
+}
@@ -0,0 +1,73 @@
+package token
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// KeyStore supplies the key new tokens are signed with and the keys that
+// tokens already in circulation can be verified against.
+type KeyStore interface {
+	// SigningKey returns the key that signs the access tokens of an
+	// organization, which is the platform key for uuid.Nil and for
+	// organizations without keys of their own.
+	SigningKey(organizationID uuid.UUID) (*SigningKey, error)
+	VerificationKey(id string) (*SigningKey, error)
+	PublicKeys() []models.PublicSigningKey
+}
+
+// StaticKeyStore serves a single key that never rotates on its own and
+// signs for every organization. Replace swaps it for a new key, such as one
+// re-fetched from a secrets backend, and keeps the previous key verifiable
+// until the next replacement so tokens already issued stay valid.
+type StaticKeyStore struct {
+	mu       sync.RWMutex
+	key      *SigningKey
+	previous *SigningKey
+}
+
+func NewStaticKeyStore(key *SigningKey) *StaticKeyStore {
+	return &StaticKeyStore{key: key}
+}
+
+// Replace makes key the signing key. Replacing a key with itself is a no-op.
+func (s *StaticKeyStore) Replace(key *SigningKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key.ID == s.key.ID {
+		return
+	}
+	s.previous, s.key = s.key, key
+}
+
+func (s *StaticKeyStore) SigningKey(organizationID uuid.UUID) (*SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.key, nil
+}
+
+func (s *StaticKeyStore) VerificationKey(id string) (*SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, key := range []*SigningKey{s.key, s.previous} {
+		if key != nil && key.ID == id {
+			return key, nil
+		}
+	}
+	return nil, errUnknownKey
+}
+
+func (s *StaticKeyStore) PublicKeys() []models.PublicSigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := []models.PublicSigningKey{s.key.Public()}
+	if s.previous != nil {
+		keys = append(keys, s.previous.Public())
+	}
+	return keys
+}
@@ -0,0 +1,103 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const minRSAKeyBits = 2048
+
+// SigningKey is a private key together with the JWS algorithm and key ID it
+// is published under. Keys of an organization sign the access tokens of its
+// sessions only and publish under a kid namespaced by the organization.
+type SigningKey struct {
+	ID             string
+	Method         jwt.SigningMethod
+	PrivateKey     crypto.Signer
+	OrganizationID uuid.UUID
+}
+
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+func (k *SigningKey) Public() models.PublicSigningKey {
+	return models.PublicSigningKey{
+		ID:        k.ID,
+		Algorithm: k.Method.Alg(),
+		Key:       k.PublicKey(),
+	}
+}
+
+// ParseSigningKey reads a PEM encoded RSA (PKCS#1 or PKCS#8) or Ed25519
+// (PKCS#8) private key. RSA keys sign with RS256 and Ed25519 keys with EdDSA.
+func ParseSigningKey(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key: no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key: unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+
+	return NewSigningKey(parsed)
+}
+
+// NewSigningKey wraps an RSA or Ed25519 private key, deriving its key ID from
+// the public key so the same key always publishes under the same kid.
+func NewSigningKey(key any) (*SigningKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("signing key: RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return newSigningKey(k, jwt.SigningMethodRS256)
+	case ed25519.PrivateKey:
+		return newSigningKey(k, jwt.SigningMethodEdDSA)
+	default:
+		return nil, fmt.Errorf("signing key: unsupported key type %T", key)
+	}
+}
+
+// forOrganization dedicates the key to an organization, prefixing its kid
+// with the organization ID: "<organization id>.<kid>".
+func (k *SigningKey) forOrganization(organizationID uuid.UUID) {
+	k.ID = organizationID.String() + "." + k.ID
+	k.OrganizationID = organizationID
+}
+
+func newSigningKey(signer crypto.Signer, method jwt.SigningMethod) (*SigningKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+
+	return &SigningKey{
+		ID:         base64.RawURLEncoding.EncodeToString(sum[:12]),
+		Method:     method,
+		PrivateKey: signer,
+	}, nil
+}
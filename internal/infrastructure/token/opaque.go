@@ -0,0 +1,74 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// errTokenLookup marks failures to reach the token store, which must not be
+// mistaken for invalid tokens.
+var errTokenLookup = errors.New("access token lookup failed")
+
+// OpaqueCodec issues random access tokens and keeps their claims in the
+// database, for deployments where tokens must not carry claims. Only this
+// service can resolve them, so resource servers introspect them instead of
+// verifying them. Signing keys are not used.
+type OpaqueCodec struct {
+	tokens repositories.AccessTokenRepository
+}
+
+func NewOpaqueCodec(tokens repositories.AccessTokenRepository) *OpaqueCodec {
+	return &OpaqueCodec{tokens: tokens}
+}
+
+func (c *OpaqueCodec) Encode(ctx context.Context, key *SigningKey, claims jwt.Claims) (string, error) {
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil {
+		return "", err
+	}
+	if expiresAt == nil {
+		return "", errors.New("opaque token: missing expiration")
+	}
+
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := security.GenerateOpaqueToken(domain.OpaqueAccessTokenBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.tokens.Create(ctx, &models.AccessToken{
+		ID:        uuid.New(),
+		TokenHash: security.HashToken(token),
+		Claims:    encoded,
+		ExpiresAt: expiresAt.Time,
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (c *OpaqueCodec) Decode(ctx context.Context, raw string, keys KeyStore, claims jwt.Claims) error {
+	stored, err := c.tokens.GetByTokenHash(ctx, security.HashToken(raw), time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return errors.New("opaque token: unknown token")
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", errTokenLookup, err)
+	}
+
+	return json.Unmarshal(stored.Claims, claims)
+}
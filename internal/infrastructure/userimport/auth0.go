@@ -0,0 +1,89 @@
+package userimport
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// auth0User is a user of an Auth0 export: the password hash export, with
+// bcrypt hashes in passwordHash, or the bulk import format, with hashes of
+// other algorithms in custom_password_hash.
+type auth0User struct {
+	Email              string `json:"email"`
+	EmailVerified      bool   `json:"email_verified"`
+	PasswordHash       string `json:"passwordHash"`
+	CustomPasswordHash *struct {
+		Algorithm string `json:"algorithm"`
+		Hash      struct {
+			Value    string `json:"value"`
+			Encoding string `json:"encoding"`
+		} `json:"hash"`
+	} `json:"custom_password_hash"`
+}
+
+// ReadAuth0 calls each with every user of an Auth0 export, given as one JSON
+// object per line or as a JSON array. Hashes are passed on as exported, so
+// algorithms other than bcrypt and PBKDF2 are refused on import.
+func ReadAuth0(r io.Reader, each func(*models.ImportedUser) error) error {
+	reader := bufio.NewReader(r)
+	decoder := json.NewDecoder(reader)
+	array, err := startsArray(reader)
+	if err != nil {
+		return err
+	}
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+
+	for n := 1; ; n++ {
+		if array && !decoder.More() {
+			_, err := decoder.Token()
+			return err
+		}
+
+		var user auth0User
+		if err := decoder.Decode(&user); errors.Is(err, io.EOF) && !array {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("user %d: %w", n, err)
+		}
+
+		hash := user.PasswordHash
+		if custom := user.CustomPasswordHash; custom != nil && hash == "" {
+			if custom.Hash.Encoding != "" && custom.Hash.Encoding != "utf8" {
+				return fmt.Errorf("user %d: unsupported %s hash encoding %q", n, custom.Algorithm, custom.Hash.Encoding)
+			}
+			hash = custom.Hash.Value
+		}
+		if err := each(&models.ImportedUser{Email: user.Email, EmailVerified: user.EmailVerified, PasswordHash: hash}); err != nil {
+			return err
+		}
+	}
+}
+
+// startsArray reports whether the first value of r is a JSON array, without
+// consuming it.
+func startsArray(r *bufio.Reader) (bool, error) {
+	for {
+		b, err := r.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = r.ReadByte()
+		default:
+			return b[0] == '[', nil
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package userimport
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+)
+
+// firebaseUser is a user of a Firebase auth:export file. Password hashes and
+// salts are base64 encoded.
+type firebaseUser struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	PasswordHash  string `json:"passwordHash"`
+	Salt          string `json:"salt"`
+}
+
+// ReadFirebase calls each with every user of a Firebase auth:export file,
+// {"users": […]}, encoding password hashes with the scrypt parameters of
+// the project they were exported from. Users without an email are skipped.
+func ReadFirebase(r io.Reader, params security.FirebaseScryptParams, each func(*models.ImportedUser) error) error {
+	decoder := json.NewDecoder(r)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if key != "users" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(decoder, '['); err != nil {
+			return err
+		}
+		for n := 1; decoder.More(); n++ {
+			var user firebaseUser
+			if err := decoder.Decode(&user); err != nil {
+				return fmt.Errorf("user %d: %w", n, err)
+			}
+			if user.Email == "" {
+				continue
+			}
+
+			imported := &models.ImportedUser{Email: user.Email, EmailVerified: user.EmailVerified}
+			if user.PasswordHash != "" {
+				if imported.PasswordHash, err = encodeFirebaseHash(params, user); err != nil {
+					return fmt.Errorf("user %d: %w", n, err)
+				}
+			}
+			if err := each(imported); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(decoder, ']'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+func encodeFirebaseHash(params security.FirebaseScryptParams, user firebaseUser) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(user.PasswordHash)
+	if err != nil {
+		return "", fmt.Errorf("decode password hash: %w", err)
+	}
+	salt, err := base64.StdEncoding.DecodeString(user.Salt)
+	if err != nil {
+		return "", fmt.Errorf("decode salt: %w", err)
+	}
+	return security.EncodeFirebaseScrypt(params, salt, key)
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
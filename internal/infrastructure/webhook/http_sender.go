@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// The headers a webhook is sent with. SignatureHeader holds
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">".
+const (
+	SignatureHeader = "Ranco-Signature"
+	EventHeader     = "Ranco-Event"
+	DeliveryHeader  = "Ranco-Delivery"
+	userAgent       = "Ranco-Webhooks/1"
+)
+
+// HTTPSender posts webhooks as signed JSON. Redirects are not followed, so an
+// endpoint cannot bounce a signed payload to another host.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: domain.WebhookTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, request ports.WebhookRequest) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, request.Event)
+	req.Header.Set(DeliveryHeader, request.DeliveryID.String())
+	req.Header.Set(SignatureHeader, Sign(request.Secret, time.Now(), request.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode/100 != 2 {
+		return resp.StatusCode, fmt.Errorf("send webhook: endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header of a payload sent at t. Receivers
+// recompute the HMAC over the timestamp and the raw body and should reject
+// timestamps too far from their clock, so a captured request cannot be
+// replayed later.
+func Sign(secret []byte, t time.Time, payload []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+type scopeKey struct{}
+
+// scope holds the attributes of a request, shared by every context derived
+// from the one it was started in.
+type scope struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// NewContext starts a scope, typically for a request, whose attributes are
+// added to every record logged with ctx or a context derived from it.
+func NewContext(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{attrs: attrs})
+}
+
+// Add adds attrs to the scope of ctx once they are known, such as the
+// account after authentication. They are logged from then on by every
+// context of the scope, the callers' included. Without a scope Add does
+// nothing.
+func Add(ctx context.Context, attrs ...slog.Attr) {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+func scopeAttrs(ctx context.Context) []slog.Attr {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]slog.Attr(nil), s.attrs...)
+}
@@ -0,0 +1,71 @@
+// Package logging builds the structured logger of the service. Records carry
+// the attributes of the request they were logged in, such as its request id,
+// and the trace and span ids of its span, and never the values of secrets
+// and tokens.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// New returns a logger writing records of level and above to w, as JSON or,
+// with format "text", as key=value pairs.
+func New(w io.Writer, format string, level slog.Level) (*slog.Logger, error) {
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "", "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+// ParseLevel parses debug, info, warn or error.
+func ParseLevel(raw string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(raw)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", raw)
+	}
+	return level, nil
+}
+
+// contextHandler adds the attributes of the scope and span of the context a
+// record is logged with, and masks the tokens in its message.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, redactString(r.Message), r.PC)
+	record.AddAttrs(scopeAttrs(ctx)...)
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", span.TraceID().String()),
+			slog.String("span_id", span.SpanID().String()),
+		)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(a)
+		return true
+	})
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are the attribute keys, compared case-insensitively with
+// underscores and dashes removed, whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"password":        true,
+	"currentpassword": true,
+	"newpassword":     true,
+	"secret":          true,
+	"clientsecret":    true,
+	"token":           true,
+	"accesstoken":     true,
+	"refreshtoken":    true,
+	"idtoken":         true,
+	"mfatoken":        true,
+	"apikey":          true,
+	"code":            true,
+	"codeverifier":    true,
+	"authorization":   true,
+	"cookie":          true,
+	"setcookie":       true,
+}
+
+// tokenPattern matches the credentials that can end up inside messages and
+// errors: JWTs, which start with an encoded JSON header, and the values of
+// Bearer and DPoP authorization headers.
+var tokenPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*|(?i:bearer|dpop) [A-Za-z0-9._~+/-]+=*`)
+
+func sensitive(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveKeys[key]
+}
+
+// redactString masks the tokens found in s.
+func redactString(s string) string {
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
+
+// redact masks the value of a with a sensitive key and the tokens found in
+// string and error values. It is used as the ReplaceAttr of the handler, so
+// it also sees the attributes within groups.
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if sensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	switch value := a.Value.Resolve(); value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, redactString(value.String()))
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return slog.String(a.Key, redactString(err.Error()))
+		}
+	}
+	return a
+}
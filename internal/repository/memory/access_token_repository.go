@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+type accessTokenRepository struct {
+	store *Store
+}
+
+func NewAccessTokenRepository(store *Store) repositories.AccessTokenRepository {
+	return &accessTokenRepository{
+		store: store,
+	}
+}
+
+func (r *accessTokenRepository) Create(ctx context.Context, token *models.AccessToken) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if r.store.accessTokens.exists(func(t *models.AccessToken) bool { return t.TokenHash == token.TokenHash }) {
+		return domain.ErrConflict
+	}
+	row := &models.AccessToken{
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		Claims:    slices.Clone(token.Claims),
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.accessTokens.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	token.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *accessTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*models.AccessToken, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	token, ok := r.store.accessTokens.first(func(t *models.AccessToken) bool {
+		return t.TokenHash == tokenHash && t.ExpiresAt.After(now)
+	})
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return token, nil
+}
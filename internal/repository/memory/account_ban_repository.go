@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type accountBanRepository struct {
+	store *Store
+}
+
+func NewAccountBanRepository(store *Store) repositories.AccountBanRepository {
+	return &accountBanRepository{
+		store: store,
+	}
+}
+
+func (r *accountBanRepository) Create(ctx context.Context, ban *models.AccountBan) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(ban.AccountID), optional(&r.store.accounts, ban.BannedBy)); err != nil {
+		return err
+	}
+	// An account has at most one ban that is not lifted.
+	if r.store.accountBans.exists(func(b *models.AccountBan) bool { return b.AccountID == ban.AccountID && b.LiftedAt == nil }) {
+		return domain.ErrConflict
+	}
+	row := &models.AccountBan{
+		ID:             ban.ID,
+		AccountID:      ban.AccountID,
+		PreviousStatus: ban.PreviousStatus,
+		Reason:         ban.Reason,
+		BannedBy:       ban.BannedBy,
+		ExpiresAt:      ban.ExpiresAt,
+		CreatedAt:      timestamp(),
+	}
+	if err := r.store.accountBans.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*ban = *row
+	return nil
+}
+
+func (r *accountBanRepository) GetActive(ctx context.Context, accountID uuid.UUID) (*models.AccountBan, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	ban, ok := r.store.accountBans.first(func(b *models.AccountBan) bool { return b.AccountID == accountID && b.LiftedAt == nil })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return ban, nil
+}
+
+func (r *accountBanRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AccountBan, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	bans := r.store.accountBans.find(func(b *models.AccountBan) bool { return b.AccountID == accountID })
+	sortNewestFirst(bans, func(b *models.AccountBan) time.Time { return b.CreatedAt })
+	return bans, nil
+}
+
+func (r *accountBanRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.AccountBan, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	bans := r.store.accountBans.find(func(b *models.AccountBan) bool {
+		return b.LiftedAt == nil && b.ExpiresAt != nil && !b.ExpiresAt.After(now)
+	})
+	sortOldestFirst(bans, func(b *models.AccountBan) time.Time { return *b.ExpiresAt })
+	return page(bans, 0, limit), nil
+}
+
+func (r *accountBanRepository) Lift(ctx context.Context, id uuid.UUID, at time.Time, liftedBy *uuid.UUID, reason string) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(optional(&r.store.accounts, liftedBy)); err != nil {
+		return err
+	}
+	_, err := r.store.accountBans.update(tx, id, func(b *models.AccountBan) bool {
+		if b.LiftedAt != nil {
+			return false
+		}
+		b.LiftedAt = &at
+		b.LiftedBy = liftedBy
+		b.LiftReason = reason
+		return true
+	})
+	return err
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type accountDeletionRepository struct {
+	store *Store
+}
+
+func NewAccountDeletionRepository(store *Store) repositories.AccountDeletionRepository {
+	return &accountDeletionRepository{
+		store: store,
+	}
+}
+
+func (r *accountDeletionRepository) Create(ctx context.Context, deletion *models.AccountDeletion) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(deletion.AccountID)); err != nil {
+		return err
+	}
+	row := &models.AccountDeletion{
+		AccountID: deletion.AccountID,
+		PurgeAt:   deletion.PurgeAt,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.accountDeletions.insert(tx, row.AccountID, row); err != nil {
+		return err
+	}
+	*deletion = *row
+	return nil
+}
+
+func (r *accountDeletionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletion, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	deletion, ok := r.store.accountDeletions.get(accountID)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return deletion, nil
+}
+
+func (r *accountDeletionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AccountDeletion, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	deletions := r.store.accountDeletions.find(func(d *models.AccountDeletion) bool { return !d.PurgeAt.After(now) })
+	sortOldestFirst(deletions, func(d *models.AccountDeletion) time.Time { return d.PurgeAt })
+	return page(deletions, 0, limit), nil
+}
+
+func (r *accountDeletionRepository) Delete(ctx context.Context, accountID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.accountDeletions.remove(tx, accountID) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
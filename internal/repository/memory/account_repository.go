@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type accountRepository struct {
+	store *Store
+}
+
+func NewAccountRepository(store *Store) repositories.AccountRepository {
+	return &accountRepository{
+		store: store,
+	}
+}
+
+func (r *accountRepository) Create(ctx context.Context, account *models.Account) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.roles.has(account.RoleCode)); err != nil {
+		return err
+	}
+	row := &models.Account{
+		ID:         account.ID,
+		RoleCode:   account.RoleCode,
+		StatusCode: account.StatusCode,
+		CreatedAt:  timestamp(),
+	}
+	if err := r.store.accounts.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*account = *row
+	return nil
+}
+
+func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	account, ok := r.store.accounts.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return account, nil
+}
+
+// GetByIDForUpdate needs no lock of its own: transactions already run one at
+// a time.
+func (r *accountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *accountRepository) ListAfter(ctx context.Context, after models.AccountCursor, limit int) ([]*models.Account, error) {
+	return r.Search(ctx, models.AccountFilter{}, after, limit)
+}
+
+func (r *accountRepository) Search(ctx context.Context, filter models.AccountFilter, after models.AccountCursor, limit int) ([]*models.Account, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	accounts := r.store.accounts.find(func(a *models.Account) bool {
+		return compareAccountCursors(a.Cursor(), after) > 0 && r.matches(a, filter)
+	})
+	slices.SortFunc(accounts, func(a, b *models.Account) int {
+		return compareAccountCursors(a.Cursor(), b.Cursor())
+	})
+	return page(accounts, 0, limit), nil
+}
+
+// matches must be called with the store locked.
+func (r *accountRepository) matches(account *models.Account, filter models.AccountFilter) bool {
+	switch {
+	case filter.Role != "" && account.RoleCode != filter.Role,
+		filter.Status != "" && account.StatusCode != filter.Status,
+		!filter.CreatedFrom.IsZero() && account.CreatedAt.Before(filter.CreatedFrom),
+		!filter.CreatedBefore.IsZero() && !account.CreatedAt.Before(filter.CreatedBefore):
+		return false
+	}
+	if filter.Provider == "" && filter.Email == "" {
+		return true
+	}
+
+	matchesProvider, matchesEmail := filter.Provider == "", filter.Email == ""
+	for _, method := range r.store.authMethods.find(func(m *models.AuthMethod) bool { return m.AccountID == account.ID }) {
+		if method.ProviderCode == filter.Provider {
+			matchesProvider = true
+		}
+		if method.ProviderCode == domain.ProviderEmail && strings.Contains(method.ProviderID, filter.Email) {
+			matchesEmail = true
+		}
+	}
+	return matchesProvider && matchesEmail
+}
+
+func (r *accountRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Account, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.store.accounts.find(func(a *models.Account) bool { return slices.Contains(ids, a.ID) }), nil
+}
+
+func (r *accountRepository) ListIDsByRoleForUpdate(ctx context.Context, role domain.Role, status domain.Status) ([]uuid.UUID, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	ids := make([]uuid.UUID, 0)
+	for _, account := range r.store.accounts.find(func(a *models.Account) bool { return a.RoleCode == role && a.StatusCode == status }) {
+		ids = append(ids, account.ID)
+	}
+	slices.SortFunc(ids, compareUUIDs)
+	return ids, nil
+}
+
+func (r *accountRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.Status) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.accounts.update(tx, id, func(a *models.Account) bool {
+		a.StatusCode = status
+		return true
+	})
+	return err
+}
+
+func (r *accountRepository) UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.accounts.has(id) {
+		return domain.ErrNotFound
+	}
+	if err := references(r.store.roles.has(role)); err != nil {
+		return err
+	}
+	_, err := r.store.accounts.update(tx, id, func(a *models.Account) bool {
+		a.RoleCode = role
+		return true
+	})
+	return err
+}
+
+func (r *accountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.deleteAccount(tx, id) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *accountRepository) Erase(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.accounts.has(id) {
+		return domain.ErrNotFound
+	}
+	r.store.eraseAccount(tx, id)
+	return nil
+}
+
+// compareAccountCursors orders accounts by creation time, then id.
+func compareAccountCursors(a, b models.AccountCursor) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return compareUUIDs(a.ID, b.ID)
+}
+
+func compareUUIDs(a, b uuid.UUID) int {
+	return strings.Compare(string(a[:]), string(b[:]))
+}
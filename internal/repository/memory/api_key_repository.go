@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type apiKeyRepository struct {
+	store *Store
+}
+
+func NewAPIKeyRepository(store *Store) repositories.APIKeyRepository {
+	return &apiKeyRepository{
+		store: store,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(key.AccountID)); err != nil {
+		return err
+	}
+	if r.store.apiKeys.exists(func(k *models.APIKey) bool { return k.KeyHash == key.KeyHash }) {
+		return domain.ErrConflict
+	}
+	row := &models.APIKey{
+		ID:        key.ID,
+		AccountID: key.AccountID,
+		Name:      key.Name,
+		KeyHash:   key.KeyHash,
+		KeyPrefix: key.KeyPrefix,
+		Scopes:    slices.Clone(key.Scopes),
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.apiKeys.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*key = *row
+	return nil
+}
+
+func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	key, ok := r.store.apiKeys.first(func(k *models.APIKey) bool { return k.KeyHash == keyHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.APIKey, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	keys := r.store.apiKeys.find(func(k *models.APIKey) bool { return k.AccountID == accountID && k.RevokedAt == nil })
+	sortNewestFirst(keys, func(k *models.APIKey) time.Time { return k.CreatedAt })
+	return keys, nil
+}
+
+func (r *apiKeyRepository) CountActive(ctx context.Context, accountID uuid.UUID, now time.Time) (int, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return len(r.store.apiKeys.find(func(k *models.APIKey) bool { return k.AccountID == accountID && k.Usable(now) })), nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id, accountID uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.apiKeys.update(tx, id, func(k *models.APIKey) bool {
+		if k.AccountID != accountID || k.RevokedAt != nil {
+			return false
+		}
+		k.RevokedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *apiKeyRepository) RecordUse(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.apiKeys.update(tx, id, func(k *models.APIKey) bool {
+		k.LastUsedAt = &at
+		return true
+	})
+	return err
+}
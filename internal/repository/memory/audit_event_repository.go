@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type auditEventRepository struct {
+	store *Store
+}
+
+func NewAuditEventRepository(store *Store) repositories.AuditEventRepository {
+	return &auditEventRepository{
+		store: store,
+	}
+}
+
+func (r *auditEventRepository) Append(ctx context.Context, event *models.AuditEvent) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	details := maps.Clone(event.Details)
+	if details == nil {
+		details = map[string]string{}
+	}
+	row := copyOf(event)
+	row.Details = details
+	row.CreatedAt = timestamp()
+	if err := r.store.auditEvents.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	event.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *auditEventRepository) Search(ctx context.Context, filter models.AuditEventFilter, after models.AuditEventCursor, limit int) ([]*models.AuditEvent, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	events := r.store.auditEvents.find(func(e *models.AuditEvent) bool {
+		cursor := models.AuditEventCursor{CreatedAt: e.CreatedAt, ID: e.ID}
+		return (after.CreatedAt.IsZero() || compareAuditCursors(cursor, after) > 0) && matchesAuditFilter(e, filter)
+	})
+	slices.SortFunc(events, func(a, b *models.AuditEvent) int {
+		return compareAuditCursors(models.AuditEventCursor{CreatedAt: a.CreatedAt, ID: a.ID}, models.AuditEventCursor{CreatedAt: b.CreatedAt, ID: b.ID})
+	})
+	return page(events, 0, limit), nil
+}
+
+// compareAuditCursors orders events newest first, by creation time and then
+// ID.
+func compareAuditCursors(a, b models.AuditEventCursor) int {
+	if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+		return c
+	}
+	return compareUUIDs(b.ID, a.ID)
+}
+
+func matchesAuditFilter(event *models.AuditEvent, filter models.AuditEventFilter) bool {
+	switch {
+	case filter.ActorID != uuid.Nil && (event.ActorID == nil || *event.ActorID != filter.ActorID):
+		return false
+	case filter.TargetID != uuid.Nil && (event.TargetID == nil || *event.TargetID != filter.TargetID):
+		return false
+	case len(filter.Actions) > 0 && !slices.Contains(filter.Actions, event.Action):
+		return false
+	case !filter.CreatedFrom.IsZero() && event.CreatedAt.Before(filter.CreatedFrom):
+		return false
+	case !filter.CreatedBefore.IsZero() && !event.CreatedAt.Before(filter.CreatedBefore):
+		return false
+	}
+	return true
+}
+
+func (r *auditEventRepository) ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	events := r.store.auditEvents.find(func(e *models.AuditEvent) bool {
+		return e.CreatedAt.Before(before) && (e.IPAddress != nil || e.UserAgent != nil)
+	})
+	events = page(events, 0, limit)
+	for _, event := range events {
+		event.IPAddress, event.UserAgent = nil, nil
+		r.store.auditEvents.put(tx, event.ID, event)
+	}
+	return int64(len(events)), nil
+}
+
+func (r *auditEventRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	events := r.store.auditEvents.find(func(e *models.AuditEvent) bool { return e.CreatedAt.Before(before) })
+	events = page(events, 0, limit)
+	for _, event := range events {
+		r.store.auditEvents.remove(tx, event.ID)
+	}
+	return int64(len(events)), nil
+}
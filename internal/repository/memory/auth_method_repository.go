@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type authMethodRepository struct {
+	store *Store
+}
+
+func NewAuthMethodRepository(store *Store) repositories.AuthMethodRepository {
+	return &authMethodRepository{
+		store: store,
+	}
+}
+
+func (r *authMethodRepository) Create(ctx context.Context, method *models.AuthMethod) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(method.AccountID), r.store.providers.has(method.ProviderCode)); err != nil {
+		return err
+	}
+	if r.providerTaken(method.ProviderCode, method.ProviderID, uuid.Nil) {
+		return domain.ErrConflict
+	}
+	row := &models.AuthMethod{
+		ID:           method.ID,
+		AccountID:    method.AccountID,
+		ProviderCode: method.ProviderCode,
+		ProviderID:   method.ProviderID,
+		IsVerified:   method.IsVerified,
+	}
+	if err := r.store.authMethods.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*method = *row
+	return nil
+}
+
+// providerTaken reports whether a method other than except has the provider
+// identity, which is unique.
+func (r *authMethodRepository) providerTaken(provider domain.Provider, providerID string, except uuid.UUID) bool {
+	return r.store.authMethods.exists(func(m *models.AuthMethod) bool {
+		return m.ID != except && m.ProviderCode == provider && m.ProviderID == providerID
+	})
+}
+
+func (r *authMethodRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AuthMethod, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	method, ok := r.store.authMethods.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return method, nil
+}
+
+func (r *authMethodRepository) GetByProvider(ctx context.Context, provider domain.Provider, providerID string) (*models.AuthMethod, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	method, ok := r.store.authMethods.first(func(m *models.AuthMethod) bool {
+		return m.ProviderCode == provider && m.ProviderID == providerID
+	})
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return method, nil
+}
+
+func (r *authMethodRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AuthMethod, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	methods := r.store.authMethods.find(func(m *models.AuthMethod) bool { return m.AccountID == accountID })
+	slices.SortFunc(methods, func(a, b *models.AuthMethod) int {
+		return strings.Compare(string(a.ProviderCode), string(b.ProviderCode))
+	})
+	return methods, nil
+}
+
+func (r *authMethodRepository) ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]*models.AuthMethod, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	methods := r.store.authMethods.find(func(m *models.AuthMethod) bool { return slices.Contains(accountIDs, m.AccountID) })
+	slices.SortFunc(methods, func(a, b *models.AuthMethod) int {
+		if c := compareUUIDs(a.AccountID, b.AccountID); c != 0 {
+			return c
+		}
+		return compareUUIDs(a.ID, b.ID)
+	})
+	return methods, nil
+}
+
+func (r *authMethodRepository) ListByProvider(ctx context.Context, provider domain.Provider, offset, limit int) ([]*models.AuthMethod, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return page(r.listByProvider(provider), offset, limit), nil
+}
+
+func (r *authMethodRepository) CountByProvider(ctx context.Context, provider domain.Provider) (int, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return len(r.listByProvider(provider)), nil
+}
+
+// listByProvider returns the methods of provider whose accounts are not
+// DELETED, in the order the accounts were created.
+func (r *authMethodRepository) listByProvider(provider domain.Provider) []*models.AuthMethod {
+	accounts := make(map[uuid.UUID]*models.Account)
+	methods := r.store.authMethods.find(func(m *models.AuthMethod) bool {
+		if m.ProviderCode != provider {
+			return false
+		}
+		account, ok := r.store.accounts.get(m.AccountID)
+		accounts[m.AccountID] = account
+		return ok && account.StatusCode != domain.StatusDeleted
+	})
+	slices.SortFunc(methods, func(a, b *models.AuthMethod) int {
+		return compareAccountCursors(accounts[a.AccountID].Cursor(), accounts[b.AccountID].Cursor())
+	})
+	return methods
+}
+
+func (r *authMethodRepository) UpdateProviderID(ctx context.Context, id uuid.UUID, providerID string, verified bool) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	method, ok := r.store.authMethods.get(id)
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if r.providerTaken(method.ProviderCode, providerID, id) {
+		return domain.ErrConflict
+	}
+	_, err := r.store.authMethods.update(tx, id, func(m *models.AuthMethod) bool {
+		m.ProviderID = providerID
+		m.IsVerified = verified
+		return true
+	})
+	return err
+}
+
+func (r *authMethodRepository) UpdateVerified(ctx context.Context, id uuid.UUID, verified bool) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.authMethods.update(tx, id, func(m *models.AuthMethod) bool {
+		m.IsVerified = verified
+		return true
+	})
+	return err
+}
+
+func (r *authMethodRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.authMethods.update(tx, id, func(m *models.AuthMethod) bool {
+		m.LastLoginAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *authMethodRepository) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	method, err := r.store.authMethods.update(tx, id, func(m *models.AuthMethod) bool {
+		m.FailedAttempts++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return method.FailedAttempts, nil
+}
+
+func (r *authMethodRepository) Lock(ctx context.Context, id uuid.UUID, until time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.authMethods.update(tx, id, func(m *models.AuthMethod) bool {
+		m.LockedUntil = &until
+		return true
+	})
+	return err
+}
+
+func (r *authMethodRepository) ResetFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.authMethods.update(tx, id, func(m *models.AuthMethod) bool {
+		m.FailedAttempts = 0
+		m.LockedUntil = nil
+		return true
+	})
+	return err
+}
+
+func (r *authMethodRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.deleteAuthMethod(tx, id) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type authPolicyRepository struct {
+	store *Store
+}
+
+func NewAuthPolicyRepository(store *Store) repositories.AuthPolicyRepository {
+	return &authPolicyRepository{
+		store: store,
+	}
+}
+
+func (r *authPolicyRepository) Save(ctx context.Context, policy *models.AuthPolicy) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	// The organization was deleted concurrently.
+	if !r.store.organizations.has(policy.OrganizationID) {
+		return domain.ErrNotFound
+	}
+	now := timestamp()
+	row := &models.AuthPolicy{
+		OrganizationID:          policy.OrganizationID,
+		PasswordMinLength:       policy.PasswordMinLength,
+		PasswordRequiredClasses: slices.Clone(policy.PasswordRequiredClasses),
+		RequireMFA:              policy.RequireMFA,
+		SessionMaxAge:           policy.SessionMaxAge.Truncate(time.Second),
+		AllowedProviders:        slices.Clone(policy.AllowedProviders),
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+	if existing, ok := r.store.policies.get(policy.OrganizationID); ok {
+		row.CreatedAt = existing.CreatedAt
+	}
+	r.store.policies.put(tx, row.OrganizationID, row)
+	*policy = *row
+	return nil
+}
+
+func (r *authPolicyRepository) Get(ctx context.Context, organizationID uuid.UUID) (*models.AuthPolicy, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	policy, ok := r.store.policies.get(organizationID)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return policy, nil
+}
+
+func (r *authPolicyRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AuthPolicy, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.store.policies.find(func(p *models.AuthPolicy) bool {
+		return r.store.memberships.has(membershipKey{organizationID: p.OrganizationID, accountID: accountID})
+	}), nil
+}
+
+func (r *authPolicyRepository) Delete(ctx context.Context, organizationID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.policies.remove(tx, organizationID) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
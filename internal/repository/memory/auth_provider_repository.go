@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+type authProviderRepository struct {
+	store *Store
+}
+
+func NewAuthProviderRepository(store *Store) repositories.AuthProviderRepository {
+	return &authProviderRepository{
+		store: store,
+	}
+}
+
+func (r *authProviderRepository) Ensure(ctx context.Context, code domain.Provider, description string) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.providers.has(code) {
+		r.store.providers.put(tx, code, &description)
+	}
+	return nil
+}
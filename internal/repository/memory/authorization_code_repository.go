@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type authorizationCodeRepository struct {
+	store *Store
+}
+
+func NewAuthorizationCodeRepository(store *Store) repositories.AuthorizationCodeRepository {
+	return &authorizationCodeRepository{
+		store: store,
+	}
+}
+
+func (r *authorizationCodeRepository) Create(ctx context.Context, code *models.AuthorizationCode) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.oauthClients.has(code.ClientID), r.store.accounts.has(code.AccountID)); err != nil {
+		return err
+	}
+	if r.store.authorizationCodes.exists(func(c *models.AuthorizationCode) bool { return c.CodeHash == code.CodeHash }) {
+		return domain.ErrConflict
+	}
+	row := copyOf(code)
+	row.ConsumedAt = nil
+	row.CreatedAt = timestamp()
+	if err := r.store.authorizationCodes.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*code = *row
+	return nil
+}
+
+func (r *authorizationCodeRepository) GetByCodeHash(ctx context.Context, codeHash string) (*models.AuthorizationCode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	code, ok := r.store.authorizationCodes.first(func(c *models.AuthorizationCode) bool { return c.CodeHash == codeHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return code, nil
+}
+
+func (r *authorizationCodeRepository) Consume(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.authorizationCodes.update(tx, id, func(c *models.AuthorizationCode) bool {
+		if c.ConsumedAt != nil {
+			return false
+		}
+		c.ConsumedAt = &at
+		return true
+	})
+	return err
+}
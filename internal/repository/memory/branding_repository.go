@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type brandingRepository struct {
+	store *Store
+}
+
+func NewBrandingRepository(store *Store) repositories.BrandingRepository {
+	return &brandingRepository{
+		store: store,
+	}
+}
+
+func (r *brandingRepository) Save(ctx context.Context, branding *models.Branding) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	// The organization was deleted concurrently.
+	if !r.store.organizations.has(branding.OrganizationID) {
+		return domain.ErrNotFound
+	}
+	now := timestamp()
+	row := copyOf(branding)
+	row.CreatedAt = now
+	row.UpdatedAt = now
+	if existing, ok := r.store.brandings.get(branding.OrganizationID); ok {
+		row.CreatedAt = existing.CreatedAt
+	}
+	r.store.brandings.put(tx, row.OrganizationID, row)
+	*branding = *row
+	return nil
+}
+
+func (r *brandingRepository) Get(ctx context.Context, organizationID uuid.UUID) (*models.Branding, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	branding, ok := r.store.brandings.get(organizationID)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return branding, nil
+}
+
+func (r *brandingRepository) GetBySlug(ctx context.Context, slug string) (*models.Branding, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	organization, ok := r.store.organizations.first(func(o *models.Organization) bool { return o.Slug == slug })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	branding, ok := r.store.brandings.get(organization.ID)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return branding, nil
+}
+
+func (r *brandingRepository) Delete(ctx context.Context, organizationID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.brandings.remove(tx, organizationID) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type browserSessionRepository struct {
+	store *Store
+}
+
+func NewBrowserSessionRepository(store *Store) repositories.BrowserSessionRepository {
+	return &browserSessionRepository{
+		store: store,
+	}
+}
+
+func (r *browserSessionRepository) Create(ctx context.Context, session *models.BrowserSession) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(session.AccountID)); err != nil {
+		return err
+	}
+	if r.store.browserSessions.exists(func(b *models.BrowserSession) bool { return b.TokenHash == session.TokenHash }) {
+		return domain.ErrConflict
+	}
+	row := copyOf(session)
+	row.CreatedAt = timestamp()
+	if err := r.store.browserSessions.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*session = *row
+	return nil
+}
+
+func (r *browserSessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.BrowserSession, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	session, ok := r.store.browserSessions.first(func(b *models.BrowserSession) bool { return b.TokenHash == tokenHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return session, nil
+}
+
+func (r *browserSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.browserSessions.remove(tx, id) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type consentRepository struct {
+	store *Store
+}
+
+func NewConsentRepository(store *Store) repositories.ConsentRepository {
+	return &consentRepository{
+		store: store,
+	}
+}
+
+func (r *consentRepository) Create(ctx context.Context, consent *models.Consent) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(consent.AccountID), r.store.legalDocuments.has(consent.DocumentID)); err != nil {
+		return err
+	}
+	if r.store.consents.exists(func(c *models.Consent) bool {
+		return c.AccountID == consent.AccountID && c.DocumentID == consent.DocumentID
+	}) {
+		return domain.ErrConflict
+	}
+	row := copyOf(consent)
+	row.Kind, row.Version = "", ""
+	row.AcceptedAt = timestamp()
+	if err := r.store.consents.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	consent.AcceptedAt = row.AcceptedAt
+	return nil
+}
+
+func (r *consentRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Consent, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	consents := r.store.consents.find(func(c *models.Consent) bool { return c.AccountID == accountID })
+	for _, consent := range consents {
+		if document, ok := r.store.legalDocuments.get(consent.DocumentID); ok {
+			consent.Kind, consent.Version = document.Kind, document.Version
+		}
+	}
+	slices.SortFunc(consents, func(a, b *models.Consent) int {
+		if c := b.AcceptedAt.Compare(a.AcceptedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(b.ID, a.ID)
+	})
+	return consents, nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type dataExportRepository struct {
+	store *Store
+}
+
+func NewDataExportRepository(store *Store) repositories.DataExportRepository {
+	return &dataExportRepository{
+		store: store,
+	}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, export *models.DataExport) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(export.AccountID)); err != nil {
+		return err
+	}
+	// An account has at most one PENDING export.
+	if r.store.dataExports.exists(func(e *models.DataExport) bool {
+		return e.AccountID == export.AccountID && e.Status == domain.DataExportPending
+	}) {
+		return domain.ErrConflict
+	}
+	row := &models.DataExport{
+		ID:        export.ID,
+		AccountID: export.AccountID,
+		Status:    domain.DataExportPending,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.dataExports.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*export = *row
+	return nil
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DataExport, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	export, ok := r.store.dataExports.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return export, nil
+}
+
+func (r *dataExportRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.DataExport, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	exports := r.store.dataExports.find(func(e *models.DataExport) bool { return e.AccountID == accountID })
+	sortNewestFirst(exports, func(e *models.DataExport) time.Time { return e.CreatedAt })
+	for _, export := range exports {
+		export.Archive = nil
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) ListPending(ctx context.Context, limit int) ([]*models.DataExport, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	exports := r.store.dataExports.find(func(e *models.DataExport) bool { return e.Status == domain.DataExportPending })
+	sortOldestFirst(exports, func(e *models.DataExport) time.Time { return e.CreatedAt })
+	return page(exports, 0, limit), nil
+}
+
+func (r *dataExportRepository) Complete(ctx context.Context, id uuid.UUID, archive []byte, at, expiresAt time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.dataExports.update(tx, id, func(e *models.DataExport) bool {
+		if e.Status != domain.DataExportPending {
+			return false
+		}
+		e.Status = domain.DataExportReady
+		e.Archive = archive
+		e.CompletedAt = &at
+		e.ExpiresAt = &expiresAt
+		return true
+	})
+	return err
+}
+
+func (r *dataExportRepository) DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	expired := r.store.dataExports.find(func(e *models.DataExport) bool {
+		return e.ExpiresAt != nil && !e.ExpiresAt.After(now)
+	})
+	expired = page(expired, 0, limit)
+	for _, export := range expired {
+		r.store.dataExports.remove(tx, export.ID)
+	}
+	return int64(len(expired)), nil
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type deviceCodeRepository struct {
+	store *Store
+}
+
+func NewDeviceCodeRepository(store *Store) repositories.DeviceCodeRepository {
+	return &deviceCodeRepository{
+		store: store,
+	}
+}
+
+func (r *deviceCodeRepository) Create(ctx context.Context, code *models.DeviceCode) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.oauthClients.has(code.ClientID)); err != nil {
+		return err
+	}
+	if r.store.deviceCodes.exists(func(c *models.DeviceCode) bool { return c.DeviceCodeHash == code.DeviceCodeHash }) {
+		return domain.ErrConflict
+	}
+	row := &models.DeviceCode{
+		ID:             code.ID,
+		DeviceCodeHash: code.DeviceCodeHash,
+		UserCodeHash:   code.UserCodeHash,
+		ClientID:       code.ClientID,
+		Scope:          code.Scope,
+		Status:         domain.DeviceCodePending,
+		Interval:       code.Interval.Truncate(time.Second),
+		ExpiresAt:      code.ExpiresAt,
+		CreatedAt:      timestamp(),
+	}
+	if err := r.store.deviceCodes.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*code = *row
+	return nil
+}
+
+func (r *deviceCodeRepository) GetByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*models.DeviceCode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	code, ok := r.store.deviceCodes.first(func(c *models.DeviceCode) bool { return c.DeviceCodeHash == deviceCodeHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return code, nil
+}
+
+func (r *deviceCodeRepository) GetPendingByUserCodeHash(ctx context.Context, userCodeHash string, now time.Time) (*models.DeviceCode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	codes := r.store.deviceCodes.find(func(c *models.DeviceCode) bool {
+		return c.UserCodeHash == userCodeHash && c.Status == domain.DeviceCodePending && c.ExpiresAt.After(now)
+	})
+	if len(codes) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	sortNewestFirst(codes, func(c *models.DeviceCode) time.Time { return c.CreatedAt })
+	return codes[0], nil
+}
+
+func (r *deviceCodeRepository) Decide(ctx context.Context, id uuid.UUID, status domain.DeviceCodeStatus, accountID uuid.UUID, authTime time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(accountID)); err != nil {
+		return err
+	}
+	_, err := r.store.deviceCodes.update(tx, id, func(c *models.DeviceCode) bool {
+		if c.Status != domain.DeviceCodePending {
+			return false
+		}
+		c.Status = status
+		c.AccountID = &accountID
+		c.AuthTime = &authTime
+		return true
+	})
+	return err
+}
+
+func (r *deviceCodeRepository) RecordPoll(ctx context.Context, id uuid.UUID, at time.Time, interval time.Duration) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.deviceCodes.update(tx, id, func(c *models.DeviceCode) bool {
+		c.LastPolledAt = &at
+		c.Interval = interval.Truncate(time.Second)
+		return true
+	})
+	return err
+}
+
+func (r *deviceCodeRepository) Consume(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.deviceCodes.update(tx, id, func(c *models.DeviceCode) bool {
+		if c.ConsumedAt != nil {
+			return false
+		}
+		c.ConsumedAt = &at
+		return true
+	})
+	return err
+}
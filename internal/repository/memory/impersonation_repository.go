@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type impersonationRepository struct {
+	store *Store
+}
+
+func NewImpersonationRepository(store *Store) repositories.ImpersonationRepository {
+	return &impersonationRepository{
+		store: store,
+	}
+}
+
+func (r *impersonationRepository) Create(ctx context.Context, impersonation *models.Impersonation) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(impersonation.AccountID), optional(&r.store.accounts, impersonation.AdminID)); err != nil {
+		return err
+	}
+	row := copyOf(impersonation)
+	row.EndedAt = nil
+	row.CreatedAt = timestamp()
+	if err := r.store.impersonations.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*impersonation = *row
+	return nil
+}
+
+func (r *impersonationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Impersonation, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	impersonation, ok := r.store.impersonations.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return impersonation, nil
+}
+
+func (r *impersonationRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Impersonation, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	impersonations := r.store.impersonations.find(func(i *models.Impersonation) bool { return i.AccountID == accountID })
+	sortNewestFirst(impersonations, func(i *models.Impersonation) time.Time { return i.CreatedAt })
+	return impersonations, nil
+}
+
+func (r *impersonationRepository) End(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.impersonations.update(tx, id, func(i *models.Impersonation) bool {
+		if i.EndedAt != nil || !i.ExpiresAt.After(at) {
+			return false
+		}
+		i.EndedAt = &at
+		return true
+	})
+	return err
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type invitationRepository struct {
+	store *Store
+}
+
+func NewInvitationRepository(store *Store) repositories.InvitationRepository {
+	return &invitationRepository{
+		store: store,
+	}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *models.Invitation) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	// The organization was deleted concurrently.
+	if !r.store.organizations.has(invitation.OrganizationID) {
+		return domain.ErrNotFound
+	}
+	if err := references(r.store.roles.has(invitation.RoleCode), optional(&r.store.accounts, invitation.InvitedBy)); err != nil {
+		return err
+	}
+	if r.store.invitations.exists(func(i *models.Invitation) bool { return i.TokenHash == invitation.TokenHash }) {
+		return domain.ErrConflict
+	}
+	row := &models.Invitation{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		RoleCode:       invitation.RoleCode,
+		TokenHash:      invitation.TokenHash,
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+		CreatedAt:      timestamp(),
+	}
+	if err := r.store.invitations.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*invitation = *row
+	return nil
+}
+
+func (r *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	invitation, ok := r.store.invitations.first(func(i *models.Invitation) bool { return i.TokenHash == tokenHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return invitation, nil
+}
+
+func (r *invitationRepository) ListPending(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]*models.Invitation, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	invitations := r.store.invitations.find(func(i *models.Invitation) bool {
+		return i.OrganizationID == organizationID && unanswered(i) && i.ExpiresAt.After(now)
+	})
+	sortNewestFirst(invitations, func(i *models.Invitation) time.Time { return i.CreatedAt })
+	return invitations, nil
+}
+
+func (r *invitationRepository) Accept(ctx context.Context, id, accountID uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(accountID)); err != nil {
+		return err
+	}
+	_, err := r.store.invitations.update(tx, id, func(i *models.Invitation) bool {
+		if !unanswered(i) || !i.ExpiresAt.After(at) {
+			return false
+		}
+		i.AcceptedAt = &at
+		i.AcceptedBy = &accountID
+		return true
+	})
+	return err
+}
+
+func (r *invitationRepository) Revoke(ctx context.Context, id, organizationID uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.invitations.update(tx, id, func(i *models.Invitation) bool {
+		if i.OrganizationID != organizationID || !unanswered(i) {
+			return false
+		}
+		i.RevokedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *invitationRepository) RevokePendingByEmail(ctx context.Context, organizationID uuid.UUID, email string, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	r.store.invitations.updateWhere(tx, func(i *models.Invitation) bool {
+		if i.OrganizationID != organizationID || i.Email != email || !unanswered(i) {
+			return false
+		}
+		i.RevokedAt = &at
+		return true
+	})
+	return nil
+}
+
+// unanswered reports whether an invitation was neither accepted nor revoked.
+func unanswered(invitation *models.Invitation) bool {
+	return invitation.AcceptedAt == nil && invitation.RevokedAt == nil
+}
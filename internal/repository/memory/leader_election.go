@@ -0,0 +1,19 @@
+package memory
+
+import "context"
+
+// LeaderElection always leads: instances on the in-memory store share
+// nothing, so each runs its own jobs against its own data.
+type LeaderElection struct{}
+
+func NewLeaderElection() *LeaderElection {
+	return &LeaderElection{}
+}
+
+func (LeaderElection) Lead(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (LeaderElection) Resign(ctx context.Context) error {
+	return nil
+}
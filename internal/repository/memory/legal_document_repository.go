@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type legalDocumentRepository struct {
+	store *Store
+}
+
+func NewLegalDocumentRepository(store *Store) repositories.LegalDocumentRepository {
+	return &legalDocumentRepository{
+		store: store,
+	}
+}
+
+func (r *legalDocumentRepository) Create(ctx context.Context, document *models.LegalDocument) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if r.store.legalDocuments.exists(func(d *models.LegalDocument) bool {
+		return d.Kind == document.Kind && d.Version == document.Version
+	}) {
+		return domain.ErrConflict
+	}
+	row := copyOf(document)
+	row.PublishedAt = timestamp()
+	if err := r.store.legalDocuments.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*document = *row
+	return nil
+}
+
+func (r *legalDocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.LegalDocument, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	document, ok := r.store.legalDocuments.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return document, nil
+}
+
+func (r *legalDocumentRepository) List(ctx context.Context) ([]*models.LegalDocument, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	documents := r.store.legalDocuments.find(func(*models.LegalDocument) bool { return true })
+	slices.SortFunc(documents, compareLegalDocuments)
+	return documents, nil
+}
+
+func (r *legalDocumentRepository) ListCurrent(ctx context.Context) ([]*models.LegalDocument, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.store.currentLegalDocuments(), nil
+}
+
+func (r *legalDocumentRepository) ListPendingByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.LegalDocument, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return slices.DeleteFunc(r.store.currentLegalDocuments(), func(d *models.LegalDocument) bool {
+		return r.store.consents.exists(func(c *models.Consent) bool {
+			return c.AccountID == accountID && c.DocumentID == d.ID
+		})
+	}), nil
+}
+
+// currentLegalDocuments returns the version of each kind published last, by
+// kind.
+func (s *Store) currentLegalDocuments() []*models.LegalDocument {
+	documents := s.legalDocuments.find(func(*models.LegalDocument) bool { return true })
+	slices.SortFunc(documents, compareLegalDocuments)
+
+	var current []*models.LegalDocument
+	for _, document := range documents {
+		if !slices.ContainsFunc(current, func(d *models.LegalDocument) bool { return d.Kind == document.Kind }) {
+			current = append(current, document)
+		}
+	}
+	slices.SortFunc(current, func(a, b *models.LegalDocument) int { return strings.Compare(string(a.Kind), string(b.Kind)) })
+	return current
+}
+
+// compareLegalDocuments orders documents newest first, by publication time
+// and then ID.
+func compareLegalDocuments(a, b *models.LegalDocument) int {
+	if c := b.PublishedAt.Compare(a.PublishedAt); c != 0 {
+		return c
+	}
+	return compareUUIDs(b.ID, a.ID)
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type membershipRepository struct {
+	store *Store
+}
+
+func NewMembershipRepository(store *Store) repositories.MembershipRepository {
+	return &membershipRepository{
+		store: store,
+	}
+}
+
+func (r *membershipRepository) Save(ctx context.Context, membership *models.Membership) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	// The organization, the account or the role was deleted concurrently.
+	if !r.store.organizations.has(membership.OrganizationID) || !r.store.accounts.has(membership.AccountID) ||
+		!r.store.roles.has(membership.RoleCode) {
+		return domain.ErrNotFound
+	}
+	key := membershipKey{organizationID: membership.OrganizationID, accountID: membership.AccountID}
+	now := timestamp()
+	row := &models.Membership{
+		OrganizationID: membership.OrganizationID,
+		AccountID:      membership.AccountID,
+		RoleCode:       membership.RoleCode,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if existing, ok := r.store.memberships.get(key); ok {
+		row.CreatedAt = existing.CreatedAt
+	}
+	r.store.memberships.put(tx, key, row)
+	*membership = *row
+	return nil
+}
+
+func (r *membershipRepository) Get(ctx context.Context, organizationID, accountID uuid.UUID) (*models.Membership, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	membership, ok := r.store.memberships.get(membershipKey{organizationID: organizationID, accountID: accountID})
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return membership, nil
+}
+
+func (r *membershipRepository) ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Membership, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	memberships := r.store.memberships.find(func(m *models.Membership) bool { return m.OrganizationID == organizationID })
+	slices.SortFunc(memberships, func(a, b *models.Membership) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(a.AccountID, b.AccountID)
+	})
+	return memberships, nil
+}
+
+func (r *membershipRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Membership, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.store.memberships.find(func(m *models.Membership) bool { return m.AccountID == accountID }), nil
+}
+
+func (r *membershipRepository) Delete(ctx context.Context, organizationID, accountID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.memberships.remove(tx, membershipKey{organizationID: organizationID, accountID: accountID}) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type mfaChallengeRepository struct {
+	store *Store
+}
+
+func NewMFAChallengeRepository(store *Store) repositories.MFAChallengeRepository {
+	return &mfaChallengeRepository{
+		store: store,
+	}
+}
+
+func (r *mfaChallengeRepository) Create(ctx context.Context, challenge *models.MFAChallenge) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(challenge.AccountID)); err != nil {
+		return err
+	}
+	if r.store.mfaChallenges.exists(func(c *models.MFAChallenge) bool { return c.TokenHash == challenge.TokenHash }) {
+		return domain.ErrConflict
+	}
+	row := &models.MFAChallenge{
+		ID:         challenge.ID,
+		AccountID:  challenge.AccountID,
+		TokenHash:  challenge.TokenHash,
+		RememberMe: challenge.RememberMe,
+		Scope:      challenge.Scope,
+		ExpiresAt:  challenge.ExpiresAt,
+		CreatedAt:  timestamp(),
+	}
+	if err := r.store.mfaChallenges.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*challenge = *row
+	return nil
+}
+
+func (r *mfaChallengeRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.MFAChallenge, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	challenge, ok := r.store.mfaChallenges.first(func(c *models.MFAChallenge) bool { return c.TokenHash == tokenHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return challenge, nil
+}
+
+func (r *mfaChallengeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	challenge, err := r.store.mfaChallenges.update(tx, id, func(c *models.MFAChallenge) bool {
+		c.Attempts++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return challenge.Attempts, nil
+}
+
+func (r *mfaChallengeRepository) MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.mfaChallenges.update(tx, id, func(c *models.MFAChallenge) bool {
+		if c.ConsumedAt != nil {
+			return false
+		}
+		c.ConsumedAt = &at
+		return true
+	})
+	return err
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type mfaCodeRepository struct {
+	store *Store
+}
+
+func NewMFACodeRepository(store *Store) repositories.MFACodeRepository {
+	return &mfaCodeRepository{
+		store: store,
+	}
+}
+
+func (r *mfaCodeRepository) Create(ctx context.Context, code *models.MFACode) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.mfaFactors.has(code.FactorID)); err != nil {
+		return err
+	}
+	row := &models.MFACode{
+		ID:        code.ID,
+		FactorID:  code.FactorID,
+		CodeHash:  code.CodeHash,
+		ExpiresAt: code.ExpiresAt,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.mfaCodes.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*code = *row
+	return nil
+}
+
+func (r *mfaCodeRepository) GetLatestByFactorID(ctx context.Context, factorID uuid.UUID) (*models.MFACode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	codes := r.store.mfaCodes.find(func(c *models.MFACode) bool { return c.FactorID == factorID })
+	if len(codes) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	sortNewestFirst(codes, func(c *models.MFACode) time.Time { return c.CreatedAt })
+	return codes[0], nil
+}
+
+func (r *mfaCodeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	code, err := r.store.mfaCodes.update(tx, id, func(c *models.MFACode) bool {
+		c.Attempts++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return code.Attempts, nil
+}
+
+func (r *mfaCodeRepository) MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.mfaCodes.update(tx, id, func(c *models.MFACode) bool {
+		if c.ConsumedAt != nil {
+			return false
+		}
+		c.ConsumedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *mfaCodeRepository) InvalidateActive(ctx context.Context, factorID uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	r.store.mfaCodes.updateWhere(tx, func(c *models.MFACode) bool {
+		if c.FactorID != factorID || c.ConsumedAt != nil || !c.ExpiresAt.After(at) {
+			return false
+		}
+		c.ExpiresAt = at
+		return true
+	})
+	return nil
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type mfaFactorRepository struct {
+	store *Store
+}
+
+func NewMFAFactorRepository(store *Store) repositories.MFAFactorRepository {
+	return &mfaFactorRepository{
+		store: store,
+	}
+}
+
+func (r *mfaFactorRepository) Create(ctx context.Context, factor *models.MFAFactor) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(factor.AccountID)); err != nil {
+		return err
+	}
+	if r.store.mfaFactors.exists(func(f *models.MFAFactor) bool {
+		return f.AccountID == factor.AccountID && f.FactorType == factor.FactorType
+	}) {
+		return domain.ErrConflict
+	}
+	row := &models.MFAFactor{
+		ID:         factor.ID,
+		AccountID:  factor.AccountID,
+		FactorType: factor.FactorType,
+		Secret:     slices.Clone(factor.Secret),
+		CreatedAt:  timestamp(),
+	}
+	if err := r.store.mfaFactors.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*factor = *row
+	return nil
+}
+
+func (r *mfaFactorRepository) GetByAccountAndType(ctx context.Context, accountID uuid.UUID, factorType domain.MFAFactorType) (*models.MFAFactor, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	factor, ok := r.store.mfaFactors.first(func(f *models.MFAFactor) bool {
+		return f.AccountID == accountID && f.FactorType == factorType
+	})
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return factor, nil
+}
+
+func (r *mfaFactorRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.MFAFactor, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	factors := r.store.mfaFactors.find(func(f *models.MFAFactor) bool { return f.AccountID == accountID })
+	sortOldestFirst(factors, func(f *models.MFAFactor) time.Time { return f.CreatedAt })
+	return factors, nil
+}
+
+func (r *mfaFactorRepository) Confirm(ctx context.Context, id uuid.UUID, step int64, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.mfaFactors.update(tx, id, func(f *models.MFAFactor) bool {
+		if f.ConfirmedAt != nil {
+			return false
+		}
+		f.ConfirmedAt = &at
+		f.LastUsedStep = step
+		return true
+	})
+	return err
+}
+
+func (r *mfaFactorRepository) UpdateLastUsedStep(ctx context.Context, id uuid.UUID, step int64) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.mfaFactors.update(tx, id, func(f *models.MFAFactor) bool {
+		if f.LastUsedStep >= step {
+			return false
+		}
+		f.LastUsedStep = step
+		return true
+	})
+	return err
+}
+
+func (r *mfaFactorRepository) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	factor, err := r.store.mfaFactors.update(tx, id, func(f *models.MFAFactor) bool {
+		f.FailedAttempts++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return factor.FailedAttempts, nil
+}
+
+func (r *mfaFactorRepository) Lock(ctx context.Context, id uuid.UUID, until time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.mfaFactors.update(tx, id, func(f *models.MFAFactor) bool {
+		f.LockedUntil = &until
+		return true
+	})
+	return err
+}
+
+func (r *mfaFactorRepository) ResetFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.mfaFactors.update(tx, id, func(f *models.MFAFactor) bool {
+		f.FailedAttempts = 0
+		f.LockedUntil = nil
+		return true
+	})
+	return err
+}
+
+func (r *mfaFactorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.deleteMFAFactor(tx, id) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type mfaRecoveryCodeRepository struct {
+	store *Store
+}
+
+func NewMFARecoveryCodeRepository(store *Store) repositories.MFARecoveryCodeRepository {
+	return &mfaRecoveryCodeRepository{
+		store: store,
+	}
+}
+
+func (r *mfaRecoveryCodeRepository) Create(ctx context.Context, code *models.MFARecoveryCode) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(code.AccountID)); err != nil {
+		return err
+	}
+	if r.store.mfaRecoveryCodes.exists(func(c *models.MFARecoveryCode) bool {
+		return c.AccountID == code.AccountID && c.CodeHash == code.CodeHash
+	}) {
+		return domain.ErrConflict
+	}
+	return r.store.mfaRecoveryCodes.insert(tx, code.ID, &models.MFARecoveryCode{
+		ID:        code.ID,
+		AccountID: code.AccountID,
+		CodeHash:  code.CodeHash,
+		CreatedAt: timestamp(),
+	})
+}
+
+func (r *mfaRecoveryCodeRepository) Consume(ctx context.Context, accountID uuid.UUID, codeHash string, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	consumed := r.store.mfaRecoveryCodes.updateWhere(tx, func(c *models.MFARecoveryCode) bool {
+		if c.AccountID != accountID || c.CodeHash != codeHash || c.ConsumedAt != nil {
+			return false
+		}
+		c.ConsumedAt = &at
+		return true
+	})
+	if consumed == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *mfaRecoveryCodeRepository) CountUnused(ctx context.Context, accountID uuid.UUID) (int, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return len(r.store.mfaRecoveryCodes.find(func(c *models.MFARecoveryCode) bool {
+		return c.AccountID == accountID && c.ConsumedAt == nil
+	})), nil
+}
+
+func (r *mfaRecoveryCodeRepository) DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	r.store.mfaRecoveryCodes.removeWhere(tx, func(c *models.MFARecoveryCode) bool { return c.AccountID == accountID })
+	return nil
+}
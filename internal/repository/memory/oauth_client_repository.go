@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type oauthClientRepository struct {
+	store *Store
+}
+
+func NewOAuthClientRepository(store *Store) repositories.OAuthClientRepository {
+	return &oauthClientRepository{
+		store: store,
+	}
+}
+
+func (r *oauthClientRepository) Upsert(ctx context.Context, client *models.OAuthClient) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	now := timestamp()
+	row := &models.OAuthClient{
+		ID:                client.ID,
+		ClientID:          client.ClientID,
+		Name:              client.Name,
+		SecretHash:        client.SecretHash,
+		Public:            client.Public,
+		RedirectURIs:      slices.Clone(client.RedirectURIs),
+		GrantTypes:        slices.Clone(client.GrantTypes),
+		Scopes:            slices.Clone(client.Scopes),
+		ExchangeAudiences: slices.Clone(client.ExchangeAudiences),
+		Impersonation:     client.Impersonation,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	// The client ID is unique; registering it again keeps the row's ID and
+	// creation time.
+	if existing, ok := r.store.oauthClients.first(func(c *models.OAuthClient) bool { return c.ClientID == client.ClientID }); ok {
+		row.ID = existing.ID
+		row.CreatedAt = existing.CreatedAt
+		r.store.oauthClients.put(tx, row.ID, row)
+	} else if err := r.store.oauthClients.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*client = *row
+	return nil
+}
+
+func (r *oauthClientRepository) GetByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	client, ok := r.store.oauthClients.first(func(c *models.OAuthClient) bool { return c.ClientID == clientID })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return client, nil
+}
+
+func (r *oauthClientRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.OAuthClient, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	client, ok := r.store.oauthClients.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return client, nil
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type organizationRepository struct {
+	store *Store
+}
+
+func NewOrganizationRepository(store *Store) repositories.OrganizationRepository {
+	return &organizationRepository{
+		store: store,
+	}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *models.Organization) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if r.store.organizations.exists(func(o *models.Organization) bool { return o.Slug == organization.Slug }) {
+		return domain.ErrConflict
+	}
+	now := timestamp()
+	row := &models.Organization{
+		ID:        organization.ID,
+		Slug:      organization.Slug,
+		Name:      organization.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := r.store.organizations.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*organization = *row
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	organization, ok := r.store.organizations.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return organization, nil
+}
+
+func (r *organizationRepository) List(ctx context.Context) ([]*models.Organization, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return sortedBySlug(r.store.organizations.find(func(*models.Organization) bool { return true })), nil
+}
+
+func (r *organizationRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Organization, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return sortedBySlug(r.store.organizations.find(func(o *models.Organization) bool {
+		return r.store.memberships.has(membershipKey{organizationID: o.ID, accountID: accountID})
+	})), nil
+}
+
+func (r *organizationRepository) UpdateName(ctx context.Context, id uuid.UUID, name string) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.organizations.update(tx, id, func(o *models.Organization) bool {
+		o.Name = name
+		o.UpdatedAt = timestamp()
+		return true
+	})
+	return err
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.deleteOrganization(tx, id) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func sortedBySlug(organizations []*models.Organization) []*models.Organization {
+	slices.SortFunc(organizations, func(a, b *models.Organization) int { return strings.Compare(a.Slug, b.Slug) })
+	return organizations
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type outboxRepository struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) repositories.OutboxRepository {
+	return &outboxRepository{
+		store: store,
+	}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	now := timestamp()
+	row := &models.OutboxEvent{
+		ID:            event.ID,
+		Name:          event.Name,
+		Payload:       slices.Clone(event.Payload),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	if err := r.store.outboxEvents.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	event.NextAttemptAt = row.NextAttemptAt
+	event.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *outboxRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.OutboxEvent, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	due := r.store.outboxEvents.find(func(e *models.OutboxEvent) bool {
+		return e.PublishedAt == nil && !e.NextAttemptAt.After(now)
+	})
+	slices.SortFunc(due, func(a, b *models.OutboxEvent) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(a.ID, b.ID)
+	})
+
+	claimed := make([]*models.OutboxEvent, 0, len(due))
+	for _, event := range page(due, 0, limit) {
+		event, err := r.store.outboxEvents.update(tx, event.ID, func(e *models.OutboxEvent) bool {
+			e.NextAttemptAt = leaseUntil
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, event)
+	}
+	return claimed, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.outboxEvents.update(tx, id, func(e *models.OutboxEvent) bool {
+		if e.PublishedAt != nil {
+			return false
+		}
+		e.Attempts++
+		e.LastError = nil
+		e.PublishedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *outboxRepository) Reschedule(ctx context.Context, id uuid.UUID, next time.Time, lastError string) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.outboxEvents.update(tx, id, func(e *models.OutboxEvent) bool {
+		if e.PublishedAt != nil {
+			return false
+		}
+		e.Attempts++
+		e.NextAttemptAt = next
+		e.LastError = &lastError
+		return true
+	})
+	return err
+}
+
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	removed := r.store.outboxEvents.removeWhere(tx, func(e *models.OutboxEvent) bool {
+		return e.PublishedAt != nil && e.PublishedAt.Before(before)
+	})
+	return int64(len(removed)), nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type passkeyCeremonyRepository struct {
+	store *Store
+}
+
+func NewPasskeyCeremonyRepository(store *Store) repositories.PasskeyCeremonyRepository {
+	return &passkeyCeremonyRepository{
+		store: store,
+	}
+}
+
+func (r *passkeyCeremonyRepository) Create(ctx context.Context, ceremony *models.PasskeyCeremony) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(optional(&r.store.accounts, ceremony.AccountID)); err != nil {
+		return err
+	}
+	row := &models.PasskeyCeremony{
+		ID:        ceremony.ID,
+		AccountID: ceremony.AccountID,
+		Purpose:   ceremony.Purpose,
+		State:     slices.Clone(ceremony.State),
+		ExpiresAt: ceremony.ExpiresAt,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.passkeyCeremonies.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*ceremony = *row
+	return nil
+}
+
+func (r *passkeyCeremonyRepository) Consume(ctx context.Context, id uuid.UUID) (*models.PasskeyCeremony, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	ceremony, ok := r.store.passkeyCeremonies.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	r.store.passkeyCeremonies.remove(tx, id)
+	return ceremony, nil
+}
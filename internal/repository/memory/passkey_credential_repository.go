@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type passkeyCredentialRepository struct {
+	store *Store
+}
+
+func NewPasskeyCredentialRepository(store *Store) repositories.PasskeyCredentialRepository {
+	return &passkeyCredentialRepository{
+		store: store,
+	}
+}
+
+func (r *passkeyCredentialRepository) Create(ctx context.Context, credential *models.PasskeyCredential) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(credential.AccountID)); err != nil {
+		return err
+	}
+	if r.store.passkeyCredentials.exists(func(c *models.PasskeyCredential) bool {
+		return bytes.Equal(c.CredentialID, credential.CredentialID)
+	}) {
+		return domain.ErrConflict
+	}
+	row := &models.PasskeyCredential{
+		ID:              credential.ID,
+		AccountID:       credential.AccountID,
+		CredentialID:    slices.Clone(credential.CredentialID),
+		PublicKey:       slices.Clone(credential.PublicKey),
+		AttestationType: credential.AttestationType,
+		AAGUID:          slices.Clone(credential.AAGUID),
+		SignCount:       credential.SignCount,
+		Transports:      slices.Clone(credential.Transports),
+		UserVerified:    credential.UserVerified,
+		BackupEligible:  credential.BackupEligible,
+		BackupState:     credential.BackupState,
+		Name:            credential.Name,
+		CreatedAt:       timestamp(),
+	}
+	if err := r.store.passkeyCredentials.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*credential = *row
+	return nil
+}
+
+func (r *passkeyCredentialRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.PasskeyCredential, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	credentials := r.store.passkeyCredentials.find(func(c *models.PasskeyCredential) bool { return c.AccountID == accountID })
+	sortOldestFirst(credentials, func(c *models.PasskeyCredential) time.Time { return c.CreatedAt })
+	return credentials, nil
+}
+
+func (r *passkeyCredentialRepository) UpdateUsage(ctx context.Context, id uuid.UUID, signCount uint32, backupState bool, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.passkeyCredentials.update(tx, id, func(c *models.PasskeyCredential) bool {
+		c.SignCount = signCount
+		c.BackupState = backupState
+		c.LastUsedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *passkeyCredentialRepository) Delete(ctx context.Context, id, accountID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	credential, ok := r.store.passkeyCredentials.get(id)
+	if !ok || credential.AccountID != accountID {
+		return domain.ErrNotFound
+	}
+	r.store.passkeyCredentials.remove(tx, id)
+	return nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type passwordCredentialRepository struct {
+	store *Store
+}
+
+func NewPasswordCredentialRepository(store *Store) repositories.PasswordCredentialRepository {
+	return &passwordCredentialRepository{
+		store: store,
+	}
+}
+
+func (r *passwordCredentialRepository) Create(ctx context.Context, credential *models.PasswordCredential) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.authMethods.has(credential.AuthMethodID)); err != nil {
+		return err
+	}
+	now := timestamp()
+	row := &models.PasswordCredential{
+		AuthMethodID: credential.AuthMethodID,
+		PasswordHash: credential.PasswordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := r.store.passwordCredentials.insert(tx, row.AuthMethodID, row); err != nil {
+		return err
+	}
+	*credential = *row
+	return nil
+}
+
+func (r *passwordCredentialRepository) GetByAuthMethodID(ctx context.Context, authMethodID uuid.UUID) (*models.PasswordCredential, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	credential, ok := r.store.passwordCredentials.get(authMethodID)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return credential, nil
+}
+
+func (r *passwordCredentialRepository) UpdateHash(ctx context.Context, authMethodID uuid.UUID, passwordHash string, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.passwordCredentials.update(tx, authMethodID, func(c *models.PasswordCredential) bool {
+		c.PasswordHash = passwordHash
+		c.UpdatedAt = at
+		return true
+	})
+	return err
+}
+
+func (r *passwordCredentialRepository) Rehash(ctx context.Context, authMethodID uuid.UUID, previousHash, passwordHash string) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.passwordCredentials.update(tx, authMethodID, func(c *models.PasswordCredential) bool {
+		if c.PasswordHash != previousHash {
+			return false
+		}
+		c.PasswordHash = passwordHash
+		return true
+	})
+	return err
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type passwordHistoryRepository struct {
+	store *Store
+}
+
+func NewPasswordHistoryRepository(store *Store) repositories.PasswordHistoryRepository {
+	return &passwordHistoryRepository{
+		store: store,
+	}
+}
+
+func (r *passwordHistoryRepository) Create(ctx context.Context, entry *models.PasswordHistoryEntry) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.authMethods.has(entry.AuthMethodID)); err != nil {
+		return err
+	}
+	return r.store.passwordHistory.insert(tx, entry.ID, &models.PasswordHistoryEntry{
+		ID:           entry.ID,
+		AuthMethodID: entry.AuthMethodID,
+		PasswordHash: entry.PasswordHash,
+		CreatedAt:    timestamp(),
+	})
+}
+
+func (r *passwordHistoryRepository) ListRecent(ctx context.Context, authMethodID uuid.UUID, limit int) ([]*models.PasswordHistoryEntry, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return page(r.newestFirst(authMethodID), 0, limit), nil
+}
+
+func (r *passwordHistoryRepository) Prune(ctx context.Context, authMethodID uuid.UUID, keep int) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	entries := r.newestFirst(authMethodID)
+	for _, entry := range page(entries, keep, -1) {
+		r.store.passwordHistory.remove(tx, entry.ID)
+	}
+	return nil
+}
+
+func (r *passwordHistoryRepository) newestFirst(authMethodID uuid.UUID) []*models.PasswordHistoryEntry {
+	entries := r.store.passwordHistory.find(func(e *models.PasswordHistoryEntry) bool { return e.AuthMethodID == authMethodID })
+	sortNewestFirst(entries, func(e *models.PasswordHistoryEntry) time.Time { return e.CreatedAt })
+	return entries
+}
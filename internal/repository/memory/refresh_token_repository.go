@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type refreshTokenRepository struct {
+	store *Store
+}
+
+func NewRefreshTokenRepository(store *Store) repositories.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		store: store,
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(token.AccountID), optional(&r.store.organizations, token.OrganizationID)); err != nil {
+		return err
+	}
+	if r.store.refreshTokens.exists(func(t *models.RefreshToken) bool { return t.TokenHash == token.TokenHash }) {
+		return domain.ErrConflict
+	}
+	row := copyOf(token)
+	row.RevokedAt = nil
+	row.CreatedAt = timestamp()
+	if err := r.store.refreshTokens.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*token = *row
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	token, ok := r.store.refreshTokens.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	token, ok := r.store.refreshTokens.first(func(t *models.RefreshToken) bool { return t.TokenHash == tokenHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) ListActiveByAccountID(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*models.RefreshToken, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	tokens := r.store.refreshTokens.find(func(t *models.RefreshToken) bool {
+		return t.AccountID == accountID && t.RevokedAt == nil && t.ExpiresAt.After(now)
+	})
+	sortNewestFirst(tokens, func(t *models.RefreshToken) time.Time { return t.CreatedAt })
+	return tokens, nil
+}
+
+func (r *refreshTokenRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.RefreshToken, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	tokens := r.store.refreshTokens.find(func(t *models.RefreshToken) bool { return t.AccountID == accountID })
+	sortNewestFirst(tokens, func(t *models.RefreshToken) time.Time { return t.CreatedAt })
+	return tokens, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.refreshTokens.update(tx, id, func(t *models.RefreshToken) bool {
+		if t.RevokedAt != nil {
+			return false
+		}
+		t.RevokedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeAllByAccountID(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.store.refreshTokens.updateWhere(tx, func(t *models.RefreshToken) bool {
+		if t.AccountID != accountID || t.RevokedAt != nil {
+			return false
+		}
+		t.RevokedAt = &at
+		return true
+	}), nil
+}
+
+func (r *refreshTokenRepository) CountActiveSessions(ctx context.Context, now time.Time) (int, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	sessions := make(map[uuid.UUID]struct{})
+	for _, token := range r.store.refreshTokens.find(func(t *models.RefreshToken) bool {
+		return t.RevokedAt == nil && t.ExpiresAt.After(now)
+	}) {
+		sessions[token.SessionID] = struct{}{}
+	}
+	return len(sessions), nil
+}
+
+func (r *refreshTokenRepository) DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	stale := r.store.refreshTokens.find(func(t *models.RefreshToken) bool {
+		return t.ExpiresAt.Before(before) || (t.RevokedAt != nil && t.RevokedAt.Before(before))
+	})
+	stale = page(stale, 0, limit)
+	for _, token := range stale {
+		r.store.refreshTokens.remove(tx, token.ID)
+	}
+	return int64(len(stale)), nil
+}
+
+func (r *refreshTokenRepository) ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	tokens := r.store.refreshTokens.find(func(t *models.RefreshToken) bool {
+		return t.CreatedAt.Before(before) && (t.IPAddress != nil || t.UserAgent != nil)
+	})
+	tokens = page(tokens, 0, limit)
+	for _, token := range tokens {
+		token.IPAddress, token.UserAgent = nil, nil
+		r.store.refreshTokens.put(tx, token.ID, token)
+	}
+	return int64(len(tokens)), nil
+}
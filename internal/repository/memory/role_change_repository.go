@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type roleChangeRepository struct {
+	store *Store
+}
+
+func NewRoleChangeRepository(store *Store) repositories.RoleChangeRepository {
+	return &roleChangeRepository{
+		store: store,
+	}
+}
+
+func (r *roleChangeRepository) Create(ctx context.Context, change *models.RoleChange) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(change.AccountID), optional(&r.store.accounts, change.ChangedBy)); err != nil {
+		return err
+	}
+	row := copyOf(change)
+	row.CreatedAt = timestamp()
+	if err := r.store.roleChanges.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*change = *row
+	return nil
+}
+
+func (r *roleChangeRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.RoleChange, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	changes := r.store.roleChanges.find(func(c *models.RoleChange) bool { return c.AccountID == accountID })
+	sortNewestFirst(changes, func(c *models.RoleChange) time.Time { return c.CreatedAt })
+	return changes, nil
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+)
+
+type roleRepository struct {
+	store *Store
+}
+
+func NewRoleRepository(store *Store) repositories.RoleRepository {
+	return &roleRepository{
+		store: store,
+	}
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	permissions, err := sortedPermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+	row := &models.Role{
+		Code:        role.Code,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   timestamp(),
+	}
+	if err := r.store.roles.insert(tx, row.Code, row); err != nil {
+		return err
+	}
+	role.System = row.System
+	role.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *roleRepository) GetByCode(ctx context.Context, code domain.Role) (*models.Role, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	role, ok := r.store.roles.get(code)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return role, nil
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	roles := r.store.roles.find(func(*models.Role) bool { return true })
+	slices.SortFunc(roles, func(a, b *models.Role) int { return strings.Compare(string(a.Code), string(b.Code)) })
+	return roles, nil
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	permissions, err := sortedPermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+	_, err = r.store.roles.update(tx, role.Code, func(row *models.Role) bool {
+		row.Description = role.Description
+		row.Permissions = permissions
+		return true
+	})
+	return err
+}
+
+func (r *roleRepository) Delete(ctx context.Context, code domain.Role) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if r.store.accounts.exists(func(a *models.Account) bool { return a.RoleCode == code }) {
+		return domain.ErrConflict
+	}
+	role, ok := r.store.roles.get(code)
+	if !ok || role.System {
+		return domain.ErrNotFound
+	}
+	// Memberships and invitations hold the role by foreign key; role changes
+	// keep the codes of deleted roles.
+	if r.store.memberships.exists(func(m *models.Membership) bool { return m.RoleCode == code }) ||
+		r.store.invitations.exists(func(i *models.Invitation) bool { return i.RoleCode == code }) {
+		return domain.ErrConflict
+	}
+	r.store.roles.remove(tx, code)
+	return nil
+}
+
+func (r *roleRepository) ListPermissions(ctx context.Context, code domain.Role) ([]string, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	role, ok := r.store.roles.get(code)
+	if !ok {
+		return nil, nil
+	}
+	return slices.Clone(role.Permissions), nil
+}
+
+// sortedPermissions sorts the permissions of a role, failing with
+// domain.ErrConflict on duplicates like the primary key of role_permissions.
+func sortedPermissions(permissions []string) ([]string, error) {
+	sorted := slices.Clone(permissions)
+	slices.Sort(sorted)
+	if len(slices.Compact(slices.Clone(sorted))) != len(sorted) {
+		return nil, domain.ErrConflict
+	}
+	return sorted, nil
+}
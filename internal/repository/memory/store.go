@@ -0,0 +1,482 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// errMissingReference stands for the foreign key violations of Postgres:
+// the row refers to one that does not exist.
+var errMissingReference = errors.New("referenced row does not exist")
+
+// Store holds the tables behind the in-memory repositories, with the same
+// unique keys, cascades and seeded roles and providers as the Postgres
+// schema. Its data lives as long as the process, so it suits tests and local
+// demos.
+type Store struct {
+	// txMu serializes transactions, standing in for the row and advisory
+	// locks of Postgres; mu guards the tables during each operation.
+	txMu sync.Mutex
+	mu   sync.Mutex
+
+	roles       table[domain.Role, models.Role]
+	providers   table[domain.Provider, string]
+	accounts    table[uuid.UUID, models.Account]
+	authMethods table[uuid.UUID, models.AuthMethod]
+
+	passwordCredentials table[uuid.UUID, models.PasswordCredential]
+	passwordHistory     table[uuid.UUID, models.PasswordHistoryEntry]
+	verificationCodes   table[uuid.UUID, models.VerificationCode]
+	refreshTokens       table[uuid.UUID, models.RefreshToken]
+	accessTokens        table[uuid.UUID, models.AccessToken]
+	apiKeys             table[uuid.UUID, models.APIKey]
+	signingKeys         table[string, models.SigningKey]
+
+	mfaFactors         table[uuid.UUID, models.MFAFactor]
+	mfaChallenges      table[uuid.UUID, models.MFAChallenge]
+	mfaCodes           table[uuid.UUID, models.MFACode]
+	mfaRecoveryCodes   table[uuid.UUID, models.MFARecoveryCode]
+	trustedDevices     table[uuid.UUID, models.TrustedDevice]
+	passkeyCredentials table[uuid.UUID, models.PasskeyCredential]
+	passkeyCeremonies  table[uuid.UUID, models.PasskeyCeremony]
+
+	oauthClients       table[uuid.UUID, models.OAuthClient]
+	authorizationCodes table[uuid.UUID, models.AuthorizationCode]
+	browserSessions    table[uuid.UUID, models.BrowserSession]
+	deviceCodes        table[uuid.UUID, models.DeviceCode]
+
+	accountBans      table[uuid.UUID, models.AccountBan]
+	accountDeletions table[uuid.UUID, models.AccountDeletion]
+	dataExports      table[uuid.UUID, models.DataExport]
+	legalDocuments   table[uuid.UUID, models.LegalDocument]
+	consents         table[uuid.UUID, models.Consent]
+	roleChanges      table[uuid.UUID, models.RoleChange]
+	impersonations   table[uuid.UUID, models.Impersonation]
+	auditEvents      table[uuid.UUID, models.AuditEvent]
+
+	organizations table[uuid.UUID, models.Organization]
+	memberships   table[membershipKey, models.Membership]
+	invitations   table[uuid.UUID, models.Invitation]
+	brandings     table[uuid.UUID, models.Branding]
+	policies      table[uuid.UUID, models.AuthPolicy]
+
+	webhookEndpoints  table[uuid.UUID, models.WebhookEndpoint]
+	webhookDeliveries table[uuid.UUID, models.WebhookDelivery]
+	webhookAttempts   table[uuid.UUID, models.WebhookDeliveryAttempt]
+	outboxEvents      table[uuid.UUID, models.OutboxEvent]
+}
+
+type membershipKey struct {
+	organizationID uuid.UUID
+	accountID      uuid.UUID
+}
+
+func NewStore() *Store {
+	s := &Store{}
+	now := timestamp()
+	for _, role := range []domain.Role{domain.RoleAdmin, domain.RoleUser} {
+		s.roles.put(nil, role, &models.Role{Code: role, System: true, CreatedAt: now})
+	}
+	for _, provider := range []domain.Provider{
+		domain.ProviderEmail, domain.ProviderGoogle, domain.ProviderGithub, domain.ProviderApple, domain.ProviderMicrosoft,
+	} {
+		s.providers.put(nil, provider, new(string))
+	}
+	return s
+}
+
+// lock holds the tables for one operation and returns the transaction of
+// ctx, or nil outside of one, to record the changes to undo on rollback.
+func (s *Store) lock(ctx context.Context) (*transaction, func()) {
+	s.mu.Lock()
+	return s.transaction(ctx), s.mu.Unlock
+}
+
+func (s *Store) transaction(ctx context.Context) *transaction {
+	if tx, ok := ctx.Value(txKey{}).(*transaction); ok && tx.store == s {
+		return tx
+	}
+	return nil
+}
+
+// transaction records how to undo the changes made within it, in the order
+// they were made, and the functions to run once it commits.
+type transaction struct {
+	store       *Store
+	undo        []func()
+	afterCommit []func(context.Context)
+}
+
+type txKey struct{}
+
+func (tx *transaction) onRollback(fn func()) {
+	if tx != nil {
+		tx.undo = append(tx.undo, fn)
+	}
+}
+
+func (tx *transaction) rollback() {
+	tx.store.mu.Lock()
+	defer tx.store.mu.Unlock()
+
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+}
+
+// table is a set of rows by primary key. Rows are copied in and out and
+// replaced rather than changed in place, so an undone change only has to
+// put the previous row back.
+type table[K comparable, V any] struct {
+	rows map[K]*V
+}
+
+func (t *table[K, V]) get(key K) (*V, bool) {
+	row, ok := t.rows[key]
+	if !ok {
+		return nil, false
+	}
+	return copyOf(row), true
+}
+
+func (t *table[K, V]) has(key K) bool {
+	_, ok := t.rows[key]
+	return ok
+}
+
+// insert adds a row, failing with domain.ErrConflict when its key is taken.
+func (t *table[K, V]) insert(tx *transaction, key K, row *V) error {
+	if t.has(key) {
+		return domain.ErrConflict
+	}
+	t.put(tx, key, row)
+	return nil
+}
+
+// put adds a copy of row, replacing any row with the same key.
+func (t *table[K, V]) put(tx *transaction, key K, row *V) {
+	if t.rows == nil {
+		t.rows = make(map[K]*V)
+	}
+	previous, existed := t.rows[key]
+	t.rows[key] = copyOf(row)
+	tx.onRollback(func() {
+		if existed {
+			t.rows[key] = previous
+		} else {
+			delete(t.rows, key)
+		}
+	})
+}
+
+// update changes the row with the key and returns a copy of it, or
+// domain.ErrNotFound when there is none or change does not apply to it.
+func (t *table[K, V]) update(tx *transaction, key K, change func(row *V) bool) (*V, error) {
+	row, ok := t.get(key)
+	if !ok || !change(row) {
+		return nil, domain.ErrNotFound
+	}
+	t.put(tx, key, row)
+	return copyOf(row), nil
+}
+
+// updateWhere changes the rows change applies to and returns how many.
+func (t *table[K, V]) updateWhere(tx *transaction, change func(row *V) bool) int64 {
+	var updated int64
+	for key, stored := range t.rows {
+		row := copyOf(stored)
+		if change(row) {
+			t.put(tx, key, row)
+			updated++
+		}
+	}
+	return updated
+}
+
+func (t *table[K, V]) remove(tx *transaction, key K) bool {
+	previous, ok := t.rows[key]
+	if !ok {
+		return false
+	}
+	delete(t.rows, key)
+	tx.onRollback(func() { t.rows[key] = previous })
+	return true
+}
+
+// removeWhere deletes the rows matching match and returns those deleted.
+func (t *table[K, V]) removeWhere(tx *transaction, match func(row *V) bool) []*V {
+	var removed []*V
+	for key, row := range t.rows {
+		if match(row) {
+			t.remove(tx, key)
+			removed = append(removed, row)
+		}
+	}
+	return removed
+}
+
+// find returns copies of the rows matching match, in no particular order.
+func (t *table[K, V]) find(match func(row *V) bool) []*V {
+	var rows []*V
+	for _, row := range t.rows {
+		if match(row) {
+			rows = append(rows, copyOf(row))
+		}
+	}
+	return rows
+}
+
+func (t *table[K, V]) first(match func(row *V) bool) (*V, bool) {
+	for _, row := range t.rows {
+		if match(row) {
+			return copyOf(row), true
+		}
+	}
+	return nil, false
+}
+
+func (t *table[K, V]) exists(match func(row *V) bool) bool {
+	_, ok := t.first(match)
+	return ok
+}
+
+// timestamp is the current time at the microsecond precision of Postgres,
+// for the columns it fills in.
+func timestamp() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+func copyOf[V any](row *V) *V {
+	copied := *row
+	return &copied
+}
+
+// page returns up to limit rows starting at offset.
+func page[V any](rows []*V, offset, limit int) []*V {
+	if offset >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// sortOldestFirst orders rows by the time at returns, oldest first.
+func sortOldestFirst[V any](rows []*V, at func(row *V) time.Time) {
+	slices.SortStableFunc(rows, func(a, b *V) int { return at(a).Compare(at(b)) })
+}
+
+// sortNewestFirst orders rows by the time at returns, newest first.
+func sortNewestFirst[V any](rows []*V, at func(row *V) time.Time) {
+	slices.SortStableFunc(rows, func(a, b *V) int { return at(b).Compare(at(a)) })
+}
+
+// references fails with errMissingReference unless every referenced row
+// exists.
+func references(exist ...bool) error {
+	for _, ok := range exist {
+		if !ok {
+			return errMissingReference
+		}
+	}
+	return nil
+}
+
+// optional reports whether a nullable reference is either unset or points
+// to a row of t.
+func optional[V any](t *table[uuid.UUID, V], id *uuid.UUID) bool {
+	return id == nil || t.has(*id)
+}
+
+// deleteAccount removes an account with the rows that cascade from it, and
+// clears the references to it that outlive it, as the schema does.
+func (s *Store) deleteAccount(tx *transaction, id uuid.UUID) bool {
+	if !s.accounts.remove(tx, id) {
+		return false
+	}
+
+	for _, method := range s.authMethods.find(func(m *models.AuthMethod) bool { return m.AccountID == id }) {
+		s.deleteAuthMethod(tx, method.ID)
+	}
+	for _, factor := range s.mfaFactors.find(func(f *models.MFAFactor) bool { return f.AccountID == id }) {
+		s.deleteMFAFactor(tx, factor.ID)
+	}
+	s.refreshTokens.removeWhere(tx, func(t *models.RefreshToken) bool { return t.AccountID == id })
+	s.apiKeys.removeWhere(tx, func(k *models.APIKey) bool { return k.AccountID == id })
+	s.mfaChallenges.removeWhere(tx, func(c *models.MFAChallenge) bool { return c.AccountID == id })
+	s.mfaRecoveryCodes.removeWhere(tx, func(c *models.MFARecoveryCode) bool { return c.AccountID == id })
+	s.trustedDevices.removeWhere(tx, func(d *models.TrustedDevice) bool { return d.AccountID == id })
+	s.passkeyCredentials.removeWhere(tx, func(c *models.PasskeyCredential) bool { return c.AccountID == id })
+	s.passkeyCeremonies.removeWhere(tx, func(c *models.PasskeyCeremony) bool { return c.AccountID != nil && *c.AccountID == id })
+	s.authorizationCodes.removeWhere(tx, func(c *models.AuthorizationCode) bool { return c.AccountID == id })
+	s.browserSessions.removeWhere(tx, func(b *models.BrowserSession) bool { return b.AccountID == id })
+	s.deviceCodes.removeWhere(tx, func(c *models.DeviceCode) bool { return c.AccountID != nil && *c.AccountID == id })
+	s.accountBans.removeWhere(tx, func(b *models.AccountBan) bool { return b.AccountID == id })
+	s.accountDeletions.remove(tx, id)
+	s.dataExports.removeWhere(tx, func(e *models.DataExport) bool { return e.AccountID == id })
+	s.consents.removeWhere(tx, func(c *models.Consent) bool { return c.AccountID == id })
+	s.roleChanges.removeWhere(tx, func(c *models.RoleChange) bool { return c.AccountID == id })
+	s.impersonations.removeWhere(tx, func(i *models.Impersonation) bool { return i.AccountID == id })
+	s.memberships.removeWhere(tx, func(m *models.Membership) bool { return m.AccountID == id })
+
+	s.accountBans.updateWhere(tx, func(b *models.AccountBan) bool {
+		return clearReferences(id, &b.BannedBy, &b.LiftedBy)
+	})
+	s.roleChanges.updateWhere(tx, func(c *models.RoleChange) bool { return clearReferences(id, &c.ChangedBy) })
+	s.impersonations.updateWhere(tx, func(i *models.Impersonation) bool { return clearReferences(id, &i.AdminID) })
+	s.invitations.updateWhere(tx, func(i *models.Invitation) bool {
+		return clearReferences(id, &i.InvitedBy, &i.AcceptedBy)
+	})
+	s.webhookEndpoints.updateWhere(tx, func(e *models.WebhookEndpoint) bool { return clearReferences(id, &e.CreatedBy) })
+	return true
+}
+
+// eraseAccount anonymizes the personal data held about an account, as the
+// Erase queries of Postgres do.
+func (s *Store) eraseAccount(tx *transaction, id uuid.UUID) {
+	methods := s.authMethods.find(func(m *models.AuthMethod) bool { return m.AccountID == id })
+	emails := make(map[string]bool)
+	for _, method := range methods {
+		if method.ProviderCode == domain.ProviderEmail {
+			emails[method.ProviderID] = true
+		}
+	}
+	s.invitations.updateWhere(tx, func(i *models.Invitation) bool {
+		if !emails[i.Email] && (i.AcceptedBy == nil || *i.AcceptedBy != id) {
+			return false
+		}
+		i.Email = domain.ErasedPrefix + i.ID.String()
+		return true
+	})
+
+	for _, method := range methods {
+		s.passwordCredentials.remove(tx, method.ID)
+		s.passwordHistory.removeWhere(tx, func(e *models.PasswordHistoryEntry) bool { return e.AuthMethodID == method.ID })
+		s.verificationCodes.removeWhere(tx, func(c *models.VerificationCode) bool { return c.AuthMethodID == method.ID })
+	}
+	s.authMethods.updateWhere(tx, func(m *models.AuthMethod) bool {
+		if m.AccountID != id {
+			return false
+		}
+		m.ProviderID = domain.ErasedPrefix + m.ID.String()
+		m.IsVerified, m.LastLoginAt, m.FailedAttempts, m.LockedUntil = false, nil, 0, nil
+		return true
+	})
+	s.refreshTokens.updateWhere(tx, func(t *models.RefreshToken) bool {
+		if t.AccountID != id {
+			return false
+		}
+		t.IPAddress, t.UserAgent = nil, nil
+		return true
+	})
+	s.auditEvents.updateWhere(tx, func(e *models.AuditEvent) bool {
+		if (e.ActorID == nil || *e.ActorID != id) && (e.TargetID == nil || *e.TargetID != id) && !emails[e.Details["email"]] {
+			return false
+		}
+		e.IPAddress, e.UserAgent = nil, nil
+		if _, ok := e.Details["email"]; ok {
+			e.Details = maps.Clone(e.Details)
+			delete(e.Details, "email")
+		}
+		return true
+	})
+	s.outboxEvents.removeWhere(tx, func(e *models.OutboxEvent) bool {
+		var payload struct {
+			AccountID uuid.UUID `json:"account_id"`
+			Email     string    `json:"email"`
+		}
+		return json.Unmarshal(e.Payload, &payload) == nil && (payload.AccountID == id || emails[payload.Email])
+	})
+	for _, delivery := range s.webhookDeliveries.removeWhere(tx, func(d *models.WebhookDelivery) bool {
+		var body struct {
+			Data struct {
+				AccountID uuid.UUID `json:"account_id"`
+			} `json:"data"`
+		}
+		return json.Unmarshal(d.Payload, &body) == nil && body.Data.AccountID == id
+	}) {
+		s.webhookAttempts.removeWhere(tx, func(a *models.WebhookDeliveryAttempt) bool { return a.DeliveryID == delivery.ID })
+	}
+	s.consents.updateWhere(tx, func(c *models.Consent) bool {
+		if c.AccountID != id {
+			return false
+		}
+		c.IPAddress, c.UserAgent = nil, nil
+		return true
+	})
+
+	for _, factor := range s.mfaFactors.find(func(f *models.MFAFactor) bool { return f.AccountID == id }) {
+		s.deleteMFAFactor(tx, factor.ID)
+	}
+	s.mfaRecoveryCodes.removeWhere(tx, func(c *models.MFARecoveryCode) bool { return c.AccountID == id })
+	s.trustedDevices.removeWhere(tx, func(d *models.TrustedDevice) bool { return d.AccountID == id })
+	s.passkeyCredentials.removeWhere(tx, func(c *models.PasskeyCredential) bool { return c.AccountID == id })
+	s.apiKeys.removeWhere(tx, func(k *models.APIKey) bool { return k.AccountID == id })
+	s.dataExports.removeWhere(tx, func(e *models.DataExport) bool { return e.AccountID == id })
+	s.accountDeletions.remove(tx, id)
+}
+
+func (s *Store) deleteAuthMethod(tx *transaction, id uuid.UUID) bool {
+	if !s.authMethods.remove(tx, id) {
+		return false
+	}
+	s.passwordCredentials.remove(tx, id)
+	s.passwordHistory.removeWhere(tx, func(e *models.PasswordHistoryEntry) bool { return e.AuthMethodID == id })
+	s.verificationCodes.removeWhere(tx, func(c *models.VerificationCode) bool { return c.AuthMethodID == id })
+	return true
+}
+
+func (s *Store) deleteMFAFactor(tx *transaction, id uuid.UUID) bool {
+	if !s.mfaFactors.remove(tx, id) {
+		return false
+	}
+	s.mfaCodes.removeWhere(tx, func(c *models.MFACode) bool { return c.FactorID == id })
+	return true
+}
+
+func (s *Store) deleteOrganization(tx *transaction, id uuid.UUID) bool {
+	if !s.organizations.remove(tx, id) {
+		return false
+	}
+	s.memberships.removeWhere(tx, func(m *models.Membership) bool { return m.OrganizationID == id })
+	s.invitations.removeWhere(tx, func(i *models.Invitation) bool { return i.OrganizationID == id })
+	s.signingKeys.removeWhere(tx, func(k *models.SigningKey) bool { return k.OrganizationID != nil && *k.OrganizationID == id })
+	s.brandings.remove(tx, id)
+	s.policies.remove(tx, id)
+	s.refreshTokens.updateWhere(tx, func(t *models.RefreshToken) bool { return clearReferences(id, &t.OrganizationID) })
+	return true
+}
+
+func (s *Store) deleteWebhookEndpoint(tx *transaction, id uuid.UUID) bool {
+	if !s.webhookEndpoints.remove(tx, id) {
+		return false
+	}
+	for _, delivery := range s.webhookDeliveries.removeWhere(tx, func(d *models.WebhookDelivery) bool { return d.EndpointID == id }) {
+		s.webhookAttempts.removeWhere(tx, func(a *models.WebhookDeliveryAttempt) bool { return a.DeliveryID == delivery.ID })
+	}
+	return true
+}
+
+// clearReferences sets the nullable references to id to nil and reports
+// whether there were any.
+func clearReferences(id uuid.UUID, refs ...**uuid.UUID) bool {
+	cleared := false
+	for _, ref := range refs {
+		if *ref != nil && **ref == id {
+			*ref = nil
+			cleared = true
+		}
+	}
+	return cleared
+}
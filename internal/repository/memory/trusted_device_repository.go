@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type trustedDeviceRepository struct {
+	store *Store
+}
+
+func NewTrustedDeviceRepository(store *Store) repositories.TrustedDeviceRepository {
+	return &trustedDeviceRepository{
+		store: store,
+	}
+}
+
+func (r *trustedDeviceRepository) Create(ctx context.Context, device *models.TrustedDevice) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.accounts.has(device.AccountID)); err != nil {
+		return err
+	}
+	if r.store.trustedDevices.exists(func(d *models.TrustedDevice) bool { return d.TokenHash == device.TokenHash }) {
+		return domain.ErrConflict
+	}
+	row := &models.TrustedDevice{
+		ID:        device.ID,
+		AccountID: device.AccountID,
+		TokenHash: device.TokenHash,
+		IPAddress: device.IPAddress,
+		UserAgent: device.UserAgent,
+		ExpiresAt: device.ExpiresAt,
+		CreatedAt: timestamp(),
+	}
+	if err := r.store.trustedDevices.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*device = *row
+	return nil
+}
+
+func (r *trustedDeviceRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.TrustedDevice, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	device, ok := r.store.trustedDevices.first(func(d *models.TrustedDevice) bool { return d.TokenHash == tokenHash })
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return device, nil
+}
+
+func (r *trustedDeviceRepository) ListActiveByAccountID(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*models.TrustedDevice, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	devices := r.store.trustedDevices.find(func(d *models.TrustedDevice) bool {
+		return d.AccountID == accountID && d.ExpiresAt.After(now)
+	})
+	sortNewestFirst(devices, func(d *models.TrustedDevice) time.Time { return d.CreatedAt })
+	return devices, nil
+}
+
+func (r *trustedDeviceRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.trustedDevices.update(tx, id, func(d *models.TrustedDevice) bool {
+		d.LastUsedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *trustedDeviceRepository) Delete(ctx context.Context, id, accountID uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	device, ok := r.store.trustedDevices.get(id)
+	if !ok || device.AccountID != accountID {
+		return domain.ErrNotFound
+	}
+	r.store.trustedDevices.remove(tx, id)
+	return nil
+}
+
+func (r *trustedDeviceRepository) DeleteAllByAccountID(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	removed := r.store.trustedDevices.removeWhere(tx, func(d *models.TrustedDevice) bool { return d.AccountID == accountID })
+	return int64(len(removed)), nil
+}
+
+func (r *trustedDeviceRepository) ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	devices := r.store.trustedDevices.find(func(d *models.TrustedDevice) bool {
+		return d.CreatedAt.Before(before) && (d.IPAddress != nil || d.UserAgent != nil)
+	})
+	devices = page(devices, 0, limit)
+	for _, device := range devices {
+		device.IPAddress, device.UserAgent = nil, nil
+		r.store.trustedDevices.put(tx, device.ID, device)
+	}
+	return int64(len(devices)), nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import "context"
+
+// MemoryTxManager runs transactions against a Store one at a time, undoing
+// their changes when they fail. A transaction started within another joins
+// it.
+type MemoryTxManager struct {
+	store *Store
+}
+
+func NewMemoryTxManager(store *Store) *MemoryTxManager {
+	return &MemoryTxManager{store: store}
+}
+
+func (m *MemoryTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.store.transaction(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx := &transaction{store: m.store}
+	if err := m.run(ctx, tx, fn); err != nil {
+		return err
+	}
+
+	for _, run := range tx.afterCommit {
+		run(ctx)
+	}
+	return nil
+}
+
+// run holds the transaction lock while fn runs, undoing its changes unless
+// it succeeds, panics included.
+func (m *MemoryTxManager) run(ctx context.Context, tx *transaction, fn func(ctx context.Context) error) error {
+	m.store.txMu.Lock()
+	defer m.store.txMu.Unlock()
+
+	committed := false
+	defer func() {
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func (m *MemoryTxManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if tx := m.store.transaction(ctx); tx != nil {
+		tx.afterCommit = append(tx.afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type verificationCodeRepository struct {
+	store *Store
+}
+
+func NewVerificationCodeRepository(store *Store) repositories.VerificationCodeRepository {
+	return &verificationCodeRepository{
+		store: store,
+	}
+}
+
+func (r *verificationCodeRepository) Create(ctx context.Context, code *models.VerificationCode) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.authMethods.has(code.AuthMethodID)); err != nil {
+		return err
+	}
+	row := &models.VerificationCode{
+		ID:           code.ID,
+		AuthMethodID: code.AuthMethodID,
+		Purpose:      code.Purpose,
+		CodeHash:     code.CodeHash,
+		Attempts:     code.Attempts,
+		ExpiresAt:    code.ExpiresAt,
+		CreatedAt:    timestamp(),
+	}
+	if err := r.store.verificationCodes.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	*code = *row
+	return nil
+}
+
+func (r *verificationCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.VerificationCode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	code, ok := r.store.verificationCodes.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return code, nil
+}
+
+func (r *verificationCodeRepository) GetLatestByAuthMethodID(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose) (*models.VerificationCode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	var latest *models.VerificationCode
+	for _, code := range r.store.verificationCodes.find(func(c *models.VerificationCode) bool {
+		return c.AuthMethodID == authMethodID && c.Purpose == purpose
+	}) {
+		if latest == nil || code.CreatedAt.After(latest.CreatedAt) {
+			latest = code
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrNotFound
+	}
+	return latest, nil
+}
+
+func (r *verificationCodeRepository) GetByCodeHash(ctx context.Context, purpose domain.CodePurpose, codeHash string) (*models.VerificationCode, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	code, ok := r.store.verificationCodes.first(func(c *models.VerificationCode) bool {
+		return c.Purpose == purpose && c.CodeHash == codeHash
+	})
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return code, nil
+}
+
+func (r *verificationCodeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	code, err := r.store.verificationCodes.update(tx, id, func(c *models.VerificationCode) bool {
+		c.Attempts++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return code.Attempts, nil
+}
+
+func (r *verificationCodeRepository) MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.verificationCodes.update(tx, id, func(c *models.VerificationCode) bool {
+		if c.ConsumedAt != nil {
+			return false
+		}
+		c.ConsumedAt = &at
+		return true
+	})
+	return err
+}
+
+func (r *verificationCodeRepository) InvalidateActive(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	r.store.verificationCodes.updateWhere(tx, func(c *models.VerificationCode) bool {
+		if c.AuthMethodID != authMethodID || c.Purpose != purpose || c.ConsumedAt != nil || !c.ExpiresAt.After(at) {
+			return false
+		}
+		c.ExpiresAt = at
+		return true
+	})
+	return nil
+}
+
+func (r *verificationCodeRepository) CountCreatedSince(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, since time.Time) (int, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return len(r.store.verificationCodes.find(func(c *models.VerificationCode) bool {
+		return c.AuthMethodID == authMethodID && c.Purpose == purpose && !c.CreatedAt.Before(since)
+	})), nil
+}
+
+func (r *verificationCodeRepository) DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	stale := r.store.verificationCodes.find(func(c *models.VerificationCode) bool {
+		return c.ExpiresAt.Before(before) || (c.ConsumedAt != nil && c.ConsumedAt.Before(before))
+	})
+	stale = page(stale, 0, limit)
+	for _, code := range stale {
+		r.store.verificationCodes.remove(tx, code.ID)
+	}
+	return int64(len(stale)), nil
+}
@@ -0,0 +1,283 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/google/uuid"
+)
+
+type webhookEndpointRepository struct {
+	store *Store
+}
+
+func NewWebhookEndpointRepository(store *Store) repositories.WebhookEndpointRepository {
+	return &webhookEndpointRepository{
+		store: store,
+	}
+}
+
+func (r *webhookEndpointRepository) Create(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(optional(&r.store.accounts, endpoint.CreatedBy)); err != nil {
+		return err
+	}
+	now := timestamp()
+	row := &models.WebhookEndpoint{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      slices.Clone(endpoint.Events),
+		Secret:      slices.Clone(endpoint.Secret),
+		IsActive:    true,
+		CreatedBy:   endpoint.CreatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := r.store.webhookEndpoints.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	endpoint.IsActive = row.IsActive
+	endpoint.CreatedAt = row.CreatedAt
+	endpoint.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookEndpointRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	endpoint, ok := r.store.webhookEndpoints.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return endpoint, nil
+}
+
+func (r *webhookEndpointRepository) List(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.list(func(*models.WebhookEndpoint) bool { return true }), nil
+}
+
+func (r *webhookEndpointRepository) ListSubscribed(ctx context.Context, event domain.WebhookEvent) ([]*models.WebhookEndpoint, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	return r.list(func(e *models.WebhookEndpoint) bool { return e.IsActive && slices.Contains(e.Events, event) }), nil
+}
+
+// list returns the endpoints matching match, oldest first.
+func (r *webhookEndpointRepository) list(match func(endpoint *models.WebhookEndpoint) bool) []*models.WebhookEndpoint {
+	endpoints := r.store.webhookEndpoints.find(match)
+	slices.SortFunc(endpoints, func(a, b *models.WebhookEndpoint) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(a.ID, b.ID)
+	})
+	return endpoints
+}
+
+func (r *webhookEndpointRepository) Update(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	row, err := r.store.webhookEndpoints.update(tx, endpoint.ID, func(e *models.WebhookEndpoint) bool {
+		e.URL = endpoint.URL
+		e.Description = endpoint.Description
+		e.Events = slices.Clone(endpoint.Events)
+		e.IsActive = endpoint.IsActive
+		e.UpdatedAt = timestamp()
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	endpoint.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookEndpointRepository) UpdateSecret(ctx context.Context, id uuid.UUID, secret []byte) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.webhookEndpoints.update(tx, id, func(e *models.WebhookEndpoint) bool {
+		e.Secret = slices.Clone(secret)
+		e.UpdatedAt = timestamp()
+		return true
+	})
+	return err
+}
+
+func (r *webhookEndpointRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if !r.store.deleteWebhookEndpoint(tx, id) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+type webhookDeliveryRepository struct {
+	store *Store
+}
+
+func NewWebhookDeliveryRepository(store *Store) repositories.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		store: store,
+	}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	if err := references(r.store.webhookEndpoints.has(delivery.EndpointID)); err != nil {
+		return err
+	}
+	// The endpoint already has a delivery of the event.
+	if r.store.webhookDeliveries.exists(func(d *models.WebhookDelivery) bool {
+		return d.EndpointID == delivery.EndpointID && d.EventID == delivery.EventID
+	}) {
+		return nil
+	}
+	now := timestamp()
+	row := &models.WebhookDelivery{
+		ID:            delivery.ID,
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Payload:       slices.Clone(delivery.Payload),
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := r.store.webhookDeliveries.insert(tx, row.ID, row); err != nil {
+		return err
+	}
+	delivery.Status = row.Status
+	delivery.CreatedAt = row.CreatedAt
+	delivery.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	delivery, ok := r.store.webhookDeliveries.get(id)
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (r *webhookDeliveryRepository) ListByEndpointID(ctx context.Context, endpointID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	deliveries := r.store.webhookDeliveries.find(func(d *models.WebhookDelivery) bool { return d.EndpointID == endpointID })
+	slices.SortFunc(deliveries, func(a, b *models.WebhookDelivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(b.ID, a.ID)
+	})
+	return page(deliveries, 0, limit), nil
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	due := r.store.webhookDeliveries.find(func(d *models.WebhookDelivery) bool {
+		return d.Status == domain.WebhookDeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now)
+	})
+	sortOldestFirst(due, func(d *models.WebhookDelivery) time.Time { return *d.NextAttemptAt })
+
+	claimed := make([]*models.WebhookDelivery, 0, len(due))
+	for _, delivery := range page(due, 0, limit) {
+		delivery, err := r.store.webhookDeliveries.update(tx, delivery.ID, func(d *models.WebhookDelivery) bool {
+			d.NextAttemptAt = &leaseUntil
+			d.UpdatedAt = timestamp()
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, delivery)
+	}
+	return claimed, nil
+}
+
+func (r *webhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.webhookDeliveries.update(tx, delivery.ID, func(d *models.WebhookDelivery) bool {
+		if d.Status != domain.WebhookDeliveryPending {
+			return false
+		}
+		d.Status = delivery.Status
+		d.Attempts++
+		d.NextAttemptAt = delivery.NextAttemptAt
+		d.LastStatusCode = attempt.StatusCode
+		d.LastError = attempt.Error
+		d.DeliveredAt = delivery.DeliveredAt
+		d.UpdatedAt = timestamp()
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.store.webhookAttempts.insert(tx, attempt.ID, &models.WebhookDeliveryAttempt{
+		ID:         attempt.ID,
+		DeliveryID: delivery.ID,
+		StatusCode: attempt.StatusCode,
+		Error:      attempt.Error,
+		Duration:   attempt.Duration.Truncate(time.Millisecond),
+		CreatedAt:  timestamp(),
+	})
+}
+
+func (r *webhookDeliveryRepository) Requeue(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	_, err := r.store.webhookDeliveries.update(tx, id, func(d *models.WebhookDelivery) bool {
+		if d.Status == domain.WebhookDeliveryPending {
+			return false
+		}
+		d.Status = domain.WebhookDeliveryPending
+		d.Attempts = 0
+		d.NextAttemptAt = &at
+		d.UpdatedAt = timestamp()
+		return true
+	})
+	return err
+}
+
+func (r *webhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*models.WebhookDeliveryAttempt, error) {
+	_, unlock := r.store.lock(ctx)
+	defer unlock()
+
+	attempts := r.store.webhookAttempts.find(func(a *models.WebhookDeliveryAttempt) bool { return a.DeliveryID == deliveryID })
+	slices.SortFunc(attempts, func(a, b *models.WebhookDeliveryAttempt) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(a.ID, b.ID)
+	})
+	return attempts, nil
+}
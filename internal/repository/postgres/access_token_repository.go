@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type accessTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccessTokenRepository(pool *pgxpool.Pool) repositories.AccessTokenRepository {
+	return &accessTokenRepository{
+		pool: pool,
+	}
+}
+
+func (r *accessTokenRepository) Create(ctx context.Context, token *models.AccessToken) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAccessToken(ctx, sqlc.CreateAccessTokenParams{
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		Claims:    token.Claims,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*token = *mapToDomainAccessToken(row)
+	return nil
+}
+
+func (r *accessTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*models.AccessToken, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAccessTokenByTokenHash(ctx, sqlc.GetAccessTokenByTokenHashParams{
+		TokenHash: tokenHash,
+		ExpiresAt: now,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAccessToken(row), nil
+}
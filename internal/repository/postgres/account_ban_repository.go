@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type accountBanRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccountBanRepository(pool *pgxpool.Pool) repositories.AccountBanRepository {
+	return &accountBanRepository{
+		pool: pool,
+	}
+}
+
+func (r *accountBanRepository) Create(ctx context.Context, ban *models.AccountBan) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAccountBan(ctx, sqlc.CreateAccountBanParams{
+		ID:                 ban.ID,
+		AccountID:          ban.AccountID,
+		PreviousStatusCode: string(ban.PreviousStatus),
+		Reason:             ban.Reason,
+		BannedBy:           ban.BannedBy,
+		ExpiresAt:          ban.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	ban.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *accountBanRepository) GetActive(ctx context.Context, accountID uuid.UUID) (*models.AccountBan, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetActiveAccountBan(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAccountBan(row), nil
+}
+
+func (r *accountBanRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AccountBan, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAccountBansByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	bans := make([]*models.AccountBan, 0, len(rows))
+	for _, row := range rows {
+		bans = append(bans, mapToDomainAccountBan(row))
+	}
+	return bans, nil
+}
+
+func (r *accountBanRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.AccountBan, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListExpiredAccountBans(ctx, sqlc.ListExpiredAccountBansParams{
+		Now:   now,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	bans := make([]*models.AccountBan, 0, len(rows))
+	for _, row := range rows {
+		bans = append(bans, mapToDomainAccountBan(row))
+	}
+	return bans, nil
+}
+
+func (r *accountBanRepository) Lift(ctx context.Context, id uuid.UUID, at time.Time, liftedBy *uuid.UUID, reason string) error {
+	q := getQueries(ctx, r.pool)
+
+	var liftReason *string
+	if reason != "" {
+		liftReason = &reason
+	}
+	return expectAffected(q.LiftAccountBan(ctx, sqlc.LiftAccountBanParams{
+		ID:         id,
+		LiftedAt:   &at,
+		LiftedBy:   liftedBy,
+		LiftReason: liftReason,
+	}))
+}
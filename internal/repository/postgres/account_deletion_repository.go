@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type accountDeletionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccountDeletionRepository(pool *pgxpool.Pool) repositories.AccountDeletionRepository {
+	return &accountDeletionRepository{
+		pool: pool,
+	}
+}
+
+func (r *accountDeletionRepository) Create(ctx context.Context, deletion *models.AccountDeletion) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAccountDeletion(ctx, sqlc.CreateAccountDeletionParams{
+		AccountID: deletion.AccountID,
+		PurgeAt:   deletion.PurgeAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	deletion.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *accountDeletionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.AccountDeletion, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAccountDeletion(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAccountDeletion(row), nil
+}
+
+func (r *accountDeletionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AccountDeletion, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListDueAccountDeletions(ctx, sqlc.ListDueAccountDeletionsParams{
+		Now:   now,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	deletions := make([]*models.AccountDeletion, 0, len(rows))
+	for _, row := range rows {
+		deletions = append(deletions, mapToDomainAccountDeletion(row))
+	}
+	return deletions, nil
+}
+
+func (r *accountDeletionRepository) Delete(ctx context.Context, accountID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteAccountDeletion(ctx, accountID))
+}
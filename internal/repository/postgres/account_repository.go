@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type accountRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccountRepository(pool *pgxpool.Pool) repositories.AccountRepository {
+	return &accountRepository{
+		pool: pool,
+	}
+}
+
+func (r *accountRepository) Create(ctx context.Context, account *models.Account) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAccount(ctx, sqlc.CreateAccountParams{
+		ID:         account.ID,
+		RoleCode:   string(account.RoleCode),
+		StatusCode: string(account.StatusCode),
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*account = *mapToDomainAccount(row)
+	return nil
+}
+
+func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAccountByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAccount(row), nil
+}
+
+func (r *accountRepository) ListAfter(ctx context.Context, after models.AccountCursor, limit int) ([]*models.Account, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAccountsAfter(ctx, sqlc.ListAccountsAfterParams{
+		CreatedAt: after.CreatedAt,
+		ID:        after.ID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	accounts := make([]*models.Account, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, mapToDomainAccount(row))
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) ListIDsByRoleForUpdate(ctx context.Context, role domain.Role, status domain.Status) ([]uuid.UUID, error) {
+	q := getQueries(ctx, r.pool)
+
+	ids, err := q.ListAccountIDsByRoleForUpdate(ctx, sqlc.ListAccountIDsByRoleForUpdateParams{
+		RoleCode:   string(role),
+		StatusCode: string(status),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return ids, nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, whose default escape
+// character is the backslash.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *accountRepository) Search(ctx context.Context, filter models.AccountFilter, after models.AccountCursor, limit int) ([]*models.Account, error) {
+	q := getQueries(ctx, r.pool)
+
+	params := sqlc.SearchAccountsParams{
+		AfterCreatedAt: after.CreatedAt,
+		AfterID:        after.ID,
+		Limit:          int32(limit),
+	}
+	if filter.Role != "" {
+		role := string(filter.Role)
+		params.RoleCode = &role
+	}
+	if filter.Status != "" {
+		status := string(filter.Status)
+		params.StatusCode = &status
+	}
+	if filter.Provider != "" {
+		provider := string(filter.Provider)
+		params.ProviderCode = &provider
+	}
+	if !filter.CreatedFrom.IsZero() {
+		params.CreatedFrom = &filter.CreatedFrom
+	}
+	if !filter.CreatedBefore.IsZero() {
+		params.CreatedBefore = &filter.CreatedBefore
+	}
+	if filter.Email != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Email) + "%"
+		params.EmailPattern = &pattern
+	}
+
+	rows, err := q.SearchAccounts(ctx, params)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	accounts := make([]*models.Account, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, mapToDomainAccount(row))
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Account, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAccountsByIDs(ctx, ids)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	accounts := make([]*models.Account, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, mapToDomainAccount(row))
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAccountByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAccount(row), nil
+}
+
+func (r *accountRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.Status) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateAccountStatus(ctx, sqlc.UpdateAccountStatusParams{
+		ID:         id,
+		StatusCode: string(status),
+	}))
+}
+
+func (r *accountRepository) UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateAccountRole(ctx, sqlc.UpdateAccountRoleParams{
+		ID:       id,
+		RoleCode: string(role),
+	}))
+}
+
+func (r *accountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteAccount(ctx, id))
+}
+
+func (r *accountRepository) Erase(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	// Invitations, audit events and outbox events are matched on the
+	// addresses, and credentials on the methods, before the methods lose
+	// them.
+	steps := []func(context.Context, uuid.UUID) error{
+		q.EraseInvitations,
+		q.EraseAuditEvents,
+		q.EraseOutboxEvents,
+		q.EraseVerificationCodes,
+		q.ErasePasswordCredentials,
+		q.ErasePasswordHistory,
+		q.EraseAuthMethods,
+		q.EraseRefreshTokens,
+		q.EraseConsents,
+		q.EraseMFAFactors,
+		q.EraseMFARecoveryCodes,
+		q.EraseTrustedDevices,
+		q.ErasePasskeyCredentials,
+		q.EraseAPIKeys,
+		q.EraseDataExports,
+		q.EraseWebhookDeliveries,
+		q.EraseAccountDeletion,
+	}
+	for _, step := range steps {
+		if err := step(ctx, id); err != nil {
+			return mapPostgresError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type apiKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) repositories.APIKeyRepository {
+	return &apiKeyRepository{
+		pool: pool,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
+		ID:        key.ID,
+		AccountID: key.AccountID,
+		Name:      key.Name,
+		KeyHash:   key.KeyHash,
+		KeyPrefix: key.KeyPrefix,
+		Scopes:    strings.Join(key.Scopes, " "),
+		ExpiresAt: key.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*key = *mapToDomainAPIKey(row)
+	return nil
+}
+
+func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAPIKeyByKeyHash(ctx, keyHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAPIKey(row), nil
+}
+
+func (r *apiKeyRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.APIKey, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAPIKeysByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	keys := make([]*models.APIKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, mapToDomainAPIKey(row))
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) CountActive(ctx context.Context, accountID uuid.UUID, now time.Time) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	count, err := q.CountActiveAPIKeysByAccountID(ctx, sqlc.CountActiveAPIKeysByAccountIDParams{
+		AccountID: accountID,
+		ExpiresAt: &now,
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(count), nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id, accountID uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RevokeAPIKey(ctx, sqlc.RevokeAPIKeyParams{
+		ID:        id,
+		AccountID: accountID,
+		RevokedAt: &at,
+	}))
+}
+
+func (r *apiKeyRepository) RecordUse(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RecordAPIKeyUse(ctx, sqlc.RecordAPIKeyUseParams{
+		ID:         id,
+		LastUsedAt: &at,
+	}))
+}
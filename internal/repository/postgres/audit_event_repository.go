@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditEventRepository(pool *pgxpool.Pool) repositories.AuditEventRepository {
+	return &auditEventRepository{
+		pool: pool,
+	}
+}
+
+func (r *auditEventRepository) Append(ctx context.Context, event *models.AuditEvent) error {
+	q := getQueries(ctx, r.pool)
+
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	row, err := q.CreateAuditEvent(ctx, sqlc.CreateAuditEventParams{
+		ID:        event.ID,
+		Action:    string(event.Action),
+		ActorID:   event.ActorID,
+		TargetID:  event.TargetID,
+		IpAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		Details:   encoded,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	event.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *auditEventRepository) Search(ctx context.Context, filter models.AuditEventFilter, after models.AuditEventCursor, limit int) ([]*models.AuditEvent, error) {
+	q := getQueries(ctx, r.pool)
+
+	params := sqlc.SearchAuditEventsParams{
+		BeforeID: after.ID,
+		Limit:    int32(limit),
+	}
+	if !after.CreatedAt.IsZero() {
+		params.BeforeCreatedAt = &after.CreatedAt
+	}
+	if filter.ActorID != uuid.Nil {
+		params.ActorID = &filter.ActorID
+	}
+	if filter.TargetID != uuid.Nil {
+		params.TargetID = &filter.TargetID
+	}
+	for _, action := range filter.Actions {
+		params.Actions = append(params.Actions, string(action))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		params.CreatedFrom = &filter.CreatedFrom
+	}
+	if !filter.CreatedBefore.IsZero() {
+		params.CreatedBefore = &filter.CreatedBefore
+	}
+
+	rows, err := q.SearchAuditEvents(ctx, params)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	events := make([]*models.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		event, err := mapToDomainAuditEvent(row)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (r *auditEventRepository) ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	cleared, err := q.ClearAuditEventOrigins(ctx, sqlc.ClearAuditEventOriginsParams{
+		Before:    before,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return cleared, nil
+}
+
+func (r *auditEventRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	deleted, err := q.DeleteAuditEventsBefore(ctx, sqlc.DeleteAuditEventsBeforeParams{
+		Before:    before,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return deleted, nil
+}
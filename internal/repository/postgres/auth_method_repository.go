@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type authMethodRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuthMethodRepository(pool *pgxpool.Pool) repositories.AuthMethodRepository {
+	return &authMethodRepository{
+		pool: pool,
+	}
+}
+
+func (r *authMethodRepository) Create(ctx context.Context, method *models.AuthMethod) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAuthMethod(ctx, sqlc.CreateAuthMethodParams{
+		ID:           method.ID,
+		AccountID:    method.AccountID,
+		ProviderCode: string(method.ProviderCode),
+		ProviderID:   method.ProviderID,
+		IsVerified:   method.IsVerified,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*method = *mapToDomainAuthMethod(row)
+	return nil
+}
+
+func (r *authMethodRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AuthMethod, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAuthMethodByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAuthMethod(row), nil
+}
+
+func (r *authMethodRepository) GetByProvider(ctx context.Context, provider domain.Provider, providerID string) (*models.AuthMethod, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAuthMethodByProvider(ctx, sqlc.GetAuthMethodByProviderParams{
+		ProviderCode: string(provider),
+		ProviderID:   providerID,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAuthMethod(row), nil
+}
+
+func (r *authMethodRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AuthMethod, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAuthMethodsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	methods := make([]*models.AuthMethod, 0, len(rows))
+	for _, row := range rows {
+		methods = append(methods, mapToDomainAuthMethod(row))
+	}
+	return methods, nil
+}
+
+func (r *authMethodRepository) ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]*models.AuthMethod, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAuthMethodsByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	methods := make([]*models.AuthMethod, 0, len(rows))
+	for _, row := range rows {
+		methods = append(methods, mapToDomainAuthMethod(row))
+	}
+	return methods, nil
+}
+
+func (r *authMethodRepository) ListByProvider(ctx context.Context, provider domain.Provider, offset, limit int) ([]*models.AuthMethod, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAuthMethodsByProvider(ctx, sqlc.ListAuthMethodsByProviderParams{
+		ProviderCode: string(provider),
+		Limit:        int32(limit),
+		Offset:       int32(offset),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	methods := make([]*models.AuthMethod, 0, len(rows))
+	for _, row := range rows {
+		methods = append(methods, mapToDomainAuthMethod(row))
+	}
+	return methods, nil
+}
+
+func (r *authMethodRepository) CountByProvider(ctx context.Context, provider domain.Provider) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	count, err := q.CountAuthMethodsByProvider(ctx, string(provider))
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return int(count), nil
+}
+
+func (r *authMethodRepository) UpdateProviderID(ctx context.Context, id uuid.UUID, providerID string, verified bool) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateAuthMethodProviderID(ctx, sqlc.UpdateAuthMethodProviderIDParams{
+		ID:         id,
+		ProviderID: providerID,
+		IsVerified: verified,
+	}))
+}
+
+func (r *authMethodRepository) UpdateVerified(ctx context.Context, id uuid.UUID, verified bool) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateAuthMethodVerified(ctx, sqlc.UpdateAuthMethodVerifiedParams{
+		ID:         id,
+		IsVerified: verified,
+	}))
+}
+
+func (r *authMethodRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateAuthMethodLastLogin(ctx, sqlc.UpdateAuthMethodLastLoginParams{
+		ID:          id,
+		LastLoginAt: &at,
+	}))
+}
+
+func (r *authMethodRepository) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	attempts, err := q.IncrementAuthMethodFailedAttempts(ctx, id)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(attempts), nil
+}
+
+func (r *authMethodRepository) Lock(ctx context.Context, id uuid.UUID, until time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.LockAuthMethod(ctx, sqlc.LockAuthMethodParams{
+		ID:          id,
+		LockedUntil: &until,
+	}))
+}
+
+func (r *authMethodRepository) ResetFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.ResetAuthMethodFailedAttempts(ctx, id))
+}
+
+func (r *authMethodRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteAuthMethod(ctx, id))
+}
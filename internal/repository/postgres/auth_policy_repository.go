@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type authPolicyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuthPolicyRepository(pool *pgxpool.Pool) repositories.AuthPolicyRepository {
+	return &authPolicyRepository{
+		pool: pool,
+	}
+}
+
+func (r *authPolicyRepository) Save(ctx context.Context, policy *models.AuthPolicy) error {
+	q := getQueries(ctx, r.pool)
+
+	classes := make([]string, len(policy.PasswordRequiredClasses))
+	for i, class := range policy.PasswordRequiredClasses {
+		classes[i] = string(class)
+	}
+	providers := make([]string, len(policy.AllowedProviders))
+	for i, provider := range policy.AllowedProviders {
+		providers[i] = string(provider)
+	}
+
+	row, err := q.UpsertOrganizationPolicy(ctx, sqlc.UpsertOrganizationPolicyParams{
+		OrganizationID:          policy.OrganizationID,
+		PasswordMinLength:       int32(policy.PasswordMinLength),
+		PasswordRequiredClasses: strings.Join(classes, " "),
+		RequireMfa:              policy.RequireMFA,
+		SessionMaxAgeSeconds:    int32(policy.SessionMaxAge.Seconds()),
+		AllowedProviders:        strings.Join(providers, " "),
+	})
+	// The organization was deleted concurrently.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*policy = *mapToDomainAuthPolicy(row)
+	return nil
+}
+
+func (r *authPolicyRepository) Get(ctx context.Context, organizationID uuid.UUID) (*models.AuthPolicy, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOrganizationPolicy(ctx, organizationID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainAuthPolicy(row), nil
+}
+
+func (r *authPolicyRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.AuthPolicy, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListOrganizationPoliciesByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	policies := make([]*models.AuthPolicy, len(rows))
+	for i, row := range rows {
+		policies[i] = mapToDomainAuthPolicy(row)
+	}
+	return policies, nil
+}
+
+func (r *authPolicyRepository) Delete(ctx context.Context, organizationID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteOrganizationPolicy(ctx, organizationID))
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type authProviderRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuthProviderRepository(pool *pgxpool.Pool) repositories.AuthProviderRepository {
+	return &authProviderRepository{
+		pool: pool,
+	}
+}
+
+func (r *authProviderRepository) Ensure(ctx context.Context, code domain.Provider, description string) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.EnsureAuthProvider(ctx, sqlc.EnsureAuthProviderParams{
+		Code:        string(code),
+		Description: &description,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type authorizationCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuthorizationCodeRepository(pool *pgxpool.Pool) repositories.AuthorizationCodeRepository {
+	return &authorizationCodeRepository{
+		pool: pool,
+	}
+}
+
+func (r *authorizationCodeRepository) Create(ctx context.Context, code *models.AuthorizationCode) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAuthorizationCode(ctx, sqlc.CreateAuthorizationCodeParams{
+		ID:                  code.ID,
+		CodeHash:            code.CodeHash,
+		ClientID:            code.ClientID,
+		AccountID:           code.AccountID,
+		RedirectUri:         code.RedirectURI,
+		Scope:               code.Scope,
+		Nonce:               code.Nonce,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		AuthTime:            code.AuthTime,
+		ExpiresAt:           code.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*code = *mapToDomainAuthorizationCode(row)
+	return nil
+}
+
+func (r *authorizationCodeRepository) GetByCodeHash(ctx context.Context, codeHash string) (*models.AuthorizationCode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAuthorizationCodeByCodeHash(ctx, codeHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainAuthorizationCode(row), nil
+}
+
+func (r *authorizationCodeRepository) Consume(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.ConsumeAuthorizationCode(ctx, sqlc.ConsumeAuthorizationCodeParams{
+		ID:         id,
+		ConsumedAt: &at,
+	}))
+}
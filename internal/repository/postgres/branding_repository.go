@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type brandingRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBrandingRepository(pool *pgxpool.Pool) repositories.BrandingRepository {
+	return &brandingRepository{
+		pool: pool,
+	}
+}
+
+func (r *brandingRepository) Save(ctx context.Context, branding *models.Branding) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.UpsertOrganizationBranding(ctx, sqlc.UpsertOrganizationBrandingParams{
+		OrganizationID:  branding.OrganizationID,
+		SenderName:      optionalText(branding.SenderName),
+		SenderAddress:   optionalText(branding.SenderAddress),
+		LogoUrl:         optionalText(branding.LogoURL),
+		PrimaryColor:    optionalText(branding.PrimaryColor),
+		BackgroundColor: optionalText(branding.BackgroundColor),
+		FooterText:      optionalText(branding.FooterText),
+	})
+	// The organization was deleted concurrently.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*branding = *mapToDomainBranding(row)
+	return nil
+}
+
+func (r *brandingRepository) Get(ctx context.Context, organizationID uuid.UUID) (*models.Branding, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOrganizationBranding(ctx, organizationID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainBranding(row), nil
+}
+
+func (r *brandingRepository) GetBySlug(ctx context.Context, slug string) (*models.Branding, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOrganizationBrandingBySlug(ctx, slug)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainBranding(row), nil
+}
+
+func (r *brandingRepository) Delete(ctx context.Context, organizationID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteOrganizationBranding(ctx, organizationID))
+}
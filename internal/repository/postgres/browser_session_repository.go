@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type browserSessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBrowserSessionRepository(pool *pgxpool.Pool) repositories.BrowserSessionRepository {
+	return &browserSessionRepository{
+		pool: pool,
+	}
+}
+
+func (r *browserSessionRepository) Create(ctx context.Context, session *models.BrowserSession) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateBrowserSession(ctx, sqlc.CreateBrowserSessionParams{
+		ID:        session.ID,
+		AccountID: session.AccountID,
+		SessionID: session.SessionID,
+		TokenHash: session.TokenHash,
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*session = *mapToDomainBrowserSession(row)
+	return nil
+}
+
+func (r *browserSessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.BrowserSession, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetBrowserSessionByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainBrowserSession(row), nil
+}
+
+func (r *browserSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteBrowserSession(ctx, id))
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type consentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewConsentRepository(pool *pgxpool.Pool) repositories.ConsentRepository {
+	return &consentRepository{
+		pool: pool,
+	}
+}
+
+func (r *consentRepository) Create(ctx context.Context, consent *models.Consent) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateConsent(ctx, sqlc.CreateConsentParams{
+		ID:         consent.ID,
+		AccountID:  consent.AccountID,
+		DocumentID: consent.DocumentID,
+		IpAddress:  consent.IPAddress,
+		UserAgent:  consent.UserAgent,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	consent.AcceptedAt = row.AcceptedAt
+	return nil
+}
+
+func (r *consentRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Consent, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListConsentsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	consents := make([]*models.Consent, 0, len(rows))
+	for _, row := range rows {
+		consents = append(consents, mapToDomainConsent(row))
+	}
+	return consents, nil
+}
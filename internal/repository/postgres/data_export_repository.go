@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type dataExportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDataExportRepository(pool *pgxpool.Pool) repositories.DataExportRepository {
+	return &dataExportRepository{
+		pool: pool,
+	}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, export *models.DataExport) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateDataExport(ctx, sqlc.CreateDataExportParams{
+		ID:        export.ID,
+		AccountID: export.AccountID,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*export = *mapToDomainDataExport(row)
+	return nil
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DataExport, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetDataExportByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainDataExport(row), nil
+}
+
+func (r *dataExportRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.DataExport, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListDataExportsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	exports := make([]*models.DataExport, 0, len(rows))
+	for _, row := range rows {
+		exports = append(exports, &models.DataExport{
+			ID:          row.ID,
+			AccountID:   row.AccountID,
+			Status:      domain.DataExportStatus(row.Status),
+			CreatedAt:   row.CreatedAt,
+			CompletedAt: row.CompletedAt,
+			ExpiresAt:   row.ExpiresAt,
+		})
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) ListPending(ctx context.Context, limit int) ([]*models.DataExport, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListPendingDataExports(ctx, int32(limit))
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	exports := make([]*models.DataExport, 0, len(rows))
+	for _, row := range rows {
+		exports = append(exports, mapToDomainDataExport(row))
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) Complete(ctx context.Context, id uuid.UUID, archive []byte, at, expiresAt time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.CompleteDataExport(ctx, sqlc.CompleteDataExportParams{
+		ID:          id,
+		Archive:     archive,
+		CompletedAt: &at,
+		ExpiresAt:   &expiresAt,
+	}))
+}
+
+func (r *dataExportRepository) DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	deleted, err := q.DeleteExpiredDataExports(ctx, sqlc.DeleteExpiredDataExportsParams{
+		Now:       now,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return deleted, nil
+}
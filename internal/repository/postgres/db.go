@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// getQueries returns the transaction-bound queries stored in ctx, falling back
+// to the pool when the caller is not inside a transaction.
+func getQueries(ctx context.Context, pool *pgxpool.Pool) *sqlc.Queries {
+	if q, ok := ctx.Value(txKey{}).(*sqlc.Queries); ok {
+		return q
+	}
+	return sqlc.New(pool)
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type deviceCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeviceCodeRepository(pool *pgxpool.Pool) repositories.DeviceCodeRepository {
+	return &deviceCodeRepository{
+		pool: pool,
+	}
+}
+
+func (r *deviceCodeRepository) Create(ctx context.Context, code *models.DeviceCode) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateDeviceCode(ctx, sqlc.CreateDeviceCodeParams{
+		ID:              code.ID,
+		DeviceCodeHash:  code.DeviceCodeHash,
+		UserCodeHash:    code.UserCodeHash,
+		ClientID:        code.ClientID,
+		Scope:           code.Scope,
+		IntervalSeconds: int32(code.Interval / time.Second),
+		ExpiresAt:       code.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*code = *mapToDomainDeviceCode(row)
+	return nil
+}
+
+func (r *deviceCodeRepository) GetByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*models.DeviceCode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetDeviceCodeByDeviceCodeHash(ctx, deviceCodeHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainDeviceCode(row), nil
+}
+
+func (r *deviceCodeRepository) GetPendingByUserCodeHash(ctx context.Context, userCodeHash string, now time.Time) (*models.DeviceCode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetPendingDeviceCodeByUserCodeHash(ctx, sqlc.GetPendingDeviceCodeByUserCodeHashParams{
+		UserCodeHash: userCodeHash,
+		ExpiresAt:    now,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainDeviceCode(row), nil
+}
+
+func (r *deviceCodeRepository) Decide(ctx context.Context, id uuid.UUID, status domain.DeviceCodeStatus, accountID uuid.UUID, authTime time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DecideDeviceCode(ctx, sqlc.DecideDeviceCodeParams{
+		ID:        id,
+		Status:    string(status),
+		AccountID: &accountID,
+		AuthTime:  &authTime,
+	}))
+}
+
+func (r *deviceCodeRepository) RecordPoll(ctx context.Context, id uuid.UUID, at time.Time, interval time.Duration) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RecordDeviceCodePoll(ctx, sqlc.RecordDeviceCodePollParams{
+		ID:              id,
+		LastPolledAt:    &at,
+		IntervalSeconds: int32(interval / time.Second),
+	}))
+}
+
+func (r *deviceCodeRepository) Consume(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.ConsumeDeviceCode(ctx, sqlc.ConsumeDeviceCodeParams{
+		ID:         id,
+		ConsumedAt: &at,
+	}))
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+func mapPostgresError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == uniqueViolation {
+			return domain.ErrConflict
+		}
+	}
+
+	return err
+}
+
+// expectAffected turns an update that matched no rows into domain.ErrNotFound.
+func expectAffected(rows int64, err error) error {
+	if err != nil {
+		return mapPostgresError(err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type impersonationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImpersonationRepository(pool *pgxpool.Pool) repositories.ImpersonationRepository {
+	return &impersonationRepository{
+		pool: pool,
+	}
+}
+
+func (r *impersonationRepository) Create(ctx context.Context, impersonation *models.Impersonation) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateImpersonation(ctx, sqlc.CreateImpersonationParams{
+		ID:        impersonation.ID,
+		AccountID: impersonation.AccountID,
+		AdminID:   impersonation.AdminID,
+		Reason:    impersonation.Reason,
+		TokenID:   impersonation.TokenID,
+		IpAddress: impersonation.IPAddress,
+		UserAgent: impersonation.UserAgent,
+		ExpiresAt: impersonation.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	impersonation.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *impersonationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Impersonation, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetImpersonation(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainImpersonation(row), nil
+}
+
+func (r *impersonationRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Impersonation, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListImpersonationsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	impersonations := make([]*models.Impersonation, 0, len(rows))
+	for _, row := range rows {
+		impersonations = append(impersonations, mapToDomainImpersonation(row))
+	}
+	return impersonations, nil
+}
+
+func (r *impersonationRepository) End(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.EndImpersonation(ctx, sqlc.EndImpersonationParams{
+		ID:      id,
+		EndedAt: &at,
+	}))
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type invitationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewInvitationRepository(pool *pgxpool.Pool) repositories.InvitationRepository {
+	return &invitationRepository{
+		pool: pool,
+	}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *models.Invitation) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateOrganizationInvitation(ctx, sqlc.CreateOrganizationInvitationParams{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		RoleCode:       string(invitation.RoleCode),
+		TokenHash:      invitation.TokenHash,
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+	})
+	// The organization or the role was deleted concurrently.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*invitation = *mapToDomainInvitation(row)
+	return nil
+}
+
+func (r *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOrganizationInvitationByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainInvitation(row), nil
+}
+
+func (r *invitationRepository) ListPending(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]*models.Invitation, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListPendingOrganizationInvitations(ctx, sqlc.ListPendingOrganizationInvitationsParams{
+		OrganizationID: organizationID,
+		ExpiresAt:      now,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	invitations := make([]*models.Invitation, 0, len(rows))
+	for _, row := range rows {
+		invitations = append(invitations, mapToDomainInvitation(row))
+	}
+	return invitations, nil
+}
+
+func (r *invitationRepository) Accept(ctx context.Context, id, accountID uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.AcceptOrganizationInvitation(ctx, sqlc.AcceptOrganizationInvitationParams{
+		ID:         id,
+		AcceptedAt: &at,
+		AcceptedBy: &accountID,
+	}))
+}
+
+func (r *invitationRepository) Revoke(ctx context.Context, id, organizationID uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RevokeOrganizationInvitation(ctx, sqlc.RevokeOrganizationInvitationParams{
+		ID:             id,
+		OrganizationID: organizationID,
+		RevokedAt:      &at,
+	}))
+}
+
+func (r *invitationRepository) RevokePendingByEmail(ctx context.Context, organizationID uuid.UUID, email string, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.RevokePendingOrganizationInvitationsByEmail(ctx, sqlc.RevokePendingOrganizationInvitationsByEmailParams{
+		OrganizationID: organizationID,
+		Email:          email,
+		RevokedAt:      &at,
+	})
+	return mapPostgresError(err)
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// leaderLockKey is the advisory lock the leading instance holds.
+const leaderLockKey int64 = 0x52414e434f4a4f42
+
+// advisoryLeaderElection leads while it holds a session advisory lock on a
+// connection it keeps out of the pool. PostgreSQL releases the lock when that
+// connection ends, so the lead passes on when the leader dies.
+type advisoryLeaderElection struct {
+	pool *pgxpool.Pool
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+func NewAdvisoryLeaderElection(pool *pgxpool.Pool) ports.LeaderElection {
+	return &advisoryLeaderElection{
+		pool: pool,
+	}
+}
+
+// Lead pings the connection holding the lock, and tries to take the lock
+// again on a new one when the ping fails.
+func (e *advisoryLeaderElection) Lead(ctx context.Context) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		if err := e.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		// The connection may be dead, taking the lock with it; close it
+		// rather than return it to the pool holding the lock.
+		e.conn.Hijack().Close(context.WithoutCancel(ctx))
+		e.conn = nil
+	}
+
+	conn, err := e.pool.Acquire(ctx)
+	if err != nil {
+		return false, mapPostgresError(err)
+	}
+	acquired, err := sqlc.New(conn).TryAcquireLeaderLock(ctx, leaderLockKey)
+	if err != nil || !acquired {
+		conn.Release()
+		return false, mapPostgresError(err)
+	}
+	e.conn = conn
+	return true, nil
+}
+
+func (e *advisoryLeaderElection) Resign(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+	_, err := sqlc.New(e.conn).ReleaseLeaderLock(ctx, leaderLockKey)
+	if err != nil {
+		e.conn.Hijack().Close(context.WithoutCancel(ctx))
+	} else {
+		e.conn.Release()
+	}
+	e.conn = nil
+	return mapPostgresError(err)
+}
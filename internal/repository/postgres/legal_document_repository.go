@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type legalDocumentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLegalDocumentRepository(pool *pgxpool.Pool) repositories.LegalDocumentRepository {
+	return &legalDocumentRepository{
+		pool: pool,
+	}
+}
+
+func (r *legalDocumentRepository) Create(ctx context.Context, document *models.LegalDocument) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateLegalDocument(ctx, sqlc.CreateLegalDocumentParams{
+		ID:      document.ID,
+		Kind:    string(document.Kind),
+		Version: document.Version,
+		Url:     document.URL,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*document = *mapToDomainLegalDocument(row)
+	return nil
+}
+
+func (r *legalDocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.LegalDocument, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetLegalDocumentByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainLegalDocument(row), nil
+}
+
+func (r *legalDocumentRepository) List(ctx context.Context) ([]*models.LegalDocument, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListLegalDocuments(ctx)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainLegalDocuments(rows), nil
+}
+
+func (r *legalDocumentRepository) ListCurrent(ctx context.Context) ([]*models.LegalDocument, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListCurrentLegalDocuments(ctx)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainLegalDocuments(rows), nil
+}
+
+func (r *legalDocumentRepository) ListPendingByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.LegalDocument, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListPendingLegalDocuments(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	documents := make([]*models.LegalDocument, 0, len(rows))
+	for _, row := range rows {
+		documents = append(documents, mapToDomainLegalDocument(sqlc.LegalDocument(row)))
+	}
+	return documents, nil
+}
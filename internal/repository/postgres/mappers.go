@@ -0,0 +1,579 @@
+package postgres
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+)
+
+func mapToDomainAccount(row sqlc.Account) *models.Account {
+	return &models.Account{
+		ID:         row.ID,
+		RoleCode:   domain.Role(row.RoleCode),
+		StatusCode: domain.Status(row.StatusCode),
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func mapToDomainAuthMethod(row sqlc.AuthMethod) *models.AuthMethod {
+	return &models.AuthMethod{
+		ID:             row.ID,
+		AccountID:      row.AccountID,
+		ProviderCode:   domain.Provider(row.ProviderCode),
+		ProviderID:     row.ProviderID,
+		IsVerified:     row.IsVerified,
+		LastLoginAt:    row.LastLoginAt,
+		FailedAttempts: int(row.FailedAttempts),
+		LockedUntil:    row.LockedUntil,
+	}
+}
+
+func mapToDomainVerificationCode(row sqlc.VerificationCode) *models.VerificationCode {
+	return &models.VerificationCode{
+		ID:           row.ID,
+		AuthMethodID: row.AuthMethodID,
+		Purpose:      domain.CodePurpose(row.Purpose),
+		CodeHash:     row.CodeHash,
+		Attempts:     int(row.Attempts),
+		ExpiresAt:    row.ExpiresAt,
+		ConsumedAt:   row.ConsumedAt,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+func mapToDomainRefreshToken(row sqlc.RefreshToken) *models.RefreshToken {
+	token := &models.RefreshToken{
+		ID:               row.ID,
+		AccountID:        row.AccountID,
+		SessionID:        row.SessionID,
+		TokenHash:        row.TokenHash,
+		IPAddress:        row.IpAddress,
+		UserAgent:        row.UserAgent,
+		RememberMe:       row.RememberMe,
+		SessionStartedAt: row.SessionStartedAt,
+		KeyThumbprint:    row.DpopJkt,
+		OrganizationID:   row.OrganizationID,
+		RevokedAt:        row.RevokedAt,
+		ExpiresAt:        row.ExpiresAt,
+		CreatedAt:        row.CreatedAt,
+	}
+	if row.Scope != nil {
+		token.Scope = *row.Scope
+	}
+	return token
+}
+
+func mapToDomainSigningKey(row sqlc.SigningKey) *models.SigningKey {
+	return &models.SigningKey{
+		ID:             row.ID,
+		Algorithm:      row.Algorithm,
+		PrivateKey:     row.PrivateKey,
+		OrganizationID: row.OrganizationID,
+		ActivatesAt:    row.ActivatesAt,
+		RetiredAt:      row.RetiredAt,
+		ExpiresAt:      row.ExpiresAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
+
+func mapToDomainPasswordCredential(row sqlc.PasswordCredential) *models.PasswordCredential {
+	return &models.PasswordCredential{
+		AuthMethodID: row.AuthMethodID,
+		PasswordHash: row.PasswordHash,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
+
+func mapToDomainPasswordHistoryEntry(row sqlc.PasswordHistory) *models.PasswordHistoryEntry {
+	return &models.PasswordHistoryEntry{
+		ID:           row.ID,
+		AuthMethodID: row.AuthMethodID,
+		PasswordHash: row.PasswordHash,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+func mapToDomainMFAFactor(row sqlc.MfaFactor) *models.MFAFactor {
+	return &models.MFAFactor{
+		ID:             row.ID,
+		AccountID:      row.AccountID,
+		FactorType:     domain.MFAFactorType(row.FactorType),
+		Secret:         row.Secret,
+		LastUsedStep:   row.LastUsedStep,
+		ConfirmedAt:    row.ConfirmedAt,
+		CreatedAt:      row.CreatedAt,
+		FailedAttempts: int(row.FailedAttempts),
+		LockedUntil:    row.LockedUntil,
+	}
+}
+
+func mapToDomainMFAChallenge(row sqlc.MfaChallenge) *models.MFAChallenge {
+	challenge := &models.MFAChallenge{
+		ID:         row.ID,
+		AccountID:  row.AccountID,
+		TokenHash:  row.TokenHash,
+		Attempts:   int(row.Attempts),
+		RememberMe: row.RememberMe,
+		ExpiresAt:  row.ExpiresAt,
+		ConsumedAt: row.ConsumedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.Scope != nil {
+		challenge.Scope = *row.Scope
+	}
+	return challenge
+}
+
+func mapToDomainPasskeyCredential(row sqlc.PasskeyCredential) *models.PasskeyCredential {
+	var transports []string
+	if row.Transports != nil && *row.Transports != "" {
+		transports = strings.Split(*row.Transports, ",")
+	}
+
+	return &models.PasskeyCredential{
+		ID:              row.ID,
+		AccountID:       row.AccountID,
+		CredentialID:    row.CredentialID,
+		PublicKey:       row.PublicKey,
+		AttestationType: row.AttestationType,
+		AAGUID:          row.Aaguid,
+		SignCount:       uint32(row.SignCount),
+		Transports:      transports,
+		UserVerified:    row.UserVerified,
+		BackupEligible:  row.BackupEligible,
+		BackupState:     row.BackupState,
+		Name:            row.Name,
+		LastUsedAt:      row.LastUsedAt,
+		CreatedAt:       row.CreatedAt,
+	}
+}
+
+func mapToDomainPasskeyCeremony(row sqlc.PasskeyCeremony) *models.PasskeyCeremony {
+	return &models.PasskeyCeremony{
+		ID:        row.ID,
+		AccountID: row.AccountID,
+		Purpose:   domain.PasskeyPurpose(row.Purpose),
+		State:     row.State,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func mapToDomainMFACode(row sqlc.MfaCode) *models.MFACode {
+	return &models.MFACode{
+		ID:         row.ID,
+		FactorID:   row.FactorID,
+		CodeHash:   row.CodeHash,
+		Attempts:   int(row.Attempts),
+		ExpiresAt:  row.ExpiresAt,
+		ConsumedAt: row.ConsumedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func mapToDomainTrustedDevice(row sqlc.TrustedDevice) *models.TrustedDevice {
+	return &models.TrustedDevice{
+		ID:         row.ID,
+		AccountID:  row.AccountID,
+		TokenHash:  row.TokenHash,
+		IPAddress:  row.IpAddress,
+		UserAgent:  row.UserAgent,
+		LastUsedAt: row.LastUsedAt,
+		ExpiresAt:  row.ExpiresAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func mapToDomainOAuthClient(row sqlc.OauthClient) *models.OAuthClient {
+	return &models.OAuthClient{
+		ID:                row.ID,
+		ClientID:          row.ClientID,
+		Name:              row.Name,
+		SecretHash:        row.SecretHash,
+		Public:            row.Public,
+		RedirectURIs:      strings.Fields(row.RedirectUris),
+		GrantTypes:        strings.Fields(row.GrantTypes),
+		Scopes:            strings.Fields(row.Scopes),
+		ExchangeAudiences: strings.Fields(row.ExchangeAudiences),
+		Impersonation:     row.Impersonation,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+	}
+}
+
+func mapToDomainAuthorizationCode(row sqlc.AuthorizationCode) *models.AuthorizationCode {
+	return &models.AuthorizationCode{
+		ID:                  row.ID,
+		CodeHash:            row.CodeHash,
+		ClientID:            row.ClientID,
+		AccountID:           row.AccountID,
+		RedirectURI:         row.RedirectUri,
+		Scope:               row.Scope,
+		Nonce:               row.Nonce,
+		CodeChallenge:       row.CodeChallenge,
+		CodeChallengeMethod: row.CodeChallengeMethod,
+		AuthTime:            row.AuthTime,
+		ExpiresAt:           row.ExpiresAt,
+		ConsumedAt:          row.ConsumedAt,
+		CreatedAt:           row.CreatedAt,
+	}
+}
+
+func mapToDomainDeviceCode(row sqlc.DeviceCode) *models.DeviceCode {
+	return &models.DeviceCode{
+		ID:             row.ID,
+		DeviceCodeHash: row.DeviceCodeHash,
+		UserCodeHash:   row.UserCodeHash,
+		ClientID:       row.ClientID,
+		Scope:          row.Scope,
+		Status:         domain.DeviceCodeStatus(row.Status),
+		AccountID:      row.AccountID,
+		AuthTime:       row.AuthTime,
+		Interval:       time.Duration(row.IntervalSeconds) * time.Second,
+		LastPolledAt:   row.LastPolledAt,
+		ExpiresAt:      row.ExpiresAt,
+		ConsumedAt:     row.ConsumedAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
+
+func mapToDomainAPIKey(row sqlc.ApiKey) *models.APIKey {
+	return &models.APIKey{
+		ID:         row.ID,
+		AccountID:  row.AccountID,
+		Name:       row.Name,
+		KeyHash:    row.KeyHash,
+		KeyPrefix:  row.KeyPrefix,
+		Scopes:     strings.Fields(row.Scopes),
+		ExpiresAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt,
+		RevokedAt:  row.RevokedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func mapToDomainAccessToken(row sqlc.AccessToken) *models.AccessToken {
+	return &models.AccessToken{
+		ID:        row.ID,
+		TokenHash: row.TokenHash,
+		Claims:    row.Claims,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func mapToDomainBrowserSession(row sqlc.BrowserSession) *models.BrowserSession {
+	return &models.BrowserSession{
+		ID:        row.ID,
+		AccountID: row.AccountID,
+		SessionID: row.SessionID,
+		TokenHash: row.TokenHash,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func mapToDomainAccountBan(row sqlc.AccountBan) *models.AccountBan {
+	ban := &models.AccountBan{
+		ID:             row.ID,
+		AccountID:      row.AccountID,
+		PreviousStatus: domain.Status(row.PreviousStatusCode),
+		Reason:         row.Reason,
+		BannedBy:       row.BannedBy,
+		ExpiresAt:      row.ExpiresAt,
+		LiftedAt:       row.LiftedAt,
+		LiftedBy:       row.LiftedBy,
+		CreatedAt:      row.CreatedAt,
+	}
+	if row.LiftReason != nil {
+		ban.LiftReason = *row.LiftReason
+	}
+	return ban
+}
+
+func mapToDomainAccountDeletion(row sqlc.AccountDeletion) *models.AccountDeletion {
+	return &models.AccountDeletion{
+		AccountID: row.AccountID,
+		PurgeAt:   row.PurgeAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func mapToDomainDataExport(row sqlc.DataExport) *models.DataExport {
+	return &models.DataExport{
+		ID:          row.ID,
+		AccountID:   row.AccountID,
+		Status:      domain.DataExportStatus(row.Status),
+		Archive:     row.Archive,
+		CreatedAt:   row.CreatedAt,
+		CompletedAt: row.CompletedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}
+}
+
+func mapToDomainRoleChange(row sqlc.AccountRoleChange) *models.RoleChange {
+	change := &models.RoleChange{
+		ID:           row.ID,
+		AccountID:    row.AccountID,
+		PreviousRole: domain.Role(row.PreviousRoleCode),
+		Role:         domain.Role(row.RoleCode),
+		ChangedBy:    row.ChangedBy,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.Reason != nil {
+		change.Reason = *row.Reason
+	}
+	return change
+}
+
+func mapToDomainRole(row sqlc.AccountRole, permissions []string) *models.Role {
+	role := &models.Role{
+		Code:        domain.Role(row.Code),
+		System:      row.IsSystem,
+		Permissions: permissions,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.Description != nil {
+		role.Description = *row.Description
+	}
+	return role
+}
+
+func mapToDomainOrganization(row sqlc.Organization) *models.Organization {
+	return &models.Organization{
+		ID:        row.ID,
+		Slug:      row.Slug,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func mapToDomainMembership(row sqlc.OrganizationMembership) *models.Membership {
+	return &models.Membership{
+		OrganizationID: row.OrganizationID,
+		AccountID:      row.AccountID,
+		RoleCode:       domain.Role(row.RoleCode),
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
+
+func mapToDomainInvitation(row sqlc.OrganizationInvitation) *models.Invitation {
+	return &models.Invitation{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Email:          row.Email,
+		RoleCode:       domain.Role(row.RoleCode),
+		TokenHash:      row.TokenHash,
+		InvitedBy:      row.InvitedBy,
+		ExpiresAt:      row.ExpiresAt,
+		AcceptedAt:     row.AcceptedAt,
+		AcceptedBy:     row.AcceptedBy,
+		RevokedAt:      row.RevokedAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
+
+func mapToDomainBranding(row sqlc.OrganizationBranding) *models.Branding {
+	return &models.Branding{
+		OrganizationID:  row.OrganizationID,
+		SenderName:      textValue(row.SenderName),
+		SenderAddress:   textValue(row.SenderAddress),
+		LogoURL:         textValue(row.LogoUrl),
+		PrimaryColor:    textValue(row.PrimaryColor),
+		BackgroundColor: textValue(row.BackgroundColor),
+		FooterText:      textValue(row.FooterText),
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+}
+
+func mapToDomainAuthPolicy(row sqlc.OrganizationPolicy) *models.AuthPolicy {
+	policy := &models.AuthPolicy{
+		OrganizationID:    row.OrganizationID,
+		PasswordMinLength: int(row.PasswordMinLength),
+		RequireMFA:        row.RequireMfa,
+		SessionMaxAge:     time.Duration(row.SessionMaxAgeSeconds) * time.Second,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+	}
+	for _, class := range strings.Fields(row.PasswordRequiredClasses) {
+		policy.PasswordRequiredClasses = append(policy.PasswordRequiredClasses, domain.CharacterClass(class))
+	}
+	for _, provider := range strings.Fields(row.AllowedProviders) {
+		policy.AllowedProviders = append(policy.AllowedProviders, domain.Provider(provider))
+	}
+	return policy
+}
+
+func mapToDomainImpersonation(row sqlc.Impersonation) *models.Impersonation {
+	return &models.Impersonation{
+		ID:        row.ID,
+		AccountID: row.AccountID,
+		AdminID:   row.AdminID,
+		Reason:    row.Reason,
+		TokenID:   row.TokenID,
+		IPAddress: row.IpAddress,
+		UserAgent: row.UserAgent,
+		ExpiresAt: row.ExpiresAt,
+		EndedAt:   row.EndedAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+// mapToDomainAuditEvent fails only on details that are not a JSON object of
+// strings, which Append never writes.
+func mapToDomainAuditEvent(row sqlc.AuditEvent) (*models.AuditEvent, error) {
+	var details map[string]string
+	if err := json.Unmarshal(row.Details, &details); err != nil {
+		return nil, err
+	}
+	return &models.AuditEvent{
+		ID:        row.ID,
+		Action:    domain.AuditAction(row.Action),
+		ActorID:   row.ActorID,
+		TargetID:  row.TargetID,
+		IPAddress: row.IpAddress,
+		UserAgent: row.UserAgent,
+		Details:   details,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
+
+func mapToDomainWebhookEndpoint(row sqlc.WebhookEndpoint) *models.WebhookEndpoint {
+	events := make([]domain.WebhookEvent, 0, len(row.EventTypes))
+	for _, event := range row.EventTypes {
+		events = append(events, domain.WebhookEvent(event))
+	}
+	return &models.WebhookEndpoint{
+		ID:          row.ID,
+		URL:         row.Url,
+		Description: row.Description,
+		Events:      events,
+		Secret:      row.Secret,
+		IsActive:    row.IsActive,
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
+
+func mapToDomainWebhookEndpoints(rows []sqlc.WebhookEndpoint) []*models.WebhookEndpoint {
+	endpoints := make([]*models.WebhookEndpoint, 0, len(rows))
+	for _, row := range rows {
+		endpoints = append(endpoints, mapToDomainWebhookEndpoint(row))
+	}
+	return endpoints
+}
+
+func mapToDomainWebhookDelivery(row sqlc.WebhookDelivery) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:             row.ID,
+		EndpointID:     row.EndpointID,
+		EventID:        row.EventID,
+		Event:          domain.WebhookEvent(row.EventType),
+		Payload:        row.Payload,
+		Status:         domain.WebhookDeliveryStatus(row.Status),
+		Attempts:       int(row.Attempts),
+		NextAttemptAt:  row.NextAttemptAt,
+		LastStatusCode: intValue(row.LastStatusCode),
+		LastError:      row.LastError,
+		DeliveredAt:    row.DeliveredAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
+
+func mapToDomainWebhookDeliveries(rows []sqlc.WebhookDelivery) []*models.WebhookDelivery {
+	deliveries := make([]*models.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, mapToDomainWebhookDelivery(row))
+	}
+	return deliveries
+}
+
+func mapToDomainWebhookDeliveryAttempt(row sqlc.WebhookDeliveryAttempt) *models.WebhookDeliveryAttempt {
+	return &models.WebhookDeliveryAttempt{
+		ID:         row.ID,
+		DeliveryID: row.DeliveryID,
+		StatusCode: intValue(row.StatusCode),
+		Error:      row.Error,
+		Duration:   time.Duration(row.DurationMs) * time.Millisecond,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+// intValue widens a nullable integer column.
+func intValue(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	widened := int(*value)
+	return &widened
+}
+
+// textValue reads NULL text as empty.
+func textValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// optionalText stores empty text as NULL.
+func optionalText(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func mapToDomainOutboxEvent(row sqlc.OutboxEvent) *models.OutboxEvent {
+	return &models.OutboxEvent{
+		ID:            row.ID,
+		Name:          row.EventType,
+		Payload:       row.Payload,
+		Attempts:      int(row.Attempts),
+		NextAttemptAt: row.NextAttemptAt,
+		LastError:     row.LastError,
+		PublishedAt:   row.PublishedAt,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func mapToDomainLegalDocument(row sqlc.LegalDocument) *models.LegalDocument {
+	return &models.LegalDocument{
+		ID:          row.ID,
+		Kind:        domain.LegalDocumentKind(row.Kind),
+		Version:     row.Version,
+		URL:         row.Url,
+		PublishedAt: row.PublishedAt,
+	}
+}
+
+func mapToDomainLegalDocuments(rows []sqlc.LegalDocument) []*models.LegalDocument {
+	documents := make([]*models.LegalDocument, 0, len(rows))
+	for _, row := range rows {
+		documents = append(documents, mapToDomainLegalDocument(row))
+	}
+	return documents
+}
+
+func mapToDomainConsent(row sqlc.ListConsentsByAccountIDRow) *models.Consent {
+	return &models.Consent{
+		ID:         row.ID,
+		AccountID:  row.AccountID,
+		DocumentID: row.DocumentID,
+		Kind:       domain.LegalDocumentKind(row.Kind),
+		Version:    row.Version,
+		IPAddress:  row.IpAddress,
+		UserAgent:  row.UserAgent,
+		AcceptedAt: row.AcceptedAt,
+	}
+}
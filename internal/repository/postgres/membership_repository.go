@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type membershipRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMembershipRepository(pool *pgxpool.Pool) repositories.MembershipRepository {
+	return &membershipRepository{
+		pool: pool,
+	}
+}
+
+func (r *membershipRepository) Save(ctx context.Context, membership *models.Membership) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.UpsertOrganizationMembership(ctx, sqlc.UpsertOrganizationMembershipParams{
+		OrganizationID: membership.OrganizationID,
+		AccountID:      membership.AccountID,
+		RoleCode:       string(membership.RoleCode),
+	})
+	// The organization, the account or the role was deleted concurrently.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*membership = *mapToDomainMembership(row)
+	return nil
+}
+
+func (r *membershipRepository) Get(ctx context.Context, organizationID, accountID uuid.UUID) (*models.Membership, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOrganizationMembership(ctx, sqlc.GetOrganizationMembershipParams{
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainMembership(row), nil
+}
+
+func (r *membershipRepository) ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*models.Membership, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainMemberships(rows), nil
+}
+
+func (r *membershipRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Membership, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListOrganizationMembershipsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainMemberships(rows), nil
+}
+
+func (r *membershipRepository) Delete(ctx context.Context, organizationID, accountID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteOrganizationMembership(ctx, sqlc.DeleteOrganizationMembershipParams{
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+	}))
+}
+
+func mapToDomainMemberships(rows []sqlc.OrganizationMembership) []*models.Membership {
+	memberships := make([]*models.Membership, 0, len(rows))
+	for _, row := range rows {
+		memberships = append(memberships, mapToDomainMembership(row))
+	}
+	return memberships
+}
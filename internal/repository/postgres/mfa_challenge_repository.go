@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaChallengeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMFAChallengeRepository(pool *pgxpool.Pool) repositories.MFAChallengeRepository {
+	return &mfaChallengeRepository{
+		pool: pool,
+	}
+}
+
+func (r *mfaChallengeRepository) Create(ctx context.Context, challenge *models.MFAChallenge) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateMFAChallenge(ctx, sqlc.CreateMFAChallengeParams{
+		ID:         challenge.ID,
+		AccountID:  challenge.AccountID,
+		TokenHash:  challenge.TokenHash,
+		RememberMe: challenge.RememberMe,
+		Scope:      optionalText(challenge.Scope),
+		ExpiresAt:  challenge.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*challenge = *mapToDomainMFAChallenge(row)
+	return nil
+}
+
+func (r *mfaChallengeRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.MFAChallenge, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetMFAChallengeByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainMFAChallenge(row), nil
+}
+
+func (r *mfaChallengeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	attempts, err := q.IncrementMFAChallengeAttempts(ctx, id)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(attempts), nil
+}
+
+func (r *mfaChallengeRepository) MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.MarkMFAChallengeConsumed(ctx, sqlc.MarkMFAChallengeConsumedParams{
+		ID:         id,
+		ConsumedAt: &at,
+	}))
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMFACodeRepository(pool *pgxpool.Pool) repositories.MFACodeRepository {
+	return &mfaCodeRepository{
+		pool: pool,
+	}
+}
+
+func (r *mfaCodeRepository) Create(ctx context.Context, code *models.MFACode) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateMFACode(ctx, sqlc.CreateMFACodeParams{
+		ID:        code.ID,
+		FactorID:  code.FactorID,
+		CodeHash:  code.CodeHash,
+		ExpiresAt: code.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*code = *mapToDomainMFACode(row)
+	return nil
+}
+
+func (r *mfaCodeRepository) GetLatestByFactorID(ctx context.Context, factorID uuid.UUID) (*models.MFACode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetLatestMFACodeByFactorID(ctx, factorID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainMFACode(row), nil
+}
+
+func (r *mfaCodeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	attempts, err := q.IncrementMFACodeAttempts(ctx, id)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(attempts), nil
+}
+
+func (r *mfaCodeRepository) MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.MarkMFACodeConsumed(ctx, sqlc.MarkMFACodeConsumedParams{
+		ID:         id,
+		ConsumedAt: &at,
+	}))
+}
+
+func (r *mfaCodeRepository) InvalidateActive(ctx context.Context, factorID uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.InvalidateActiveMFACodes(ctx, sqlc.InvalidateActiveMFACodesParams{
+		FactorID:  factorID,
+		ExpiresAt: at,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
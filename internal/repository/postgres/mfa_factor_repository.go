@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaFactorRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMFAFactorRepository(pool *pgxpool.Pool) repositories.MFAFactorRepository {
+	return &mfaFactorRepository{
+		pool: pool,
+	}
+}
+
+func (r *mfaFactorRepository) Create(ctx context.Context, factor *models.MFAFactor) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateMFAFactor(ctx, sqlc.CreateMFAFactorParams{
+		ID:         factor.ID,
+		AccountID:  factor.AccountID,
+		FactorType: string(factor.FactorType),
+		Secret:     factor.Secret,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*factor = *mapToDomainMFAFactor(row)
+	return nil
+}
+
+func (r *mfaFactorRepository) GetByAccountAndType(ctx context.Context, accountID uuid.UUID, factorType domain.MFAFactorType) (*models.MFAFactor, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetMFAFactorByAccountAndType(ctx, sqlc.GetMFAFactorByAccountAndTypeParams{
+		AccountID:  accountID,
+		FactorType: string(factorType),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainMFAFactor(row), nil
+}
+
+func (r *mfaFactorRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.MFAFactor, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListMFAFactorsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	factors := make([]*models.MFAFactor, 0, len(rows))
+	for _, row := range rows {
+		factors = append(factors, mapToDomainMFAFactor(row))
+	}
+	return factors, nil
+}
+
+func (r *mfaFactorRepository) Confirm(ctx context.Context, id uuid.UUID, step int64, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.ConfirmMFAFactor(ctx, sqlc.ConfirmMFAFactorParams{
+		ID:           id,
+		ConfirmedAt:  &at,
+		LastUsedStep: step,
+	}))
+}
+
+func (r *mfaFactorRepository) UpdateLastUsedStep(ctx context.Context, id uuid.UUID, step int64) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateMFAFactorLastUsedStep(ctx, sqlc.UpdateMFAFactorLastUsedStepParams{
+		ID:           id,
+		LastUsedStep: step,
+	}))
+}
+
+func (r *mfaFactorRepository) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	attempts, err := q.IncrementMFAFactorFailedAttempts(ctx, id)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(attempts), nil
+}
+
+func (r *mfaFactorRepository) Lock(ctx context.Context, id uuid.UUID, until time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.LockMFAFactor(ctx, sqlc.LockMFAFactorParams{
+		ID:          id,
+		LockedUntil: &until,
+	}))
+}
+
+func (r *mfaFactorRepository) ResetFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.ResetMFAFactorFailedAttempts(ctx, id))
+}
+
+func (r *mfaFactorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteMFAFactor(ctx, id))
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaRecoveryCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMFARecoveryCodeRepository(pool *pgxpool.Pool) repositories.MFARecoveryCodeRepository {
+	return &mfaRecoveryCodeRepository{
+		pool: pool,
+	}
+}
+
+func (r *mfaRecoveryCodeRepository) Create(ctx context.Context, code *models.MFARecoveryCode) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.CreateMFARecoveryCode(ctx, sqlc.CreateMFARecoveryCodeParams{
+		ID:        code.ID,
+		AccountID: code.AccountID,
+		CodeHash:  code.CodeHash,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
+
+func (r *mfaRecoveryCodeRepository) Consume(ctx context.Context, accountID uuid.UUID, codeHash string, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.ConsumeMFARecoveryCode(ctx, sqlc.ConsumeMFARecoveryCodeParams{
+		AccountID:  accountID,
+		CodeHash:   codeHash,
+		ConsumedAt: &at,
+	}))
+}
+
+func (r *mfaRecoveryCodeRepository) CountUnused(ctx context.Context, accountID uuid.UUID) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	count, err := q.CountUnusedMFARecoveryCodes(ctx, accountID)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(count), nil
+}
+
+func (r *mfaRecoveryCodeRepository) DeleteByAccountID(ctx context.Context, accountID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	if err := q.DeleteMFARecoveryCodesByAccountID(ctx, accountID); err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Migrator applies the migrations embedded in the build with golang-migrate,
+// which records the version in schema_migrations and holds an advisory lock
+// while it runs, so instances starting together apply them once.
+type Migrator struct {
+	db      *sql.DB
+	migrate *migrate.Migrate
+}
+
+func NewMigrator(databaseURL string) (*Migrator, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	db, err := sql.Open("pgx", databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := pgx.WithInstance(db, &pgx.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect database: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "pgx", driver)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	m.Log = migrateLogger{}
+	return &Migrator{db: db, migrate: m}, nil
+}
+
+// Up applies every migration not applied yet.
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.migrate.Up())
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(steps int) error {
+	return ignoreNoChange(m.migrate.Steps(-steps))
+}
+
+// Force records version as applied and clean without running anything, once
+// a failed migration has been repaired by hand.
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+// Version returns the applied version, 0 when none is.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, databaseErr := m.migrate.Close()
+	return errors.Join(sourceErr, databaseErr, m.db.Close())
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// migrateLogger reports the migrations applied through slog.
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...any) {
+	slog.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
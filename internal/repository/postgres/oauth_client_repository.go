@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type oauthClientRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOAuthClientRepository(pool *pgxpool.Pool) repositories.OAuthClientRepository {
+	return &oauthClientRepository{
+		pool: pool,
+	}
+}
+
+func (r *oauthClientRepository) Upsert(ctx context.Context, client *models.OAuthClient) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.UpsertOAuthClient(ctx, sqlc.UpsertOAuthClientParams{
+		ID:                client.ID,
+		ClientID:          client.ClientID,
+		Name:              client.Name,
+		SecretHash:        client.SecretHash,
+		RedirectUris:      strings.Join(client.RedirectURIs, " "),
+		Public:            client.Public,
+		GrantTypes:        strings.Join(client.GrantTypes, " "),
+		Scopes:            strings.Join(client.Scopes, " "),
+		ExchangeAudiences: strings.Join(client.ExchangeAudiences, " "),
+		Impersonation:     client.Impersonation,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*client = *mapToDomainOAuthClient(row)
+	return nil
+}
+
+func (r *oauthClientRepository) GetByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOAuthClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainOAuthClient(row), nil
+}
+
+func (r *oauthClientRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.OAuthClient, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOAuthClientByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainOAuthClient(row), nil
+}
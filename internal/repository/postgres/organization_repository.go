@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type organizationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrganizationRepository(pool *pgxpool.Pool) repositories.OrganizationRepository {
+	return &organizationRepository{
+		pool: pool,
+	}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *models.Organization) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateOrganization(ctx, sqlc.CreateOrganizationParams{
+		ID:   organization.ID,
+		Slug: organization.Slug,
+		Name: organization.Name,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*organization = *mapToDomainOrganization(row)
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetOrganization(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainOrganization(row), nil
+}
+
+func (r *organizationRepository) List(ctx context.Context) ([]*models.Organization, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListOrganizations(ctx)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainOrganizations(rows), nil
+}
+
+func (r *organizationRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Organization, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListOrganizationsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainOrganizations(rows), nil
+}
+
+func (r *organizationRepository) UpdateName(ctx context.Context, id uuid.UUID, name string) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateOrganizationName(ctx, sqlc.UpdateOrganizationNameParams{
+		ID:   id,
+		Name: name,
+	}))
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteOrganization(ctx, id))
+}
+
+func mapToDomainOrganizations(rows []sqlc.Organization) []*models.Organization {
+	organizations := make([]*models.Organization, 0, len(rows))
+	for _, row := range rows {
+		organizations = append(organizations, mapToDomainOrganization(row))
+	}
+	return organizations
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type outboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) repositories.OutboxRepository {
+	return &outboxRepository{
+		pool: pool,
+	}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		ID:        event.ID,
+		EventType: event.Name,
+		Payload:   event.Payload,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	event.NextAttemptAt = row.NextAttemptAt
+	event.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *outboxRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.OutboxEvent, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ClaimDueOutboxEvents(ctx, sqlc.ClaimDueOutboxEventsParams{
+		LeaseUntil: leaseUntil,
+		Now:        now,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	events := make([]*models.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, mapToDomainOutboxEvent(row))
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.MarkOutboxEventPublished(ctx, sqlc.MarkOutboxEventPublishedParams{
+		ID:          id,
+		PublishedAt: &at,
+	}))
+}
+
+func (r *outboxRepository) Reschedule(ctx context.Context, id uuid.UUID, next time.Time, lastError string) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RescheduleOutboxEvent(ctx, sqlc.RescheduleOutboxEventParams{
+		ID:            id,
+		NextAttemptAt: next,
+		LastError:     &lastError,
+	}))
+}
+
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	deleted, err := q.DeletePublishedOutboxEvents(ctx, &before)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return deleted, nil
+}
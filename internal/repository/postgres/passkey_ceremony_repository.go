@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passkeyCeremonyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPasskeyCeremonyRepository(pool *pgxpool.Pool) repositories.PasskeyCeremonyRepository {
+	return &passkeyCeremonyRepository{
+		pool: pool,
+	}
+}
+
+func (r *passkeyCeremonyRepository) Create(ctx context.Context, ceremony *models.PasskeyCeremony) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreatePasskeyCeremony(ctx, sqlc.CreatePasskeyCeremonyParams{
+		ID:        ceremony.ID,
+		AccountID: ceremony.AccountID,
+		Purpose:   string(ceremony.Purpose),
+		State:     ceremony.State,
+		ExpiresAt: ceremony.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*ceremony = *mapToDomainPasskeyCeremony(row)
+	return nil
+}
+
+func (r *passkeyCeremonyRepository) Consume(ctx context.Context, id uuid.UUID) (*models.PasskeyCeremony, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.ConsumePasskeyCeremony(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainPasskeyCeremony(row), nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passkeyCredentialRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPasskeyCredentialRepository(pool *pgxpool.Pool) repositories.PasskeyCredentialRepository {
+	return &passkeyCredentialRepository{
+		pool: pool,
+	}
+}
+
+func (r *passkeyCredentialRepository) Create(ctx context.Context, credential *models.PasskeyCredential) error {
+	q := getQueries(ctx, r.pool)
+
+	var transports *string
+	if len(credential.Transports) > 0 {
+		joined := strings.Join(credential.Transports, ",")
+		transports = &joined
+	}
+
+	row, err := q.CreatePasskeyCredential(ctx, sqlc.CreatePasskeyCredentialParams{
+		ID:              credential.ID,
+		AccountID:       credential.AccountID,
+		CredentialID:    credential.CredentialID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Aaguid:          credential.AAGUID,
+		SignCount:       int64(credential.SignCount),
+		Transports:      transports,
+		UserVerified:    credential.UserVerified,
+		BackupEligible:  credential.BackupEligible,
+		BackupState:     credential.BackupState,
+		Name:            credential.Name,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*credential = *mapToDomainPasskeyCredential(row)
+	return nil
+}
+
+func (r *passkeyCredentialRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.PasskeyCredential, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListPasskeyCredentialsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	credentials := make([]*models.PasskeyCredential, 0, len(rows))
+	for _, row := range rows {
+		credentials = append(credentials, mapToDomainPasskeyCredential(row))
+	}
+	return credentials, nil
+}
+
+func (r *passkeyCredentialRepository) UpdateUsage(ctx context.Context, id uuid.UUID, signCount uint32, backupState bool, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdatePasskeyCredentialUsage(ctx, sqlc.UpdatePasskeyCredentialUsageParams{
+		ID:          id,
+		SignCount:   int64(signCount),
+		BackupState: backupState,
+		LastUsedAt:  &at,
+	}))
+}
+
+func (r *passkeyCredentialRepository) Delete(ctx context.Context, id, accountID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeletePasskeyCredential(ctx, sqlc.DeletePasskeyCredentialParams{
+		ID:        id,
+		AccountID: accountID,
+	}))
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passwordCredentialRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPasswordCredentialRepository(pool *pgxpool.Pool) repositories.PasswordCredentialRepository {
+	return &passwordCredentialRepository{
+		pool: pool,
+	}
+}
+
+func (r *passwordCredentialRepository) Create(ctx context.Context, credential *models.PasswordCredential) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreatePasswordCredential(ctx, sqlc.CreatePasswordCredentialParams{
+		AuthMethodID: credential.AuthMethodID,
+		PasswordHash: credential.PasswordHash,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*credential = *mapToDomainPasswordCredential(row)
+	return nil
+}
+
+func (r *passwordCredentialRepository) GetByAuthMethodID(ctx context.Context, authMethodID uuid.UUID) (*models.PasswordCredential, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetPasswordCredentialByAuthMethodID(ctx, authMethodID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainPasswordCredential(row), nil
+}
+
+func (r *passwordCredentialRepository) UpdateHash(ctx context.Context, authMethodID uuid.UUID, passwordHash string, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdatePasswordCredential(ctx, sqlc.UpdatePasswordCredentialParams{
+		AuthMethodID: authMethodID,
+		PasswordHash: passwordHash,
+		UpdatedAt:    at,
+	}))
+}
+
+func (r *passwordCredentialRepository) Rehash(ctx context.Context, authMethodID uuid.UUID, previousHash, passwordHash string) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RehashPasswordCredential(ctx, sqlc.RehashPasswordCredentialParams{
+		PasswordHash: passwordHash,
+		AuthMethodID: authMethodID,
+		PreviousHash: previousHash,
+	}))
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passwordHistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPasswordHistoryRepository(pool *pgxpool.Pool) repositories.PasswordHistoryRepository {
+	return &passwordHistoryRepository{
+		pool: pool,
+	}
+}
+
+func (r *passwordHistoryRepository) Create(ctx context.Context, entry *models.PasswordHistoryEntry) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.CreatePasswordHistoryEntry(ctx, sqlc.CreatePasswordHistoryEntryParams{
+		ID:           entry.ID,
+		AuthMethodID: entry.AuthMethodID,
+		PasswordHash: entry.PasswordHash,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
+
+func (r *passwordHistoryRepository) ListRecent(ctx context.Context, authMethodID uuid.UUID, limit int) ([]*models.PasswordHistoryEntry, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListRecentPasswordHistory(ctx, sqlc.ListRecentPasswordHistoryParams{
+		AuthMethodID: authMethodID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	entries := make([]*models.PasswordHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mapToDomainPasswordHistoryEntry(row))
+	}
+	return entries, nil
+}
+
+func (r *passwordHistoryRepository) Prune(ctx context.Context, authMethodID uuid.UUID, keep int) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.PrunePasswordHistory(ctx, sqlc.PrunePasswordHistoryParams{
+		AuthMethodID: authMethodID,
+		Limit:        int32(keep),
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+-- name: CreateAccessToken :one
+INSERT INTO access_tokens (id, token_hash, claims, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAccessTokenByTokenHash :one
+SELECT * FROM access_tokens
+WHERE token_hash = $1 AND expires_at > $2;
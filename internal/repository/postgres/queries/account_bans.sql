@@ -0,0 +1,24 @@
+-- name: CreateAccountBan :one
+INSERT INTO account_bans (id, account_id, previous_status_code, reason, banned_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetActiveAccountBan :one
+SELECT * FROM account_bans
+WHERE account_id = $1 AND lifted_at IS NULL;
+
+-- name: ListAccountBansByAccountID :many
+SELECT * FROM account_bans
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListExpiredAccountBans :many
+SELECT * FROM account_bans
+WHERE lifted_at IS NULL AND expires_at <= sqlc.arg(now)::timestamptz
+ORDER BY expires_at
+LIMIT sqlc.arg(row_limit);
+
+-- name: LiftAccountBan :execrows
+UPDATE account_bans
+SET lifted_at = $2, lifted_by = $3, lift_reason = $4
+WHERE id = $1 AND lifted_at IS NULL;
@@ -0,0 +1,18 @@
+-- name: CreateAccountDeletion :one
+INSERT INTO account_deletions (account_id, purge_at)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetAccountDeletion :one
+SELECT * FROM account_deletions
+WHERE account_id = $1;
+
+-- name: ListDueAccountDeletions :many
+SELECT * FROM account_deletions
+WHERE purge_at <= sqlc.arg(now)::timestamptz
+ORDER BY purge_at
+LIMIT sqlc.arg(row_limit);
+
+-- name: DeleteAccountDeletion :execrows
+DELETE FROM account_deletions
+WHERE account_id = $1;
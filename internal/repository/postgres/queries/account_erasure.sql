@@ -0,0 +1,76 @@
+-- name: EraseAPIKeys :exec
+DELETE FROM api_keys
+WHERE account_id = $1;
+
+-- name: EraseAccountDeletion :exec
+DELETE FROM account_deletions
+WHERE account_id = $1;
+
+-- name: EraseAuditEvents :exec
+UPDATE audit_events
+SET ip_address = NULL, user_agent = NULL, details = details - 'email'
+WHERE actor_id = sqlc.arg(account_id)
+   OR target_id = sqlc.arg(account_id)
+   OR details->>'email' IN (SELECT provider_id FROM auth_methods WHERE account_id = sqlc.arg(account_id) AND provider_code = 'EMAIL');
+
+-- name: EraseAuthMethods :exec
+UPDATE auth_methods
+SET provider_id = 'erased:' || id::text, is_verified = false, last_login_at = NULL, failed_attempts = 0, locked_until = NULL
+WHERE account_id = $1;
+
+-- name: EraseConsents :exec
+UPDATE consents
+SET ip_address = NULL, user_agent = NULL
+WHERE account_id = $1;
+
+-- name: EraseDataExports :exec
+DELETE FROM data_exports
+WHERE account_id = $1;
+
+-- name: EraseInvitations :exec
+UPDATE organization_invitations
+SET email = 'erased:' || id::text
+WHERE accepted_by = sqlc.arg(account_id)
+   OR email IN (SELECT provider_id FROM auth_methods WHERE account_id = sqlc.arg(account_id) AND provider_code = 'EMAIL');
+
+-- name: EraseMFAFactors :exec
+DELETE FROM mfa_factors
+WHERE account_id = $1;
+
+-- name: EraseMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE account_id = $1;
+
+-- name: EraseOutboxEvents :exec
+DELETE FROM outbox_events
+WHERE (payload->>'account_id')::uuid = sqlc.arg(account_id)
+   OR payload->>'email' IN (SELECT provider_id FROM auth_methods WHERE account_id = sqlc.arg(account_id) AND provider_code = 'EMAIL');
+
+-- name: ErasePasskeyCredentials :exec
+DELETE FROM passkey_credentials
+WHERE account_id = $1;
+
+-- name: ErasePasswordCredentials :exec
+DELETE FROM password_credentials
+WHERE auth_method_id IN (SELECT id FROM auth_methods WHERE account_id = $1);
+
+-- name: ErasePasswordHistory :exec
+DELETE FROM password_history
+WHERE auth_method_id IN (SELECT id FROM auth_methods WHERE account_id = $1);
+
+-- name: EraseRefreshTokens :exec
+UPDATE refresh_tokens
+SET ip_address = NULL, user_agent = NULL
+WHERE account_id = $1;
+
+-- name: EraseTrustedDevices :exec
+DELETE FROM trusted_devices
+WHERE account_id = $1;
+
+-- name: EraseVerificationCodes :exec
+DELETE FROM verification_codes
+WHERE auth_method_id IN (SELECT id FROM auth_methods WHERE account_id = $1);
+
+-- name: EraseWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE (payload->'data'->>'account_id')::uuid = $1;
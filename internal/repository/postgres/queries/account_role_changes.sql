@@ -0,0 +1,9 @@
+-- name: CreateAccountRoleChange :one
+INSERT INTO account_role_changes (id, account_id, previous_role_code, role_code, changed_by, reason)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListAccountRoleChangesByAccountID :many
+SELECT * FROM account_role_changes
+WHERE account_id = $1
+ORDER BY created_at DESC;
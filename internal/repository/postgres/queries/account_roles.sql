@@ -0,0 +1,42 @@
+-- name: CreateAccountRole :one
+INSERT INTO account_roles (code, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetAccountRole :one
+SELECT * FROM account_roles
+WHERE code = $1;
+
+-- name: ListAccountRoles :many
+SELECT * FROM account_roles
+ORDER BY code;
+
+-- name: UpdateAccountRoleDescription :execrows
+UPDATE account_roles
+SET description = $2
+WHERE code = $1;
+
+-- name: DeleteAccountRole :execrows
+DELETE FROM account_roles
+WHERE code = $1 AND NOT is_system;
+
+-- name: CountAccountsByRole :one
+SELECT count(*) FROM accounts
+WHERE role_code = $1;
+
+-- name: ListRolePermissions :many
+SELECT permission FROM role_permissions
+WHERE role_code = $1
+ORDER BY permission;
+
+-- name: ListAllRolePermissions :many
+SELECT * FROM role_permissions
+ORDER BY role_code, permission;
+
+-- name: CreateRolePermission :exec
+INSERT INTO role_permissions (role_code, permission)
+VALUES ($1, $2);
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions
+WHERE role_code = $1;
@@ -0,0 +1,61 @@
+-- name: CreateAccount :one
+INSERT INTO accounts (id, role_code, status_code)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetAccountByID :one
+SELECT * FROM accounts
+WHERE id = $1;
+
+-- name: GetAccountByIDForUpdate :one
+SELECT * FROM accounts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateAccountStatus :execrows
+UPDATE accounts
+SET status_code = $2
+WHERE id = $1;
+
+-- name: UpdateAccountRole :execrows
+UPDATE accounts
+SET role_code = $2
+WHERE id = $1;
+
+-- name: DeleteAccount :execrows
+DELETE FROM accounts
+WHERE id = $1;
+
+-- name: ListAccountsAfter :many
+SELECT * FROM accounts
+WHERE (created_at, id) > (sqlc.arg(created_at)::timestamptz, sqlc.arg(id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListAccountsByIDs :many
+SELECT * FROM accounts
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: SearchAccounts :many
+SELECT a.* FROM accounts a
+WHERE (a.created_at, a.id) > (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+  AND (sqlc.narg(role_code)::varchar IS NULL OR a.role_code = sqlc.narg(role_code))
+  AND (sqlc.narg(status_code)::varchar IS NULL OR a.status_code = sqlc.narg(status_code))
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR a.created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR a.created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(provider_code)::varchar IS NULL OR EXISTS (
+    SELECT 1 FROM auth_methods m
+    WHERE m.account_id = a.id AND m.provider_code = sqlc.narg(provider_code)
+  ))
+  AND (sqlc.narg(email_pattern)::varchar IS NULL OR EXISTS (
+    SELECT 1 FROM auth_methods m
+    WHERE m.account_id = a.id AND m.provider_code = 'EMAIL' AND m.provider_id LIKE sqlc.narg(email_pattern)
+  ))
+ORDER BY a.created_at, a.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListAccountIDsByRoleForUpdate :many
+SELECT id FROM accounts
+WHERE role_code = $1 AND status_code = $2
+ORDER BY id
+FOR UPDATE;
@@ -0,0 +1,27 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (id, account_id, name, key_hash, key_prefix, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAPIKeyByKeyHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1;
+
+-- name: ListAPIKeysByAccountID :many
+SELECT * FROM api_keys
+WHERE account_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: CountActiveAPIKeysByAccountID :one
+SELECT COUNT(*) FROM api_keys
+WHERE account_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > $2);
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = $3
+WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL;
+
+-- name: RecordAPIKeyUse :execrows
+UPDATE api_keys
+SET last_used_at = $2
+WHERE id = $1;
@@ -0,0 +1,34 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (id, action, actor_id, target_id, ip_address, user_agent, details)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: SearchAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg(before_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (sqlc.narg(before_created_at)::timestamptz, sqlc.arg(before_id)::uuid))
+  AND (sqlc.narg(actor_id)::uuid IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (sqlc.narg(target_id)::uuid IS NULL OR target_id = sqlc.narg(target_id))
+  AND (sqlc.narg(actions)::varchar[] IS NULL OR action = ANY(sqlc.narg(actions)::varchar[]))
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ClearAuditEventOrigins :execrows
+UPDATE audit_events
+SET ip_address = NULL, user_agent = NULL
+WHERE id IN (
+  SELECT id FROM audit_events
+  WHERE created_at < sqlc.arg(before)::timestamptz
+    AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: DeleteAuditEventsBefore :execrows
+DELETE FROM audit_events
+WHERE id IN (
+  SELECT id FROM audit_events
+  WHERE created_at < sqlc.arg(before)::timestamptz
+  LIMIT sqlc.arg(batch_size)
+);
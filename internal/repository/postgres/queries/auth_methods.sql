@@ -0,0 +1,69 @@
+-- name: CreateAuthMethod :one
+INSERT INTO auth_methods (id, account_id, provider_code, provider_id, is_verified)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAuthMethodByID :one
+SELECT * FROM auth_methods
+WHERE id = $1;
+
+-- name: GetAuthMethodByProvider :one
+SELECT * FROM auth_methods
+WHERE provider_code = $1 AND provider_id = $2;
+
+-- name: ListAuthMethodsByAccountID :many
+SELECT * FROM auth_methods
+WHERE account_id = $1
+ORDER BY provider_code;
+
+-- name: UpdateAuthMethodVerified :execrows
+UPDATE auth_methods
+SET is_verified = $2
+WHERE id = $1;
+
+-- name: UpdateAuthMethodLastLogin :execrows
+UPDATE auth_methods
+SET last_login_at = $2
+WHERE id = $1;
+
+-- name: DeleteAuthMethod :execrows
+DELETE FROM auth_methods
+WHERE id = $1;
+
+-- name: IncrementAuthMethodFailedAttempts :one
+UPDATE auth_methods
+SET failed_attempts = failed_attempts + 1
+WHERE id = $1
+RETURNING failed_attempts;
+
+-- name: LockAuthMethod :execrows
+UPDATE auth_methods
+SET locked_until = $2
+WHERE id = $1;
+
+-- name: ResetAuthMethodFailedAttempts :execrows
+UPDATE auth_methods
+SET failed_attempts = 0, locked_until = NULL
+WHERE id = $1;
+
+-- name: ListAuthMethodsByAccountIDs :many
+SELECT * FROM auth_methods
+WHERE account_id = ANY(sqlc.arg(account_ids)::uuid[])
+ORDER BY account_id, id;
+
+-- name: ListAuthMethodsByProvider :many
+SELECT m.* FROM auth_methods m
+JOIN accounts a ON a.id = m.account_id
+WHERE m.provider_code = $1 AND a.status_code <> 'DELETED'
+ORDER BY a.created_at, a.id
+LIMIT $2 OFFSET $3;
+
+-- name: CountAuthMethodsByProvider :one
+SELECT count(*) FROM auth_methods m
+JOIN accounts a ON a.id = m.account_id
+WHERE m.provider_code = $1 AND a.status_code <> 'DELETED';
+
+-- name: UpdateAuthMethodProviderID :execrows
+UPDATE auth_methods
+SET provider_id = $2, is_verified = $3
+WHERE id = $1;
@@ -0,0 +1,4 @@
+-- name: EnsureAuthProvider :exec
+INSERT INTO auth_providers (code, description)
+VALUES ($1, $2)
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,16 @@
+-- name: CreateAuthorizationCode :one
+INSERT INTO authorization_codes (
+    id, code_hash, client_id, account_id, redirect_uri, scope, nonce,
+    code_challenge, code_challenge_method, auth_time, expires_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetAuthorizationCodeByCodeHash :one
+SELECT * FROM authorization_codes
+WHERE code_hash = $1;
+
+-- name: ConsumeAuthorizationCode :execrows
+UPDATE authorization_codes
+SET consumed_at = $2
+WHERE id = $1 AND consumed_at IS NULL;
@@ -0,0 +1,12 @@
+-- name: CreateBrowserSession :one
+INSERT INTO browser_sessions (id, account_id, session_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetBrowserSessionByTokenHash :one
+SELECT * FROM browser_sessions
+WHERE token_hash = $1;
+
+-- name: DeleteBrowserSession :execrows
+DELETE FROM browser_sessions
+WHERE id = $1;
@@ -0,0 +1,11 @@
+-- name: CreateConsent :one
+INSERT INTO consents (id, account_id, document_id, ip_address, user_agent)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListConsentsByAccountID :many
+SELECT consents.id, consents.account_id, consents.document_id, legal_documents.kind, legal_documents.version, consents.ip_address, consents.user_agent, consents.accepted_at
+FROM consents
+JOIN legal_documents ON legal_documents.id = consents.document_id
+WHERE consents.account_id = $1
+ORDER BY consents.accepted_at DESC, consents.id DESC;
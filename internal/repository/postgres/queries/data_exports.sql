@@ -0,0 +1,32 @@
+-- name: CreateDataExport :one
+INSERT INTO data_exports (id, account_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetDataExportByID :one
+SELECT * FROM data_exports
+WHERE id = $1;
+
+-- name: ListDataExportsByAccountID :many
+SELECT id, account_id, status, created_at, completed_at, expires_at FROM data_exports
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListPendingDataExports :many
+SELECT * FROM data_exports
+WHERE status = 'PENDING'
+ORDER BY created_at
+LIMIT sqlc.arg(row_limit);
+
+-- name: CompleteDataExport :execrows
+UPDATE data_exports
+SET status = 'READY', archive = $2, completed_at = $3, expires_at = $4
+WHERE id = $1 AND status = 'PENDING';
+
+-- name: DeleteExpiredDataExports :execrows
+DELETE FROM data_exports
+WHERE id IN (
+  SELECT id FROM data_exports
+  WHERE expires_at <= sqlc.arg(now)::timestamptz
+  LIMIT sqlc.arg(batch_size)
+);
@@ -0,0 +1,29 @@
+-- name: CreateDeviceCode :one
+INSERT INTO device_codes (id, device_code_hash, user_code_hash, client_id, scope, interval_seconds, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetDeviceCodeByDeviceCodeHash :one
+SELECT * FROM device_codes
+WHERE device_code_hash = $1;
+
+-- name: GetPendingDeviceCodeByUserCodeHash :one
+SELECT * FROM device_codes
+WHERE user_code_hash = $1 AND status = 'PENDING' AND expires_at > $2
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: DecideDeviceCode :execrows
+UPDATE device_codes
+SET status = $2, account_id = $3, auth_time = $4
+WHERE id = $1 AND status = 'PENDING';
+
+-- name: RecordDeviceCodePoll :execrows
+UPDATE device_codes
+SET last_polled_at = $2, interval_seconds = $3
+WHERE id = $1;
+
+-- name: ConsumeDeviceCode :execrows
+UPDATE device_codes
+SET consumed_at = $2
+WHERE id = $1 AND consumed_at IS NULL;
@@ -0,0 +1,18 @@
+-- name: CreateImpersonation :one
+INSERT INTO impersonations (id, account_id, admin_id, reason, token_id, ip_address, user_agent, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetImpersonation :one
+SELECT * FROM impersonations
+WHERE id = $1;
+
+-- name: ListImpersonationsByAccountID :many
+SELECT * FROM impersonations
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: EndImpersonation :execrows
+UPDATE impersonations
+SET ended_at = $2
+WHERE id = $1 AND ended_at IS NULL AND expires_at > $2;
@@ -0,0 +1,5 @@
+-- name: TryAcquireLeaderLock :one
+SELECT pg_try_advisory_lock(sqlc.arg(lock_key)::bigint);
+
+-- name: ReleaseLeaderLock :one
+SELECT pg_advisory_unlock(sqlc.arg(lock_key)::bigint);
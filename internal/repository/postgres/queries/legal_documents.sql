@@ -0,0 +1,28 @@
+-- name: CreateLegalDocument :one
+INSERT INTO legal_documents (id, kind, version, url)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetLegalDocumentByID :one
+SELECT * FROM legal_documents
+WHERE id = $1;
+
+-- name: ListLegalDocuments :many
+SELECT * FROM legal_documents
+ORDER BY published_at DESC, id DESC;
+
+-- name: ListCurrentLegalDocuments :many
+SELECT DISTINCT ON (kind) * FROM legal_documents
+ORDER BY kind, published_at DESC, id DESC;
+
+-- name: ListPendingLegalDocuments :many
+SELECT latest.id, latest.kind, latest.version, latest.url, latest.published_at
+FROM (
+  SELECT DISTINCT ON (kind) * FROM legal_documents
+  ORDER BY kind, published_at DESC, id DESC
+) latest
+WHERE NOT EXISTS (
+  SELECT 1 FROM consents
+  WHERE consents.account_id = $1 AND consents.document_id = latest.id
+)
+ORDER BY latest.kind;
@@ -0,0 +1,19 @@
+-- name: CreateMFAChallenge :one
+INSERT INTO mfa_challenges (id, account_id, token_hash, remember_me, scope, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetMFAChallengeByTokenHash :one
+SELECT * FROM mfa_challenges
+WHERE token_hash = $1;
+
+-- name: IncrementMFAChallengeAttempts :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts;
+
+-- name: MarkMFAChallengeConsumed :execrows
+UPDATE mfa_challenges
+SET consumed_at = $2
+WHERE id = $1 AND consumed_at IS NULL;
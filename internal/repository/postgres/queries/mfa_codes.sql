@@ -0,0 +1,26 @@
+-- name: CreateMFACode :one
+INSERT INTO mfa_codes (id, factor_id, code_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetLatestMFACodeByFactorID :one
+SELECT * FROM mfa_codes
+WHERE factor_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: IncrementMFACodeAttempts :one
+UPDATE mfa_codes
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts;
+
+-- name: MarkMFACodeConsumed :execrows
+UPDATE mfa_codes
+SET consumed_at = $2
+WHERE id = $1 AND consumed_at IS NULL;
+
+-- name: InvalidateActiveMFACodes :exec
+UPDATE mfa_codes
+SET expires_at = $2
+WHERE factor_id = $1 AND consumed_at IS NULL AND expires_at > $2;
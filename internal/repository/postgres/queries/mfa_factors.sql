@@ -0,0 +1,43 @@
+-- name: CreateMFAFactor :one
+INSERT INTO mfa_factors (id, account_id, factor_type, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetMFAFactorByAccountAndType :one
+SELECT * FROM mfa_factors
+WHERE account_id = $1 AND factor_type = $2;
+
+-- name: ListMFAFactorsByAccountID :many
+SELECT * FROM mfa_factors
+WHERE account_id = $1
+ORDER BY created_at;
+
+-- name: ConfirmMFAFactor :execrows
+UPDATE mfa_factors
+SET confirmed_at = $2, last_used_step = $3
+WHERE id = $1 AND confirmed_at IS NULL;
+
+-- name: UpdateMFAFactorLastUsedStep :execrows
+UPDATE mfa_factors
+SET last_used_step = $2
+WHERE id = $1 AND last_used_step < $2;
+
+-- name: DeleteMFAFactor :execrows
+DELETE FROM mfa_factors
+WHERE id = $1;
+
+-- name: IncrementMFAFactorFailedAttempts :one
+UPDATE mfa_factors
+SET failed_attempts = failed_attempts + 1
+WHERE id = $1
+RETURNING failed_attempts;
+
+-- name: LockMFAFactor :execrows
+UPDATE mfa_factors
+SET locked_until = $2
+WHERE id = $1;
+
+-- name: ResetMFAFactorFailedAttempts :execrows
+UPDATE mfa_factors
+SET failed_attempts = 0, locked_until = NULL
+WHERE id = $1;
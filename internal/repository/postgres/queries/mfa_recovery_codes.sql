@@ -0,0 +1,16 @@
+-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (id, account_id, code_hash)
+VALUES ($1, $2, $3);
+
+-- name: ConsumeMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET consumed_at = $3
+WHERE account_id = $1 AND code_hash = $2 AND consumed_at IS NULL;
+
+-- name: CountUnusedMFARecoveryCodes :one
+SELECT COUNT(*) FROM mfa_recovery_codes
+WHERE account_id = $1 AND consumed_at IS NULL;
+
+-- name: DeleteMFARecoveryCodesByAccountID :exec
+DELETE FROM mfa_recovery_codes
+WHERE account_id = $1;
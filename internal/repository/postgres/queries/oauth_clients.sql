@@ -0,0 +1,25 @@
+-- name: UpsertOAuthClient :one
+INSERT INTO oauth_clients (
+    id, client_id, name, secret_hash, redirect_uris, public, grant_types, scopes,
+    exchange_audiences, impersonation
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (client_id) DO UPDATE
+SET name = EXCLUDED.name,
+    secret_hash = EXCLUDED.secret_hash,
+    redirect_uris = EXCLUDED.redirect_uris,
+    public = EXCLUDED.public,
+    grant_types = EXCLUDED.grant_types,
+    scopes = EXCLUDED.scopes,
+    exchange_audiences = EXCLUDED.exchange_audiences,
+    impersonation = EXCLUDED.impersonation,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetOAuthClientByClientID :one
+SELECT * FROM oauth_clients
+WHERE client_id = $1;
+
+-- name: GetOAuthClientByID :one
+SELECT * FROM oauth_clients
+WHERE id = $1;
@@ -0,0 +1,25 @@
+-- name: UpsertOrganizationBranding :one
+INSERT INTO organization_branding (organization_id, sender_name, sender_address, logo_url, primary_color, background_color, footer_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (organization_id) DO UPDATE
+SET sender_name = EXCLUDED.sender_name,
+    sender_address = EXCLUDED.sender_address,
+    logo_url = EXCLUDED.logo_url,
+    primary_color = EXCLUDED.primary_color,
+    background_color = EXCLUDED.background_color,
+    footer_text = EXCLUDED.footer_text,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetOrganizationBranding :one
+SELECT * FROM organization_branding
+WHERE organization_id = $1;
+
+-- name: GetOrganizationBrandingBySlug :one
+SELECT b.* FROM organization_branding b
+JOIN organizations o ON o.id = b.organization_id
+WHERE o.slug = $1;
+
+-- name: DeleteOrganizationBranding :execrows
+DELETE FROM organization_branding
+WHERE organization_id = $1;
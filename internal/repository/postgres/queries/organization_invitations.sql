@@ -0,0 +1,28 @@
+-- name: CreateOrganizationInvitation :one
+INSERT INTO organization_invitations (id, organization_id, email, role_code, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetOrganizationInvitationByTokenHash :one
+SELECT * FROM organization_invitations
+WHERE token_hash = $1;
+
+-- name: ListPendingOrganizationInvitations :many
+SELECT * FROM organization_invitations
+WHERE organization_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+ORDER BY created_at DESC;
+
+-- name: AcceptOrganizationInvitation :execrows
+UPDATE organization_invitations
+SET accepted_at = $2, accepted_by = $3
+WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2;
+
+-- name: RevokeOrganizationInvitation :execrows
+UPDATE organization_invitations
+SET revoked_at = $3
+WHERE id = $1 AND organization_id = $2 AND accepted_at IS NULL AND revoked_at IS NULL;
+
+-- name: RevokePendingOrganizationInvitationsByEmail :exec
+UPDATE organization_invitations
+SET revoked_at = $3
+WHERE organization_id = $1 AND email = $2 AND accepted_at IS NULL AND revoked_at IS NULL;
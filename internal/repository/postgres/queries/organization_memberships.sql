@@ -0,0 +1,23 @@
+-- name: UpsertOrganizationMembership :one
+INSERT INTO organization_memberships (organization_id, account_id, role_code)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, account_id) DO UPDATE
+SET role_code = EXCLUDED.role_code, updated_at = now()
+RETURNING *;
+
+-- name: GetOrganizationMembership :one
+SELECT * FROM organization_memberships
+WHERE organization_id = $1 AND account_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT * FROM organization_memberships
+WHERE organization_id = $1
+ORDER BY created_at, account_id;
+
+-- name: ListOrganizationMembershipsByAccountID :many
+SELECT * FROM organization_memberships
+WHERE account_id = $1;
+
+-- name: DeleteOrganizationMembership :execrows
+DELETE FROM organization_memberships
+WHERE organization_id = $1 AND account_id = $2;
@@ -0,0 +1,24 @@
+-- name: UpsertOrganizationPolicy :one
+INSERT INTO organization_policies (organization_id, password_min_length, password_required_classes, require_mfa, session_max_age_seconds, allowed_providers)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (organization_id) DO UPDATE
+SET password_min_length = EXCLUDED.password_min_length,
+    password_required_classes = EXCLUDED.password_required_classes,
+    require_mfa = EXCLUDED.require_mfa,
+    session_max_age_seconds = EXCLUDED.session_max_age_seconds,
+    allowed_providers = EXCLUDED.allowed_providers,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetOrganizationPolicy :one
+SELECT * FROM organization_policies
+WHERE organization_id = $1;
+
+-- name: ListOrganizationPoliciesByAccountID :many
+SELECT p.* FROM organization_policies p
+JOIN organization_memberships m ON m.organization_id = p.organization_id
+WHERE m.account_id = $1;
+
+-- name: DeleteOrganizationPolicy :execrows
+DELETE FROM organization_policies
+WHERE organization_id = $1;
@@ -0,0 +1,27 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (id, slug, name)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = $1;
+
+-- name: ListOrganizations :many
+SELECT * FROM organizations
+ORDER BY slug;
+
+-- name: ListOrganizationsByAccountID :many
+SELECT o.* FROM organizations o
+JOIN organization_memberships m ON m.organization_id = o.id
+WHERE m.account_id = $1
+ORDER BY o.slug;
+
+-- name: UpdateOrganizationName :execrows
+UPDATE organizations
+SET name = $2, updated_at = now()
+WHERE id = $1;
+
+-- name: DeleteOrganization :execrows
+DELETE FROM organizations
+WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (id, event_type, payload)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ClaimDueOutboxEvents :many
+UPDATE outbox_events
+SET next_attempt_at = sqlc.arg(lease_until)::timestamptz
+WHERE id IN (
+  SELECT e.id FROM outbox_events e
+  WHERE e.published_at IS NULL AND e.next_attempt_at <= sqlc.arg(now)::timestamptz
+  ORDER BY e.created_at, e.id
+  LIMIT sqlc.arg(row_limit)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxEventPublished :execrows
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = NULL, published_at = $2
+WHERE id = $1 AND published_at IS NULL;
+
+-- name: RescheduleOutboxEvent :execrows
+UPDATE outbox_events
+SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+WHERE id = $1 AND published_at IS NULL;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE published_at < $1;
@@ -0,0 +1,9 @@
+-- name: CreatePasskeyCeremony :one
+INSERT INTO passkey_ceremonies (id, account_id, purpose, state, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ConsumePasskeyCeremony :one
+DELETE FROM passkey_ceremonies
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,21 @@
+-- name: CreatePasskeyCredential :one
+INSERT INTO passkey_credentials (
+    id, account_id, credential_id, public_key, attestation_type, aaguid, sign_count,
+    transports, user_verified, backup_eligible, backup_state, name
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: ListPasskeyCredentialsByAccountID :many
+SELECT * FROM passkey_credentials
+WHERE account_id = $1
+ORDER BY created_at;
+
+-- name: UpdatePasskeyCredentialUsage :execrows
+UPDATE passkey_credentials
+SET sign_count = $2, backup_state = $3, last_used_at = $4
+WHERE id = $1;
+
+-- name: DeletePasskeyCredential :execrows
+DELETE FROM passkey_credentials
+WHERE id = $1 AND account_id = $2;
@@ -0,0 +1,18 @@
+-- name: CreatePasswordCredential :one
+INSERT INTO password_credentials (auth_method_id, password_hash)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetPasswordCredentialByAuthMethodID :one
+SELECT * FROM password_credentials
+WHERE auth_method_id = $1;
+
+-- name: UpdatePasswordCredential :execrows
+UPDATE password_credentials
+SET password_hash = $2, updated_at = $3
+WHERE auth_method_id = $1;
+
+-- name: RehashPasswordCredential :execrows
+UPDATE password_credentials
+SET password_hash = sqlc.arg(password_hash)
+WHERE auth_method_id = sqlc.arg(auth_method_id) AND password_hash = sqlc.arg(previous_hash);
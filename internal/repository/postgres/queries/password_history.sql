@@ -0,0 +1,19 @@
+-- name: CreatePasswordHistoryEntry :exec
+INSERT INTO password_history (id, auth_method_id, password_hash)
+VALUES ($1, $2, $3);
+
+-- name: ListRecentPasswordHistory :many
+SELECT * FROM password_history
+WHERE auth_method_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: PrunePasswordHistory :exec
+DELETE FROM password_history
+WHERE auth_method_id = $1
+  AND id NOT IN (
+    SELECT id FROM password_history
+    WHERE auth_method_id = $1
+    ORDER BY created_at DESC
+    LIMIT $2
+  );
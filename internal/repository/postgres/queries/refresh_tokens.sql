@@ -0,0 +1,54 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (id, account_id, session_id, token_hash, ip_address, user_agent, remember_me, session_started_at, expires_at, dpop_jkt, scope, organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: GetRefreshTokenByID :one
+SELECT * FROM refresh_tokens
+WHERE id = $1;
+
+-- name: GetRefreshTokenByTokenHash :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = $1;
+
+-- name: ListActiveRefreshTokensByAccountID :many
+SELECT * FROM refresh_tokens
+WHERE account_id = $1 AND revoked_at IS NULL AND expires_at > $2
+ORDER BY created_at DESC;
+
+-- name: ListRefreshTokensByAccountID :many
+SELECT * FROM refresh_tokens
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeAllRefreshTokensByAccountID :execrows
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE account_id = $1 AND revoked_at IS NULL;
+
+-- name: CountActiveSessions :one
+SELECT COUNT(DISTINCT session_id) FROM refresh_tokens
+WHERE revoked_at IS NULL AND expires_at > $1;
+
+-- name: DeleteStaleRefreshTokens :execrows
+DELETE FROM refresh_tokens
+WHERE id IN (
+  SELECT id FROM refresh_tokens
+  WHERE expires_at < sqlc.arg(before)::timestamptz OR revoked_at < sqlc.arg(before)::timestamptz
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: ClearRefreshTokenOrigins :execrows
+UPDATE refresh_tokens
+SET ip_address = NULL, user_agent = NULL
+WHERE id IN (
+  SELECT id FROM refresh_tokens
+  WHERE created_at < sqlc.arg(before)::timestamptz
+    AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)
+  LIMIT sqlc.arg(batch_size)
+);
@@ -0,0 +1,22 @@
+-- name: CreateSigningKey :one
+INSERT INTO signing_keys (id, algorithm, private_key, activates_at, organization_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListPublishedSigningKeys :many
+SELECT * FROM signing_keys
+WHERE expires_at IS NULL OR expires_at > $1
+ORDER BY activates_at DESC;
+
+-- name: RetireSigningKey :execrows
+UPDATE signing_keys
+SET retired_at = $2, expires_at = $3
+WHERE id = $1 AND retired_at IS NULL;
+
+-- name: ExpireOrganizationSigningKeys :execrows
+UPDATE signing_keys
+SET retired_at = COALESCE(retired_at, $2), expires_at = $2
+WHERE organization_id = $1 AND (expires_at IS NULL OR expires_at > $2);
+
+-- name: AcquireSigningKeyLock :exec
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_key)::bigint);
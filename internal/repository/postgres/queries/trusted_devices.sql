@@ -0,0 +1,36 @@
+-- name: CreateTrustedDevice :one
+INSERT INTO trusted_devices (id, account_id, token_hash, ip_address, user_agent, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTrustedDeviceByTokenHash :one
+SELECT * FROM trusted_devices
+WHERE token_hash = $1;
+
+-- name: ListActiveTrustedDevicesByAccountID :many
+SELECT * FROM trusted_devices
+WHERE account_id = $1 AND expires_at > $2
+ORDER BY created_at DESC;
+
+-- name: UpdateTrustedDeviceLastUsed :execrows
+UPDATE trusted_devices
+SET last_used_at = $2
+WHERE id = $1;
+
+-- name: DeleteTrustedDevice :execrows
+DELETE FROM trusted_devices
+WHERE id = $1 AND account_id = $2;
+
+-- name: DeleteTrustedDevicesByAccountID :execrows
+DELETE FROM trusted_devices
+WHERE account_id = $1;
+
+-- name: ClearTrustedDeviceOrigins :execrows
+UPDATE trusted_devices
+SET ip_address = NULL, user_agent = NULL
+WHERE id IN (
+  SELECT id FROM trusted_devices
+  WHERE created_at < sqlc.arg(before)::timestamptz
+    AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)
+  LIMIT sqlc.arg(batch_size)
+);
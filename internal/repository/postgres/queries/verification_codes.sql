@@ -0,0 +1,46 @@
+-- name: CreateVerificationCode :one
+INSERT INTO verification_codes (id, auth_method_id, purpose, code_hash, attempts, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetVerificationCodeByID :one
+SELECT * FROM verification_codes
+WHERE id = $1;
+
+-- name: GetLatestVerificationCodeByAuthMethodID :one
+SELECT * FROM verification_codes
+WHERE auth_method_id = $1 AND purpose = $2
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetVerificationCodeByHash :one
+SELECT * FROM verification_codes
+WHERE purpose = $1 AND code_hash = $2;
+
+-- name: IncrementVerificationCodeAttempts :one
+UPDATE verification_codes
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts;
+
+-- name: MarkVerificationCodeConsumed :execrows
+UPDATE verification_codes
+SET consumed_at = $2
+WHERE id = $1 AND consumed_at IS NULL;
+
+-- name: InvalidateActiveVerificationCodes :exec
+UPDATE verification_codes
+SET expires_at = $3
+WHERE auth_method_id = $1 AND purpose = $2 AND consumed_at IS NULL AND expires_at > $3;
+
+-- name: CountVerificationCodesCreatedSince :one
+SELECT COUNT(*) FROM verification_codes
+WHERE auth_method_id = $1 AND purpose = $2 AND created_at >= $3;
+
+-- name: DeleteStaleVerificationCodes :execrows
+DELETE FROM verification_codes
+WHERE id IN (
+  SELECT id FROM verification_codes
+  WHERE expires_at < sqlc.arg(before)::timestamptz OR consumed_at < sqlc.arg(before)::timestamptz
+  LIMIT sqlc.arg(batch_size)
+);
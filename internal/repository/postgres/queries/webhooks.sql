@@ -0,0 +1,80 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (id, url, description, event_types, secret, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1;
+
+-- name: ListWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+ORDER BY created_at, id;
+
+-- name: ListActiveWebhookEndpointsByEventType :many
+SELECT * FROM webhook_endpoints
+WHERE is_active AND sqlc.arg(event_type)::text = ANY(event_types)
+ORDER BY created_at, id;
+
+-- name: UpdateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET url = $2, description = $3, event_types = $4, is_active = $5, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateWebhookEndpointSecret :execrows
+UPDATE webhook_endpoints
+SET secret = $2, updated_at = now()
+WHERE id = $1;
+
+-- name: DeleteWebhookEndpoint :execrows
+DELETE FROM webhook_endpoints
+WHERE id = $1;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, payload, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (endpoint_id, event_id) DO NOTHING
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: ListWebhookDeliveriesByEndpointID :many
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = sqlc.arg(endpoint_id)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)::timestamptz, updated_at = now()
+WHERE id IN (
+  SELECT d.id FROM webhook_deliveries d
+  WHERE d.status = 'PENDING' AND d.next_attempt_at <= sqlc.arg(now)::timestamptz
+  ORDER BY d.next_attempt_at
+  LIMIT sqlc.arg(row_limit)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordWebhookDeliveryAttempt :execrows
+UPDATE webhook_deliveries
+SET status = $2, attempts = attempts + 1, next_attempt_at = $3, last_status_code = $4,
+    last_error = $5, delivered_at = $6, updated_at = now()
+WHERE id = $1 AND status = 'PENDING';
+
+-- name: RequeueWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET status = 'PENDING', attempts = 0, next_attempt_at = $2, updated_at = now()
+WHERE id = $1 AND status <> 'PENDING';
+
+-- name: CreateWebhookDeliveryAttempt :exec
+INSERT INTO webhook_delivery_attempts (id, delivery_id, status_code, error, duration_ms)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListWebhookDeliveryAttempts :many
+SELECT * FROM webhook_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY created_at, id;
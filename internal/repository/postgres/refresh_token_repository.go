@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type refreshTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRefreshTokenRepository(pool *pgxpool.Pool) repositories.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		pool: pool,
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateRefreshToken(ctx, sqlc.CreateRefreshTokenParams{
+		ID:               token.ID,
+		AccountID:        token.AccountID,
+		SessionID:        token.SessionID,
+		TokenHash:        token.TokenHash,
+		IpAddress:        token.IPAddress,
+		UserAgent:        token.UserAgent,
+		RememberMe:       token.RememberMe,
+		SessionStartedAt: token.SessionStartedAt,
+		ExpiresAt:        token.ExpiresAt,
+		DpopJkt:          token.KeyThumbprint,
+		Scope:            optionalText(token.Scope),
+		OrganizationID:   token.OrganizationID,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*token = *mapToDomainRefreshToken(row)
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetRefreshTokenByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainRefreshToken(row), nil
+}
+
+func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetRefreshTokenByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainRefreshToken(row), nil
+}
+
+func (r *refreshTokenRepository) ListActiveByAccountID(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*models.RefreshToken, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListActiveRefreshTokensByAccountID(ctx, sqlc.ListActiveRefreshTokensByAccountIDParams{
+		AccountID: accountID,
+		ExpiresAt: now,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	tokens := make([]*models.RefreshToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, mapToDomainRefreshToken(row))
+	}
+	return tokens, nil
+}
+
+func (r *refreshTokenRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.RefreshToken, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListRefreshTokensByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	tokens := make([]*models.RefreshToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, mapToDomainRefreshToken(row))
+	}
+	return tokens, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RevokeRefreshToken(ctx, sqlc.RevokeRefreshTokenParams{
+		ID:        id,
+		RevokedAt: &at,
+	}))
+}
+
+func (r *refreshTokenRepository) RevokeAllByAccountID(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	revoked, err := q.RevokeAllRefreshTokensByAccountID(ctx, sqlc.RevokeAllRefreshTokensByAccountIDParams{
+		AccountID: accountID,
+		RevokedAt: &at,
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return revoked, nil
+}
+
+func (r *refreshTokenRepository) CountActiveSessions(ctx context.Context, now time.Time) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	count, err := q.CountActiveSessions(ctx, now)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(count), nil
+}
+
+func (r *refreshTokenRepository) DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	deleted, err := q.DeleteStaleRefreshTokens(ctx, sqlc.DeleteStaleRefreshTokensParams{
+		Before:    before,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return deleted, nil
+}
+
+func (r *refreshTokenRepository) ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	cleared, err := q.ClearRefreshTokenOrigins(ctx, sqlc.ClearRefreshTokenOriginsParams{
+		Before:    before,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return cleared, nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type roleChangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRoleChangeRepository(pool *pgxpool.Pool) repositories.RoleChangeRepository {
+	return &roleChangeRepository{
+		pool: pool,
+	}
+}
+
+func (r *roleChangeRepository) Create(ctx context.Context, change *models.RoleChange) error {
+	q := getQueries(ctx, r.pool)
+
+	var reason *string
+	if change.Reason != "" {
+		reason = &change.Reason
+	}
+	row, err := q.CreateAccountRoleChange(ctx, sqlc.CreateAccountRoleChangeParams{
+		ID:               change.ID,
+		AccountID:        change.AccountID,
+		PreviousRoleCode: string(change.PreviousRole),
+		RoleCode:         string(change.Role),
+		ChangedBy:        change.ChangedBy,
+		Reason:           reason,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	change.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *roleChangeRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.RoleChange, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAccountRoleChangesByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	changes := make([]*models.RoleChange, 0, len(rows))
+	for _, row := range rows {
+		changes = append(changes, mapToDomainRoleChange(row))
+	}
+	return changes, nil
+}
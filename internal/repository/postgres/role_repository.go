@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type roleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRoleRepository(pool *pgxpool.Pool) repositories.RoleRepository {
+	return &roleRepository{
+		pool: pool,
+	}
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateAccountRole(ctx, sqlc.CreateAccountRoleParams{
+		Code:        string(role.Code),
+		Description: roleDescription(role),
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+	if err := r.createPermissions(ctx, q, role); err != nil {
+		return err
+	}
+
+	role.System = row.IsSystem
+	role.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *roleRepository) GetByCode(ctx context.Context, code domain.Role) (*models.Role, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetAccountRole(ctx, string(code))
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	permissions, err := r.ListPermissions(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return mapToDomainRole(row, permissions), nil
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListAccountRoles(ctx)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	grants, err := q.ListAllRolePermissions(ctx)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	permissions := make(map[string][]string)
+	for _, grant := range grants {
+		permissions[grant.RoleCode] = append(permissions[grant.RoleCode], grant.Permission)
+	}
+	roles := make([]*models.Role, 0, len(rows))
+	for _, row := range rows {
+		roles = append(roles, mapToDomainRole(row, permissions[row.Code]))
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	q := getQueries(ctx, r.pool)
+
+	err := expectAffected(q.UpdateAccountRoleDescription(ctx, sqlc.UpdateAccountRoleDescriptionParams{
+		Code:        string(role.Code),
+		Description: roleDescription(role),
+	}))
+	if err != nil {
+		return err
+	}
+	if err := q.DeleteRolePermissions(ctx, string(role.Code)); err != nil {
+		return mapPostgresError(err)
+	}
+	return r.createPermissions(ctx, q, role)
+}
+
+func (r *roleRepository) Delete(ctx context.Context, code domain.Role) error {
+	q := getQueries(ctx, r.pool)
+
+	assigned, err := q.CountAccountsByRole(ctx, string(code))
+	if err != nil {
+		return mapPostgresError(err)
+	}
+	if assigned > 0 {
+		return domain.ErrConflict
+	}
+
+	rows, err := q.DeleteAccountRole(ctx, string(code))
+	// An account given the role concurrently holds it by foreign key.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrConflict
+	}
+	return expectAffected(rows, err)
+}
+
+func (r *roleRepository) ListPermissions(ctx context.Context, code domain.Role) ([]string, error) {
+	q := getQueries(ctx, r.pool)
+
+	permissions, err := q.ListRolePermissions(ctx, string(code))
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return permissions, nil
+}
+
+func (r *roleRepository) createPermissions(ctx context.Context, q *sqlc.Queries, role *models.Role) error {
+	for _, permission := range role.Permissions {
+		err := q.CreateRolePermission(ctx, sqlc.CreateRolePermissionParams{
+			RoleCode:   string(role.Code),
+			Permission: permission,
+		})
+		if err != nil {
+			return mapPostgresError(err)
+		}
+	}
+	return nil
+}
+
+func roleDescription(role *models.Role) *string {
+	if role.Description == "" {
+		return nil
+	}
+	return &role.Description
+}
@@ -0,0 +1,5 @@
+package postgres
+
+// SchemaVersion is the migration the queries of this build are written
+// against. It must be raised with every migration added.
+const SchemaVersion = 50
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// signingKeyLockKey is the advisory lock taken while rotating signing keys.
+const signingKeyLockKey int64 = 0x52414e434f4b4559
+
+type signingKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSigningKeyRepository(pool *pgxpool.Pool) repositories.SigningKeyRepository {
+	return &signingKeyRepository{
+		pool: pool,
+	}
+}
+
+func (r *signingKeyRepository) Create(ctx context.Context, key *models.SigningKey) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateSigningKey(ctx, sqlc.CreateSigningKeyParams{
+		ID:             key.ID,
+		Algorithm:      key.Algorithm,
+		PrivateKey:     key.PrivateKey,
+		ActivatesAt:    key.ActivatesAt,
+		OrganizationID: key.OrganizationID,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*key = *mapToDomainSigningKey(row)
+	return nil
+}
+
+func (r *signingKeyRepository) ListPublished(ctx context.Context, now time.Time) ([]*models.SigningKey, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListPublishedSigningKeys(ctx, &now)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	keys := make([]*models.SigningKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, mapToDomainSigningKey(row))
+	}
+	return keys, nil
+}
+
+func (r *signingKeyRepository) Retire(ctx context.Context, id string, retiredAt, expiresAt time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RetireSigningKey(ctx, sqlc.RetireSigningKeyParams{
+		ID:        id,
+		RetiredAt: &retiredAt,
+		ExpiresAt: &expiresAt,
+	}))
+}
+
+func (r *signingKeyRepository) ExpireByOrganizationID(ctx context.Context, organizationID uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	_, err := q.ExpireOrganizationSigningKeys(ctx, sqlc.ExpireOrganizationSigningKeysParams{
+		OrganizationID: &organizationID,
+		ExpiresAt:      &at,
+	})
+	return mapPostgresError(err)
+}
+
+func (r *signingKeyRepository) AcquireRotationLock(ctx context.Context) error {
+	q := getQueries(ctx, r.pool)
+
+	if err := q.AcquireSigningKeyLock(ctx, signingKeyLockKey); err != nil {
+		return mapPostgresError(err)
+	}
+	return nil
+}
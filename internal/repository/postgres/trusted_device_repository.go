@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type trustedDeviceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTrustedDeviceRepository(pool *pgxpool.Pool) repositories.TrustedDeviceRepository {
+	return &trustedDeviceRepository{
+		pool: pool,
+	}
+}
+
+func (r *trustedDeviceRepository) Create(ctx context.Context, device *models.TrustedDevice) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateTrustedDevice(ctx, sqlc.CreateTrustedDeviceParams{
+		ID:        device.ID,
+		AccountID: device.AccountID,
+		TokenHash: device.TokenHash,
+		IpAddress: device.IPAddress,
+		UserAgent: device.UserAgent,
+		ExpiresAt: device.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*device = *mapToDomainTrustedDevice(row)
+	return nil
+}
+
+func (r *trustedDeviceRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.TrustedDevice, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetTrustedDeviceByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainTrustedDevice(row), nil
+}
+
+func (r *trustedDeviceRepository) ListActiveByAccountID(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*models.TrustedDevice, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListActiveTrustedDevicesByAccountID(ctx, sqlc.ListActiveTrustedDevicesByAccountIDParams{
+		AccountID: accountID,
+		ExpiresAt: now,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	devices := make([]*models.TrustedDevice, 0, len(rows))
+	for _, row := range rows {
+		devices = append(devices, mapToDomainTrustedDevice(row))
+	}
+	return devices, nil
+}
+
+func (r *trustedDeviceRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateTrustedDeviceLastUsed(ctx, sqlc.UpdateTrustedDeviceLastUsedParams{
+		ID:         id,
+		LastUsedAt: &at,
+	}))
+}
+
+func (r *trustedDeviceRepository) Delete(ctx context.Context, id, accountID uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteTrustedDevice(ctx, sqlc.DeleteTrustedDeviceParams{
+		ID:        id,
+		AccountID: accountID,
+	}))
+}
+
+func (r *trustedDeviceRepository) DeleteAllByAccountID(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	deleted, err := q.DeleteTrustedDevicesByAccountID(ctx, accountID)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return deleted, nil
+}
+
+func (r *trustedDeviceRepository) ClearOrigins(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	cleared, err := q.ClearTrustedDeviceOrigins(ctx, sqlc.ClearTrustedDeviceOriginsParams{
+		Before:    before,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return cleared, nil
+}
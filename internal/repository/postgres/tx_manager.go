@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// afterCommitKey carries the functions to run once the transaction of a
+// context commits.
+type afterCommitKey struct{}
+
+type PostgresTxManager struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTxManager(pool *pgxpool.Pool) *PostgresTxManager {
+	return &PostgresTxManager{pool: pool}
+}
+
+func (m *PostgresTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Operations composed of others that open transactions of their own run
+	// as one: the inner ones join the outer transaction.
+	if _, ok := ctx.Value(txKey{}).(*sqlc.Queries); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// Rolling back after the commit does nothing; it releases the connection
+	// when fn fails or panics.
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(tx)
+	var afterCommit []func(context.Context)
+	txCtx := context.WithValue(ctx, txKey{}, q)
+	txCtx = context.WithValue(txCtx, afterCommitKey{}, &afterCommit)
+
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	for _, run := range afterCommit {
+		run(ctx)
+	}
+	return nil
+}
+
+func (m *PostgresTxManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if afterCommit, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context)); ok {
+		*afterCommit = append(*afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
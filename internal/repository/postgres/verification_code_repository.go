@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type verificationCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewVerificationCodeRepository(pool *pgxpool.Pool) repositories.VerificationCodeRepository {
+	return &verificationCodeRepository{
+		pool: pool,
+	}
+}
+
+func (r *verificationCodeRepository) Create(ctx context.Context, code *models.VerificationCode) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateVerificationCode(ctx, sqlc.CreateVerificationCodeParams{
+		ID:           code.ID,
+		AuthMethodID: code.AuthMethodID,
+		Purpose:      string(code.Purpose),
+		CodeHash:     code.CodeHash,
+		Attempts:     int32(code.Attempts),
+		ExpiresAt:    code.ExpiresAt,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	*code = *mapToDomainVerificationCode(row)
+	return nil
+}
+
+func (r *verificationCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.VerificationCode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetVerificationCodeByID(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainVerificationCode(row), nil
+}
+
+func (r *verificationCodeRepository) GetLatestByAuthMethodID(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose) (*models.VerificationCode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetLatestVerificationCodeByAuthMethodID(ctx, sqlc.GetLatestVerificationCodeByAuthMethodIDParams{
+		AuthMethodID: authMethodID,
+		Purpose:      string(purpose),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainVerificationCode(row), nil
+}
+
+func (r *verificationCodeRepository) GetByCodeHash(ctx context.Context, purpose domain.CodePurpose, codeHash string) (*models.VerificationCode, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetVerificationCodeByHash(ctx, sqlc.GetVerificationCodeByHashParams{
+		Purpose:  string(purpose),
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	return mapToDomainVerificationCode(row), nil
+}
+
+func (r *verificationCodeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	attempts, err := q.IncrementVerificationCodeAttempts(ctx, id)
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+
+	return int(attempts), nil
+}
+
+func (r *verificationCodeRepository) MarkConsumed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.MarkVerificationCodeConsumed(ctx, sqlc.MarkVerificationCodeConsumedParams{
+		ID:         id,
+		ConsumedAt: &at,
+	}))
+}
+
+func (r *verificationCodeRepository) InvalidateActive(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	err := q.InvalidateActiveVerificationCodes(ctx, sqlc.InvalidateActiveVerificationCodesParams{
+		AuthMethodID: authMethodID,
+		Purpose:      string(purpose),
+		ExpiresAt:    at,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	return nil
+}
+
+func (r *verificationCodeRepository) CountCreatedSince(ctx context.Context, authMethodID uuid.UUID, purpose domain.CodePurpose, since time.Time) (int, error) {
+	q := getQueries(ctx, r.pool)
+
+	count, err := q.CountVerificationCodesCreatedSince(ctx, sqlc.CountVerificationCodesCreatedSinceParams{
+		AuthMethodID: authMethodID,
+		Purpose:      string(purpose),
+		CreatedAt:    since,
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return int(count), nil
+}
+
+func (r *verificationCodeRepository) DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := getQueries(ctx, r.pool)
+
+	deleted, err := q.DeleteStaleVerificationCodes(ctx, sqlc.DeleteStaleVerificationCodesParams{
+		Before:    before,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return 0, mapPostgresError(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/repositories"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/postgres/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookEndpointRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookEndpointRepository(pool *pgxpool.Pool) repositories.WebhookEndpointRepository {
+	return &webhookEndpointRepository{
+		pool: pool,
+	}
+}
+
+func (r *webhookEndpointRepository) Create(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateWebhookEndpoint(ctx, sqlc.CreateWebhookEndpointParams{
+		ID:          endpoint.ID,
+		Url:         endpoint.URL,
+		Description: endpoint.Description,
+		EventTypes:  webhookEventStrings(endpoint.Events),
+		Secret:      endpoint.Secret,
+		CreatedBy:   endpoint.CreatedBy,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	endpoint.IsActive = row.IsActive
+	endpoint.CreatedAt = row.CreatedAt
+	endpoint.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookEndpointRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetWebhookEndpoint(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainWebhookEndpoint(row), nil
+}
+
+func (r *webhookEndpointRepository) List(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListWebhookEndpoints(ctx)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainWebhookEndpoints(rows), nil
+}
+
+func (r *webhookEndpointRepository) ListSubscribed(ctx context.Context, event domain.WebhookEvent) ([]*models.WebhookEndpoint, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListActiveWebhookEndpointsByEventType(ctx, string(event))
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainWebhookEndpoints(rows), nil
+}
+
+func (r *webhookEndpointRepository) Update(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.UpdateWebhookEndpoint(ctx, sqlc.UpdateWebhookEndpointParams{
+		ID:          endpoint.ID,
+		Url:         endpoint.URL,
+		Description: endpoint.Description,
+		EventTypes:  webhookEventStrings(endpoint.Events),
+		IsActive:    endpoint.IsActive,
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	endpoint.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookEndpointRepository) UpdateSecret(ctx context.Context, id uuid.UUID, secret []byte) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.UpdateWebhookEndpointSecret(ctx, sqlc.UpdateWebhookEndpointSecretParams{
+		ID:     id,
+		Secret: secret,
+	}))
+}
+
+func (r *webhookEndpointRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.DeleteWebhookEndpoint(ctx, id))
+}
+
+func webhookEventStrings(events []domain.WebhookEvent) []string {
+	values := make([]string, 0, len(events))
+	for _, event := range events {
+		values = append(values, string(event))
+	}
+	return values
+}
+
+type webhookDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(pool *pgxpool.Pool) repositories.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		pool: pool,
+	}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+		ID:            delivery.ID,
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     string(delivery.Event),
+		Payload:       delivery.Payload,
+		NextAttemptAt: delivery.NextAttemptAt,
+	})
+	// The endpoint already has a delivery of the event.
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return mapPostgresError(err)
+	}
+
+	delivery.Status = domain.WebhookDeliveryStatus(row.Status)
+	delivery.CreatedAt = row.CreatedAt
+	delivery.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	q := getQueries(ctx, r.pool)
+
+	row, err := q.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainWebhookDelivery(row), nil
+}
+
+func (r *webhookDeliveryRepository) ListByEndpointID(ctx context.Context, endpointID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListWebhookDeliveriesByEndpointID(ctx, sqlc.ListWebhookDeliveriesByEndpointIDParams{
+		EndpointID: endpointID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainWebhookDeliveries(rows), nil
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ClaimDueWebhookDeliveries(ctx, sqlc.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: leaseUntil,
+		Now:        now,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+	return mapToDomainWebhookDeliveries(rows), nil
+}
+
+func (r *webhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error {
+	q := getQueries(ctx, r.pool)
+
+	err := expectAffected(q.RecordWebhookDeliveryAttempt(ctx, sqlc.RecordWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         string(delivery.Status),
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: int32Pointer(attempt.StatusCode),
+		LastError:      attempt.Error,
+		DeliveredAt:    delivery.DeliveredAt,
+	}))
+	if err != nil {
+		return err
+	}
+
+	err = q.CreateWebhookDeliveryAttempt(ctx, sqlc.CreateWebhookDeliveryAttemptParams{
+		ID:         attempt.ID,
+		DeliveryID: delivery.ID,
+		StatusCode: int32Pointer(attempt.StatusCode),
+		Error:      attempt.Error,
+		DurationMs: int32(attempt.Duration.Milliseconds()),
+	})
+	if err != nil {
+		return mapPostgresError(err)
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) Requeue(ctx context.Context, id uuid.UUID, at time.Time) error {
+	q := getQueries(ctx, r.pool)
+
+	return expectAffected(q.RequeueWebhookDelivery(ctx, sqlc.RequeueWebhookDeliveryParams{
+		ID:            id,
+		NextAttemptAt: &at,
+	}))
+}
+
+func (r *webhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*models.WebhookDeliveryAttempt, error) {
+	q := getQueries(ctx, r.pool)
+
+	rows, err := q.ListWebhookDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, mapPostgresError(err)
+	}
+
+	attempts := make([]*models.WebhookDeliveryAttempt, 0, len(rows))
+	for _, row := range rows {
+		attempts = append(attempts, mapToDomainWebhookDeliveryAttempt(row))
+	}
+	return attempts, nil
+}
+
+func int32Pointer(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+	converted := int32(*value)
+	return &converted
+}
@@ -0,0 +1,118 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// GenerateNumericCode returns a uniformly distributed decimal code of the given length.
+func GenerateNumericCode(length int) (string, error) {
+	digits := make([]byte, length)
+	for i := range digits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + n.Int64())
+	}
+	return string(digits), nil
+}
+
+// recoveryCodeAlphabet leaves out characters that are easily confused when a
+// code is copied by hand, such as 0/o and 1/l.
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateRecoveryCode returns a random code of the form xxxxx-xxxxx.
+func GenerateRecoveryCode() (string, error) {
+	code := make([]byte, 0, 11)
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := range 10 {
+		if i == 5 {
+			code = append(code, '-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, recoveryCodeAlphabet[n.Int64()])
+	}
+	return string(code), nil
+}
+
+// NormalizeRecoveryCode lowercases a recovery code and drops separators, so
+// the stored hash does not depend on how the user typed it.
+func NormalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, strings.TrimSpace(code))
+}
+
+// userCodeAlphabet is the vowel-free alphabet RFC 8628 section 6.1 suggests
+// for user codes: it avoids spelling words and survives being read off a TV
+// screen and typed on a phone.
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// GenerateUserCode returns a random device flow user code of the form
+// XXXX-XXXX for the given even length.
+func GenerateUserCode(length int) (string, error) {
+	code := make([]byte, 0, length+1)
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := range length {
+		if i == length/2 {
+			code = append(code, '-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, userCodeAlphabet[n.Int64()])
+	}
+	return string(code), nil
+}
+
+// NormalizeUserCode uppercases a user code and drops separators, so the
+// stored hash does not depend on how the user typed it.
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ':
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, strings.TrimSpace(code))
+}
+
+// GenerateOpaqueToken returns a URL-safe random token with the given entropy in bytes.
+func GenerateOpaqueToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 digest persisted in place of plaintext codes and tokens.
+func HashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// CompareTokenHash reports whether value hashes to the stored digest in constant time.
+func CompareTokenHash(value, hash string) bool {
+	return ConstantTimeEqual(HashToken(value), hash)
+}
+
+// ConstantTimeEqual compares two secrets without leaking their common prefix length.
+func ConstantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
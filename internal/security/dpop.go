@@ -0,0 +1,13 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// VerifyAccessTokenHash reports whether ath, the access token hash of a DPoP
+// proof, is the unpadded base64url SHA-256 digest of token.
+func VerifyAccessTokenHash(token, ath string) bool {
+	sum := sha256.Sum256([]byte(token))
+	return ConstantTimeEqual(base64.RawURLEncoding.EncodeToString(sum[:]), ath)
+}
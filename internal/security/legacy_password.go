@@ -0,0 +1,255 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+// legacyHash checks hashes made by another system.
+type legacyHash struct {
+	// valid reports whether encoded is well formed, without hashing.
+	valid  func(encoded string) bool
+	verify func(password, encoded string) (bool, error)
+}
+
+// legacyHashes verify hashes made by other systems, by their modular crypt
+// identifier, so imported passwords keep working until the next login
+// rehashes them with Argon2id.
+var legacyHashes = map[string]legacyHash{
+	"2a":              {validBcrypt, verifyBcrypt},
+	"2b":              {validBcrypt, verifyBcrypt},
+	"2y":              {validBcrypt, verifyBcrypt},
+	"pbkdf2":          {validPBKDF2, verifyPBKDF2},
+	"pbkdf2-sha256":   {validPBKDF2, verifyPBKDF2},
+	"pbkdf2-sha512":   {validPBKDF2, verifyPBKDF2},
+	"firebase-scrypt": {validFirebaseScrypt, verifyFirebaseScrypt},
+}
+
+// hashIdentifier returns the identifier of a $id$… encoded hash.
+func hashIdentifier(encoded string) string {
+	parts := strings.SplitN(encoded, "$", 3)
+	if len(parts) != 3 || parts[0] != "" {
+		return ""
+	}
+	return parts[1]
+}
+
+// decodeHashBase64 decodes the base64 of PHC strings, padded or not, also in
+// the adapted alphabet of passlib that uses . for +.
+func decodeHashBase64(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.ReplaceAll(strings.TrimRight(s, "="), ".", "+"))
+}
+
+func validBcrypt(encoded string) bool {
+	_, err := bcrypt.Cost([]byte(encoded))
+	return err == nil
+}
+
+func verifyBcrypt(password, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("invalid bcrypt hash: %w", err)
+	}
+	return true, nil
+}
+
+// maxPBKDF2Iterations keeps corrupt hashes from hashing for minutes.
+const maxPBKDF2Iterations = 10_000_000
+
+type pbkdf2Hash struct {
+	digest     func() hash.Hash
+	iterations int
+	salt, key  []byte
+}
+
+// decodePBKDF2 parses $pbkdf2-<digest>$i=<iterations>[,l=<length>]$salt$key,
+// the PHC form of Auth0 custom password hashes, or passlib's
+// $pbkdf2-<digest>$<iterations>$salt$key. A bare pbkdf2 identifier stands
+// for SHA-1.
+func decodePBKDF2(encoded string) (*pbkdf2Hash, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 {
+		return nil, errInvalidPasswordHash
+	}
+
+	decoded := &pbkdf2Hash{}
+	switch parts[1] {
+	case "pbkdf2":
+		decoded.digest = sha1.New
+	case "pbkdf2-sha256":
+		decoded.digest = sha256.New
+	case "pbkdf2-sha512":
+		decoded.digest = sha512.New
+	default:
+		return nil, errInvalidPasswordHash
+	}
+
+	length := 0
+	for _, param := range strings.Split(parts[2], ",") {
+		name, value, found := strings.Cut(param, "=")
+		if !found {
+			name, value = "i", param
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, errInvalidPasswordHash
+		}
+		switch name {
+		case "i":
+			decoded.iterations = n
+		case "l":
+			length = n
+		default:
+			return nil, errInvalidPasswordHash
+		}
+	}
+	if decoded.iterations == 0 || decoded.iterations > maxPBKDF2Iterations {
+		return nil, errInvalidPasswordHash
+	}
+
+	var err error
+	if decoded.salt, err = decodeHashBase64(parts[3]); err != nil {
+		return nil, errInvalidPasswordHash
+	}
+	if decoded.key, err = decodeHashBase64(parts[4]); err != nil || len(decoded.key) == 0 {
+		return nil, errInvalidPasswordHash
+	}
+	if length != 0 && length != len(decoded.key) {
+		return nil, errInvalidPasswordHash
+	}
+	return decoded, nil
+}
+
+func validPBKDF2(encoded string) bool {
+	_, err := decodePBKDF2(encoded)
+	return err == nil
+}
+
+func verifyPBKDF2(password, encoded string) (bool, error) {
+	decoded, err := decodePBKDF2(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate, err := pbkdf2.Key(decoded.digest, password, decoded.salt, decoded.iterations, len(decoded.key))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(candidate, decoded.key) == 1, nil
+}
+
+// FirebaseScryptParams is the password hash configuration of a Firebase
+// project, shown in its console as base64 values.
+type FirebaseScryptParams struct {
+	SignerKey     []byte
+	SaltSeparator []byte
+	Rounds        int
+	MemoryCost    int
+}
+
+// EncodeFirebaseScrypt encodes a password hash and salt exported from
+// Firebase with the project's parameters, as
+// $firebase-scrypt$r=<rounds>,m=<mem_cost>,s=<separator>,k=<signer key>$salt$hash.
+func EncodeFirebaseScrypt(params FirebaseScryptParams, salt, key []byte) (string, error) {
+	if params.Rounds < 1 || params.Rounds > 8 || params.MemoryCost < 1 || params.MemoryCost > 14 || len(params.SignerKey) == 0 {
+		return "", fmt.Errorf("invalid firebase scrypt parameters r=%d m=%d", params.Rounds, params.MemoryCost)
+	}
+	encode := base64.RawStdEncoding.EncodeToString
+	return fmt.Sprintf("$firebase-scrypt$r=%d,m=%d,s=%s,k=%s$%s$%s",
+		params.Rounds, params.MemoryCost, encode(params.SaltSeparator), encode(params.SignerKey),
+		encode(salt), encode(key),
+	), nil
+}
+
+func decodeFirebaseScrypt(encoded string) (FirebaseScryptParams, []byte, []byte, error) {
+	var params FirebaseScryptParams
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != "firebase-scrypt" {
+		return params, nil, nil, errInvalidPasswordHash
+	}
+
+	var separator, signerKey string
+	for _, param := range strings.Split(parts[2], ",") {
+		name, value, _ := strings.Cut(param, "=")
+		var err error
+		switch name {
+		case "r":
+			params.Rounds, err = strconv.Atoi(value)
+		case "m":
+			params.MemoryCost, err = strconv.Atoi(value)
+		case "s":
+			separator = value
+		case "k":
+			signerKey = value
+		default:
+			err = errInvalidPasswordHash
+		}
+		if err != nil {
+			return params, nil, nil, errInvalidPasswordHash
+		}
+	}
+	if params.Rounds < 1 || params.Rounds > 8 || params.MemoryCost < 1 || params.MemoryCost > 14 {
+		return params, nil, nil, errInvalidPasswordHash
+	}
+
+	var err error
+	if params.SaltSeparator, err = decodeHashBase64(separator); err != nil {
+		return params, nil, nil, errInvalidPasswordHash
+	}
+	if params.SignerKey, err = decodeHashBase64(signerKey); err != nil || len(params.SignerKey) == 0 {
+		return params, nil, nil, errInvalidPasswordHash
+	}
+	salt, err := decodeHashBase64(parts[3])
+	if err != nil {
+		return params, nil, nil, errInvalidPasswordHash
+	}
+	key, err := decodeHashBase64(parts[4])
+	if err != nil || len(key) != len(params.SignerKey) {
+		return params, nil, nil, errInvalidPasswordHash
+	}
+	return params, salt, key, nil
+}
+
+func validFirebaseScrypt(encoded string) bool {
+	_, _, _, err := decodeFirebaseScrypt(encoded)
+	return err == nil
+}
+
+// verifyFirebaseScrypt follows Firebase's modified scrypt: the signer key
+// encrypted by AES-256-CTR, with a zero IV, under the scrypt key of the
+// password and the salt followed by the separator.
+func verifyFirebaseScrypt(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeFirebaseScrypt(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	derived, err := scrypt.Key([]byte(password), append(salt, params.SaltSeparator...), 1<<params.MemoryCost, params.Rounds, 1, 32)
+	if err != nil {
+		return false, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return false, err
+	}
+	candidate := make([]byte, len(params.SignerKey))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(candidate, params.SignerKey)
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
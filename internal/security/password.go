@@ -0,0 +1,176 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2SaltBytes = 16
+	argon2KeyBytes  = 32
+)
+
+var (
+	errInvalidPasswordHash = errors.New("invalid argon2id hash encoding")
+	errMissingPepper       = errors.New("argon2id hash is peppered but no peppers are configured")
+	errUnsupportedHash     = errors.New("unsupported password hash algorithm")
+)
+
+// Argon2Params tunes the Argon2id cost. Memory is expressed in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// DefaultArgon2Params follows the second recommended option of RFC 9106.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+}
+
+// Argon2Hasher hashes passwords with Argon2id into the PHC string format, so
+// hashes produced under older parameters remain verifiable until rehashed.
+// With peppers, the password is first keyed with the current pepper and its
+// version is stored as the keyid parameter, e.g. m=65536,t=3,p=4,keyid=2.
+// Hashes without a keyid were made unpeppered and verify as such.
+type Argon2Hasher struct {
+	params  Argon2Params
+	peppers *Peppers
+}
+
+// NewArgon2Hasher returns a hasher with the given cost. peppers may be nil
+// to hash passwords unpeppered.
+func NewArgon2Hasher(params Argon2Params, peppers *Peppers) (*Argon2Hasher, error) {
+	if params.Memory < 8*uint32(params.Parallelism) || params.Iterations == 0 || params.Parallelism == 0 {
+		return nil, fmt.Errorf("invalid argon2id parameters m=%d t=%d p=%d", params.Memory, params.Iterations, params.Parallelism)
+	}
+	return &Argon2Hasher{params: params, peppers: peppers}, nil
+}
+
+func (h *Argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	input, params := []byte(password), fmt.Sprintf("m=%d,t=%d,p=%d", h.params.Memory, h.params.Iterations, h.params.Parallelism)
+	if h.peppers != nil {
+		version := h.peppers.Current()
+		peppered, err := h.peppers.apply(version, password)
+		if err != nil {
+			return "", err
+		}
+		input = peppered
+		params += ",keyid=" + strconv.Itoa(version)
+	}
+
+	key := argon2.IDKey(input, salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, argon2KeyBytes)
+
+	return fmt.Sprintf("$argon2id$v=%d$%s$%s$%s",
+		argon2.Version,
+		params,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify also accepts the legacy hashes of legacyHashes, which NeedsRehash
+// then reports.
+func (h *Argon2Hasher) Verify(password, encoded string) (bool, error) {
+	if id := hashIdentifier(encoded); id != "argon2id" {
+		legacy, ok := legacyHashes[id]
+		if !ok {
+			return false, errUnsupportedHash
+		}
+		return legacy.verify(password, encoded)
+	}
+
+	params, pepper, salt, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	input := []byte(password)
+	if pepper != 0 {
+		if h.peppers == nil {
+			return false, errMissingPepper
+		}
+		if input, err = h.peppers.apply(pepper, password); err != nil {
+			return false, err
+		}
+	}
+
+	candidate := argon2.IDKey(input, salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
+
+// Supports reports whether encoded is a well formed Argon2id or legacy hash.
+func (h *Argon2Hasher) Supports(encoded string) bool {
+	if id := hashIdentifier(encoded); id != "argon2id" {
+		legacy, ok := legacyHashes[id]
+		return ok && legacy.valid(encoded)
+	}
+	_, _, _, _, err := decodeArgon2Hash(encoded)
+	return err == nil
+}
+
+// NeedsRehash reports whether encoded was made with another algorithm, other
+// Argon2id parameters or another pepper version than the current ones.
+func (h *Argon2Hasher) NeedsRehash(encoded string) bool {
+	params, pepper, _, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return true
+	}
+	current := 0
+	if h.peppers != nil {
+		current = h.peppers.Current()
+	}
+	return params != h.params || len(key) != argon2KeyBytes || pepper != current
+}
+
+// decodeArgon2Hash parses a PHC string into its cost, pepper version (zero
+// when unpeppered), salt and key.
+func decodeArgon2Hash(encoded string) (Argon2Params, int, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, 0, nil, nil, errInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, 0, nil, nil, errInvalidPasswordHash
+	}
+	cost, keyID, peppered := strings.Cut(parts[3], ",keyid=")
+	if _, err := fmt.Sscanf(cost, "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, 0, nil, nil, errInvalidPasswordHash
+	}
+	var pepper int
+	if peppered {
+		var err error
+		if pepper, err = strconv.Atoi(keyID); err != nil || pepper <= 0 {
+			return params, 0, nil, nil, errInvalidPasswordHash
+		}
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, 0, nil, nil, errInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, 0, nil, nil, errInvalidPasswordHash
+	}
+
+	return params, pepper, salt, key, nil
+}
@@ -0,0 +1,76 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+// minPepperBytes is the shortest pepper accepted, the output size of the
+// HMAC it keys.
+const minPepperBytes = 32
+
+// Peppers holds the versions of the secret key mixed into password hashes
+// with HMAC-SHA256 before Argon2id. New hashes take the current version;
+// older ones stay available so that hashes made with them keep verifying
+// after a rotation.
+type Peppers struct {
+	mu      sync.RWMutex
+	current int
+	keys    map[int][]byte
+}
+
+func NewPeppers(current int, keys map[int][]byte) (*Peppers, error) {
+	peppers := &Peppers{}
+	if err := peppers.Replace(current, keys); err != nil {
+		return nil, err
+	}
+	return peppers, nil
+}
+
+// Replace swaps every version for keys, such as when the peppers are
+// re-fetched from a secrets backend. Invalid keys leave the peppers as
+// they were.
+func (p *Peppers) Replace(current int, keys map[int][]byte) error {
+	for version, key := range keys {
+		if version <= 0 {
+			return fmt.Errorf("invalid pepper version %d", version)
+		}
+		if len(key) < minPepperBytes {
+			return fmt.Errorf("pepper version %d must be at least %d bytes", version, minPepperBytes)
+		}
+	}
+	if _, ok := keys[current]; !ok {
+		return fmt.Errorf("current pepper version %d is not available", current)
+	}
+
+	copied := make(map[int][]byte, len(keys))
+	for version, key := range keys {
+		copied[version] = append([]byte(nil), key...)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current, p.keys = current, copied
+	return nil
+}
+
+// Current returns the version new hashes are peppered with.
+func (p *Peppers) Current() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.current
+}
+
+// apply returns the HMAC of password keyed with the pepper of version.
+func (p *Peppers) apply(version int, password string) ([]byte, error) {
+	p.mu.RLock()
+	key, ok := p.keys[version]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pepper version %d is not available", version)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(password))
+	return mac.Sum(nil), nil
+}
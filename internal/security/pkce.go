@@ -0,0 +1,54 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// PKCE code verifiers are 43 to 128 characters long (RFC 7636 section 4.1).
+const (
+	minCodeVerifierLength = 43
+	maxCodeVerifierLength = 128
+)
+
+// S256 challenges are the unpadded base64url SHA-256 digest of the verifier.
+var s256ChallengeLength = base64.RawURLEncoding.EncodedLen(sha256.Size)
+
+// ValidCodeVerifier checks the syntax of a PKCE code verifier.
+func ValidCodeVerifier(verifier string) bool {
+	if len(verifier) < minCodeVerifierLength || len(verifier) > maxCodeVerifierLength {
+		return false
+	}
+	for _, c := range verifier {
+		if !isUnreserved(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidS256Challenge checks that challenge has the shape of an S256 code
+// challenge.
+func ValidS256Challenge(challenge string) bool {
+	if len(challenge) != s256ChallengeLength {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil
+}
+
+// VerifyS256Challenge reports whether verifier is the one challenge was
+// derived from with the S256 method.
+func VerifyS256Challenge(verifier, challenge string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	return ConstantTimeEqual(base64.RawURLEncoding.EncodeToString(sum[:]), challenge)
+}
+
+// isUnreserved matches the unreserved characters of RFC 3986.
+func isUnreserved(c rune) bool {
+	switch {
+	case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		return true
+	}
+	return c == '-' || c == '.' || c == '_' || c == '~'
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/pkg/authpb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTokenClaims(claims *models.AccessTokenClaims) *authpb.TokenClaims {
+	response := &authpb.TokenClaims{
+		TokenId:          claims.TokenID,
+		ClientId:         claims.ClientID,
+		RoleCode:         string(claims.RoleCode),
+		StatusCode:       string(claims.StatusCode),
+		Scope:            string(claims.Scope),
+		Permissions:      claims.Permissions,
+		OrganizationRole: string(claims.OrganizationRole),
+		KeyThumbprint:    claims.KeyThumbprint,
+		IssuedAt:         timestamppb.New(claims.IssuedAt),
+	}
+	if claims.HasAccount() {
+		response.AccountId = claims.AccountID.String()
+	}
+	if claims.SessionID != uuid.Nil {
+		response.SessionId = claims.SessionID.String()
+	}
+	if claims.OrganizationID != uuid.Nil {
+		response.OrganizationId = claims.OrganizationID.String()
+	}
+	if claims.APIKeyID != uuid.Nil {
+		response.ApiKeyId = claims.APIKeyID.String()
+	}
+	if !claims.ExpiresAt.IsZero() {
+		response.ExpiresAt = timestamppb.New(claims.ExpiresAt)
+	}
+	if claims.Impersonated() {
+		response.ImpersonatorId = claims.Actor.Subject
+	}
+	return response
+}
+
+func newAccount(account *models.Account) *authpb.Account {
+	return &authpb.Account{
+		Id:         account.ID.String(),
+		RoleCode:   string(account.RoleCode),
+		StatusCode: string(account.StatusCode),
+		CreatedAt:  timestamppb.New(account.CreatedAt),
+	}
+}
@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorMapping pairs domain errors with the status code they are served
+// with; their messages are public.
+var errorMapping = map[error]codes.Code{
+	domain.ErrInvalidAccessToken: codes.Unauthenticated,
+	domain.ErrAccountNotFound:    codes.NotFound,
+	domain.ErrTooManyTokens:      codes.InvalidArgument,
+}
+
+// statusError converts err into a gRPC status, hiding unexpected errors
+// behind codes.Internal.
+func statusError(ctx context.Context, err error) error {
+	for target, code := range errorMapping {
+		if errors.Is(err, target) {
+			return status.Error(code, target.Error())
+		}
+	}
+
+	slog.ErrorContext(ctx, "grpc internal error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/logging"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ClientAuthenticator admits calls carrying a client token, obtained with
+// the client credentials grant, as "authorization: Bearer <token>".
+type ClientAuthenticator struct {
+	validator *application.TokenValidator
+}
+
+func NewClientAuthenticator(validator *application.TokenValidator) *ClientAuthenticator {
+	return &ClientAuthenticator{validator: validator}
+}
+
+// Unary rejects calls without a valid client token. Account tokens and
+// DPoP-bound tokens are refused: the API acts for services, and a proof
+// cannot be checked without an HTTP request to bind it to.
+func (a *ClientAuthenticator) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "missing client token")
+	}
+	scheme, raw, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || raw == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing client token")
+	}
+
+	claims, err := a.validator.Validate(ctx, raw)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	if claims.HasAccount() || claims.KeyThumbprint != "" {
+		return nil, statusError(ctx, domain.ErrInvalidAccessToken)
+	}
+
+	logging.Add(ctx, slog.String("client_id", claims.ClientID))
+	return handler(ctx, req)
+}
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// logCalls gives every call a request id, taken from its x-request-id
+// metadata when the caller sent a well-formed one, logged with every record
+// of the call, and logs the call once it has been handled.
+func logCalls(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := uuid.NewString()
+	if values := md.Get("x-request-id"); len(values) == 1 && requestIDPattern.MatchString(values[0]) {
+		id = values[0]
+	}
+	ctx = logging.NewContext(ctx, slog.String("request_id", id))
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	slog.LogAttrs(ctx, level, "grpc call",
+		slog.String("method", info.FullMethod),
+		slog.String("status", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return resp, err
+}
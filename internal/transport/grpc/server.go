@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/pkg/authpb"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthServer implements the internal AuthService API.
+type AuthServer struct {
+	authpb.UnimplementedAuthServiceServer
+
+	validator   *application.TokenValidator
+	accounts    *application.AccountService
+	permissions *application.PermissionService
+}
+
+func NewAuthServer(validator *application.TokenValidator, accounts *application.AccountService, permissions *application.PermissionService) *AuthServer {
+	return &AuthServer{validator: validator, accounts: accounts, permissions: permissions}
+}
+
+// NewServer returns a gRPC server exposing auth to authenticated clients,
+// tracing and logging each call.
+func NewServer(auth *AuthServer, clients *ClientAuthenticator) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logCalls, clients.Unary),
+	)
+	authpb.RegisterAuthServiceServer(server, auth)
+	return server
+}
+
+func (s *AuthServer) ValidateToken(ctx context.Context, req *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
+	claims, err := s.validator.Validate(ctx, req.GetAccessToken())
+	if errors.Is(err, domain.ErrInvalidAccessToken) {
+		return &authpb.ValidateTokenResponse{}, nil
+	}
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &authpb.ValidateTokenResponse{Active: true, Claims: newTokenClaims(claims)}, nil
+}
+
+func (s *AuthServer) ValidateTokens(ctx context.Context, req *authpb.ValidateTokensRequest) (*authpb.ValidateTokensResponse, error) {
+	claims, err := s.validator.ValidateBatch(ctx, req.GetAccessTokens())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	results := make([]*authpb.ValidateTokenResponse, 0, len(claims))
+	for _, tokenClaims := range claims {
+		if tokenClaims == nil {
+			results = append(results, &authpb.ValidateTokenResponse{})
+			continue
+		}
+		results = append(results, &authpb.ValidateTokenResponse{Active: true, Claims: newTokenClaims(tokenClaims)})
+	}
+	return &authpb.ValidateTokensResponse{Results: results}, nil
+}
+
+func (s *AuthServer) GetAccount(ctx context.Context, req *authpb.GetAccountRequest) (*authpb.GetAccountResponse, error) {
+	accountID, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	account, err := s.accounts.Get(ctx, accountID)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &authpb.GetAccountResponse{Account: newAccount(account)}, nil
+}
+
+func (s *AuthServer) CheckPermission(ctx context.Context, req *authpb.CheckPermissionRequest) (*authpb.CheckPermissionResponse, error) {
+	decision, err := s.permissions.Check(ctx, req.GetAccessToken(), application.PermissionRequirement{
+		Role:        domain.Role(req.GetRole()),
+		Scopes:      req.GetScopes(),
+		Permissions: req.GetPermissions(),
+	})
+	if errors.Is(err, domain.ErrInvalidAccessToken) {
+		return &authpb.CheckPermissionResponse{}, nil
+	}
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &authpb.CheckPermissionResponse{
+		Allowed: decision.Allowed,
+		Claims:  newTokenClaims(decision.Claims),
+	}, nil
+}
@@ -0,0 +1,1286 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/google/uuid"
+)
+
+// exportFlushInterval is how many accounts are written between flushes of an
+// export, so clients receive it as it is read.
+const exportFlushInterval = 100
+
+// accountExportColumns head CSV exports, which have a row per auth method and
+// one with empty method columns for accounts without any.
+var accountExportColumns = []string{
+	"cursor", "account_id", "role_code", "status_code", "created_at",
+	"auth_method_id", "provider", "provider_id", "is_verified", "last_login_at",
+}
+
+// AdminHandler serves the administration endpoints, open to ADMIN accounts
+// only.
+type AdminHandler struct {
+	accounts       *application.AccountService
+	search         *application.AccountService
+	bans           *application.BanService
+	impersonations *application.ImpersonationService
+	roles          *application.RoleService
+	orgs           *application.OrganizationService
+	audit          *application.AuditLog
+	webhooks       *application.WebhookService
+	sessions       *application.SessionService
+	verification   *application.AuthService
+	consents       *application.ConsentService
+	keys           ports.PlatformKeyManager
+	config         ports.ConfigReloader
+	auth           *Authenticator
+}
+
+func NewAdminHandler(accounts, search *application.AccountService, bans *application.BanService, impersonations *application.ImpersonationService, roles *application.RoleService, orgs *application.OrganizationService, audit *application.AuditLog, webhooks *application.WebhookService, sessions *application.SessionService, verification *application.AuthService, consents *application.ConsentService, keys ports.PlatformKeyManager, config ports.ConfigReloader, auth *Authenticator) *AdminHandler {
+	return &AdminHandler{accounts: accounts, search: search, bans: bans, impersonations: impersonations, roles: roles, orgs: orgs, audit: audit, webhooks: webhooks, sessions: sessions, verification: verification, consents: consents, keys: keys, config: config, auth: auth}
+}
+
+func (h *AdminHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/admin/accounts", h.auth.RequireAdmin(h.SearchAccounts))
+	mux.HandleFunc("GET /v1/admin/accounts/export", h.auth.RequireAdmin(h.ExportAccounts))
+	mux.HandleFunc("GET /v1/admin/accounts/{id}", h.auth.RequireAdmin(h.GetAccount))
+	mux.HandleFunc("POST /v1/admin/accounts/{id}/sessions/revoke", h.auth.RequireAdmin(h.RevokeSessions))
+	mux.HandleFunc("POST /v1/admin/accounts/{id}/verification/resend", h.auth.RequireAdmin(h.ResendVerification))
+	mux.HandleFunc("GET /v1/admin/accounts/{id}/bans", h.auth.RequireAdmin(h.ListBans))
+	mux.HandleFunc("POST /v1/admin/accounts/{id}/ban", h.auth.RequireAdmin(h.BanAccount))
+	mux.HandleFunc("POST /v1/admin/accounts/{id}/unban", h.auth.RequireAdmin(h.UnbanAccount))
+	mux.HandleFunc("POST /v1/admin/accounts/{id}/erase", h.auth.RequireAdmin(h.EraseAccount))
+	mux.HandleFunc("GET /v1/admin/accounts/{id}/consents", h.auth.RequireAdmin(h.ListConsents))
+	mux.HandleFunc("GET /v1/admin/accounts/{id}/impersonations", h.auth.RequireAdmin(h.ListImpersonations))
+	mux.HandleFunc("POST /v1/admin/accounts/{id}/impersonate", h.auth.RequireAdmin(h.Impersonate))
+	mux.HandleFunc("POST /v1/admin/impersonations/{id}/end", h.auth.RequireAdmin(h.EndImpersonation))
+	mux.HandleFunc("PUT /v1/admin/accounts/{id}/role", h.auth.RequireAdmin(h.AssignRole))
+	mux.HandleFunc("GET /v1/admin/accounts/{id}/role-changes", h.auth.RequireAdmin(h.ListRoleChanges))
+	mux.HandleFunc("GET /v1/admin/audit-events", h.auth.RequireAdmin(h.SearchAuditEvents))
+	mux.HandleFunc("GET /v1/admin/legal-documents", h.auth.RequireAdmin(h.ListLegalDocuments))
+	mux.HandleFunc("POST /v1/admin/legal-documents", h.auth.RequireAdmin(h.PublishLegalDocument))
+	mux.HandleFunc("GET /v1/admin/roles", h.auth.RequireAdmin(h.ListRoles))
+	mux.HandleFunc("POST /v1/admin/roles", h.auth.RequireAdmin(h.CreateRole))
+	mux.HandleFunc("GET /v1/admin/roles/{code}", h.auth.RequireAdmin(h.GetRole))
+	mux.HandleFunc("PUT /v1/admin/roles/{code}", h.auth.RequireAdmin(h.UpdateRole))
+	mux.HandleFunc("DELETE /v1/admin/roles/{code}", h.auth.RequireAdmin(h.DeleteRole))
+	mux.HandleFunc("GET /v1/admin/signing-keys", h.auth.RequireAdmin(h.ListPlatformSigningKeys))
+	mux.HandleFunc("POST /v1/admin/signing-keys/rotate", h.auth.RequireAdmin(h.RotatePlatformSigningKey))
+	mux.HandleFunc("GET /v1/admin/organizations", h.auth.RequireAdmin(h.ListOrganizations))
+	mux.HandleFunc("POST /v1/admin/organizations", h.auth.RequireAdmin(h.CreateOrganization))
+	mux.HandleFunc("GET /v1/admin/organizations/{id}", h.auth.RequireAdmin(h.GetOrganization))
+	mux.HandleFunc("PUT /v1/admin/organizations/{id}", h.auth.RequireAdmin(h.RenameOrganization))
+	mux.HandleFunc("DELETE /v1/admin/organizations/{id}", h.auth.RequireAdmin(h.DeleteOrganization))
+	mux.HandleFunc("GET /v1/admin/organizations/{id}/members", h.auth.RequireAdmin(h.ListMembers))
+	mux.HandleFunc("PUT /v1/admin/organizations/{id}/members/{account_id}", h.auth.RequireAdmin(h.SetMember))
+	mux.HandleFunc("DELETE /v1/admin/organizations/{id}/members/{account_id}", h.auth.RequireAdmin(h.RemoveMember))
+	mux.HandleFunc("GET /v1/admin/organizations/{id}/signing-keys", h.auth.RequireAdmin(h.ListSigningKeys))
+	mux.HandleFunc("POST /v1/admin/organizations/{id}/signing-keys/rotate", h.auth.RequireAdmin(h.RotateSigningKey))
+	mux.HandleFunc("DELETE /v1/admin/organizations/{id}/signing-keys", h.auth.RequireAdmin(h.RevokeSigningKeys))
+	mux.HandleFunc("GET /v1/admin/organizations/{id}/branding", h.auth.RequireAdmin(h.GetBranding))
+	mux.HandleFunc("PUT /v1/admin/organizations/{id}/branding", h.auth.RequireAdmin(h.SetBranding))
+	mux.HandleFunc("DELETE /v1/admin/organizations/{id}/branding", h.auth.RequireAdmin(h.DeleteBranding))
+	mux.HandleFunc("GET /v1/admin/webhooks", h.auth.RequireAdmin(h.ListWebhooks))
+	mux.HandleFunc("POST /v1/admin/webhooks", h.auth.RequireAdmin(h.CreateWebhook))
+	mux.HandleFunc("GET /v1/admin/webhooks/{id}", h.auth.RequireAdmin(h.GetWebhook))
+	mux.HandleFunc("PUT /v1/admin/webhooks/{id}", h.auth.RequireAdmin(h.UpdateWebhook))
+	mux.HandleFunc("DELETE /v1/admin/webhooks/{id}", h.auth.RequireAdmin(h.DeleteWebhook))
+	mux.HandleFunc("POST /v1/admin/webhooks/{id}/rotate-secret", h.auth.RequireAdmin(h.RotateWebhookSecret))
+	mux.HandleFunc("GET /v1/admin/webhooks/{id}/deliveries", h.auth.RequireAdmin(h.ListWebhookDeliveries))
+	mux.HandleFunc("GET /v1/admin/webhook-deliveries/{id}", h.auth.RequireAdmin(h.GetWebhookDelivery))
+	mux.HandleFunc("POST /v1/admin/webhook-deliveries/{id}/redeliver", h.auth.RequireAdmin(h.RedeliverWebhook))
+	mux.HandleFunc("POST /v1/admin/config/reload", h.auth.RequireAdmin(h.ReloadConfig))
+}
+
+// ListWebhooks lists every webhook endpoint, oldest first.
+func (h *AdminHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	endpoints, err := h.webhooks.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]webhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		response = append(response, newWebhookEndpointResponse(endpoint))
+	}
+	writeJSON(w, http.StatusOK, webhookEndpointsResponse{Webhooks: response})
+}
+
+// CreateWebhook registers an endpoint and returns its signing secret, which
+// is not shown again.
+func (h *AdminHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req createWebhookEndpointRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	created, err := h.webhooks.Create(r.Context(), claims.AccountID, req.URL, req.Description, webhookEvents(req.Events))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, createdWebhookEndpointResponse{
+		webhookEndpointResponse: newWebhookEndpointResponse(created.Endpoint),
+		Secret:                  created.Secret,
+	})
+}
+
+func (h *AdminHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	endpoint, err := h.webhooks.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookEndpointResponse(endpoint))
+}
+
+// UpdateWebhook replaces the URL, description and events of an endpoint and
+// turns it on or off.
+func (h *AdminHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req updateWebhookEndpointRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	endpoint, err := h.webhooks.Update(r.Context(), id, req.URL, req.Description, webhookEvents(req.Events), req.IsActive)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookEndpointResponse(endpoint))
+}
+
+// DeleteWebhook removes an endpoint with its deliveries.
+func (h *AdminHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.webhooks.Delete(r.Context(), id); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateWebhookSecret replaces the signing secret of an endpoint and returns
+// the new one.
+func (h *AdminHandler) RotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	secret, err := h.webhooks.RotateSecret(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, webhookSecretResponse{Secret: secret})
+}
+
+// ListWebhookDeliveries returns the latest deliveries to an endpoint, newest
+// first.
+func (h *AdminHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	deliveries, err := h.webhooks.Deliveries(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]webhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, newWebhookDeliveryResponse(delivery))
+	}
+	writeJSON(w, http.StatusOK, webhookDeliveriesResponse{Deliveries: response})
+}
+
+// GetWebhookDelivery returns a delivery with its payload and attempts.
+func (h *AdminHandler) GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	delivery, attempts, err := h.webhooks.Delivery(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookDeliveryDetailResponse(delivery, attempts))
+}
+
+// RedeliverWebhook queues a delivery that succeeded or failed again.
+func (h *AdminHandler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.webhooks.Redeliver(r.Context(), id); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// BanAccount bans an account, until expires_at when it is set.
+func (h *AdminHandler) BanAccount(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req banAccountRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ban, err := h.bans.Ban(r.Context(), claims.AccountID, accountID, req.Reason, req.ExpiresAt)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newAccountBanResponse(ban))
+}
+
+// GetAccount returns an account with its auth methods, in the format of the
+// account search.
+func (h *AdminHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	account, err := h.accounts.Lookup(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newExportedAccountResponse(account))
+}
+
+// RevokeSessions ends every session of an account and invalidates its access
+// tokens.
+func (h *AdminHandler) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.sessions.RevokeAccountSessions(r.Context(), claims.AccountID, accountID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EraseAccount anonymizes the personal data of an account for good, such as
+// on a right to be forgotten request.
+func (h *AdminHandler) EraseAccount(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.verification.EraseAccountByAdmin(r.Context(), claims.AccountID, accountID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendVerification emails a new confirmation code to a PENDING account.
+func (h *AdminHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	result, err := h.verification.ResendVerification(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newCodeIssuedResponse("verification_resent", result))
+}
+
+// UnbanAccount lifts the ban of an account.
+func (h *AdminHandler) UnbanAccount(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req unbanAccountRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ban, err := h.bans.Unban(r.Context(), claims.AccountID, accountID, req.Reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountBanResponse(ban))
+}
+
+// AssignRole changes the role of an account.
+func (h *AdminHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req assignRoleRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	change, err := h.roles.Assign(r.Context(), claims.AccountID, accountID, domain.Role(strings.ToUpper(req.Role)), req.Reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newRoleChangeResponse(change))
+}
+
+// ListRoleChanges lists the role changes of an account, newest first.
+func (h *AdminHandler) ListRoleChanges(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	changes, err := h.roles.History(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]roleChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		response = append(response, newRoleChangeResponse(change))
+	}
+	writeJSON(w, http.StatusOK, roleChangesResponse{RoleChanges: response})
+}
+
+// ListRoles lists every role with its permissions.
+func (h *AdminHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.roles.ListRoles(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]roleResponse, 0, len(roles))
+	for _, role := range roles {
+		response = append(response, newRoleResponse(role))
+	}
+	writeJSON(w, http.StatusOK, rolesResponse{Roles: response})
+}
+
+// CreateRole defines a custom role.
+func (h *AdminHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	var req createRoleRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	role, err := h.roles.CreateRole(r.Context(), domain.Role(strings.ToUpper(req.Code)), req.Description, req.Permissions)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newRoleResponse(role))
+}
+
+func (h *AdminHandler) GetRole(w http.ResponseWriter, r *http.Request) {
+	role, err := h.roles.GetRole(r.Context(), domain.Role(strings.ToUpper(r.PathValue("code"))))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newRoleResponse(role))
+}
+
+// UpdateRole replaces the description and the permissions of a role.
+func (h *AdminHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	var req updateRoleRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	role, err := h.roles.UpdateRole(r.Context(), domain.Role(strings.ToUpper(r.PathValue("code"))), req.Description, req.Permissions)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newRoleResponse(role))
+}
+
+// DeleteRole deletes a custom role that no account has.
+func (h *AdminHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	if err := h.roles.DeleteRole(r.Context(), domain.Role(strings.ToUpper(r.PathValue("code")))); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOrganizations lists every organization by slug.
+func (h *AdminHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.orgs.ListOrganizations(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]organizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		response = append(response, newOrganizationResponse(organization))
+	}
+	writeJSON(w, http.StatusOK, organizationsResponse{Organizations: response})
+}
+
+func (h *AdminHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req createOrganizationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	organization, err := h.orgs.CreateOrganization(r.Context(), strings.ToLower(req.Slug), req.Name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newOrganizationResponse(organization))
+}
+
+func (h *AdminHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	organization, err := h.orgs.GetOrganization(r.Context(), organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newOrganizationResponse(organization))
+}
+
+// RenameOrganization changes the name of an organization.
+func (h *AdminHandler) RenameOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req renameOrganizationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	organization, err := h.orgs.RenameOrganization(r.Context(), organizationID, req.Name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newOrganizationResponse(organization))
+}
+
+// DeleteOrganization deletes an organization with its memberships.
+func (h *AdminHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.orgs.DeleteOrganization(r.Context(), organizationID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMembers lists the members of an organization, oldest first.
+func (h *AdminHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	members, err := h.orgs.Members(r.Context(), organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]membershipResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, newMembershipResponse(member))
+	}
+	writeJSON(w, http.StatusOK, membershipsResponse{Members: response})
+}
+
+// SetMember adds an account to an organization with a role, or changes its
+// role there.
+func (h *AdminHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req setMemberRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	membership, err := h.orgs.SetMember(r.Context(), organizationID, accountID, domain.Role(strings.ToUpper(req.Role)))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newMembershipResponse(membership))
+}
+
+// RemoveMember removes an account from an organization.
+func (h *AdminHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.orgs.RemoveMember(r.Context(), organizationID, accountID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPlatformSigningKeys lists the published platform signing keys, newest
+// first.
+func (h *AdminHandler) ListPlatformSigningKeys(w http.ResponseWriter, r *http.Request) {
+	if h.keys == nil {
+		writeError(w, r, domain.ErrKeyRotationDisabled)
+		return
+	}
+
+	keys, err := h.keys.PlatformKeys(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSigningKeysResponse(keys))
+}
+
+// RotatePlatformSigningKey schedules a new platform signing key ahead of the
+// rotation interval, such as after a suspected key compromise.
+func (h *AdminHandler) RotatePlatformSigningKey(w http.ResponseWriter, r *http.Request) {
+	if h.keys == nil {
+		writeError(w, r, domain.ErrKeyRotationDisabled)
+		return
+	}
+
+	if err := h.keys.Rotate(r.Context()); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	keys, err := h.keys.PlatformKeys(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSigningKeysResponse(keys))
+}
+
+// ListSigningKeys lists the published signing keys of an organization, newest
+// first.
+func (h *AdminHandler) ListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	keys, err := h.orgs.SigningKeys(r.Context(), organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSigningKeysResponse(keys))
+}
+
+// RotateSigningKey schedules a new signing key for an organization.
+func (h *AdminHandler) RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	keys, err := h.orgs.RotateSigningKey(r.Context(), organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSigningKeysResponse(keys))
+}
+
+// RevokeSigningKeys revokes every signing key of an organization.
+func (h *AdminHandler) RevokeSigningKeys(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.orgs.RevokeSigningKeys(r.Context(), organizationID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBranding returns the email branding of an organization.
+func (h *AdminHandler) GetBranding(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	branding, err := h.orgs.Branding(r.Context(), organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newBrandingResponse(branding))
+}
+
+// SetBranding replaces the email branding of an organization; omitted fields
+// fall back to the default branding.
+func (h *AdminHandler) SetBranding(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req setBrandingRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	branding, err := h.orgs.SetBranding(r.Context(), &models.Branding{
+		OrganizationID:  organizationID,
+		SenderName:      req.SenderName,
+		SenderAddress:   req.SenderAddress,
+		LogoURL:         req.LogoURL,
+		PrimaryColor:    req.PrimaryColor,
+		BackgroundColor: req.BackgroundColor,
+		FooterText:      req.FooterText,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newBrandingResponse(branding))
+}
+
+// DeleteBranding returns the emails of an organization to the default
+// branding.
+func (h *AdminHandler) DeleteBranding(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.orgs.DeleteBranding(r.Context(), organizationID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBans lists the bans of an account, lifted or not, newest first.
+func (h *AdminHandler) ListBans(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	bans, err := h.bans.History(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]accountBanResponse, 0, len(bans))
+	for _, ban := range bans {
+		response = append(response, newAccountBanResponse(ban))
+	}
+	writeJSON(w, http.StatusOK, accountBansResponse{Bans: response})
+}
+
+// ListConsents returns the consents of an account, newest first, with the
+// origin each was given from.
+func (h *AdminHandler) ListConsents(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	consents, err := h.consents.History(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, consentsResponse{Consents: newConsentResponses(consents)})
+}
+
+// ListLegalDocuments returns every published version of the legal
+// documents, newest first.
+func (h *AdminHandler) ListLegalDocuments(w http.ResponseWriter, r *http.Request) {
+	documents, err := h.consents.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, legalDocumentsResponse{Documents: newLegalDocumentResponses(documents)})
+}
+
+// PublishLegalDocument publishes a new version of a legal document, which
+// every account must accept at its next login.
+func (h *AdminHandler) PublishLegalDocument(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req publishLegalDocumentRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	document, err := h.consents.Publish(r.Context(), claims.AccountID, domain.LegalDocumentKind(req.Kind), req.Version, req.URL)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newLegalDocumentResponse(document))
+}
+
+// Impersonate issues an access token acting as an account, whose act claim
+// names the administrator.
+func (h *AdminHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req impersonateAccountRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if req.Duration < 0 {
+		writeError(w, r, domain.ErrInvalidImpersonationDuration)
+		return
+	}
+
+	token, err := h.impersonations.Start(r.Context(), claims.AccountID, accountID, req.Reason, time.Duration(req.Duration)*time.Second, clientInfo(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, impersonationTokenResponse{
+		AccessToken:   token.AccessToken,
+		TokenType:     "Bearer",
+		ExpiresIn:     int(time.Until(token.Impersonation.ExpiresAt).Seconds()),
+		Impersonation: newImpersonationResponse(token.Impersonation),
+	})
+}
+
+// EndImpersonation revokes the token of an impersonation before it expires.
+func (h *AdminHandler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	impersonation, err := h.impersonations.End(r.Context(), claims.AccountID, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newImpersonationResponse(impersonation))
+}
+
+// ListImpersonations returns every impersonation of an account, newest
+// first.
+func (h *AdminHandler) ListImpersonations(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	impersonations, err := h.impersonations.History(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]impersonationResponse, 0, len(impersonations))
+	for _, impersonation := range impersonations {
+		response = append(response, newImpersonationResponse(impersonation))
+	}
+	writeJSON(w, http.StatusOK, impersonationsResponse{Impersonations: response})
+}
+
+// SearchAccounts pages through the accounts matching the role, status,
+// provider, created_from, created_before and email parameters, oldest
+// first. next_cursor, passed back as cursor, gives the next page.
+func (h *AdminHandler) SearchAccounts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, err := accountFilter(query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var after models.AccountCursor
+	if raw := query.Get("cursor"); raw != "" {
+		if after, err = decodeAccountCursor(raw); err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	limit, err := pageLimit(query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := h.search.Search(r.Context(), filter, after, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountSearchResponse(page))
+}
+
+// SearchAuditEvents pages through the audit events matching the actor_id,
+// target_id, action, created_from and created_before parameters, newest
+// first. action may be repeated to match any of several actions. next_cursor,
+// passed back as cursor, gives the next page.
+func (h *AdminHandler) SearchAuditEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, err := auditEventFilter(query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var after models.AuditEventCursor
+	if raw := query.Get("cursor"); raw != "" {
+		if after, err = decodeAuditEventCursor(raw); err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	limit, err := pageLimit(query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := h.audit.Search(r.Context(), filter, after, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAuditEventsResponse(page))
+}
+
+// ExportAccounts streams every account with its auth methods as NDJSON or,
+// with format=csv, as CSV. Each record carries the cursor that resumes the
+// export after it. An export that fails midway is aborted rather than ended,
+// so clients can tell it apart from a complete one.
+func (h *AdminHandler) ExportAccounts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var after models.AccountCursor
+	if raw := query.Get("cursor"); raw != "" {
+		cursor, err := decodeAccountCursor(raw)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		after = cursor
+	}
+
+	var exporter accountExporter
+	switch format := query.Get("format"); format {
+	case "", "ndjson":
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		exporter = &ndjsonExporter{encoder: json.NewEncoder(w)}
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		exporter = &csvExporter{writer: csv.NewWriter(w)}
+	default:
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	written := 0
+	err := h.search.Export(r.Context(), after, func(exported *application.ExportedAccount) error {
+		if err := exporter.write(exported); err != nil {
+			return err
+		}
+		if written++; written%exportFlushInterval != 0 {
+			return nil
+		}
+		if err := exporter.flush(); err != nil {
+			return err
+		}
+		return controller.Flush()
+	})
+	if err == nil {
+		err = exporter.flush()
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "export accounts", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// accountExporter renders exported accounts in one format.
+type accountExporter interface {
+	write(exported *application.ExportedAccount) error
+	flush() error
+}
+
+type ndjsonExporter struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExporter) write(exported *application.ExportedAccount) error {
+	return e.encoder.Encode(newExportedAccountResponse(exported))
+}
+
+func (e *ndjsonExporter) flush() error {
+	return nil
+}
+
+type csvExporter struct {
+	writer *csv.Writer
+	// started is set once the header row is written.
+	started bool
+}
+
+func (e *csvExporter) write(exported *application.ExportedAccount) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	for _, row := range accountExportRows(exported) {
+		if err := e.writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvExporter) flush() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// start writes the header row, even of an empty export.
+func (e *csvExporter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.writer.Write(accountExportColumns)
+}
+
+// accountExportRows renders an account as CSV rows under
+// accountExportColumns.
+func accountExportRows(exported *application.ExportedAccount) [][]string {
+	account := []string{
+		encodeAccountCursor(exported.Account.Cursor()),
+		exported.Account.ID.String(),
+		string(exported.Account.RoleCode),
+		string(exported.Account.StatusCode),
+		exported.Account.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if len(exported.AuthMethods) == 0 {
+		return [][]string{append(account, "", "", "", "", "")}
+	}
+
+	rows := make([][]string, 0, len(exported.AuthMethods))
+	for _, method := range exported.AuthMethods {
+		lastLogin := ""
+		if method.LastLoginAt != nil {
+			lastLogin = method.LastLoginAt.UTC().Format(time.RFC3339Nano)
+		}
+		rows = append(rows, append(append([]string(nil), account...),
+			method.ID.String(),
+			string(method.ProviderCode),
+			method.ProviderID,
+			strconv.FormatBool(method.IsVerified),
+			lastLogin,
+		))
+	}
+	return rows
+}
+
+// encodeAccountCursor renders a cursor as an opaque URL-safe string.
+func encodeAccountCursor(cursor models.AccountCursor) string {
+	return encodeCursor(cursor.CreatedAt, cursor.ID)
+}
+
+func decodeAccountCursor(raw string) (models.AccountCursor, error) {
+	createdAt, id, err := decodeCursor(raw)
+	return models.AccountCursor{CreatedAt: createdAt, ID: id}, err
+}
+
+func encodeAuditEventCursor(cursor models.AuditEventCursor) string {
+	return encodeCursor(cursor.CreatedAt, cursor.ID)
+}
+
+func decodeAuditEventCursor(raw string) (models.AuditEventCursor, error) {
+	createdAt, id, err := decodeCursor(raw)
+	return models.AuditEventCursor{CreatedAt: createdAt, ID: id}, err
+}
+
+// encodeCursor renders a position in a listing ordered by creation time, then
+// id, as an opaque string.
+func encodeCursor(createdAt time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + " " + id.String()))
+}
+
+func decodeCursor(raw string) (time.Time, uuid.UUID, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	rawCreatedAt, rawID, ok := strings.Cut(string(decoded), " ")
+	if !ok {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, rawCreatedAt)
+	if err != nil {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	return createdAt, id, nil
+}
+
+// webhookEvents reads the event names a webhook endpoint subscribes to,
+// normalized for case and spacing.
+func webhookEvents(raw []string) []domain.WebhookEvent {
+	events := make([]domain.WebhookEvent, 0, len(raw))
+	for _, event := range raw {
+		events = append(events, domain.WebhookEvent(strings.ToLower(strings.TrimSpace(event))))
+	}
+	return events
+}
+
+// pageLimit reads the optional page size of a listing; services cap it.
+func pageLimit(query url.Values) (int, error) {
+	raw := query.Get("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return 0, errInvalidRequest
+	}
+	return limit, nil
+}
+
+// auditEventFilter reads the filters of an audit log search. The creation
+// bounds are RFC 3339 times.
+func auditEventFilter(query url.Values) (models.AuditEventFilter, error) {
+	var (
+		filter models.AuditEventFilter
+		err    error
+	)
+	if raw := query.Get("actor_id"); raw != "" {
+		if filter.ActorID, err = uuid.Parse(raw); err != nil {
+			return filter, errInvalidRequest
+		}
+	}
+	if raw := query.Get("target_id"); raw != "" {
+		if filter.TargetID, err = uuid.Parse(raw); err != nil {
+			return filter, errInvalidRequest
+		}
+	}
+	for _, action := range query["action"] {
+		if action == "" {
+			return filter, errInvalidRequest
+		}
+		filter.Actions = append(filter.Actions, domain.AuditAction(action))
+	}
+	if raw := query.Get("created_from"); raw != "" {
+		if filter.CreatedFrom, err = time.Parse(time.RFC3339, raw); err != nil {
+			return filter, errInvalidRequest
+		}
+	}
+	if raw := query.Get("created_before"); raw != "" {
+		if filter.CreatedBefore, err = time.Parse(time.RFC3339, raw); err != nil {
+			return filter, errInvalidRequest
+		}
+	}
+	return filter, nil
+}
+
+// ReloadConfig reloads the configuration file and applies the settings that
+// can change without a restart. An invalid configuration is refused with
+// 422 and logged with its problems, keeping the active one.
+func (h *AdminHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if err := h.config.Reload(r.Context()); err != nil {
+		slog.WarnContext(r.Context(), "reload configuration", "error", err)
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// accountFilter reads the filters of an account search. Roles, statuses and
+// providers are matched in any case; the creation bounds are RFC 3339 times.
+func accountFilter(query url.Values) (models.AccountFilter, error) {
+	filter := models.AccountFilter{
+		Role:     domain.Role(strings.ToUpper(query.Get("role"))),
+		Status:   domain.Status(strings.ToUpper(query.Get("status"))),
+		Provider: domain.Provider(strings.ToUpper(query.Get("provider"))),
+		Email:    query.Get("email"),
+	}
+	switch filter.Status {
+	case "", domain.StatusPending, domain.StatusActive, domain.StatusBanned, domain.StatusDeleted:
+	default:
+		return filter, errInvalidRequest
+	}
+
+	var err error
+	if raw := query.Get("created_from"); raw != "" {
+		if filter.CreatedFrom, err = time.Parse(time.RFC3339, raw); err != nil {
+			return filter, errInvalidRequest
+		}
+	}
+	if raw := query.Get("created_before"); raw != "" {
+		if filter.CreatedBefore, err = time.Parse(time.RFC3339, raw); err != nil {
+			return filter, errInvalidRequest
+		}
+	}
+	return filter, nil
+}
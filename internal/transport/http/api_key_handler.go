@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type APIKeyHandler struct {
+	service *application.APIKeyService
+	auth    *Authenticator
+}
+
+func NewAPIKeyHandler(service *application.APIKeyService, auth *Authenticator) *APIKeyHandler {
+	return &APIKeyHandler{service: service, auth: auth}
+}
+
+func (h *APIKeyHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/api-keys", h.auth.Require(h.List))
+	mux.HandleFunc("POST /v1/api-keys", h.auth.RequireRecentAuth(domain.StepUpMaxAge, h.Create))
+	mux.HandleFunc("DELETE /v1/api-keys/{id}", h.auth.RequireAccountHolder(h.Revoke))
+}
+
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	keys, err := h.service.List(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]apiKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, newAPIKeyResponse(key))
+	}
+	writeJSON(w, http.StatusOK, apiKeysResponse{APIKeys: response})
+}
+
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req createAPIKeyRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	created, err := h.service.Create(r.Context(), claims.AccountID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createdAPIKeyResponse{
+		apiKeyResponse: newAPIKeyResponse(created.APIKey),
+		Key:            created.Key,
+	})
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	apiKeyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), claims.AccountID, apiKeyID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
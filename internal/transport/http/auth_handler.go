@@ -0,0 +1,397 @@
+package http
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// deviceTokenHeader carries the token of a trusted device on login requests.
+const deviceTokenHeader = "X-Device-Token"
+
+// captchaTokenHeader carries the response token of a solved CAPTCHA.
+const captchaTokenHeader = "X-Captcha-Token"
+
+type AuthHandler struct {
+	service *application.AuthService
+	auth    *Authenticator
+	limits  *RateLimiter
+}
+
+func NewAuthHandler(service *application.AuthService, auth *Authenticator, limits *RateLimiter) *AuthHandler {
+	return &AuthHandler{service: service, auth: auth, limits: limits}
+}
+
+func (h *AuthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/auth/register", h.limits.Register("email", h.Register))
+	mux.HandleFunc("POST /v1/auth/verify", h.limits.Verification("email", h.VerifyEmail))
+	mux.HandleFunc("POST /v1/auth/verify/resend", h.limits.Verification("email", h.ResendVerification))
+	mux.HandleFunc("POST /v1/auth/login", h.limits.Login("email", h.Login))
+	mux.HandleFunc("POST /v1/auth/login/verify", h.limits.Verification("email", h.VerifyLogin))
+	mux.HandleFunc("POST /v1/auth/login/password", h.limits.Login("email", h.PasswordLogin))
+	mux.HandleFunc("POST /v1/auth/magic-link", h.limits.Login("email", h.RequestMagicLink))
+	mux.HandleFunc("POST /v1/auth/magic-link/verify", h.limits.Verification("", h.VerifyMagicLink))
+	mux.HandleFunc("POST /v1/auth/password/forgot", h.limits.Verification("email", h.ForgotPassword))
+	mux.HandleFunc("POST /v1/auth/password/reset", h.limits.Verification("email", h.ResetPassword))
+	mux.HandleFunc("POST /v1/auth/password/change", h.limits.Login("", h.auth.AllowPasswordChange(h.ChangePassword)))
+	mux.HandleFunc("POST /v1/auth/password/strength", h.PasswordStrength)
+	mux.HandleFunc("DELETE /v1/auth/account", h.auth.RequireRecentAuth(domain.StepUpMaxAge, h.DeleteAccount))
+	mux.HandleFunc("POST /v1/auth/account/restore", h.limits.Verification("email", h.RequestAccountRestore))
+	mux.HandleFunc("POST /v1/auth/account/restore/confirm", h.limits.Verification("email", h.RestoreAccount))
+	mux.HandleFunc("POST /v1/auth/account/erase", h.auth.RequireRecentAuth(domain.StepUpMaxAge, h.EraseAccount))
+	mux.HandleFunc("POST /v1/auth/refresh", h.limits.Refresh("refresh_token", h.Refresh))
+	mux.HandleFunc("POST /v1/auth/switch-organization", h.limits.Refresh("refresh_token", h.SwitchOrganization))
+	mux.HandleFunc("POST /v1/auth/logout", h.Logout)
+}
+
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req registerRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.Organization = req.Organization
+
+	result, err := h.service.RegisterWithEmail(r.Context(), req.Email, req.Password, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newCodeIssuedResponse("registration_pending", result))
+}
+
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req verifyEmailRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.RememberMe = req.RememberMe
+	client.Scope = req.Scope
+
+	result, err := h.service.VerifyEmail(r.Context(), req.Email, req.Code, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+// ResendVerification emails a new confirmation code to a pending account,
+// answering the same for addresses that do not await one.
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req resendVerificationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.ResendVerificationEmail(r.Context(), req.Email)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, newCodeIssuedResponse("verification_resent", result))
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.Organization = req.Organization
+
+	result, err := h.service.RequestLoginCode(r.Context(), req.Email, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newCodeIssuedResponse("login_verification_pending", result))
+}
+
+func (h *AuthHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+	var req verifyLoginRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.RememberMe = req.RememberMe
+	client.Scope = req.Scope
+
+	result, err := h.service.VerifyLoginCode(r.Context(), req.Email, req.Code, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+func (h *AuthHandler) PasswordLogin(w http.ResponseWriter, r *http.Request) {
+	var req passwordLoginRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.RememberMe = req.RememberMe
+	client.Scope = req.Scope
+
+	result, err := h.service.LoginWithPassword(r.Context(), req.Email, req.Password, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+func (h *AuthHandler) RequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	var req magicLinkRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.Organization = req.Organization
+
+	result, err := h.service.RequestMagicLink(r.Context(), req.Email, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, newCodeIssuedResponse("magic_link_sent", result))
+}
+
+func (h *AuthHandler) VerifyMagicLink(w http.ResponseWriter, r *http.Request) {
+	var req verifyMagicLinkRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.RememberMe = req.RememberMe
+	client.Scope = req.Scope
+
+	result, err := h.service.VerifyMagicLink(r.Context(), req.Token, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req forgotPasswordRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.Organization = req.Organization
+
+	result, err := h.service.ForgotPassword(r.Context(), req.Email, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, newCodeIssuedResponse("password_reset_pending", result))
+}
+
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req resetPasswordRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), req.Email, req.Code, req.Password); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangePassword also accepts the restricted tokens of expired passwords.
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req changePasswordRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), claims.AccountID, req.CurrentPassword, req.NewPassword); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAccount demands a recent reauthentication, as the account is signed
+// out everywhere and purged once its grace period ends.
+func (h *AuthHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	deletion, err := h.service.DeleteAccount(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, accountDeletionResponse{Message: "account_deleted", PurgeAt: deletion.PurgeAt})
+}
+
+// EraseAccount demands a recent reauthentication, as the account's personal
+// data is anonymized at once and for good.
+func (h *AuthHandler) EraseAccount(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	if err := h.service.EraseAccount(r.Context(), claims.AccountID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) RequestAccountRestore(w http.ResponseWriter, r *http.Request) {
+	var req accountRestoreRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.RequestAccountRestore(r.Context(), req.Email)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, newCodeIssuedResponse("account_restore_pending", result))
+}
+
+func (h *AuthHandler) RestoreAccount(w http.ResponseWriter, r *http.Request) {
+	var req confirmAccountRestoreRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.RestoreAccount(r.Context(), req.Email, req.Code); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PasswordStrength rates a candidate password for live feedback while it is
+// typed. The email is optional and makes passwords built from it weaker.
+func (h *AuthHandler) PasswordStrength(w http.ResponseWriter, r *http.Request) {
+	var req passwordStrengthRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.EstimatePasswordStrength(req.Password, req.Email)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPasswordStrengthResponse(result))
+}
+
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.Refresh(r.Context(), req.RefreshToken, clientInfo(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+// SwitchOrganization rotates a refresh token into the session of another
+// organization of the account, or out of any when organization_id is null.
+func (h *AuthHandler) SwitchOrganization(w http.ResponseWriter, r *http.Request) {
+	var req switchOrganizationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var organizationID uuid.UUID
+	if req.OrganizationID != nil {
+		organizationID = *req.OrganizationID
+	}
+	result, err := h.service.SwitchOrganization(r.Context(), req.RefreshToken, organizationID, clientInfo(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req logoutRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), req.RefreshToken); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func clientInfo(r *http.Request) application.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return application.ClientInfo{
+		IPAddress:    ip,
+		UserAgent:    r.UserAgent(),
+		DeviceToken:  r.Header.Get(deviceTokenHeader),
+		CaptchaToken: r.Header.Get(captchaTokenHeader),
+	}
+}
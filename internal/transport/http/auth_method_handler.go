@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/google/uuid"
+)
+
+type AuthMethodHandler struct {
+	service *application.AuthMethodService
+	auth    *Authenticator
+}
+
+func NewAuthMethodHandler(service *application.AuthMethodService, auth *Authenticator) *AuthMethodHandler {
+	return &AuthMethodHandler{service: service, auth: auth}
+}
+
+func (h *AuthMethodHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/auth/methods", h.auth.Require(h.List))
+	mux.HandleFunc("DELETE /v1/auth/methods/{id}", h.auth.RequireAccountHolder(h.Unlink))
+}
+
+func (h *AuthMethodHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	methods, err := h.service.List(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]authMethodResponse, 0, len(methods))
+	for _, method := range methods {
+		response = append(response, newAuthMethodResponse(method))
+	}
+	writeJSON(w, http.StatusOK, authMethodsResponse{Methods: response})
+}
+
+func (h *AuthMethodHandler) Unlink(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	methodID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.Unlink(r.Context(), claims.AccountID, methodID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
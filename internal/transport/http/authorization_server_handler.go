@@ -0,0 +1,172 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// AuthorizationServerHandler serves the OAuth 2.0 endpoints called by
+// registered clients. Requests are form encoded, as the RFCs require.
+type AuthorizationServerHandler struct {
+	clients       *application.ClientService
+	introspection *application.IntrospectionService
+	revocation    *application.RevocationService
+}
+
+func NewAuthorizationServerHandler(
+	clients *application.ClientService,
+	introspection *application.IntrospectionService,
+	revocation *application.RevocationService,
+) *AuthorizationServerHandler {
+	return &AuthorizationServerHandler{
+		clients:       clients,
+		introspection: introspection,
+		revocation:    revocation,
+	}
+}
+
+func (h *AuthorizationServerHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /oauth/introspect", h.Introspect)
+	mux.HandleFunc("POST /oauth/introspect/batch", h.IntrospectBatch)
+	mux.HandleFunc("POST /oauth/revoke", h.Revoke)
+}
+
+// Introspect implements RFC 7662. Tokens that are not active are described
+// by {"active": false} alone.
+func (h *AuthorizationServerHandler) Introspect(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(w, r); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if _, err := authenticateClient(w, r, h.clients, false); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	introspection, err := h.introspection.Introspect(r.Context(), token, r.PostForm.Get("token_type_hint"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, newIntrospectionResponse(introspection))
+}
+
+// IntrospectBatch describes every token form field as an access token, in
+// order, with the members of an RFC 7662 response.
+func (h *AuthorizationServerHandler) IntrospectBatch(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(w, r); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if _, err := authenticateClient(w, r, h.clients, false); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	tokens := r.PostForm["token"]
+	if len(tokens) == 0 {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	introspections, err := h.introspection.IntrospectAccessTokens(r.Context(), tokens)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]introspectionResponse, 0, len(introspections))
+	for _, introspection := range introspections {
+		response = append(response, newIntrospectionResponse(introspection))
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, batchIntrospectionResponse{Tokens: response})
+}
+
+// Revoke implements RFC 7009. Unknown and already invalid tokens are
+// answered with 200 like revoked ones.
+func (h *AuthorizationServerHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(w, r); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if _, err := authenticateClient(w, r, h.clients, true); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.revocation.Revoke(r.Context(), token, r.PostForm.Get("token_type_hint")); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// authenticateClient accepts client_secret_basic and client_secret_post
+// credentials, and a bare client_id from public clients when allowPublic is
+// set. Failures carry the Basic challenge of RFC 6749 section 5.2.
+func authenticateClient(w http.ResponseWriter, r *http.Request, clients *application.ClientService, allowPublic bool) (*models.OAuthClient, error) {
+	clientID, secret, ok := r.BasicAuth()
+	if ok {
+		// Basic credentials are form encoded before being base64 encoded.
+		var err error
+		if clientID, err = url.QueryUnescape(clientID); err != nil {
+			ok = false
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			ok = false
+		}
+	} else {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		ok = true
+	}
+
+	var (
+		client *models.OAuthClient
+		err    = domain.ErrInvalidClient
+	)
+	switch {
+	case ok && allowPublic:
+		client, err = clients.Identify(r.Context(), clientID, secret)
+	case ok:
+		client, err = clients.Authenticate(r.Context(), clientID, secret)
+	}
+	if errors.Is(err, domain.ErrInvalidClient) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func parseForm(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	if err := r.ParseForm(); err != nil {
+		return errInvalidRequest
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+)
+
+// ConsentHandler publishes the current legal documents and lets account
+// holders accept them, including with the restricted token their login
+// returns while they have not.
+type ConsentHandler struct {
+	service *application.ConsentService
+	auth    *Authenticator
+}
+
+func NewConsentHandler(service *application.ConsentService, auth *Authenticator) *ConsentHandler {
+	return &ConsentHandler{service: service, auth: auth}
+}
+
+func (h *ConsentHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/legal-documents", h.Current)
+	mux.HandleFunc("GET /v1/auth/consents", h.auth.AllowConsent(h.List))
+	mux.HandleFunc("POST /v1/auth/consents", h.auth.AllowConsent(h.Accept))
+}
+
+// Current returns the version of each legal document published last, such
+// as for a registration form to link to.
+func (h *ConsentHandler) Current(w http.ResponseWriter, r *http.Request) {
+	documents, err := h.service.Current(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, legalDocumentsResponse{Documents: newLegalDocumentResponses(documents)})
+}
+
+// List returns the consents of the account, newest first, and the current
+// legal documents it has yet to accept.
+func (h *ConsentHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	consents, err := h.service.History(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	pending, err := h.service.Pending(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, consentsResponse{
+		Consents: newConsentResponses(consents),
+		Pending:  newLegalDocumentResponses(pending),
+	})
+}
+
+// Accept records the acceptance of the given documents. Holders of a
+// restricted token sign in again once they have accepted every pending one.
+func (h *ConsentHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req acceptConsentsRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.Accept(r.Context(), claims.AccountID, req.DocumentIDs); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
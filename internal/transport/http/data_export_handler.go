@@ -0,0 +1,85 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+// DataExportHandler lets account holders ask for a copy of their data and
+// download it through the signed link they are sent.
+type DataExportHandler struct {
+	service *application.DataExportService
+	auth    *Authenticator
+}
+
+func NewDataExportHandler(service *application.DataExportService, auth *Authenticator) *DataExportHandler {
+	return &DataExportHandler{service: service, auth: auth}
+}
+
+func (h *DataExportHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/auth/data-exports", h.auth.RequireRecentAuth(domain.StepUpMaxAge, h.Request))
+	mux.HandleFunc("GET /v1/auth/data-exports", h.auth.RequireAccountHolder(h.List))
+	mux.HandleFunc("GET /v1/auth/data-exports/download", h.Download)
+}
+
+// Request demands a recent reauthentication, as the archive holds every
+// address and session of the account. The archive is generated in the
+// background and its link emailed once it is ready.
+func (h *DataExportHandler) Request(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	export, err := h.service.Request(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newDataExportResponse(export, ""))
+}
+
+// List returns the account's exports, newest first, with the download link
+// of those that are ready.
+func (h *DataExportHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	exports, err := h.service.List(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := dataExportsResponse{Exports: make([]dataExportResponse, 0, len(exports))}
+	for _, export := range exports {
+		var link string
+		if export.Status == domain.DataExportReady {
+			if link, err = h.service.DownloadLink(export); err != nil {
+				writeError(w, r, err)
+				return
+			}
+		}
+		response.Exports = append(response.Exports, newDataExportResponse(export, link))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Download serves the archive a signed link points to. The link is the
+// credential, so no token is needed.
+func (h *DataExportHandler) Download(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	export, err := h.service.Download(r.Context(), query.Get("id"), query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="data-export-`+export.ID.String()+`.json"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(export.Archive); err != nil {
+		slog.ErrorContext(r.Context(), "write data export", "export_id", export.ID, "error", err)
+	}
+}
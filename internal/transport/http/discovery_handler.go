@@ -0,0 +1,110 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// discoveryMaxAge matches the key set, whose URL the document advertises.
+const discoveryMaxAge = jwksMaxAge
+
+// discoveryResponse is the provider metadata of OpenID Connect Discovery
+// 1.0, with the RFC 8414 fields for the revocation and introspection
+// endpoints, the RFC 8628 device authorization endpoint and the RFC 9449
+// DPoP algorithms.
+type discoveryResponse struct {
+	Issuer                                    string   `json:"issuer"`
+	AuthorizationEndpoint                     string   `json:"authorization_endpoint"`
+	TokenEndpoint                             string   `json:"token_endpoint"`
+	UserInfoEndpoint                          string   `json:"userinfo_endpoint"`
+	JWKSURI                                   string   `json:"jwks_uri"`
+	RevocationEndpoint                        string   `json:"revocation_endpoint"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	DeviceAuthorizationEndpoint               string   `json:"device_authorization_endpoint"`
+	ScopesSupported                           []string `json:"scopes_supported"`
+	ResponseTypesSupported                    []string `json:"response_types_supported"`
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	SubjectTypesSupported                     []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported          []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported         []string `json:"token_endpoint_auth_methods_supported"`
+	RevocationEndpointAuthMethodsSupported    []string `json:"revocation_endpoint_auth_methods_supported"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+	ClaimsSupported                           []string `json:"claims_supported"`
+	PromptValuesSupported                     []string `json:"prompt_values_supported"`
+	CodeChallengeMethodsSupported             []string `json:"code_challenge_methods_supported"`
+	DPoPSigningAlgValuesSupported             []string `json:"dpop_signing_alg_values_supported"`
+}
+
+// clientAuthMethods are the client authentication methods accepted by every
+// endpoint that authenticates clients. The token, device authorization and
+// revocation endpoints also accept public clients, which send their
+// client_id alone.
+var (
+	clientAuthMethods       = []string{"client_secret_basic", "client_secret_post"}
+	publicClientAuthMethods = append(slices.Clone(clientAuthMethods), "none")
+)
+
+// DiscoveryHandler publishes the provider metadata so OpenID Connect client
+// libraries can configure themselves from the issuer URL alone. The issuer
+// must be the public base URL of the service, as it is also the iss claim of
+// ID tokens.
+type DiscoveryHandler struct {
+	issuer string
+	keys   ports.KeySet
+	dpop   *application.DPoPValidator
+}
+
+func NewDiscoveryHandler(issuer string, keys ports.KeySet, dpop *application.DPoPValidator) *DiscoveryHandler {
+	return &DiscoveryHandler{issuer: issuer, keys: keys, dpop: dpop}
+}
+
+func (h *DiscoveryHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /.well-known/openid-configuration", h.Configuration)
+}
+
+func (h *DiscoveryHandler) Configuration(w http.ResponseWriter, r *http.Request) {
+	base := strings.TrimSuffix(h.issuer, "/")
+
+	w.Header().Set("Cache-Control", discoveryMaxAge)
+	writeJSON(w, http.StatusOK, discoveryResponse{
+		Issuer:                                    h.issuer,
+		AuthorizationEndpoint:                     base + "/oauth/authorize",
+		TokenEndpoint:                             base + "/oauth/token",
+		UserInfoEndpoint:                          base + "/userinfo",
+		JWKSURI:                                   base + "/.well-known/jwks.json",
+		RevocationEndpoint:                        base + "/oauth/revoke",
+		IntrospectionEndpoint:                     base + "/oauth/introspect",
+		DeviceAuthorizationEndpoint:               base + "/oauth/device_authorization",
+		ScopesSupported:                           []string{domain.ScopeOpenID, domain.ScopeEmail},
+		ResponseTypesSupported:                    []string{domain.ResponseTypeCode},
+		GrantTypesSupported:                       supportedGrantTypes,
+		SubjectTypesSupported:                     []string{"public"},
+		IDTokenSigningAlgValuesSupported:          h.signingAlgorithms(),
+		TokenEndpointAuthMethodsSupported:         publicClientAuthMethods,
+		RevocationEndpointAuthMethodsSupported:    publicClientAuthMethods,
+		IntrospectionEndpointAuthMethodsSupported: clientAuthMethods,
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "sid", "email", "email_verified",
+		},
+		PromptValuesSupported:         []string{domain.PromptNone},
+		CodeChallengeMethodsSupported: []string{domain.CodeChallengeMethodS256},
+		DPoPSigningAlgValuesSupported: h.dpop.Algorithms(),
+	})
+}
+
+// signingAlgorithms lists the algorithms of the published keys, so a key
+// type change is announced together with the new key.
+func (h *DiscoveryHandler) signingAlgorithms() []string {
+	var algorithms []string
+	for _, key := range h.keys.PublicKeys() {
+		if !slices.Contains(algorithms, key.Algorithm) {
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
@@ -0,0 +1,1432 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type registerRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	// Organization is the slug of the white-label organization whose
+	// branding the emails use; it does not make the account a member.
+	Organization string `json:"organization"`
+}
+
+type verifyEmailRequest struct {
+	Email      string `json:"email"`
+	Code       string `json:"code"`
+	RememberMe bool   `json:"remember_me"`
+	Scope      string `json:"scope"`
+}
+
+type resendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+type loginRequest struct {
+	Email        string `json:"email"`
+	Organization string `json:"organization"`
+}
+
+type passwordLoginRequest struct {
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"remember_me"`
+	Scope      string `json:"scope"`
+}
+
+type verifyLoginRequest struct {
+	Email      string `json:"email"`
+	Code       string `json:"code"`
+	RememberMe bool   `json:"remember_me"`
+	Scope      string `json:"scope"`
+}
+
+type magicLinkRequest struct {
+	Email        string `json:"email"`
+	Organization string `json:"organization"`
+}
+
+type verifyMagicLinkRequest struct {
+	Token      string `json:"token"`
+	RememberMe bool   `json:"remember_me"`
+	Scope      string `json:"scope"`
+}
+
+type forgotPasswordRequest struct {
+	Email        string `json:"email"`
+	Organization string `json:"organization"`
+}
+
+type resetPasswordRequest struct {
+	Email    string `json:"email"`
+	Code     string `json:"code"`
+	Password string `json:"password"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type accountRestoreRequest struct {
+	Email string `json:"email"`
+}
+
+type confirmAccountRestoreRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+type passwordStrengthRequest struct {
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+type verifyMFARequest struct {
+	MFAToken       string `json:"mfa_token"`
+	Method         string `json:"method"`
+	Code           string `json:"code"`
+	RememberDevice bool   `json:"remember_device"`
+}
+
+type sendSMSChallengeRequest struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+type enrollSMSRequest struct {
+	PhoneNumber string `json:"phone_number"`
+}
+
+type reauthenticateRequest struct {
+	Password string `json:"password"`
+	Method   string `json:"method"`
+	Code     string `json:"code"`
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type finishPasskeyRegistrationRequest struct {
+	CeremonyID uuid.UUID       `json:"ceremony_id"`
+	Name       string          `json:"name"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+type finishPasskeyLoginRequest struct {
+	CeremonyID uuid.UUID       `json:"ceremony_id"`
+	Credential json.RawMessage `json:"credential"`
+	RememberMe bool            `json:"remember_me"`
+	Scope      string          `json:"scope"`
+}
+
+type createAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type beginPasskeyMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+type finishPasskeyMFARequest struct {
+	MFAToken       string          `json:"mfa_token"`
+	CeremonyID     uuid.UUID       `json:"ceremony_id"`
+	Credential     json.RawMessage `json:"credential"`
+	RememberDevice bool            `json:"remember_device"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type switchOrganizationRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	// OrganizationID is null to leave the active organization.
+	OrganizationID *uuid.UUID `json:"organization_id"`
+}
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type logoutAllRequest struct {
+	InvalidateAccessTokens bool `json:"invalidate_access_tokens"`
+}
+
+type deviceDecisionRequest struct {
+	UserCode string `json:"user_code"`
+}
+
+type acceptConsentsRequest struct {
+	DocumentIDs []uuid.UUID `json:"document_ids"`
+}
+
+type banAccountRequest struct {
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type unbanAccountRequest struct {
+	Reason string `json:"reason"`
+}
+
+// impersonateAccountRequest asks for an impersonation token lasting
+// duration seconds, or the default lifetime when it is zero.
+type impersonateAccountRequest struct {
+	Reason   string `json:"reason"`
+	Duration int    `json:"duration"`
+}
+
+type publishLegalDocumentRequest struct {
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+type assignRoleRequest struct {
+	Role   string `json:"role"`
+	Reason string `json:"reason"`
+}
+
+type createRoleRequest struct {
+	Code        string   `json:"code"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type updateRoleRequest struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type createOrganizationRequest struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type renameOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type createWebhookEndpointRequest struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+}
+
+type updateWebhookEndpointRequest struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+	IsActive    bool     `json:"is_active"`
+}
+
+type setMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type inviteRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type acceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// setPolicyRequest sets an organization policy; session_max_age is in
+// seconds, and zero values keep the service-wide rules.
+type setPolicyRequest struct {
+	PasswordMinLength       int      `json:"password_min_length"`
+	PasswordRequiredClasses []string `json:"password_required_classes"`
+	RequireMFA              bool     `json:"require_mfa"`
+	SessionMaxAge           int      `json:"session_max_age"`
+	AllowedProviders        []string `json:"allowed_providers"`
+}
+
+type setBrandingRequest struct {
+	SenderName      string `json:"sender_name"`
+	SenderAddress   string `json:"sender_address"`
+	LogoURL         string `json:"logo_url"`
+	PrimaryColor    string `json:"primary_color"`
+	BackgroundColor string `json:"background_color"`
+	FooterText      string `json:"footer_text"`
+}
+
+type codeIssuedResponse struct {
+	Message              string `json:"message"`
+	VerificationRequired bool   `json:"verification_required"`
+	ExpiresIn            int    `json:"expires_in"`
+	PasswordBreached     bool   `json:"password_breached,omitempty"`
+}
+
+type accountResponse struct {
+	ID         uuid.UUID `json:"id"`
+	StatusCode string    `json:"status_code"`
+	RoleCode   string    `json:"role_code"`
+}
+
+type authResponse struct {
+	AccessToken           string          `json:"access_token"`
+	TokenType             string          `json:"token_type"`
+	ExpiresIn             int             `json:"expires_in"`
+	RefreshToken          string          `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time       `json:"refresh_token_expires_at"`
+	RefreshTokenExpiresIn int             `json:"refresh_token_expires_in"`
+	RememberMe            bool            `json:"remember_me"`
+	Scope                 string          `json:"scope,omitempty"`
+	OrganizationID        *uuid.UUID      `json:"organization_id,omitempty"`
+	OrganizationRole      string          `json:"organization_role,omitempty"`
+	DeviceToken           string          `json:"device_token,omitempty"`
+	DeviceTokenExpiresAt  *time.Time      `json:"device_token_expires_at,omitempty"`
+	Account               accountResponse `json:"account"`
+}
+
+type elevatedTokenResponse struct {
+	AccessToken string   `json:"access_token"`
+	TokenType   string   `json:"token_type"`
+	ExpiresIn   int      `json:"expires_in"`
+	AuthTime    int64    `json:"auth_time"`
+	AMR         []string `json:"amr"`
+	ACR         string   `json:"acr"`
+}
+
+type mfaEnrollmentResponse struct {
+	MFAEnrollmentRequired bool            `json:"mfa_enrollment_required"`
+	AccessToken           string          `json:"access_token"`
+	TokenType             string          `json:"token_type"`
+	ExpiresIn             int             `json:"expires_in"`
+	Account               accountResponse `json:"account"`
+}
+
+type passwordChangeResponse struct {
+	PasswordChangeRequired bool            `json:"password_change_required"`
+	AccessToken            string          `json:"access_token"`
+	TokenType              string          `json:"token_type"`
+	ExpiresIn              int             `json:"expires_in"`
+	Account                accountResponse `json:"account"`
+}
+
+type consentRequiredResponse struct {
+	ConsentRequired bool                    `json:"consent_required"`
+	AccessToken     string                  `json:"access_token"`
+	TokenType       string                  `json:"token_type"`
+	ExpiresIn       int                     `json:"expires_in"`
+	Account         accountResponse         `json:"account"`
+	Documents       []legalDocumentResponse `json:"documents"`
+}
+
+type legalDocumentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Kind        string    `json:"kind"`
+	Version     string    `json:"version"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type legalDocumentsResponse struct {
+	Documents []legalDocumentResponse `json:"documents"`
+}
+
+type consentResponse struct {
+	ID         uuid.UUID `json:"id"`
+	DocumentID uuid.UUID `json:"document_id"`
+	Kind       string    `json:"kind"`
+	Version    string    `json:"version"`
+	IPAddress  *string   `json:"ip_address,omitempty"`
+	UserAgent  *string   `json:"user_agent,omitempty"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+// consentsResponse lists the consents of an account and, to its holder, the
+// current legal documents still to accept.
+type consentsResponse struct {
+	Consents []consentResponse       `json:"consents"`
+	Pending  []legalDocumentResponse `json:"pending,omitempty"`
+}
+
+type accountDeletionResponse struct {
+	Message string    `json:"message"`
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+type dataExportResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+type dataExportsResponse struct {
+	Exports []dataExportResponse `json:"exports"`
+}
+
+type passwordStrengthResponse struct {
+	Score        int                  `json:"score"`
+	MinScore     int                  `json:"min_score"`
+	Acceptable   bool                 `json:"acceptable"`
+	GuessesLog10 float64              `json:"guesses_log10"`
+	Warning      string               `json:"warning,omitempty"`
+	Suggestions  []string             `json:"suggestions"`
+	Fields       []fieldErrorResponse `json:"fields,omitempty"`
+}
+
+type mfaChallengeResponse struct {
+	MFARequired bool     `json:"mfa_required"`
+	MFAToken    string   `json:"mfa_token"`
+	MFAMethods  []string `json:"mfa_methods"`
+	ExpiresIn   int      `json:"expires_in"`
+}
+
+type mfaFactorResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Type        string     `json:"type"`
+	Confirmed   bool       `json:"confirmed"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type mfaFactorsResponse struct {
+	Factors []mfaFactorResponse `json:"factors"`
+}
+
+type totpEnrollmentResponse struct {
+	FactorID        uuid.UUID `json:"factor_id"`
+	Secret          string    `json:"secret"`
+	ProvisioningURI string    `json:"provisioning_uri"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type remainingRecoveryCodesResponse struct {
+	Remaining int `json:"remaining"`
+}
+
+type passkeyCeremonyResponse struct {
+	CeremonyID uuid.UUID       `json:"ceremony_id"`
+	Options    json.RawMessage `json:"options"`
+	ExpiresIn  int             `json:"expires_in"`
+}
+
+type passkeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       *string    `json:"name,omitempty"`
+	Synced     bool       `json:"synced"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type passkeyRegistrationResponse struct {
+	passkeyResponse
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type trustedDeviceResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	IPAddress  *string    `json:"ip_address,omitempty"`
+	UserAgent  *string    `json:"user_agent,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type trustedDevicesResponse struct {
+	Devices []trustedDeviceResponse `json:"devices"`
+}
+
+type sessionResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	IPAddress   *string           `json:"ip_address,omitempty"`
+	UserAgent   *string           `json:"user_agent,omitempty"`
+	Device      string            `json:"device,omitempty"`
+	Location    *locationResponse `json:"location,omitempty"`
+	Current     bool              `json:"current"`
+	CreatedAt   time.Time         `json:"created_at"`
+	RefreshedAt time.Time         `json:"refreshed_at"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+}
+
+type locationResponse struct {
+	City    string `json:"city,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+type sessionsResponse struct {
+	Sessions []sessionResponse `json:"sessions"`
+}
+
+// tokenResponse is the token endpoint response of RFC 6749 section 5.1, with
+// the ID token of OpenID Connect and the issued token type of RFC 8693.
+type tokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	RefreshToken    string `json:"refresh_token,omitempty"`
+	IDToken         string `json:"id_token,omitempty"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// deviceAuthorizationResponse is the device authorization response of
+// RFC 8628 section 3.2.
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceRequestResponse struct {
+	ClientName string `json:"client_name"`
+	Scope      string `json:"scope,omitempty"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// userInfoResponse carries the standard claims of OpenID Connect Core
+// section 5.1.
+type userInfoResponse struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+// introspectionResponse follows RFC 7662 section 2.2; times are Unix seconds.
+type introspectionResponse struct {
+	Active    bool   `json:"active"`
+	TokenType string `json:"token_type,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	// Role and Status are extensions describing the account.
+	Role             string   `json:"role,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	Permissions      []string `json:"permissions,omitempty"`
+	OrganizationID   string   `json:"org_id,omitempty"`
+	OrganizationRole string   `json:"org_role,omitempty"`
+	TokenID          string   `json:"jti,omitempty"`
+	SessionID        string   `json:"sid,omitempty"`
+	IssuedAt         int64    `json:"iat,omitempty"`
+	ExpiresAt        int64    `json:"exp,omitempty"`
+	// Confirmation is the RFC 7800 cnf member of DPoP-bound tokens.
+	Confirmation *confirmationResponse `json:"cnf,omitempty"`
+	// Actor is the RFC 8693 act member of delegated and impersonation
+	// tokens.
+	Actor *actorResponse `json:"act,omitempty"`
+}
+
+type batchIntrospectionResponse struct {
+	Tokens []introspectionResponse `json:"tokens"`
+}
+
+type confirmationResponse struct {
+	KeyThumbprint string `json:"jkt"`
+}
+
+type actorResponse struct {
+	Subject      string `json:"sub"`
+	ClientID     string `json:"client_id,omitempty"`
+	Impersonator bool   `json:"impersonator,omitempty"`
+}
+
+type passkeysResponse struct {
+	Passkeys []passkeyResponse `json:"passkeys"`
+}
+
+type apiKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// createdAPIKeyResponse is the only response that carries the key itself.
+type createdAPIKeyResponse struct {
+	apiKeyResponse
+	Key string `json:"key"`
+}
+
+type apiKeysResponse struct {
+	APIKeys []apiKeyResponse `json:"api_keys"`
+}
+
+type authMethodResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Provider    string     `json:"provider"`
+	IsVerified  bool       `json:"is_verified"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+type authMethodsResponse struct {
+	Methods []authMethodResponse `json:"methods"`
+}
+
+// exportedAccountResponse is an account with its auth methods, one line of
+// an NDJSON export or an entry of search results.
+type exportedAccountResponse struct {
+	Cursor      string                       `json:"cursor"`
+	ID          uuid.UUID                    `json:"id"`
+	RoleCode    string                       `json:"role_code"`
+	StatusCode  string                       `json:"status_code"`
+	CreatedAt   time.Time                    `json:"created_at"`
+	AuthMethods []exportedAuthMethodResponse `json:"auth_methods"`
+}
+
+type accountSearchResponse struct {
+	Accounts   []exportedAccountResponse `json:"accounts"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
+
+type exportedAuthMethodResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Provider    string     `json:"provider"`
+	ProviderID  string     `json:"provider_id"`
+	IsVerified  bool       `json:"is_verified"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+type accountBanResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	AccountID      uuid.UUID  `json:"account_id"`
+	PreviousStatus string     `json:"previous_status"`
+	Reason         string     `json:"reason"`
+	BannedBy       *uuid.UUID `json:"banned_by,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	LiftedAt       *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy       *uuid.UUID `json:"lifted_by,omitempty"`
+	LiftReason     string     `json:"lift_reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type accountBansResponse struct {
+	Bans []accountBanResponse `json:"bans"`
+}
+
+type impersonationResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	AccountID uuid.UUID  `json:"account_id"`
+	AdminID   *uuid.UUID `json:"admin_id,omitempty"`
+	Reason    string     `json:"reason"`
+	IPAddress *string    `json:"ip_address,omitempty"`
+	UserAgent *string    `json:"user_agent,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type impersonationsResponse struct {
+	Impersonations []impersonationResponse `json:"impersonations"`
+}
+
+type auditEventResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Action    string            `json:"action"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	TargetID  *uuid.UUID        `json:"target_id,omitempty"`
+	IPAddress *string           `json:"ip_address,omitempty"`
+	UserAgent *string           `json:"user_agent,omitempty"`
+	Details   map[string]string `json:"details"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type auditEventsResponse struct {
+	Events     []auditEventResponse `json:"events"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// securityActivityResponse is an audit event as its account sees it, without
+// the actor and target.
+type securityActivityResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Action    string            `json:"action"`
+	IPAddress *string           `json:"ip_address,omitempty"`
+	UserAgent *string           `json:"user_agent,omitempty"`
+	Details   map[string]string `json:"details"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type securityActivitiesResponse struct {
+	Activity   []securityActivityResponse `json:"activity"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+}
+
+type impersonationTokenResponse struct {
+	AccessToken   string                `json:"access_token"`
+	TokenType     string                `json:"token_type"`
+	ExpiresIn     int                   `json:"expires_in"`
+	Impersonation impersonationResponse `json:"impersonation"`
+}
+
+type roleChangeResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	AccountID    uuid.UUID  `json:"account_id"`
+	PreviousRole string     `json:"previous_role"`
+	Role         string     `json:"role"`
+	ChangedBy    *uuid.UUID `json:"changed_by,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type roleChangesResponse struct {
+	RoleChanges []roleChangeResponse `json:"role_changes"`
+}
+
+type roleResponse struct {
+	Code        string    `json:"code"`
+	Description string    `json:"description,omitempty"`
+	System      bool      `json:"system"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type rolesResponse struct {
+	Roles []roleResponse `json:"roles"`
+}
+
+type webhookEndpointResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	URL         string     `json:"url"`
+	Description string     `json:"description,omitempty"`
+	Events      []string   `json:"events"`
+	IsActive    bool       `json:"is_active"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// createdWebhookEndpointResponse and webhookSecretResponse are the only
+// responses that carry a signing secret.
+type createdWebhookEndpointResponse struct {
+	webhookEndpointResponse
+	Secret string `json:"secret"`
+}
+
+type webhookSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+type webhookEndpointsResponse struct {
+	Webhooks []webhookEndpointResponse `json:"webhooks"`
+}
+
+type webhookDeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	EndpointID     uuid.UUID  `json:"endpoint_id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	Event          string     `json:"event"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type webhookDeliveriesResponse struct {
+	Deliveries []webhookDeliveryResponse `json:"deliveries"`
+}
+
+// webhookDeliveryDetailResponse adds the payload sent and the log of every
+// attempt to a delivery.
+type webhookDeliveryDetailResponse struct {
+	webhookDeliveryResponse
+	Payload json.RawMessage                  `json:"payload"`
+	Log     []webhookDeliveryAttemptResponse `json:"log"`
+}
+
+type webhookDeliveryAttemptResponse struct {
+	ID         uuid.UUID `json:"id"`
+	StatusCode *int      `json:"status_code,omitempty"`
+	Error      *string   `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type organizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type organizationsResponse struct {
+	Organizations []organizationResponse `json:"organizations"`
+}
+
+type membershipResponse struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	AccountID      uuid.UUID `json:"account_id"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type membershipsResponse struct {
+	Members []membershipResponse `json:"members"`
+}
+
+type accountOrganizationResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Slug     string    `json:"slug"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type accountOrganizationsResponse struct {
+	Organizations []accountOrganizationResponse `json:"organizations"`
+}
+
+type invitationResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	InvitedBy      *uuid.UUID `json:"invited_by,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type invitationsResponse struct {
+	Invitations []invitationResponse `json:"invitations"`
+}
+
+type signingKeyResponse struct {
+	ID          string     `json:"kid"`
+	Algorithm   string     `json:"alg"`
+	ActivatesAt time.Time  `json:"activates_at"`
+	RetiredAt   *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type signingKeysResponse struct {
+	Keys []signingKeyResponse `json:"keys"`
+}
+
+type policyResponse struct {
+	OrganizationID          uuid.UUID `json:"organization_id"`
+	PasswordMinLength       int       `json:"password_min_length"`
+	PasswordRequiredClasses []string  `json:"password_required_classes"`
+	RequireMFA              bool      `json:"require_mfa"`
+	SessionMaxAge           int       `json:"session_max_age"`
+	AllowedProviders        []string  `json:"allowed_providers"`
+	UpdatedAt               time.Time `json:"updated_at"`
+}
+
+type brandingResponse struct {
+	OrganizationID  uuid.UUID `json:"organization_id"`
+	SenderName      string    `json:"sender_name,omitempty"`
+	SenderAddress   string    `json:"sender_address,omitempty"`
+	LogoURL         string    `json:"logo_url,omitempty"`
+	PrimaryColor    string    `json:"primary_color,omitempty"`
+	BackgroundColor string    `json:"background_color,omitempty"`
+	FooterText      string    `json:"footer_text,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type authorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+func newCodeIssuedResponse(message string, result *application.CodeIssuedResult) codeIssuedResponse {
+	return codeIssuedResponse{
+		Message:              message,
+		VerificationRequired: true,
+		ExpiresIn:            int(result.ExpiresIn.Seconds()),
+		PasswordBreached:     result.PasswordBreached,
+	}
+}
+
+func newAccountResponse(account *models.Account) accountResponse {
+	return accountResponse{
+		ID:         account.ID,
+		StatusCode: string(account.StatusCode),
+		RoleCode:   string(account.RoleCode),
+	}
+}
+
+func newAuthResponse(result *application.AuthResult) authResponse {
+	response := authResponse{
+		AccessToken:           result.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(time.Until(result.AccessTokenExpiresAt).Seconds()),
+		RefreshToken:          result.RefreshToken,
+		RefreshTokenExpiresAt: result.RefreshTokenExpiresAt,
+		RefreshTokenExpiresIn: int(time.Until(result.RefreshTokenExpiresAt).Seconds()),
+		RememberMe:            result.RememberMe,
+		Scope:                 string(result.Scope),
+		Account:               newAccountResponse(result.Account),
+	}
+	if result.Membership != nil {
+		response.OrganizationID = &result.Membership.OrganizationID
+		response.OrganizationRole = string(result.Membership.RoleCode)
+	}
+	if result.DeviceToken != "" {
+		response.DeviceToken = result.DeviceToken
+		response.DeviceTokenExpiresAt = &result.DeviceTokenExpiresAt
+	}
+	return response
+}
+
+func newMFAEnrollmentResponse(result *application.AuthResult) mfaEnrollmentResponse {
+	return mfaEnrollmentResponse{
+		MFAEnrollmentRequired: true,
+		AccessToken:           result.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(time.Until(result.AccessTokenExpiresAt).Seconds()),
+		Account:               newAccountResponse(result.Account),
+	}
+}
+
+func newPasswordChangeResponse(result *application.AuthResult) passwordChangeResponse {
+	return passwordChangeResponse{
+		PasswordChangeRequired: true,
+		AccessToken:            result.AccessToken,
+		TokenType:              "Bearer",
+		ExpiresIn:              int(time.Until(result.AccessTokenExpiresAt).Seconds()),
+		Account:                newAccountResponse(result.Account),
+	}
+}
+
+func newConsentRequiredResponse(result *application.AuthResult) consentRequiredResponse {
+	return consentRequiredResponse{
+		ConsentRequired: true,
+		AccessToken:     result.AccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int(time.Until(result.AccessTokenExpiresAt).Seconds()),
+		Account:         newAccountResponse(result.Account),
+		Documents:       newLegalDocumentResponses(result.PendingDocuments),
+	}
+}
+
+func newPasswordStrengthResponse(result *application.PasswordStrengthResult) passwordStrengthResponse {
+	suggestions := result.Strength.Suggestions
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+	return passwordStrengthResponse{
+		Score:        result.Strength.Score,
+		MinScore:     result.MinScore,
+		Acceptable:   len(result.Violations) == 0,
+		GuessesLog10: result.Strength.GuessesLog10,
+		Warning:      result.Strength.Warning,
+		Suggestions:  suggestions,
+		Fields:       newFieldErrorResponses(result.Violations),
+	}
+}
+
+func newFieldErrorResponses(violations []domain.FieldError) []fieldErrorResponse {
+	fields := make([]fieldErrorResponse, 0, len(violations))
+	for _, field := range violations {
+		fields = append(fields, fieldErrorResponse{Field: field.Field, Code: field.Code, Params: field.Params})
+	}
+	return fields
+}
+
+func newAuthMethodResponse(method *models.AuthMethod) authMethodResponse {
+	return authMethodResponse{
+		ID:          method.ID,
+		Provider:    string(method.ProviderCode),
+		IsVerified:  method.IsVerified,
+		LastLoginAt: method.LastLoginAt,
+	}
+}
+
+func newExportedAccountResponse(exported *application.ExportedAccount) exportedAccountResponse {
+	methods := make([]exportedAuthMethodResponse, 0, len(exported.AuthMethods))
+	for _, method := range exported.AuthMethods {
+		methods = append(methods, exportedAuthMethodResponse{
+			ID:          method.ID,
+			Provider:    string(method.ProviderCode),
+			ProviderID:  method.ProviderID,
+			IsVerified:  method.IsVerified,
+			LastLoginAt: method.LastLoginAt,
+		})
+	}
+	return exportedAccountResponse{
+		Cursor:      encodeAccountCursor(exported.Account.Cursor()),
+		ID:          exported.Account.ID,
+		RoleCode:    string(exported.Account.RoleCode),
+		StatusCode:  string(exported.Account.StatusCode),
+		CreatedAt:   exported.Account.CreatedAt,
+		AuthMethods: methods,
+	}
+}
+
+func newAccountSearchResponse(page *application.AccountPage) accountSearchResponse {
+	accounts := make([]exportedAccountResponse, 0, len(page.Accounts))
+	for _, account := range page.Accounts {
+		accounts = append(accounts, newExportedAccountResponse(account))
+	}
+	response := accountSearchResponse{Accounts: accounts}
+	if page.Next != nil {
+		response.NextCursor = encodeAccountCursor(*page.Next)
+	}
+	return response
+}
+
+func newMFAChallengeResponse(challenge *application.MFAChallengeResult) mfaChallengeResponse {
+	methods := make([]string, 0, len(challenge.Methods))
+	for _, method := range challenge.Methods {
+		methods = append(methods, string(method))
+	}
+
+	return mfaChallengeResponse{
+		MFARequired: true,
+		MFAToken:    challenge.Token,
+		MFAMethods:  methods,
+		ExpiresIn:   int(time.Until(challenge.ExpiresAt).Seconds()),
+	}
+}
+
+func newMFAFactorResponse(factor *models.MFAFactor) mfaFactorResponse {
+	return mfaFactorResponse{
+		ID:          factor.ID,
+		Type:        string(factor.FactorType),
+		Confirmed:   factor.ConfirmedAt != nil,
+		ConfirmedAt: factor.ConfirmedAt,
+		CreatedAt:   factor.CreatedAt,
+	}
+}
+
+func newPasskeyCeremonyResponse(result *application.PasskeyCeremonyResult) passkeyCeremonyResponse {
+	return passkeyCeremonyResponse{
+		CeremonyID: result.CeremonyID,
+		Options:    result.Options,
+		ExpiresIn:  int(time.Until(result.ExpiresAt).Seconds()),
+	}
+}
+
+func newTrustedDeviceResponse(device *models.TrustedDevice) trustedDeviceResponse {
+	return trustedDeviceResponse{
+		ID:         device.ID,
+		IPAddress:  device.IPAddress,
+		UserAgent:  device.UserAgent,
+		LastUsedAt: device.LastUsedAt,
+		ExpiresAt:  device.ExpiresAt,
+		CreatedAt:  device.CreatedAt,
+	}
+}
+
+func newSessionResponse(session *application.Session) sessionResponse {
+	response := sessionResponse{
+		ID:          session.ID,
+		IPAddress:   session.IPAddress,
+		UserAgent:   session.UserAgent,
+		Device:      session.Device,
+		Current:     session.Current,
+		CreatedAt:   session.CreatedAt,
+		RefreshedAt: session.RefreshedAt,
+		ExpiresAt:   session.ExpiresAt,
+	}
+	if session.Location != nil {
+		response.Location = &locationResponse{
+			City:    session.Location.City,
+			Region:  session.Location.Region,
+			Country: session.Location.Country,
+		}
+	}
+	return response
+}
+
+func newTokenResponse(result *application.AuthResult) tokenResponse {
+	return tokenResponse{
+		AccessToken:  result.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(result.AccessTokenExpiresAt).Seconds()),
+		RefreshToken: result.RefreshToken,
+		Scope:        string(result.Scope),
+	}
+}
+
+func newUserInfoResponse(info *application.UserInfo) userInfoResponse {
+	response := userInfoResponse{Subject: info.Subject.String()}
+	if info.Email != "" {
+		response.Email = info.Email
+		response.EmailVerified = &info.EmailVerified
+	}
+	return response
+}
+
+func newClientTokenResponse(token *application.ClientToken) tokenResponse {
+	return tokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(time.Until(token.ExpiresAt).Seconds()),
+		Scope:       token.Scope,
+	}
+}
+
+func newExchangedTokenResponse(token *application.ExchangedToken) tokenResponse {
+	return tokenResponse{
+		AccessToken:     token.AccessToken,
+		IssuedTokenType: domain.TokenTypeURIAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int(time.Until(token.ExpiresAt).Seconds()),
+		Scope:           token.Scope,
+	}
+}
+
+func newIntrospectionResponse(introspection *application.TokenIntrospection) introspectionResponse {
+	if !introspection.Active {
+		return introspectionResponse{}
+	}
+
+	response := introspectionResponse{
+		Active:           true,
+		TokenType:        introspection.TokenType,
+		Scope:            string(introspection.Scope),
+		Subject:          introspection.Subject.String(),
+		Role:             string(introspection.RoleCode),
+		Status:           string(introspection.StatusCode),
+		Permissions:      introspection.Permissions,
+		OrganizationRole: string(introspection.OrganizationRole),
+		TokenID:          introspection.TokenID,
+		IssuedAt:         introspection.IssuedAt.Unix(),
+	}
+	if !introspection.ExpiresAt.IsZero() {
+		response.ExpiresAt = introspection.ExpiresAt.Unix()
+	}
+	if introspection.ClientID != "" {
+		response.Subject = introspection.ClientID
+		response.ClientID = introspection.ClientID
+	}
+	if introspection.SessionID != uuid.Nil {
+		response.SessionID = introspection.SessionID.String()
+	}
+	if introspection.OrganizationID != uuid.Nil {
+		response.OrganizationID = introspection.OrganizationID.String()
+	}
+	if introspection.KeyThumbprint != "" {
+		response.Confirmation = &confirmationResponse{KeyThumbprint: introspection.KeyThumbprint}
+	}
+	if actor := introspection.Actor; actor != nil {
+		response.Actor = &actorResponse{Subject: actor.Subject, ClientID: actor.ClientID, Impersonator: actor.Impersonator}
+	}
+	return response
+}
+
+func newAPIKeyResponse(key *models.APIKey) apiKeyResponse {
+	return apiKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.KeyPrefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func newAccountBanResponse(ban *models.AccountBan) accountBanResponse {
+	return accountBanResponse{
+		ID:             ban.ID,
+		AccountID:      ban.AccountID,
+		PreviousStatus: string(ban.PreviousStatus),
+		Reason:         ban.Reason,
+		BannedBy:       ban.BannedBy,
+		ExpiresAt:      ban.ExpiresAt,
+		LiftedAt:       ban.LiftedAt,
+		LiftedBy:       ban.LiftedBy,
+		LiftReason:     ban.LiftReason,
+		CreatedAt:      ban.CreatedAt,
+	}
+}
+
+func newImpersonationResponse(impersonation *models.Impersonation) impersonationResponse {
+	return impersonationResponse{
+		ID:        impersonation.ID,
+		AccountID: impersonation.AccountID,
+		AdminID:   impersonation.AdminID,
+		Reason:    impersonation.Reason,
+		IPAddress: impersonation.IPAddress,
+		UserAgent: impersonation.UserAgent,
+		ExpiresAt: impersonation.ExpiresAt,
+		EndedAt:   impersonation.EndedAt,
+		CreatedAt: impersonation.CreatedAt,
+	}
+}
+
+func newAuditEventsResponse(page *application.AuditEventPage) auditEventsResponse {
+	events := make([]auditEventResponse, 0, len(page.Events))
+	for _, event := range page.Events {
+		events = append(events, auditEventResponse{
+			ID:        event.ID,
+			Action:    string(event.Action),
+			ActorID:   event.ActorID,
+			TargetID:  event.TargetID,
+			IPAddress: event.IPAddress,
+			UserAgent: event.UserAgent,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	response := auditEventsResponse{Events: events}
+	if page.Next != nil {
+		response.NextCursor = encodeAuditEventCursor(*page.Next)
+	}
+	return response
+}
+
+func newSecurityActivitiesResponse(page *application.AuditEventPage) securityActivitiesResponse {
+	activity := make([]securityActivityResponse, 0, len(page.Events))
+	for _, event := range page.Events {
+		activity = append(activity, securityActivityResponse{
+			ID:        event.ID,
+			Action:    string(event.Action),
+			IPAddress: event.IPAddress,
+			UserAgent: event.UserAgent,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	response := securityActivitiesResponse{Activity: activity}
+	if page.Next != nil {
+		response.NextCursor = encodeAuditEventCursor(*page.Next)
+	}
+	return response
+}
+
+func newDataExportResponse(export *models.DataExport, downloadURL string) dataExportResponse {
+	return dataExportResponse{
+		ID:          export.ID,
+		Status:      string(export.Status),
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+		DownloadURL: downloadURL,
+	}
+}
+
+func newLegalDocumentResponse(document *models.LegalDocument) legalDocumentResponse {
+	return legalDocumentResponse{
+		ID:          document.ID,
+		Kind:        string(document.Kind),
+		Version:     document.Version,
+		URL:         document.URL,
+		PublishedAt: document.PublishedAt,
+	}
+}
+
+func newLegalDocumentResponses(documents []*models.LegalDocument) []legalDocumentResponse {
+	response := make([]legalDocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		response = append(response, newLegalDocumentResponse(document))
+	}
+	return response
+}
+
+func newConsentResponses(consents []*models.Consent) []consentResponse {
+	response := make([]consentResponse, 0, len(consents))
+	for _, consent := range consents {
+		response = append(response, consentResponse{
+			ID:         consent.ID,
+			DocumentID: consent.DocumentID,
+			Kind:       string(consent.Kind),
+			Version:    consent.Version,
+			IPAddress:  consent.IPAddress,
+			UserAgent:  consent.UserAgent,
+			AcceptedAt: consent.AcceptedAt,
+		})
+	}
+	return response
+}
+
+func newRoleChangeResponse(change *models.RoleChange) roleChangeResponse {
+	return roleChangeResponse{
+		ID:           change.ID,
+		AccountID:    change.AccountID,
+		PreviousRole: string(change.PreviousRole),
+		Role:         string(change.Role),
+		ChangedBy:    change.ChangedBy,
+		Reason:       change.Reason,
+		CreatedAt:    change.CreatedAt,
+	}
+}
+
+func newRoleResponse(role *models.Role) roleResponse {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return roleResponse{
+		Code:        string(role.Code),
+		Description: role.Description,
+		System:      role.System,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+	}
+}
+
+func newWebhookEndpointResponse(endpoint *models.WebhookEndpoint) webhookEndpointResponse {
+	events := make([]string, 0, len(endpoint.Events))
+	for _, event := range endpoint.Events {
+		events = append(events, string(event))
+	}
+	return webhookEndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      events,
+		IsActive:    endpoint.IsActive,
+		CreatedBy:   endpoint.CreatedBy,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
+
+func newWebhookDeliveryResponse(delivery *models.WebhookDelivery) webhookDeliveryResponse {
+	return webhookDeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		Event:          string(delivery.Event),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func newWebhookDeliveryDetailResponse(delivery *models.WebhookDelivery, attempts []*models.WebhookDeliveryAttempt) webhookDeliveryDetailResponse {
+	log := make([]webhookDeliveryAttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		log = append(log, webhookDeliveryAttemptResponse{
+			ID:         attempt.ID,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.Duration.Milliseconds(),
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+	return webhookDeliveryDetailResponse{
+		webhookDeliveryResponse: newWebhookDeliveryResponse(delivery),
+		Payload:                 delivery.Payload,
+		Log:                     log,
+	}
+}
+
+func newOrganizationResponse(organization *models.Organization) organizationResponse {
+	return organizationResponse{
+		ID:        organization.ID,
+		Slug:      organization.Slug,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
+
+func newMembershipResponse(membership *models.Membership) membershipResponse {
+	return membershipResponse{
+		OrganizationID: membership.OrganizationID,
+		AccountID:      membership.AccountID,
+		Role:           string(membership.RoleCode),
+		CreatedAt:      membership.CreatedAt,
+		UpdatedAt:      membership.UpdatedAt,
+	}
+}
+
+func newAccountOrganizationResponse(organization *application.AccountOrganization) accountOrganizationResponse {
+	return accountOrganizationResponse{
+		ID:       organization.Organization.ID,
+		Slug:     organization.Organization.Slug,
+		Name:     organization.Organization.Name,
+		Role:     string(organization.Membership.RoleCode),
+		JoinedAt: organization.Membership.CreatedAt,
+	}
+}
+
+func newSigningKeysResponse(keys []*models.SigningKey) signingKeysResponse {
+	response := make([]signingKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, signingKeyResponse{
+			ID:          key.ID,
+			Algorithm:   key.Algorithm,
+			ActivatesAt: key.ActivatesAt,
+			RetiredAt:   key.RetiredAt,
+			ExpiresAt:   key.ExpiresAt,
+			CreatedAt:   key.CreatedAt,
+		})
+	}
+	return signingKeysResponse{Keys: response}
+}
+
+func newInvitationResponse(invitation *models.Invitation) invitationResponse {
+	return invitationResponse{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           string(invitation.RoleCode),
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+		CreatedAt:      invitation.CreatedAt,
+	}
+}
+
+func newPolicyResponse(policy *models.AuthPolicy) policyResponse {
+	response := policyResponse{
+		OrganizationID:          policy.OrganizationID,
+		PasswordMinLength:       policy.PasswordMinLength,
+		PasswordRequiredClasses: make([]string, 0, len(policy.PasswordRequiredClasses)),
+		RequireMFA:              policy.RequireMFA,
+		SessionMaxAge:           int(policy.SessionMaxAge.Seconds()),
+		AllowedProviders:        make([]string, 0, len(policy.AllowedProviders)),
+		UpdatedAt:               policy.UpdatedAt,
+	}
+	for _, class := range policy.PasswordRequiredClasses {
+		response.PasswordRequiredClasses = append(response.PasswordRequiredClasses, string(class))
+	}
+	for _, provider := range policy.AllowedProviders {
+		response.AllowedProviders = append(response.AllowedProviders, string(provider))
+	}
+	return response
+}
+
+func newBrandingResponse(branding *models.Branding) brandingResponse {
+	return brandingResponse{
+		OrganizationID:  branding.OrganizationID,
+		SenderName:      branding.SenderName,
+		SenderAddress:   branding.SenderAddress,
+		LogoURL:         branding.LogoURL,
+		PrimaryColor:    branding.PrimaryColor,
+		BackgroundColor: branding.BackgroundColor,
+		FooterText:      branding.FooterText,
+		UpdatedAt:       branding.UpdatedAt,
+	}
+}
+
+func newPasskeyResponse(passkey *models.PasskeyCredential) passkeyResponse {
+	return passkeyResponse{
+		ID:         passkey.ID,
+		Name:       passkey.Name,
+		Synced:     passkey.BackupState,
+		LastUsedAt: passkey.LastUsedAt,
+		CreatedAt:  passkey.CreatedAt,
+	}
+}
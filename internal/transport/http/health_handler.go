@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+)
+
+type dependencyHealthResponse struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Version    string `json:"version,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type readinessResponse struct {
+	Status       string                     `json:"status"`
+	Dependencies []dependencyHealthResponse `json:"dependencies"`
+}
+
+// HealthHandler serves the probes of an orchestrator such as Kubernetes.
+// Readiness reports why a dependency is unavailable, so it belongs on an
+// internal listener.
+type HealthHandler struct {
+	health *application.HealthService
+}
+
+func NewHealthHandler(health *application.HealthService) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+func (h *HealthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.Live)
+	mux.HandleFunc("GET /readyz", h.Ready)
+}
+
+// Live answers as long as the process serves requests. It checks no
+// dependency, so an outage of one does not get every instance restarted.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Ready answers 200 when every dependency is available and 503 otherwise,
+// or once the service is draining, with the outcome of each check.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.health.Check(r.Context())
+
+	resp := readinessResponse{Status: "ok", Dependencies: make([]dependencyHealthResponse, 0, len(report.Dependencies))}
+	status := http.StatusOK
+	switch {
+	case report.Draining:
+		resp.Status = "draining"
+		status = http.StatusServiceUnavailable
+	case !report.Ready():
+		resp.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+	for _, dependency := range report.Dependencies {
+		entry := dependencyHealthResponse{Name: dependency.Name, Status: "ok", Version: dependency.Version, DurationMS: dependency.Duration.Milliseconds(), Error: dependency.Error}
+		if !dependency.Healthy() {
+			entry.Status = "unavailable"
+		}
+		resp.Dependencies = append(resp.Dependencies, entry)
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, resp)
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+)
+
+// jwksMaxAge bounds how long consumers may cache the key set. Keys are
+// pre-published for longer than this before they start signing.
+const jwksMaxAge = "public, max-age=300"
+
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type jwksResponse struct {
+	Keys []jwk `json:"keys"`
+}
+
+type JWKSHandler struct {
+	keys ports.KeySet
+}
+
+func NewJWKSHandler(keys ports.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+func (h *JWKSHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /.well-known/jwks.json", h.JWKS)
+}
+
+func (h *JWKSHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	published := h.keys.PublicKeys()
+
+	keys := make([]jwk, 0, len(published))
+	for _, key := range published {
+		if encoded, ok := encodeJWK(key); ok {
+			keys = append(keys, encoded)
+		}
+	}
+
+	w.Header().Set("Cache-Control", jwksMaxAge)
+	writeJSON(w, http.StatusOK, jwksResponse{Keys: keys})
+}
+
+func encodeJWK(key models.PublicSigningKey) (jwk, bool) {
+	encoded := jwk{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+
+	switch k := key.Key.(type) {
+	case *rsa.PublicKey:
+		encoded.KeyType = "RSA"
+		encoded.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		encoded.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		encoded.KeyType = "OKP"
+		encoded.Curve = "Ed25519"
+		encoded.X = base64.RawURLEncoding.EncodeToString(k)
+	default:
+		return jwk{}, false
+	}
+
+	return encoded, true
+}
@@ -0,0 +1,276 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type MFAHandler struct {
+	service *application.MFAService
+	auth    *Authenticator
+	limits  *RateLimiter
+}
+
+func NewMFAHandler(service *application.MFAService, auth *Authenticator, limits *RateLimiter) *MFAHandler {
+	return &MFAHandler{service: service, auth: auth, limits: limits}
+}
+
+func (h *MFAHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/auth/mfa/verify", h.limits.Verification("mfa_token", h.Verify))
+	mux.HandleFunc("GET /v1/auth/mfa/factors", h.auth.AllowMFAEnrollment(h.ListFactors))
+	mux.HandleFunc("POST /v1/auth/mfa/totp", h.auth.AllowMFAEnrollment(h.EnrollTOTP))
+	mux.HandleFunc("POST /v1/auth/mfa/totp/confirm", h.auth.AllowMFAEnrollment(h.ConfirmTOTP))
+	mux.HandleFunc("POST /v1/auth/mfa/totp/disable", h.limits.Verification("", h.auth.RequireAccountHolder(h.DisableTOTP)))
+	mux.HandleFunc("POST /v1/auth/mfa/sms", h.auth.AllowMFAEnrollment(h.EnrollSMS))
+	mux.HandleFunc("POST /v1/auth/mfa/sms/confirm", h.auth.AllowMFAEnrollment(h.ConfirmSMS))
+	mux.HandleFunc("POST /v1/auth/mfa/sms/code", h.auth.RequireAccountHolder(h.SendSMSCode))
+	mux.HandleFunc("POST /v1/auth/mfa/sms/disable", h.auth.RequireAccountHolder(h.DisableSMS))
+	mux.HandleFunc("POST /v1/auth/mfa/sms/challenge", h.limits.Verification("mfa_token", h.SendChallengeSMS))
+	mux.HandleFunc("GET /v1/auth/mfa/recovery-codes", h.auth.Require(h.RemainingRecoveryCodes))
+	mux.HandleFunc("POST /v1/auth/mfa/recovery-codes", h.auth.RequireRecentAuth(domain.StepUpMaxAge, h.RegenerateRecoveryCodes))
+	mux.HandleFunc("GET /v1/auth/mfa/trusted-devices", h.auth.Require(h.ListTrustedDevices))
+	mux.HandleFunc("DELETE /v1/auth/mfa/trusted-devices", h.auth.RequireAccountHolder(h.RevokeAllTrustedDevices))
+	mux.HandleFunc("DELETE /v1/auth/mfa/trusted-devices/{id}", h.auth.RequireAccountHolder(h.RevokeTrustedDevice))
+}
+
+func (h *MFAHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var req verifyMFARequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.VerifyChallenge(r.Context(), req.MFAToken, domain.MFAFactorType(req.Method), req.Code, req.RememberDevice, clientInfo(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newAuthResponse(result))
+}
+
+func (h *MFAHandler) ListFactors(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	factors, err := h.service.ListFactors(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]mfaFactorResponse, 0, len(factors))
+	for _, factor := range factors {
+		response = append(response, newMFAFactorResponse(factor))
+	}
+	writeJSON(w, http.StatusOK, mfaFactorsResponse{Factors: response})
+}
+
+func (h *MFAHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	enrollment, err := h.service.EnrollTOTP(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, totpEnrollmentResponse{
+		FactorID:        enrollment.FactorID,
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+func (h *MFAHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req mfaCodeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	recoveryCodes, err := h.service.ConfirmTOTP(r.Context(), claims.AccountID, req.Code)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeRecoveryCodes(w, recoveryCodes)
+}
+
+func (h *MFAHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req mfaCodeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), claims.AccountID, req.Code); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MFAHandler) EnrollSMS(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req enrollSMSRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.EnrollSMS(r.Context(), claims.AccountID, req.PhoneNumber); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *MFAHandler) ConfirmSMS(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req mfaCodeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	recoveryCodes, err := h.service.ConfirmSMS(r.Context(), claims.AccountID, req.Code)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeRecoveryCodes(w, recoveryCodes)
+}
+
+func (h *MFAHandler) SendSMSCode(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	if err := h.service.SendSMSCode(r.Context(), claims.AccountID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *MFAHandler) DisableSMS(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req mfaCodeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.DisableSMS(r.Context(), claims.AccountID, req.Code); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MFAHandler) SendChallengeSMS(w http.ResponseWriter, r *http.Request) {
+	var req sendSMSChallengeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.SendChallengeSMS(r.Context(), req.MFAToken); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *MFAHandler) RemainingRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	remaining, err := h.service.RemainingRecoveryCodes(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, remainingRecoveryCodesResponse{Remaining: remaining})
+}
+
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	recoveryCodes, err := h.service.RegenerateRecoveryCodes(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, recoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+func (h *MFAHandler) ListTrustedDevices(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	devices, err := h.service.ListTrustedDevices(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]trustedDeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		response = append(response, newTrustedDeviceResponse(device))
+	}
+	writeJSON(w, http.StatusOK, trustedDevicesResponse{Devices: response})
+}
+
+func (h *MFAHandler) RevokeTrustedDevice(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	deviceID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.RevokeTrustedDevice(r.Context(), claims.AccountID, deviceID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MFAHandler) RevokeAllTrustedDevices(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	if err := h.service.RevokeAllTrustedDevices(r.Context(), claims.AccountID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeRecoveryCodes answers a factor confirmation: with the recovery codes
+// when the first factor was enrolled, or with no content otherwise.
+func writeRecoveryCodes(w http.ResponseWriter, recoveryCodes []string) {
+	if len(recoveryCodes) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
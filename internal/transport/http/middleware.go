@@ -0,0 +1,367 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/logging"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type claimsKey struct{}
+
+// Authenticator guards endpoints that require a signed-in account.
+type Authenticator struct {
+	validator *application.TokenValidator
+	dpop      *application.DPoPValidator
+}
+
+func NewAuthenticator(validator *application.TokenValidator, dpop *application.DPoPValidator) *Authenticator {
+	return &Authenticator{validator: validator, dpop: dpop}
+}
+
+// Require rejects requests without a valid, unrestricted bearer access token
+// and exposes the token claims to next through the request context. Tokens
+// bound to a DPoP key must come with the DPoP scheme and a proof of that key.
+func (a *Authenticator) Require(next http.HandlerFunc) http.HandlerFunc {
+	return a.authenticate(next, domain.TokenScopeFull)
+}
+
+// AllowMFAEnrollment is like Require but also accepts the restricted tokens
+// issued to accounts that must enroll a second factor. It guards the
+// enrollment endpoints.
+func (a *Authenticator) AllowMFAEnrollment(next http.HandlerFunc) http.HandlerFunc {
+	return a.authenticate(next, domain.TokenScopeMFAEnrollment)
+}
+
+// AllowPasswordChange is like Require but also accepts the restricted tokens
+// issued to accounts whose password has expired. It guards the password
+// change endpoint.
+func (a *Authenticator) AllowPasswordChange(next http.HandlerFunc) http.HandlerFunc {
+	return a.authenticate(next, domain.TokenScopePasswordChange)
+}
+
+// AllowConsent is like Require but also accepts the restricted tokens issued
+// to accounts yet to accept the current legal documents. It guards the
+// consent endpoints.
+func (a *Authenticator) AllowConsent(next http.HandlerFunc) http.HandlerFunc {
+	return a.authenticate(next, domain.TokenScopeConsent)
+}
+
+// RequireAccountHolder is like Require but refuses the tokens of
+// administrators impersonating the account. It guards the endpoints that
+// change how the account signs in or end its sessions.
+func (a *Authenticator) RequireAccountHolder(next http.HandlerFunc) http.HandlerFunc {
+	return a.Require(func(w http.ResponseWriter, r *http.Request) {
+		if claimsFromContext(r.Context()).Impersonated() {
+			writeError(w, r, domain.ErrImpersonationNotAllowed)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// RequireAdmin is like Require but also demands a token of an ADMIN account.
+// It guards the administration endpoints.
+func (a *Authenticator) RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return a.Require(func(w http.ResponseWriter, r *http.Request) {
+		if claimsFromContext(r.Context()).RoleCode != domain.RoleAdmin {
+			writeError(w, r, domain.ErrAdminRequired)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// RequireRecentAuth is like Require but also demands an elevated token from a
+// reauthentication no older than maxAge. Other requests are answered with the
+// RFC 9470 insufficient_user_authentication challenge so the client knows to
+// step up through /v1/auth/reauthenticate. Impersonation tokens are refused,
+// as their holder cannot step up.
+func (a *Authenticator) RequireRecentAuth(maxAge time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return a.RequireAccountHolder(func(w http.ResponseWriter, r *http.Request) {
+		if !claimsFromContext(r.Context()).AuthenticatedWithin(maxAge, time.Now()) {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_user_authentication", max_age=%d`, int(maxAge.Seconds())))
+			writeError(w, r, domain.ErrReauthenticationRequired)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// RequireScope guards endpoints open to least-privilege tokens. It accepts
+// unrestricted account tokens and the tokens and API keys whose scope
+// includes scope; others are answered with the RFC 6750 insufficient_scope
+// challenge.
+func (a *Authenticator) RequireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := a.validate(w, r)
+		if !ok {
+			return
+		}
+
+		unrestricted := claims.HasAccount() && claims.Scope == domain.TokenScopeFull
+		if !unrestricted && !slices.Contains(strings.Fields(string(claims.Scope)), scope) {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, scope))
+			writeError(w, r, domain.ErrInsufficientScope)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims)))
+	}
+}
+
+// RequireAdminScope is like RequireScope but also demands a token of an
+// ADMIN account. It guards endpoints meant for services acting for an
+// administrator, such as identity providers.
+func (a *Authenticator) RequireAdminScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return a.RequireScope(scope, func(w http.ResponseWriter, r *http.Request) {
+		if claimsFromContext(r.Context()).RoleCode != domain.RoleAdmin {
+			writeError(w, r, domain.ErrAdminRequired)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// authenticate accepts unrestricted tokens and, when allowed is a restricted
+// scope, the tokens restricted to it. The endpoints open to restricted scopes
+// enroll credentials, so they refuse impersonation tokens.
+func (a *Authenticator) authenticate(next http.HandlerFunc, allowed domain.TokenScope) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := a.validate(w, r)
+		if !ok {
+			return
+		}
+
+		if allowed != domain.TokenScopeFull && claims.Impersonated() {
+			writeError(w, r, domain.ErrImpersonationNotAllowed)
+			return
+		}
+
+		switch claims.Scope {
+		case domain.TokenScopeFull:
+		case domain.TokenScopeMFAEnrollment:
+			if allowed != domain.TokenScopeMFAEnrollment {
+				writeError(w, r, domain.ErrMFAEnrollmentRequired)
+				return
+			}
+		case domain.TokenScopePasswordChange:
+			if allowed != domain.TokenScopePasswordChange {
+				writeError(w, r, domain.ErrPasswordChangeRequired)
+				return
+			}
+		case domain.TokenScopeConsent:
+			if allowed != domain.TokenScopeConsent {
+				writeError(w, r, domain.ErrConsentRequired)
+				return
+			}
+		default:
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeError(w, r, domain.ErrInvalidAccessToken)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims)))
+	}
+}
+
+// validate resolves the access token of r into the claims of its account,
+// answering the request itself when there is none.
+func (a *Authenticator) validate(w http.ResponseWriter, r *http.Request) (*models.AccessTokenClaims, bool) {
+	raw, scheme, ok := accessToken(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, r, domain.ErrInvalidAccessToken)
+		return nil, false
+	}
+
+	claims, err := a.validator.Validate(r.Context(), raw)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAccessToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+		writeError(w, r, err)
+		return nil, false
+	}
+
+	if err := a.checkBinding(r, claims, raw, scheme); err != nil {
+		if errors.Is(err, domain.ErrInvalidDPoPProof) {
+			w.Header().Set("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+			err = domain.ErrInvalidAccessToken
+		} else if errors.Is(err, domain.ErrInvalidAccessToken) {
+			w.Header().Set("WWW-Authenticate", `DPoP error="invalid_token"`)
+		}
+		writeError(w, r, err)
+		return nil, false
+	}
+
+	// Client tokens have no account to act on.
+	if !claims.HasAccount() {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		writeError(w, r, domain.ErrInvalidAccessToken)
+		return nil, false
+	}
+
+	logging.Add(r.Context(), slog.String("account_id", claims.AccountID.String()))
+	return claims, true
+}
+
+// checkBinding enforces RFC 9449 section 7: a bound token is only accepted
+// with the DPoP scheme and a proof for this request, signed by its key and
+// carrying its hash, while the DPoP scheme is refused for bearer tokens.
+func (a *Authenticator) checkBinding(r *http.Request, claims *models.AccessTokenClaims, raw, scheme string) error {
+	if claims.KeyThumbprint == "" {
+		if scheme == domain.TokenTypeDPoP {
+			return domain.ErrInvalidAccessToken
+		}
+		return nil
+	}
+	if scheme != domain.TokenTypeDPoP {
+		return domain.ErrInvalidAccessToken
+	}
+
+	thumbprint, err := dpopThumbprint(r, a.dpop, raw)
+	if err != nil {
+		return err
+	}
+	if thumbprint != claims.KeyThumbprint {
+		return domain.ErrInvalidDPoPProof
+	}
+	return nil
+}
+
+// withRequestOrigin attaches the IP address and user agent of every request
+// to its context, for the audit log.
+func withRequestOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := clientInfo(r)
+		ctx := application.WithRequestOrigin(r.Context(), application.RequestOrigin{
+			IPAddress: client.IPAddress,
+			UserAgent: client.UserAgent,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestIDHeader carries the request id, taken from the caller when it sent
+// a well-formed one so requests can be followed across services.
+const requestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// withRequestLogging gives every request an id, returned in
+// X-Request-ID and logged with every record of the request, and logs the
+// request once it has been served.
+func withRequestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, id)
+		ctx := logging.NewContext(r.Context(), slog.String("request_id", id))
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(ctx, level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Duration("duration", time.Since(start)),
+		)
+	})
+}
+
+// statusRecorder remembers the status a handler answered with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// withRoute names the span of every request after the route pattern it
+// matched, such as "POST /auth/login", once next has handled it.
+func withRoute(next *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+		if r.Pattern == "" {
+			return
+		}
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Pattern)
+		_, route, _ := strings.Cut(r.Pattern, " ")
+		span.SetAttributes(attribute.String("http.route", route))
+	})
+}
+
+// claimsFromContext returns the claims stored by Require.
+func claimsFromContext(ctx context.Context) *models.AccessTokenClaims {
+	claims, _ := ctx.Value(claimsKey{}).(*models.AccessTokenClaims)
+	return claims
+}
+
+// accessToken reads the access token of the Authorization header and the
+// scheme it was sent with, Bearer or DPoP.
+func accessToken(r *http.Request) (string, string, bool) {
+	scheme, raw, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || (scheme != "Bearer" && scheme != domain.TokenTypeDPoP) {
+		return "", "", false
+	}
+	raw = strings.TrimSpace(raw)
+	return raw, scheme, raw != ""
+}
+
+// dpopThumbprint validates the DPoP proof of a request, if it has one, and
+// returns the thumbprint of its key. A request may carry a single proof.
+func dpopThumbprint(r *http.Request, dpop *application.DPoPValidator, accessToken string) (string, error) {
+	proofs := r.Header.Values("DPoP")
+	switch len(proofs) {
+	case 0:
+		if accessToken != "" {
+			return "", domain.ErrInvalidDPoPProof
+		}
+		return "", nil
+	case 1:
+		return dpop.Validate(r.Context(), proofs[0], r.Method, requestURL(r).String(), accessToken)
+	default:
+		return "", domain.ErrInvalidDPoPProof
+	}
+}
+
+// requestURL rebuilds the URL the client sent the request to.
+func requestURL(r *http.Request) *url.URL {
+	scheme := "http"
+	if isSecureRequest(r) {
+		scheme = "https"
+	}
+	return &url.URL{Scheme: scheme, Host: r.Host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+}
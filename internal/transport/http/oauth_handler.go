@@ -0,0 +1,233 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/logging"
+)
+
+const (
+	oauthCookieName   = "ranco_oauth"
+	oauthCookieMaxAge = 10 * time.Minute
+)
+
+// oauthCookie keeps the authorization secrets on the browser that started the
+// flow, binding the callback to it. AccessToken is set when a signed-in user
+// links the identity instead of signing in; being signed, it cannot be forged
+// to attach an identity to someone else's account. RememberMe and Scope carry
+// the remember_me and scope query parameters of the authorize request to the
+// session.
+type oauthCookie struct {
+	State        string `json:"s"`
+	Nonce        string `json:"n"`
+	CodeVerifier string `json:"v"`
+	AccessToken  string `json:"t,omitempty"`
+	RememberMe   bool   `json:"r,omitempty"`
+	Scope        string `json:"c,omitempty"`
+}
+
+type OAuthHandler struct {
+	service *application.OAuthService
+	auth    *Authenticator
+}
+
+func NewOAuthHandler(service *application.OAuthService, auth *Authenticator) *OAuthHandler {
+	return &OAuthHandler{service: service, auth: auth}
+}
+
+func (h *OAuthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/auth/oauth/{provider}/authorize", h.Authorize)
+	mux.HandleFunc("GET /v1/auth/oauth/{provider}/callback", h.Callback)
+	// Providers using response_mode=form_post, such as Apple, POST the callback.
+	mux.HandleFunc("POST /v1/auth/oauth/{provider}/callback", h.Callback)
+	// Linking needs the signed-in account, so it starts from the API rather
+	// than from a redirect.
+	mux.HandleFunc("POST /v1/auth/oauth/{provider}/link", h.auth.RequireAccountHolder(h.Link))
+}
+
+func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	authorization, err := h.service.Authorize(providerFromPath(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rememberMe := r.URL.Query().Get("remember_me") == "true"
+	if err := setOAuthCookie(w, r, oauthCookie{
+		State:        authorization.State,
+		Nonce:        authorization.Nonce,
+		CodeVerifier: authorization.CodeVerifier,
+		RememberMe:   rememberMe,
+		Scope:        r.URL.Query().Get("scope"),
+	}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, authorization.URL, http.StatusFound)
+}
+
+// Link starts the flow that attaches a provider identity to the signed-in
+// account. It answers with the provider URL for the client to navigate to,
+// since a redirect cannot carry the bearer token.
+func (h *OAuthHandler) Link(w http.ResponseWriter, r *http.Request) {
+	authorization, err := h.service.Authorize(providerFromPath(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	token, _, _ := accessToken(r)
+	if err := setOAuthCookie(w, r, oauthCookie{
+		State:        authorization.State,
+		Nonce:        authorization.Nonce,
+		CodeVerifier: authorization.CodeVerifier,
+		AccessToken:  token,
+	}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, authorizationResponse{AuthorizationURL: authorization.URL})
+}
+
+func (h *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	expected := readOAuthCookie(r)
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthCookieName,
+		Path:     callbackPath(r),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: oauthCookieSameSite(r),
+	})
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+	if r.Form.Get("error") != "" {
+		writeError(w, r, domain.ErrInvalidCredentials)
+		return
+	}
+
+	authorization := ports.OAuthAuthorization{
+		State:        expected.State,
+		Nonce:        expected.Nonce,
+		CodeVerifier: expected.CodeVerifier,
+	}
+
+	if expected.AccessToken != "" {
+		h.completeLink(w, r, expected.AccessToken, authorization)
+		return
+	}
+
+	client := clientInfo(r)
+	client.RememberMe = expected.RememberMe
+	client.Scope = expected.Scope
+
+	result, err := h.service.Callback(
+		r.Context(),
+		providerFromPath(r),
+		r.Form.Get("code"),
+		r.Form.Get("state"),
+		authorization,
+		client,
+	)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeAuthResult(w, result)
+}
+
+func (h *OAuthHandler) completeLink(w http.ResponseWriter, r *http.Request, accessToken string, expected ports.OAuthAuthorization) {
+	claims, err := h.auth.validator.Validate(r.Context(), accessToken)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	method, err := h.service.Link(
+		r.Context(),
+		claims.AccountID,
+		providerFromPath(r),
+		r.Form.Get("code"),
+		r.Form.Get("state"),
+		expected,
+	)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newAuthMethodResponse(method))
+}
+
+func setOAuthCookie(w http.ResponseWriter, r *http.Request, cookie oauthCookie) error {
+	value, err := json.Marshal(cookie)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(value),
+		Path:     callbackPath(r),
+		MaxAge:   int(oauthCookieMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: oauthCookieSameSite(r),
+	})
+	return nil
+}
+
+func readOAuthCookie(r *http.Request) oauthCookie {
+	var value oauthCookie
+
+	cookie, err := r.Cookie(oauthCookieName)
+	if err != nil {
+		return value
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return value
+	}
+	_ = json.Unmarshal(raw, &value)
+	return value
+}
+
+// providerFromPath reads the provider of the route, which is logged with the
+// request from then on.
+func providerFromPath(r *http.Request) domain.Provider {
+	provider := domain.Provider(strings.ToUpper(r.PathValue("provider")))
+	logging.Add(r.Context(), slog.String("provider", string(provider)))
+	return provider
+}
+
+func callbackPath(r *http.Request) string {
+	return "/v1/auth/oauth/" + r.PathValue("provider") + "/callback"
+}
+
+// oauthCookieSameSite relaxes the cookie to SameSite=None over HTTPS so it
+// survives cross-site form_post callbacks; the state check still binds the
+// callback to the browser that started the flow.
+func oauthCookieSameSite(r *http.Request) http.SameSite {
+	if isSecureRequest(r) {
+		return http.SameSiteNoneMode
+	}
+	return http.SameSiteLaxMode
+}
+
+func isSecureRequest(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
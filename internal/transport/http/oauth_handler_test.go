@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/eventbus"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/metrics"
+	"github.com/TheJisus28/ranco-auth-service/internal/infrastructure/token"
+	"github.com/TheJisus28/ranco-auth-service/internal/repository/memory"
+)
+
+// fakeOAuthProvider accepts a single authorization code for a fixed
+// identity.
+type fakeOAuthProvider struct {
+	code     domain.Provider
+	accepted string
+	identity ports.ExternalIdentity
+}
+
+func (p *fakeOAuthProvider) Code() domain.Provider { return p.code }
+
+func (p *fakeOAuthProvider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return "https://provider.example.com/authorize?state=" + url.QueryEscape(state)
+}
+
+func (p *fakeOAuthProvider) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*ports.ExternalIdentity, error) {
+	if code != p.accepted {
+		return nil, errors.New("invalid code")
+	}
+	identity := p.identity
+	return &identity, nil
+}
+
+// newTestOAuthMux serves the routes of an OAuthHandler whose only provider
+// is a fake Apple, backed by the in-memory repositories.
+func newTestOAuthMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	store := memory.NewStore()
+	txManager := memory.NewMemoryTxManager(store)
+	accounts := memory.NewAccountRepository(store)
+	authMethods := memory.NewAuthMethodRepository(store)
+	memberships := memory.NewMembershipRepository(store)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingKey, err := token.NewSigningKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := token.NewJWTService(token.NewStaticKeyStore(signingKey), token.JWTConfig{
+		Issuer:   "https://auth.example.com",
+		Audience: "test",
+		TTL:      15 * time.Minute,
+	})
+
+	eventBus := eventbus.NewLogBus()
+	audit := application.NewAuditLog(txManager, memory.NewAuditEventRepository(store), nil)
+	features := application.NewFeatureFlags(memberships)
+	sessions := application.NewSessionIssuer(
+		memory.NewRefreshTokenRepository(store),
+		tokens,
+		memory.NewRoleRepository(store),
+		memberships,
+		memory.NewAuthPolicyRepository(store),
+		memory.NewMFAFactorRepository(store),
+		memory.NewMFAChallengeRepository(store),
+		memory.NewPasskeyCredentialRepository(store),
+		memory.NewMFARecoveryCodeRepository(store),
+		authMethods,
+		memory.NewPasswordCredentialRepository(store),
+		application.NewMFAPolicy(),
+		features,
+		application.NewPasswordExpiry(nil),
+		memory.NewLegalDocumentRepository(store),
+		memory.NewTrustedDeviceRepository(store),
+		domain.TrustedDeviceTTL,
+		application.SessionLimit{},
+		application.SessionLifetime{Default: time.Hour, RememberMe: 24 * time.Hour, Refresh: application.FixedExpiration{}},
+		metrics.NewPrometheus(),
+		audit,
+		eventBus,
+	)
+	service := application.NewOAuthService(
+		txManager,
+		accounts,
+		authMethods,
+		memory.NewAuthProviderRepository(store),
+		sessions,
+		features,
+		eventBus,
+		&fakeOAuthProvider{
+			code:     domain.ProviderApple,
+			accepted: "apple-code",
+			identity: ports.ExternalIdentity{
+				Provider:      domain.ProviderApple,
+				Subject:       "001234.apple",
+				Email:         "ana@example.com",
+				EmailVerified: true,
+			},
+		},
+	)
+
+	mux := http.NewServeMux()
+	NewOAuthHandler(service, NewAuthenticator(nil, nil)).RegisterRoutes(mux)
+	return mux
+}
+
+// formPostCallback builds the callback Apple POSTs with response_mode=form_post,
+// carrying the cookie set when the flow started.
+func formPostCallback(t *testing.T, code, state string) *http.Request {
+	t.Helper()
+
+	started := httptest.NewRecorder()
+	if err := setOAuthCookie(started, httptest.NewRequest(http.MethodGet, "/", nil), oauthCookie{
+		State:        "expected-state",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	form := url.Values{"code": {code}, "state": {state}}
+	r := httptest.NewRequest(http.MethodPost, "/v1/auth/oauth/apple/callback", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range started.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+	return r
+}
+
+func TestOAuthCallbackFormPost(t *testing.T) {
+	mux := newTestOAuthMux(t)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, formPostCallback(t, "apple-code", "expected-state"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.AccessToken == "" || body.RefreshToken == "" {
+		t.Errorf("callback did not open a session: %+v", body)
+	}
+}
+
+func TestOAuthCallbackFormPostRejectsState(t *testing.T) {
+	mux := newTestOAuthMux(t)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, formPostCallback(t, "apple-code", "forged-state"))
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_oauth_state") {
+		t.Errorf("forged state: got %d %s, want %d invalid_oauth_state", w.Code, w.Body, http.StatusBadRequest)
+	}
+}
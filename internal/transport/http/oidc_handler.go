@@ -0,0 +1,466 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+const (
+	browserSessionCookieName = "ranco_session"
+	browserSessionCookiePath = "/oauth"
+)
+
+// supportedGrantTypes are the grants of the token endpoint.
+var supportedGrantTypes = []string{
+	domain.GrantTypeAuthorizationCode,
+	domain.GrantTypeRefreshToken,
+	domain.GrantTypeClientCredentials,
+	domain.GrantTypeDeviceCode,
+	domain.GrantTypeTokenExchange,
+}
+
+// OIDCHandler serves the OpenID Connect provider endpoints used by
+// first-party applications: the authorization and token endpoints, the
+// browser session cookie the login page creates after a regular login, and
+// the device authorization grant for clients without a browser.
+type OIDCHandler struct {
+	authorization *application.AuthorizationService
+	clients       *application.ClientService
+	exchanges     *application.TokenExchangeService
+	authService   *application.AuthService
+	dpop          *application.DPoPValidator
+	auth          *Authenticator
+	// loginURL is the login page browsers without a session are sent to. It
+	// receives the authorization request to resume in the return_to query
+	// parameter.
+	loginURL string
+	// deviceVerificationURL is the page where users enter the user code of
+	// a device authorization request. It receives the code in the user_code
+	// query parameter when the device can show a link or QR code.
+	deviceVerificationURL string
+}
+
+func NewOIDCHandler(
+	authorization *application.AuthorizationService,
+	clients *application.ClientService,
+	exchanges *application.TokenExchangeService,
+	authService *application.AuthService,
+	dpop *application.DPoPValidator,
+	auth *Authenticator,
+	loginURL string,
+	deviceVerificationURL string,
+) *OIDCHandler {
+	return &OIDCHandler{
+		authorization:         authorization,
+		clients:               clients,
+		exchanges:             exchanges,
+		authService:           authService,
+		dpop:                  dpop,
+		auth:                  auth,
+		loginURL:              loginURL,
+		deviceVerificationURL: deviceVerificationURL,
+	}
+}
+
+func (h *OIDCHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /oauth/authorize", h.Authorize)
+	mux.HandleFunc("POST /oauth/token", h.Token)
+	mux.HandleFunc("POST /oauth/device_authorization", h.DeviceAuthorization)
+	mux.HandleFunc("GET /oauth/device", h.auth.Require(h.DescribeDevice))
+	mux.HandleFunc("POST /oauth/device/approve", h.auth.RequireAccountHolder(h.ApproveDevice))
+	mux.HandleFunc("POST /oauth/device/deny", h.auth.RequireAccountHolder(h.DenyDevice))
+	mux.HandleFunc("POST /oauth/session", h.auth.RequireAccountHolder(h.StartSession))
+	mux.HandleFunc("DELETE /oauth/session", h.EndSession)
+	mux.HandleFunc("GET /userinfo", h.auth.Require(h.UserInfo))
+	mux.HandleFunc("POST /userinfo", h.auth.Require(h.UserInfo))
+}
+
+// Authorize answers an authentication request by redirecting back to the
+// client with a code, or to the login page when the browser has no session.
+// Requests with an unknown client or redirect URI are rejected without
+// redirecting.
+func (h *OIDCHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := application.AuthorizationRequest{
+		ClientID:     query.Get("client_id"),
+		RedirectURI:  query.Get("redirect_uri"),
+		ResponseType: query.Get("response_type"),
+		Scope:        query.Get("scope"),
+		State:        query.Get("state"),
+		Nonce:        query.Get("nonce"),
+		Prompt:       query.Get("prompt"),
+		// PKCE parameters of RFC 7636.
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	}
+
+	client, err := h.authorization.Client(r.Context(), req.ClientID, req.RedirectURI)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	code, err := h.authorization.Authorize(r.Context(), client, req, readBrowserSessionCookie(r))
+	if errors.Is(err, domain.ErrLoginRequired) && req.Prompt != domain.PromptNone && h.loginURL != "" {
+		h.redirectToLogin(w, r)
+		return
+	}
+	if err != nil {
+		redirectAuthorizationError(w, r, req, err)
+		return
+	}
+
+	redirectAuthorization(w, r, req, url.Values{"code": {code}})
+}
+
+// Token implements the token endpoint for authenticated clients, which may
+// only use the grants they are registered for. A DPoP proof binds the issued
+// tokens to its key, and the response then has the DPoP token type.
+func (h *OIDCHandler) Token(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(w, r); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client, err := authenticateClient(w, r, h.clients, true)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	grantType := r.PostForm.Get("grant_type")
+	if !slices.Contains(supportedGrantTypes, grantType) {
+		writeError(w, r, domain.ErrUnsupportedGrantType)
+		return
+	}
+	if !client.AllowsGrantType(grantType) {
+		writeError(w, r, domain.ErrUnauthorizedClient)
+		return
+	}
+
+	info := clientInfo(r)
+	info.KeyThumbprint, err = dpopThumbprint(r, h.dpop, "")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	switch grantType {
+	case domain.GrantTypeAuthorizationCode:
+		h.exchangeCode(w, r, client, info)
+	case domain.GrantTypeRefreshToken:
+		h.refresh(w, r, info)
+	case domain.GrantTypeClientCredentials:
+		h.clientCredentials(w, r, client, info)
+	case domain.GrantTypeDeviceCode:
+		h.pollDevice(w, r, client, info)
+	case domain.GrantTypeTokenExchange:
+		h.exchangeToken(w, r, client, info)
+	}
+}
+
+func (h *OIDCHandler) exchangeCode(w http.ResponseWriter, r *http.Request, client *models.OAuthClient, info application.ClientInfo) {
+	code := r.PostForm.Get("code")
+	if code == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	grant, err := h.authorization.Exchange(r.Context(), client, application.CodeExchange{
+		Code:         code,
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+	}, info)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := newTokenResponse(grant.AuthResult)
+	response.IDToken = grant.IDToken
+	response.Scope = grant.Scope
+	writeTokenResponse(w, response, info)
+}
+
+func (h *OIDCHandler) refresh(w http.ResponseWriter, r *http.Request, info application.ClientInfo) {
+	refreshToken := r.PostForm.Get("refresh_token")
+	if refreshToken == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	result, err := h.authService.Refresh(r.Context(), refreshToken, info)
+	if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrInvalidAccountState) {
+		err = domain.ErrInvalidGrant
+	}
+	if err == nil && result.Restricted() {
+		err = domain.ErrInvalidGrant
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeTokenResponse(w, newTokenResponse(result), info)
+}
+
+func (h *OIDCHandler) clientCredentials(w http.ResponseWriter, r *http.Request, client *models.OAuthClient, info application.ClientInfo) {
+	token, err := h.clients.IssueToken(r.Context(), client, r.PostForm.Get("scope"), info.KeyThumbprint)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeTokenResponse(w, newClientTokenResponse(token), info)
+}
+
+func (h *OIDCHandler) pollDevice(w http.ResponseWriter, r *http.Request, client *models.OAuthClient, info application.ClientInfo) {
+	deviceCode := r.PostForm.Get("device_code")
+	if deviceCode == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	grant, err := h.authorization.PollDevice(r.Context(), client, deviceCode, info)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := newTokenResponse(grant.AuthResult)
+	response.IDToken = grant.IDToken
+	response.Scope = grant.Scope
+	writeTokenResponse(w, response, info)
+}
+
+func (h *OIDCHandler) exchangeToken(w http.ResponseWriter, r *http.Request, client *models.OAuthClient, info application.ClientInfo) {
+	form := r.PostForm
+	if form.Get("subject_token") == "" || form.Get("subject_token_type") == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	token, err := h.exchanges.Exchange(r.Context(), client, application.TokenExchange{
+		SubjectToken:       form.Get("subject_token"),
+		SubjectTokenType:   form.Get("subject_token_type"),
+		ActorToken:         form.Get("actor_token"),
+		ActorTokenType:     form.Get("actor_token_type"),
+		Audience:           form.Get("audience"),
+		Scope:              form.Get("scope"),
+		RequestedTokenType: form.Get("requested_token_type"),
+		KeyThumbprint:      info.KeyThumbprint,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeTokenResponse(w, newExchangedTokenResponse(token), info)
+}
+
+// writeTokenResponse sends a successful token response, whose token type
+// tells the client whether the access token is bound to its DPoP key.
+func writeTokenResponse(w http.ResponseWriter, response tokenResponse, info application.ClientInfo) {
+	if info.KeyThumbprint != "" {
+		response.TokenType = domain.TokenTypeDPoP
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// DeviceAuthorization implements the device authorization endpoint of
+// RFC 8628 section 3.1.
+func (h *OIDCHandler) DeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(w, r); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client, err := authenticateClient(w, r, h.clients, true)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	authorization, err := h.authorization.StartDeviceAuthorization(r.Context(), client, r.PostForm.Get("scope"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	complete, err := url.Parse(h.deviceVerificationURL)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	query := complete.Query()
+	query.Set("user_code", authorization.UserCode)
+	complete.RawQuery = query.Encode()
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, deviceAuthorizationResponse{
+		DeviceCode:              authorization.DeviceCode,
+		UserCode:                authorization.UserCode,
+		VerificationURI:         h.deviceVerificationURL,
+		VerificationURIComplete: complete.String(),
+		ExpiresIn:               int(time.Until(authorization.ExpiresAt).Seconds()),
+		Interval:                int(authorization.Interval.Seconds()),
+	})
+}
+
+// DescribeDevice tells the verification page which client is asking to sign
+// in, before the user approves the request.
+func (h *OIDCHandler) DescribeDevice(w http.ResponseWriter, r *http.Request) {
+	request, err := h.authorization.DescribeDeviceRequest(r.Context(), r.URL.Query().Get("user_code"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deviceRequestResponse{
+		ClientName: request.ClientName,
+		Scope:      request.Scope,
+		ExpiresIn:  int(time.Until(request.ExpiresAt).Seconds()),
+	})
+}
+
+func (h *OIDCHandler) ApproveDevice(w http.ResponseWriter, r *http.Request) {
+	h.decideDevice(w, r, h.authorization.ApproveDevice)
+}
+
+func (h *OIDCHandler) DenyDevice(w http.ResponseWriter, r *http.Request) {
+	h.decideDevice(w, r, h.authorization.DenyDevice)
+}
+
+func (h *OIDCHandler) decideDevice(w http.ResponseWriter, r *http.Request, decide func(context.Context, *models.AccessTokenClaims, string) error) {
+	var req deviceDecisionRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := decide(r.Context(), claimsFromContext(r.Context()), req.UserCode); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UserInfo implements the OpenID Connect UserInfo endpoint for the account
+// of the bearer access token.
+func (h *OIDCHandler) UserInfo(w http.ResponseWriter, r *http.Request) {
+	info, err := h.authorization.UserInfo(r.Context(), claimsFromContext(r.Context()).AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAccessToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, newUserInfoResponse(info))
+}
+
+// StartSession sets the browser session cookie for the session of the
+// caller's access token. The login page calls it after signing the user in.
+func (h *OIDCHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	result, err := h.authorization.StartBrowserSession(r.Context(), claimsFromContext(r.Context()))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     browserSessionCookieName,
+		Value:    result.Token,
+		Path:     browserSessionCookiePath,
+		Expires:  result.ExpiresAt,
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EndSession signs the browser out of the authorization endpoint.
+func (h *OIDCHandler) EndSession(w http.ResponseWriter, r *http.Request) {
+	if err := h.authorization.EndBrowserSession(r.Context(), readBrowserSessionCookie(r)); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     browserSessionCookieName,
+		Path:     browserSessionCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OIDCHandler) redirectToLogin(w http.ResponseWriter, r *http.Request) {
+	returnTo := requestURL(r)
+
+	login, err := url.Parse(h.loginURL)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	query := login.Query()
+	query.Set("return_to", returnTo.String())
+	login.RawQuery = query.Encode()
+
+	http.Redirect(w, r, login.String(), http.StatusFound)
+}
+
+// redirectAuthorizationError reports a failed authentication request to the
+// client as RFC 6749 section 4.1.2.1 describes.
+func redirectAuthorizationError(w http.ResponseWriter, r *http.Request, req application.AuthorizationRequest, err error) {
+	code := "server_error"
+	if mapped, ok := publicError(err); ok {
+		code = mapped.code
+	} else {
+		slog.ErrorContext(r.Context(), "internal error", "error", err)
+	}
+	redirectAuthorization(w, r, req, url.Values{"error": {code}})
+}
+
+func redirectAuthorization(w http.ResponseWriter, r *http.Request, req application.AuthorizationRequest, params url.Values) {
+	target, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	query := target.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	target.RawQuery = query.Encode()
+
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+func readBrowserSessionCookie(r *http.Request) string {
+	cookie, err := r.Cookie(browserSessionCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
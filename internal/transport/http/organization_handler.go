@@ -0,0 +1,210 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type OrganizationHandler struct {
+	service *application.OrganizationService
+	auth    *Authenticator
+}
+
+func NewOrganizationHandler(service *application.OrganizationService, auth *Authenticator) *OrganizationHandler {
+	return &OrganizationHandler{service: service, auth: auth}
+}
+
+func (h *OrganizationHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/organizations", h.auth.Require(h.List))
+	mux.HandleFunc("GET /v1/organizations/{id}/invitations", h.auth.Require(h.ListInvitations))
+	mux.HandleFunc("POST /v1/organizations/{id}/invitations", h.auth.Require(h.Invite))
+	mux.HandleFunc("DELETE /v1/organizations/{id}/invitations/{invitation_id}", h.auth.Require(h.RevokeInvitation))
+	mux.HandleFunc("POST /v1/invitations/accept", h.auth.RequireAccountHolder(h.AcceptInvitation))
+	mux.HandleFunc("GET /v1/organizations/{id}/policy", h.auth.Require(h.GetPolicy))
+	mux.HandleFunc("PUT /v1/organizations/{id}/policy", h.auth.RequireAccountHolder(h.SetPolicy))
+	mux.HandleFunc("DELETE /v1/organizations/{id}/policy", h.auth.RequireAccountHolder(h.DeletePolicy))
+}
+
+// List lists the organizations the caller is a member of, with its role in
+// each.
+func (h *OrganizationHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	organizations, err := h.service.AccountOrganizations(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]accountOrganizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		response = append(response, newAccountOrganizationResponse(organization))
+	}
+	writeJSON(w, http.StatusOK, accountOrganizationsResponse{Organizations: response})
+}
+
+// ListInvitations lists the pending invitations of an organization, newest
+// first.
+func (h *OrganizationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	invitations, err := h.service.PendingInvitations(r.Context(), claims.AccountID, organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]invitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		response = append(response, newInvitationResponse(invitation))
+	}
+	writeJSON(w, http.StatusOK, invitationsResponse{Invitations: response})
+}
+
+// Invite emails an invitation to join an organization with a role.
+func (h *OrganizationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req inviteRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	invitation, err := h.service.Invite(r.Context(), claims.AccountID, organizationID, req.Email, domain.Role(strings.ToUpper(req.Role)))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newInvitationResponse(invitation))
+}
+
+// RevokeInvitation revokes a pending invitation.
+func (h *OrganizationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+	invitationID, err := uuid.Parse(r.PathValue("invitation_id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.RevokeInvitation(r.Context(), claims.AccountID, organizationID, invitationID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcceptInvitation makes the caller a member of the organization an
+// invitation token was emailed for.
+func (h *OrganizationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req acceptInvitationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if req.Token == "" {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	organization, err := h.service.AcceptInvitation(r.Context(), claims.AccountID, req.Token)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountOrganizationResponse(organization))
+}
+
+// GetPolicy returns the authentication policy of an organization.
+func (h *OrganizationHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	policy, err := h.service.Policy(r.Context(), claims.AccountID, organizationID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newPolicyResponse(policy))
+}
+
+// SetPolicy replaces the authentication policy of an organization.
+func (h *OrganizationHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	var req setPolicyRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	policy := &models.AuthPolicy{
+		OrganizationID:    organizationID,
+		PasswordMinLength: req.PasswordMinLength,
+		RequireMFA:        req.RequireMFA,
+		SessionMaxAge:     time.Duration(req.SessionMaxAge) * time.Second,
+	}
+	for _, class := range req.PasswordRequiredClasses {
+		policy.PasswordRequiredClasses = append(policy.PasswordRequiredClasses, domain.CharacterClass(class))
+	}
+	for _, provider := range req.AllowedProviders {
+		policy.AllowedProviders = append(policy.AllowedProviders, domain.Provider(provider))
+	}
+
+	policy, err = h.service.SetPolicy(r.Context(), claims.AccountID, policy)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newPolicyResponse(policy))
+}
+
+// DeletePolicy returns the members of an organization to the service-wide
+// policies.
+func (h *OrganizationHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.DeletePolicy(r.Context(), claims.AccountID, organizationID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/google/uuid"
+)
+
+type PasskeyHandler struct {
+	service *application.PasskeyService
+	auth    *Authenticator
+}
+
+func NewPasskeyHandler(service *application.PasskeyService, auth *Authenticator) *PasskeyHandler {
+	return &PasskeyHandler{service: service, auth: auth}
+}
+
+func (h *PasskeyHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/auth/passkeys", h.auth.Require(h.List))
+	mux.HandleFunc("POST /v1/auth/passkeys/register", h.auth.AllowMFAEnrollment(h.BeginRegistration))
+	mux.HandleFunc("POST /v1/auth/passkeys/register/finish", h.auth.AllowMFAEnrollment(h.FinishRegistration))
+	mux.HandleFunc("DELETE /v1/auth/passkeys/{id}", h.auth.RequireAccountHolder(h.Delete))
+	mux.HandleFunc("POST /v1/auth/passkeys/login", h.BeginLogin)
+	mux.HandleFunc("POST /v1/auth/passkeys/login/finish", h.FinishLogin)
+	mux.HandleFunc("POST /v1/auth/mfa/passkey", h.BeginMFA)
+	mux.HandleFunc("POST /v1/auth/mfa/passkey/verify", h.FinishMFA)
+}
+
+func (h *PasskeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	passkeys, err := h.service.List(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]passkeyResponse, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		response = append(response, newPasskeyResponse(passkey))
+	}
+	writeJSON(w, http.StatusOK, passkeysResponse{Passkeys: response})
+}
+
+func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	result, err := h.service.BeginRegistration(r.Context(), claims.AccountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPasskeyCeremonyResponse(result))
+}
+
+func (h *PasskeyHandler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req finishPasskeyRegistrationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	registration, err := h.service.FinishRegistration(r.Context(), claims.AccountID, req.CeremonyID, req.Name, req.Credential)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, passkeyRegistrationResponse{
+		passkeyResponse: newPasskeyResponse(registration.Credential),
+		RecoveryCodes:   registration.RecoveryCodes,
+	})
+}
+
+func (h *PasskeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	passkeyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), claims.AccountID, passkeyID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PasskeyHandler) BeginLogin(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.BeginLogin(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPasskeyCeremonyResponse(result))
+}
+
+func (h *PasskeyHandler) FinishLogin(w http.ResponseWriter, r *http.Request) {
+	var req finishPasskeyLoginRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := clientInfo(r)
+	client.RememberMe = req.RememberMe
+	client.Scope = req.Scope
+
+	result, err := h.service.FinishLogin(r.Context(), req.CeremonyID, req.Credential, client)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newAuthResponse(result))
+}
+
+func (h *PasskeyHandler) BeginMFA(w http.ResponseWriter, r *http.Request) {
+	var req beginPasskeyMFARequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.BeginMFA(r.Context(), req.MFAToken)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPasskeyCeremonyResponse(result))
+}
+
+func (h *PasskeyHandler) FinishMFA(w http.ResponseWriter, r *http.Request) {
+	var req finishPasskeyMFARequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.FinishMFA(r.Context(), req.MFAToken, req.CeremonyID, req.Credential, req.RememberDevice, clientInfo(r))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newAuthResponse(result))
+}
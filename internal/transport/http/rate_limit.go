@@ -0,0 +1,133 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/ports"
+	"github.com/TheJisus28/ranco-auth-service/internal/security"
+)
+
+// RateLimit limits a group of endpoints per client IP address and per
+// identifier, such as the email address a request is about.
+type RateLimit struct {
+	IP         models.RateLimit
+	Identifier models.RateLimit
+}
+
+// RateLimits are the limits of each endpoint group.
+type RateLimits struct {
+	Login        RateLimit
+	Register     RateLimit
+	Refresh      RateLimit
+	Verification RateLimit
+}
+
+// RateLimiter guards endpoints against brute force and abuse. Requests over
+// a limit are answered with 429 and Retry-After.
+type RateLimiter struct {
+	limiter ports.RateLimiter
+	limits  atomic.Pointer[RateLimits]
+}
+
+func NewRateLimiter(limiter ports.RateLimiter, limits RateLimits) *RateLimiter {
+	l := &RateLimiter{limiter: limiter}
+	l.SetLimits(limits)
+	return l
+}
+
+// SetLimits changes the limits applied to the requests received from now
+// on. Requests already counted keep counting against the new limits.
+func (l *RateLimiter) SetLimits(limits RateLimits) {
+	l.limits.Store(&limits)
+}
+
+// Login, Register, Refresh and Verification apply the limits of their group
+// to next. field names the JSON body member holding the identifier; an
+// empty field limits per IP address only.
+func (l *RateLimiter) Login(field string, next http.HandlerFunc) http.HandlerFunc {
+	return l.limit("login", func(limits *RateLimits) RateLimit { return limits.Login }, field, next)
+}
+
+func (l *RateLimiter) Register(field string, next http.HandlerFunc) http.HandlerFunc {
+	return l.limit("register", func(limits *RateLimits) RateLimit { return limits.Register }, field, next)
+}
+
+func (l *RateLimiter) Refresh(field string, next http.HandlerFunc) http.HandlerFunc {
+	return l.limit("refresh", func(limits *RateLimits) RateLimit { return limits.Refresh }, field, next)
+}
+
+func (l *RateLimiter) Verification(field string, next http.HandlerFunc) http.HandlerFunc {
+	return l.limit("verification", func(limits *RateLimits) RateLimit { return limits.Verification }, field, next)
+}
+
+// limit counts the request against the IP address and the identifier of the
+// request. Limiter failures let the request through, so an unavailable
+// Redis does not take logins down with it.
+func (l *RateLimiter) limit(group string, of func(*RateLimits) RateLimit, field string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := of(l.limits.Load())
+		if limit.IP.Enabled() {
+			if !l.allow(w, r, group+":ip:"+clientInfo(r).IPAddress, limit.IP) {
+				return
+			}
+		}
+
+		if field != "" && limit.Identifier.Enabled() {
+			identifier, err := bodyField(w, r, field)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			if identifier != "" && !l.allow(w, r, group+":id:"+security.HashToken(identifier), limit.Identifier) {
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
+
+func (l *RateLimiter) allow(w http.ResponseWriter, r *http.Request, key string, limit models.RateLimit) bool {
+	allowed, retryAfter, err := l.limiter.Allow(r.Context(), key, limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "rate limiter", "error", err)
+		return true
+	}
+	if !allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		writeError(w, r, domain.ErrRateLimited)
+	}
+	return allowed
+}
+
+// bodyField reads the string member field of a JSON body, normalized for
+// case and spacing, and restores the body for the handler. Bodies that are
+// not JSON objects yield no identifier and are left to the handler to
+// reject.
+func bodyField(w http.ResponseWriter, r *http.Request, field string) (string, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		return "", errInvalidRequest
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return "", nil
+	}
+	var value string
+	if err := json.Unmarshal(members[field], &value); err != nil {
+		return "", nil
+	}
+	return strings.ToLower(strings.TrimSpace(value)), nil
+}
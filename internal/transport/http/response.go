@@ -0,0 +1,240 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+const maxBodyBytes = 1 << 20
+
+type errorResponse struct {
+	Error  string               `json:"error"`
+	Fields []fieldErrorResponse `json:"fields,omitempty"`
+}
+
+// fieldErrorResponse explains why one field of the request was refused, so
+// clients can show the reason next to it.
+type fieldErrorResponse struct {
+	Field  string         `json:"field"`
+	Code   string         `json:"code"`
+	Params map[string]int `json:"params,omitempty"`
+}
+
+// apiError pairs a public error code with the HTTP status it is served with.
+type apiError struct {
+	status int
+	code   string
+}
+
+var errorMapping = map[error]apiError{
+	domain.ErrInvalidEmail:                 {http.StatusBadRequest, "invalid_email"},
+	domain.ErrInvalidPassword:              {http.StatusBadRequest, "invalid_password"},
+	domain.ErrAccountAlreadyExists:         {http.StatusConflict, "account_already_exists"},
+	domain.ErrInvalidCredentials:           {http.StatusBadRequest, "invalid_credentials"},
+	domain.ErrInvalidAccountState:          {http.StatusConflict, "invalid_account_state"},
+	domain.ErrInvalidOrExpiredCode:         {http.StatusBadRequest, "invalid_or_expired_code"},
+	domain.ErrVerificationAttemptsExceeded: {http.StatusBadRequest, "verification_attempts_exceeded"},
+	domain.ErrInvalidRefreshToken:          {http.StatusUnauthorized, "invalid_refresh_token"},
+	domain.ErrInvalidAccessToken:           {http.StatusUnauthorized, "invalid_token"},
+	domain.ErrUnsupportedProvider:          {http.StatusNotFound, "unsupported_provider"},
+	domain.ErrInvalidOAuthState:            {http.StatusBadRequest, "invalid_oauth_state"},
+	domain.ErrAuthMethodNotFound:           {http.StatusNotFound, "auth_method_not_found"},
+	domain.ErrIdentityAlreadyLinked:        {http.StatusConflict, "identity_already_linked"},
+	domain.ErrProviderAlreadyLinked:        {http.StatusConflict, "provider_already_linked"},
+	domain.ErrLastAuthMethod:               {http.StatusConflict, "last_auth_method"},
+	domain.ErrMFAAlreadyEnrolled:           {http.StatusConflict, "mfa_already_enrolled"},
+	domain.ErrMFANotEnrolled:               {http.StatusNotFound, "mfa_not_enrolled"},
+	domain.ErrInvalidMFACode:               {http.StatusBadRequest, "invalid_mfa_code"},
+	domain.ErrInvalidMFAChallenge:          {http.StatusUnauthorized, "invalid_mfa_challenge"},
+	domain.ErrInvalidPhoneNumber:           {http.StatusBadRequest, "invalid_phone_number"},
+	domain.ErrInvalidPasskeyCeremony:       {http.StatusBadRequest, "invalid_passkey_ceremony"},
+	domain.ErrInvalidPasskey:               {http.StatusBadRequest, "invalid_passkey"},
+	domain.ErrPasskeyNotFound:              {http.StatusNotFound, "passkey_not_found"},
+	domain.ErrInvalidPasskeyName:           {http.StatusBadRequest, "invalid_passkey_name"},
+	domain.ErrMFAEnrollmentRequired:        {http.StatusForbidden, "mfa_enrollment_required"},
+	domain.ErrTrustedDeviceNotFound:        {http.StatusNotFound, "trusted_device_not_found"},
+	domain.ErrReauthenticationRequired:     {http.StatusUnauthorized, "reauthentication_required"},
+	domain.ErrSessionNotFound:              {http.StatusNotFound, "session_not_found"},
+	domain.ErrSessionLimitReached:          {http.StatusConflict, "session_limit_reached"},
+	domain.ErrAPIKeyNotFound:               {http.StatusNotFound, "api_key_not_found"},
+	domain.ErrInvalidAPIKeyName:            {http.StatusBadRequest, "invalid_api_key_name"},
+	domain.ErrInvalidAPIKeyExpiry:          {http.StatusBadRequest, "invalid_api_key_expiry"},
+	domain.ErrAPIKeyLimitReached:           {http.StatusConflict, "api_key_limit_reached"},
+	domain.ErrAccountNotFound:              {http.StatusNotFound, "account_not_found"},
+	domain.ErrTooManyTokens:                {http.StatusBadRequest, "too_many_tokens"},
+	domain.ErrRateLimited:                  {http.StatusTooManyRequests, "rate_limited"},
+	domain.ErrVerificationResendThrottled:  {http.StatusTooManyRequests, "verification_resend_throttled"},
+	domain.ErrAuthMethodLocked:             {http.StatusLocked, "auth_method_locked"},
+	domain.ErrMFAFactorLocked:              {http.StatusLocked, "mfa_factor_locked"},
+	domain.ErrCaptchaRequired:              {http.StatusBadRequest, "captcha_required"},
+	domain.ErrInvalidCaptcha:               {http.StatusBadRequest, "invalid_captcha"},
+	domain.ErrDisposableEmail:              {http.StatusBadRequest, "disposable_email"},
+	domain.ErrBreachedPassword:             {http.StatusBadRequest, "breached_password"},
+	domain.ErrPasswordChangeRequired:       {http.StatusForbidden, "password_change_required"},
+	domain.ErrUnsupportedPasswordHash:      {http.StatusBadRequest, "unsupported_password_hash"},
+	domain.ErrAdminRequired:                {http.StatusForbidden, "admin_required"},
+	domain.ErrInvalidCursor:                {http.StatusBadRequest, "invalid_cursor"},
+	domain.ErrInsufficientScope:            {http.StatusForbidden, "insufficient_scope"},
+	domain.ErrInvalidBanReason:             {http.StatusBadRequest, "invalid_ban_reason"},
+	domain.ErrInvalidBanExpiry:             {http.StatusBadRequest, "invalid_ban_expiry"},
+	domain.ErrAccountAlreadyBanned:         {http.StatusConflict, "account_already_banned"},
+	domain.ErrAccountNotBanned:             {http.StatusConflict, "account_not_banned"},
+	domain.ErrCannotBanSelf:                {http.StatusBadRequest, "cannot_ban_self"},
+	domain.ErrCannotEraseSelf:              {http.StatusBadRequest, "cannot_erase_self"},
+	domain.ErrInvalidRole:                  {http.StatusBadRequest, "invalid_role"},
+	domain.ErrRoleUnchanged:                {http.StatusConflict, "role_unchanged"},
+	domain.ErrLastAdmin:                    {http.StatusConflict, "last_admin"},
+	domain.ErrInvalidRoleChangeReason:      {http.StatusBadRequest, "invalid_role_change_reason"},
+	domain.ErrRoleNotFound:                 {http.StatusNotFound, "role_not_found"},
+	domain.ErrRoleAlreadyExists:            {http.StatusConflict, "role_already_exists"},
+	domain.ErrSystemRole:                   {http.StatusConflict, "system_role"},
+	domain.ErrRoleInUse:                    {http.StatusConflict, "role_in_use"},
+	domain.ErrInvalidPermission:            {http.StatusBadRequest, "invalid_permission"},
+	domain.ErrInvalidRoleDescription:       {http.StatusBadRequest, "invalid_role_description"},
+	domain.ErrOrganizationNotFound:         {http.StatusNotFound, "organization_not_found"},
+	domain.ErrOrganizationAlreadyExists:    {http.StatusConflict, "organization_already_exists"},
+	domain.ErrInvalidOrganizationName:      {http.StatusBadRequest, "invalid_organization_name"},
+	domain.ErrInvalidOrganizationSlug:      {http.StatusBadRequest, "invalid_organization_slug"},
+	domain.ErrMembershipNotFound:           {http.StatusNotFound, "membership_not_found"},
+	domain.ErrOrganizationAdminRequired:    {http.StatusForbidden, "organization_admin_required"},
+	domain.ErrInvitationNotFound:           {http.StatusNotFound, "invitation_not_found"},
+	domain.ErrInvalidOrExpiredInvitation:   {http.StatusBadRequest, "invalid_or_expired_invitation"},
+	domain.ErrInvitationEmailMismatch:      {http.StatusForbidden, "invitation_email_mismatch"},
+	domain.ErrKeyRotationDisabled:          {http.StatusConflict, "key_rotation_disabled"},
+	domain.ErrBrandingNotFound:             {http.StatusNotFound, "branding_not_found"},
+	domain.ErrInvalidBranding:              {http.StatusBadRequest, "invalid_branding"},
+	domain.ErrPolicyNotFound:               {http.StatusNotFound, "policy_not_found"},
+	domain.ErrInvalidPolicy:                {http.StatusBadRequest, "invalid_policy"},
+	domain.ErrProviderNotAllowed:           {http.StatusForbidden, "provider_not_allowed"},
+	domain.ErrPasswordResetRequired:        {http.StatusForbidden, "password_reset_required"},
+	domain.ErrImpersonationNotFound:        {http.StatusNotFound, "impersonation_not_found"},
+	domain.ErrCannotImpersonate:            {http.StatusForbidden, "cannot_impersonate"},
+	domain.ErrInvalidImpersonationReason:   {http.StatusBadRequest, "invalid_impersonation_reason"},
+	domain.ErrInvalidImpersonationDuration: {http.StatusBadRequest, "invalid_impersonation_duration"},
+	domain.ErrImpersonationNotAllowed:      {http.StatusForbidden, "impersonation_not_allowed"},
+	domain.ErrWebhookNotFound:              {http.StatusNotFound, "webhook_not_found"},
+	domain.ErrWebhookDeliveryNotFound:      {http.StatusNotFound, "webhook_delivery_not_found"},
+	domain.ErrInvalidWebhookURL:            {http.StatusBadRequest, "invalid_webhook_url"},
+	domain.ErrInvalidWebhookEvents:         {http.StatusBadRequest, "invalid_webhook_events"},
+	domain.ErrInvalidWebhookDescription:    {http.StatusBadRequest, "invalid_webhook_description"},
+	domain.ErrWebhookDeliveryPending:       {http.StatusConflict, "webhook_delivery_pending"},
+	domain.ErrInvalidConfiguration:         {http.StatusUnprocessableEntity, "invalid_configuration"},
+	domain.ErrFeatureNotEnabled:            {http.StatusForbidden, "feature_not_enabled"},
+	domain.ErrDataExportNotFound:           {http.StatusNotFound, "data_export_not_found"},
+	domain.ErrDataExportInProgress:         {http.StatusConflict, "data_export_in_progress"},
+	domain.ErrInvalidDownloadLink:          {http.StatusForbidden, "invalid_download_link"},
+	domain.ErrConsentRequired:              {http.StatusForbidden, "consent_required"},
+	domain.ErrLegalDocumentNotFound:        {http.StatusNotFound, "legal_document_not_found"},
+	domain.ErrLegalDocumentExists:          {http.StatusConflict, "legal_document_exists"},
+	domain.ErrLegalDocumentSuperseded:      {http.StatusConflict, "legal_document_superseded"},
+	domain.ErrInvalidLegalDocument:         {http.StatusBadRequest, "invalid_legal_document"},
+	domain.ErrInvalidClient:                {http.StatusUnauthorized, "invalid_client"},
+	domain.ErrInvalidRedirectURI:           {http.StatusBadRequest, "invalid_redirect_uri"},
+	domain.ErrUnsupportedResponseType:      {http.StatusBadRequest, "unsupported_response_type"},
+	domain.ErrInvalidScope:                 {http.StatusBadRequest, "invalid_scope"},
+	domain.ErrCodeChallengeRequired:        {http.StatusBadRequest, "invalid_request"},
+	domain.ErrInvalidCodeChallenge:         {http.StatusBadRequest, "invalid_request"},
+	domain.ErrLoginRequired:                {http.StatusUnauthorized, "login_required"},
+	domain.ErrInvalidGrant:                 {http.StatusBadRequest, "invalid_grant"},
+	domain.ErrUnsupportedGrantType:         {http.StatusBadRequest, "unsupported_grant_type"},
+	domain.ErrUnauthorizedClient:           {http.StatusBadRequest, "unauthorized_client"},
+	domain.ErrAuthorizationPending:         {http.StatusBadRequest, "authorization_pending"},
+	domain.ErrSlowDown:                     {http.StatusBadRequest, "slow_down"},
+	domain.ErrAccessDenied:                 {http.StatusBadRequest, "access_denied"},
+	domain.ErrExpiredToken:                 {http.StatusBadRequest, "expired_token"},
+	domain.ErrInvalidUserCode:              {http.StatusBadRequest, "invalid_user_code"},
+	domain.ErrInvalidTarget:                {http.StatusBadRequest, "invalid_target"},
+	domain.ErrInvalidExchangeToken:         {http.StatusBadRequest, "invalid_request"},
+	domain.ErrUnsupportedTokenType:         {http.StatusBadRequest, "invalid_request"},
+	domain.ErrInvalidDPoPProof:             {http.StatusBadRequest, "invalid_dpop_proof"},
+}
+
+var errInvalidRequest = errors.New("invalid request")
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("encode response", "error", err)
+	}
+}
+
+// writeAuthResult renders a completed login, the MFA challenge that stands
+// between the client and its tokens, or the restricted session of an account
+// that must enroll a second factor, change its password or accept the legal
+// documents.
+func writeAuthResult(w http.ResponseWriter, result *application.AuthResult) {
+	if result.MFAChallenge != nil {
+		writeJSON(w, http.StatusOK, newMFAChallengeResponse(result.MFAChallenge))
+		return
+	}
+	if result.MFAEnrollmentRequired {
+		writeJSON(w, http.StatusOK, newMFAEnrollmentResponse(result))
+		return
+	}
+	if result.PasswordChangeRequired {
+		writeJSON(w, http.StatusOK, newPasswordChangeResponse(result))
+		return
+	}
+	if result.ConsentRequired {
+		writeJSON(w, http.StatusOK, newConsentRequiredResponse(result))
+		return
+	}
+	writeJSON(w, http.StatusOK, newAuthResponse(result))
+}
+
+// writeError renders err using its public code. Unknown errors are logged and
+// surfaced as internal_error so no implementation detail leaks to clients.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errInvalidRequest) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_request"})
+		return
+	}
+
+	if mapped, ok := publicError(err); ok {
+		writeJSON(w, mapped.status, errorResponse{Error: mapped.code, Fields: fieldErrors(err)})
+		return
+	}
+
+	slog.ErrorContext(r.Context(), "internal error", "error", err)
+	writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "internal_error"})
+}
+
+// fieldErrors lists the field errors of a ValidationError in err.
+func fieldErrors(err error) []fieldErrorResponse {
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) {
+		return nil
+	}
+
+	return newFieldErrorResponses(validation.Fields)
+}
+
+// publicError looks up the public code of a domain error.
+func publicError(err error) (apiError, bool) {
+	for target, mapped := range errorMapping {
+		if errors.Is(err, target) {
+			return mapped, true
+		}
+	}
+	return apiError{}, false
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return errInvalidRequest
+	}
+	return nil
+}
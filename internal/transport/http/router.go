@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+func NewRouter(auth *AuthHandler, oauth *OAuthHandler, methods *AuthMethodHandler, mfa *MFAHandler, passkeys *PasskeyHandler, apiKeys *APIKeyHandler, stepUp *StepUpHandler, sessions *SessionHandler, authorizationServer *AuthorizationServerHandler, oidc *OIDCHandler, discovery *DiscoveryHandler, jwks *JWKSHandler, admin *AdminHandler, scim *SCIMHandler, organizations *OrganizationHandler, securityActivity *SecurityActivityHandler, dataExports *DataExportHandler, consents *ConsentHandler) http.Handler {
+	mux := http.NewServeMux()
+	auth.RegisterRoutes(mux)
+	oauth.RegisterRoutes(mux)
+	methods.RegisterRoutes(mux)
+	mfa.RegisterRoutes(mux)
+	passkeys.RegisterRoutes(mux)
+	apiKeys.RegisterRoutes(mux)
+	stepUp.RegisterRoutes(mux)
+	sessions.RegisterRoutes(mux)
+	authorizationServer.RegisterRoutes(mux)
+	oidc.RegisterRoutes(mux)
+	discovery.RegisterRoutes(mux)
+	jwks.RegisterRoutes(mux)
+	admin.RegisterRoutes(mux)
+	scim.RegisterRoutes(mux)
+	organizations.RegisterRoutes(mux)
+	securityActivity.RegisterRoutes(mux)
+	dataExports.RegisterRoutes(mux)
+	consents.RegisterRoutes(mux)
+	// Requests are traced as children of the spans of callers that
+	// propagate a trace context, and logged within their span.
+	return otelhttp.NewHandler(withRequestLogging(withRequestOrigin(withRoute(mux))), "http")
+}
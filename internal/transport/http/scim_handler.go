@@ -0,0 +1,514 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// SCIM schemas and media type, from RFC 7643 and RFC 7644.
+const (
+	scimContentType                 = "application/scim+json"
+	scimUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// scimFilter matches the only filters identity providers need to find a
+// user before provisioning it: userName eq "…" and emails.value eq "…".
+var scimFilter = regexp.MustCompile(`(?i)^\s*(userName|emails\.value|emails)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// scimError is a SCIM error response, RFC 7644 section 3.12.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+var (
+	errSCIMInvalidSyntax = &scimError{http.StatusBadRequest, "invalidSyntax", "The request body is not a valid SCIM message."}
+	errSCIMInvalidFilter = &scimError{http.StatusBadRequest, "invalidFilter", `Only userName eq "…" and emails.value eq "…" filters are supported.`}
+	errSCIMInvalidValue  = &scimError{http.StatusBadRequest, "invalidValue", "An attribute has an invalid value."}
+	errSCIMMutability    = &scimError{http.StatusBadRequest, "mutability", "The userName and active attributes can be replaced but not removed."}
+)
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type scimMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	Location     string     `json:"location"`
+}
+
+type scimUserResponse struct {
+	Schemas  []string    `json:"schemas"`
+	ID       string      `json:"id"`
+	UserName string      `json:"userName"`
+	Emails   []scimEmail `json:"emails"`
+	Active   bool        `json:"active"`
+	Meta     scimMeta    `json:"meta"`
+}
+
+type scimListResponse struct {
+	Schemas      []string            `json:"schemas"`
+	TotalResults int                 `json:"totalResults"`
+	StartIndex   int                 `json:"startIndex"`
+	ItemsPerPage int                 `json:"itemsPerPage"`
+	Resources    []*scimUserResponse `json:"Resources"`
+}
+
+type scimErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// scimBool is a boolean attribute, also accepted as the "True" and "False"
+// strings some identity providers send.
+type scimBool bool
+
+func (b *scimBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch value := value.(type) {
+	case bool:
+		*b = scimBool(value)
+	case string:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return errSCIMInvalidValue
+		}
+		*b = scimBool(parsed)
+	default:
+		return errSCIMInvalidValue
+	}
+	return nil
+}
+
+// scimUserRequest holds the attributes of a User that map to an account.
+// Others, such as name, externalId or password, are ignored.
+type scimUserRequest struct {
+	UserName *string     `json:"userName"`
+	Emails   []scimEmail `json:"emails"`
+	Active   *scimBool   `json:"active"`
+}
+
+// email picks the address of the user: its userName when that is an
+// address, or else its primary email.
+func (u *scimUserRequest) email() string {
+	if u.UserName != nil && strings.Contains(*u.UserName, "@") {
+		return *u.UserName
+	}
+	if email := primaryEmail(u.Emails); email != "" {
+		return email
+	}
+	if u.UserName != nil {
+		return *u.UserName
+	}
+	return ""
+}
+
+func primaryEmail(emails []scimEmail) string {
+	for _, email := range emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+type scimPatchRequest struct {
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// SCIMHandler serves the SCIM 2.0 Users endpoints, through which identity
+// providers such as Okta and Entra ID provision and deprovision accounts.
+type SCIMHandler struct {
+	provisioning *application.ProvisioningService
+	auth         *Authenticator
+	// baseURL is the public URL the endpoints are served under, which
+	// resource locations start with.
+	baseURL string
+}
+
+func NewSCIMHandler(provisioning *application.ProvisioningService, auth *Authenticator, baseURL string) *SCIMHandler {
+	return &SCIMHandler{provisioning: provisioning, auth: auth, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (h *SCIMHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /scim/v2/ServiceProviderConfig", h.auth.RequireAdminScope(domain.SCIMScope, h.ServiceProviderConfig))
+	mux.HandleFunc("GET /scim/v2/Users", h.auth.RequireAdminScope(domain.SCIMScope, h.ListUsers))
+	mux.HandleFunc("POST /scim/v2/Users", h.auth.RequireAdminScope(domain.SCIMScope, h.CreateUser))
+	mux.HandleFunc("GET /scim/v2/Users/{id}", h.auth.RequireAdminScope(domain.SCIMScope, h.GetUser))
+	mux.HandleFunc("PUT /scim/v2/Users/{id}", h.auth.RequireAdminScope(domain.SCIMScope, h.ReplaceUser))
+	mux.HandleFunc("PATCH /scim/v2/Users/{id}", h.auth.RequireAdminScope(domain.SCIMScope, h.PatchUser))
+	mux.HandleFunc("DELETE /scim/v2/Users/{id}", h.auth.RequireAdminScope(domain.SCIMScope, h.DeleteUser))
+}
+
+// ServiceProviderConfig describes the SCIM features this service supports.
+func (h *SCIMHandler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	supported := func(supported bool) map[string]bool { return map[string]bool{"supported": supported} }
+	writeSCIM(w, http.StatusOK, map[string]any{
+		"schemas":        []string{scimServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": domain.SCIMMaxPageSize},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "An API key with the scim scope, or an access token, of an ADMIN account.",
+			"primary":     true,
+		}},
+		"meta": scimMeta{ResourceType: "ServiceProviderConfig", Location: h.baseURL + "/scim/v2/ServiceProviderConfig"},
+	})
+}
+
+// ListUsers pages through the provisioned users with the 1-based startIndex
+// and count parameters, or finds one by address with a filter.
+func (h *SCIMHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	startIndex := 1
+	if raw := query.Get("startIndex"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeSCIMError(w, r, errSCIMInvalidValue)
+			return
+		}
+		startIndex = max(n, 1)
+	}
+	count := domain.SCIMDefaultPageSize
+	if raw := query.Get("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeSCIMError(w, r, errSCIMInvalidValue)
+			return
+		}
+		count = min(max(n, 0), domain.SCIMMaxPageSize)
+	}
+
+	var (
+		users []*application.ProvisionedUser
+		total int
+	)
+	if filter := query.Get("filter"); filter != "" {
+		match := scimFilter.FindStringSubmatch(filter)
+		if match == nil {
+			writeSCIMError(w, r, errSCIMInvalidFilter)
+			return
+		}
+		email, err := strconv.Unquote(match[2])
+		if err != nil {
+			writeSCIMError(w, r, errSCIMInvalidFilter)
+			return
+		}
+
+		user, err := h.provisioning.FindByEmail(r.Context(), email)
+		switch {
+		case errors.Is(err, domain.ErrAccountNotFound):
+		case err != nil:
+			writeSCIMError(w, r, err)
+			return
+		default:
+			total = 1
+			if startIndex == 1 && count > 0 {
+				users = append(users, user)
+			}
+		}
+	} else {
+		var err error
+		users, total, err = h.provisioning.List(r.Context(), startIndex-1, count)
+		if err != nil {
+			writeSCIMError(w, r, err)
+			return
+		}
+	}
+
+	resources := make([]*scimUserResponse, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, h.userResponse(user))
+	}
+	writeSCIM(w, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// CreateUser provisions an account, active unless active is false.
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req scimUserRequest
+	if err := decodeSCIM(w, r, &req); err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+
+	user, err := h.provisioning.Create(r.Context(), req.email(), req.Active == nil || bool(*req.Active))
+	if err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+
+	response := h.userResponse(user)
+	w.Header().Set("Location", response.Meta.Location)
+	writeSCIM(w, http.StatusCreated, response)
+}
+
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := scimUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.provisioning.Get(r.Context(), accountID)
+	if err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, h.userResponse(user))
+}
+
+// ReplaceUser sets the address and activation of a user. As with creation,
+// a user without active is active.
+func (h *SCIMHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := scimUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req scimUserRequest
+	if err := decodeSCIM(w, r, &req); err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+
+	user, err := h.provisioning.Update(r.Context(), accountID, req.email(), req.Active == nil || bool(*req.Active))
+	if err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, h.userResponse(user))
+}
+
+// PatchUser applies the operations of a PatchOp message to the address and
+// the activation of a user. Operations on other attributes are ignored.
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := scimUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req scimPatchRequest
+	if err := decodeSCIM(w, r, &req); err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+
+	user, err := h.provisioning.Get(r.Context(), accountID)
+	if err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+
+	email, active := user.Email, user.Active()
+	for _, operation := range req.Operations {
+		if err := applySCIMPatch(operation, &email, &active); err != nil {
+			writeSCIMError(w, r, err)
+			return
+		}
+	}
+
+	user, err = h.provisioning.Update(r.Context(), accountID, email, active)
+	if err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, h.userResponse(user))
+}
+
+// DeleteUser deletes the account of a user.
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := scimUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.provisioning.Delete(r.Context(), accountID); err != nil {
+		writeSCIMError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applySCIMPatch applies an operation to the address and the activation of
+// a user. Without a path, the value of an add or replace holds the
+// attributes to set.
+func applySCIMPatch(operation scimPatchOperation, email *string, active *bool) error {
+	path := strings.ToLower(strings.TrimSpace(operation.Path))
+	emailPath := path == "username" || path == "emails" || strings.HasPrefix(path, "emails[")
+
+	switch strings.ToLower(operation.Op) {
+	case "add", "replace":
+	case "remove":
+		if path == "" || path == "active" || emailPath {
+			return errSCIMMutability
+		}
+		return nil
+	default:
+		return errSCIMInvalidSyntax
+	}
+
+	if path == "" {
+		var attributes scimUserRequest
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return scimValueError(err)
+		}
+		if attributes.UserName != nil || len(attributes.Emails) > 0 {
+			*email = attributes.email()
+		}
+		if attributes.Active != nil {
+			*active = bool(*attributes.Active)
+		}
+		return nil
+	}
+
+	switch {
+	case path == "active":
+		var value scimBool
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return scimValueError(err)
+		}
+		*active = bool(value)
+	case path == "emails":
+		var emails []scimEmail
+		if err := json.Unmarshal(operation.Value, &emails); err != nil {
+			return scimValueError(err)
+		}
+		*email = primaryEmail(emails)
+	case emailPath:
+		// userName, or the value of an email such as emails[type eq "work"].value.
+		if err := json.Unmarshal(operation.Value, email); err != nil {
+			return scimValueError(err)
+		}
+	}
+	return nil
+}
+
+// scimValueError reports a value that could not be decoded.
+func scimValueError(err error) error {
+	var scimErr *scimError
+	if errors.As(err, &scimErr) {
+		return scimErr
+	}
+	return errSCIMInvalidValue
+}
+
+func (h *SCIMHandler) userResponse(user *application.ProvisionedUser) *scimUserResponse {
+	created := user.Account.CreatedAt.UTC()
+	id := user.Account.ID.String()
+	return &scimUserResponse{
+		Schemas:  []string{scimUserSchema},
+		ID:       id,
+		UserName: user.Email,
+		Emails:   []scimEmail{{Value: user.Email, Type: "work", Primary: true}},
+		Active:   user.Active(),
+		Meta: scimMeta{
+			ResourceType: "User",
+			Created:      &created,
+			Location:     h.baseURL + "/scim/v2/Users/" + id,
+		},
+	}
+}
+
+// scimUserID parses the user id of the path. Ids that are not account ids
+// name no user.
+func scimUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	accountID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeSCIMError(w, r, domain.ErrAccountNotFound)
+		return uuid.Nil, false
+	}
+	return accountID, true
+}
+
+// decodeSCIM reads a SCIM message, which, unlike the requests of the rest of
+// the API, may carry attributes this service ignores.
+func decodeSCIM(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		var scimErr *scimError
+		if errors.As(err, &scimErr) {
+			return scimErr
+		}
+		return errSCIMInvalidSyntax
+	}
+	return nil
+}
+
+func writeSCIM(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("encode response", "error", err)
+	}
+}
+
+// writeSCIMError renders err as a SCIM error. Unknown errors are logged and
+// surfaced without detail, as writeError does.
+func writeSCIMError(w http.ResponseWriter, r *http.Request, err error) {
+	var scimErr *scimError
+	switch {
+	case errors.As(err, &scimErr):
+	case errors.Is(err, domain.ErrAccountNotFound):
+		scimErr = &scimError{http.StatusNotFound, "", "User not found."}
+	case errors.Is(err, domain.ErrAccountAlreadyExists):
+		scimErr = &scimError{http.StatusConflict, "uniqueness", "The userName is already taken."}
+	case errors.Is(err, domain.ErrInvalidEmail):
+		scimErr = &scimError{http.StatusBadRequest, "invalidValue", "The userName must be an email address."}
+	default:
+		slog.ErrorContext(r.Context(), "internal error", "error", err)
+		scimErr = &scimError{http.StatusInternalServerError, "", "Internal error."}
+	}
+
+	writeSCIM(w, scimErr.status, scimErrorResponse{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(scimErr.status),
+		SCIMType: scimErr.scimType,
+		Detail:   scimErr.detail,
+	})
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain/models"
+)
+
+// SecurityActivityHandler shows accounts their own recent security activity
+// from the audit log.
+type SecurityActivityHandler struct {
+	audit *application.AuditLog
+	auth  *Authenticator
+}
+
+func NewSecurityActivityHandler(audit *application.AuditLog, auth *Authenticator) *SecurityActivityHandler {
+	return &SecurityActivityHandler{audit: audit, auth: auth}
+}
+
+func (h *SecurityActivityHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/auth/security-activity", h.auth.Require(h.List))
+}
+
+// List pages through the account's recent sign-ins and changes of its
+// password and second factors, newest first. next_cursor, passed back as
+// cursor, gives the next page.
+func (h *SecurityActivityHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+	query := r.URL.Query()
+
+	var (
+		after models.AuditEventCursor
+		err   error
+	)
+	if raw := query.Get("cursor"); raw != "" {
+		if after, err = decodeAuditEventCursor(raw); err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	limit, err := pageLimit(query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := h.audit.SecurityActivity(r.Context(), claims.AccountID, after, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSecurityActivitiesResponse(page))
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/google/uuid"
+)
+
+type SessionHandler struct {
+	service *application.SessionService
+	auth    *Authenticator
+}
+
+func NewSessionHandler(service *application.SessionService, auth *Authenticator) *SessionHandler {
+	return &SessionHandler{service: service, auth: auth}
+}
+
+func (h *SessionHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/sessions", h.auth.Require(h.List))
+	mux.HandleFunc("DELETE /v1/sessions/{id}", h.auth.RequireAccountHolder(h.Revoke))
+	mux.HandleFunc("POST /v1/auth/logout-all", h.auth.RequireAccountHolder(h.LogoutAll))
+}
+
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	sessions, err := h.service.List(r.Context(), claims.AccountID, claims.SessionID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := make([]sessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, newSessionResponse(session))
+	}
+	writeJSON(w, http.StatusOK, sessionsResponse{Sessions: response})
+}
+
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, errInvalidRequest)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), claims.AccountID, sessionID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SessionHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req logoutAllRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.RevokeAll(r.Context(), claims.AccountID, req.InvalidateAccessTokens); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
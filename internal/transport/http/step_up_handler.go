@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TheJisus28/ranco-auth-service/internal/application"
+	"github.com/TheJisus28/ranco-auth-service/internal/domain"
+)
+
+type StepUpHandler struct {
+	service *application.StepUpService
+	auth    *Authenticator
+	limits  *RateLimiter
+}
+
+func NewStepUpHandler(service *application.StepUpService, auth *Authenticator, limits *RateLimiter) *StepUpHandler {
+	return &StepUpHandler{service: service, auth: auth, limits: limits}
+}
+
+func (h *StepUpHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/auth/reauthenticate", h.limits.Verification("", h.auth.RequireAccountHolder(h.Reauthenticate)))
+}
+
+// Reauthenticate accepts either a password or an MFA code.
+func (h *StepUpHandler) Reauthenticate(w http.ResponseWriter, r *http.Request) {
+	claims := claimsFromContext(r.Context())
+
+	var req reauthenticateRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var (
+		elevated *application.ElevatedToken
+		err      error
+	)
+	switch {
+	case req.Password != "" && req.Code == "":
+		elevated, err = h.service.WithPassword(r.Context(), claims.AccountID, req.Password)
+	case req.Code != "" && req.Password == "":
+		elevated, err = h.service.WithMFA(r.Context(), claims.AccountID, domain.MFAFactorType(req.Method), req.Code)
+	default:
+		err = errInvalidRequest
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, elevatedTokenResponse{
+		AccessToken: elevated.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(time.Until(elevated.ExpiresAt).Seconds()),
+		AuthTime:    elevated.AuthTime.Unix(),
+		AMR:         elevated.AMR,
+		ACR:         elevated.ACR,
+	})
+}
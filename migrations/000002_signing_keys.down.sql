@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_signing_keys_activates_at;
+
+DROP TABLE IF EXISTS signing_keys;
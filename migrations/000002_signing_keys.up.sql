@@ -0,0 +1,13 @@
+CREATE TABLE signing_keys (
+    id VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key BYTEA NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL,
+    retired_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_signing_keys_activates_at ON signing_keys (activates_at);
+
+COMMENT ON TABLE signing_keys IS 'Rotating JWT signing keys, encrypted at rest';
@@ -0,0 +1 @@
+INSERT INTO auth_providers (code) VALUES ('GITHUB');
@@ -0,0 +1 @@
+DELETE FROM auth_providers WHERE code = 'APPLE';
@@ -0,0 +1,8 @@
+CREATE TABLE password_credentials (
+    auth_method_id UUID PRIMARY KEY REFERENCES auth_methods(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE password_credentials IS 'Argon2id password hashes of EMAIL auth methods';
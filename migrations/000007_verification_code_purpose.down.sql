@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_verification_codes_auth_method_purpose;
+CREATE INDEX idx_verification_codes_auth_method_id ON verification_codes (auth_method_id);
+
+ALTER TABLE verification_codes DROP COLUMN IF EXISTS purpose;
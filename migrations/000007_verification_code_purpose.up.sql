@@ -0,0 +1,6 @@
+ALTER TABLE verification_codes ADD COLUMN purpose VARCHAR(32) NOT NULL DEFAULT 'LOGIN';
+
+DROP INDEX IF EXISTS idx_verification_codes_auth_method_id;
+CREATE INDEX idx_verification_codes_auth_method_purpose ON verification_codes (auth_method_id, purpose);
+
+COMMENT ON COLUMN verification_codes.purpose IS 'Flow the code belongs to: EMAIL_VERIFICATION, LOGIN or PASSWORD_RESET';
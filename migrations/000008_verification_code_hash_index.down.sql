@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_verification_codes_code_hash;
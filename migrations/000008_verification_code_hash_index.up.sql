@@ -0,0 +1 @@
+CREATE INDEX idx_verification_codes_code_hash ON verification_codes (code_hash);
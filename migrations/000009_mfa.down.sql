@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_mfa_challenges_account_id;
+
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS mfa_factors;
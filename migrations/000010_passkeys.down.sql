@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS passkey_ceremonies;
+
+DROP INDEX IF EXISTS idx_passkey_credentials_account_id;
+DROP TABLE IF EXISTS passkey_credentials;
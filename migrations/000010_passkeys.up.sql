@@ -0,0 +1,30 @@
+CREATE TABLE passkey_credentials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    credential_id BYTEA NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL,
+    aaguid BYTEA,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports VARCHAR(255),
+    user_verified BOOLEAN NOT NULL DEFAULT false,
+    backup_eligible BOOLEAN NOT NULL DEFAULT false,
+    backup_state BOOLEAN NOT NULL DEFAULT false,
+    name VARCHAR(64),
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_passkey_credentials_account_id ON passkey_credentials (account_id);
+
+CREATE TABLE passkey_ceremonies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID REFERENCES accounts(id) ON DELETE CASCADE,
+    purpose VARCHAR(32) NOT NULL,
+    state BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE passkey_credentials IS 'WebAuthn public key credentials registered by accounts';
+COMMENT ON TABLE passkey_ceremonies IS 'Server-side state of WebAuthn ceremonies in progress';
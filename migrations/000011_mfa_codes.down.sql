@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_mfa_codes_factor_id;
+
+DROP TABLE IF EXISTS mfa_codes;
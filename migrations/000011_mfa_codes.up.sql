@@ -0,0 +1,13 @@
+CREATE TABLE mfa_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    factor_id UUID NOT NULL REFERENCES mfa_factors(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mfa_codes_factor_id ON mfa_codes (factor_id);
+
+COMMENT ON TABLE mfa_codes IS 'One-time codes delivered out of band for MFA factors such as SMS';
@@ -0,0 +1,10 @@
+CREATE TABLE mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (account_id, code_hash)
+);
+
+COMMENT ON TABLE mfa_recovery_codes IS 'Single-use codes that replace a second factor when it is unavailable';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_trusted_devices_account_id;
+
+DROP TABLE IF EXISTS trusted_devices;
@@ -0,0 +1,14 @@
+CREATE TABLE trusted_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_trusted_devices_account_id ON trusted_devices (account_id);
+
+COMMENT ON TABLE trusted_devices IS 'Devices allowed to skip the MFA challenge until their trust expires';
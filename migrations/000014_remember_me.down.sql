@@ -0,0 +1,2 @@
+ALTER TABLE mfa_challenges DROP COLUMN IF EXISTS remember_me;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS remember_me;
@@ -0,0 +1,5 @@
+ALTER TABLE refresh_tokens ADD COLUMN remember_me BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE mfa_challenges ADD COLUMN remember_me BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN refresh_tokens.remember_me IS 'Whether the session was opened with remember me, selecting the long refresh token lifetime';
+COMMENT ON COLUMN mfa_challenges.remember_me IS 'Remember me choice of the primary login, applied to the session opened by the challenge';
@@ -0,0 +1,4 @@
+ALTER TABLE refresh_tokens ADD COLUMN session_started_at TIMESTAMPTZ NOT NULL DEFAULT now();
+UPDATE refresh_tokens SET session_started_at = created_at;
+
+COMMENT ON COLUMN refresh_tokens.session_started_at IS 'Login that opened the session, carried over by every rotation';
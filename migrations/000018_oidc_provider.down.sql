@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_browser_sessions_account_id;
+
+DROP TABLE IF EXISTS browser_sessions;
+
+DROP TABLE IF EXISTS authorization_codes;
+
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS redirect_uris;
@@ -0,0 +1,33 @@
+ALTER TABLE oauth_clients ADD COLUMN redirect_uris TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN oauth_clients.redirect_uris IS 'Space separated redirect URIs accepted in authorization requests';
+
+CREATE TABLE authorization_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code_hash VARCHAR(255) NOT NULL UNIQUE,
+    client_id UUID NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    redirect_uri TEXT NOT NULL,
+    scope VARCHAR(255) NOT NULL,
+    nonce VARCHAR(255),
+    auth_time TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE authorization_codes IS 'Single-use codes issued by the authorization endpoint and exchanged at the token endpoint';
+
+CREATE TABLE browser_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_browser_sessions_account_id ON browser_sessions (account_id);
+
+COMMENT ON TABLE browser_sessions IS 'Session cookies that let the authorization endpoint recognize a signed-in browser';
+COMMENT ON COLUMN browser_sessions.session_id IS 'Refresh token session the cookie was created from; the cookie ends with it';
@@ -0,0 +1,6 @@
+ALTER TABLE authorization_codes DROP COLUMN IF EXISTS code_challenge_method;
+ALTER TABLE authorization_codes DROP COLUMN IF EXISTS code_challenge;
+
+DELETE FROM oauth_clients WHERE secret_hash IS NULL;
+ALTER TABLE oauth_clients ALTER COLUMN secret_hash SET NOT NULL;
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS public;
@@ -0,0 +1,9 @@
+ALTER TABLE oauth_clients ADD COLUMN public BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE oauth_clients ALTER COLUMN secret_hash DROP NOT NULL;
+
+ALTER TABLE authorization_codes ADD COLUMN code_challenge VARCHAR(128);
+ALTER TABLE authorization_codes ADD COLUMN code_challenge_method VARCHAR(10);
+
+COMMENT ON COLUMN oauth_clients.public IS 'Whether the client cannot keep a secret, such as a mobile app; public clients must use PKCE';
+COMMENT ON COLUMN authorization_codes.code_challenge IS 'PKCE code challenge (RFC 7636) the code verifier must match at the exchange';
+COMMENT ON COLUMN authorization_codes.code_challenge_method IS 'PKCE transformation of the code challenge; only S256 is accepted';
@@ -0,0 +1,5 @@
+ALTER TABLE oauth_clients ADD COLUMN grant_types TEXT NOT NULL DEFAULT 'authorization_code refresh_token';
+ALTER TABLE oauth_clients ADD COLUMN scopes TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN oauth_clients.grant_types IS 'Space separated grant types the client may use at the token endpoint';
+COMMENT ON COLUMN oauth_clients.scopes IS 'Space separated scopes the client may request with the client credentials grant';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_device_codes_user_code_hash;
+
+DROP TABLE IF EXISTS device_codes;
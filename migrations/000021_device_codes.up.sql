@@ -0,0 +1,21 @@
+CREATE TABLE device_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    device_code_hash VARCHAR(255) NOT NULL UNIQUE,
+    user_code_hash VARCHAR(255) NOT NULL,
+    client_id UUID NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    scope VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    account_id UUID REFERENCES accounts(id) ON DELETE CASCADE,
+    auth_time TIMESTAMPTZ,
+    interval_seconds INTEGER NOT NULL,
+    last_polled_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_device_codes_user_code_hash ON device_codes (user_code_hash);
+
+COMMENT ON TABLE device_codes IS 'Pending device authorization grants of RFC 8628, approved by a signed-in user through the user code';
+COMMENT ON COLUMN device_codes.status IS 'PENDING until the user approves (APPROVED) or denies (DENIED) the request';
+COMMENT ON COLUMN device_codes.interval_seconds IS 'Minimum seconds between token requests; raised each time the device polls too fast';
@@ -0,0 +1,2 @@
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS impersonation;
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS exchange_audiences;
@@ -0,0 +1,5 @@
+ALTER TABLE oauth_clients ADD COLUMN exchange_audiences TEXT NOT NULL DEFAULT '';
+ALTER TABLE oauth_clients ADD COLUMN impersonation BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN oauth_clients.exchange_audiences IS 'Space separated audiences the client may request with the token exchange grant';
+COMMENT ON COLUMN oauth_clients.impersonation IS 'Whether the client may exchange tokens without an actor token, impersonating the subject';
@@ -0,0 +1,3 @@
+ALTER TABLE refresh_tokens ADD COLUMN dpop_jkt VARCHAR(64);
+
+COMMENT ON COLUMN refresh_tokens.dpop_jkt IS 'JWK thumbprint of the DPoP key the token is bound to; refreshing requires a proof signed with it';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_access_tokens_expires_at;
+
+DROP TABLE IF EXISTS access_tokens;
@@ -0,0 +1,12 @@
+CREATE TABLE access_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    claims JSONB NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_access_tokens_expires_at ON access_tokens (expires_at);
+
+COMMENT ON TABLE access_tokens IS 'Access tokens of the opaque format, which carry no claims and are resolved here';
+COMMENT ON COLUMN access_tokens.claims IS 'Claims of the token, encoded like the payload of a JWT access token';
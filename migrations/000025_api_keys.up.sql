@@ -0,0 +1,19 @@
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    key_hash VARCHAR(255) NOT NULL UNIQUE,
+    key_prefix VARCHAR(16) NOT NULL,
+    scopes TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_keys_account_id ON api_keys (account_id);
+
+COMMENT ON TABLE api_keys IS 'Long-lived credentials account holders create for integrations';
+COMMENT ON COLUMN api_keys.key_prefix IS 'First characters of the key, kept in clear so holders can tell their keys apart';
+COMMENT ON COLUMN api_keys.scopes IS 'Space separated scopes; empty keys act with the full rights of the account';
+COMMENT ON COLUMN api_keys.last_used_at IS 'Last use of the key, recorded at most once a minute';
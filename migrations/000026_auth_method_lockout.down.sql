@@ -0,0 +1,2 @@
+ALTER TABLE auth_methods DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE auth_methods DROP COLUMN IF EXISTS failed_attempts;
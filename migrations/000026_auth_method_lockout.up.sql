@@ -0,0 +1,5 @@
+ALTER TABLE auth_methods ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE auth_methods ADD COLUMN locked_until TIMESTAMPTZ;
+
+COMMENT ON COLUMN auth_methods.failed_attempts IS 'Consecutive failed password or code attempts since the last successful sign-in';
+COMMENT ON COLUMN auth_methods.locked_until IS 'End of the current lockout; the method refuses sign-in attempts until then';
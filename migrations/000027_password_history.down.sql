@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_password_history_auth_method_id;
+
+DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,11 @@
+CREATE TABLE password_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auth_method_id UUID NOT NULL REFERENCES auth_methods(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_password_history_auth_method_id ON password_history (auth_method_id, created_at DESC);
+
+COMMENT ON TABLE password_history IS 'Hashes of the passwords an EMAIL auth method used before its current one, checked to refuse reuse';
+COMMENT ON COLUMN password_history.created_at IS 'Moment the password was replaced';
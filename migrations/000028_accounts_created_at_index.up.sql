@@ -0,0 +1 @@
+CREATE INDEX idx_accounts_created_at_id ON accounts (created_at, id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_auth_methods_email_trgm;
@@ -0,0 +1,4 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_auth_methods_email_trgm ON auth_methods USING gin (provider_id gin_trgm_ops)
+WHERE provider_code = 'EMAIL';
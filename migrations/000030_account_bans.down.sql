@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_account_bans_expires_at;
+DROP INDEX IF EXISTS idx_account_bans_active;
+DROP INDEX IF EXISTS idx_account_bans_account_id;
+
+DROP TABLE IF EXISTS account_bans;
@@ -0,0 +1,20 @@
+CREATE TABLE account_bans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    previous_status_code VARCHAR(32) NOT NULL REFERENCES account_statuses(code),
+    reason TEXT NOT NULL,
+    banned_by UUID REFERENCES accounts(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ,
+    lifted_at TIMESTAMPTZ,
+    lifted_by UUID REFERENCES accounts(id) ON DELETE SET NULL,
+    lift_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_account_bans_account_id ON account_bans (account_id, created_at DESC);
+CREATE UNIQUE INDEX idx_account_bans_active ON account_bans (account_id) WHERE lifted_at IS NULL;
+CREATE INDEX idx_account_bans_expires_at ON account_bans (expires_at) WHERE lifted_at IS NULL AND expires_at IS NOT NULL;
+
+COMMENT ON TABLE account_bans IS 'Bans of accounts by administrators and their lifting, kept as an audit trail';
+COMMENT ON COLUMN account_bans.previous_status_code IS 'Status the account returns to when the ban is lifted';
+COMMENT ON COLUMN account_bans.lifted_by IS 'Administrator who lifted the ban, NULL when it expired';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_account_role_changes_account_id;
+
+DROP TABLE IF EXISTS account_role_changes;
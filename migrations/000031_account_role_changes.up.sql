@@ -0,0 +1,13 @@
+CREATE TABLE account_role_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    previous_role_code VARCHAR(32) NOT NULL REFERENCES account_roles(code),
+    role_code VARCHAR(32) NOT NULL REFERENCES account_roles(code),
+    changed_by UUID REFERENCES accounts(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_account_role_changes_account_id ON account_role_changes (account_id, created_at DESC);
+
+COMMENT ON TABLE account_role_changes IS 'Role changes of accounts by administrators, kept as an audit trail';
@@ -0,0 +1,11 @@
+DELETE FROM account_roles WHERE NOT is_system;
+
+ALTER TABLE account_role_changes
+    ADD CONSTRAINT account_role_changes_previous_role_code_fkey FOREIGN KEY (previous_role_code) REFERENCES account_roles(code),
+    ADD CONSTRAINT account_role_changes_role_code_fkey FOREIGN KEY (role_code) REFERENCES account_roles(code);
+
+DROP TABLE IF EXISTS role_permissions;
+
+ALTER TABLE account_roles
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS is_system;
@@ -0,0 +1,19 @@
+ALTER TABLE account_roles
+    ADD COLUMN is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE account_roles SET is_system = TRUE WHERE code IN ('ADMIN', 'USER');
+
+CREATE TABLE role_permissions (
+    role_code VARCHAR(32) NOT NULL REFERENCES account_roles(code) ON DELETE CASCADE,
+    permission VARCHAR(128) NOT NULL,
+    PRIMARY KEY (role_code, permission)
+);
+
+-- The audit trail keeps the codes of roles that were deleted since.
+ALTER TABLE account_role_changes
+    DROP CONSTRAINT account_role_changes_previous_role_code_fkey,
+    DROP CONSTRAINT account_role_changes_role_code_fkey;
+
+COMMENT ON COLUMN account_roles.is_system IS 'Built-in roles, which cannot be deleted';
+COMMENT ON TABLE role_permissions IS 'Permissions granted by each role, carried in the access tokens of its accounts';
@@ -0,0 +1,5 @@
+ALTER TABLE refresh_tokens ADD COLUMN scope TEXT;
+ALTER TABLE mfa_challenges ADD COLUMN scope TEXT;
+
+COMMENT ON COLUMN refresh_tokens.scope IS 'Space separated scopes the session''s access tokens are restricted to; NULL for unrestricted sessions';
+COMMENT ON COLUMN mfa_challenges.scope IS 'Scopes requested at the primary login, applied to the session opened by the challenge';
@@ -0,0 +1,4 @@
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_memberships;
+DROP TABLE IF EXISTS organizations;
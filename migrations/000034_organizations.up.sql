@@ -0,0 +1,24 @@
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    slug VARCHAR(63) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE organization_memberships (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    role_code VARCHAR(32) NOT NULL REFERENCES account_roles(code),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (organization_id, account_id)
+);
+
+CREATE INDEX idx_organization_memberships_account_id ON organization_memberships (account_id);
+
+ALTER TABLE refresh_tokens ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE organizations IS 'Customer organizations that accounts belong to';
+COMMENT ON TABLE organization_memberships IS 'Accounts belonging to each organization, with their role in it';
+COMMENT ON COLUMN refresh_tokens.organization_id IS 'Active organization of the session, carried in its access tokens';
@@ -0,0 +1,18 @@
+CREATE TABLE organization_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role_code VARCHAR(32) NOT NULL REFERENCES account_roles(code),
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    invited_by UUID REFERENCES accounts(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by UUID REFERENCES accounts(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_organization_invitations_organization_id ON organization_invitations (organization_id, email);
+
+COMMENT ON TABLE organization_invitations IS 'Invitations for email addresses to join an organization with a role';
+COMMENT ON COLUMN organization_invitations.token_hash IS 'Hash of the invitation token emailed to the address';
@@ -0,0 +1,3 @@
+DELETE FROM signing_keys WHERE organization_id IS NOT NULL;
+
+ALTER TABLE signing_keys DROP COLUMN IF EXISTS organization_id;
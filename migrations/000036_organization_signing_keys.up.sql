@@ -0,0 +1,5 @@
+ALTER TABLE signing_keys ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_signing_keys_organization_id ON signing_keys (organization_id);
+
+COMMENT ON COLUMN signing_keys.organization_id IS 'Organization whose access tokens the key signs; platform keys have none';
@@ -0,0 +1,14 @@
+CREATE TABLE organization_branding (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    sender_name VARCHAR(100),
+    sender_address VARCHAR(255),
+    logo_url TEXT,
+    primary_color VARCHAR(7),
+    background_color VARCHAR(7),
+    footer_text TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE organization_branding IS 'Branding of the emails sent on behalf of white-label organizations';
+COMMENT ON COLUMN organization_branding.sender_address IS 'From address of the emails; null uses the default sender';
@@ -0,0 +1,16 @@
+CREATE TABLE organization_policies (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    password_min_length INTEGER NOT NULL DEFAULT 0,
+    password_required_classes TEXT NOT NULL DEFAULT '',
+    require_mfa BOOLEAN NOT NULL DEFAULT false,
+    session_max_age_seconds INTEGER NOT NULL DEFAULT 0,
+    allowed_providers TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE organization_policies IS 'Authentication policies of organizations, applied to their members on top of the service-wide policies';
+COMMENT ON COLUMN organization_policies.password_min_length IS 'Minimum password length of members; 0 keeps the service-wide minimum';
+COMMENT ON COLUMN organization_policies.password_required_classes IS 'Space separated character classes member passwords must contain';
+COMMENT ON COLUMN organization_policies.session_max_age_seconds IS 'Absolute lifetime of member sessions; 0 keeps the service-wide lifetime';
+COMMENT ON COLUMN organization_policies.allowed_providers IS 'Space separated providers members may sign in with; empty allows every provider';
@@ -0,0 +1,18 @@
+CREATE TABLE impersonations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    admin_id UUID REFERENCES accounts(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    token_id VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_impersonations_account_id ON impersonations (account_id, created_at DESC);
+CREATE INDEX idx_impersonations_admin_id ON impersonations (admin_id, created_at DESC);
+
+COMMENT ON TABLE impersonations IS 'Administrators acting as other accounts, kept as an audit trail';
+COMMENT ON COLUMN impersonations.token_id IS 'jti of the access token issued for the impersonation, denylisted when it ends early';
@@ -0,0 +1,19 @@
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    action VARCHAR(64) NOT NULL,
+    actor_id UUID,
+    target_id UUID,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_events_target_id ON audit_events (target_id, created_at DESC);
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id, created_at DESC);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+
+COMMENT ON TABLE audit_events IS 'Append-only log of security-relevant actions';
+COMMENT ON COLUMN audit_events.actor_id IS 'Account that acted, kept without a foreign key so entries outlive it; NULL for anonymous attempts';
+COMMENT ON COLUMN audit_events.target_id IS 'Account acted on, kept without a foreign key so entries outlive it';
+COMMENT ON COLUMN audit_events.details IS 'String attributes of the action, such as the provider of a login or the reason of a ban';
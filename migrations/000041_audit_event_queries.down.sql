@@ -0,0 +1,8 @@
+DROP INDEX idx_audit_events_created_at;
+DROP INDEX idx_audit_events_action;
+DROP INDEX idx_audit_events_actor_id;
+DROP INDEX idx_audit_events_target_id;
+
+CREATE INDEX idx_audit_events_target_id ON audit_events (target_id, created_at DESC);
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id, created_at DESC);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
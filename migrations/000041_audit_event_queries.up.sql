@@ -0,0 +1,10 @@
+-- Pages of the audit log are read newest first by (created_at, id), so the
+-- indexes end with both to keep the order stable across equal timestamps.
+DROP INDEX idx_audit_events_target_id;
+DROP INDEX idx_audit_events_actor_id;
+DROP INDEX idx_audit_events_created_at;
+
+CREATE INDEX idx_audit_events_target_id ON audit_events (target_id, created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id, created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_action ON audit_events (action, created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_endpoint_event;
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,22 @@
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_outbox_events_due ON outbox_events (next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events (published_at) WHERE published_at IS NOT NULL;
+
+-- Relayed events may be published twice; their deliveries to an endpoint are
+-- queued once.
+CREATE UNIQUE INDEX idx_webhook_deliveries_endpoint_event ON webhook_deliveries (endpoint_id, event_id);
+
+COMMENT ON TABLE outbox_events IS 'Domain events stored with the change that raised them, until they are published to the broker and webhooks';
+COMMENT ON COLUMN outbox_events.id IS 'Identifier of the event, from which the ids consumers deduplicate by are derived';
+COMMENT ON COLUMN outbox_events.payload IS 'The event as JSON, without the codes or tokens it carries for emails';
+COMMENT ON COLUMN outbox_events.next_attempt_at IS 'Time of the next attempt of an unpublished event, pushed back while an attempt is in flight';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_verification_codes_consumed_at;
+DROP INDEX IF EXISTS idx_verification_codes_expires_at;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_verification_codes_expires_at ON verification_codes (expires_at);
+CREATE INDEX idx_verification_codes_consumed_at ON verification_codes (consumed_at) WHERE consumed_at IS NOT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE account_deletions (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+    purge_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_account_deletions_purge_at ON account_deletions (purge_at);
+
+COMMENT ON TABLE account_deletions IS 'Accounts deleted by their holder, restorable until they are purged';
+COMMENT ON COLUMN account_deletions.purge_at IS 'When the account is deleted for good, ending the grace period';
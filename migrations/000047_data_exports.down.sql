@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_data_exports_expires_at;
+DROP INDEX IF EXISTS idx_data_exports_pending;
+DROP INDEX IF EXISTS idx_data_exports_account_id;
+
+DROP TABLE IF EXISTS data_exports;
@@ -0,0 +1,17 @@
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    archive BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_data_exports_account_id ON data_exports (account_id, created_at DESC);
+CREATE UNIQUE INDEX idx_data_exports_pending ON data_exports (account_id) WHERE status = 'PENDING';
+CREATE INDEX idx_data_exports_expires_at ON data_exports (expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON TABLE data_exports IS 'Archives of the data held about an account, requested by its holder';
+COMMENT ON COLUMN data_exports.status IS 'PENDING until the archive is generated, then READY until it expires';
+COMMENT ON COLUMN data_exports.archive IS 'JSON archive, NULL while PENDING';
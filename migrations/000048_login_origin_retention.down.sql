@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_trusted_devices_origin_created_at;
+DROP INDEX IF EXISTS idx_refresh_tokens_origin_created_at;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_refresh_tokens_origin_created_at ON refresh_tokens (created_at) WHERE ip_address IS NOT NULL OR user_agent IS NOT NULL;
+CREATE INDEX idx_trusted_devices_origin_created_at ON trusted_devices (created_at) WHERE ip_address IS NOT NULL OR user_agent IS NOT NULL;
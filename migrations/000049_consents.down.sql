@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS consents;
+
+DROP INDEX IF EXISTS idx_legal_documents_kind;
+
+DROP TABLE IF EXISTS legal_documents;
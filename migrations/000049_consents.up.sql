@@ -0,0 +1,24 @@
+CREATE TABLE legal_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(32) NOT NULL,
+    version VARCHAR(64) NOT NULL,
+    url TEXT NOT NULL,
+    published_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (kind, version)
+);
+
+CREATE INDEX idx_legal_documents_kind ON legal_documents (kind, published_at DESC, id DESC);
+
+CREATE TABLE consents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES legal_documents(id),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    accepted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (account_id, document_id)
+);
+
+COMMENT ON TABLE legal_documents IS 'Published versions of the terms of service and the privacy policy';
+COMMENT ON COLUMN legal_documents.kind IS 'TERMS_OF_SERVICE or PRIVACY_POLICY; the version of each kind published last is current';
+COMMENT ON TABLE consents IS 'Legal document versions accepted by each account, with where they were accepted from';
@@ -0,0 +1,2 @@
+ALTER TABLE mfa_factors DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE mfa_factors DROP COLUMN IF EXISTS failed_attempts;
@@ -0,0 +1,5 @@
+ALTER TABLE mfa_factors ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE mfa_factors ADD COLUMN locked_until TIMESTAMPTZ;
+
+COMMENT ON COLUMN mfa_factors.failed_attempts IS 'Consecutive failed codes checked outside an MFA challenge, such as on step-up, since the last accepted one';
+COMMENT ON COLUMN mfa_factors.locked_until IS 'End of the current lockout; the factor refuses codes checked outside an MFA challenge until then';
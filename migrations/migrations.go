@@ -0,0 +1,11 @@
+// Package migrations embeds the schema migrations, so the service binary
+// can apply them without the files being shipped next to it.
+package migrations
+
+import "embed"
+
+// FS holds the golang-migrate migration files, named
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
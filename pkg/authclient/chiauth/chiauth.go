@@ -0,0 +1,44 @@
+// Package chiauth adapts authclient to chi routers.
+//
+//	r.Use(chiauth.Authenticate(verifier))
+//	r.With(chiauth.RequireRole(authclient.RoleAdmin)).Delete("/orders/{id}", deleteOrder)
+//
+// Handlers read the caller with authclient.IdentityFromContext.
+package chiauth
+
+import (
+	"net/http"
+
+	"github.com/TheJisus28/ranco-auth-service/pkg/authclient"
+	"github.com/go-chi/chi/v5"
+)
+
+// Authenticate rejects requests without an acceptable bearer token and
+// stores the identity of the others in their context.
+func Authenticate(verifier authclient.Verifier) func(http.Handler) http.Handler {
+	return authclient.Middleware(verifier)
+}
+
+// Require rejects requests whose identity does not meet requirement. It
+// must run after Authenticate.
+func Require(requirement authclient.Requirement) func(http.Handler) http.Handler {
+	return authclient.Require(requirement)
+}
+
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return Require(authclient.Requirement{Role: role})
+}
+
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	return Require(authclient.Requirement{Scopes: scopes})
+}
+
+func RequirePermissions(permissions ...string) func(http.Handler) http.Handler {
+	return Require(authclient.Requirement{Permissions: permissions})
+}
+
+// Protect authenticates and checks requirement in one step, for routes
+// outside an authenticated group: r.With(chiauth.Protect(verifier, req)...).
+func Protect(verifier authclient.Verifier, requirement authclient.Requirement) chi.Middlewares {
+	return chi.Middlewares{Authenticate(verifier), Require(requirement)}
+}
@@ -0,0 +1,184 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// maxResponseBytes bounds the responses read from the auth service.
+const maxResponseBytes = 1 << 20
+
+// Config locates the auth service and the client credentials a service
+// authenticates with.
+type Config struct {
+	// BaseURL is the JWT_ISSUER of the auth service, e.g.
+	// https://auth.example.com.
+	BaseURL      string
+	ClientID     string
+	ClientSecret string
+	HTTPClient   *http.Client
+}
+
+// Client calls the HTTP API of the auth service as a confidential client.
+type Client struct {
+	config Config
+}
+
+func NewClient(config Config) *Client {
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &Client{config: config}
+}
+
+// APIError is an error response of the auth service.
+type APIError struct {
+	StatusCode int
+	Code       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("authclient: %s (status %d)", e.Code, e.StatusCode)
+}
+
+// Introspection is the RFC 7662 description of a token. Only Active is set
+// for tokens that are not active.
+type Introspection struct {
+	Active           bool     `json:"active"`
+	TokenType        string   `json:"token_type"`
+	Scope            string   `json:"scope"`
+	Subject          string   `json:"sub"`
+	ClientID         string   `json:"client_id"`
+	Role             string   `json:"role"`
+	Status           string   `json:"status"`
+	Permissions      []string `json:"permissions"`
+	OrganizationID   string   `json:"org_id"`
+	OrganizationRole string   `json:"org_role"`
+	TokenID          string   `json:"jti"`
+	SessionID        string   `json:"sid"`
+	IssuedAt         int64    `json:"iat"`
+	// ExpiresAt is zero for API keys that never expire.
+	ExpiresAt    int64 `json:"exp"`
+	Confirmation *struct {
+		KeyThumbprint string `json:"jkt"`
+	} `json:"cnf"`
+	// Actor is set on delegated and impersonation tokens.
+	Actor *struct {
+		Subject      string `json:"sub"`
+		ClientID     string `json:"client_id"`
+		Impersonator bool   `json:"impersonator"`
+	} `json:"act"`
+}
+
+// UserInfo holds the OpenID Connect claims about an account.
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+// TokenSource returns client credentials tokens for scopes, reused until
+// they are about to expire. Passing it to GRPCCredentials authenticates
+// calls to the gRPC API.
+func (c *Client) TokenSource(ctx context.Context, scopes ...string) oauth2.TokenSource {
+	config := clientcredentials.Config{
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+		TokenURL:     c.config.BaseURL + "/oauth/token",
+		Scopes:       scopes,
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+	return config.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, c.config.HTTPClient))
+}
+
+// Introspect describes token, asking for an access token first.
+func (c *Client) Introspect(ctx context.Context, token string) (*Introspection, error) {
+	var introspection Introspection
+	err := c.postForm(ctx, "/oauth/introspect", url.Values{
+		"token":           {token},
+		"token_type_hint": {"access_token"},
+	}, &introspection)
+	if err != nil {
+		return nil, err
+	}
+	return &introspection, nil
+}
+
+// IntrospectBatch describes up to 100 access tokens in one call, in order.
+func (c *Client) IntrospectBatch(ctx context.Context, tokens []string) ([]*Introspection, error) {
+	var batch struct {
+		Tokens []*Introspection `json:"tokens"`
+	}
+	if err := c.postForm(ctx, "/oauth/introspect/batch", url.Values{"token": tokens}, &batch); err != nil {
+		return nil, err
+	}
+	return batch.Tokens, nil
+}
+
+// Revoke revokes an access or refresh token; hint may be empty. Unknown
+// tokens are not an error.
+func (c *Client) Revoke(ctx context.Context, token, hint string) error {
+	form := url.Values{"token": {token}}
+	if hint != "" {
+		form.Set("token_type_hint", hint)
+	}
+	return c.postForm(ctx, "/oauth/revoke", form, nil)
+}
+
+// UserInfo returns the claims about the account of an access token.
+func (c *Client) UserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+"/userinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	var info UserInfo
+	if err := c.do(req, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (c *Client) postForm(ctx context.Context, path string, form url.Values, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(c.config.ClientSecret))
+	return c.do(req, dst)
+}
+
+func (c *Client) do(req *http.Request, dst any) error {
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("authclient: %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	body := io.LimitReader(resp.Body, maxResponseBytes)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var problem struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(body).Decode(&problem)
+		return &APIError{StatusCode: resp.StatusCode, Code: problem.Error}
+	}
+	if dst == nil {
+		return nil
+	}
+	if err := json.NewDecoder(body).Decode(dst); err != nil {
+		return fmt.Errorf("authclient: decode %s response: %w", req.URL.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+// Package authclient lets other Ranco services rely on the auth service:
+// a Client for its HTTP API, a GRPCClient for its internal gRPC API, and
+// Middleware that validates access tokens and stores the Identity of the
+// caller in the request context.
+//
+// Tokens are verified locally against the JWKS with a JWKSVerifier, or
+// remotely with an IntrospectionVerifier or a GRPCClient. Only remote
+// verification accepts opaque and PASETO tokens and API keys, and notices
+// revocations before tokens expire.
+package authclient
@@ -0,0 +1,64 @@
+// Package echoauth adapts authclient to echo servers.
+//
+//	e.Use(echoauth.Authenticate(verifier))
+//	e.DELETE("/orders/:id", deleteOrder, echoauth.RequireRole(authclient.RoleAdmin))
+//
+// Handlers read the caller with echoauth.Identity.
+package echoauth
+
+import (
+	"github.com/TheJisus28/ranco-auth-service/pkg/authclient"
+	"github.com/labstack/echo/v4"
+)
+
+// Authenticate answers requests without an acceptable bearer token and
+// stores the identity of the others in their request context.
+func Authenticate(verifier authclient.Verifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity, err := authclient.Authenticate(c.Request(), verifier)
+			if err != nil {
+				authclient.WriteError(c.Response(), err)
+				return nil
+			}
+			c.SetRequest(c.Request().WithContext(authclient.WithIdentity(c.Request().Context(), identity)))
+			return next(c)
+		}
+	}
+}
+
+// Require answers requests whose identity does not meet requirement. It
+// must run after Authenticate.
+func Require(requirement authclient.Requirement) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity, ok := Identity(c)
+			if !ok {
+				authclient.WriteError(c.Response(), authclient.ErrMissingToken)
+				return nil
+			}
+			if !requirement.Allows(identity) {
+				authclient.WriteError(c.Response(), authclient.ErrForbidden)
+				return nil
+			}
+			return next(c)
+		}
+	}
+}
+
+func RequireRole(role string) echo.MiddlewareFunc {
+	return Require(authclient.Requirement{Role: role})
+}
+
+func RequireScopes(scopes ...string) echo.MiddlewareFunc {
+	return Require(authclient.Requirement{Scopes: scopes})
+}
+
+func RequirePermissions(permissions ...string) echo.MiddlewareFunc {
+	return Require(authclient.Requirement{Permissions: permissions})
+}
+
+// Identity returns the caller stored by Authenticate.
+func Identity(c echo.Context) (*authclient.Identity, bool) {
+	return authclient.IdentityFromContext(c.Request().Context())
+}
@@ -0,0 +1,65 @@
+// Package ginauth adapts authclient to gin engines.
+//
+//	router.Use(ginauth.Authenticate(verifier))
+//	router.DELETE("/orders/:id", ginauth.RequireRole(authclient.RoleAdmin), deleteOrder)
+//
+// Handlers read the caller with ginauth.Identity.
+package ginauth
+
+import (
+	"github.com/TheJisus28/ranco-auth-service/pkg/authclient"
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate aborts requests without an acceptable bearer token and
+// stores the identity of the others in their request context.
+func Authenticate(verifier authclient.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, err := authclient.Authenticate(c.Request, verifier)
+		if err != nil {
+			abort(c, err)
+			return
+		}
+		c.Request = c.Request.WithContext(authclient.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// Require aborts requests whose identity does not meet requirement. It
+// must run after Authenticate.
+func Require(requirement authclient.Requirement) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := Identity(c)
+		if !ok {
+			abort(c, authclient.ErrMissingToken)
+			return
+		}
+		if !requirement.Allows(identity) {
+			abort(c, authclient.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+func RequireRole(role string) gin.HandlerFunc {
+	return Require(authclient.Requirement{Role: role})
+}
+
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return Require(authclient.Requirement{Scopes: scopes})
+}
+
+func RequirePermissions(permissions ...string) gin.HandlerFunc {
+	return Require(authclient.Requirement{Permissions: permissions})
+}
+
+// Identity returns the caller stored by Authenticate.
+func Identity(c *gin.Context) (*authclient.Identity, bool) {
+	return authclient.IdentityFromContext(c.Request.Context())
+}
+
+func abort(c *gin.Context, err error) {
+	authclient.WriteError(c.Writer, err)
+	c.Abort()
+}
@@ -0,0 +1,137 @@
+package authclient
+
+import (
+	"context"
+
+	"github.com/TheJisus28/ranco-auth-service/pkg/authpb"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Account is the current role and status of an account.
+type Account struct {
+	ID     string
+	Role   string
+	Status string
+}
+
+// GRPCClient calls the internal gRPC API of the auth service. The
+// connection must authenticate calls, typically with GRPCCredentials.
+type GRPCClient struct {
+	client authpb.AuthServiceClient
+}
+
+func NewGRPCClient(conn grpc.ClientConnInterface) *GRPCClient {
+	return &GRPCClient{client: authpb.NewAuthServiceClient(conn)}
+}
+
+// Verify validates token at the auth service, like an IntrospectionVerifier
+// with less overhead.
+func (c *GRPCClient) Verify(ctx context.Context, token string) (*Identity, error) {
+	resp, err := c.client.ValidateToken(ctx, &authpb.ValidateTokenRequest{AccessToken: token})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.GetActive() {
+		return nil, ErrInvalidToken
+	}
+	return newIdentity(resp.GetClaims()), nil
+}
+
+// VerifyBatch validates up to 100 tokens in one call, for gateways checking
+// many tokens at once. Identities come back in order, nil for tokens that
+// are not accepted; like Check, restricted and DPoP-bound tokens are not.
+func (c *GRPCClient) VerifyBatch(ctx context.Context, tokens []string) ([]*Identity, error) {
+	resp, err := c.client.ValidateTokens(ctx, &authpb.ValidateTokensRequest{AccessTokens: tokens})
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*Identity, len(resp.GetResults()))
+	for i, result := range resp.GetResults() {
+		if !result.GetActive() {
+			continue
+		}
+		if identity := newIdentity(result.GetClaims()); !identity.restricted() && identity.KeyThumbprint == "" {
+			identities[i] = identity
+		}
+	}
+	return identities, nil
+}
+
+// Account looks an account up by ID.
+func (c *GRPCClient) Account(ctx context.Context, accountID string) (*Account, error) {
+	resp, err := c.client.GetAccount(ctx, &authpb.GetAccountRequest{AccountId: accountID})
+	if err != nil {
+		return nil, err
+	}
+	account := resp.GetAccount()
+	return &Account{ID: account.GetId(), Role: account.GetRoleCode(), Status: account.GetStatusCode()}, nil
+}
+
+// CheckPermission tells whether token meets requirement, judged on the
+// current role and status of its account. Invalid tokens are reported as
+// ErrInvalidToken.
+func (c *GRPCClient) CheckPermission(ctx context.Context, token string, requirement Requirement) (bool, *Identity, error) {
+	resp, err := c.client.CheckPermission(ctx, &authpb.CheckPermissionRequest{
+		AccessToken: token,
+		Role:        requirement.Role,
+		Scopes:      requirement.Scopes,
+		Permissions: requirement.Permissions,
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	if resp.GetClaims() == nil {
+		return false, nil, ErrInvalidToken
+	}
+	return resp.GetAllowed(), newIdentity(resp.GetClaims()), nil
+}
+
+func newIdentity(claims *authpb.TokenClaims) *Identity {
+	identity := &Identity{
+		AccountID:        claims.GetAccountId(),
+		ClientID:         claims.GetClientId(),
+		Role:             claims.GetRoleCode(),
+		Status:           claims.GetStatusCode(),
+		Scopes:           splitScope(claims.GetScope()),
+		Permissions:      claims.GetPermissions(),
+		OrganizationID:   claims.GetOrganizationId(),
+		OrganizationRole: claims.GetOrganizationRole(),
+		SessionID:        claims.GetSessionId(),
+		TokenID:          claims.GetTokenId(),
+		APIKeyID:         claims.GetApiKeyId(),
+		KeyThumbprint:    claims.GetKeyThumbprint(),
+		ImpersonatorID:   claims.GetImpersonatorId(),
+	}
+	if claims.GetExpiresAt() != nil {
+		identity.ExpiresAt = claims.GetExpiresAt().AsTime()
+	}
+	return identity
+}
+
+// tokenCredentials sends a client token with every call.
+type tokenCredentials struct {
+	source        oauth2.TokenSource
+	allowInsecure bool
+}
+
+// GRPCCredentials authenticates gRPC calls with tokens from source, such as
+// Client.TokenSource. Tokens are only sent over TLS unless allowInsecure is
+// set, for connections that stay on a trusted network.
+func GRPCCredentials(source oauth2.TokenSource, allowInsecure bool) credentials.PerRPCCredentials {
+	return tokenCredentials{source: source, allowInsecure: allowInsecure}
+}
+
+func (c tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token.AccessToken}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return !c.allowInsecure
+}
@@ -0,0 +1,121 @@
+package authclient
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Roles of Ranco accounts.
+const (
+	RoleAdmin = "ADMIN"
+	RoleUser  = "USER"
+)
+
+// Scopes of restricted tokens, which are only good for enrolling a second
+// factor or changing the password at the auth service.
+const (
+	scopeMFAEnrollment  = "mfa_enrollment"
+	scopePasswordChange = "password_change"
+)
+
+// Identity is the caller an access token was issued for. AccountID, Role and
+// Status are empty on client tokens, which carry ClientID instead.
+type Identity struct {
+	AccountID string
+	ClientID  string
+	Role      string
+	Status    string
+	// Scopes is empty on unrestricted account tokens.
+	Scopes []string
+	// Permissions are those of the account's role when the token was
+	// issued.
+	Permissions []string
+	// OrganizationID is the active organization of the caller's session, if
+	// any, and OrganizationRole the account's role in it.
+	OrganizationID   string
+	OrganizationRole string
+	SessionID        string
+	TokenID          string
+	// APIKeyID is set when the caller used an API key, which only remote
+	// verifiers report.
+	APIKeyID string
+	// KeyThumbprint is set on DPoP-bound tokens.
+	KeyThumbprint string
+	// ImpersonatorID is the administrator acting as the account on
+	// impersonation tokens.
+	ImpersonatorID string
+	// ExpiresAt is zero for API keys that never expire.
+	ExpiresAt time.Time
+}
+
+// IsClient reports whether the token was issued to a client acting on its
+// own behalf rather than for an account.
+func (i *Identity) IsClient() bool {
+	return i.AccountID == ""
+}
+
+// IsImpersonated reports whether an administrator is acting as the account.
+// Services may refuse sensitive operations to impersonation tokens.
+func (i *Identity) IsImpersonated() bool {
+	return i.ImpersonatorID != ""
+}
+
+// HasRole reports whether the account has role. ADMIN accounts have every
+// role; clients have none.
+func (i *Identity) HasRole(role string) bool {
+	return !i.IsClient() && (i.Role == role || i.Role == RoleAdmin)
+}
+
+// HasScopes reports whether the token grants every scope. Unrestricted
+// account tokens grant all of them.
+func (i *Identity) HasScopes(scopes ...string) bool {
+	if !i.IsClient() && len(i.Scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(i.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPermissions reports whether the account's role grants every
+// permission. ADMIN accounts have all of them; clients have none.
+func (i *Identity) HasPermissions(permissions ...string) bool {
+	if i.IsClient() {
+		return len(permissions) == 0
+	}
+	if i.Role == RoleAdmin {
+		return true
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(i.Permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func (i *Identity) restricted() bool {
+	return slices.Contains(i.Scopes, scopeMFAEnrollment) || slices.Contains(i.Scopes, scopePasswordChange)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying identity.
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity stored by Middleware.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+func splitScope(scope string) []string {
+	return strings.Fields(scope)
+}
@@ -0,0 +1,239 @@
+package authclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval bounds how often an unknown kid triggers a refetch, so
+// forged tokens cannot hammer the auth service. The service publishes keys
+// well ahead of using them.
+const jwksRefreshInterval = time.Minute
+
+// JWKSConfig configures a JWKSVerifier. Issuer and Audience must match the
+// JWT_ISSUER and JWT_AUDIENCE of the auth service, or the audience of
+// exchanged tokens addressed to this service.
+type JWKSConfig struct {
+	// JWKSURL defaults to Issuer + "/.well-known/jwks.json".
+	JWKSURL    string
+	Issuer     string
+	Audience   string
+	HTTPClient *http.Client
+	// Leeway tolerates clock skew on exp and nbf.
+	Leeway time.Duration
+}
+
+type jwksAccessClaims struct {
+	jwt.RegisteredClaims
+	Role             string   `json:"role"`
+	Status           string   `json:"status"`
+	Scope            string   `json:"scope"`
+	ClientID         string   `json:"client_id"`
+	Permissions      []string `json:"permissions"`
+	OrganizationID   string   `json:"org_id"`
+	OrganizationRole string   `json:"org_role"`
+	SessionID        string   `json:"sid"`
+	Confirmation     *struct {
+		KeyThumbprint string `json:"jkt"`
+	} `json:"cnf"`
+	Actor *actorClaim `json:"act"`
+}
+
+// actorClaim is the RFC 8693 act claim, which names the administrator on
+// impersonation tokens.
+type actorClaim struct {
+	Subject      string `json:"sub"`
+	Impersonator bool   `json:"impersonator"`
+}
+
+// JWKSVerifier verifies JWT access tokens with the published keys of the
+// auth service, without a call per token. Revocations go unnoticed until
+// the token expires.
+type JWKSVerifier struct {
+	config JWKSConfig
+	parser *jwt.Parser
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewJWKSVerifier(config JWKSConfig) *JWKSVerifier {
+	if config.JWKSURL == "" {
+		config.JWKSURL = config.Issuer + "/.well-known/jwks.json"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &JWKSVerifier{
+		config: config,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+			jwt.WithIssuer(config.Issuer),
+			jwt.WithAudience(config.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(config.Leeway),
+		),
+	}
+}
+
+func (v *JWKSVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	var (
+		claims   jwksAccessClaims
+		kid      string
+		fetchErr error
+	)
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ = t.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		if err != nil && !errors.Is(err, errUnknownKey) {
+			fetchErr = err
+		}
+		return key, err
+	})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	// Keys of an organization publish as "<organization id>.<kid>" and only
+	// vouch for tokens of that organization.
+	if organizationID, _, ok := strings.Cut(kid, "."); ok && organizationID != claims.OrganizationID {
+		return nil, ErrInvalidToken
+	}
+
+	identity := &Identity{
+		Role:             claims.Role,
+		Status:           claims.Status,
+		Scopes:           splitScope(claims.Scope),
+		Permissions:      claims.Permissions,
+		OrganizationID:   claims.OrganizationID,
+		OrganizationRole: claims.OrganizationRole,
+		SessionID:        claims.SessionID,
+		TokenID:          claims.ID,
+		ExpiresAt:        claims.ExpiresAt.Time,
+	}
+	if claims.ClientID != "" && claims.Subject == claims.ClientID {
+		identity.ClientID = claims.ClientID
+	} else {
+		identity.AccountID = claims.Subject
+	}
+	if claims.Confirmation != nil {
+		identity.KeyThumbprint = claims.Confirmation.KeyThumbprint
+	}
+	if claims.Actor != nil && claims.Actor.Impersonator {
+		identity.ImpersonatorID = claims.Actor.Subject
+	}
+	return identity, nil
+}
+
+var errUnknownKey = errors.New("authclient: unknown signing key")
+
+// key returns the key named kid, refetching the key set when kid is
+// unknown and the last fetch is old enough. Fetch failures are returned as
+// is; unknown keys are reported as errUnknownKey, an invalid token.
+func (v *JWKSVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(v.fetchedAt) < jwksRefreshInterval {
+		return nil, errUnknownKey
+	}
+
+	keys, err := v.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys, v.fetchedAt = keys, time.Now()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errUnknownKey
+}
+
+func (v *JWKSVerifier) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.config.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("authclient: fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authclient: fetch jwks: status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("authclient: decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, encoded := range set.Keys {
+		if key, err := encoded.publicKey(); err == nil {
+			keys[encoded.KeyID] = key
+		}
+	}
+	return keys, nil
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Curve   string `json:"crv"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	X       string `json:"x"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch {
+	case k.KeyType == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("authclient: invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case k.KeyType == "OKP" && k.Curve == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("authclient: invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("authclient: unsupported key type %q", k.KeyType)
+	}
+}
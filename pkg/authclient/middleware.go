@@ -0,0 +1,100 @@
+package authclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Middleware authenticates requests with their bearer token and stores the
+// identity of the caller in the request context, where IdentityFromContext
+// finds it. Requests without an acceptable token are answered with 401.
+func Middleware(verifier Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, err := Authenticate(r, verifier)
+			if err != nil {
+				WriteError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+		})
+	}
+}
+
+// Require answers 403 to requests whose identity does not meet requirement.
+// It must run after Middleware.
+func Require(requirement Requirement) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := IdentityFromContext(r.Context())
+			if !ok {
+				WriteError(w, ErrMissingToken)
+				return
+			}
+			if !requirement.Allows(identity) {
+				WriteError(w, ErrForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Authenticate checks the bearer token of r with verifier, as Middleware
+// does.
+func Authenticate(r *http.Request, verifier Verifier) (*Identity, error) {
+	token, ok := BearerToken(r)
+	if !ok {
+		return nil, ErrMissingToken
+	}
+	return Check(r.Context(), verifier, token)
+}
+
+// BearerToken returns the token of an "Authorization: Bearer" header.
+func BearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// ErrorStatus returns the HTTP status and error code an authentication
+// error is answered with, following RFC 6750 section 3.1. Failures to
+// reach the auth service are reported as 503.
+func ErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrMissingToken):
+		return http.StatusUnauthorized, "unauthorized"
+	case errors.Is(err, ErrInvalidToken):
+		return http.StatusUnauthorized, "invalid_token"
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden, "insufficient_scope"
+	default:
+		return http.StatusServiceUnavailable, "temporarily_unavailable"
+	}
+}
+
+// WriteError renders err as a JSON {"error": code} body with the matching
+// WWW-Authenticate challenge.
+func WriteError(w http.ResponseWriter, err error) {
+	status, code := ErrorStatus(err)
+	switch status {
+	case http.StatusUnauthorized:
+		if errors.Is(err, ErrMissingToken) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+		} else {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+	case http.StatusForbidden:
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope"`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{code})
+}
@@ -0,0 +1,54 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrMissingToken is returned for requests without a bearer token.
+	ErrMissingToken = errors.New("authclient: missing bearer token")
+	// ErrInvalidToken is returned for tokens that are malformed, expired,
+	// revoked or otherwise not accepted.
+	ErrInvalidToken = errors.New("authclient: invalid token")
+	// ErrForbidden is returned for valid tokens lacking a required role or
+	// scope.
+	ErrForbidden = errors.New("authclient: insufficient permissions")
+)
+
+// Verifier turns an access token into the identity of its caller. Tokens
+// that are not accepted are reported as ErrInvalidToken; other errors mean
+// the token could not be checked.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Identity, error)
+}
+
+// Check validates token with verifier and enforces what every resource
+// server requires: restricted and DPoP-bound tokens are refused, as proofs
+// cannot be checked here.
+func Check(ctx context.Context, verifier Verifier, token string) (*Identity, error) {
+	identity, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if identity.restricted() || identity.KeyThumbprint != "" {
+		return nil, ErrInvalidToken
+	}
+	return identity, nil
+}
+
+// Requirement is a role, scopes and permissions a caller must have. An empty
+// Role requires no role.
+type Requirement struct {
+	Role        string
+	Scopes      []string
+	Permissions []string
+}
+
+// Allows reports whether identity meets the requirement.
+func (r Requirement) Allows(identity *Identity) bool {
+	if r.Role != "" && !identity.HasRole(r.Role) {
+		return false
+	}
+	return identity.HasPermissions(r.Permissions...) && identity.HasScopes(r.Scopes...)
+}
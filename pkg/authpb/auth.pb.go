@@ -0,0 +1,796 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: ranco/auth/v1/auth.proto
+
+package authpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ValidateTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenRequest) Reset() {
+	*x = ValidateTokenRequest{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenRequest) ProtoMessage() {}
+
+func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type ValidateTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// active is false for tokens that are invalid, expired or revoked, which
+	// carry no claims.
+	Active        bool         `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	Claims        *TokenClaims `protobuf:"bytes,2,opt,name=claims,proto3" json:"claims,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenResponse) Reset() {
+	*x = ValidateTokenResponse{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenResponse) ProtoMessage() {}
+
+func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidateTokenResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *ValidateTokenResponse) GetClaims() *TokenClaims {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+// TokenClaims mirrors the claims of an access token. account_id, role_code
+// and status_code are empty on client tokens, which carry client_id instead.
+type TokenClaims struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TokenId    string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	AccountId  string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ClientId   string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	RoleCode   string                 `protobuf:"bytes,4,opt,name=role_code,json=roleCode,proto3" json:"role_code,omitempty"`
+	StatusCode string                 `protobuf:"bytes,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// scope is space separated; empty on unrestricted account tokens.
+	Scope     string `protobuf:"bytes,6,opt,name=scope,proto3" json:"scope,omitempty"`
+	SessionId string `protobuf:"bytes,7,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// key_thumbprint is the cnf.jkt claim of DPoP-bound tokens, whose proof
+	// the caller must check.
+	KeyThumbprint string `protobuf:"bytes,8,opt,name=key_thumbprint,json=keyThumbprint,proto3" json:"key_thumbprint,omitempty"`
+	// api_key_id is set when the token is an API key.
+	ApiKeyId string                 `protobuf:"bytes,9,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	IssuedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// expires_at is unset for API keys that never expire.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// permissions are those of the account's role carried by the token.
+	Permissions []string `protobuf:"bytes,12,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// organization_id is the active organization of the session, if any, and
+	// organization_role the account's role in it.
+	OrganizationId   string `protobuf:"bytes,13,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	OrganizationRole string `protobuf:"bytes,14,opt,name=organization_role,json=organizationRole,proto3" json:"organization_role,omitempty"`
+	// impersonator_id is the administrator acting as the account on
+	// impersonation tokens, which callers may want to refuse.
+	ImpersonatorId string `protobuf:"bytes,15,opt,name=impersonator_id,json=impersonatorId,proto3" json:"impersonator_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TokenClaims) Reset() {
+	*x = TokenClaims{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenClaims) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenClaims) ProtoMessage() {}
+
+func (x *TokenClaims) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenClaims.ProtoReflect.Descriptor instead.
+func (*TokenClaims) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TokenClaims) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetRoleCode() string {
+	if x != nil {
+		return x.RoleCode
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetKeyThumbprint() string {
+	if x != nil {
+		return x.KeyThumbprint
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *TokenClaims) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *TokenClaims) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *TokenClaims) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetOrganizationRole() string {
+	if x != nil {
+		return x.OrganizationRole
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetImpersonatorId() string {
+	if x != nil {
+		return x.ImpersonatorId
+	}
+	return ""
+}
+
+type ValidateTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessTokens  []string               `protobuf:"bytes,1,rep,name=access_tokens,json=accessTokens,proto3" json:"access_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokensRequest) Reset() {
+	*x = ValidateTokensRequest{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokensRequest) ProtoMessage() {}
+
+func (x *ValidateTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokensRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTokensRequest) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidateTokensRequest) GetAccessTokens() []string {
+	if x != nil {
+		return x.AccessTokens
+	}
+	return nil
+}
+
+type ValidateTokensResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*ValidateTokenResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokensResponse) Reset() {
+	*x = ValidateTokensResponse{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokensResponse) ProtoMessage() {}
+
+func (x *ValidateTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokensResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTokensResponse) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidateTokensResponse) GetResults() []*ValidateTokenResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type GetAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountResponse) Reset() {
+	*x = GetAccountResponse{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountResponse) ProtoMessage() {}
+
+func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAccountResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoleCode      string                 `protobuf:"bytes,2,opt,name=role_code,json=roleCode,proto3" json:"role_code,omitempty"`
+	StatusCode    string                 `protobuf:"bytes,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Account) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Account) GetRoleCode() string {
+	if x != nil {
+		return x.RoleCode
+	}
+	return ""
+}
+
+func (x *Account) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *Account) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CheckPermissionRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// role is the role the account must have; ADMIN accounts satisfy any
+	// role. Empty for no role requirement.
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// scopes must all be granted by the token. Unrestricted account tokens
+	// grant every scope.
+	Scopes []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// permissions must all be granted by the current role of the account;
+	// ADMIN accounts have every permission.
+	Permissions   []string `protobuf:"bytes,4,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionRequest) Reset() {
+	*x = CheckPermissionRequest{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionRequest) ProtoMessage() {}
+
+func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionRequest.ProtoReflect.Descriptor instead.
+func (*CheckPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckPermissionRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CheckPermissionRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type CheckPermissionResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Allowed bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// claims is unset when the token is invalid.
+	Claims        *TokenClaims `protobuf:"bytes,2,opt,name=claims,proto3" json:"claims,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionResponse) Reset() {
+	*x = CheckPermissionResponse{}
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionResponse) ProtoMessage() {}
+
+func (x *CheckPermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ranco_auth_v1_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionResponse.ProtoReflect.Descriptor instead.
+func (*CheckPermissionResponse) Descriptor() ([]byte, []int) {
+	return file_ranco_auth_v1_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CheckPermissionResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *CheckPermissionResponse) GetClaims() *TokenClaims {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+var File_ranco_auth_v1_auth_proto protoreflect.FileDescriptor
+
+const file_ranco_auth_v1_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x18ranco/auth/v1/auth.proto\x12\rranco.auth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"9\n" +
+	"\x14ValidateTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"c\n" +
+	"\x15ValidateTokenResponse\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x122\n" +
+	"\x06claims\x18\x02 \x01(\v2\x1a.ranco.auth.v1.TokenClaimsR\x06claims\"\xb1\x04\n" +
+	"\vTokenClaims\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\tR\atokenId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1b\n" +
+	"\trole_code\x18\x04 \x01(\tR\broleCode\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\tR\n" +
+	"statusCode\x12\x14\n" +
+	"\x05scope\x18\x06 \x01(\tR\x05scope\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\a \x01(\tR\tsessionId\x12%\n" +
+	"\x0ekey_thumbprint\x18\b \x01(\tR\rkeyThumbprint\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\t \x01(\tR\bapiKeyId\x127\n" +
+	"\tissued_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12 \n" +
+	"\vpermissions\x18\f \x03(\tR\vpermissions\x12'\n" +
+	"\x0forganization_id\x18\r \x01(\tR\x0eorganizationId\x12+\n" +
+	"\x11organization_role\x18\x0e \x01(\tR\x10organizationRole\x12'\n" +
+	"\x0fimpersonator_id\x18\x0f \x01(\tR\x0eimpersonatorId\"<\n" +
+	"\x15ValidateTokensRequest\x12#\n" +
+	"\raccess_tokens\x18\x01 \x03(\tR\faccessTokens\"X\n" +
+	"\x16ValidateTokensResponse\x12>\n" +
+	"\aresults\x18\x01 \x03(\v2$.ranco.auth.v1.ValidateTokenResponseR\aresults\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"F\n" +
+	"\x12GetAccountResponse\x120\n" +
+	"\aaccount\x18\x01 \x01(\v2\x16.ranco.auth.v1.AccountR\aaccount\"\x92\x01\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\trole_code\x18\x02 \x01(\tR\broleCode\x12\x1f\n" +
+	"\vstatus_code\x18\x03 \x01(\tR\n" +
+	"statusCode\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x89\x01\n" +
+	"\x16CheckPermissionRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12 \n" +
+	"\vpermissions\x18\x04 \x03(\tR\vpermissions\"g\n" +
+	"\x17CheckPermissionResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x122\n" +
+	"\x06claims\x18\x02 \x01(\v2\x1a.ranco.auth.v1.TokenClaimsR\x06claims2\xfd\x02\n" +
+	"\vAuthService\x12Z\n" +
+	"\rValidateToken\x12#.ranco.auth.v1.ValidateTokenRequest\x1a$.ranco.auth.v1.ValidateTokenResponse\x12]\n" +
+	"\x0eValidateTokens\x12$.ranco.auth.v1.ValidateTokensRequest\x1a%.ranco.auth.v1.ValidateTokensResponse\x12Q\n" +
+	"\n" +
+	"GetAccount\x12 .ranco.auth.v1.GetAccountRequest\x1a!.ranco.auth.v1.GetAccountResponse\x12`\n" +
+	"\x0fCheckPermission\x12%.ranco.auth.v1.CheckPermissionRequest\x1a&.ranco.auth.v1.CheckPermissionResponseB<Z:github.com/TheJisus28/ranco-auth-service/pkg/authpb;authpbb\x06proto3"
+
+var (
+	file_ranco_auth_v1_auth_proto_rawDescOnce sync.Once
+	file_ranco_auth_v1_auth_proto_rawDescData []byte
+)
+
+func file_ranco_auth_v1_auth_proto_rawDescGZIP() []byte {
+	file_ranco_auth_v1_auth_proto_rawDescOnce.Do(func() {
+		file_ranco_auth_v1_auth_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ranco_auth_v1_auth_proto_rawDesc), len(file_ranco_auth_v1_auth_proto_rawDesc)))
+	})
+	return file_ranco_auth_v1_auth_proto_rawDescData
+}
+
+var file_ranco_auth_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_ranco_auth_v1_auth_proto_goTypes = []any{
+	(*ValidateTokenRequest)(nil),    // 0: ranco.auth.v1.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),   // 1: ranco.auth.v1.ValidateTokenResponse
+	(*TokenClaims)(nil),             // 2: ranco.auth.v1.TokenClaims
+	(*ValidateTokensRequest)(nil),   // 3: ranco.auth.v1.ValidateTokensRequest
+	(*ValidateTokensResponse)(nil),  // 4: ranco.auth.v1.ValidateTokensResponse
+	(*GetAccountRequest)(nil),       // 5: ranco.auth.v1.GetAccountRequest
+	(*GetAccountResponse)(nil),      // 6: ranco.auth.v1.GetAccountResponse
+	(*Account)(nil),                 // 7: ranco.auth.v1.Account
+	(*CheckPermissionRequest)(nil),  // 8: ranco.auth.v1.CheckPermissionRequest
+	(*CheckPermissionResponse)(nil), // 9: ranco.auth.v1.CheckPermissionResponse
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
+}
+var file_ranco_auth_v1_auth_proto_depIdxs = []int32{
+	2,  // 0: ranco.auth.v1.ValidateTokenResponse.claims:type_name -> ranco.auth.v1.TokenClaims
+	10, // 1: ranco.auth.v1.TokenClaims.issued_at:type_name -> google.protobuf.Timestamp
+	10, // 2: ranco.auth.v1.TokenClaims.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: ranco.auth.v1.ValidateTokensResponse.results:type_name -> ranco.auth.v1.ValidateTokenResponse
+	7,  // 4: ranco.auth.v1.GetAccountResponse.account:type_name -> ranco.auth.v1.Account
+	10, // 5: ranco.auth.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 6: ranco.auth.v1.CheckPermissionResponse.claims:type_name -> ranco.auth.v1.TokenClaims
+	0,  // 7: ranco.auth.v1.AuthService.ValidateToken:input_type -> ranco.auth.v1.ValidateTokenRequest
+	3,  // 8: ranco.auth.v1.AuthService.ValidateTokens:input_type -> ranco.auth.v1.ValidateTokensRequest
+	5,  // 9: ranco.auth.v1.AuthService.GetAccount:input_type -> ranco.auth.v1.GetAccountRequest
+	8,  // 10: ranco.auth.v1.AuthService.CheckPermission:input_type -> ranco.auth.v1.CheckPermissionRequest
+	1,  // 11: ranco.auth.v1.AuthService.ValidateToken:output_type -> ranco.auth.v1.ValidateTokenResponse
+	4,  // 12: ranco.auth.v1.AuthService.ValidateTokens:output_type -> ranco.auth.v1.ValidateTokensResponse
+	6,  // 13: ranco.auth.v1.AuthService.GetAccount:output_type -> ranco.auth.v1.GetAccountResponse
+	9,  // 14: ranco.auth.v1.AuthService.CheckPermission:output_type -> ranco.auth.v1.CheckPermissionResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_ranco_auth_v1_auth_proto_init() }
+func file_ranco_auth_v1_auth_proto_init() {
+	if File_ranco_auth_v1_auth_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ranco_auth_v1_auth_proto_rawDesc), len(file_ranco_auth_v1_auth_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ranco_auth_v1_auth_proto_goTypes,
+		DependencyIndexes: file_ranco_auth_v1_auth_proto_depIdxs,
+		MessageInfos:      file_ranco_auth_v1_auth_proto_msgTypes,
+	}.Build()
+	File_ranco_auth_v1_auth_proto = out.File
+	file_ranco_auth_v1_auth_proto_goTypes = nil
+	file_ranco_auth_v1_auth_proto_depIdxs = nil
+}